@@ -42,9 +42,11 @@ type Configuration struct {
 	MetaDBSSLMode  string `json:"meta_db_ssl_mode"`
 
 	// Security configuration
-	EnableAuth      bool `json:"enable_auth"`
-	EnableRateLimit bool `json:"enable_rate_limit"`
-	RateLimitRPS    int  `json:"rate_limit_rps"`
+	EnableAuth      bool   `json:"enable_auth"`
+	ServerKeysFile  string `json:"server_keys_file"`
+	EnableRateLimit bool   `json:"enable_rate_limit"`
+	RateLimitRPS    int    `json:"rate_limit_rps"`
+	RateLimitBurst  int    `json:"rate_limit_burst"`
 
 	// Logging configuration
 	LogLevel string `json:"log_level"`
@@ -131,12 +133,17 @@ func showHelp() {
 	fmt.Println("        Show this help message")
 	fmt.Println("")
 	fmt.Println("Environment Variables:")
-	fmt.Println("  METADB_HOST     - Metadata database host")
-	fmt.Println("  METADB_PORT     - Metadata database port")
-	fmt.Println("  METADB_USER     - Metadata database user")
-	fmt.Println("  METADB_PASSWORD - Metadata database password")
-	fmt.Println("  METADB_NAME     - Metadata database name")
-	fmt.Println("  METADB_SSL_MODE - Metadata database SSL mode")
+	fmt.Println("  METADB_HOST       - Metadata database host")
+	fmt.Println("  METADB_PORT       - Metadata database port")
+	fmt.Println("  METADB_USER       - Metadata database user")
+	fmt.Println("  METADB_PASSWORD   - Metadata database password")
+	fmt.Println("  METADB_NAME       - Metadata database name")
+	fmt.Println("  METADB_SSL_MODE   - Metadata database SSL mode")
+	fmt.Println("  ENABLE_AUTH       - Require API keys on all calls but Ping (default true)")
+	fmt.Println("  SERVER_KEYS_FILE  - Server keys written by db-keygen (default ./keys/server/server-keys.json)")
+	fmt.Println("  ENABLE_RATE_LIMIT - Limit each client's request rate (default true)")
+	fmt.Println("  RATE_LIMIT_RPS    - Requests per second allowed per client (default 100)")
+	fmt.Println("  RATE_LIMIT_BURST  - Requests a client may make at once (default 20)")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  # Start server with default settings")
@@ -159,6 +166,7 @@ func loadConfiguration(configFile string, port int, host string, enableTLS bool,
 		EnableAuth:      true,
 		EnableRateLimit: true,
 		RateLimitRPS:    100,
+		RateLimitBurst:  20,
 		LogLevel:        "info",
 	}
 
@@ -169,6 +177,11 @@ func loadConfiguration(configFile string, port int, host string, enableTLS bool,
 	config.MetaDBPassword = getEnvOrDefault("METADB_PASSWORD", "password")
 	config.MetaDBName = getEnvOrDefault("METADB_NAME", "db_agent_metadata")
 	config.MetaDBSSLMode = getEnvOrDefault("METADB_SSL_MODE", "disable")
+	config.EnableAuth = getEnvBoolOrDefault("ENABLE_AUTH", config.EnableAuth)
+	config.ServerKeysFile = getEnvOrDefault("SERVER_KEYS_FILE", "./keys/server/server-keys.json")
+	config.EnableRateLimit = getEnvBoolOrDefault("ENABLE_RATE_LIMIT", config.EnableRateLimit)
+	config.RateLimitRPS = getEnvIntOrDefault("RATE_LIMIT_RPS", config.RateLimitRPS)
+	config.RateLimitBurst = getEnvIntOrDefault("RATE_LIMIT_BURST", config.RateLimitBurst)
 
 	return config
 }
@@ -228,6 +241,34 @@ func initializeGRPCServer(config *Configuration, agentService *dbagent.AgentServ
 		PermitWithoutStream: true,
 	}))
 
+	// Require a valid API key on every call except Ping, and the key's permission for each operation
+	if config.EnableAuth {
+		keys, err := dbagent.LoadServerKeys(config.ServerKeysFile)
+		if err != nil {
+			log.Fatalf("Authentication enabled but server keys could not be loaded: %v", err)
+		}
+		if keys.Len() == 0 {
+			log.Printf("⚠️  No usable keys in %s; only Ping will succeed. Regenerate keys with db-keygen -server -client", config.ServerKeysFile)
+		}
+
+		authInterceptor := dbagent.NewAuthInterceptor(keys, auditLogger)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(authInterceptor.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(authInterceptor.StreamServerInterceptor()),
+		)
+	} else {
+		log.Printf("⚠️  Authentication disabled; any client can compare, download, deploy and bootstrap")
+	}
+
+	// Limit each client's request rate. Chained after auth so authenticated calls are
+	// keyed by client ID rather than IP; the auth interceptor throttles failed
+	// authentications per IP before they reach here.
+	if config.EnableRateLimit && config.RateLimitRPS > 0 {
+		rateLimiter := dbagent.NewRateLimiter(config.RateLimitRPS, config.RateLimitBurst)
+		opts = append(opts, grpc.ChainUnaryInterceptor(rateLimiter.UnaryServerInterceptor()))
+		log.Printf("✅ Rate limiting enabled: %d requests/second per client, burst %d", config.RateLimitRPS, config.RateLimitBurst)
+	}
+
 	// Create gRPC server
 	server := grpc.NewServer(opts...)
 
@@ -237,7 +278,7 @@ func initializeGRPCServer(config *Configuration, agentService *dbagent.AgentServ
 	// Enable reflection for debugging
 	reflection.Register(server)
 
-	log.Printf("✅ gRPC server initialized with TLS: %t", config.EnableTLS)
+	log.Printf("✅ gRPC server initialized with TLS: %t, auth: %t", config.EnableTLS, config.EnableAuth)
 	return server
 }
 
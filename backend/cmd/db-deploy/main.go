@@ -2,6 +2,7 @@ package main
 
 import (
 	"database/sql"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"civicweave/backend/config"
 	"civicweave/backend/database"
@@ -23,7 +25,10 @@ func main() {
 		dryRun   = flag.Bool("dry-run", false, "Show what would be executed without running")
 		version  = flag.String("version", "", "Run migration up to specific version (e.g., 011)")
 		rollback = flag.String("rollback", "", "Rollback to specific version (e.g., 010)")
+		redo     = flag.Bool("redo", false, "Rollback and reapply the latest applied migration")
 		status   = flag.Bool("status", false, "Show migration status")
+		strict   = flag.Bool("strict-checksums", false, "Fail instead of warning when an applied migration has changed")
+		jsonOut  = flag.Bool("json", false, "Print status and migration results as JSON")
 		help     = flag.Bool("help", false, "Show help")
 	)
 	flag.Parse()
@@ -61,7 +66,7 @@ func main() {
 	}
 
 	if *status {
-		showMigrationStatus(db)
+		showMigrationStatus(db, *jsonOut)
 		return
 	}
 
@@ -72,8 +77,15 @@ func main() {
 		return
 	}
 
+	if *redo {
+		if err := redoLatestMigration(db, *dryRun); err != nil {
+			log.Fatal("Failed to redo migration:", err)
+		}
+		return
+	}
+
 	// Run migrations
-	if err := runMigrations(db, *version, *dryRun); err != nil {
+	if err := runMigrations(db, *version, *dryRun, *strict, *jsonOut); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 }
@@ -93,8 +105,14 @@ func showHelp() {
 	fmt.Println("        Run migration up to specific version (e.g., \"011\")")
 	fmt.Println("  -rollback string")
 	fmt.Println("        Rollback to specific version (e.g., \"010\")")
+	fmt.Println("  -redo")
+	fmt.Println("        Rollback and reapply the latest applied migration")
 	fmt.Println("  -status")
 	fmt.Println("        Show migration status")
+	fmt.Println("  -strict-checksums")
+	fmt.Println("        Fail instead of warning when an applied migration has changed")
+	fmt.Println("  -json")
+	fmt.Println("        Print status and migration results as JSON (for CI)")
 	fmt.Println("  -help")
 	fmt.Println("        Show this help message")
 	fmt.Println("")
@@ -102,6 +120,9 @@ func showHelp() {
 	fmt.Println("  # Show migration status")
 	fmt.Println("  go run cmd/db-deploy/main.go -status")
 	fmt.Println("")
+	fmt.Println("  # Migration status as JSON for CI")
+	fmt.Println("  go run cmd/db-deploy/main.go -status -json")
+	fmt.Println("")
 	fmt.Println("  # Run all pending migrations")
 	fmt.Println("  go run cmd/db-deploy/main.go")
 	fmt.Println("")
@@ -113,6 +134,9 @@ func showHelp() {
 	fmt.Println("")
 	fmt.Println("  # Rollback to version 010")
 	fmt.Println("  go run cmd/db-deploy/main.go -rollback 010")
+	fmt.Println("")
+	fmt.Println("  # Re-run the latest migration after editing it")
+	fmt.Println("  go run cmd/db-deploy/main.go -redo")
 }
 
 func createMigrationsTable(db *sql.DB) error {
@@ -127,10 +151,48 @@ func createMigrationsTable(db *sql.DB) error {
 	return err
 }
 
-func showMigrationStatus(db *sql.DB) {
-	fmt.Println("📊 Migration Status")
-	fmt.Println("==================")
+// statusOutput is the -status -json output
+type statusOutput struct {
+	Applied    int                            `json:"applied"`
+	Pending    int                            `json:"pending"`
+	Total      int                            `json:"total"`
+	OutOfOrder []database.OutOfOrderMigration `json:"out_of_order"`
+	Migrations []migrationStatusOutput        `json:"migrations"`
+}
+
+// migrationStatusOutput is one migration in the -status -json output
+type migrationStatusOutput struct {
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Checksum    string `json:"checksum,omitempty"`
+}
+
+// runOutput is the -json output of a migration run
+type runOutput struct {
+	DryRun          bool                 `json:"dry_run"`
+	Applied         []migrationRunOutput `json:"applied"`
+	TotalDurationMs int64                `json:"total_duration_ms"`
+}
+
+// migrationRunOutput is one migration applied during a run
+type migrationRunOutput struct {
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
 
+func showMigrationStatus(db *sql.DB, jsonOutput bool) {
 	// Get applied migrations
 	applied, err := getAppliedMigrations(db)
 	if err != nil {
@@ -143,6 +205,38 @@ func showMigrationStatus(db *sql.DB) {
 		log.Fatal("Failed to get available migrations:", err)
 	}
 
+	if jsonOutput {
+		output := statusOutput{
+			Applied:    len(applied),
+			Pending:    len(getPendingMigrations(applied, available)),
+			Total:      len(available),
+			OutOfOrder: findOutOfOrderMigrations(applied, available),
+			Migrations: make([]migrationStatusOutput, 0, len(available)),
+		}
+		if output.OutOfOrder == nil {
+			output.OutOfOrder = []database.OutOfOrderMigration{}
+		}
+		for _, migration := range available {
+			entry := migrationStatusOutput{
+				Version:     migration.Version,
+				Description: migration.Description,
+				Status:      "pending",
+			}
+			if checksum, exists := applied[migration.Version]; exists {
+				entry.Status = "applied"
+				entry.Checksum = checksum
+			}
+			output.Migrations = append(output.Migrations, entry)
+		}
+		if err := printJSON(output); err != nil {
+			log.Fatal("Failed to write migration status:", err)
+		}
+		return
+	}
+
+	fmt.Println("📊 Migration Status")
+	fmt.Println("==================")
+
 	fmt.Printf("Applied migrations: %d\n", len(applied))
 	fmt.Printf("Available migrations: %d\n", len(available))
 	fmt.Println("")
@@ -166,6 +260,14 @@ func showMigrationStatus(db *sql.DB) {
 	} else {
 		fmt.Println("\n✅ All migrations are up to date!")
 	}
+
+	// Warn about pending migrations that sort before applied ones
+	if outOfOrder := findOutOfOrderMigrations(applied, available); len(outOfOrder) > 0 {
+		fmt.Printf("\n⚠️  %s\n", database.OutOfOrderMessage(outOfOrder))
+		for _, migration := range outOfOrder {
+			fmt.Printf("  - %s is pending but %s is already applied\n", migration.Version, migration.LatestApplied)
+		}
+	}
 }
 
 type Migration struct {
@@ -210,7 +312,7 @@ func getAvailableMigrations() ([]Migration, error) {
 }
 
 func getAppliedMigrations(db *sql.DB) (map[string]string, error) {
-	query := "SELECT version, checksum FROM schema_migrations ORDER BY version"
+	query := "SELECT version, COALESCE(checksum, '') FROM schema_migrations ORDER BY version"
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -239,7 +341,19 @@ func getPendingMigrations(applied map[string]string, available []Migration) []Mi
 	return pending
 }
 
-func runMigrations(db *sql.DB, targetVersion string, dryRun bool) error {
+func findOutOfOrderMigrations(applied map[string]string, available []Migration) []database.OutOfOrderMigration {
+	ordered := make([]string, 0, len(available))
+	appliedVersions := make(map[string]bool, len(applied))
+	for _, migration := range available {
+		ordered = append(ordered, migration.Version)
+		if _, exists := applied[migration.Version]; exists {
+			appliedVersions[migration.Version] = true
+		}
+	}
+	return database.FindOutOfOrderMigrations(ordered, appliedVersions)
+}
+
+func runMigrations(db *sql.DB, targetVersion string, dryRun, strictChecksums, jsonOutput bool) error {
 	applied, err := getAppliedMigrations(db)
 	if err != nil {
 		return err
@@ -250,9 +364,18 @@ func runMigrations(db *sql.DB, targetVersion string, dryRun bool) error {
 		return err
 	}
 
+	// Check that applied migrations haven't changed since they were applied
+	if err := verifyAppliedChecksums(db, applied, available, strictChecksums); err != nil {
+		return err
+	}
+
 	pending := getPendingMigrations(applied, available)
+	output := runOutput{DryRun: dryRun, Applied: []migrationRunOutput{}}
 
 	if len(pending) == 0 {
+		if jsonOutput {
+			return printJSON(output)
+		}
 		fmt.Println("✅ All migrations are up to date!")
 		return nil
 	}
@@ -269,24 +392,80 @@ func runMigrations(db *sql.DB, targetVersion string, dryRun bool) error {
 	}
 
 	if len(pending) == 0 {
+		if jsonOutput {
+			return printJSON(output)
+		}
 		fmt.Printf("✅ No migrations to run up to version %s\n", targetVersion)
 		return nil
 	}
 
-	fmt.Printf("🚀 Running %d migrations...\n", len(pending))
+	if !jsonOutput {
+		fmt.Printf("🚀 Running %d migrations...\n", len(pending))
+	}
 
+	runStart := time.Now()
 	for _, migration := range pending {
-		if err := runMigration(db, migration, dryRun); err != nil {
+		start := time.Now()
+		if err := runMigration(db, migration, dryRun, jsonOutput); err != nil {
 			return fmt.Errorf("failed to run migration %s: %w", migration.Version, err)
 		}
+		output.Applied = append(output.Applied, migrationRunOutput{
+			Version:     migration.Version,
+			Description: migration.Description,
+			DurationMs:  time.Since(start).Milliseconds(),
+		})
+	}
+	output.TotalDurationMs = time.Since(runStart).Milliseconds()
+
+	if jsonOutput {
+		return printJSON(output)
 	}
 
 	fmt.Println("✅ All migrations completed successfully!")
 	return nil
 }
 
-func runMigration(db *sql.DB, migration Migration, dryRun bool) error {
-	fmt.Printf("📝 Running migration %s: %s\n", migration.Version, migration.Description)
+// verifyAppliedChecksums warns, or fails when strict, if any applied migration's file has
+// changed since it was applied. Migrations recorded without a SHA-256 checksum get theirs
+// filled in.
+func verifyAppliedChecksums(db *sql.DB, applied map[string]string, available []Migration, strict bool) error {
+	current := make(map[string]string, len(available))
+	for _, migration := range available {
+		if _, exists := applied[migration.Version]; !exists {
+			continue
+		}
+		content, err := os.ReadFile(migration.Path)
+		if err != nil {
+			return err
+		}
+		current[migration.Version] = database.MigrationChecksum(content)
+	}
+
+	if err := database.ChecksumMismatchError(database.FindChecksumMismatches(applied, current)); err != nil {
+		if strict {
+			return err
+		}
+		log.Printf("⚠️ %v", err)
+	}
+
+	for version, checksum := range current {
+		if database.IsMigrationChecksum(applied[version]) {
+			continue
+		}
+		if _, err := db.Exec("UPDATE schema_migrations SET checksum = $2 WHERE version = $1", version, checksum); err != nil {
+			return fmt.Errorf("failed to record checksum for migration %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// runMigration applies a migration's UP section. quiet suppresses progress output so
+// that -json output stays parseable.
+func runMigration(db *sql.DB, migration Migration, dryRun, quiet bool) error {
+	if !quiet {
+		fmt.Printf("📝 Running migration %s: %s\n", migration.Version, migration.Description)
+	}
 
 	// Read migration file
 	content, err := os.ReadFile(migration.Path)
@@ -294,20 +473,15 @@ func runMigration(db *sql.DB, migration Migration, dryRun bool) error {
 		return err
 	}
 
-	// Split into UP and DOWN sections
-	sections := strings.Split(string(content), "-- DOWN")
-	if len(sections) < 2 {
-		return fmt.Errorf("migration file must contain -- UP and -- DOWN sections")
-	}
-
-	upSection := strings.TrimSpace(sections[0])
-	// Remove "-- UP" header
-	if strings.HasPrefix(upSection, "-- UP") {
-		upSection = strings.TrimSpace(strings.TrimPrefix(upSection, "-- UP"))
+	upSection, _, err := splitMigration(content)
+	if err != nil {
+		return err
 	}
 
 	if dryRun {
-		fmt.Printf("🔍 [DRY RUN] Would execute:\n%s\n", upSection)
+		if !quiet {
+			fmt.Printf("🔍 [DRY RUN] Would execute:\n%s\n", upSection)
+		}
 		return nil
 	}
 
@@ -318,23 +492,7 @@ func runMigration(db *sql.DB, migration Migration, dryRun bool) error {
 	}
 	defer tx.Rollback()
 
-	// Split by semicolon and execute each statement
-	statements := strings.Split(upSection, ";")
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
-		}
-
-		if _, err := tx.Exec(stmt); err != nil {
-			return fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
-		}
-	}
-
-	// Record migration as applied
-	checksum := fmt.Sprintf("%x", len(content)) // Simple checksum
-	_, err = tx.Exec("INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", migration.Version, checksum)
-	if err != nil {
+	if err := applyUp(tx, migration, content, upSection); err != nil {
 		return err
 	}
 
@@ -342,7 +500,9 @@ func runMigration(db *sql.DB, migration Migration, dryRun bool) error {
 		return err
 	}
 
-	fmt.Printf("✅ Migration %s completed successfully\n", migration.Version)
+	if !quiet {
+		fmt.Printf("✅ Migration %s completed successfully\n", migration.Version)
+	}
 	return nil
 }
 
@@ -387,53 +547,143 @@ func rollbackMigration(db *sql.DB, targetVersion string, dryRun bool) error {
 	return nil
 }
 
-func rollbackSingleMigration(db *sql.DB, migration Migration, dryRun bool) error {
-	fmt.Printf("🔄 Rolling back migration %s: %s\n", migration.Version, migration.Description)
+// redoLatestMigration rolls back the most recently applied migration and applies it
+// again, so it can be re-run after editing. Only that one migration is touched, and
+// both steps run in one transaction so a failing UP leaves it applied as before.
+func redoLatestMigration(db *sql.DB, dryRun bool) error {
+	var latest string
+	err := db.QueryRow("SELECT version FROM schema_migrations ORDER BY applied_at DESC, version DESC LIMIT 1").Scan(&latest)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no applied migrations to redo")
+	}
+	if err != nil {
+		return err
+	}
 
-	// Read migration file
-	content, err := os.ReadFile(migration.Path)
+	available, err := getAvailableMigrations()
 	if err != nil {
 		return err
 	}
 
-	// Split into UP and DOWN sections
-	sections := strings.Split(string(content), "-- DOWN")
-	if len(sections) < 2 {
-		return fmt.Errorf("migration file must contain -- UP and -- DOWN sections")
+	var migration *Migration
+	for i := range available {
+		if available[i].Version == latest {
+			migration = &available[i]
+			break
+		}
+	}
+	if migration == nil {
+		return fmt.Errorf("latest applied migration %s has no migration file", latest)
 	}
 
-	downSection := strings.TrimSpace(sections[1])
+	fmt.Printf("🔁 Redoing migration %s: %s\n", migration.Version, migration.Description)
+
+	content, err := os.ReadFile(migration.Path)
+	if err != nil {
+		return err
+	}
+	upSection, downSection, err := splitMigration(content)
+	if err != nil {
+		return err
+	}
 
 	if dryRun {
 		fmt.Printf("🔍 [DRY RUN] Would execute rollback:\n%s\n", downSection)
+		fmt.Printf("🔍 [DRY RUN] Would execute:\n%s\n", upSection)
 		return nil
 	}
 
-	// Execute rollback
 	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// Split by semicolon and execute each statement
-	statements := strings.Split(downSection, ";")
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
+	if err := applyDown(tx, *migration, downSection); err != nil {
+		return fmt.Errorf("failed to rollback migration %s: %w", migration.Version, err)
+	}
+	if err := applyUp(tx, *migration, content, upSection); err != nil {
+		return fmt.Errorf("failed to reapply migration %s: %w", migration.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Redo completed successfully!")
+	return nil
+}
+
+// splitMigration returns the UP and DOWN sections of a migration file
+func splitMigration(content []byte) (up, down string, err error) {
+	sections := strings.Split(string(content), "-- DOWN")
+	if len(sections) < 2 {
+		return "", "", fmt.Errorf("migration file must contain -- UP and -- DOWN sections")
+	}
+
+	up = strings.TrimSpace(sections[0])
+	// Remove "-- UP" header
+	if strings.HasPrefix(up, "-- UP") {
+		up = strings.TrimSpace(strings.TrimPrefix(up, "-- UP"))
+	}
+	return up, strings.TrimSpace(sections[1]), nil
+}
+
+// applyUp runs a migration's UP section in tx and records it as applied
+func applyUp(tx *sql.Tx, migration Migration, content []byte, upSection string) error {
+	// Split into statements, keeping dollar-quoted function bodies intact
+	for _, stmt := range database.SplitStatements(upSection) {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to execute statement: %w\nStatement: %s", err, stmt)
 		}
+	}
 
+	// Record migration as applied
+	checksum := database.MigrationChecksum(content)
+	_, err := tx.Exec("INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", migration.Version, checksum)
+	return err
+}
+
+// applyDown runs a migration's DOWN section in tx and removes it from the applied list
+func applyDown(tx *sql.Tx, migration Migration, downSection string) error {
+	for _, stmt := range database.SplitStatements(downSection) {
 		if _, err := tx.Exec(stmt); err != nil {
 			return fmt.Errorf("failed to execute rollback statement: %w\nStatement: %s", err, stmt)
 		}
 	}
 
-	// Remove migration from applied list
-	_, err = tx.Exec("DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	_, err := tx.Exec("DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	return err
+}
+
+func rollbackSingleMigration(db *sql.DB, migration Migration, dryRun bool) error {
+	fmt.Printf("🔄 Rolling back migration %s: %s\n", migration.Version, migration.Description)
+
+	// Read migration file
+	content, err := os.ReadFile(migration.Path)
+	if err != nil {
+		return err
+	}
+
+	_, downSection, err := splitMigration(content)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Printf("🔍 [DRY RUN] Would execute rollback:\n%s\n", downSection)
+		return nil
+	}
+
+	// Execute rollback
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	if err := applyDown(tx, migration, downSection); err != nil {
+		return err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return err
@@ -32,15 +32,17 @@ type ServerKeyConfig struct {
 	Keys []ServerKey `json:"keys"`
 }
 
-// ServerKey represents a server-side key entry
+// ServerKey represents a server-side key entry. The agent authenticates a client by
+// hashing the private key it presents and comparing it to PrivateKeyHash.
 type ServerKey struct {
-	ID          string    `json:"id"`
-	PublicKey   string    `json:"public_key"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	Permissions []string  `json:"permissions"`
-	IsActive    bool      `json:"is_active"`
+	ID             string    `json:"id"`
+	PublicKey      string    `json:"public_key"`
+	PrivateKeyHash string    `json:"private_key_hash"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Permissions    []string  `json:"permissions"`
+	IsActive       bool      `json:"is_active"`
 }
 
 // ClientKeyConfig represents client-side key configuration
@@ -101,7 +103,9 @@ func main() {
 	// Determine output directory
 	outputDir := *output
 	if outputDir == "" {
-		if *serverMode {
+		if *serverMode && *clientMode {
+			outputDir = "./keys"
+		} else if *serverMode {
 			outputDir = "./keys/server"
 		} else if *clientMode {
 			outputDir = "./keys/client"
@@ -115,7 +119,13 @@ func main() {
 		log.Fatalf("Failed to create output directory: %v", err)
 	}
 
-	if *serverMode {
+	if *serverMode && *clientMode {
+		// Issue the same key to both sides so the client can authenticate to the agent
+		if *agentURL == "" {
+			log.Fatal("Agent URL is required for client mode")
+		}
+		err = saveServerAndClientConfig(apiKey, *agentURL, outputDir)
+	} else if *serverMode {
 		err = saveServerConfig(apiKey, outputDir)
 	} else if *clientMode {
 		if *agentURL == "" {
@@ -155,7 +165,7 @@ func showHelp() {
 	fmt.Println("  -server")
 	fmt.Println("        Generate server-side configuration")
 	fmt.Println("  -client")
-	fmt.Println("        Generate client-side configuration")
+	fmt.Println("        Generate client-side configuration (combine with -server to issue one key to both)")
 	fmt.Println("  -agent-url string")
 	fmt.Println("        Agent URL for client configuration")
 	fmt.Println("  -list")
@@ -172,6 +182,9 @@ func showHelp() {
 	fmt.Println("  # Generate a client key")
 	fmt.Println("  go run cmd/db-keygen/main.go -client -agent-url \"localhost:50051\" -description \"CI/CD Client\"")
 	fmt.Println("")
+	fmt.Println("  # Generate a key the agent will accept from a client")
+	fmt.Println("  go run cmd/db-keygen/main.go -server -client -agent-url \"localhost:50051\" -description \"CI/CD\"")
+	fmt.Println("")
 	fmt.Println("  # List existing keys")
 	fmt.Println("  go run cmd/db-keygen/main.go -list")
 	fmt.Println("")
@@ -348,14 +361,16 @@ func saveServerConfig(apiKey *APIKey, outputDir string) error {
 	}
 
 	// Add new key to config
+	privateKeyHash := sha256.Sum256([]byte(apiKey.PrivateKey))
 	serverKey := ServerKey{
-		ID:          apiKey.ID,
-		PublicKey:   apiKey.PublicKey,
-		Description: apiKey.Description,
-		CreatedAt:   apiKey.CreatedAt,
-		ExpiresAt:   apiKey.ExpiresAt,
-		Permissions: apiKey.Permissions,
-		IsActive:    true,
+		ID:             apiKey.ID,
+		PublicKey:      apiKey.PublicKey,
+		PrivateKeyHash: base64.StdEncoding.EncodeToString(privateKeyHash[:]),
+		Description:    apiKey.Description,
+		CreatedAt:      apiKey.CreatedAt,
+		ExpiresAt:      apiKey.ExpiresAt,
+		Permissions:    apiKey.Permissions,
+		IsActive:       true,
 	}
 	config.Keys = append(config.Keys, serverKey)
 
@@ -383,6 +398,23 @@ func saveServerConfig(apiKey *APIKey, outputDir string) error {
 	return nil
 }
 
+// saveServerAndClientConfig writes the server and client configuration for one key
+// into the server and client subdirectories of outputDir
+func saveServerAndClientConfig(apiKey *APIKey, agentURL, outputDir string) error {
+	serverDir := filepath.Join(outputDir, "server")
+	clientDir := filepath.Join(outputDir, "client")
+	for _, dir := range []string{serverDir, clientDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	if err := saveServerConfig(apiKey, serverDir); err != nil {
+		return err
+	}
+	return saveClientConfig(apiKey, agentURL, clientDir)
+}
+
 func saveClientConfig(apiKey *APIKey, agentURL, outputDir string) error {
 	config := &ClientKeyConfig{
 		AgentURL:   agentURL,
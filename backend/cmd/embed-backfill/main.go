@@ -0,0 +1,206 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/database"
+	"civicweave/backend/models"
+	"civicweave/backend/services"
+
+	"github.com/google/uuid"
+	"github.com/pgvector/pgvector-go"
+)
+
+const (
+	initialBackoff = 2 * time.Second
+	maxBackoff     = 2 * time.Minute
+)
+
+func main() {
+	var (
+		model      = flag.String("model", "", "Only re-embed claims produced by this model (use \""+models.LegacyEmbeddingModel+"\" for claims with no recorded model)")
+		batchSize  = flag.Int("batch-size", 50, "Number of claims sent to the embedding API per request")
+		delay      = flag.Duration("delay", time.Second, "Pause between embedding requests")
+		maxRetries = flag.Int("max-retries", 5, "Retries per batch before giving up")
+		dryRun     = flag.Bool("dry-run", false, "Report how many claims would be processed without calling the API")
+	)
+	flag.Parse()
+
+	log.Println("🚀 Starting CivicWeave Embedding Backfill...")
+
+	if *batchSize <= 0 {
+		log.Fatalf("❌ -batch-size must be positive")
+	}
+
+	// Load configuration
+	cfg := config.Load()
+	log.Printf("📋 Configuration loaded: DB=%s:%s", cfg.Database.Host, cfg.Database.Port)
+
+	embedder, err := services.NewEmbedder(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to configure embeddings: %v", err)
+	}
+	embeddingService := services.NewEmbeddingService(embedder)
+	targetModel := embeddingService.GetEmbeddingModel()
+	if *model != "" && *model == targetModel {
+		log.Fatalf("❌ -model %q is the current embedding model; nothing would change", *model)
+	}
+
+	// Connect to database
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	log.Println("✅ Database connected successfully")
+
+	skillClaimService := models.NewSkillClaimService(db)
+
+	// Without -model, claims left on another model are flagged first so the run
+	// picks up a model change; flags are cleared as claims are embedded
+	stale := 0
+	if *model == "" {
+		if *dryRun {
+			stale, err = skillClaimService.CountStaleClaims(targetModel)
+		} else {
+			var flagged int64
+			flagged, err = skillClaimService.FlagStaleClaims(targetModel)
+			if flagged > 0 {
+				log.Printf("🚩 Flagged %d active claims embedded with another model", flagged)
+			}
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	total, err := skillClaimService.CountClaimsToEmbed(*model)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	total += stale
+
+	if *model != "" {
+		log.Printf("📊 %d active claims embedded with %q will be re-embedded with %q", total, *model, targetModel)
+	} else {
+		log.Printf("📊 %d active claims flagged needs_embedding will be embedded with %q", total, targetModel)
+	}
+
+	if *dryRun {
+		log.Println("ℹ️  Dry run, no embeddings requested")
+		return
+	}
+	if total == 0 {
+		return
+	}
+
+	if !embeddingService.IsConfigured() {
+		log.Fatalf("❌ An embedding provider must be configured for embedding backfill")
+	}
+
+	// Stop between batches on SIGINT/SIGTERM; progress is kept in the database
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+
+	var (
+		afterID   = uuid.Nil
+		processed int
+		skipped   int
+		failed    int
+	)
+
+	for {
+		select {
+		case sig := <-stop:
+			log.Printf("🛑 Received signal %v, stopping; rerun to resume", sig)
+			log.Printf("📊 Embedded %d, skipped %d, failed %d of %d claims", processed, skipped, failed, total)
+			return
+		default:
+		}
+
+		claims, err := skillClaimService.GetClaimsToEmbed(*model, afterID, *batchSize)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(claims) == 0 {
+			break
+		}
+		afterID = claims[len(claims)-1].ID
+
+		// The embedding API drops blank inputs, which would misalign the results
+		batch := make([]models.PendingSkillClaim, 0, len(claims))
+		texts := make([]string, 0, len(claims))
+		for _, claim := range claims {
+			text := strings.TrimSpace(claim.ClaimText)
+			if text == "" {
+				log.Printf("⚠️  Skipping claim %s with empty text", claim.ID)
+				skipped++
+				continue
+			}
+			batch = append(batch, claim)
+			texts = append(texts, text)
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		embeddings, err := embedWithBackoff(embeddingService, texts, *maxRetries)
+		if err != nil {
+			log.Printf("❌ Giving up on batch ending at claim %s: %v", afterID, err)
+			failed += len(batch)
+			continue
+		}
+
+		for i, claim := range batch {
+			if err := skillClaimService.UpdateClaimEmbedding(claim.ID, embeddings[i], targetModel); err != nil {
+				log.Printf("❌ Failed to store embedding for claim %s: %v", claim.ID, err)
+				failed++
+				continue
+			}
+			processed++
+		}
+
+		log.Printf("🔄 Embedded %d/%d claims", processed, total)
+		time.Sleep(*delay)
+	}
+
+	log.Printf("🎉 Backfill finished: embedded %d, skipped %d, failed %d of %d claims", processed, skipped, failed, total)
+	if processed > 0 {
+		log.Println("ℹ️  Volunteer skill vectors are stale until they are re-aggregated")
+	}
+}
+
+// embedWithBackoff requests embeddings for texts, retrying with exponential backoff
+// so rate limits and transient API errors do not abort the run
+func embedWithBackoff(embeddingService *services.EmbeddingService, texts []string, maxRetries int) ([]pgvector.Vector, error) {
+	backoff := initialBackoff
+
+	for attempt := 0; ; attempt++ {
+		embeddings, err := embeddingService.GenerateBatchEmbeddings(texts)
+		if err == nil && len(embeddings) != len(texts) {
+			err = fmt.Errorf("embedding API returned %d vectors for %d texts", len(embeddings), len(texts))
+		}
+		if err == nil {
+			return embeddings, nil
+		}
+		if attempt >= maxRetries {
+			return nil, err
+		}
+
+		log.Printf("⚠️  Embedding request failed (attempt %d/%d), retrying in %s: %v", attempt+1, maxRetries+1, backoff, err)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,9 +11,17 @@ import (
 
 	"civicweave/backend/config"
 	"civicweave/backend/database"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/metrics"
 	"civicweave/backend/services"
 )
 
+// pendingEmbeddingBatchSize bounds how many queued skill claims are embedded per poll
+const pendingEmbeddingBatchSize = 50
+
+// recurringTaskBatchSize bounds how many recurring task instances are created per poll
+const recurringTaskBatchSize = 100
+
 func main() {
 	log.Println("🚀 Starting CivicWeave Matching Worker...")
 
@@ -28,8 +37,37 @@ func main() {
 	defer db.Close()
 	log.Println("✅ Database connected successfully")
 
+	// Serve metrics on a separate listener; the worker has no HTTP API otherwise
+	if cfg.Metrics.Enabled {
+		metrics.RegisterDBStats(db)
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			log.Printf("📈 Metrics available at %s/metrics", cfg.Metrics.WorkerAddr)
+			if err := http.ListenAndServe(cfg.Metrics.WorkerAddr, mux); err != nil {
+				log.Printf("❌ Metrics listener stopped: %v", err)
+			}
+		}()
+	}
+
 	// Create skill matching service
-	matchingService := services.NewSkillMatchingService(db)
+	matchingService := services.NewSkillMatchingService(db, cfg.Matching.CategoryCreditPercent)
+	recalculationService := models.NewMatchingRecalculationService(db)
+	taskService := models.NewTaskService(db)
+	idempotencyKeyService := models.NewIdempotencyKeyService(db)
+
+	// Skill claims submitted while the embedding API was unavailable are embedded here
+	skillClaimService := models.NewSkillClaimService(db)
+	embedder, err := services.NewEmbedder(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to configure embeddings: %v", err)
+	}
+	embeddingService := services.NewEmbeddingService(embedder)
+	pendingEmbeddingProcessor := services.NewPendingEmbeddingProcessor(
+		models.NewPendingEmbeddingService(db),
+		embeddingService,
+		services.NewVectorAggregationService(db, skillClaimService),
+	)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -39,19 +77,50 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Run initial calculation
-	log.Println("🔄 Running initial match calculation...")
-	if err := matchingService.BatchCalculateProjectMatches(); err != nil {
-		log.Printf("❌ Initial calculation failed: %v", err)
-	} else {
-		log.Println("✅ Initial calculation completed successfully")
+	// SIGHUP forces an immediate recalculation
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+
+	// runCalculation recalculates all matches and records the outcome of request.
+	// Without a request, any queued admin request is claimed first so that requests
+	// arriving before the run are satisfied by it.
+	runCalculation := func(reason string, request *models.MatchingRecalculationRequest) {
+		if request == nil {
+			claimed, err := recalculationService.ClaimPending()
+			if err != nil {
+				log.Printf("❌ Failed to claim recalculation request: %v", err)
+			}
+			request = claimed
+		}
+
+		log.Printf("🔄 Running %s match calculation...", reason)
+		start := time.Now()
+		runErr := matchingService.BatchCalculateProjectMatches()
+		metrics.ObserveMatchingRun(reason, time.Since(start), runErr)
+		if runErr != nil {
+			log.Printf("❌ %s calculation failed: %v", reason, runErr)
+		} else {
+			log.Printf("✅ %s calculation completed successfully", reason)
+		}
+
+		if request != nil {
+			if err := recalculationService.Finish(request.ID, runErr); err != nil {
+				log.Printf("❌ Failed to record outcome of recalculation request %s: %v", request.ID, err)
+			}
+		}
 	}
 
-	// Set up ticker for periodic calculations
-	ticker := time.NewTicker(15 * time.Minute)
+	// Run initial calculation
+	runCalculation("initial", nil)
+
+	// Set up tickers for periodic calculations and on-demand requests
+	ticker := time.NewTicker(cfg.Matching.Interval)
 	defer ticker.Stop()
+	triggerTicker := time.NewTicker(cfg.Matching.TriggerPollInterval)
+	defer triggerTicker.Stop()
 
-	log.Println("⏰ Starting periodic calculations (every 15 minutes)")
+	log.Printf("⏰ Starting periodic calculations (every %s, checking for requests every %s)",
+		cfg.Matching.Interval, cfg.Matching.TriggerPollInterval)
 
 	// Main loop
 	for {
@@ -60,12 +129,52 @@ func main() {
 			log.Println("🛑 Shutdown signal received, stopping worker...")
 			return
 		case <-ticker.C:
-			log.Println("🔄 Running periodic match calculation...")
-			if err := matchingService.BatchCalculateProjectMatches(); err != nil {
-				log.Printf("❌ Periodic calculation failed: %v", err)
-			} else {
-				log.Println("✅ Periodic calculation completed successfully")
+			runCalculation("periodic", nil)
+
+			// Drop expired Idempotency-Keys and reservations abandoned mid-request
+			if removed, err := idempotencyKeyService.CleanupExpired(); err != nil {
+				log.Printf("❌ Failed to clean up idempotency keys: %v", err)
+			} else if removed > 0 {
+				log.Printf("✅ Removed %d expired idempotency keys", removed)
+			}
+		case <-triggerTicker.C:
+			// Recalculate volunteers and projects whose skills changed since the last pass
+			if recalculated, err := matchingService.RecalculateDirty(); err != nil {
+				log.Printf("❌ Incremental calculation failed: %v", err)
+			} else if recalculated > 0 {
+				log.Printf("✅ Recalculated matches for %d changed volunteers and projects", recalculated)
+			}
+
+			// Create the next instance of recurring tasks whose current one was completed
+			if generated, err := taskService.GenerateRecurringTasks(time.Now(), recurringTaskBatchSize); err != nil {
+				log.Printf("❌ Failed to generate recurring tasks: %v", err)
+			} else if generated > 0 {
+				log.Printf("✅ Created %d recurring task instances", generated)
+			}
+
+			// Embed skill claims queued while the embedding API was unavailable
+			if embeddingService.IsConfigured() {
+				if embedded, err := pendingEmbeddingProcessor.ProcessDue(pendingEmbeddingBatchSize); err != nil {
+					log.Printf("❌ Failed to embed queued skill claims: %v", err)
+				} else if embedded > 0 {
+					log.Printf("✅ Embedded %d queued skill claims", embedded)
+				}
+			}
+
+			request, err := recalculationService.ClaimPending()
+			if err != nil {
+				log.Printf("❌ Failed to check for recalculation requests: %v", err)
+				continue
+			}
+			if request == nil {
+				continue
 			}
+			runCalculation("requested", request)
+			// A full run just happened; don't follow it with a periodic one
+			ticker.Reset(cfg.Matching.Interval)
+		case <-hupChan:
+			runCalculation("signalled", nil)
+			ticker.Reset(cfg.Matching.Interval)
 		case sig := <-sigChan:
 			log.Printf("🛑 Received signal %v, initiating graceful shutdown...", sig)
 			cancel()
@@ -116,8 +116,8 @@ func showMigrationStatus(db *sql.DB, quiet bool) error {
 
 	if quiet {
 		// JSON output for programmatic use
-		fmt.Printf(`{"current_db_version":"%s","applied_count":%d,"pending_count":%d,"overall_status":"%s"}`,
-			status.CurrentDBVersion, len(status.AppliedMigrations), len(status.PendingMigrations), status.OverallStatus)
+		fmt.Printf(`{"current_db_version":"%s","applied_count":%d,"pending_count":%d,"out_of_order_count":%d,"overall_status":"%s"}`,
+			status.CurrentDBVersion, len(status.AppliedMigrations), len(status.PendingMigrations), len(status.OutOfOrder), status.OverallStatus)
 		return nil
 	}
 
@@ -149,6 +149,10 @@ func showMigrationStatus(db *sql.DB, quiet bool) error {
 		}
 	}
 
+	if len(status.OutOfOrder) > 0 {
+		fmt.Printf("\n⚠️  %s\n", database.OutOfOrderMessage(status.OutOfOrder))
+	}
+
 	return nil
 }
 
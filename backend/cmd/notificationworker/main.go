@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/database"
+	"civicweave/backend/models"
+	"civicweave/backend/services"
+)
+
+func main() {
+	log.Println("🚀 Starting CivicWeave Notification Worker...")
+
+	// Load configuration
+	cfg := config.Load()
+	log.Printf("📋 Configuration loaded: DB=%s:%s", cfg.Database.Host, cfg.Database.Port)
+
+	if !cfg.Features.EmailEnabled || !cfg.Notifications.DMEmailFallbackEnabled {
+		log.Println("⚠️  Direct message email fallback is disabled, nothing to do")
+		return
+	}
+
+	// Connect to database
+	db, err := database.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	log.Println("✅ Database connected successfully")
+
+	// Create fallback service
+	window := time.Duration(cfg.Notifications.DMEmailFallbackMinutes) * time.Minute
+	fallbackService := services.NewMessageEmailFallbackService(
+		models.NewMessageService(db),
+		services.NewEmailService(&cfg.Mailgun),
+		window,
+		cfg.Notifications.FrontendURL,
+	)
+
+	// Create context for graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	runFallback := func() {
+		sent, err := fallbackService.ProcessUnreadDirectMessages()
+		if err != nil {
+			log.Printf("❌ Direct message email fallback failed: %v", err)
+			return
+		}
+		log.Printf("✅ Sent %d unread direct message emails", sent)
+	}
+
+	// Run initial pass
+	log.Printf("🔄 Emailing direct messages unread for more than %s...", window)
+	runFallback()
+
+	// Set up ticker for periodic passes
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	log.Println("⏰ Starting periodic notification passes (every 5 minutes)")
+
+	// Main loop
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Shutdown signal received, stopping worker...")
+			return
+		case <-ticker.C:
+			runFallback()
+		case sig := <-sigChan:
+			log.Printf("🛑 Received signal %v, initiating graceful shutdown...", sig)
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,580 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+
+	"civicweave/backend/config"
+	"civicweave/backend/handlers"
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/metrics"
+	"civicweave/backend/services"
+	"civicweave/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// serverDeps holds the connections, services and handlers the router is built from.
+// Any of them may be nil when it failed to initialize; its routes are then skipped.
+type serverDeps struct {
+	db                         *sql.DB
+	redisClient                *redis.Client
+	userService                *models.UserService
+	volunteerService           *models.VolunteerService
+	projectService             *models.ProjectService
+	apiTokenService            *models.APITokenService
+	maintenanceService         *models.MaintenanceService
+	broadcastService           *models.BroadcastService
+	resourceService            *models.ResourceService
+	geocodingService           *utils.GeocodingService
+	resourceStorage            services.ResourceStorage
+	authHandler                *handlers.AuthHandler
+	googleOAuthHandler         *handlers.GoogleOAuthHandler
+	volunteerHandler           *handlers.VolunteerHandler
+	volunteerRatingHandler     *handlers.VolunteerRatingHandler
+	projectHandler             *handlers.ProjectHandler
+	projectTemplateHandler     *handlers.ProjectTemplateHandler
+	projectSurveyHandler       *handlers.ProjectSurveyHandler
+	taskHandler                *handlers.TaskHandler
+	messageHandler             *handlers.MessageHandler
+	calendarHandler            *handlers.CalendarHandler
+	campaignHandler            *handlers.CampaignHandler
+	userDashboardHandler       *handlers.UserDashboardHandler
+	applicationHandler         *handlers.ApplicationHandler
+	skillHandler               *handlers.SkillHandler
+	skillMatchingHandler       *handlers.SkillMatchingHandler
+	matchingHandler            *handlers.MatchingHandler
+	skillClaimHandler          *handlers.SkillClaimHandler
+	adminProfileHandler        *handlers.AdminProfileHandler
+	adminUserManagementHandler *handlers.AdminUserManagementHandler
+	roleHandler                *handlers.RoleHandler
+}
+
+// newRouter builds the API router, registering the routes of every handler in deps
+// that initialized
+func newRouter(cfg *config.Config, deps serverDeps) *gin.Engine {
+	// Setup Gin router. Requests are logged by RequestLogger rather than Gin's default
+	// logger so access lines use the configured format and carry the request ID.
+	router := gin.New()
+	router.Use(gin.Recovery(), middleware.RequestID(), middleware.RequestLogger())
+	if cfg.Metrics.Enabled {
+		router.Use(metrics.Middleware())
+	}
+
+	// CORS middleware
+	router.Use(middleware.CORS(cfg.CORS.AllowedOrigins))
+
+	// Log handler status before registering routes
+	log.Println("📋 Handler Status:")
+	log.Printf("   authHandler: %v", deps.authHandler != nil)
+	log.Printf("   projectHandler: %v", deps.projectHandler != nil)
+	log.Printf("   volunteerHandler: %v", deps.volunteerHandler != nil)
+	log.Printf("   messageHandler: %v", deps.messageHandler != nil)
+	log.Printf("   adminUserManagementHandler: %v", deps.adminUserManagementHandler != nil)
+
+	// API routes
+	log.Println("🛣️  Registering API routes...")
+	api := router.Group("/api")
+	// Maintenance covers every API route; the few that must keep working are allowlisted in the middleware
+	api.Use(middleware.MaintenanceMode(deps.maintenanceService, cfg.JWT.Secret))
+	{
+		// Public routes
+		auth := api.Group("/auth")
+		{
+			if deps.authHandler != nil {
+				auth.POST("/register", middleware.RegistrationRateLimiter(), deps.authHandler.Register)
+				auth.POST("/login", middleware.LoginRateLimiter(), deps.authHandler.Login)
+				auth.POST("/verify-email", deps.authHandler.VerifyEmail)
+				auth.POST("/forgot-password", middleware.LoginRateLimiter(), deps.authHandler.ForgotPassword)
+				auth.POST("/reset-password", middleware.LoginRateLimiter(), deps.authHandler.ResetPassword)
+				auth.POST("/refresh", middleware.LoginRateLimiter(), deps.authHandler.Refresh)
+				auth.POST("/logout", deps.authHandler.Logout)
+				log.Println("✅ Auth routes registered")
+			} else {
+				log.Println("❌ CRITICAL: Auth routes NOT registered (authHandler is nil)")
+			}
+			if deps.googleOAuthHandler != nil {
+				auth.POST("/google", middleware.LoginRateLimiter(), deps.googleOAuthHandler.GoogleAuth)
+			}
+		}
+
+		// Campaign tracking and unsubscribe routes are public: they are hit from recipients' mail clients
+		if deps.campaignHandler != nil {
+			api.GET("/track/open/:recipient_token", deps.campaignHandler.TrackOpen)
+			api.GET("/track/click/:recipient_token", deps.campaignHandler.TrackClick)
+			api.GET("/campaigns/unsubscribe/:token", deps.campaignHandler.ConfirmUnsubscribe)
+			api.POST("/campaigns/unsubscribe/:token", deps.campaignHandler.Unsubscribe)
+		}
+
+		// Project calendar feeds are public: calendar apps authenticate with a signed feed token
+		if deps.calendarHandler != nil {
+			api.GET("/projects/:id/calendar.ics", deps.calendarHandler.GetProjectCalendar)
+		}
+
+		// Protected routes
+		protected := api.Group("")
+		protected.Use(middleware.AuthRequired(cfg.JWT.Secret, deps.apiTokenService, deps.userService))
+		{
+			// User routes
+			if deps.authHandler != nil {
+				protected.GET("/me", deps.authHandler.GetProfile)
+				protected.PUT("/me", deps.authHandler.UpdateProfile)
+				protected.GET("/me/notification-preferences", deps.authHandler.GetNotificationPreferences)
+				protected.PUT("/me/notification-preferences", deps.authHandler.UpdateNotificationPreferences)
+				protected.GET("/me/privacy-preferences", deps.authHandler.GetPrivacyPreferences)
+				protected.PUT("/me/privacy-preferences", deps.authHandler.UpdatePrivacyPreferences)
+				protected.DELETE("/auth/oauth/:provider", deps.authHandler.UnlinkOAuthProvider)
+			} else {
+				log.Println("⚠️  Profile routes NOT registered (authHandler is nil)")
+			}
+			if deps.googleOAuthHandler != nil {
+				protected.POST("/auth/google/link", deps.googleOAuthHandler.LinkGoogle)
+			}
+
+			// Calendar feed token routes
+			if deps.calendarHandler != nil {
+				protected.GET("/me/calendar-feed", deps.calendarHandler.GetCalendarFeedToken)
+				protected.POST("/me/calendar-feed/reset", deps.calendarHandler.ResetCalendarFeedToken)
+			}
+
+			// Personal access token routes
+			if deps.apiTokenService != nil {
+				apiTokenHandler := handlers.NewAPITokenHandler(deps.apiTokenService)
+				protected.POST("/me/tokens", apiTokenHandler.CreateAPIToken)
+				protected.GET("/me/tokens", apiTokenHandler.ListAPITokens)
+				protected.DELETE("/me/tokens/:id", apiTokenHandler.RevokeAPIToken)
+			}
+
+			// Geocoding routes, sharing one per-user limit
+			geocodingHandler := handlers.NewGeocodingHandler(deps.geocodingService)
+			geocodeRateLimiter := middleware.GeocodeRateLimiter()
+			protected.GET("/geocode", geocodeRateLimiter, geocodingHandler.Geocode)
+			protected.GET("/geocode/reverse", geocodeRateLimiter, geocodingHandler.ReverseGeocode)
+
+			// Volunteer routes
+			if deps.volunteerHandler != nil {
+				protected.GET("/volunteers", deps.volunteerHandler.ListVolunteers)
+				protected.POST("/volunteers", deps.volunteerHandler.CreateVolunteer)
+				protected.GET("/volunteers/:id", deps.volunteerHandler.GetVolunteer)
+				protected.PUT("/volunteers/:id", deps.volunteerHandler.UpdateVolunteer)
+				protected.GET("/admin/volunteers/export", middleware.RequireRole("admin"), deps.volunteerHandler.ExportVolunteers)
+			} else {
+				log.Println("⚠️  Volunteer routes NOT registered (volunteerHandler is nil)")
+			}
+
+			// Volunteer rating routes
+			if deps.volunteerRatingHandler != nil {
+				protected.POST("/volunteers/:id/ratings", deps.volunteerRatingHandler.CreateRating)
+				protected.GET("/volunteers/:id/scorecard", deps.volunteerRatingHandler.GetVolunteerScorecard)
+				protected.GET("/volunteers/:id/ratings", deps.volunteerRatingHandler.ListRatingsForVolunteer)
+				protected.GET("/volunteers/top-rated", deps.volunteerRatingHandler.GetTopRatedVolunteers)
+				protected.GET("/ratings/my-ratings", deps.volunteerRatingHandler.ListRatingsByRater)
+				protected.PUT("/ratings/:id", deps.volunteerRatingHandler.UpdateRating)
+				protected.DELETE("/ratings/:id", deps.volunteerRatingHandler.DeleteRating)
+				protected.POST("/ratings/:id/dispute", deps.volunteerRatingHandler.FileDispute)
+				protected.POST("/admin/ratings/recompute-scorecards", middleware.RequireRole("admin"), deps.volunteerRatingHandler.RecomputeScorecards)
+				protected.GET("/admin/rating-disputes", middleware.RequireRole("admin"), deps.volunteerRatingHandler.ListDisputes)
+				protected.POST("/admin/rating-disputes/:id/resolve", middleware.RequireRole("admin"), deps.volunteerRatingHandler.ResolveDispute)
+			} else {
+				log.Println("⚠️  Volunteer rating routes NOT registered (volunteerRatingHandler is nil)")
+			}
+
+			// Project routes (renamed from initiatives)
+			if deps.projectHandler != nil {
+				protected.GET("/projects", deps.projectHandler.ListProjects)
+				protected.POST("/projects", middleware.RequireRole("team_lead"), deps.projectHandler.CreateProject)
+				protected.GET("/projects/:id", deps.projectHandler.GetProject)
+				protected.GET("/projects/:id/details", deps.projectHandler.GetProjectWithDetails)
+				protected.PUT("/projects/:id", middleware.RequireRole("team_lead"), deps.projectHandler.UpdateProject)
+				protected.PUT("/projects/:id/status", deps.projectHandler.TransitionProjectStatus)
+				protected.GET("/projects/:id/status-history", deps.projectHandler.GetProjectStatusHistory)
+				protected.POST("/admin/projects/bulk-status", middleware.RequireRole("admin"), deps.projectHandler.BulkTransitionProjectStatus)
+				protected.DELETE("/projects/:id", middleware.RequireRole("admin"), deps.projectHandler.DeleteProject)
+				protected.POST("/projects/:id/restore", middleware.RequireRole("admin"), deps.projectHandler.RestoreProject)
+
+				// Project team management routes
+				protected.GET("/projects/:id/signups", deps.projectHandler.GetProjectSignups)
+				protected.GET("/projects/:id/team-members", deps.projectHandler.GetProjectTeamMembers)
+				protected.GET("/projects/:id/team-members-with-details", deps.projectHandler.GetProjectTeamMembersWithDetails)
+				protected.POST("/projects/:id/team-members", deps.projectHandler.AddTeamMember)
+				protected.PUT("/projects/:id/team-members/:volunteerId", deps.projectHandler.UpdateTeamMemberStatus)
+				protected.GET("/projects/:id/waitlist", middleware.RequireRole("team_lead"), deps.projectHandler.GetWaitlist)
+				protected.PUT("/projects/:id/team-lead", middleware.RequireRole("admin"), deps.projectHandler.AssignTeamLead)
+				protected.GET("/projects/:id/team-lead/reassign-preview", middleware.RequireRole("admin"), deps.projectHandler.PreviewTeamLeadReassignment)
+
+				// Project logistics routes
+				protected.POST("/projects/:id/geocode", middleware.RequireRole("team_lead"), deps.projectHandler.RetryGeocoding)
+				protected.GET("/projects/:id/logistics", deps.projectHandler.GetLogistics)
+				protected.PUT("/projects/:id/logistics", deps.projectHandler.UpdateLogistics)
+				protected.POST("/projects/:id/approve-volunteer", deps.projectHandler.ApproveVolunteer)
+				protected.DELETE("/projects/:id/volunteers/:volunteerId", deps.projectHandler.RemoveVolunteer)
+			} else {
+				log.Println("⚠️  Project routes NOT registered (projectHandler is nil)")
+			}
+
+			// Project template routes
+			if deps.projectTemplateHandler != nil {
+				protected.GET("/project-templates", deps.projectTemplateHandler.ListTemplates)
+				protected.GET("/project-templates/:id", deps.projectTemplateHandler.GetTemplate)
+				protected.POST("/projects/from-template/:templateId", middleware.RequireRole("team_lead"), deps.projectTemplateHandler.CreateProjectFromTemplate)
+				protected.POST("/admin/project-templates", middleware.RequireRole("admin"), deps.projectTemplateHandler.CreateTemplate)
+				protected.PUT("/admin/project-templates/:id", middleware.RequireRole("admin"), deps.projectTemplateHandler.UpdateTemplate)
+				protected.DELETE("/admin/project-templates/:id", middleware.RequireRole("admin"), deps.projectTemplateHandler.DeleteTemplate)
+			}
+
+			// Project survey routes
+			if deps.projectSurveyHandler != nil {
+				protected.GET("/projects/:id/surveys", deps.projectSurveyHandler.ListSurveys)
+				protected.POST("/projects/:id/surveys", deps.projectSurveyHandler.CreateSurvey)
+				protected.POST("/projects/:id/surveys/:sid/respond", deps.projectSurveyHandler.RespondToSurvey)
+				protected.GET("/projects/:id/surveys/:sid/results", deps.projectSurveyHandler.GetSurveyResults)
+			}
+
+			// Project task routes
+			if deps.taskHandler != nil {
+				protected.GET("/projects/:id/tasks", deps.taskHandler.ListTasks)
+				protected.GET("/projects/:id/tasks/unassigned", deps.taskHandler.ListUnassignedTasks)
+				protected.POST("/projects/:id/tasks", deps.taskHandler.CreateTask)
+				protected.GET("/tasks/:id", deps.taskHandler.GetTask)
+				protected.PUT("/tasks/:id", deps.taskHandler.UpdateTask)
+				protected.DELETE("/tasks/:id", deps.taskHandler.DeleteTask)
+				protected.GET("/projects/:id/task-recurrences", deps.taskHandler.ListTaskRecurrences)
+				protected.PUT("/task-recurrences/:id", deps.taskHandler.UpdateTaskRecurrence)
+				protected.DELETE("/task-recurrences/:id", deps.taskHandler.StopTaskRecurrence)
+				protected.POST("/tasks/:id/assign", deps.taskHandler.SelfAssignTask)
+				protected.PUT("/tasks/:id/assign", deps.taskHandler.AssignTask)
+				protected.GET("/volunteers/me/tasks", deps.taskHandler.GetMyTasks)
+				protected.POST("/tasks/:id/updates", deps.taskHandler.AddTaskUpdate)
+
+				// Task comments
+				protected.POST("/tasks/:id/comments", deps.taskHandler.AddTaskComment)
+				protected.GET("/tasks/:id/comments", deps.taskHandler.GetTaskComments)
+
+				// Task time logging
+				protected.POST("/tasks/:id/time-logs", deps.taskHandler.LogTaskTime)
+				protected.GET("/tasks/:id/time-logs", deps.taskHandler.GetTaskTimeLogs)
+				protected.GET("/projects/:id/time-summary", deps.taskHandler.GetProjectTimeSummary)
+				protected.GET("/volunteers/hours-leaderboard", deps.taskHandler.GetHoursLeaderboard)
+				protected.GET("/volunteers/me/leaderboard-visibility", deps.taskHandler.GetLeaderboardVisibility)
+				protected.PUT("/volunteers/me/leaderboard-visibility", deps.taskHandler.UpdateLeaderboardVisibility)
+
+				// Task dependencies
+				protected.POST("/tasks/:id/dependencies", deps.taskHandler.AddTaskDependency)
+				protected.GET("/tasks/:id/dependencies", deps.taskHandler.GetTaskDependencies)
+				protected.DELETE("/tasks/:id/dependencies/:dependsOnId", deps.taskHandler.RemoveTaskDependency)
+
+				// Task resources
+				protected.POST("/tasks/:id/resources", deps.taskHandler.LinkTaskResource)
+				protected.GET("/tasks/:id/resources", deps.taskHandler.GetTaskResources)
+				protected.DELETE("/tasks/:id/resources/:resourceId", deps.taskHandler.UnlinkTaskResource)
+
+				// Task status transitions
+				protected.POST("/tasks/:id/start", deps.taskHandler.StartTask)
+				protected.POST("/tasks/:id/mark-blocked", deps.taskHandler.MarkTaskBlocked)
+				protected.POST("/tasks/:id/request-takeover", deps.taskHandler.RequestTaskTakeover)
+				protected.POST("/tasks/:id/mark-done", deps.taskHandler.MarkTaskDone)
+				protected.POST("/tasks/:id/reopen", deps.taskHandler.ReopenTask)
+			}
+
+			// Project message routes
+			if deps.messageHandler != nil {
+				protected.GET("/projects/:id/messages", deps.messageHandler.ListMessages)
+				protected.GET("/projects/:id/messages/recent", deps.messageHandler.GetRecentMessages)
+				protected.GET("/projects/:id/messages/new", deps.messageHandler.GetNewMessages)
+				protected.GET("/projects/:id/messages/stream", deps.messageHandler.StreamMessages)
+				protected.POST("/projects/:id/messages", deps.messageHandler.SendMessage)
+				protected.POST("/projects/:id/messages/typing", deps.messageHandler.SendTypingIndicator)
+				protected.POST("/projects/:id/messages/read-all", deps.messageHandler.MarkAllAsRead)
+				protected.GET("/projects/:id/messages/unread-count", deps.messageHandler.GetUnreadCount)
+				protected.PUT("/messages/:id", deps.messageHandler.EditMessage)
+				protected.DELETE("/messages/:id", deps.messageHandler.DeleteMessage)
+				protected.POST("/messages/:id/read", deps.messageHandler.MarkMessageAsRead)
+				protected.GET("/messages/:id/receipts", deps.messageHandler.GetMessageReceipts)
+				protected.GET("/messages/:id/reactions", deps.messageHandler.GetReactions)
+				protected.POST("/messages/:id/reactions", deps.messageHandler.AddReaction)
+				protected.DELETE("/messages/:id/reactions/:emoji", deps.messageHandler.RemoveReaction)
+				protected.GET("/messages/unread-counts", deps.messageHandler.GetAllUnreadCounts)
+			}
+
+			// Universal messaging routes
+			if deps.messageHandler != nil {
+				protected.POST("/messages", deps.messageHandler.SendUniversalMessage)
+				protected.GET("/messages/inbox", deps.messageHandler.GetInbox)
+				protected.GET("/messages/sent", deps.messageHandler.GetSentMessages)
+				protected.GET("/messages/scheduled", deps.messageHandler.GetScheduledMessages)
+				protected.GET("/messages/conversations", deps.messageHandler.GetConversations)
+				protected.GET("/messages/conversations/:id", deps.messageHandler.GetConversation)
+				protected.GET("/messages/unread-count", deps.messageHandler.GetUniversalUnreadCount)
+				protected.GET("/messages/recipients/search", deps.messageHandler.SearchRecipients)
+				protected.GET("/messages/search", deps.messageHandler.SearchMessages)
+			}
+
+			// Broadcast routes
+			if deps.broadcastService != nil {
+				broadcastHandler := handlers.NewBroadcastHandler(deps.broadcastService)
+				protected.GET("/broadcasts", broadcastHandler.ListBroadcasts)
+				protected.GET("/broadcasts/:id", broadcastHandler.GetBroadcast)
+				protected.POST("/broadcasts", middleware.RequirePermission(models.PermissionSendBroadcasts), broadcastHandler.CreateBroadcast)
+				protected.PUT("/broadcasts/:id", broadcastHandler.UpdateBroadcast)
+				protected.DELETE("/broadcasts/:id", broadcastHandler.DeleteBroadcast)
+				protected.POST("/broadcasts/:id/read", broadcastHandler.MarkBroadcastAsRead)
+				protected.POST("/broadcasts/:id/acknowledge", broadcastHandler.AcknowledgeBroadcast)
+				protected.GET("/broadcasts/:id/acknowledgments", broadcastHandler.GetBroadcastAcknowledgments)
+				protected.GET("/broadcasts/stats", broadcastHandler.GetBroadcastStats)
+			}
+
+			// Volunteer credential routes
+			if deps.volunteerService != nil && deps.projectService != nil {
+				credentialHandler := handlers.NewCredentialHandler(models.NewCredentialService(deps.db), deps.volunteerService, deps.projectService, services.NewLocalFileStorage("uploads"))
+				protected.GET("/volunteers/me/credentials", credentialHandler.ListMyCredentials)
+				protected.POST("/volunteers/me/credentials", credentialHandler.UploadCredential)
+				protected.GET("/volunteers/me/credentials/:id", credentialHandler.GetMyCredential)
+				protected.PUT("/volunteers/me/credentials/:id", credentialHandler.UpdateMyCredential)
+				protected.DELETE("/volunteers/me/credentials/:id", credentialHandler.DeleteMyCredential)
+				protected.GET("/volunteers/me/credentials/:id/document", credentialHandler.DownloadMyCredentialDocument)
+				protected.GET("/projects/:id/required-credentials", credentialHandler.GetRequiredCredentials)
+				protected.PUT("/projects/:id/required-credentials", middleware.RequireRole("team_lead"), credentialHandler.SetRequiredCredentials)
+				protected.GET("/admin/credentials", middleware.RequireRole("admin"), credentialHandler.ListCredentialsForReview)
+				protected.POST("/admin/credentials/:id/verify", middleware.RequireRole("admin"), credentialHandler.VerifyCredential)
+				protected.GET("/admin/credentials/:id/document", middleware.RequireRole("admin"), credentialHandler.DownloadCredentialDocument)
+			}
+
+			// Volunteer availability routes
+			if deps.volunteerService != nil {
+				availabilityHandler := handlers.NewAvailabilityHandler(models.NewVolunteerAvailabilityService(deps.db), deps.volunteerService)
+				protected.GET("/volunteers/me/availability", availabilityHandler.GetMyAvailability)
+				protected.POST("/volunteers/me/availability", availabilityHandler.AddAvailability)
+				protected.PUT("/volunteers/me/availability", availabilityHandler.ReplaceMyAvailability)
+				protected.DELETE("/volunteers/me/availability/:id", availabilityHandler.DeleteAvailability)
+			}
+
+			// Resource library routes
+			if deps.resourceService != nil {
+				resourceHandler := handlers.NewResourceHandler(deps.resourceService, deps.resourceStorage, cfg)
+				protected.GET("/resources", resourceHandler.ListResources)
+				protected.GET("/resources/:id", resourceHandler.GetResource)
+				protected.POST("/resources", middleware.RequireRole("team_lead"), resourceHandler.CreateResource)
+				protected.PUT("/resources/:id", resourceHandler.UpdateResource)
+				protected.DELETE("/resources/:id", resourceHandler.DeleteResource)
+				protected.GET("/resources/:id/download", resourceHandler.DownloadResource)
+				protected.GET("/resources/:id/versions", resourceHandler.ListResourceVersions)
+				protected.GET("/resources/:id/versions/:version/download", resourceHandler.DownloadResourceVersion)
+				protected.GET("/resources/stats", middleware.RequireRole("admin"), resourceHandler.GetResourceStats)
+				protected.GET("/resources/recent", resourceHandler.GetRecentResources)
+			}
+
+			// User dashboard routes
+			if deps.userDashboardHandler != nil {
+				protected.GET("/users/me/projects", deps.userDashboardHandler.GetUserProjects)
+				protected.GET("/users/me/tasks", deps.userDashboardHandler.GetUserTasks)
+				protected.GET("/users/me/dashboard", deps.userDashboardHandler.GetDashboardData)
+			}
+
+			// Application routes
+			if deps.applicationHandler != nil {
+				protected.GET("/applications", deps.applicationHandler.ListApplications)
+				protected.POST("/applications", deps.applicationHandler.CreateApplication)
+				protected.GET("/applications/:id", deps.applicationHandler.GetApplication)
+				protected.PUT("/applications/:id", deps.applicationHandler.UpdateApplication)
+				protected.POST("/applications/:id/withdraw", deps.applicationHandler.WithdrawApplication)
+				protected.DELETE("/applications/:id", deps.applicationHandler.DeleteApplication)
+			} else {
+				log.Println("⚠️  Application routes NOT registered (applicationHandler is nil)")
+			}
+
+			// New matching routes (sparse vector system)
+			if deps.skillMatchingHandler != nil {
+				protected.GET("/matching/my-matches", deps.skillMatchingHandler.GetMyMatches)
+				protected.GET("/projects/:id/candidate-volunteers", deps.skillMatchingHandler.GetCandidateVolunteers)
+				protected.GET("/projects/:id/skill-gap", middleware.RequireRole("team_lead"), deps.skillMatchingHandler.GetTeamSkillGap)
+				protected.GET("/volunteers/me/recommended-projects", deps.skillMatchingHandler.GetRecommendedInitiatives)
+				protected.GET("/matching/explanation/:volunteerId/:projectId", deps.skillMatchingHandler.GetMatchExplanation)
+
+				// On-demand recalculation, picked up by the matching worker (admin only)
+				matchingRecalculationHandler := handlers.NewMatchingRecalculationHandler(models.NewMatchingRecalculationService(deps.db))
+				protected.POST("/admin/matching/recalculate", middleware.RequireRole("admin"), matchingRecalculationHandler.RequestRecalculation)
+				protected.GET("/admin/matching/recalculate", middleware.RequireRole("admin"), matchingRecalculationHandler.GetRecalculationStatus)
+			}
+
+			// Legacy matching routes (updated to use projects)
+			if deps.matchingHandler != nil {
+				protected.GET("/matching/legacy/my-matches", deps.matchingHandler.GetMyMatches)
+				protected.GET("/matching/legacy/volunteer/:id", deps.matchingHandler.GetMatchesForVolunteer)
+				protected.GET("/matching/legacy/project/:id", deps.matchingHandler.GetMatchesForProject)
+				protected.GET("/matching/legacy/explanation/:volunteerId/:projectId", deps.matchingHandler.GetMatchExplanation)
+			}
+
+			// Skill taxonomy routes (new sparse vector system)
+			if deps.skillHandler != nil {
+				// Public skill taxonomy
+				api.GET("/skills/taxonomy", deps.skillHandler.GetTaxonomy)
+				api.POST("/skills/taxonomy", deps.skillHandler.AddSkill)
+
+				// Volunteer skill management
+				protected.GET("/volunteers/me/skills", deps.skillHandler.GetVolunteerSkills)
+				protected.PUT("/volunteers/me/skills", deps.skillHandler.UpdateVolunteerSkills)
+				protected.POST("/volunteers/me/skills", deps.skillHandler.AddVolunteerSkills)
+				protected.DELETE("/volunteers/me/skills/:skill_id", deps.skillHandler.RemoveVolunteerSkill)
+				protected.PUT("/volunteers/me/skills/:skill_id/visibility", deps.skillHandler.UpdateVolunteerSkillVisibility)
+				protected.POST("/volunteers/me/skills/extract", middleware.SkillExtractRateLimiter(), deps.skillHandler.ExtractSkills)
+				protected.GET("/volunteers/me/profile-completion", deps.skillHandler.GetProfileCompletion)
+
+				// Project skill management (legacy initiative endpoints for backward compatibility)
+				protected.GET("/initiatives/:id/skills", deps.skillHandler.GetInitiativeSkills)
+				protected.PUT("/initiatives/:id/skills", deps.skillHandler.UpdateInitiativeSkills)
+
+				// Project skill management
+				protected.GET("/projects/:id/skills", deps.skillHandler.GetProjectSkills)
+				protected.PUT("/projects/:id/skills", deps.skillHandler.UpdateProjectSkills)
+
+				// Taxonomy cleanup (admin only)
+				protected.POST("/admin/skills/:id/merge", middleware.RequireRole("admin"), deps.skillHandler.MergeSkill)
+				protected.POST("/admin/skills/:id/aliases", middleware.RequireRole("admin"), deps.skillHandler.AddSkillAlias)
+				protected.PUT("/admin/skills/:id/parent", middleware.RequireRole("admin"), deps.skillHandler.SetSkillParent)
+				protected.GET("/admin/skills/aliases", middleware.RequireRole("admin"), deps.skillHandler.ListSkillAliases)
+				protected.DELETE("/admin/skills/aliases/:alias", middleware.RequireRole("admin"), deps.skillHandler.RemoveSkillAlias)
+
+				// Skill demand and supply over time
+				protected.GET("/admin/analytics/skill-trends", middleware.RequireRole("admin"), deps.skillHandler.GetSkillTrends)
+			}
+
+			// Skill claim routes (legacy - keeping for backward compatibility)
+			if deps.skillClaimHandler != nil {
+				// Volunteer skill management
+				protected.GET("/volunteers/me/skill-claims", deps.skillClaimHandler.GetMySkillClaims)
+				protected.POST("/volunteers/me/skill-claims", deps.skillClaimHandler.CreateSkillClaim)
+				protected.DELETE("/volunteers/me/skill-claims/:id", deps.skillClaimHandler.DeleteSkillClaim)
+				protected.GET("/volunteers/me/skills-visibility", deps.skillClaimHandler.GetSkillsVisibility)
+				protected.POST("/volunteers/me/skills/reaggregate", middleware.SkillReaggregateRateLimiter(), deps.skillClaimHandler.ReaggregateMySkillVector)
+				protected.PUT("/volunteers/me/skills-visibility", deps.skillClaimHandler.UpdateSkillsVisibility)
+				protected.GET("/volunteers/me/matches", deps.skillClaimHandler.GetTopMatches)
+				protected.GET("/volunteers/me/matches/:project_id/explanation", deps.skillClaimHandler.GetMatchExplanation) // TODO: Update handler to use projects
+
+				// Admin skill management
+				protected.GET("/admin/skill-claims", middleware.RequireRole("admin"), deps.skillClaimHandler.ListAllSkillClaims)
+				protected.PATCH("/admin/skill-claims/:id/weight", middleware.RequireRole("admin"), deps.skillClaimHandler.UpdateSkillWeight)
+			}
+		}
+
+		// Campaign routes
+		if deps.campaignHandler != nil {
+			protected.GET("/campaigns", deps.campaignHandler.ListCampaigns)
+			protected.POST("/campaigns", deps.campaignHandler.CreateCampaign)
+			protected.GET("/campaigns/:id", deps.campaignHandler.GetCampaignByID)
+			protected.PUT("/campaigns/:id", deps.campaignHandler.UpdateCampaign)
+			protected.DELETE("/campaigns/:id", deps.campaignHandler.DeleteCampaign)
+			protected.GET("/campaigns/:id/stats", deps.campaignHandler.GetCampaignStats)
+			protected.GET("/campaigns/:id/recipients", deps.campaignHandler.GetCampaignRecipients)
+			protected.GET("/campaigns/:id/preview", deps.campaignHandler.PreviewCampaign)
+			protected.POST("/campaigns/:id/send", middleware.RequirePermission(models.PermissionSendCampaigns), deps.campaignHandler.SendCampaign)
+			protected.GET("/admin/campaign-unsubscribes", middleware.RequireRole("admin"), deps.campaignHandler.ListUnsubscribes)
+			protected.DELETE("/admin/campaign-unsubscribes/:id", middleware.RequireRole("admin"), deps.campaignHandler.Resubscribe)
+		}
+
+		// Admin profile routes
+		if deps.adminProfileHandler != nil {
+			protected.GET("/admin/profile", middleware.RequireRole("admin"), deps.adminProfileHandler.GetAdminProfile)
+			protected.PUT("/admin/profile", middleware.RequireRole("admin"), deps.adminProfileHandler.UpdateAdminProfile)
+			protected.GET("/admin/stats", middleware.RequireRole("admin"), deps.adminProfileHandler.GetSystemStats)
+			protected.PUT("/admin/change-password", middleware.RequireRole("admin"), deps.adminProfileHandler.ChangePassword)
+		}
+
+		// Maintenance mode routes (admin only)
+		if deps.maintenanceService != nil {
+			maintenanceHandler := handlers.NewMaintenanceHandler(deps.maintenanceService)
+			protected.GET("/admin/maintenance", middleware.RequireRole("admin"), maintenanceHandler.GetMaintenance)
+			protected.PUT("/admin/maintenance", middleware.RequireRole("admin"), maintenanceHandler.UpdateMaintenance)
+		}
+
+		// Webhook routes (admin only)
+		if deps.db != nil {
+			webhookHandler := handlers.NewWebhookHandler(models.NewWebhookService(deps.db))
+			protected.GET("/admin/webhooks", middleware.RequireRole("admin"), webhookHandler.ListWebhooks)
+			protected.POST("/admin/webhooks", middleware.RequireRole("admin"), webhookHandler.CreateWebhook)
+			protected.GET("/admin/webhooks/:id", middleware.RequireRole("admin"), webhookHandler.GetWebhook)
+			protected.PUT("/admin/webhooks/:id", middleware.RequireRole("admin"), webhookHandler.UpdateWebhook)
+			protected.DELETE("/admin/webhooks/:id", middleware.RequireRole("admin"), webhookHandler.DeleteWebhook)
+			protected.POST("/admin/webhooks/:id/rotate-secret", middleware.RequireRole("admin"), webhookHandler.RotateWebhookSecret)
+			protected.GET("/admin/webhooks/:id/deliveries", middleware.RequireRole("admin"), webhookHandler.ListWebhookDeliveries)
+		}
+
+		// Admin user management routes (admin only) - must come before role management to avoid conflicts
+		if deps.adminUserManagementHandler != nil {
+			protected.GET("/admin/users/:id", middleware.RequireRole("admin"), deps.adminUserManagementHandler.GetUserDetails)
+			protected.DELETE("/admin/users/:id", middleware.RequireRole("admin"), deps.adminUserManagementHandler.DeleteUser)
+			protected.PUT("/admin/users/:id/verification", middleware.RequireRole("admin"), deps.adminUserManagementHandler.ForceVerificationStatus)
+			protected.PUT("/admin/users/:id/password", middleware.RequireRole("admin"), deps.adminUserManagementHandler.ChangeUserPassword)
+			protected.POST("/admin/users/:id/revoke-sessions", middleware.RequireRole("admin"), deps.adminUserManagementHandler.RevokeUserSessions)
+			protected.POST("/admin/users/:id/unlock", middleware.RequireRole("admin"), deps.adminUserManagementHandler.UnlockUser)
+			protected.GET("/admin/audit-log", middleware.RequireRole("admin"), deps.adminUserManagementHandler.GetAuditLog)
+			log.Println("✅ Admin user management routes registered")
+		} else {
+			log.Println("❌ Admin user management routes NOT registered (adminUserManagementHandler is nil)")
+		}
+
+		// Role management routes (admin only)
+		if deps.roleHandler != nil {
+			protected.GET("/admin/roles", middleware.RequireRole("admin"), deps.roleHandler.ListRoles)
+			protected.POST("/admin/roles", middleware.RequireRole("admin"), deps.roleHandler.CreateRole)
+			protected.GET("/admin/roles/:id", middleware.RequireRole("admin"), deps.roleHandler.GetRoleByID)
+			protected.PUT("/admin/roles/:id", middleware.RequireRole("admin"), deps.roleHandler.UpdateRole)
+			protected.DELETE("/admin/roles/:id", middleware.RequireRole("admin"), deps.roleHandler.DeleteRole)
+			protected.GET("/admin/roles/:id/users", middleware.RequireRole("admin"), deps.roleHandler.ListUsersWithRole)
+			protected.GET("/admin/roles/:id/inherits", middleware.RequireRole("admin"), deps.roleHandler.GetInheritedRoles)
+			protected.GET("/admin/permissions", middleware.RequireRole("admin"), deps.roleHandler.ListPermissions)
+			protected.PUT("/admin/roles/:id/permissions", middleware.RequireRole("admin"), deps.roleHandler.SetRolePermissions)
+			protected.PUT("/admin/roles/:id/inherits", middleware.RequireRole("admin"), deps.roleHandler.SetInheritedRoles)
+
+			// User role assignment routes
+			protected.GET("/admin/users", middleware.RequireRole("admin"), deps.roleHandler.ListAllUsers)
+			protected.GET("/admin/users/:id/roles", middleware.RequireRole("admin"), deps.roleHandler.GetUserRoles)
+			protected.POST("/admin/users/:id/roles", middleware.RequireRole("admin"), deps.roleHandler.AssignRoleToUser)
+			protected.DELETE("/admin/users/:id/roles/:roleId", middleware.RequireRole("admin"), deps.roleHandler.RevokeRoleFromUser)
+			protected.GET("/admin/users/:id/role-assignments", middleware.RequireRole("admin"), deps.roleHandler.GetUserRoleAssignments)
+		}
+	}
+
+	// Routes whose handler failed to initialize are left unregistered. Without a
+	// database that is most of the API, so report it as unavailable, not missing.
+	if deps.db == nil {
+		router.NoRoute(func(c *gin.Context) {
+			if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Service temporarily unavailable"})
+			}
+		})
+	}
+
+	// Admin setup routes (disabled by default for security)
+	// Uncomment only if you need to create another admin user manually
+	// if adminSetupHandler != nil {
+	// 	router.POST("/api/admin/setup", adminSetupHandler.CreateAdmin)
+	// }
+
+	// Health checks: /health reports readiness (database and Redis reachable),
+	// /health/live only that the process is up
+	healthHandler := handlers.NewHealthHandler(deps.db, deps.redisClient, GetVersionInfo())
+	router.GET("/health", healthHandler.Ready)
+	router.GET("/health/live", healthHandler.Live)
+
+	// Prometheus metrics, off unless METRICS_ENABLED=true
+	if cfg.Metrics.Enabled {
+		if deps.db != nil {
+			metrics.RegisterDBStats(deps.db)
+		}
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+		log.Println("📈 Metrics available at /metrics")
+	}
+
+	// Version endpoint
+	router.GET("/version", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"version": GetVersionInfo(),
+		})
+	})
+
+	return router
+}
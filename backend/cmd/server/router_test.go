@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"civicweave/backend/config"
+	"civicweave/backend/handlers"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(router *gin.Engine, method, path string) int {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+	return recorder.Code
+}
+
+func hasRoute(router *gin.Engine, method, path string) bool {
+	for _, route := range router.Routes() {
+		if route.Method == method && route.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewRouterSkipsRoutesOfNilHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, _ := fakesql.Open()
+	defer db.Close()
+	cfg := &config.Config{}
+
+	// The message handler initialized but the project handler didn't
+	router := newRouter(cfg, serverDeps{
+		db:             db,
+		messageHandler: handlers.NewMessageHandler(models.NewMessageService(db), nil, nil, nil, cfg),
+	})
+
+	if !hasRoute(router, http.MethodPut, "/api/messages/:id") {
+		t.Error("message routes were not registered")
+	}
+	if hasRoute(router, http.MethodGet, "/api/projects") {
+		t.Error("project routes were registered without a project handler")
+	}
+	if code := serve(router, http.MethodGet, "/api/projects"); code != http.StatusNotFound {
+		t.Errorf("GET /api/projects status = %d, want 404", code)
+	}
+	if code := serve(router, http.MethodGet, "/health/live"); code != http.StatusOK {
+		t.Errorf("GET /health/live status = %d, want 200", code)
+	}
+}
+
+func TestNewRouterWithoutDatabaseReportsAPIUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newRouter(&config.Config{}, serverDeps{})
+
+	if code := serve(router, http.MethodGet, "/api/projects"); code != http.StatusServiceUnavailable {
+		t.Errorf("GET /api/projects status = %d, want 503", code)
+	}
+	if code := serve(router, http.MethodGet, "/health/live"); code != http.StatusOK {
+		t.Errorf("GET /health/live status = %d, want 200", code)
+	}
+}
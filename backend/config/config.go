@@ -1,21 +1,74 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Database  DatabaseConfig
-	Redis     RedisConfig
-	JWT       JWTConfig
-	Mailgun   MailgunConfig
-	Google    GoogleConfig
-	Geocoding GeocodingConfig
-	OpenAI    OpenAIConfig
-	Features  FeatureFlags
-	CORS      CORSConfig
+	Database      DatabaseConfig
+	Redis         RedisConfig
+	JWT           JWTConfig
+	Auth          AuthConfig
+	Mailgun       MailgunConfig
+	Google        GoogleConfig
+	Geocoding     GeocodingConfig
+	OpenAI        OpenAIConfig
+	Embedding     EmbeddingConfig
+	Features      FeatureFlags
+	CORS          CORSConfig
+	Notifications NotificationConfig
+	Projects      ProjectConfig
+	Ratings       RatingConfig
+	Messaging     MessagingConfig
+	Campaigns     CampaignConfig
+	Matching      MatchingConfig
+	Logging       LoggingConfig
+	Metrics       MetricsConfig
+	Resources     ResourceConfig
+}
+
+// ResourceConfig holds resource library upload and storage settings
+type ResourceConfig struct {
+	// StorageBackend is "local" to keep uploads on disk or "s3" for an S3-compatible object store
+	StorageBackend string
+	// UploadDir is where the local backend keeps uploads
+	UploadDir string
+	// MaxUploadBytes caps the size of a single uploaded file
+	MaxUploadBytes int64
+	// AllowedMIMETypes lists the content types that may be uploaded
+	AllowedMIMETypes []string
+	S3               S3Config
+}
+
+// S3Config holds S3-compatible object store settings
+type S3Config struct {
+	Bucket string
+	Region string
+	// Endpoint overrides the AWS endpoint for S3-compatible stores such as MinIO
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// MetricsConfig holds Prometheus metrics settings
+type MetricsConfig struct {
+	// Enabled exposes /metrics on the API server and the matching worker's metrics listener
+	Enabled bool
+	// WorkerAddr is the address the matching worker serves /metrics on
+	WorkerAddr string
+}
+
+// LoggingConfig holds log output settings
+type LoggingConfig struct {
+	// Format is "text" for local development or "json" for log aggregation
+	Format string
+	// Level is the minimum level logged: debug, info, warn or error
+	Level string
 }
 
 // FeatureFlags holds feature toggle settings
@@ -31,6 +84,12 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	SSLMode  string
+
+	// Connection pool limits applied by database.Connect
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 // RedisConfig holds Redis connection settings
@@ -41,15 +100,54 @@ type RedisConfig struct {
 	DB       int
 }
 
+// Bounds on configured JWT lifetimes
+const (
+	MinJWTExpiry = 5 * time.Minute
+	MaxJWTExpiry = 30 * 24 * time.Hour
+)
+
 // JWTConfig holds JWT settings
 type JWTConfig struct {
 	Secret string
+	// Expiry is the token lifetime for users with no role-specific lifetime
+	Expiry time.Duration
+	// RoleExpiry maps role names to token lifetimes. A user holding several of
+	// these roles gets the shortest, so privileged accounts expire soonest.
+	RoleExpiry map[string]time.Duration
+	// RefreshExpiry is how long a refresh token can be exchanged for a new access token
+	RefreshExpiry time.Duration
+}
+
+// ExpiryForRoles returns the token lifetime for a user holding the given roles
+func (c JWTConfig) ExpiryForRoles(roles []string) time.Duration {
+	expiry := time.Duration(0)
+	for _, role := range roles {
+		if roleExpiry, ok := c.RoleExpiry[role]; ok && (expiry == 0 || roleExpiry < expiry) {
+			expiry = roleExpiry
+		}
+	}
+	if expiry == 0 {
+		expiry = c.Expiry
+	}
+	return expiry
+}
+
+// AuthConfig holds account security settings
+type AuthConfig struct {
+	// PasswordResetExpiry is how long a password reset link stays valid
+	PasswordResetExpiry time.Duration
+	// MaxFailedLogins is how many wrong passwords in a row lock an account (0 disables lockout)
+	MaxFailedLogins int
+	// LockoutDuration is how long a locked account stays locked
+	LockoutDuration time.Duration
 }
 
 // MailgunConfig holds Mailgun settings
 type MailgunConfig struct {
 	APIKey string
 	Domain string
+	// BatchSize is how many recipients share one Mailgun request when sending campaigns (Mailgun allows up to 1000)
+	BatchSize int
 }
 
 // GoogleConfig holds Google OAuth settings
@@ -61,6 +159,12 @@ type GoogleConfig struct {
 // GeocodingConfig holds geocoding service settings
 type GeocodingConfig struct {
 	NominatimBaseURL string
+	// CacheTTL is how long a resolved address is reused before Nominatim is asked again
+	CacheTTL time.Duration
+	// CacheSize bounds the in-memory cache used when Redis is unavailable
+	CacheSize int
+	// MinRequestInterval spaces out calls to Nominatim, whose public instance allows one per second
+	MinRequestInterval time.Duration
 }
 
 // OpenAIConfig holds OpenAI API settings
@@ -69,11 +173,72 @@ type OpenAIConfig struct {
 	EmbeddingModel string
 }
 
+// EmbeddingConfig selects the provider that embeds skill claims
+type EmbeddingConfig struct {
+	// Provider is "openai" (using OpenAIConfig) or "local" for a self-hosted server
+	Provider string
+	// BaseURL, Model and APIKey configure the local provider; APIKey is optional
+	BaseURL string
+	Model   string
+	APIKey  string
+}
+
 // CORSConfig holds CORS settings
 type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+// NotificationConfig holds settings for the notification worker
+type NotificationConfig struct {
+	FrontendURL string
+	// DMEmailFallbackEnabled emails recipients about direct messages left unread
+	DMEmailFallbackEnabled bool
+	// DMEmailFallbackMinutes is how long a direct message may stay unread before the email goes out
+	DMEmailFallbackMinutes int
+}
+
+// RatingConfig holds volunteer rating settings
+type RatingConfig struct {
+	// RecencyHalfLifeDays is how many days it takes a rating's weight to halve in recency-weighted scorecards
+	RecencyHalfLifeDays int
+}
+
+// ProjectConfig holds project validation settings
+type ProjectConfig struct {
+	// MaxRequiredSkills caps how many required skills a project may list (0 disables the cap)
+	MaxRequiredSkills int
+	// RequireTeamLeadMembership only allows reassigning a project's team lead to an
+	// active member of its team
+	RequireTeamLeadMembership bool
+	// ReapplyCooldownDays is how long a volunteer must wait after a rejection before
+	// applying to the same project again (0 or less allows re-applying immediately)
+	ReapplyCooldownDays int
+}
+
+// MessagingConfig holds messaging policy settings
+type MessagingConfig struct {
+	// EditWindowMinutes is how long senders may edit a message (0 or less disables editing)
+	EditWindowMinutes int
+}
+
+// CampaignConfig holds campaign delivery settings
+type CampaignConfig struct {
+	// TrackingBaseURL is the public API address used in open-tracking pixels and click redirects
+	TrackingBaseURL string
+}
+
+// MatchingConfig holds matching worker settings
+type MatchingConfig struct {
+	// Interval is how often the worker recalculates all matches
+	Interval time.Duration
+	// TriggerPollInterval is how often the worker checks for on-demand recalculation requests
+	TriggerPollInterval time.Duration
+	// CategoryCreditPercent is how much credit, as a percentage of the related skill's
+	// weight, a volunteer gets for a skill in the same category as a missing required
+	// one. 0 keeps matching exact-only.
+	CategoryCreditPercent int
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
@@ -84,6 +249,11 @@ func Load() *Config {
 			User:     getEnv("DB_USER", "civicweave"),
 			Password: getEnv("DB_PASSWORD", "civicweave_dev"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+			ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", time.Minute),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -92,35 +262,105 @@ func Load() *Config {
 			DB:       0,
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "dev_jwt_secret_key_change_in_production"),
+			Secret:        getEnv("JWT_SECRET", "dev_jwt_secret_key_change_in_production"),
+			Expiry:        getEnvJWTExpiry("JWT_EXPIRY", 15*time.Minute),
+			RoleExpiry:    parseRoleExpiry(getEnv("JWT_ROLE_EXPIRY", "admin=10m")),
+			RefreshExpiry: getEnvDuration("JWT_REFRESH_EXPIRY", 30*24*time.Hour),
+		},
+		Auth: AuthConfig{
+			PasswordResetExpiry: getEnvDuration("PASSWORD_RESET_TOKEN_EXPIRY", time.Hour),
+			MaxFailedLogins:     getEnvInt("MAX_FAILED_LOGINS", 5),
+			LockoutDuration:     getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
 		},
 		Mailgun: MailgunConfig{
-			APIKey: getEnv("MAILGUN_API_KEY", ""),
-			Domain: getEnv("MAILGUN_DOMAIN", ""),
+			APIKey:    getEnv("MAILGUN_API_KEY", ""),
+			Domain:    getEnv("MAILGUN_DOMAIN", ""),
+			BatchSize: getEnvInt("MAILGUN_BATCH_SIZE", 500),
 		},
 		Google: GoogleConfig{
 			ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		},
 		Geocoding: GeocodingConfig{
-			NominatimBaseURL: getEnv("NOMINATIM_BASE_URL", "https://nominatim.openstreetmap.org"),
+			NominatimBaseURL:   getEnv("NOMINATIM_BASE_URL", "https://nominatim.openstreetmap.org"),
+			CacheTTL:           getEnvDuration("GEOCODING_CACHE_TTL", 30*24*time.Hour),
+			CacheSize:          getEnvInt("GEOCODING_CACHE_SIZE", 1000),
+			MinRequestInterval: getEnvDuration("GEOCODING_MIN_REQUEST_INTERVAL", time.Second),
 		},
 		OpenAI: OpenAIConfig{
 			APIKey:         getEnv("OPENAI_API_KEY", ""),
 			EmbeddingModel: getEnv("OPENAI_EMBEDDING_MODEL", "text-embedding-3-small"),
 		},
+		Embedding: EmbeddingConfig{
+			Provider: getEnv("EMBEDDING_PROVIDER", "openai"),
+			BaseURL:  getEnv("EMBEDDING_BASE_URL", ""),
+			Model:    getEnv("EMBEDDING_MODEL", ""),
+			APIKey:   getEnv("EMBEDDING_API_KEY", ""),
+		},
 		Features: FeatureFlags{
 			EmailEnabled: getEnv("ENABLE_EMAIL", "true") == "true",
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: parseCORSOrigins(getEnv("CORS_ALLOWED_ORIGINS", defaultCORSOrigins)),
 		},
+		Notifications: NotificationConfig{
+			FrontendURL:            getEnv("FRONTEND_URL", "http://localhost:3000"),
+			DMEmailFallbackEnabled: getEnv("DM_EMAIL_FALLBACK_ENABLED", "false") == "true",
+			DMEmailFallbackMinutes: getEnvInt("DM_EMAIL_FALLBACK_MINUTES", 60),
+		},
+		Projects: ProjectConfig{
+			MaxRequiredSkills:         getEnvInt("MAX_PROJECT_REQUIRED_SKILLS", 10),
+			RequireTeamLeadMembership: getEnv("REQUIRE_TEAM_LEAD_MEMBERSHIP", "false") == "true",
+			ReapplyCooldownDays:       getEnvInt("APPLICATION_REAPPLY_COOLDOWN_DAYS", 14),
+		},
+		Ratings: RatingConfig{
+			RecencyHalfLifeDays: getEnvInt("RATING_RECENCY_HALF_LIFE_DAYS", 180),
+		},
+		Messaging: MessagingConfig{
+			EditWindowMinutes: getEnvInt("MESSAGE_EDIT_WINDOW_MINUTES", 15),
+		},
+		Campaigns: CampaignConfig{
+			TrackingBaseURL: strings.TrimRight(getEnv("CAMPAIGN_TRACKING_BASE_URL", "http://localhost:8080"), "/"),
+		},
+		Matching: MatchingConfig{
+			Interval:              getEnvDuration("MATCHING_INTERVAL", 15*time.Minute),
+			TriggerPollInterval:   getEnvDuration("MATCHING_TRIGGER_POLL_INTERVAL", 15*time.Second),
+			CategoryCreditPercent: getEnvInt("MATCHING_CATEGORY_CREDIT_PERCENT", 0),
+		},
+		Logging: LoggingConfig{
+			Format: getEnv("LOG_FORMAT", "text"),
+			Level:  getEnv("LOG_LEVEL", "info"),
+		},
+		Metrics: MetricsConfig{
+			Enabled:    getEnv("METRICS_ENABLED", "false") == "true",
+			WorkerAddr: getEnv("METRICS_WORKER_ADDR", ":9091"),
+		},
+		Resources: ResourceConfig{
+			StorageBackend:   getEnv("RESOURCE_STORAGE_BACKEND", "local"),
+			UploadDir:        getEnv("RESOURCE_UPLOAD_DIR", "uploads"),
+			MaxUploadBytes:   int64(getEnvInt("RESOURCE_MAX_UPLOAD_MB", 25)) << 20,
+			AllowedMIMETypes: parseMIMETypes(getEnv("RESOURCE_ALLOWED_MIME_TYPES", defaultResourceMIMETypes)),
+			S3: S3Config{
+				Bucket:          getEnv("RESOURCE_S3_BUCKET", ""),
+				Region:          getEnv("RESOURCE_S3_REGION", "us-east-1"),
+				Endpoint:        getEnv("RESOURCE_S3_ENDPOINT", ""),
+				AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", ""),
+				SecretAccessKey: getEnv("AWS_SECRET_ACCESS_KEY", ""),
+			},
+		},
 	}
 }
 
 // Default CORS origins for development and production
 const defaultCORSOrigins = "http://localhost:3000,http://localhost:3001,https://civicweave.com,https://civicweave-frontend-162941711179.us-central1.run.app"
 
+// Default content types accepted by the resource library: documents, spreadsheets, slides, images and plain text
+const defaultResourceMIMETypes = "application/pdf,application/msword," +
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document," +
+	"application/vnd.ms-excel,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet," +
+	"application/vnd.ms-powerpoint,application/vnd.openxmlformats-officedocument.presentationml.presentation," +
+	"text/plain,text/csv,image/png,image/jpeg,image/gif,image/webp"
+
 // getEnv gets an environment variable with a fallback default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -129,12 +369,72 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt gets an integer environment variable, falling back to the default if unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration gets a positive duration such as "15m", falling back to the default if unset or invalid
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("⚠️  Ignoring %s=%q: expected a positive duration such as 15m", key, value)
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvJWTExpiry gets a token lifetime such as "24h", falling back to the
+// default if unset, invalid, or outside MinJWTExpiry..MaxJWTExpiry
+func getEnvJWTExpiry(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	expiry, err := time.ParseDuration(value)
+	if err != nil || expiry < MinJWTExpiry || expiry > MaxJWTExpiry {
+		log.Printf("⚠️  Ignoring %s=%q: expected a duration between %s and %s", key, value, MinJWTExpiry, MaxJWTExpiry)
+		return defaultValue
+	}
+	return expiry
+}
+
+// parseRoleExpiry parses comma-separated role=duration pairs such as
+// "admin=8h,volunteer=72h". Malformed or out-of-range entries are skipped.
+func parseRoleExpiry(spec string) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		role, value, found := strings.Cut(entry, "=")
+		role = strings.TrimSpace(role)
+		expiry, err := time.ParseDuration(strings.TrimSpace(value))
+		if !found || role == "" || err != nil || expiry < MinJWTExpiry || expiry > MaxJWTExpiry {
+			log.Printf("⚠️  Ignoring JWT_ROLE_EXPIRY entry %q: expected role=duration between %s and %s", entry, MinJWTExpiry, MaxJWTExpiry)
+			continue
+		}
+		result[role] = expiry
+	}
+	return result
+}
+
 // parseCORSOrigins parses comma-separated CORS origins
 func parseCORSOrigins(origins string) []string {
 	if origins == "" {
 		return []string{}
 	}
-	
+
 	var result []string
 	for _, origin := range strings.Split(origins, ",") {
 		trimmed := strings.TrimSpace(origin)
@@ -144,3 +444,15 @@ func parseCORSOrigins(origins string) []string {
 	}
 	return result
 }
+
+// parseMIMETypes parses comma-separated MIME types, lowercasing them for comparison
+func parseMIMETypes(types string) []string {
+	var result []string
+	for _, mimeType := range strings.Split(types, ",") {
+		trimmed := strings.ToLower(strings.TrimSpace(mimeType))
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRoleExpirySkipsInvalidEntries(t *testing.T) {
+	got := parseRoleExpiry(" admin=8h, volunteer=72h,lead,=1h,guest=soon,spam=1s,hoarder=90d ")
+
+	want := map[string]time.Duration{"admin": 8 * time.Hour, "volunteer": 72 * time.Hour}
+	if len(got) != len(want) {
+		t.Fatalf("parseRoleExpiry() = %v, want %v", got, want)
+	}
+	for role, expiry := range want {
+		if got[role] != expiry {
+			t.Errorf("%s expiry = %s, want %s", role, got[role], expiry)
+		}
+	}
+}
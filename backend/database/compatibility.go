@@ -11,12 +11,13 @@ import (
 
 // CompatibilityMatrix represents the compatibility status between database and runtime
 type CompatibilityMatrix struct {
-	CurrentDBVersion    string            `json:"current_db_version"`
-	RuntimeVersion      string            `json:"runtime_version"`
-	OverallStatus       string            `json:"overall_status"` // compatible, warning, incompatible
-	AppliedMigrations   []MigrationStatus `json:"applied_migrations"`
-	PendingMigrations   []MigrationStatus `json:"pending_migrations"`
-	CompatibilityIssues []string          `json:"compatibility_issues,omitempty"`
+	CurrentDBVersion    string                `json:"current_db_version"`
+	RuntimeVersion      string                `json:"runtime_version"`
+	OverallStatus       string                `json:"overall_status"` // compatible, warning, incompatible
+	AppliedMigrations   []MigrationStatus     `json:"applied_migrations"`
+	PendingMigrations   []MigrationStatus     `json:"pending_migrations"`
+	CompatibilityIssues []string              `json:"compatibility_issues,omitempty"`
+	OutOfOrder          []OutOfOrderMigration `json:"out_of_order_migrations,omitempty"`
 }
 
 // CheckDatabaseCompatibility checks the overall compatibility between database and runtime
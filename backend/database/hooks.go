@@ -106,8 +106,21 @@ func GetMigrationStatus(db *sql.DB) (*CompatibilityMatrix, error) {
 		OverallStatus:     "unknown",
 	}
 
+	// Flag pending migrations that sort before applied ones
+	ordered := make([]string, 0, len(registry.Migrations))
+	for _, migration := range registry.GetSortedMigrations() {
+		ordered = append(ordered, migration.Version)
+	}
+	applied := make(map[string]bool, len(appliedMigrations))
+	for _, migration := range appliedMigrations {
+		applied[migration.Version] = true
+	}
+	status.OutOfOrder = FindOutOfOrderMigrations(ordered, applied)
+
 	// Determine overall status
-	if len(pendingMigrations) > 0 {
+	if len(status.OutOfOrder) > 0 {
+		status.OverallStatus = "out_of_order"
+	} else if len(pendingMigrations) > 0 {
 		status.OverallStatus = "pending_migrations"
 	} else if len(appliedMigrations) > 0 {
 		status.OverallStatus = "up_to_date"
@@ -170,6 +183,10 @@ func CheckMigrationHealth(db *sql.DB, runtimeVersion string) (int, string, error
 		return 2, "", fmt.Errorf("failed to get migration status: %w", err)
 	}
 
+	if len(status.OutOfOrder) > 0 {
+		return 2, OutOfOrderMessage(status.OutOfOrder), nil // Exit code 2: applied migrations skipped over pending ones
+	}
+
 	if len(status.PendingMigrations) > 0 {
 		return 1, fmt.Sprintf("Pending migrations: %d", len(status.PendingMigrations)), nil // Exit code 1: needs migration
 	}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	_ "github.com/lib/pq"
@@ -14,10 +15,11 @@ import (
 
 // Migration represents a database migration
 type Migration struct {
-	Version int
-	Name    string
-	Up      string
-	Down    string
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
 }
 
 // RunMigrations executes all pending migrations
@@ -39,6 +41,11 @@ func Migrate(db *sql.DB) error {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
+	// Refuse to run if an applied migration has changed since it was applied
+	if err := verifyAppliedChecksums(db, appliedMigrations, migrations); err != nil {
+		return err
+	}
+
 	// Apply pending migrations
 	for _, migration := range migrations {
 		if _, exists := appliedMigrations[migration.Version]; !exists {
@@ -58,32 +65,69 @@ func createMigrationsTable(db *sql.DB) error {
 	query := `
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
-		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		checksum VARCHAR(64)
 	);`
 	
-	_, err := db.Exec(query)
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+
+	// Tables created before checksums were recorded
+	_, err := db.Exec("ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64)")
 	return err
 }
 
-func getAppliedMigrations(db *sql.DB) (map[int]bool, error) {
-	rows, err := db.Query("SELECT version FROM schema_migrations")
+// getAppliedMigrations returns the checksum recorded for each applied version
+func getAppliedMigrations(db *sql.DB) (map[int]string, error) {
+	rows, err := db.Query("SELECT version, COALESCE(checksum, '') FROM schema_migrations")
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	applied := make(map[int]bool)
+	applied := make(map[int]string)
 	for rows.Next() {
 		var version int
-		if err := rows.Scan(&version); err != nil {
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
 			return nil, err
 		}
-		applied[version] = true
+		applied[version] = checksum
 	}
 
 	return applied, rows.Err()
 }
 
+// verifyAppliedChecksums fails with ErrMigrationChecksumMismatch if any applied migration's
+// file has changed. Migrations applied before checksums were recorded get theirs filled in.
+func verifyAppliedChecksums(db *sql.DB, applied map[int]string, migrations []Migration) error {
+	recorded := make(map[string]string, len(applied))
+	for version, checksum := range applied {
+		recorded[strconv.Itoa(version)] = checksum
+	}
+	current := make(map[string]string, len(migrations))
+	for _, migration := range migrations {
+		current[strconv.Itoa(migration.Version)] = migration.Checksum
+	}
+
+	if err := ChecksumMismatchError(FindChecksumMismatches(recorded, current)); err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		checksum, exists := applied[migration.Version]
+		if !exists || IsMigrationChecksum(checksum) {
+			continue
+		}
+		if _, err := db.Exec("UPDATE schema_migrations SET checksum = $2 WHERE version = $1", migration.Version, migration.Checksum); err != nil {
+			return fmt.Errorf("failed to record checksum for migration %d: %w", migration.Version, err)
+		}
+	}
+
+	return nil
+}
+
 func loadMigrations() ([]Migration, error) {
 	migrationsDir := "migrations"
 	
@@ -157,10 +201,11 @@ func parseMigrationFile(filepath string) (Migration, error) {
 	}
 
 	return Migration{
-		Version: version,
-		Name:    name,
-		Up:      up,
-		Down:    down,
+		Version:  version,
+		Name:     name,
+		Up:       up,
+		Down:     down,
+		Checksum: MigrationChecksum(content),
 	}, nil
 }
 
@@ -178,7 +223,7 @@ func applyMigration(db *sql.DB, migration Migration) error {
 	}
 
 	// Record migration as applied
-	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", migration.Version); err != nil {
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", migration.Version, migration.Checksum); err != nil {
 		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
@@ -50,6 +50,11 @@ func MigrateV2(db *sql.DB, options *MigrationOptions) error {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
+	// Refuse to run if an applied migration has changed since it was applied
+	if err := verifyAppliedChecksumsV2(appliedMigrations); err != nil {
+		return err
+	}
+
 	appliedVersions := make(map[string]bool)
 	for _, migration := range appliedMigrations {
 		appliedVersions[migration.Version] = true
@@ -229,7 +234,7 @@ func applyMigrationV2(db *sql.DB, migration *MigrationMetadata, options *Migrati
 
 	// If checksum differs, this is an error (migration was modified)
 	if err == nil && existingChecksum != checksum {
-		return nil, fmt.Errorf("migration %s was modified after being applied (checksum mismatch)", migration.Version)
+		return nil, fmt.Errorf("%w: migration %s", ErrMigrationChecksumMismatch, migration.Version)
 	}
 
 	if options.DryRun {
@@ -345,6 +350,28 @@ func rollbackMigrationV2(db *sql.DB, migration *MigrationMetadata, options *Migr
 	}, nil
 }
 
+// verifyAppliedChecksumsV2 fails with ErrMigrationChecksumMismatch if any applied
+// migration's up.sql has changed since it was applied
+func verifyAppliedChecksumsV2(appliedMigrations []MigrationStatus) error {
+	recorded := make(map[string]string, len(appliedMigrations))
+	current := make(map[string]string, len(appliedMigrations))
+	for _, migration := range appliedMigrations {
+		upPath, _ := GetMigrationPath("migrations_v2", migration.Version)
+		if upPath == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(upPath)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", migration.Version, err)
+		}
+		recorded[migration.Version] = migration.Checksum
+		current[migration.Version] = calculateChecksum(content)
+	}
+
+	return ChecksumMismatchError(FindChecksumMismatches(recorded, current))
+}
+
 // calculateChecksum calculates SHA256 checksum of migration content
 func calculateChecksum(content []byte) string {
 	hash := sha256.Sum256(content)
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrMigrationChecksumMismatch is returned when an applied migration's file no longer
+// matches the content that was applied, e.g. a version was reused or edited after merging
+var ErrMigrationChecksumMismatch = fmt.Errorf("applied migration has changed since it was applied")
+
+// sha256ChecksumPattern matches checksums produced by MigrationChecksum. Older tooling
+// recorded other values (such as the file length), which are replaced as they're found.
+var sha256ChecksumPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ChecksumMismatch describes an applied migration whose file content has changed
+type ChecksumMismatch struct {
+	Version  string
+	Recorded string
+	Current  string
+}
+
+// MigrationChecksum returns the checksum recorded for a migration file's content
+func MigrationChecksum(content []byte) string {
+	return calculateChecksum(content)
+}
+
+// IsMigrationChecksum reports whether a recorded checksum was produced by MigrationChecksum
+func IsMigrationChecksum(checksum string) bool {
+	return sha256ChecksumPattern.MatchString(checksum)
+}
+
+// FindChecksumMismatches compares the checksums recorded for applied migrations with the
+// checksums of the migration files now on disk, both keyed by version. Versions with no
+// file, or without a MigrationChecksum recorded, can't be compared and are skipped.
+func FindChecksumMismatches(recorded, current map[string]string) []ChecksumMismatch {
+	var mismatches []ChecksumMismatch
+	for version, recordedChecksum := range recorded {
+		currentChecksum, exists := current[version]
+		if !exists || !IsMigrationChecksum(recordedChecksum) {
+			continue
+		}
+		if recordedChecksum != currentChecksum {
+			mismatches = append(mismatches, ChecksumMismatch{
+				Version:  version,
+				Recorded: recordedChecksum,
+				Current:  currentChecksum,
+			})
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Version < mismatches[j].Version
+	})
+	return mismatches
+}
+
+// ChecksumMismatchError describes mismatches as an error wrapping ErrMigrationChecksumMismatch,
+// or returns nil if there are none
+func ChecksumMismatchError(mismatches []ChecksumMismatch) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	details := make([]string, 0, len(mismatches))
+	for _, mismatch := range mismatches {
+		details = append(details, fmt.Sprintf("migration %s (recorded %.12s, file now %.12s)",
+			mismatch.Version, mismatch.Recorded, mismatch.Current))
+	}
+	return fmt.Errorf("%w: %s; restore the original files and add a new migration for the change",
+		ErrMigrationChecksumMismatch, strings.Join(details, ", "))
+}
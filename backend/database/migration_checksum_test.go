@@ -0,0 +1,87 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindChecksumMismatches(t *testing.T) {
+	sum001 := MigrationChecksum([]byte("CREATE TABLE a (id INT);"))
+	sum002 := MigrationChecksum([]byte("CREATE TABLE b (id INT);"))
+	edited002 := MigrationChecksum([]byte("CREATE TABLE b (id BIGINT);"))
+	sum003 := MigrationChecksum([]byte("CREATE TABLE c (id INT);"))
+	edited003 := MigrationChecksum([]byte("CREATE TABLE c (id BIGINT);"))
+
+	tests := []struct {
+		name     string
+		recorded map[string]string
+		current  map[string]string
+		want     []ChecksumMismatch
+	}{
+		{
+			name:     "unchanged",
+			recorded: map[string]string{"001": sum001, "002": sum002},
+			current:  map[string]string{"001": sum001, "002": sum002},
+		},
+		{
+			name:     "edited files sorted by version",
+			recorded: map[string]string{"003": sum003, "001": sum001, "002": sum002},
+			current:  map[string]string{"003": edited003, "001": sum001, "002": edited002},
+			want: []ChecksumMismatch{
+				{Version: "002", Recorded: sum002, Current: edited002},
+				{Version: "003", Recorded: sum003, Current: edited003},
+			},
+		},
+		{
+			name:     "file removed",
+			recorded: map[string]string{"001": sum001, "002": sum002},
+			current:  map[string]string{"001": sum001},
+		},
+		{
+			name:     "legacy length checksum",
+			recorded: map[string]string{"001": "1a"},
+			current:  map[string]string{"001": sum001},
+		},
+		{
+			name:     "not yet applied",
+			recorded: map[string]string{"001": sum001},
+			current:  map[string]string{"001": sum001, "002": sum002},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindChecksumMismatches(tt.recorded, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindChecksumMismatches() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksumMismatchError(t *testing.T) {
+	if err := ChecksumMismatchError(nil); err != nil {
+		t.Errorf("ChecksumMismatchError(nil) = %v, want nil", err)
+	}
+
+	err := ChecksumMismatchError([]ChecksumMismatch{{Version: "002", Recorded: strings.Repeat("a", 64), Current: strings.Repeat("b", 64)}})
+	if !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Fatalf("error %v does not wrap ErrMigrationChecksumMismatch", err)
+	}
+	if !strings.Contains(err.Error(), "migration 002 (recorded aaaaaaaaaaaa, file now bbbbbbbbbbbb)") {
+		t.Errorf("error %q does not describe the mismatch", err)
+	}
+}
+
+func TestIsMigrationChecksum(t *testing.T) {
+	if !IsMigrationChecksum(MigrationChecksum([]byte("SELECT 1;"))) {
+		t.Error("MigrationChecksum output not recognized")
+	}
+	for _, legacy := range []string{"", "1a", strings.Repeat("A", 64)} {
+		if IsMigrationChecksum(legacy) {
+			t.Errorf("IsMigrationChecksum(%q) = true, want false", legacy)
+		}
+	}
+}
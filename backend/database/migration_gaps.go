@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutOfOrderMigration is a pending migration that sorts before one that's already
+// applied, usually left behind when branches with new migrations are merged
+type OutOfOrderMigration struct {
+	Version       string `json:"version"`
+	LatestApplied string `json:"latest_applied"`
+}
+
+// FindOutOfOrderMigrations returns the pending migrations that sort before an applied
+// one. ordered lists every available version in the order migrations run.
+func FindOutOfOrderMigrations(ordered []string, applied map[string]bool) []OutOfOrderMigration {
+	latestApplied := -1
+	for i, version := range ordered {
+		if applied[version] {
+			latestApplied = i
+		}
+	}
+
+	var outOfOrder []OutOfOrderMigration
+	for i := 0; i < latestApplied; i++ {
+		if !applied[ordered[i]] {
+			outOfOrder = append(outOfOrder, OutOfOrderMigration{
+				Version:       ordered[i],
+				LatestApplied: ordered[latestApplied],
+			})
+		}
+	}
+	return outOfOrder
+}
+
+// OutOfOrderMessage describes out-of-order migrations for an operator
+func OutOfOrderMessage(outOfOrder []OutOfOrderMigration) string {
+	if len(outOfOrder) == 0 {
+		return ""
+	}
+
+	versions := make([]string, 0, len(outOfOrder))
+	for _, migration := range outOfOrder {
+		versions = append(versions, migration.Version)
+	}
+	return fmt.Sprintf("Out-of-order migrations: %s not applied but %s already is",
+		strings.Join(versions, ", "), outOfOrder[0].LatestApplied)
+}
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+// dollarQuoteTagPattern matches the opening of a dollar-quoted string, $$ or $tag$
+var dollarQuoteTagPattern = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// SplitStatements splits a SQL script into individual statements on semicolons,
+// ignoring semicolons inside string literals (including E'...' escape strings),
+// quoted identifiers, comments and dollar-quoted blocks such as PL/pgSQL function
+// bodies. Statements are trimmed and empty ones are dropped.
+func SplitStatements(script string) []string {
+	var statements []string
+	start := 0
+
+	for i := 0; i < len(script); {
+		switch {
+		case script[i] == '\'' && isEscapeStringPrefix(script, i):
+			i = skipEscapeString(script, i)
+		case script[i] == '\'' || script[i] == '"':
+			i = skipQuoted(script, i, script[i])
+		case strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(script)
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			i = skipBlockComment(script, i)
+		case script[i] == '$':
+			tag := dollarQuoteTagPattern.FindString(script[i:])
+			if tag == "" || (i > 0 && isIdentifierByte(script[i-1])) {
+				i++
+				continue
+			}
+			if end := strings.Index(script[i+len(tag):], tag); end >= 0 {
+				i += len(tag) + end + len(tag)
+			} else {
+				i = len(script)
+			}
+		case script[i] == ';':
+			statements = appendStatement(statements, script[start:i])
+			i++
+			start = i
+		default:
+			i++
+		}
+	}
+
+	return appendStatement(statements, script[start:])
+}
+
+// skipQuoted returns the index just past a quoted string or identifier starting at
+// start, treating a doubled quote character as an escaped quote
+func skipQuoted(script string, start int, quote byte) int {
+	for i := start + 1; i < len(script); i++ {
+		if script[i] != quote {
+			continue
+		}
+		if i+1 < len(script) && script[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(script)
+}
+
+// isEscapeStringPrefix reports whether the quote at i opens an E'...' escape string,
+// where backslashes escape the next character
+func isEscapeStringPrefix(script string, i int) bool {
+	if i == 0 || (script[i-1] != 'E' && script[i-1] != 'e') {
+		return false
+	}
+	return i == 1 || !isIdentifierByte(script[i-2])
+}
+
+// skipEscapeString returns the index just past an E'...' string whose opening quote
+// is at start, honouring both backslash escapes and doubled quotes
+func skipEscapeString(script string, start int) int {
+	for i := start + 1; i < len(script); i++ {
+		switch script[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 < len(script) && script[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(script)
+}
+
+// skipBlockComment returns the index just past a (possibly nested) /* */ comment
+func skipBlockComment(script string, start int) int {
+	depth := 0
+	for i := start; i < len(script)-1; i++ {
+		switch script[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(script)
+}
+
+// isIdentifierByte reports whether b can appear in an unquoted identifier, in which
+// case a following $ is part of the identifier rather than a dollar quote
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// appendStatement adds a statement unless it's empty or only comments
+func appendStatement(statements []string, statement string) []string {
+	statement = strings.TrimSpace(statement)
+	if statement == "" || isOnlyComments(statement) {
+		return statements
+	}
+	return append(statements, statement)
+}
+
+// isOnlyComments reports whether a trimmed statement contains nothing but line comments
+func isOnlyComments(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
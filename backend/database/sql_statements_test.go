@@ -0,0 +1,112 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "plain statements",
+			script: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "trailing statement without semicolon",
+			script: "SELECT 1; SELECT 2",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "empty statements dropped",
+			script: ";;\n  ;SELECT 1;;",
+			want:   []string{"SELECT 1"},
+		},
+		{
+			name: "semicolons in a $$ function body",
+			script: `CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+SELECT 1;`,
+			want: []string{
+				"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n    NEW.updated_at = now();\n    RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+				"SELECT 1",
+			},
+		},
+		{
+			name:   "semicolons in a tagged $body$ block containing $$",
+			script: "DO $body$ BEGIN PERFORM '$$;'; END; $body$; SELECT 2;",
+			want:   []string{"DO $body$ BEGIN PERFORM '$$;'; END; $body$", "SELECT 2"},
+		},
+		{
+			name:   "dollar in identifiers and parameters is not a quote",
+			script: "SELECT a$b FROM t WHERE id = $1; SELECT 2;",
+			want:   []string{"SELECT a$b FROM t WHERE id = $1", "SELECT 2"},
+		},
+		{
+			name:   "semicolons in string literals with doubled quotes",
+			script: "INSERT INTO notes VALUES ('it''s; fine'); SELECT 2;",
+			want:   []string{"INSERT INTO notes VALUES ('it''s; fine')", "SELECT 2"},
+		},
+		{
+			name:   "semicolons in quoted identifiers",
+			script: `CREATE TABLE "odd;name" (id INT); SELECT 2;`,
+			want:   []string{`CREATE TABLE "odd;name" (id INT)`, "SELECT 2"},
+		},
+		{
+			name:   "backslash-escaped quote in an E string",
+			script: `INSERT INTO notes VALUES (E'it\'s; fine'); SELECT 2;`,
+			want:   []string{`INSERT INTO notes VALUES (E'it\'s; fine')`, "SELECT 2"},
+		},
+		{
+			name:   "escaped backslash ends an E string",
+			script: `SELECT e'C:\\'; SELECT 2;`,
+			want:   []string{`SELECT e'C:\\'`, "SELECT 2"},
+		},
+		{
+			name:   "backslash in a standard string is literal",
+			script: `SELECT 'C:\'; SELECT 2;`,
+			want:   []string{`SELECT 'C:\'`, "SELECT 2"},
+		},
+		{
+			name:   "identifier ending in e before a string",
+			script: `SELECT name'x\'; SELECT 2;`,
+			want:   []string{`SELECT name'x\'`, "SELECT 2"},
+		},
+		{
+			name:   "semicolons in line comments",
+			script: "-- drop; everything\nSELECT 1; -- trailing; comment\nSELECT 2;",
+			want:   []string{"-- drop; everything\nSELECT 1", "-- trailing; comment\nSELECT 2"},
+		},
+		{
+			name:   "comment-only statements dropped",
+			script: "SELECT 1;\n-- nothing after this;",
+			want:   []string{"SELECT 1"},
+		},
+		{
+			name:   "semicolons in nested block comments",
+			script: "/* outer; /* inner; */ still; */ SELECT 1; SELECT 2;",
+			want:   []string{"/* outer; /* inner; */ still; */ SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "unterminated string runs to the end",
+			script: "SELECT 1; SELECT 'open;",
+			want:   []string{"SELECT 1", "SELECT 'open;"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
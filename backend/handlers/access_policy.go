@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Access policy for detail endpoints
+//
+// Handlers that address a single resource by ID (projects and their team
+// resources, tasks, messages, campaigns) must not reveal whether that ID exists
+// to callers who are not allowed to see it. The rules are:
+//
+//   - A resource the caller cannot see is reported exactly like a missing one:
+//     404 with the same "<Resource> not found" body, whatever the HTTP method.
+//     Visibility is checked before the resource is loaded where possible, so
+//     the lookup itself can't tell the two cases apart.
+//   - 403 is reserved for callers who can already see the resource but are
+//     attempting something they are not allowed to do (e.g. a team member
+//     deleting someone else's message, a volunteer updating a task they are
+//     not assigned to).
+//   - Role and permission checks that do not depend on the resource (e.g.
+//     holding view_campaigns) run before the lookup and may keep returning 403,
+//     since they say nothing about whether a particular ID exists.
+//
+// A project's own listing and details are visible to every authenticated user
+// once it is recruiting, active or completed. Draft and archived projects, and
+// a project's team resources (signups, team members, logistics, surveys,
+// messages, skill gap, waitlist), are only visible to its team: its lead, its
+// active members and admins. Anyone else gets the "Project not found" 404 a
+// missing project gets, including when they try to edit a project they can see
+// listed; team members asking for something only the lead may do get 403.
+
+// respondNotFound writes the 404 used for both missing and inaccessible resources
+func respondNotFound(c *gin.Context, resource string) {
+	c.JSON(http.StatusNotFound, gin.H{"error": resource + " not found"})
+}
+
+// projectTeamChecker is the part of ProjectService the access policy needs
+type projectTeamChecker interface {
+	IsTeamLead(projectID, userID uuid.UUID) (bool, error)
+	IsTeamMember(projectID, userID uuid.UUID) (bool, error)
+}
+
+// canSeeMessage reports whether the caller is a participant in the message:
+// its sender or direct recipient, a lead or member of its project, or an admin
+func canSeeMessage(message *models.ProjectMessage, userCtx *middleware.UserContext, projects projectTeamChecker) (bool, error) {
+	if userCtx.HasPermission(models.PermissionModerateMessages) || message.SenderID == userCtx.ID {
+		return true, nil
+	}
+	if message.RecipientUserID != nil && *message.RecipientUserID == userCtx.ID {
+		return true, nil
+	}
+	if message.ProjectID != nil {
+		return canSeeProjectTeam(*message.ProjectID, userCtx, projects)
+	}
+	return false, nil
+}
+
+// canSeeProjectTeam reports whether the caller may see a project's team resources,
+// such as its tasks and their attachments: a lead or member of the project, or an admin
+func canSeeProjectTeam(projectID uuid.UUID, userCtx *middleware.UserContext, projects projectTeamChecker) (bool, error) {
+	if userCtx.HasPermission(models.PermissionViewAllProjects) {
+		return true, nil
+	}
+	isTeamLead, err := projects.IsTeamLead(projectID, userCtx.ID)
+	if err != nil || isTeamLead {
+		return isTeamLead, err
+	}
+	return projects.IsTeamMember(projectID, userCtx.ID)
+}
+
+// publicProjectStatuses are the statuses in which a project is visible to everyone
+var publicProjectStatuses = map[models.ProjectStatus]bool{
+	models.ProjectStatusRecruiting: true,
+	models.ProjectStatusActive:     true,
+	models.ProjectStatusCompleted:  true,
+}
+
+// canSeeProject reports whether the caller may see a project's details: anyone once
+// it is public, otherwise only its team and admins
+func canSeeProject(project *models.Project, userCtx *middleware.UserContext, projects projectTeamChecker) (bool, error) {
+	if publicProjectStatuses[project.ProjectStatus] {
+		return true, nil
+	}
+	return canSeeProjectTeam(project.ID, userCtx, projects)
+}
+
+// requireProjectVisible responds with the missing-project 404 and returns false unless
+// the caller can see the project's details
+func requireProjectVisible(c *gin.Context, project *models.Project, userCtx *middleware.UserContext, projects projectTeamChecker) bool {
+	canSee, err := canSeeProject(project, userCtx, projects)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return false
+	}
+	if !canSee {
+		respondNotFound(c, "Project")
+		return false
+	}
+	return true
+}
+
+// requireProjectTeam responds with the missing-project 404 and returns false unless
+// the caller can see the project's team resources
+func requireProjectTeam(c *gin.Context, projectID uuid.UUID, userCtx *middleware.UserContext, projects projectTeamChecker) bool {
+	canSee, err := canSeeProjectTeam(projectID, userCtx, projects)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return false
+	}
+	if !canSee {
+		respondNotFound(c, "Project")
+		return false
+	}
+	return true
+}
+
+// respondProjectForbidden rejects a caller who isn't allowed something on a project or
+// one of its resources: members of the team get 403 with message, and anyone else
+// gets the 404 for a missing resource (e.g. "Project" or "Task")
+func respondProjectForbidden(c *gin.Context, resource string, projectID uuid.UUID, userCtx *middleware.UserContext, projects projectTeamChecker, message string) {
+	canSee, err := canSeeProjectTeam(projectID, userCtx, projects)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, resource)
+		return
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": message})
+}
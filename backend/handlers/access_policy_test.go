@@ -0,0 +1,407 @@
+package handlers
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// fakeProjectTeam is a projectTeamChecker for a single project with a lead and members
+type fakeProjectTeam struct {
+	projectID uuid.UUID
+	leadID    uuid.UUID
+	members   []uuid.UUID
+	err       error
+}
+
+func (f *fakeProjectTeam) IsTeamLead(projectID, userID uuid.UUID) (bool, error) {
+	return projectID == f.projectID && userID == f.leadID, f.err
+}
+
+func (f *fakeProjectTeam) IsTeamMember(projectID, userID uuid.UUID) (bool, error) {
+	if projectID != f.projectID {
+		return false, f.err
+	}
+	for _, member := range f.members {
+		if member == userID {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func newFakeProjectTeam() (*fakeProjectTeam, *middleware.UserContext, *middleware.UserContext, *middleware.UserContext) {
+	lead := &middleware.UserContext{ID: uuid.New(), Roles: []string{"team_lead"}}
+	member := &middleware.UserContext{ID: uuid.New(), Roles: []string{"volunteer"}}
+	outsider := &middleware.UserContext{ID: uuid.New(), Roles: []string{"volunteer"}}
+	team := &fakeProjectTeam{projectID: uuid.New(), leadID: lead.ID, members: []uuid.UUID{member.ID}}
+	return team, lead, member, outsider
+}
+
+func policyContext() (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	return c, recorder
+}
+
+// missingBody is what respondNotFound writes for a resource that doesn't exist
+func missingBody(resource string) string {
+	c, recorder := policyContext()
+	respondNotFound(c, resource)
+	return recorder.Body.String()
+}
+
+func TestCanSeeProjectTeam(t *testing.T) {
+	team, lead, member, outsider := newFakeProjectTeam()
+	admin := &middleware.UserContext{ID: uuid.New(), Roles: []string{"admin"}}
+
+	tests := []struct {
+		name    string
+		userCtx *middleware.UserContext
+		want    bool
+	}{
+		{"admin", admin, true},
+		{"team lead", lead, true},
+		{"team member", member, true},
+		{"outsider", outsider, false},
+	}
+
+	for _, tt := range tests {
+		got, err := canSeeProjectTeam(team.projectID, tt.userCtx, team)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: canSeeProjectTeam() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got, _ := canSeeProjectTeam(uuid.New(), lead, team); got {
+		t.Error("team lead can see another project's team")
+	}
+}
+
+func TestCanSeeProjectTeamGrantedByPermission(t *testing.T) {
+	permissions := models.DefaultRolePermissions()
+	permissions["auditor"] = []string{models.PermissionViewAllProjects}
+	middleware.SetRolePermissions(permissions)
+	t.Cleanup(func() { middleware.SetRolePermissions(models.DefaultRolePermissions()) })
+
+	team, _, _, _ := newFakeProjectTeam()
+	auditor := &middleware.UserContext{ID: uuid.New(), Roles: []string{"auditor"}}
+	if got, err := canSeeProjectTeam(team.projectID, auditor, team); err != nil || !got {
+		t.Errorf("canSeeProjectTeam() = %v, %v; a role granted view_all_projects should see every team", got, err)
+	}
+
+	lead := &middleware.UserContext{ID: uuid.New(), Roles: []string{"team_lead"}}
+	if got, _ := canSeeProjectTeam(team.projectID, lead, team); got {
+		t.Error("a team lead of another project should not see this team")
+	}
+}
+
+func TestCanSeeProject(t *testing.T) {
+	team, lead, member, outsider := newFakeProjectTeam()
+	admin := &middleware.UserContext{ID: uuid.New(), Roles: []string{"admin"}}
+	project := func(status models.ProjectStatus) *models.Project {
+		return &models.Project{ID: team.projectID, ProjectStatus: status}
+	}
+
+	tests := []struct {
+		name    string
+		project *models.Project
+		userCtx *middleware.UserContext
+		want    bool
+	}{
+		{"recruiting, outsider", project(models.ProjectStatusRecruiting), outsider, true},
+		{"active, outsider", project(models.ProjectStatusActive), outsider, true},
+		{"completed, outsider", project(models.ProjectStatusCompleted), outsider, true},
+		{"draft, outsider", project(models.ProjectStatusDraft), outsider, false},
+		{"archived, outsider", project(models.ProjectStatusArchived), outsider, false},
+		{"draft, team lead", project(models.ProjectStatusDraft), lead, true},
+		{"archived, team member", project(models.ProjectStatusArchived), member, true},
+		{"draft, admin", project(models.ProjectStatusDraft), admin, true},
+	}
+
+	for _, tt := range tests {
+		got, err := canSeeProject(tt.project, tt.userCtx, team)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: canSeeProject() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequireProjectVisibleHidesDraftsFromOutsiders(t *testing.T) {
+	team, _, _, outsider := newFakeProjectTeam()
+	draft := &models.Project{ID: team.projectID, ProjectStatus: models.ProjectStatusDraft}
+
+	c, recorder := policyContext()
+	if requireProjectVisible(c, draft, outsider, team) {
+		t.Fatal("outsider allowed to see a draft project")
+	}
+	if recorder.Code != http.StatusNotFound || recorder.Body.String() != missingBody("Project") {
+		t.Errorf("outsider got %d %s, want the missing-project 404", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestCanSeeMessage(t *testing.T) {
+	team, lead, member, outsider := newFakeProjectTeam()
+	recipientID := uuid.New()
+	projectMessage := &models.ProjectMessage{ProjectID: &team.projectID, SenderID: uuid.New()}
+	directMessage := &models.ProjectMessage{SenderID: lead.ID, RecipientUserID: &recipientID}
+
+	tests := []struct {
+		name    string
+		message *models.ProjectMessage
+		userCtx *middleware.UserContext
+		want    bool
+	}{
+		{"project message, team member", projectMessage, member, true},
+		{"project message, team lead", projectMessage, lead, true},
+		{"project message, outsider", projectMessage, outsider, false},
+		{"direct message, sender", directMessage, lead, true},
+		{"direct message, recipient", directMessage, &middleware.UserContext{ID: recipientID}, true},
+		{"direct message, team member", directMessage, member, false},
+	}
+
+	for _, tt := range tests {
+		got, err := canSeeMessage(tt.message, tt.userCtx, team)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: canSeeMessage() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequireProjectTeamHidesProjectFromOutsiders(t *testing.T) {
+	team, _, member, outsider := newFakeProjectTeam()
+
+	c, recorder := policyContext()
+	if requireProjectTeam(c, team.projectID, outsider, team) {
+		t.Fatal("outsider allowed to see the project team")
+	}
+	if recorder.Code != http.StatusNotFound || recorder.Body.String() != missingBody("Project") {
+		t.Errorf("outsider got %d %s, want the missing-project 404", recorder.Code, recorder.Body.String())
+	}
+
+	c, _ = policyContext()
+	if !requireProjectTeam(c, team.projectID, member, team) {
+		t.Error("team member not allowed to see the project team")
+	}
+}
+
+func TestRespondProjectForbidden(t *testing.T) {
+	team, _, member, outsider := newFakeProjectTeam()
+
+	tests := []struct {
+		name       string
+		resource   string
+		userCtx    *middleware.UserContext
+		wantStatus int
+		wantBody   string
+	}{
+		{"team member is forbidden", "Project", member, http.StatusForbidden, `{"error":"Only the team lead can do this"}`},
+		{"outsider sees a missing project", "Project", outsider, http.StatusNotFound, missingBody("Project")},
+		{"outsider sees a missing task", "Task", outsider, http.StatusNotFound, missingBody("Task")},
+	}
+
+	for _, tt := range tests {
+		c, recorder := policyContext()
+		respondProjectForbidden(c, tt.resource, team.projectID, tt.userCtx, team, "Only the team lead can do this")
+		if recorder.Code != tt.wantStatus || recorder.Body.String() != tt.wantBody {
+			t.Errorf("%s: got %d %s, want %d %s", tt.name, recorder.Code, recorder.Body.String(), tt.wantStatus, tt.wantBody)
+		}
+	}
+
+	// A project that doesn't exist has no team, so it's indistinguishable from one the caller isn't on
+	c, recorder := policyContext()
+	respondProjectForbidden(c, "Project", uuid.New(), member, team, "Only the team lead can do this")
+	if recorder.Code != http.StatusNotFound || recorder.Body.String() != missingBody("Project") {
+		t.Errorf("missing project: got %d %s, want the missing-project 404", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestProjectAccessCheckErrors(t *testing.T) {
+	team, _, member, _ := newFakeProjectTeam()
+	team.err = errors.New("connection refused")
+
+	c, recorder := policyContext()
+	if requireProjectTeam(c, team.projectID, member, team) {
+		t.Error("requireProjectTeam() allowed access after a failed check")
+	}
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("requireProjectTeam() status = %d, want 500", recorder.Code)
+	}
+
+	c, recorder = policyContext()
+	respondProjectForbidden(c, "Project", team.projectID, member, team, "Only the team lead can do this")
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("respondProjectForbidden() status = %d, want 500", recorder.Code)
+	}
+}
+
+// newPolicyDB returns a fake database in which the caller is on no project's team,
+// except as the lead of every project if isLead is set
+func newPolicyDB(isLead bool) (*sql.DB, *fakesql.Recorder) {
+	db, recorder := fakesql.Open()
+	leads := int64(0)
+	if isLead {
+		leads = 1
+	}
+	recorder.Rows("team_lead_id = $2", []string{"count"}, []driver.Value{leads})
+	recorder.Rows("v.user_id = $2 AND ptm.status = 'active'", []string{"count"}, []driver.Value{int64(0)})
+	return db, recorder
+}
+
+// servePolicyRequest serves one request to handler as callerID, on a route with an :id
+func servePolicyRequest(handler gin.HandlerFunc, callerID uuid.UUID, method, route, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		c.Set("user_email", "caller@example.com")
+		c.Set("user_roles", []string{"volunteer"})
+		handler(c)
+	})
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(method, path, nil))
+	return response
+}
+
+func TestDetailEndpointsHideResourcesFromOutsiders(t *testing.T) {
+	projectID := uuid.New()
+	now := time.Now()
+	projectColumns := []string{
+		"id", "title", "description", "content_json", "required_skills", "location_lat", "location_lng",
+		"location_address", "start_date", "end_date", "project_status", "created_by_admin_id", "team_lead_id",
+		"auto_notify_matches", "max_team_size", "auto_close_applications", "task_digest_minutes",
+		"location_status", "created_at", "updated_at", "deleted_at",
+	}
+	taskColumns := []string{
+		"id", "project_id", "title", "description", "assignee_id", "created_by_id",
+		"status", "priority", "due_date", "labels", "created_at", "updated_at", "recurrence_id",
+	}
+	messageColumns := []string{
+		"id", "project_id", "sender_id", "recipient_user_id", "recipient_team_id", "subject", "message_text",
+		"task_id", "message_type", "message_scope", "created_at", "edited_at", "deleted_at", "scheduled_at",
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		seed     func(*fakesql.Recorder)
+		handler  func(*sql.DB) gin.HandlerFunc
+		method   string
+		route    string
+	}{
+		{
+			name:     "get draft project",
+			resource: "Project",
+			seed: func(r *fakesql.Recorder) {
+				r.Rows("FROM projects WHERE id = $1 AND ($2", projectColumns, []driver.Value{
+					projectID.String(), "Park Cleanup", "Pick up litter", nil, "[]", nil, nil,
+					"", nil, nil, "draft", uuid.New().String(), uuid.New().String(),
+					false, nil, false, nil, "none", now, now, nil,
+				})
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc {
+				return (&ProjectHandler{service: models.NewProjectService(db)}).GetProject
+			},
+			method: http.MethodGet,
+			route:  "/api/projects/:id",
+		},
+		{
+			name:     "get task",
+			resource: "Task",
+			seed: func(r *fakesql.Recorder) {
+				r.Rows("FROM project_tasks WHERE id = $1", taskColumns, taskRow(uuid.New(), projectID, uuid.New(), models.TaskStatusTodo))
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return newPolicyTaskHandler(db).GetTask },
+			method:  http.MethodGet,
+			route:   "/api/tasks/:id",
+		},
+		{
+			name:     "delete task",
+			resource: "Task",
+			seed: func(r *fakesql.Recorder) {
+				r.Rows("FROM project_tasks WHERE id = $1", taskColumns, taskRow(uuid.New(), projectID, uuid.New(), models.TaskStatusTodo))
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc { return newPolicyTaskHandler(db).DeleteTask },
+			method:  http.MethodDelete,
+			route:   "/api/tasks/:id",
+		},
+		{
+			name:     "get message receipts",
+			resource: "Message",
+			seed: func(r *fakesql.Recorder) {
+				r.Rows("FROM project_messages WHERE id = $1", messageColumns, []driver.Value{
+					uuid.New().String(), projectID.String(), uuid.New().String(), nil, nil, nil, "Meet at noon",
+					nil, "general", "project", now, nil, nil, nil,
+				})
+			},
+			handler: func(db *sql.DB) gin.HandlerFunc {
+				return (&MessageHandler{messageService: models.NewMessageService(db), projectService: models.NewProjectService(db)}).GetMessageReceipts
+			},
+			method: http.MethodGet,
+			route:  "/api/messages/:id/receipts",
+		},
+	}
+
+	for _, tt := range tests {
+		db, recorder := newPolicyDB(false)
+		tt.seed(recorder)
+		path := strings.Replace(tt.route, ":id", uuid.New().String(), 1)
+		response := servePolicyRequest(tt.handler(db), uuid.New(), tt.method, tt.route, path)
+		db.Close()
+
+		// Outsiders get exactly the response for a resource that doesn't exist
+		if response.Code != http.StatusNotFound || response.Body.String() != missingBody(tt.resource) {
+			t.Errorf("%s: got %d %s, want 404 %s", tt.name, response.Code, response.Body.String(), missingBody(tt.resource))
+		}
+		if recorder.Ran("DELETE FROM project_tasks") {
+			t.Errorf("%s: an outsider deleted the task", tt.name)
+		}
+	}
+}
+
+func TestGetTaskIsVisibleToTheProjectLead(t *testing.T) {
+	// The lead isn't a volunteer member of their own project
+	db, recorder := newPolicyDB(true)
+	defer db.Close()
+	recorder.Rows("FROM project_tasks WHERE id = $1", []string{
+		"id", "project_id", "title", "description", "assignee_id", "created_by_id",
+		"status", "priority", "due_date", "labels", "created_at", "updated_at", "recurrence_id",
+	}, taskRow(uuid.New(), uuid.New(), uuid.New(), models.TaskStatusTodo))
+
+	response := servePolicyRequest(newPolicyTaskHandler(db).GetTask, uuid.New(),
+		http.MethodGet, "/api/tasks/:id", "/api/tasks/"+uuid.New().String())
+
+	if response.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200: %s", response.Code, response.Body.String())
+	}
+}
+
+func newPolicyTaskHandler(db *sql.DB) *TaskHandler {
+	return &TaskHandler{
+		taskService:      models.NewTaskService(db),
+		projectService:   models.NewProjectService(db),
+		volunteerService: models.NewVolunteerService(db),
+	}
+}
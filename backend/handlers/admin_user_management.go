@@ -1,12 +1,14 @@
 package handlers
 
 import (
-	"log"
+	"database/sql"
 	"net/http"
+	"strconv"
 	"time"
 
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,6 +21,7 @@ type AdminUserManagementHandler struct {
 	volunteerService *models.VolunteerService
 	adminService     *models.AdminService
 	roleService      *models.RoleService
+	auditService     *models.AdminAuditService
 }
 
 // NewAdminUserManagementHandler creates a new AdminUserManagementHandler
@@ -27,12 +30,14 @@ func NewAdminUserManagementHandler(
 	volunteerService *models.VolunteerService,
 	adminService *models.AdminService,
 	roleService *models.RoleService,
+	auditService *models.AdminAuditService,
 ) *AdminUserManagementHandler {
 	return &AdminUserManagementHandler{
 		userService:      userService,
 		volunteerService: volunteerService,
 		adminService:     adminService,
 		roleService:      roleService,
+		auditService:     auditService,
 	}
 }
 
@@ -83,13 +88,13 @@ func (h *AdminUserManagementHandler) DeleteUser(c *gin.Context) {
 	// Check for constraints that would prevent deletion
 	constraintIssues, err := h.checkUserDeletionConstraints(userID)
 	if err != nil {
-		log.Printf("❌ USER_DELETE_CONSTRAINT_CHECK: Failed to check constraints for user %s: %v", userID, err)
+		logging.Errorf(c.Request.Context(), "❌ USER_DELETE_CONSTRAINT_CHECK: Failed to check constraints for user %s: %v", userID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate user deletion constraints"})
 		return
 	}
 
 	if len(constraintIssues) > 0 {
-		log.Printf("❌ USER_DELETE_CONSTRAINTS: User %s has constraint violations: %v", userID, constraintIssues)
+		logging.Errorf(c.Request.Context(), "❌ USER_DELETE_CONSTRAINTS: User %s has constraint violations: %v", userID, constraintIssues)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error":       "Cannot delete user due to existing references",
 			"constraints": constraintIssues,
@@ -98,14 +103,16 @@ func (h *AdminUserManagementHandler) DeleteUser(c *gin.Context) {
 	}
 
 	// Delete user (this will cascade delete related records due to foreign key constraints)
-	log.Printf("🔄 USER_DELETE: Attempting to delete user %s", userID)
+	logging.Printf(c.Request.Context(), "🔄 USER_DELETE: Attempting to delete user %s", userID)
 	if err := h.userService.Delete(userID); err != nil {
-		log.Printf("❌ USER_DELETE: Failed to delete user %s: %v", userID, err)
+		logging.Errorf(c.Request.Context(), "❌ USER_DELETE: Failed to delete user %s: %v", userID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user", "details": err.Error()})
 		return
 	}
 
-	log.Printf("✅ USER_DELETE: Successfully deleted user %s", userID)
+	logging.Printf(c.Request.Context(), "✅ USER_DELETE: Successfully deleted user %s", userID)
+	h.recordAudit(c, userCtx, userID, user.Email, models.AdminAuditActionDeleteUser,
+		map[string]interface{}{"email": user.Email, "email_verified": user.EmailVerified}, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
@@ -119,6 +126,12 @@ func (h *AdminUserManagementHandler) ForceVerificationStatus(c *gin.Context) {
 		return
 	}
 
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
 	var req struct {
 		EmailVerified bool `json:"email_verified" binding:"required"`
 	}
@@ -140,12 +153,17 @@ func (h *AdminUserManagementHandler) ForceVerificationStatus(c *gin.Context) {
 	}
 
 	// Update user verification status
+	wasVerified := user.EmailVerified
 	user.EmailVerified = req.EmailVerified
 	if err := h.userService.Update(user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user verification status"})
 		return
 	}
 
+	h.recordAudit(c, userCtx, userID, user.Email, models.AdminAuditActionSetVerification,
+		map[string]interface{}{"email_verified": wasVerified},
+		map[string]interface{}{"email_verified": req.EmailVerified})
+
 	status := "verified"
 	if !req.EmailVerified {
 		status = "unverified"
@@ -167,6 +185,12 @@ func (h *AdminUserManagementHandler) ChangeUserPassword(c *gin.Context) {
 		return
 	}
 
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
 	var req struct {
 		NewPassword string `json:"new_password" binding:"required,min=8"`
 	}
@@ -202,9 +226,83 @@ func (h *AdminUserManagementHandler) ChangeUserPassword(c *gin.Context) {
 		return
 	}
 
+	// Only the fact that a reset happened is recorded, never the password
+	h.recordAudit(c, userCtx, userID, user.Email, models.AdminAuditActionResetPassword, nil, nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "User password changed successfully"})
 }
 
+// RevokeUserSessions handles POST /api/admin/users/:id/revoke-sessions
+func (h *AdminUserManagementHandler) RevokeUserSessions(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	revoked, err := h.userService.RevokeSessions(userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ USER_REVOKE_SESSIONS: Failed to revoke sessions for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke user sessions"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔒 USER_REVOKE_SESSIONS: Revoked sessions for user %s (%d refresh tokens, %d API tokens)",
+		userID, revoked.RefreshTokens, revoked.APITokens)
+	h.recordAudit(c, userCtx, userID, "", models.AdminAuditActionRevokeSessions, nil, map[string]interface{}{
+		"revoked_refresh_tokens": revoked.RefreshTokens,
+		"revoked_api_tokens":     revoked.APITokens,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":                "User sessions revoked successfully",
+		"revoked_refresh_tokens": revoked.RefreshTokens,
+		"revoked_api_tokens":     revoked.APITokens,
+	})
+}
+
+// UnlockUser handles POST /api/admin/users/:id/unlock
+func (h *AdminUserManagementHandler) UnlockUser(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if err := h.userService.ResetFailedLogins(userID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ USER_UNLOCK: Failed to unlock user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock user"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔓 USER_UNLOCK: Unlocked user %s", userID)
+	h.recordAudit(c, userCtx, userID, "", models.AdminAuditActionUnlockUser, nil, nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
 // GetUserDetails handles GET /api/admin/users/:id
 func (h *AdminUserManagementHandler) GetUserDetails(c *gin.Context) {
 	userIDStr := c.Param("id")
@@ -305,3 +403,65 @@ func (h *AdminUserManagementHandler) checkUserDeletionConstraints(userID uuid.UU
 
 	return constraintIssues, nil
 }
+
+// GetAuditLog handles GET /api/admin/audit-log
+func (h *AdminUserManagementHandler) GetAuditLog(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	var filters models.AdminAuditFilters
+	if actorIDStr := c.Query("actor_id"); actorIDStr != "" {
+		actorID, err := uuid.Parse(actorIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor ID"})
+			return
+		}
+		filters.ActorID = &actorID
+	}
+	if targetIDStr := c.Query("target_user_id"); targetIDStr != "" {
+		targetID, err := uuid.Parse(targetIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target user ID"})
+			return
+		}
+		filters.TargetUserID = &targetID
+	}
+	if action := c.Query("action"); action != "" {
+		filters.Action = &action
+	}
+
+	entries, err := h.auditService.List(filters, limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ ADMIN_AUDIT_LOG: Failed to list audit entries: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// recordAudit stores an audit entry for an admin action that has already been applied.
+// A failure is logged rather than returned since the action itself succeeded.
+func (h *AdminUserManagementHandler) recordAudit(c *gin.Context, actor *middleware.UserContext, targetID uuid.UUID, targetEmail, action string, before, after map[string]interface{}) {
+	entry := &models.AdminAuditEntry{
+		ActorID:      actor.ID,
+		ActorEmail:   actor.Email,
+		TargetUserID: targetID,
+		TargetEmail:  targetEmail,
+		Action:       action,
+	}
+	if err := h.auditService.Record(entry, before, after); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ ADMIN_AUDIT: Failed to record %s by %s on user %s: %v", action, actor.ID, targetID, err)
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAPITokenExpiryDays = 365
+	maxAPITokenExpiryDays     = 3650
+)
+
+// APITokenHandler handles personal access token requests
+type APITokenHandler struct {
+	service *models.APITokenService
+}
+
+// NewAPITokenHandler creates a new API token handler
+func NewAPITokenHandler(service *models.APITokenService) *APITokenHandler {
+	return &APITokenHandler{
+		service: service,
+	}
+}
+
+// CreateAPITokenRequest represents a personal access token request
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes"`          // Role names; defaults to all of the caller's roles
+	ExpiresInDays *int     `json:"expires_in_days"` // Defaults to 365
+}
+
+// CreateAPIToken handles POST /api/me/tokens
+func (h *APITokenHandler) CreateAPIToken(c *gin.Context) {
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// A token must not be able to mint longer-lived tokens for itself
+	if userCtx.AuthMethod == middleware.AuthMethodAPIToken {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API tokens cannot issue other tokens; sign in to create one"})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token name must be between 1 and 100 characters"})
+		return
+	}
+
+	// Scopes reuse the role model: a token can only carry roles its owner holds,
+	// directly or by inheritance
+	scopes := userCtx.Roles
+	if len(req.Scopes) > 0 {
+		seen := make(map[string]bool)
+		scopes = make([]string, 0, len(req.Scopes))
+		for _, scope := range req.Scopes {
+			if !userCtx.HasEffectiveRole(scope) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot grant a role you do not hold", "scope": scope})
+				return
+			}
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	expiresInDays := defaultAPITokenExpiryDays
+	if req.ExpiresInDays != nil {
+		expiresInDays = *req.ExpiresInDays
+	}
+	if expiresInDays < 1 || expiresInDays > maxAPITokenExpiryDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in_days must be between 1 and 3650"})
+		return
+	}
+	expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+
+	rawToken, token, err := h.service.Issue(userCtx.ID, name, scopes, &expiresAt)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_API_TOKEN: Failed to issue token for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create token"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔑 CREATE_API_TOKEN: User %s issued token %s with scopes %v", userCtx.ID, token.ID, scopes)
+	c.JSON(http.StatusCreated, gin.H{
+		"token":   rawToken,
+		"details": token,
+		"message": "Store this token now; it will not be shown again",
+	})
+}
+
+// ListAPITokens handles GET /api/me/tokens
+func (h *APITokenHandler) ListAPITokens(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	tokens, err := h.service.ListByUser(userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_API_TOKENS: Failed to list tokens for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// RevokeAPIToken handles DELETE /api/me/tokens/:id
+func (h *APITokenHandler) RevokeAPIToken(c *gin.Context) {
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if err := h.service.Revoke(tokenID, userCtx.ID); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Token")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ REVOKE_API_TOKEN: Failed to revoke token %s: %v", tokenID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke token"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔑 REVOKE_API_TOKEN: User %s revoked token %s", userCtx.ID, tokenID)
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
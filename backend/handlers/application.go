@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,15 +18,17 @@ import (
 
 // ApplicationHandler handles application-related requests
 type ApplicationHandler struct {
-	service *models.ApplicationService
-	config  *config.Config
+	service  *models.ApplicationService
+	webhooks *services.WebhookDispatcher
+	config   *config.Config
 }
 
 // NewApplicationHandler creates a new application handler
 func NewApplicationHandler(service *models.ApplicationService, config *config.Config) *ApplicationHandler {
 	return &ApplicationHandler{
-		service: service,
-		config:  config,
+		service:  service,
+		webhooks: services.NewWebhookDispatcher(models.NewWebhookService(service.GetDB())),
+		config:   config,
 	}
 }
 
@@ -115,28 +121,122 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		return
 	}
 
-	// Check if application already exists
+	// Only one active application per project, and rejected volunteers wait out the cooldown
 	existing, err := h.service.GetByProjectAndVolunteer(projectID, volunteer.ID)
-	if err == nil && existing != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Application already exists"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing applications"})
+		return
+	}
+	if existing != nil && !h.canReapply(c, existing) {
 		return
 	}
 
 	application := &models.Application{
 		ProjectID:   projectID,
 		VolunteerID: volunteer.ID,
-		Status:      "pending",
+		Status:      models.ApplicationStatusPending,
 		AdminNotes:  req.Message, // Store volunteer message in admin_notes for now
 	}
 
 	if err := h.service.Create(application); err != nil {
+		if errors.Is(err, models.ErrActiveApplicationExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Application already exists", "code": "APPLICATION_EXISTS"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create application"})
 		return
 	}
 
+	h.webhooks.Publish(models.WebhookEventApplicationCreated, gin.H{
+		"application_id": application.ID,
+		"project_id":     application.ProjectID,
+		"volunteer_id":   application.VolunteerID,
+		"status":         application.Status,
+	})
+
 	c.JSON(http.StatusCreated, application)
 }
 
+// canReapply checks whether a volunteer whose latest application for the project is
+// previous may apply again, writing a 409 and returning false if not
+func (h *ApplicationHandler) canReapply(c *gin.Context, previous *models.Application) bool {
+	switch previous.Status {
+	case models.ApplicationStatusWithdrawn:
+		return true
+	case models.ApplicationStatusRejected:
+		cooldown := time.Duration(h.config.Projects.ReapplyCooldownDays) * 24 * time.Hour
+		reapplyAfter := previous.UpdatedAt.Add(cooldown)
+		if cooldown <= 0 || !time.Now().Before(reapplyAfter) {
+			return true
+		}
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "You can re-apply to this project after the cooldown following your rejected application",
+			"code":          "REAPPLY_COOLDOWN",
+			"reapply_after": reapplyAfter,
+		})
+		return false
+	default:
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          "Application already exists",
+			"code":           "APPLICATION_EXISTS",
+			"application_id": previous.ID,
+		})
+		return false
+	}
+}
+
+// WithdrawApplication handles POST /api/applications/:id/withdraw
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	volunteerService := models.NewVolunteerService(h.service.GetDB())
+	volunteer, err := volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile not found"})
+		return
+	}
+
+	existing, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get application"})
+		return
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+		return
+	}
+	if existing.VolunteerID != volunteer.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the applicant can withdraw an application"})
+		return
+	}
+
+	application, err := h.service.Withdraw(id, volunteer.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":  "Only pending applications can be withdrawn",
+				"code":   "APPLICATION_NOT_PENDING",
+				"status": existing.Status,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to withdraw application"})
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
 // GetApplication handles GET /api/applications/:id
 func (h *ApplicationHandler) GetApplication(c *gin.Context) {
 	idStr := c.Param("id")
@@ -177,11 +277,36 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 
 	application.ID = id
 
+	// Accepting enrolls the volunteer, so it is subject to the project's credential requirements
+	var acceptedProjectID *uuid.UUID
+	if application.Status == "accepted" {
+		existing, err := h.service.GetByID(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get application"})
+			return
+		}
+		if existing == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Application not found"})
+			return
+		}
+		if !checkRequiredCredentials(c, h.service.GetDB(), existing.VolunteerID, existing.ProjectID) {
+			return
+		}
+		acceptedProjectID = &existing.ProjectID
+	}
+
 	if err := h.service.Update(&application); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update application"})
 		return
 	}
 
+	// Accepting may have filled the team
+	if acceptedProjectID != nil {
+		if userCtx, exists := middleware.GetUserFromContext(c); exists {
+			closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), *acceptedProjectID, userCtx.ID)
+		}
+	}
+
 	c.JSON(http.StatusOK, application)
 }
 
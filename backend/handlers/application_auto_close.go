@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/google/uuid"
+)
+
+// closeApplicationsIfNeeded rejects a project's pending applications when it has opted
+// in to auto-closing and has left recruiting or filled its team, then lets each
+// applicant know why. senderID is the user whose action closed the applications.
+// Failures are logged rather than returned: the action that triggered the close has
+// already succeeded.
+func closeApplicationsIfNeeded(ctx context.Context, db *sql.DB, projectID, senderID uuid.UUID) {
+	projectService := models.NewProjectService(db)
+	project, err := projectService.GetByID(projectID)
+	if err != nil || project == nil {
+		logging.Errorf(ctx, "❌ AUTO_CLOSE_APPLICATIONS: Failed to get project %s: %v", projectID, err)
+		return
+	}
+
+	reason, err := projectService.ApplicationCloseReason(project)
+	if err != nil {
+		logging.Errorf(ctx, "❌ AUTO_CLOSE_APPLICATIONS: Failed to check project %s: %v", projectID, err)
+		return
+	}
+	if reason == "" {
+		return
+	}
+
+	applicationService := models.NewApplicationService(db)
+	closed, err := applicationService.BulkUpdateStatusForProject(projectID, "pending", "rejected",
+		fmt.Sprintf("Automatically closed because %s", reason))
+	if err != nil {
+		logging.Errorf(ctx, "❌ AUTO_CLOSE_APPLICATIONS: Failed to close applications for project %s: %v", projectID, err)
+		return
+	}
+	if len(closed) == 0 {
+		return
+	}
+	logging.Printf(ctx, "📪 AUTO_CLOSE_APPLICATIONS: Closed %d pending applications for project %s because %s", len(closed), projectID, reason)
+
+	volunteerService := models.NewVolunteerService(db)
+	messageService := models.NewMessageService(db)
+	subject := fmt.Sprintf("Update on your application to %s", project.Title)
+	messageText := fmt.Sprintf("Thank you for applying to %s. We're no longer accepting applications because %s, "+
+		"so we weren't able to move yours forward this time. We really appreciate your interest "+
+		"and hope you'll find another project to join soon.", project.Title, reason)
+
+	for _, application := range closed {
+		volunteer, err := volunteerService.GetByID(application.VolunteerID)
+		if err != nil || volunteer == nil {
+			logging.Warnf(ctx, "⚠️ AUTO_CLOSE_APPLICATIONS: Failed to find volunteer %s to notify: %v", application.VolunteerID, err)
+			continue
+		}
+
+		recipientID := volunteer.UserID
+		message := &models.ProjectMessage{
+			ProjectID:       &project.ID,
+			SenderID:        senderID,
+			RecipientUserID: &recipientID,
+			Subject:         &subject,
+			MessageText:     messageText,
+		}
+		if err := messageService.CreateUniversalMessage(message); err != nil {
+			logging.Warnf(ctx, "⚠️ AUTO_CLOSE_APPLICATIONS: Failed to notify volunteer %s: %v", application.VolunteerID, err)
+		}
+	}
+}
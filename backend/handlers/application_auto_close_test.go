@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+// autoCloseFixture is a fake database holding a recruiting project that auto-closes
+// applications once its team of two fills, with two pending applicants
+type autoCloseFixture struct {
+	db         *sql.DB
+	recorder   *fakesql.Recorder
+	projectID  uuid.UUID
+	applicants []uuid.UUID // The applicants' user IDs
+}
+
+func newAutoCloseFixture(activeMembers int64) (*autoCloseFixture, func()) {
+	db, recorder := fakesql.Open()
+	fixture := &autoCloseFixture{db: db, recorder: recorder, projectID: uuid.New()}
+	now := time.Now()
+
+	recorder.Rows("FROM projects WHERE id = $1 AND ($2", []string{
+		"id", "title", "description", "content_json", "required_skills", "location_lat", "location_lng",
+		"location_address", "start_date", "end_date", "project_status", "created_by_admin_id", "team_lead_id",
+		"auto_notify_matches", "max_team_size", "auto_close_applications", "task_digest_minutes",
+		"location_status", "created_at", "updated_at", "deleted_at",
+	}, []driver.Value{
+		fixture.projectID.String(), "Park Cleanup", "Pick up litter", nil, "[]", nil, nil,
+		"", nil, nil, "recruiting", uuid.New().String(), uuid.New().String(),
+		false, int64(2), true, nil,
+		"none", now, now, nil,
+	})
+	recorder.Rows("WHERE project_id = $1 AND status = 'active'", []string{"count"}, []driver.Value{activeMembers})
+
+	var closed [][]driver.Value
+	for i := 0; i < 2; i++ {
+		volunteerID, userID := uuid.New(), uuid.New()
+		fixture.applicants = append(fixture.applicants, userID)
+		closed = append(closed, []driver.Value{
+			uuid.New().String(), volunteerID.String(), fixture.projectID.String(), "rejected", now, now, "Automatically closed",
+		})
+		recorder.RowsOnce("FROM volunteers WHERE id = $1", []string{
+			"id", "user_id", "name", "phone", "location_lat", "location_lng",
+			"location_address", "skills", "availability", "skills_visible", "consent_given", "created_at", "updated_at",
+		}, volunteerRow(volunteerID, userID))
+	}
+	recorder.Rows("UPDATE applications", []string{
+		"id", "volunteer_id", "project_id", "status", "applied_at", "updated_at", "admin_notes",
+	}, closed...)
+	recorder.Rows("INSERT INTO project_messages", []string{"created_at"}, []driver.Value{now})
+
+	return fixture, func() { db.Close() }
+}
+
+func TestFillingTheTeamRejectsPendingApplications(t *testing.T) {
+	fixture, closeDB := newAutoCloseFixture(2)
+	defer closeDB()
+
+	closeApplicationsIfNeeded(context.Background(), fixture.db, fixture.projectID, uuid.New())
+
+	var rejected *fakesql.Statement
+	var notified []driver.Value
+	for _, statement := range fixture.recorder.Statements() {
+		statement := statement
+		switch {
+		case strings.Contains(statement.Query, "UPDATE applications"):
+			rejected = &statement
+		case strings.Contains(statement.Query, "INSERT INTO project_messages"):
+			notified = append(notified, statement.Args[3])
+		}
+	}
+	if rejected == nil {
+		t.Fatal("pending applications were not closed")
+	}
+	if rejected.Args[0] != fixture.projectID.String() || rejected.Args[1] != "pending" || rejected.Args[2] != "rejected" {
+		t.Errorf("close args = %v, want the project's pending applications rejected", rejected.Args)
+	}
+	if notes, _ := rejected.Args[3].(string); !strings.Contains(notes, "the team is now full") {
+		t.Errorf("admin notes = %q, want the reason", notes)
+	}
+	if len(notified) != 2 || notified[0] != fixture.applicants[0].String() || notified[1] != fixture.applicants[1].String() {
+		t.Errorf("notified %v, want both applicants %v", notified, fixture.applicants)
+	}
+}
+
+func TestApplicationsStayOpenWhileTheTeamHasRoom(t *testing.T) {
+	fixture, closeDB := newAutoCloseFixture(1)
+	defer closeDB()
+
+	closeApplicationsIfNeeded(context.Background(), fixture.db, fixture.projectID, uuid.New())
+
+	if fixture.recorder.Ran("UPDATE applications") {
+		t.Error("applications were closed while the team had room")
+	}
+}
@@ -2,18 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
-	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 	"civicweave/backend/services"
 	"civicweave/backend/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -24,6 +26,9 @@ type AuthHandler struct {
 	AdminService        *models.AdminService
 	OAuthAccountService *models.OAuthAccountService
 	RoleService         *models.RoleService
+	PasswordResetTokens *models.PasswordResetTokenService
+	EmailVerifications  *models.EmailVerificationTokenService
+	RefreshTokens       *models.RefreshTokenService
 	EmailService        *services.EmailService
 	GeocodingService    *utils.GeocodingService
 	config              *config.Config
@@ -36,6 +41,9 @@ func NewAuthHandler(
 	adminService *models.AdminService,
 	oauthAccountService *models.OAuthAccountService,
 	roleService *models.RoleService,
+	passwordResetTokens *models.PasswordResetTokenService,
+	emailVerifications *models.EmailVerificationTokenService,
+	refreshTokens *models.RefreshTokenService,
 	emailService *services.EmailService,
 	geocodingService *utils.GeocodingService,
 	config *config.Config,
@@ -46,6 +54,9 @@ func NewAuthHandler(
 		AdminService:        adminService,
 		OAuthAccountService: oauthAccountService,
 		RoleService:         roleService,
+		PasswordResetTokens: passwordResetTokens,
+		EmailVerifications:  emailVerifications,
+		RefreshTokens:       refreshTokens,
 		EmailService:        emailService,
 		GeocodingService:    geocodingService,
 		config:              config,
@@ -84,7 +95,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// Check if user already exists
 	existingUser, err := h.UserService.GetByEmail(req.Email)
 	if err != nil {
-		log.Printf("❌ REGISTER_DB_ERROR: Failed to check existing user for email %s: %v", req.Email, err)
+		logging.Errorf(c.Request.Context(), "❌ REGISTER_DB_ERROR: Failed to check existing user for email %s: %v", req.Email, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 		return
 	}
@@ -108,7 +119,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	if err := h.UserService.Create(user); err != nil {
-		log.Printf("❌ REGISTER_USER_CREATE_ERROR: Failed to create user %s: %v", req.Email, err)
+		logging.Errorf(c.Request.Context(), "❌ REGISTER_USER_CREATE_ERROR: Failed to create user %s: %v", req.Email, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
 		return
 	}
@@ -166,14 +177,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	if err := h.VolunteerService.Create(volunteer); err != nil {
 		// Log the actual error for debugging
-		log.Printf("❌ VOLUNTEER_CREATE_ERROR: %v", err)
-		log.Printf("❌ VOLUNTEER_CREATE_ERROR: Volunteer data: %+v", volunteer)
+		logging.Errorf(c.Request.Context(), "❌ VOLUNTEER_CREATE_ERROR: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ VOLUNTEER_CREATE_ERROR: Volunteer data: %+v", volunteer)
 
 		// Rollback: Delete the user we just created
 		if userCreated {
-			log.Printf("⚠️  Registration failed at volunteer creation, rolling back user: %s", user.Email)
+			logging.Warnf(c.Request.Context(), "⚠️  Registration failed at volunteer creation, rolling back user: %s", user.Email)
 			if deleteErr := h.UserService.Delete(user.ID); deleteErr != nil {
-				log.Printf("❌ Failed to rollback user creation: %v", deleteErr)
+				logging.Errorf(c.Request.Context(), "❌ Failed to rollback user creation: %v", deleteErr)
 			}
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create volunteer profile", "details": err.Error()})
@@ -188,13 +199,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		skillIDs, err := taxonomyService.ResolveSkillNames(req.SelectedSkills)
 		if err != nil {
 			// Log error but don't fail registration - skills can be added later
-			log.Printf("Failed to resolve skill names during registration: %v", err)
+			logging.Printf(c.Request.Context(), "Failed to resolve skill names during registration: %v", err)
 		} else {
 			// Add skills to volunteer with default weight 0.5
 			err = taxonomyService.AddVolunteerSkills(volunteer.ID, skillIDs)
 			if err != nil {
 				// Log error but don't fail registration
-				log.Printf("Failed to add skills during registration: %v", err)
+				logging.Printf(c.Request.Context(), "Failed to add skills during registration: %v", err)
 			}
 		}
 	}
@@ -202,14 +213,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// Generate verification token and send email (if enabled)
 	message := "User registered successfully."
 	if h.config.Features.EmailEnabled {
-		verificationToken := utils.GenerateRandomToken()
-		if err := h.createEmailVerificationToken(user.ID, verificationToken); err != nil {
-			log.Printf("Warning: Failed to create verification token: %v", err)
+		verificationToken, err := h.EmailVerifications.Issue(user.ID, nil, emailVerificationExpiry)
+		if err != nil {
+			logging.Printf(c.Request.Context(), "Warning: Failed to create verification token: %v", err)
 			// Don't fail registration, just log the error
 		} else {
 			// Send verification email
 			if err := h.EmailService.SendVerificationEmail(user.Email, verificationToken); err != nil {
-				log.Printf("Warning: Failed to send verification email: %v", err)
+				logging.Printf(c.Request.Context(), "Warning: Failed to send verification email: %v", err)
 				// Don't fail registration, just log the error
 			} else {
 				message = "User registered successfully. Please check your email for verification."
@@ -231,123 +242,156 @@ type LoginRequest struct {
 
 // AuthResponse represents authentication response
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  interface{} `json:"user"`
+	Token        string      `json:"token"`
+	RefreshToken string      `json:"refresh_token,omitempty"`
+	User         interface{} `json:"user"`
 }
 
 // Login handles user login
 func (h *AuthHandler) Login(c *gin.Context) {
 	// Only log in development mode
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("🔐 LOGIN: Starting login attempt")
+		logging.Printf(c.Request.Context(), "🔐 LOGIN: Starting login attempt")
 	}
 
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: Failed to bind JSON: %v", err)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to bind JSON: %v", err)
 		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("📧 LOGIN: Email: %s", req.Email)
-		log.Printf("🔑 LOGIN: Password length: %d", len(req.Password))
-		log.Printf("🔑 LOGIN: Password (masked): %s", maskPassword(req.Password))
+		logging.Printf(c.Request.Context(), "📧 LOGIN: Email: %s", req.Email)
+		logging.Printf(c.Request.Context(), "🔑 LOGIN: Password length: %d", len(req.Password))
+		logging.Printf(c.Request.Context(), "🔑 LOGIN: Password (masked): %s", maskPassword(req.Password))
 	}
 
 	// Get user by email
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("🔍 LOGIN: Looking up user by email...")
+		logging.Printf(c.Request.Context(), "🔍 LOGIN: Looking up user by email...")
 	}
 	user, err := h.UserService.GetByEmail(req.Email)
 	if err != nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: Database error: %v", err)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Database error: %v", err)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 		return
 	}
 	if user == nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: User not found for email: %s", req.Email)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: User not found for email: %s", req.Email)
 		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("✅ LOGIN: User found - ID: %s, EmailVerified: %v", user.ID, user.EmailVerified)
-		log.Printf("🔑 LOGIN: Password hash present: %v", len(user.PasswordHash) > 0)
+		logging.Printf(c.Request.Context(), "✅ LOGIN: User found - ID: %s, EmailVerified: %v", user.ID, user.EmailVerified)
+		logging.Printf(c.Request.Context(), "🔑 LOGIN: Password hash present: %v", len(user.PasswordHash) > 0)
 	}
 
 	// Check if user has a password (not OAuth-only user)
 	if user.PasswordHash == "" {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: User has no password (OAuth-only user). Use Google Sign-In instead.")
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: User has no password (OAuth-only user). Use Google Sign-In instead.")
 		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "This account uses Google Sign-In. Please use the Google Sign-In button."})
 		return
 	}
 
+	// Refuse locked accounts, even with the right password
+	lockedUntil, err := h.UserService.GetLockedUntil(user.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to check lockout for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if lockedUntil != nil {
+		respondAccountLocked(c, *lockedUntil)
+		return
+	}
+
 	// Check password
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("🔍 LOGIN: Comparing passwords...")
+		logging.Printf(c.Request.Context(), "🔍 LOGIN: Comparing passwords...")
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: Password comparison failed: %v", err)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Password comparison failed: %v", err)
+		}
+		lockedUntil, err := h.UserService.RecordFailedLogin(user.ID, h.config.Auth.MaxFailedLogins, h.config.Auth.LockoutDuration)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to record failed login for user %s: %v", user.ID, err)
+		}
+		if lockedUntil != nil {
+			logging.Printf(c.Request.Context(), "🔒 LOGIN: Locked user %s until %s after %d failed logins", user.ID, lockedUntil.Format(time.RFC3339), h.config.Auth.MaxFailedLogins)
+			respondAccountLocked(c, *lockedUntil)
+			return
 		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("✅ LOGIN: Password comparison successful")
+		logging.Printf(c.Request.Context(), "✅ LOGIN: Password comparison successful")
+	}
+	if err := h.UserService.ResetFailedLogins(user.ID); err != nil {
+		logging.Warnf(c.Request.Context(), "⚠️  LOGIN: Failed to reset failed logins for user %s: %v", user.ID, err)
 	}
 
 	// Check if email is verified (skip check if email system is disabled)
 	if !user.EmailVerified && h.config.Features.EmailEnabled {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: Email not verified for user: %s", user.Email)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Email not verified for user: %s", user.Email)
 		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Please verify your email before logging in"})
 		return
 	}
 	if gin.Mode() == gin.DebugMode {
 		if h.config.Features.EmailEnabled {
-			log.Printf("✅ LOGIN: Email verified")
+			logging.Printf(c.Request.Context(), "✅ LOGIN: Email verified")
 		} else {
-			log.Printf("⚠️  LOGIN: Email verification skipped (email system disabled)")
+			logging.Warnf(c.Request.Context(), "⚠️  LOGIN: Email verification skipped (email system disabled)")
 		}
 	}
 
 	// Generate JWT token
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("🎫 LOGIN: Generating JWT token...")
+		logging.Printf(c.Request.Context(), "🎫 LOGIN: Generating JWT token...")
 	}
-	token, err := middleware.GenerateJWT(user, h.UserService, h.config.JWT.Secret)
+	token, err := middleware.GenerateJWT(user, h.UserService, h.config.JWT)
 	if err != nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("❌ LOGIN: Failed to generate JWT token: %v", err)
+			logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to generate JWT token: %v", err)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("✅ LOGIN: JWT token generated successfully")
+		logging.Printf(c.Request.Context(), "✅ LOGIN: JWT token generated successfully")
+	}
+
+	// Issue a refresh token so the client can renew the access token
+	refreshToken, err := h.RefreshTokens.Issue(user.ID, h.config.JWT.RefreshExpiry)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to issue refresh token for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
 	}
 
 	// Get user profile based on role
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("👤 LOGIN: Getting user profile")
+		logging.Printf(c.Request.Context(), "👤 LOGIN: Getting user profile")
 	}
 
 	// Get user roles
 	rolesData, err := h.UserService.GetUserRoles(user.ID)
 	if err != nil {
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("⚠️  LOGIN: Failed to get user roles: %v", err)
+			logging.Warnf(c.Request.Context(), "⚠️  LOGIN: Failed to get user roles: %v", err)
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
 		return
@@ -372,7 +416,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		volunteer, err := h.VolunteerService.GetByUserID(user.ID)
 		if err != nil {
 			if gin.Mode() == gin.DebugMode {
-				log.Printf("❌ LOGIN: Failed to get volunteer profile: %v", err)
+				logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to get volunteer profile: %v", err)
 			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get volunteer profile"})
 			return
@@ -387,13 +431,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"roles":            roles,
 		}
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("✅ LOGIN: Volunteer profile retrieved")
+			logging.Printf(c.Request.Context(), "✅ LOGIN: Volunteer profile retrieved")
 		}
 	} else if hasAdminRole {
 		admin, err := h.AdminService.GetByUserID(user.ID)
 		if err != nil {
 			if gin.Mode() == gin.DebugMode {
-				log.Printf("❌ LOGIN: Failed to get admin profile: %v", err)
+				logging.Errorf(c.Request.Context(), "❌ LOGIN: Failed to get admin profile: %v", err)
 			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get admin profile"})
 			return
@@ -406,16 +450,30 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"roles":      roles,
 		}
 		if gin.Mode() == gin.DebugMode {
-			log.Printf("✅ LOGIN: Admin profile retrieved")
+			logging.Printf(c.Request.Context(), "✅ LOGIN: Admin profile retrieved")
 		}
 	}
 
 	if gin.Mode() == gin.DebugMode {
-		log.Printf("🎉 LOGIN: Login successful for user: %s", user.Email)
+		logging.Printf(c.Request.Context(), "🎉 LOGIN: Login successful for user: %s", user.Email)
 	}
 	c.JSON(http.StatusOK, AuthResponse{
-		Token: token,
-		User:  userProfile,
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         userProfile,
+	})
+}
+
+// respondAccountLocked tells the client the account is locked and when to retry
+func respondAccountLocked(c *gin.Context, lockedUntil time.Time) {
+	retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusLocked, gin.H{
+		"error":        "Account temporarily locked after too many failed login attempts. Try again later or reset your password.",
+		"locked_until": lockedUntil,
 	})
 }
 
@@ -449,11 +507,32 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	}
 
 	// Verify token and mark email as verified
-	userID, err := h.verifyEmailToken(req.Token)
+	token, err := h.EmailVerifications.Consume(req.Token)
 	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ VERIFY_EMAIL: Failed to verify token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+		return
+	}
+	if token == nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired verification token"})
 		return
 	}
+	userID := token.UserID
+
+	// Tokens carrying an address confirm an email change made through PUT /me
+	if token.Email != nil {
+		if err := h.UserService.ConfirmEmailChange(userID, *token.Email); err != nil {
+			if err == models.ErrEmailChangeNotPending {
+				c.JSON(http.StatusConflict, gin.H{"error": "This email change is no longer pending"})
+				return
+			}
+			logging.Errorf(c.Request.Context(), "❌ VERIFY_EMAIL: Failed to change email for user %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Email address updated successfully", "email": *token.Email})
+		return
+	}
 
 	// Mark email as verified
 	if err := h.UserService.VerifyEmail(userID); err != nil {
@@ -475,8 +554,10 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	}
 
 	// Send welcome email
-	if err := h.EmailService.SendWelcomeEmail(user.Email, volunteer.Name); err != nil {
-		// Log error but don't fail verification
+	if volunteer != nil {
+		if err := h.EmailService.SendWelcomeEmail(user.Email, volunteer.Name); err != nil {
+			// Log error but don't fail verification
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
@@ -490,6 +571,17 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
+	userProfile, err := h.profileFor(userCtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, userProfile)
+}
+
+// profileFor builds the profile returned for a user based on their roles
+func (h *AuthHandler) profileFor(userCtx *middleware.UserContext) (map[string]interface{}, error) {
 	// Get user roles
 	rolesData, err := h.UserService.GetUserRoles(userCtx.ID)
 	if err != nil {
@@ -508,8 +600,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	if userCtx.HasRole("volunteer") {
 		volunteer, err := h.VolunteerService.GetByUserID(userCtx.ID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
-			return
+			return nil, err
 		}
 		userProfile = map[string]interface{}{
 			"id":               volunteer.ID,
@@ -523,8 +614,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	} else if userCtx.HasRole("admin") {
 		admin, err := h.AdminService.GetByUserID(userCtx.ID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
-			return
+			return nil, err
 		}
 		userProfile = map[string]interface{}{
 			"id":         admin.ID,
@@ -535,18 +625,134 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, userProfile)
+	if userProfile != nil {
+		linkedProviders, err := h.OAuthAccountService.LinkedProviders(userCtx.ID)
+		if err != nil {
+			return nil, err
+		}
+		userProfile["linked_providers"] = linkedProviders
+	}
+
+	return userProfile, nil
+}
+
+// PrivacyPreferencesRequest represents a privacy preferences update
+type PrivacyPreferencesRequest struct {
+	HideReadReceipts *bool `json:"hide_read_receipts" binding:"required"`
+}
+
+// GetPrivacyPreferences handles GET /api/me/privacy-preferences
+func (h *AuthHandler) GetPrivacyPreferences(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	hidden, err := h.UserService.GetHideReadReceipts(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get privacy preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"hide_read_receipts": hidden})
 }
 
-// Helper methods for email verification
-func (h *AuthHandler) createEmailVerificationToken(userID uuid.UUID, token string) error {
-	// TODO: Implement database storage for verification tokens
-	// For now, just return nil
-	return nil
+// UpdatePrivacyPreferences handles PUT /api/me/privacy-preferences
+// Hiding read receipts keeps senders from seeing when the user read their
+// messages; they can still see that a message was read.
+func (h *AuthHandler) UpdatePrivacyPreferences(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	var req PrivacyPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.UserService.SetHideReadReceipts(userCtx.ID, *req.HideReadReceipts); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update privacy preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":            "Privacy preferences updated successfully",
+		"hide_read_receipts": *req.HideReadReceipts,
+	})
+}
+
+// NotificationPreferencesRequest represents a notification preferences update
+type NotificationPreferencesRequest struct {
+	EmailNotifications *bool `json:"email_notifications" binding:"required"`
+	TaskDigestMinutes  *int  `json:"task_digest_minutes"` // 0 posts every task notification on its own
+}
+
+// GetNotificationPreferences handles GET /api/me/notification-preferences
+func (h *AuthHandler) GetNotificationPreferences(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	enabled, err := h.UserService.GetEmailNotifications(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notification preferences"})
+		return
+	}
+
+	// Nil when the user follows each project's digest window
+	digestMinutes, err := h.UserService.GetTaskDigestMinutes(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"email_notifications": enabled,
+		"task_digest_minutes": digestMinutes,
+	})
 }
 
-func (h *AuthHandler) verifyEmailToken(token string) (uuid.UUID, error) {
-	// TODO: Implement token verification from database
-	// For now, return a placeholder
-	return uuid.New(), nil
+// UpdateNotificationPreferences handles PUT /api/me/notification-preferences
+func (h *AuthHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	var req NotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.TaskDigestMinutes != nil && (*req.TaskDigestMinutes < 0 || *req.TaskDigestMinutes > models.MaxTaskDigestMinutes) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task_digest_minutes must be between 0 and 1440"})
+		return
+	}
+
+	if err := h.UserService.SetEmailNotifications(userCtx.ID, *req.EmailNotifications); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification preferences"})
+		return
+	}
+
+	response := gin.H{
+		"message":             "Notification preferences updated successfully",
+		"email_notifications": *req.EmailNotifications,
+	}
+	if req.TaskDigestMinutes != nil {
+		if err := h.UserService.SetTaskDigestMinutes(userCtx.ID, *req.TaskDigestMinutes); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification preferences"})
+			return
+		}
+		response["task_digest_minutes"] = *req.TaskDigestMinutes
+	}
+
+	c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RefreshRequest represents a request to exchange or revoke a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Refresh handles POST /api/auth/refresh. The presented refresh token is revoked and
+// a replacement is returned with the new access token.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, refreshToken, err := h.RefreshTokens.Rotate(req.RefreshToken, h.config.JWT.RefreshExpiry)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REFRESH_TOKEN: Failed to rotate refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	if userID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+
+	// A locked account can't renew its access token any more than it can log in
+	lockedUntil, err := h.UserService.GetLockedUntil(*userID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REFRESH_TOKEN: Failed to check lockout for user %s: %v", *userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if lockedUntil != nil {
+		respondAccountLocked(c, *lockedUntil)
+		return
+	}
+
+	user, err := h.UserService.GetByID(*userID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REFRESH_TOKEN: Failed to get user %s: %v", *userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	}
+
+	token, err := middleware.GenerateJWT(user, h.UserService, h.config.JWT)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REFRESH_TOKEN: Failed to generate JWT for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
+
+// Logout handles POST /api/auth/logout. Only the presented refresh token is revoked,
+// so other devices stay signed in and this device's access token lapses within minutes.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.RefreshTokens.Revoke(req.RefreshToken); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LOGOUT: Failed to revoke refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
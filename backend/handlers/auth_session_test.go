@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func serveAuthSession(h *AuthHandler, path string, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST(path, handler)
+
+	recorder := httptest.NewRecorder()
+	body := strings.NewReader(`{"refresh_token":"presented"}`)
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, body))
+	return recorder
+}
+
+func TestLogoutRevokesOnlyThePresentedRefreshToken(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("RETURNING user_id", []string{"user_id"}, []driver.Value{uuid.New().String()})
+
+	h := &AuthHandler{UserService: models.NewUserService(db), RefreshTokens: models.NewRefreshTokenService(db)}
+	response := serveAuthSession(h, "/api/auth/logout", h.Logout)
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", response.Code)
+	}
+	statements := recorder.Statements()
+	if len(statements) != 1 || !strings.Contains(statements[0].Query, "WHERE token_hash = $1") {
+		t.Errorf("statements = %+v, want only the presented token revoked", statements)
+	}
+}
+
+func TestRefreshRefusesLockedAccounts(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("RETURNING user_id", []string{"user_id"}, []driver.Value{uuid.New().String()})
+	recorder.Rows("SELECT locked_until", []string{"locked_until"}, []driver.Value{time.Now().Add(10 * time.Minute)})
+
+	h := &AuthHandler{
+		UserService:   models.NewUserService(db),
+		RefreshTokens: models.NewRefreshTokenService(db),
+		config:        &config.Config{},
+	}
+	response := serveAuthSession(h, "/api/auth/refresh", h.Refresh)
+
+	if response.Code != http.StatusLocked {
+		t.Fatalf("status = %d, want 423", response.Code)
+	}
+	if strings.Contains(response.Body.String(), "refresh_token") {
+		t.Errorf("locked account was given a refresh token: %s", response.Body.String())
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AvailabilityHandler handles volunteer availability endpoints
+type AvailabilityHandler struct {
+	service          *models.VolunteerAvailabilityService
+	volunteerService *models.VolunteerService
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(service *models.VolunteerAvailabilityService, volunteerService *models.VolunteerService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		service:          service,
+		volunteerService: volunteerService,
+	}
+}
+
+// AvailabilitySlotRequest represents one availability slot. Weekly slots set
+// day_of_week (0 = Sunday), start_time and end_time as HH:MM; date ranges set
+// start_date and end_date as YYYY-MM-DD.
+type AvailabilitySlotRequest struct {
+	Kind      models.AvailabilityKind `json:"kind" binding:"required"`
+	DayOfWeek *int                    `json:"day_of_week"`
+	StartTime *string                 `json:"start_time"`
+	EndTime   *string                 `json:"end_time"`
+	StartDate *string                 `json:"start_date"`
+	EndDate   *string                 `json:"end_date"`
+	Note      *string                 `json:"note"`
+}
+
+// ReplaceAvailabilityRequest represents a volunteer's full set of availability slots
+type ReplaceAvailabilityRequest struct {
+	Slots []AvailabilitySlotRequest `json:"slots" binding:"dive"`
+}
+
+// GetMyAvailability handles GET /api/volunteers/me/availability
+func (h *AvailabilityHandler) GetMyAvailability(c *gin.Context) {
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	slots, err := h.service.ListByVolunteer(volunteer.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_AVAILABILITY: Failed to list availability for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"availability":     slots,
+		"always_available": len(slots) == 0,
+	})
+}
+
+// AddAvailability handles POST /api/volunteers/me/availability
+func (h *AvailabilityHandler) AddAvailability(c *gin.Context) {
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	var req AvailabilitySlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot, err := req.toSlot(volunteer.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Create(slot); err != nil {
+		if errors.Is(err, models.ErrTooManyAvailabilitySlots) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ ADD_AVAILABILITY: Failed to add availability for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add availability"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, slot)
+}
+
+// ReplaceMyAvailability handles PUT /api/volunteers/me/availability
+func (h *AvailabilityHandler) ReplaceMyAvailability(c *gin.Context) {
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	var req ReplaceAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slots := make([]models.VolunteerAvailability, 0, len(req.Slots))
+	for _, slotReq := range req.Slots {
+		slot, err := slotReq.toSlot(volunteer.ID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		slots = append(slots, *slot)
+	}
+
+	if err := h.service.Replace(volunteer.ID, slots); err != nil {
+		if errors.Is(err, models.ErrTooManyAvailabilitySlots) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ REPLACE_AVAILABILITY: Failed to replace availability for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"availability":     slots,
+		"always_available": len(slots) == 0,
+	})
+}
+
+// DeleteAvailability handles DELETE /api/volunteers/me/availability/:id
+func (h *AvailabilityHandler) DeleteAvailability(c *gin.Context) {
+	slotID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid availability ID"})
+		return
+	}
+
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(slotID, volunteer.ID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Availability slot not found"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ DELETE_AVAILABILITY: Failed to delete availability %s: %v", slotID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete availability"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability slot deleted"})
+}
+
+// toSlot parses and validates a slot request
+func (r *AvailabilitySlotRequest) toSlot(volunteerID uuid.UUID) (*models.VolunteerAvailability, error) {
+	slot := &models.VolunteerAvailability{
+		VolunteerID: volunteerID,
+		Kind:        r.Kind,
+		DayOfWeek:   r.DayOfWeek,
+		StartTime:   r.StartTime,
+		EndTime:     r.EndTime,
+		Note:        r.Note,
+	}
+
+	if r.Kind == models.AvailabilityDateRange {
+		if r.StartDate == nil || r.EndDate == nil {
+			return nil, models.ErrInvalidAvailabilityDates
+		}
+		startDate, err := time.Parse("2006-01-02", *r.StartDate)
+		if err != nil {
+			return nil, models.ErrInvalidAvailabilityDates
+		}
+		endDate, err := time.Parse("2006-01-02", *r.EndDate)
+		if err != nil {
+			return nil, models.ErrInvalidAvailabilityDates
+		}
+		slot.StartDate, slot.EndDate = &startDate, &endDate
+	}
+
+	if err := slot.Validate(); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+// currentVolunteer loads the volunteer profile of the authenticated user
+func (h *AvailabilityHandler) currentVolunteer(c *gin.Context) (*models.Volunteer, bool) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return nil, false
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile not found"})
+		return nil, false
+	}
+
+	return volunteer, true
+}
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +9,7 @@ import (
 
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -26,22 +27,31 @@ func NewBroadcastHandler(service *models.BroadcastService) *BroadcastHandler {
 	}
 }
 
-// CreateBroadcastRequest represents broadcast creation request
+// CreateBroadcastRequest represents broadcast creation request. The target is either
+// TargetType with TargetIDs, or one of the original fixed TargetAudience values.
 type CreateBroadcastRequest struct {
-	Title          string     `json:"title" binding:"required"`
-	Content        string     `json:"content" binding:"required"`
-	TargetAudience string     `json:"target_audience" binding:"required"`
-	Priority       string     `json:"priority"`
-	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Title          string      `json:"title" binding:"required"`
+	Content        string      `json:"content" binding:"required"`
+	TargetAudience string      `json:"target_audience"`
+	TargetType     string      `json:"target_type"`
+	TargetIDs      []uuid.UUID `json:"target_ids"`
+	Priority       string      `json:"priority"`
+	ExpiresAt      *time.Time  `json:"expires_at,omitempty"`
+	// AcknowledgmentRequired asks recipients to explicitly acknowledge, e.g. for policy changes
+	AcknowledgmentRequired bool `json:"acknowledgment_required"`
 }
 
 // UpdateBroadcastRequest represents broadcast update request
 type UpdateBroadcastRequest struct {
-	Title          string     `json:"title"`
-	Content        string     `json:"content"`
-	TargetAudience string     `json:"target_audience"`
-	Priority       string     `json:"priority"`
-	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Title          string      `json:"title"`
+	Content        string      `json:"content"`
+	TargetAudience string      `json:"target_audience"`
+	TargetType     string      `json:"target_type"`
+	TargetIDs      []uuid.UUID `json:"target_ids"`
+	Priority       string      `json:"priority"`
+	ExpiresAt      *time.Time  `json:"expires_at,omitempty"`
+	// AcknowledgmentRequired is left unchanged when omitted
+	AcknowledgmentRequired *bool `json:"acknowledgment_required,omitempty"`
 }
 
 // ListBroadcasts handles GET /api/broadcasts
@@ -67,37 +77,24 @@ func (h *BroadcastHandler) ListBroadcasts(c *gin.Context) {
 		offset = 0
 	}
 
-	// Get user primary role (use the first role or default to volunteer)
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
-		return
-	}
-
-	userRole := "volunteer" // default
-	if len(roles) > 0 {
-		userRole = roles[0].Name
-	}
-
-	// Get broadcasts
-	broadcasts, err := h.service.List(userCtx.ID, userRole, limit, offset)
+	// Get broadcasts targeted at any of the user's roles, including inherited ones
+	broadcasts, err := h.service.List(userCtx.ID, middleware.EffectiveRoles(userCtx.Roles), limit, offset)
 	if err != nil {
 		// Check if the error is due to missing table
 		if strings.Contains(err.Error(), "does not exist") {
-			log.Printf("⚠️ LIST_BROADCASTS: Broadcast table not found, returning empty list")
+			logging.Warnf(c.Request.Context(), "⚠️ LIST_BROADCASTS: Broadcast table not found, returning empty list")
 			c.JSON(http.StatusOK, gin.H{
 				"broadcasts": []interface{}{},
 				"count":      0,
 			})
 			return
 		}
-		log.Printf("❌ LIST_BROADCASTS: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ LIST_BROADCASTS: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcasts"})
 		return
 	}
 
-	log.Printf("✅ LIST_BROADCASTS: Successfully fetched %d broadcasts", len(broadcasts))
+	logging.Printf(c.Request.Context(), "✅ LIST_BROADCASTS: Successfully fetched %d broadcasts", len(broadcasts))
 
 	c.JSON(http.StatusOK, gin.H{
 		"broadcasts": broadcasts,
@@ -116,10 +113,27 @@ func (h *BroadcastHandler) GetBroadcast(c *gin.Context) {
 		return
 	}
 
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Authors and broadcast managers can see any broadcast; others only those targeted at them
+	isAuthor, err := h.service.IsAuthor(broadcastID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorship"})
+		return
+	}
+	if !isAuthor && !userCtx.HasPermission(models.PermissionManageBroadcasts) && !h.requireTargeted(c, broadcastID, userCtx) {
+		return
+	}
+
 	// Get broadcast
 	broadcast, err := h.service.GetByID(broadcastID)
 	if err != nil {
-		log.Printf("❌ GET_BROADCAST: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_BROADCAST: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast"})
 		return
 	}
@@ -129,7 +143,7 @@ func (h *BroadcastHandler) GetBroadcast(c *gin.Context) {
 		return
 	}
 
-	log.Printf("✅ GET_BROADCAST: Successfully fetched broadcast %s", broadcastID)
+	logging.Printf(c.Request.Context(), "✅ GET_BROADCAST: Successfully fetched broadcast %s", broadcastID)
 
 	c.JSON(http.StatusOK, broadcast)
 }
@@ -149,24 +163,8 @@ func (h *BroadcastHandler) CreateBroadcast(c *gin.Context) {
 		return
 	}
 
-	// Check if user is admin
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
-		return
-	}
-
-	isAdmin := false
-	for _, role := range roles {
-		if role.Name == "admin" {
-			isAdmin = true
-			break
-		}
-	}
-
-	if !isAdmin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can create broadcasts"})
+	if !userCtx.HasPermission(models.PermissionSendBroadcasts) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to create broadcasts"})
 		return
 	}
 
@@ -178,21 +176,28 @@ func (h *BroadcastHandler) CreateBroadcast(c *gin.Context) {
 
 	// Create broadcast
 	broadcast := &models.BroadcastMessage{
-		Title:          req.Title,
-		Content:        req.Content,
-		AuthorID:       userCtx.ID,
-		TargetAudience: req.TargetAudience,
-		Priority:       priority,
-		ExpiresAt:      req.ExpiresAt,
+		Title:                  req.Title,
+		Content:                req.Content,
+		AuthorID:               userCtx.ID,
+		TargetAudience:         req.TargetAudience,
+		TargetType:             req.TargetType,
+		TargetIDs:              req.TargetIDs,
+		Priority:               priority,
+		ExpiresAt:              req.ExpiresAt,
+		AcknowledgmentRequired: req.AcknowledgmentRequired,
 	}
 
 	if err := h.service.Create(broadcast); err != nil {
-		log.Printf("❌ CREATE_BROADCAST: Database error: %v", err)
+		if errors.Is(err, models.ErrInvalidBroadcastTarget) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ CREATE_BROADCAST: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create broadcast"})
 		return
 	}
 
-	log.Printf("✅ CREATE_BROADCAST: Successfully created broadcast %s", broadcast.ID)
+	logging.Printf(c.Request.Context(), "✅ CREATE_BROADCAST: Successfully created broadcast %s", broadcast.ID)
 
 	c.JSON(http.StatusCreated, broadcast)
 }
@@ -219,31 +224,15 @@ func (h *BroadcastHandler) UpdateBroadcast(c *gin.Context) {
 		return
 	}
 
-	// Check if user is author or admin
+	// Check if user is the author or may manage any broadcast
 	isAuthor, err := h.service.IsAuthor(broadcastID, userCtx.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorship"})
 		return
 	}
 
-	// Check if user is admin
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
-		return
-	}
-
-	isAdmin := false
-	for _, role := range roles {
-		if role.Name == "admin" {
-			isAdmin = true
-			break
-		}
-	}
-
-	if !isAuthor && !isAdmin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only broadcast author or admin can update"})
+	if !isAuthor && !userCtx.HasPermission(models.PermissionManageBroadcasts) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the broadcast author or a broadcast manager can update"})
 		return
 	}
 
@@ -266,8 +255,14 @@ func (h *BroadcastHandler) UpdateBroadcast(c *gin.Context) {
 	if req.Content != "" {
 		broadcast.Content = req.Content
 	}
-	if req.TargetAudience != "" {
+	if req.TargetType != "" {
+		broadcast.TargetType = req.TargetType
+		broadcast.TargetIDs = req.TargetIDs
+	} else if req.TargetAudience != "" {
+		// A fixed audience replaces the current target
 		broadcast.TargetAudience = req.TargetAudience
+		broadcast.TargetType = ""
+		broadcast.TargetIDs = nil
 	}
 	if req.Priority != "" {
 		broadcast.Priority = req.Priority
@@ -275,14 +270,21 @@ func (h *BroadcastHandler) UpdateBroadcast(c *gin.Context) {
 	if req.ExpiresAt != nil {
 		broadcast.ExpiresAt = req.ExpiresAt
 	}
+	if req.AcknowledgmentRequired != nil {
+		broadcast.AcknowledgmentRequired = *req.AcknowledgmentRequired
+	}
 
 	if err := h.service.Update(broadcast); err != nil {
-		log.Printf("❌ UPDATE_BROADCAST: Database error: %v", err)
+		if errors.Is(err, models.ErrInvalidBroadcastTarget) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_BROADCAST: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update broadcast"})
 		return
 	}
 
-	log.Printf("✅ UPDATE_BROADCAST: Successfully updated broadcast %s", broadcastID)
+	logging.Printf(c.Request.Context(), "✅ UPDATE_BROADCAST: Successfully updated broadcast %s", broadcastID)
 
 	c.JSON(http.StatusOK, broadcast)
 }
@@ -303,41 +305,25 @@ func (h *BroadcastHandler) DeleteBroadcast(c *gin.Context) {
 		return
 	}
 
-	// Check if user is author or admin
+	// Check if user is the author or may manage any broadcast
 	isAuthor, err := h.service.IsAuthor(broadcastID, userCtx.ID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorship"})
 		return
 	}
 
-	// Check if user is admin
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
-		return
-	}
-
-	isAdmin := false
-	for _, role := range roles {
-		if role.Name == "admin" {
-			isAdmin = true
-			break
-		}
-	}
-
-	if !isAuthor && !isAdmin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only broadcast author or admin can delete"})
+	if !isAuthor && !userCtx.HasPermission(models.PermissionManageBroadcasts) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the broadcast author or a broadcast manager can delete"})
 		return
 	}
 
 	if err := h.service.SoftDelete(broadcastID); err != nil {
-		log.Printf("❌ DELETE_BROADCAST: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ DELETE_BROADCAST: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete broadcast"})
 		return
 	}
 
-	log.Printf("✅ DELETE_BROADCAST: Successfully deleted broadcast %s", broadcastID)
+	logging.Printf(c.Request.Context(), "✅ DELETE_BROADCAST: Successfully deleted broadcast %s", broadcastID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Broadcast deleted successfully"})
 }
@@ -358,13 +344,17 @@ func (h *BroadcastHandler) MarkBroadcastAsRead(c *gin.Context) {
 		return
 	}
 
+	if !h.requireTargeted(c, broadcastID, userCtx) {
+		return
+	}
+
 	if err := h.service.MarkAsRead(broadcastID, userCtx.ID); err != nil {
-		log.Printf("❌ MARK_BROADCAST_READ: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ MARK_BROADCAST_READ: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark broadcast as read"})
 		return
 	}
 
-	log.Printf("✅ MARK_BROADCAST_READ: Successfully marked broadcast %s as read for user %s", broadcastID, userCtx.ID)
+	logging.Printf(c.Request.Context(), "✅ MARK_BROADCAST_READ: Successfully marked broadcast %s as read for user %s", broadcastID, userCtx.ID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Broadcast marked as read"})
 }
@@ -378,29 +368,160 @@ func (h *BroadcastHandler) GetBroadcastStats(c *gin.Context) {
 		return
 	}
 
-	// Get user roles
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
+	// Get stats over the broadcasts targeted at the user
+	stats, err := h.service.GetStats(userCtx.ID, middleware.EffectiveRoles(userCtx.Roles))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_BROADCAST_STATS: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast stats"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "✅ GET_BROADCAST_STATS: Successfully fetched stats for user %s", userCtx.ID)
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// AcknowledgeBroadcast handles POST /api/broadcasts/:id/acknowledge
+func (h *BroadcastHandler) AcknowledgeBroadcast(c *gin.Context) {
+	broadcastIDStr := c.Param("id")
+	broadcastID, err := uuid.Parse(broadcastIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid broadcast ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !h.requireTargeted(c, broadcastID, userCtx) {
+		return
+	}
+
+	broadcast, err := h.service.GetByID(broadcastID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
+		logging.Errorf(c.Request.Context(), "❌ ACKNOWLEDGE_BROADCAST: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast"})
+		return
+	}
+
+	if broadcast == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Broadcast not found"})
 		return
 	}
 
-	// Convert roles to string slice
-	var roleNames []string
-	for _, role := range roles {
-		roleNames = append(roleNames, role.Name)
+	if !broadcast.AcknowledgmentRequired {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Broadcast does not require acknowledgment"})
+		return
 	}
 
-	// Get stats
-	stats, err := h.service.GetStats(userCtx.ID, roleNames)
+	if err := h.service.Acknowledge(broadcastID, userCtx.ID); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ ACKNOWLEDGE_BROADCAST: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge broadcast"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "✅ ACKNOWLEDGE_BROADCAST: User %s acknowledged broadcast %s", userCtx.ID, broadcastID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Broadcast acknowledged"})
+}
+
+// GetBroadcastAcknowledgments handles GET /api/broadcasts/:id/acknowledgments
+func (h *BroadcastHandler) GetBroadcastAcknowledgments(c *gin.Context) {
+	broadcastIDStr := c.Param("id")
+	broadcastID, err := uuid.Parse(broadcastIDStr)
 	if err != nil {
-		log.Printf("❌ GET_BROADCAST_STATS: Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast stats"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid broadcast ID"})
 		return
 	}
 
-	log.Printf("✅ GET_BROADCAST_STATS: Successfully fetched stats for user %s", userCtx.ID)
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
 
-	c.JSON(http.StatusOK, stats)
+	// Check if user is the author or may manage any broadcast
+	isAuthor, err := h.service.IsAuthor(broadcastID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorship"})
+		return
+	}
+
+	if !isAuthor && !userCtx.HasPermission(models.PermissionManageBroadcasts) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the broadcast author or a broadcast manager can view acknowledgments"})
+		return
+	}
+
+	broadcast, err := h.service.GetByID(broadcastID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_BROADCAST_ACKNOWLEDGMENTS: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast"})
+		return
+	}
+
+	if broadcast == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Broadcast not found"})
+		return
+	}
+
+	recipients, err := h.service.GetAcknowledgmentStatus(broadcast)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_BROADCAST_ACKNOWLEDGMENTS: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast acknowledgments"})
+		return
+	}
+
+	// A read without an acknowledgment still leaves the recipient pending
+	acknowledged := []models.BroadcastRecipientStatus{}
+	pending := []models.BroadcastRecipientStatus{}
+	readCount := 0
+	for _, recipient := range recipients {
+		if recipient.ReadAt != nil {
+			readCount++
+		}
+		if recipient.AcknowledgedAt != nil {
+			acknowledged = append(acknowledged, recipient)
+		} else {
+			pending = append(pending, recipient)
+		}
+	}
+
+	readRate, acknowledgmentRate := 0.0, 0.0
+	if len(recipients) > 0 {
+		readRate = float64(readCount) / float64(len(recipients))
+		acknowledgmentRate = float64(len(acknowledged)) / float64(len(recipients))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"broadcast_id":            broadcast.ID,
+		"acknowledgment_required": broadcast.AcknowledgmentRequired,
+		"audience_count":          len(recipients),
+		"read_count":              readCount,
+		"read_rate":               readRate,
+		"acknowledged_count":      len(acknowledged),
+		"acknowledgment_rate":     acknowledgmentRate,
+		"acknowledged":            acknowledged,
+		"pending":                 pending,
+	})
+}
+
+// requireTargeted checks a broadcast is targeted at the user, responding 404 if it isn't
+// so broadcasts for other audiences aren't revealed. It reports whether it is.
+func (h *BroadcastHandler) requireTargeted(c *gin.Context, broadcastID uuid.UUID, userCtx *middleware.UserContext) bool {
+	targeted, err := h.service.IsTargetedAt(broadcastID, userCtx.ID, middleware.EffectiveRoles(userCtx.Roles))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ BROADCAST: Failed to check targeting of broadcast %s: %v", broadcastID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcast"})
+		return false
+	}
+	if !targeted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Broadcast not found"})
+		return false
+	}
+	return true
 }
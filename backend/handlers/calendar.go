@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// calendarFeedPathTemplate is where calendar apps fetch a project feed; {project_id}
+// is replaced by the client
+const calendarFeedPathTemplate = "/api/projects/{project_id}/calendar.ics?token=%s"
+
+// CalendarHandler handles iCalendar project feeds and the tokens that authorize them
+type CalendarHandler struct {
+	projectService *models.ProjectService
+	taskService    *models.TaskService
+	userService    *models.UserService
+	config         *config.Config
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(projectService *models.ProjectService, taskService *models.TaskService, userService *models.UserService, config *config.Config) *CalendarHandler {
+	return &CalendarHandler{
+		projectService: projectService,
+		taskService:    taskService,
+		userService:    userService,
+		config:         config,
+	}
+}
+
+// GetCalendarFeedToken handles GET /api/me/calendar-feed
+// Returns the token calendar apps use to subscribe to the user's project feeds.
+func (h *CalendarHandler) GetCalendarFeedToken(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	version, err := h.userService.GetCalendarFeedVersion(userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CALENDAR_FEED: Failed to get feed version for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get calendar feed token"})
+		return
+	}
+
+	h.respondWithFeedToken(c, userCtx.ID, version)
+}
+
+// ResetCalendarFeedToken handles POST /api/me/calendar-feed/reset
+// Issues a new token; calendars subscribed with the old one stop updating.
+func (h *CalendarHandler) ResetCalendarFeedToken(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	version, err := h.userService.RotateCalendarFeedVersion(userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CALENDAR_FEED: Failed to reset feed token for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset calendar feed token"})
+		return
+	}
+
+	h.respondWithFeedToken(c, userCtx.ID, version)
+}
+
+func (h *CalendarHandler) respondWithFeedToken(c *gin.Context, userID uuid.UUID, version int) {
+	token := services.SignCalendarFeedToken(h.config.JWT.Secret, userID, version)
+	c.JSON(http.StatusOK, gin.H{
+		"token":              token,
+		"feed_path_template": fmt.Sprintf(calendarFeedPathTemplate, token),
+	})
+}
+
+// GetProjectCalendar handles GET /api/projects/:id/calendar.ics
+// It's authenticated by the ?token= from GetCalendarFeedToken rather than a JWT, since
+// calendar apps can't send one, and only serves projects the token's user leads or
+// is an active team member of; other projects get the same 404 as a missing one.
+func (h *CalendarHandler) GetProjectCalendar(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userID, version, err := services.ParseCalendarFeedToken(h.config.JWT.Secret, c.Query("token"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid calendar feed token"})
+		return
+	}
+	currentVersion, err := h.userService.GetCalendarFeedVersion(userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logging.Errorf(c.Request.Context(), "❌ CALENDAR_FEED: Failed to get feed version for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify calendar feed token"})
+		return
+	}
+	if err != nil || version != currentVersion {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Calendar feed token has been revoked"})
+		return
+	}
+
+	project, err := h.projectService.GetByID(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CALENDAR_FEED: Failed to get project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		respondNotFound(c, "Project")
+		return
+	}
+
+	isTeamMember, err := h.projectService.IsTeamMember(projectID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return
+	}
+	if !isTeamMember && !isTeamLead {
+		respondNotFound(c, "Project")
+		return
+	}
+
+	tasks, err := h.taskService.ListCalendarForUser(userID, projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CALENDAR_FEED: Failed to list tasks for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project tasks"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="project-%s.ics"`, projectID))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", services.BuildProjectCalendar(project, tasks, time.Now()))
+}
@@ -1,6 +1,10 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,6 +12,7 @@ import (
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +23,7 @@ import (
 type CampaignHandler struct {
 	campaignService *models.CampaignService
 	emailService    *services.EmailService
+	campaignSender  *services.CampaignSender
 	config          *config.Config
 }
 
@@ -26,6 +32,7 @@ func NewCampaignHandler(campaignService *models.CampaignService, emailService *s
 	return &CampaignHandler{
 		campaignService: campaignService,
 		emailService:    emailService,
+		campaignSender:  services.NewCampaignSender(campaignService, emailService, config.Mailgun.BatchSize, config.Campaigns.TrackingBaseURL),
 		config:          config,
 	}
 }
@@ -54,8 +61,8 @@ func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to view campaigns
+	if !userCtx.HasPermission(models.PermissionViewCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view campaigns"})
 		return
 	}
@@ -67,8 +74,8 @@ func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
 	}
 
 	var createdByUserID *uuid.UUID
-	// If user is not admin, only show their own campaigns
-	if !userCtx.HasRole("admin") {
+	// Unless they can manage every campaign, users only see their own
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) {
 		createdByUserID = &userCtx.ID
 	}
 
@@ -102,8 +109,8 @@ func (h *CampaignHandler) GetCampaignByID(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to view campaigns
+	if !userCtx.HasPermission(models.PermissionViewCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view campaigns"})
 		return
 	}
@@ -120,8 +127,8 @@ func (h *CampaignHandler) GetCampaignByID(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && campaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view this campaign"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && campaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
@@ -131,12 +138,14 @@ func (h *CampaignHandler) GetCampaignByID(c *gin.Context) {
 // CreateCampaign handles POST /api/campaigns
 func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 	var req struct {
-		Title        string   `json:"title" binding:"required"`
-		Description  string   `json:"description"`
-		TargetRoles  []string `json:"target_roles" binding:"required"`
-		EmailSubject string   `json:"email_subject" binding:"required"`
-		EmailBody    string   `json:"email_body" binding:"required"`
-		ScheduledAt  *string  `json:"scheduled_at"`
+		Title         string   `json:"title" binding:"required"`
+		Description   string   `json:"description"`
+		TargetRoles   []string `json:"target_roles" binding:"required"`
+		EmailSubject  string   `json:"email_subject" binding:"required"`
+		EmailBody     string   `json:"email_body" binding:"required"`
+		EmailSubjectB *string  `json:"email_subject_b"`
+		ABTestPercent int      `json:"ab_test_percent"` // 1-50 enables an A/B subject test; 0 disables it
+		ScheduledAt   *string  `json:"scheduled_at"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -151,8 +160,8 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to create campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to create campaigns
+	if !userCtx.HasPermission(models.PermissionCreateCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to create campaigns"})
 		return
 	}
@@ -181,10 +190,17 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		Status:          status,
 		EmailSubject:    req.EmailSubject,
 		EmailBody:       req.EmailBody,
+		EmailSubjectB:   req.EmailSubjectB,
+		ABTestPercent:   req.ABTestPercent,
 		CreatedByUserID: userCtx.ID,
 		ScheduledAt:     scheduledAt,
 	}
 
+	if err := campaign.ValidateABTest(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	if err := h.campaignService.CreateCampaign(campaign); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create campaign"})
 		return
@@ -203,13 +219,15 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 	}
 
 	var req struct {
-		Title        string   `json:"title" binding:"required"`
-		Description  string   `json:"description"`
-		TargetRoles  []string `json:"target_roles" binding:"required"`
-		Status       string   `json:"status"`
-		EmailSubject string   `json:"email_subject" binding:"required"`
-		EmailBody    string   `json:"email_body" binding:"required"`
-		ScheduledAt  *string  `json:"scheduled_at"`
+		Title         string   `json:"title" binding:"required"`
+		Description   string   `json:"description"`
+		TargetRoles   []string `json:"target_roles" binding:"required"`
+		Status        string   `json:"status"`
+		EmailSubject  string   `json:"email_subject" binding:"required"`
+		EmailBody     string   `json:"email_body" binding:"required"`
+		EmailSubjectB *string  `json:"email_subject_b"`
+		ABTestPercent int      `json:"ab_test_percent"` // 1-50 enables an A/B subject test; 0 disables it
+		ScheduledAt   *string  `json:"scheduled_at"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -224,8 +242,8 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to update campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to update campaigns
+	if !userCtx.HasPermission(models.PermissionCreateCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to update campaigns"})
 		return
 	}
@@ -242,8 +260,8 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && existingCampaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to update this campaign"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && existingCampaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
@@ -274,6 +292,8 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 		Status:          status,
 		EmailSubject:    req.EmailSubject,
 		EmailBody:       req.EmailBody,
+		EmailSubjectB:   req.EmailSubjectB,
+		ABTestPercent:   req.ABTestPercent,
 		CreatedByUserID: existingCampaign.CreatedByUserID,
 		ScheduledAt:     scheduledAt,
 		SentAt:          existingCampaign.SentAt,
@@ -281,6 +301,11 @@ func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
 		UpdatedAt:       existingCampaign.UpdatedAt,
 	}
 
+	if err := campaign.ValidateABTest(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	if err := h.campaignService.UpdateCampaign(campaign); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update campaign"})
 		return
@@ -305,8 +330,8 @@ func (h *CampaignHandler) DeleteCampaign(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to delete campaigns (admin only for now)
-	if !userCtx.HasRole("admin") {
+	// Check if user has permission to delete campaigns
+	if !userCtx.HasPermission(models.PermissionDeleteCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to delete campaigns"})
 		return
 	}
@@ -335,8 +360,8 @@ func (h *CampaignHandler) GetCampaignStats(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view campaign stats (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to view campaign stats
+	if !userCtx.HasPermission(models.PermissionViewCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view campaign stats"})
 		return
 	}
@@ -353,8 +378,8 @@ func (h *CampaignHandler) GetCampaignStats(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && campaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view this campaign's stats"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && campaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
@@ -383,8 +408,8 @@ func (h *CampaignHandler) GetCampaignRecipients(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view campaign recipients (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to view campaign recipients
+	if !userCtx.HasPermission(models.PermissionViewCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view campaign recipients"})
 		return
 	}
@@ -401,8 +426,8 @@ func (h *CampaignHandler) GetCampaignRecipients(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && campaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view this campaign's recipients"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && campaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
@@ -431,8 +456,8 @@ func (h *CampaignHandler) PreviewCampaign(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to preview campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to preview campaigns
+	if !userCtx.HasPermission(models.PermissionViewCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to preview campaigns"})
 		return
 	}
@@ -449,8 +474,8 @@ func (h *CampaignHandler) PreviewCampaign(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && campaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to preview this campaign"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && campaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
@@ -486,8 +511,8 @@ func (h *CampaignHandler) SendCampaign(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to send campaigns (campaign_manager or admin)
-	if !userCtx.HasAnyRole("campaign_manager", "admin") {
+	// Check if user has permission to send campaigns
+	if !userCtx.HasPermission(models.PermissionSendCampaigns) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to send campaigns"})
 		return
 	}
@@ -504,20 +529,29 @@ func (h *CampaignHandler) SendCampaign(c *gin.Context) {
 	}
 
 	// If user is not admin, check if they created this campaign
-	if !userCtx.HasRole("admin") && campaign.CreatedByUserID != userCtx.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to send this campaign"})
+	if !userCtx.HasPermission(models.PermissionManageAllCampaigns) && campaign.CreatedByUserID != userCtx.ID {
+		respondNotFound(c, "Campaign")
 		return
 	}
 
-	// Check if campaign can be sent
-	if campaign.Status != models.CampaignStatusDraft && campaign.Status != models.CampaignStatusScheduled {
+	// Check if campaign can be sent; a campaign is only ever sent once
+	switch campaign.Status {
+	case models.CampaignStatusDraft, models.CampaignStatusScheduled:
+	case models.CampaignStatusSent:
+		c.JSON(http.StatusConflict, gin.H{"error": "Campaign has already been sent"})
+		return
+	case models.CampaignStatusSending:
+		c.JSON(http.StatusConflict, gin.H{"error": "Campaign is already being sent"})
+		return
+	default:
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Campaign cannot be sent in its current status"})
 		return
 	}
 
-	// Get target users for the campaign
-	targetUsers, err := h.campaignService.GetTargetUsersForCampaign(campaign.TargetRoles)
+	// Resolve target roles into recipients
+	targetUsers, err := h.campaignService.ResolveRecipients(campaign)
 	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ SEND_CAMPAIGN: Failed to resolve recipients for campaign %s: %v", id, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get target users for campaign"})
 		return
 	}
@@ -527,32 +561,200 @@ func (h *CampaignHandler) SendCampaign(c *gin.Context) {
 		return
 	}
 
-	// Extract email addresses
-	var recipients []string
-	for _, user := range targetUsers {
-		recipients = append(recipients, user.Email)
+	// Claim the campaign so concurrent requests can't send it twice
+	if err := h.campaignService.BeginSending(id); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, gin.H{"error": "Campaign has already been sent"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ SEND_CAMPAIGN: Failed to start sending campaign %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update campaign status"})
+		return
+	}
+	campaign.Status = models.CampaignStatusSending
+
+	userIDs := make([]uuid.UUID, len(targetUsers))
+	for i, user := range targetUsers {
+		userIDs[i] = user.ID
+	}
+	if err := h.campaignService.AddCampaignRecipients(campaign, userIDs); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ SEND_CAMPAIGN: Failed to record recipients for campaign %s: %v", id, err)
+		if err := h.campaignService.FinishSending(id, models.CampaignStatusFailed); err != nil {
+			logging.Errorf(c.Request.Context(), "❌ SEND_CAMPAIGN: Failed to mark campaign %s as failed: %v", id, err)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record campaign recipients"})
+		return
+	}
+
+	// Delivery can take a while for large audiences, so it runs in the background;
+	// progress is visible through the stats and recipients endpoints. If the server
+	// stops mid-send, CampaignSender.Run resumes the campaign's pending recipients.
+	// The request context ends with this response, so the send must not use it.
+	ctx := context.WithoutCancel(c.Request.Context())
+	go func() {
+		if _, _, err := h.campaignSender.Send(campaign); err != nil {
+			logging.Errorf(ctx, "❌ SEND_CAMPAIGN: Failed to send campaign %s: %v", id, err)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":     "Campaign is being sent",
+		"recipients":  len(targetUsers),
+		"campaign_id": campaign.ID,
+		"status":      campaign.Status,
+	})
+}
+
+// trackingPixel is a transparent 1x1 GIF
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// TrackOpen handles GET /api/track/open/:recipient_token
+func (h *CampaignHandler) TrackOpen(c *gin.Context) {
+	token := c.Param("recipient_token")
+
+	if _, err := h.campaignService.RecordOpen(token); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ TRACK_OPEN: Failed to record open: %v", err)
 	}
 
-	// Send campaign emails
-	err = h.emailService.SendCampaignEmail(recipients, campaign.EmailSubject, campaign.EmailBody, "")
+	// Always serve the pixel, whatever the token, so it can't be used to probe tokens
+	c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	c.Data(http.StatusOK, "image/gif", trackingPixel)
+}
+
+// TrackClick handles GET /api/track/click/:recipient_token
+func (h *CampaignHandler) TrackClick(c *gin.Context) {
+	token := c.Param("recipient_token")
+
+	destination, err := h.campaignService.RecordClick(token, c.Query("url"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send campaign emails"})
+		logging.Errorf(c.Request.Context(), "❌ TRACK_CLICK: Failed to record click: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow link"})
+		return
+	}
+	if destination == "" {
+		respondNotFound(c, "Link")
 		return
 	}
 
-	// Update campaign status to sent
-	campaign.Status = models.CampaignStatusSent
-	now := time.Now()
-	campaign.SentAt = &now
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, destination)
+}
 
-	if err := h.campaignService.UpdateCampaign(campaign); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update campaign status"})
+// unsubscribePageTemplate is the page shown to people following an unsubscribe link;
+// %s is the body
+const unsubscribePageTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Unsubscribe - CivicWeave</title></head>
+<body style="font-family:sans-serif;max-width:480px;margin:48px auto;text-align:center">
+%s
+</body>
+</html>`
+
+// unsubscribeConfirmBody asks the reader to confirm; the form posts back to the same URL
+const unsubscribeConfirmBody = `<h1>Unsubscribe from CivicWeave campaigns?</h1>
+<p>You will no longer receive campaign emails. Account emails such as password resets are not affected.</p>
+<form method="post"><button type="submit">Unsubscribe</button></form>`
+
+// ConfirmUnsubscribe handles GET /api/campaigns/unsubscribe/:token
+// It only shows a confirmation page: link scanners and prefetchers follow GET links,
+// so unsubscribing takes the POST the page makes.
+func (h *CampaignHandler) ConfirmUnsubscribe(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(fmt.Sprintf(unsubscribePageTemplate, unsubscribeConfirmBody)))
+}
+
+// Unsubscribe handles POST /api/campaigns/unsubscribe/:token
+// It's posted by the confirmation page and by mail clients' one-click unsubscribe
+// (RFC 8058). Browsers get a page back; other clients get JSON.
+func (h *CampaignHandler) Unsubscribe(c *gin.Context) {
+	token := c.Param("token")
+	wantsHTML := c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML
+
+	unsubscribe, err := h.campaignService.UnsubscribeByToken(token)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CAMPAIGN_UNSUBSCRIBE: Failed to record unsubscribe: %v", err)
+		if wantsHTML {
+			respondUnsubscribePage(c, http.StatusInternalServerError, "Something went wrong. Please try again later.")
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unsubscribe"})
+		return
+	}
+	if unsubscribe == nil {
+		if wantsHTML {
+			respondUnsubscribePage(c, http.StatusNotFound, "This unsubscribe link is not valid.")
+			return
+		}
+		respondNotFound(c, "Unsubscribe link")
 		return
 	}
 
+	logging.Printf(c.Request.Context(), "📭 CAMPAIGN_UNSUBSCRIBE: %s unsubscribed from campaign emails", unsubscribe.Email)
+	if wantsHTML {
+		respondUnsubscribePage(c, http.StatusOK, "You have been unsubscribed from future campaign emails.")
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"message":     "Campaign sent successfully",
-		"recipients":  len(recipients),
-		"campaign_id": campaign.ID,
+		"message": "You have been unsubscribed from future campaign emails",
+		"email":   unsubscribe.Email,
+	})
+}
+
+// respondUnsubscribePage writes an unsubscribe page showing message
+func respondUnsubscribePage(c *gin.Context, status int, message string) {
+	body := "<p>" + html.EscapeString(message) + "</p>"
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", []byte(fmt.Sprintf(unsubscribePageTemplate, body)))
+}
+
+// ListUnsubscribes handles GET /api/admin/campaign-unsubscribes
+func (h *CampaignHandler) ListUnsubscribes(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	unsubscribes, total, err := h.campaignService.ListUnsubscribes(limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_CAMPAIGN_UNSUBSCRIBES: Failed to list suppression list: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get unsubscribes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unsubscribes": unsubscribes,
+		"total":        total,
+		"limit":        limit,
+		"offset":       offset,
 	})
 }
+
+// Resubscribe handles DELETE /api/admin/campaign-unsubscribes/:id
+func (h *CampaignHandler) Resubscribe(c *gin.Context) {
+	unsubscribeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unsubscribe ID"})
+		return
+	}
+
+	if err := h.campaignService.Resubscribe(unsubscribeID); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Unsubscribe")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ CAMPAIGN_RESUBSCRIBE: Failed to remove unsubscribe %s: %v", unsubscribeID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resubscribe"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Resubscribed to campaign emails successfully"})
+}
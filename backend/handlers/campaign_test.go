@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestConfirmUnsubscribeDoesNotUnsubscribe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// A nil campaign service panics if the confirmation page tries to unsubscribe
+	h := &CampaignHandler{}
+	router := gin.New()
+	router.GET("/api/campaigns/unsubscribe/:token", h.ConfirmUnsubscribe)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/campaigns/unsubscribe/abc123", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("Content-Type = %q, want text/html", contentType)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, `<form method="post">`) {
+		t.Errorf("page has no form posting back to the link:\n%s", body)
+	}
+}
+
+func TestRespondUnsubscribePageEscapesMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	respondUnsubscribePage(c, http.StatusNotFound, "<script>")
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", recorder.Code)
+	}
+	if body := recorder.Body.String(); strings.Contains(body, "<script>") || !strings.Contains(body, "&lt;script&gt;") {
+		t.Errorf("message not escaped:\n%s", body)
+	}
+}
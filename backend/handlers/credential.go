@@ -0,0 +1,568 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxCredentialDocumentSize bounds uploaded credential documents (10 MB)
+const maxCredentialDocumentSize = 10 << 20
+
+// credentialDocumentExtensions lists the document types accepted, by sniffed MIME type
+var credentialDocumentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// CredentialHandler handles volunteer credential requests
+type CredentialHandler struct {
+	service          *models.CredentialService
+	volunteerService *models.VolunteerService
+	projectService   *models.ProjectService
+	storage          services.FileStorage
+}
+
+// NewCredentialHandler creates a new credential handler
+func NewCredentialHandler(service *models.CredentialService, volunteerService *models.VolunteerService, projectService *models.ProjectService, storage services.FileStorage) *CredentialHandler {
+	return &CredentialHandler{
+		service:          service,
+		volunteerService: volunteerService,
+		projectService:   projectService,
+		storage:          storage,
+	}
+}
+
+// UpdateCredentialRequest represents a change to a credential's details
+type UpdateCredentialRequest struct {
+	CredentialType string  `json:"credential_type" binding:"required"`
+	Issuer         *string `json:"issuer"`
+	IssuedAt       string  `json:"issued_at"`  // YYYY-MM-DD
+	ExpiresAt      string  `json:"expires_at"` // YYYY-MM-DD
+}
+
+// VerifyCredentialRequest represents an admin's verification decision
+type VerifyCredentialRequest struct {
+	Approve bool    `json:"approve"`
+	Note    *string `json:"note"`
+}
+
+// RequiredCredentialsRequest represents the credential types a project requires
+type RequiredCredentialsRequest struct {
+	CredentialTypes []string `json:"credential_types"`
+}
+
+// ListMyCredentials handles GET /api/volunteers/me/credentials
+func (h *CredentialHandler) ListMyCredentials(c *gin.Context) {
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	credentials, err := h.service.ListByVolunteer(volunteer.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_CREDENTIALS: Failed to list credentials for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credentials": credentials})
+}
+
+// UploadCredential handles POST /api/volunteers/me/credentials (multipart: file,
+// credential_type, issuer, issued_at, expires_at)
+func (h *CredentialHandler) UploadCredential(c *gin.Context) {
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	credentialType, err := models.NormalizeCredentialType(c.PostForm("credential_type"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	issuedAt, expiresAt, ok := parseCredentialDates(c, c.PostForm("issued_at"), c.PostForm("expires_at"))
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A credential document is required"})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxCredentialDocumentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Credential documents must be 10 MB or smaller"})
+		return
+	}
+
+	// Trust the content, not the client's Content-Type header
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read credential document"})
+		return
+	}
+	mimeType := http.DetectContentType(sniff[:n])
+	if i := strings.Index(mimeType, ";"); i >= 0 {
+		mimeType = mimeType[:i]
+	}
+	extension, allowed := credentialDocumentExtensions[mimeType]
+	if !allowed {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Credential documents must be PDF, JPEG or PNG"})
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read credential document"})
+		return
+	}
+
+	documentName := filepath.Base(header.Filename)
+	if len(documentName) > 255 {
+		documentName = documentName[:255]
+	}
+
+	credential := &models.VolunteerCredential{
+		ID:             uuid.New(),
+		VolunteerID:    volunteer.ID,
+		CredentialType: credentialType,
+		Issuer:         optionalString(c.PostForm("issuer")),
+		IssuedAt:       issuedAt,
+		ExpiresAt:      expiresAt,
+		DocumentName:   documentName,
+		MimeType:       mimeType,
+	}
+	credential.DocumentKey = "credentials/" + credential.ID.String() + extension
+
+	size, err := h.storage.Save(credential.DocumentKey, io.LimitReader(file, maxCredentialDocumentSize+1))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPLOAD_CREDENTIAL: Failed to store document for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store credential document"})
+		return
+	}
+	if size > maxCredentialDocumentSize {
+		h.deleteDocument(c.Request.Context(), credential.DocumentKey)
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Credential documents must be 10 MB or smaller"})
+		return
+	}
+	credential.FileSize = size
+
+	if err := h.service.Create(credential); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPLOAD_CREDENTIAL: Failed to create credential for volunteer %s: %v", volunteer.ID, err)
+		h.deleteDocument(c.Request.Context(), credential.DocumentKey)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create credential"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "📄 UPLOAD_CREDENTIAL: Volunteer %s uploaded %s credential %s", volunteer.ID, credentialType, credential.ID)
+	c.JSON(http.StatusCreated, credential)
+}
+
+// GetMyCredential handles GET /api/volunteers/me/credentials/:id
+func (h *CredentialHandler) GetMyCredential(c *gin.Context) {
+	credential, ok := h.ownCredential(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// UpdateMyCredential handles PUT /api/volunteers/me/credentials/:id
+func (h *CredentialHandler) UpdateMyCredential(c *gin.Context) {
+	var req UpdateCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credential, ok := h.ownCredential(c)
+	if !ok {
+		return
+	}
+
+	credentialType, err := models.NormalizeCredentialType(req.CredentialType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	issuedAt, expiresAt, ok := parseCredentialDates(c, req.IssuedAt, req.ExpiresAt)
+	if !ok {
+		return
+	}
+
+	credential.CredentialType = credentialType
+	credential.Issuer = req.Issuer
+	credential.IssuedAt = issuedAt
+	credential.ExpiresAt = expiresAt
+
+	if err := h.service.UpdateDetails(credential); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Credential")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_CREDENTIAL: Failed to update credential %s: %v", credential.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update credential"})
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// DeleteMyCredential handles DELETE /api/volunteers/me/credentials/:id
+func (h *CredentialHandler) DeleteMyCredential(c *gin.Context) {
+	credentialID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credential ID"})
+		return
+	}
+
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return
+	}
+
+	documentKey, err := h.service.Delete(credentialID, volunteer.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Credential")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ DELETE_CREDENTIAL: Failed to delete credential %s: %v", credentialID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete credential"})
+		return
+	}
+	h.deleteDocument(c.Request.Context(), documentKey)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credential deleted successfully"})
+}
+
+// DownloadMyCredentialDocument handles GET /api/volunteers/me/credentials/:id/document
+func (h *CredentialHandler) DownloadMyCredentialDocument(c *gin.Context) {
+	credential, ok := h.ownCredential(c)
+	if !ok {
+		return
+	}
+
+	h.serveDocument(c, credential)
+}
+
+// ListCredentialsForReview handles GET /api/admin/credentials
+func (h *CredentialHandler) ListCredentialsForReview(c *gin.Context) {
+	status := models.CredentialStatus(c.DefaultQuery("status", string(models.CredentialStatusPending)))
+	switch status {
+	case models.CredentialStatusPending, models.CredentialStatusVerified, models.CredentialStatusRejected:
+	case "all":
+		status = ""
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	credentials, err := h.service.ListForReview(status, limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_CREDENTIALS_FOR_REVIEW: Failed to list credentials: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"credentials": credentials,
+		"limit":       limit,
+		"offset":      offset,
+		"count":       len(credentials),
+	})
+}
+
+// VerifyCredential handles POST /api/admin/credentials/:id/verify
+func (h *CredentialHandler) VerifyCredential(c *gin.Context) {
+	credentialID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credential ID"})
+		return
+	}
+
+	var req VerifyCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	credential, err := h.service.Review(credentialID, req.Approve, userCtx.ID, req.Note)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			respondNotFound(c, "Credential")
+		case models.ErrCredentialNotPending:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			logging.Errorf(c.Request.Context(), "❌ VERIFY_CREDENTIAL: Failed to review credential %s: %v", credentialID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review credential"})
+		}
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "📄 VERIFY_CREDENTIAL: Admin %s marked credential %s as %s", userCtx.ID, credentialID, credential.Status)
+	c.JSON(http.StatusOK, credential)
+}
+
+// DownloadCredentialDocument handles GET /api/admin/credentials/:id/document
+func (h *CredentialHandler) DownloadCredentialDocument(c *gin.Context) {
+	credentialID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credential ID"})
+		return
+	}
+
+	credential, err := h.service.GetByID(credentialID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_CREDENTIAL: Failed to get credential %s: %v", credentialID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get credential"})
+		return
+	}
+	if credential == nil {
+		respondNotFound(c, "Credential")
+		return
+	}
+
+	h.serveDocument(c, credential)
+}
+
+// GetRequiredCredentials handles GET /api/projects/:id/required-credentials
+func (h *CredentialHandler) GetRequiredCredentials(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	credentialTypes, err := h.service.GetRequiredCredentialTypes(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_REQUIRED_CREDENTIALS: Failed to get required credentials for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get required credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"project_id": projectID, "credential_types": credentialTypes})
+}
+
+// SetRequiredCredentials handles PUT /api/projects/:id/required-credentials
+func (h *CredentialHandler) SetRequiredCredentials(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req RequiredCredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return
+	}
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only the project team lead can set required credentials")
+		return
+	}
+
+	seen := make(map[string]bool)
+	credentialTypes := make([]string, 0, len(req.CredentialTypes))
+	for _, raw := range req.CredentialTypes {
+		credentialType, err := models.NormalizeCredentialType(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "credential_type": raw})
+			return
+		}
+		if !seen[credentialType] {
+			seen[credentialType] = true
+			credentialTypes = append(credentialTypes, credentialType)
+		}
+	}
+
+	if err := h.service.SetRequiredCredentialTypes(projectID, credentialTypes); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ SET_REQUIRED_CREDENTIALS: Failed to set required credentials for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set required credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"project_id": projectID, "credential_types": credentialTypes})
+}
+
+// checkRequiredCredentials writes a 409 and returns false if the volunteer lacks a
+// verified, unexpired credential the project requires
+func checkRequiredCredentials(c *gin.Context, db *sql.DB, volunteerID, projectID uuid.UUID) bool {
+	missing, err := models.NewCredentialService(db).MissingRequiredCredentials(volunteerID, projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CHECK_CREDENTIALS: Failed to check credentials of volunteer %s for project %s: %v", volunteerID, projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check required credentials"})
+		return false
+	}
+	if len(missing) > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":               "Volunteer does not hold the credentials this project requires",
+			"code":                "CREDENTIALS_REQUIRED",
+			"missing_credentials": missing,
+		})
+		return false
+	}
+	return true
+}
+
+// currentVolunteer looks up the caller's volunteer profile, writing an error response if there is none
+func (h *CredentialHandler) currentVolunteer(c *gin.Context) (*models.Volunteer, bool) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return nil, false
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile not found"})
+		return nil, false
+	}
+
+	return volunteer, true
+}
+
+// ownCredential loads the credential named by the :id param if it belongs to the caller
+func (h *CredentialHandler) ownCredential(c *gin.Context) (*models.VolunteerCredential, bool) {
+	credentialID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid credential ID"})
+		return nil, false
+	}
+
+	volunteer, ok := h.currentVolunteer(c)
+	if !ok {
+		return nil, false
+	}
+
+	credential, err := h.service.GetByID(credentialID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_CREDENTIAL: Failed to get credential %s: %v", credentialID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get credential"})
+		return nil, false
+	}
+	if credential == nil || credential.VolunteerID != volunteer.ID {
+		respondNotFound(c, "Credential")
+		return nil, false
+	}
+
+	return credential, true
+}
+
+// serveDocument streams a credential's document from storage
+func (h *CredentialHandler) serveDocument(c *gin.Context, credential *models.VolunteerCredential) {
+	document, err := h.storage.Open(credential.DocumentKey)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_CREDENTIAL: Failed to open document for credential %s: %v", credential.ID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Credential document not found"})
+		return
+	}
+	defer document.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": credential.DocumentName})
+	c.DataFromReader(http.StatusOK, credential.FileSize, credential.MimeType, document, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+// deleteDocument removes a stored document, logging rather than failing on errors
+func (h *CredentialHandler) deleteDocument(ctx context.Context, key string) {
+	if err := h.storage.Delete(key); err != nil {
+		logging.Errorf(ctx, "❌ DELETE_CREDENTIAL_DOCUMENT: Failed to delete %s: %v", key, err)
+	}
+}
+
+// parseCredentialDates parses optional YYYY-MM-DD issue and expiry dates. It writes a
+// 400 and returns false if either is malformed, the credential has already expired, or
+// it expires before it was issued.
+func parseCredentialDates(c *gin.Context, issuedAtStr, expiresAtStr string) (*time.Time, *time.Time, bool) {
+	var issuedAt, expiresAt *time.Time
+	if issuedAtStr != "" {
+		parsed, err := time.Parse("2006-01-02", issuedAtStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid issued_at, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		issuedAt = &parsed
+	}
+	if expiresAtStr != "" {
+		parsed, err := time.Parse("2006-01-02", expiresAtStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expires_at, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		expiresAt = &parsed
+	}
+
+	if expiresAt != nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		if expiresAt.Before(today) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Credential has already expired"})
+			return nil, nil, false
+		}
+		if issuedAt != nil && expiresAt.Before(*issuedAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must not be before issued_at"})
+			return nil, nil, false
+		}
+	}
+
+	return issuedAt, expiresAt, true
+}
+
+// optionalString returns nil for blank strings
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxGeocodeAddressLength bounds the address accepted by the geocoding endpoint
+const maxGeocodeAddressLength = 500
+
+// GeocodingHandler exposes address lookups so clients can validate a location
+// before submitting it
+type GeocodingHandler struct {
+	geocodingService *utils.GeocodingService
+}
+
+// NewGeocodingHandler creates a new geocoding handler
+func NewGeocodingHandler(geocodingService *utils.GeocodingService) *GeocodingHandler {
+	return &GeocodingHandler{geocodingService: geocodingService}
+}
+
+// Geocode handles GET /api/geocode?address=
+func (h *GeocodingHandler) Geocode(c *gin.Context) {
+	address := strings.TrimSpace(c.Query("address"))
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+	if len(address) > maxGeocodeAddressLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is too long"})
+		return
+	}
+
+	location, err := h.geocodingService.Geocode(address)
+	if err != nil {
+		h.respondError(c, "GEOCODE", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// ReverseGeocode handles GET /api/geocode/reverse?lat=&lng=
+func (h *GeocodingHandler) ReverseGeocode(c *gin.Context) {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat must be a number between -90 and 90"})
+		return
+	}
+
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lng must be a number between -180 and 180"})
+		return
+	}
+
+	location, err := h.geocodingService.ReverseGeocode(lat, lng)
+	if err != nil {
+		h.respondError(c, "REVERSE_GEOCODE", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// respondError maps a geocoding failure to a response: no match is a 404, and an
+// unreachable or overloaded Nominatim is a 503 the client can retry
+func (h *GeocodingHandler) respondError(c *gin.Context, logTag string, err error) {
+	switch {
+	case errors.Is(err, utils.ErrNoGeocodingResults):
+		c.JSON(http.StatusNotFound, gin.H{"error": "No matching location found"})
+	case errors.Is(err, utils.ErrGeocodingUnavailable):
+		logging.Errorf(c.Request.Context(), "❌ %s: Geocoding service unavailable: %v", logTag, err)
+		c.Header("Retry-After", "5")
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Geocoding service is temporarily unavailable"})
+	default:
+		logging.Errorf(c.Request.Context(), "❌ %s: Geocoding failed: %v", logTag, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up location"})
+	}
+}
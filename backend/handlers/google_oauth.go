@@ -11,6 +11,7 @@ import (
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +25,7 @@ type GoogleOAuthHandler struct {
 	AdminService        *models.AdminService
 	OAuthAccountService *models.OAuthAccountService
 	RoleService         *models.RoleService
+	RefreshTokens       *models.RefreshTokenService
 	EmailService        *services.EmailService
 	config              *config.Config
 }
@@ -35,6 +37,7 @@ func NewGoogleOAuthHandler(
 	adminService *models.AdminService,
 	oauthAccountService *models.OAuthAccountService,
 	roleService *models.RoleService,
+	refreshTokens *models.RefreshTokenService,
 	emailService *services.EmailService,
 	config *config.Config,
 ) *GoogleOAuthHandler {
@@ -44,6 +47,7 @@ func NewGoogleOAuthHandler(
 		AdminService:        adminService,
 		OAuthAccountService: oauthAccountService,
 		RoleService:         roleService,
+		RefreshTokens:       refreshTokens,
 		EmailService:        emailService,
 		config:              config,
 	}
@@ -78,8 +82,21 @@ func (h *GoogleOAuthHandler) GoogleAuth(c *gin.Context) {
 		return
 	}
 
-	// Check if user already exists by email
-	user, err := h.UserService.GetByEmail(googleUser.Email)
+	// Check if OAuth account is linked
+	oauthAccount, err := h.OAuthAccountService.GetByProviderAndUserID("google", googleUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+
+	// A linked Google account signs in to the user it was linked to, even if its
+	// email differs; otherwise match an existing user by email
+	var user *models.User
+	if oauthAccount != nil {
+		user, err = h.UserService.GetByID(oauthAccount.UserID)
+	} else {
+		user, err = h.UserService.GetByEmail(googleUser.Email)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
 		return
@@ -94,13 +111,6 @@ func (h *GoogleOAuthHandler) GoogleAuth(c *gin.Context) {
 		}
 	}
 
-	// Check if OAuth account is linked
-	oauthAccount, err := h.OAuthAccountService.GetByProviderAndUserID("google", googleUser.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
-	}
-
 	// If OAuth account doesn't exist, create it
 	if oauthAccount == nil {
 		oauthAccount = &models.OAuthAccount{
@@ -115,12 +125,20 @@ func (h *GoogleOAuthHandler) GoogleAuth(c *gin.Context) {
 	}
 
 	// Generate JWT token
-	token, err := middleware.GenerateJWT(user, h.UserService, h.config.JWT.Secret)
+	token, err := middleware.GenerateJWT(user, h.UserService, h.config.JWT)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
+	// Issue a refresh token so Google sign-ins can renew the access token too
+	refreshToken, err := h.RefreshTokens.Issue(user.ID, h.config.JWT.RefreshExpiry)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GOOGLE_AUTH: Failed to issue refresh token for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
 	// Get user roles
 	rolesData, err := h.UserService.GetUserRoles(user.ID)
 	if err != nil {
@@ -158,11 +176,75 @@ func (h *GoogleOAuthHandler) GoogleAuth(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user":  userProfile,
+		"token":         token,
+		"refresh_token": refreshToken,
+		"user":          userProfile,
 	})
 }
 
+// LinkGoogle handles POST /api/auth/google/link
+func (h *GoogleOAuthHandler) LinkGoogle(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	var req GoogleAuthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	googleUser, err := h.verifyGoogleCredential(req.Credential)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Google credential"})
+		return
+	}
+
+	oauthAccount, err := h.OAuthAccountService.GetByProviderAndUserID("google", googleUser.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LINK_GOOGLE: Failed to look up Google account for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if oauthAccount != nil {
+		if oauthAccount.UserID != userCtx.ID {
+			c.JSON(http.StatusConflict, gin.H{"error": "This Google account is linked to another user"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Google account is already linked", "provider": "google"})
+		return
+	}
+
+	providers, err := h.OAuthAccountService.LinkedProviders(userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LINK_GOOGLE: Failed to list linked providers for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	for _, provider := range providers {
+		if provider == "google" {
+			c.JSON(http.StatusConflict, gin.H{"error": "A different Google account is already linked. Unlink it first."})
+			return
+		}
+	}
+
+	oauthAccount = &models.OAuthAccount{
+		UserID:         userCtx.ID,
+		Provider:       "google",
+		ProviderUserID: googleUser.ID,
+	}
+	if err := h.OAuthAccountService.Create(oauthAccount); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LINK_GOOGLE: Failed to link Google account for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link OAuth account"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔗 LINK_GOOGLE: Linked Google account to user %s", userCtx.ID)
+	c.JSON(http.StatusCreated, gin.H{"message": "Google account linked", "provider": "google"})
+}
+
 // verifyGoogleCredential verifies the Google credential and returns user info
 func (h *GoogleOAuthHandler) verifyGoogleCredential(credential string) (*GoogleUserInfo, error) {
 	// Parse and verify the JWT token structure and claims
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// healthCheckTimeout bounds each dependency probe so a hung connection can't stall
+// the health check
+const healthCheckTimeout = 2 * time.Second
+
+// Component statuses reported by the health check
+const (
+	componentUp       = "up"
+	componentDown     = "down"
+	componentDisabled = "disabled" // Optional and not configured; doesn't affect readiness
+)
+
+// HealthHandler reports whether the server and its dependencies are healthy
+type HealthHandler struct {
+	db      *sql.DB
+	redis   *redis.Client
+	version map[string]string
+}
+
+// NewHealthHandler creates a new health handler. db and redisClient are nil when the
+// server started without them. A missing database is reported as down; the server runs
+// without Redis by design, so a missing Redis is reported as disabled.
+func NewHealthHandler(db *sql.DB, redisClient *redis.Client, version map[string]string) *HealthHandler {
+	return &HealthHandler{
+		db:      db,
+		redis:   redisClient,
+		version: version,
+	}
+}
+
+// Live handles GET /health/live. It succeeds as long as the process can serve
+// requests, whatever the state of its dependencies.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready handles GET /health, probing the database and Redis. It returns 503 with a
+// component breakdown when any dependency is down; disabled ones don't count.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	components := gin.H{
+		"database": h.checkDatabase(c.Request.Context()),
+		"redis":    h.checkRedis(c.Request.Context()),
+	}
+
+	status, code := "ok", http.StatusOK
+	for _, componentStatus := range components {
+		if componentStatus == componentDown {
+			status, code = "degraded", http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	c.JSON(code, gin.H{
+		"status":     status,
+		"components": components,
+		"version":    h.version,
+	})
+}
+
+// checkDatabase pings the database with a short timeout
+func (h *HealthHandler) checkDatabase(ctx context.Context) string {
+	if h.db == nil {
+		return componentDown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := h.db.PingContext(ctx); err != nil {
+		return componentDown
+	}
+	return componentUp
+}
+
+// checkRedis pings Redis with a short timeout
+func (h *HealthHandler) checkRedis(ctx context.Context) string {
+	if h.redis == nil {
+		return componentDisabled
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := h.redis.Ping(ctx).Err(); err != nil {
+		return componentDown
+	}
+	return componentUp
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// serveHealth runs the readiness check and decodes its component breakdown
+func serveHealth(t *testing.T, h *HealthHandler) (int, map[string]string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/health", h.Ready)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var body struct {
+		Components map[string]string `json:"components"`
+	}
+	if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode %s: %v", response.Body.String(), err)
+	}
+	return response.Code, body.Components
+}
+
+func TestReadyWithoutRedisReportsItDisabled(t *testing.T) {
+	db, _ := fakesql.Open()
+	defer db.Close()
+
+	code, components := serveHealth(t, NewHealthHandler(db, nil, nil))
+
+	if code != http.StatusOK {
+		t.Errorf("status = %d, want 200", code)
+	}
+	if components["database"] != componentUp || components["redis"] != componentDisabled {
+		t.Errorf("components = %v, want the database up and Redis disabled", components)
+	}
+}
+
+func TestReadyFailsWithoutTheDatabase(t *testing.T) {
+	code, components := serveHealth(t, NewHealthHandler(nil, nil, nil))
+
+	if code != http.StatusServiceUnavailable || components["database"] != componentDown {
+		t.Errorf("status = %d, components = %v; want 503 with the database down", code, components)
+	}
+}
+
+func TestReadyFailsWhenConfiguredRedisIsUnreachable(t *testing.T) {
+	db, _ := fakesql.Open()
+	defer db.Close()
+	// Nothing listens on port 1, so the ping is refused
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 100 * time.Millisecond, MaxRetries: -1})
+	defer redisClient.Close()
+
+	code, components := serveHealth(t, NewHealthHandler(db, redisClient, nil))
+
+	if code != http.StatusServiceUnavailable || components["redis"] != componentDown {
+		t.Errorf("status = %d, components = %v; want 503 with Redis down", code, components)
+	}
+}
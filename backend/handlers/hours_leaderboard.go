@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+// UpdateLeaderboardVisibilityRequest represents a request to opt in to or out of the hours leaderboard
+type UpdateLeaderboardVisibilityRequest struct {
+	Visible *bool `json:"visible" binding:"required"`
+}
+
+// GetHoursLeaderboard handles GET /api/volunteers/hours-leaderboard
+func (h *TaskHandler) GetHoursLeaderboard(c *gin.Context) {
+	limit := defaultLeaderboardLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > maxLeaderboardLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+			return
+		}
+		limit = parsed
+	}
+
+	var projectID *uuid.UUID
+	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
+		parsed, err := uuid.Parse(projectIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+
+		project, err := h.projectService.GetByID(parsed)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ GET_HOURS_LEADERBOARD: Failed to get project %s: %v", parsed, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+			return
+		}
+		if project == nil {
+			respondNotFound(c, "Project")
+			return
+		}
+		projectID = &parsed
+	}
+
+	period := c.DefaultQuery("period", "all")
+	from, to, ok := parseLeaderboardPeriod(c, period)
+	if !ok {
+		return
+	}
+
+	entries, err := h.taskTimeLogService.GetHoursLeaderboard(projectID, from, to, limit)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_HOURS_LEADERBOARD: Failed to get leaderboard: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get hours leaderboard"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"leaderboard": entries,
+		"project_id":  projectID,
+		"period":      period,
+		"from":        from,
+		"to":          to,
+	})
+}
+
+// GetLeaderboardVisibility handles GET /api/volunteers/me/leaderboard-visibility
+func (h *TaskHandler) GetLeaderboardVisibility(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile required"})
+		return
+	}
+
+	visible, err := h.volunteerService.GetLeaderboardVisibility(volunteer.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_LEADERBOARD_VISIBILITY: Failed to get visibility for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get leaderboard visibility"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"visible": visible})
+}
+
+// UpdateLeaderboardVisibility handles PUT /api/volunteers/me/leaderboard-visibility
+func (h *TaskHandler) UpdateLeaderboardVisibility(c *gin.Context) {
+	var req UpdateLeaderboardVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile required"})
+		return
+	}
+
+	if err := h.volunteerService.UpdateLeaderboardVisibility(volunteer.ID, *req.Visible); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Volunteer")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_LEADERBOARD_VISIBILITY: Failed to update visibility for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update leaderboard visibility"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Leaderboard visibility updated successfully",
+		"visible": *req.Visible,
+	})
+}
+
+// parseLeaderboardPeriod resolves a named period (week, month, year, all) or an
+// explicit from/to range (YYYY-MM-DD, inclusive) into date bounds. Explicit dates
+// take precedence over the period. It writes a 400 and returns false if invalid.
+func parseLeaderboardPeriod(c *gin.Context, period string) (*time.Time, *time.Time, bool) {
+	var from, to *time.Time
+
+	now := time.Now()
+	switch period {
+	case "week":
+		start := now.AddDate(0, 0, -7)
+		from = &start
+	case "month":
+		start := now.AddDate(0, -1, 0)
+		from = &start
+	case "year":
+		start := now.AddDate(-1, 0, 0)
+		from = &start
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period must be one of week, month, year, all"})
+		return nil, nil, false
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return nil, nil, false
+		}
+		to = &parsed
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to date must not be before from date"})
+		return nil, nil, false
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// idempotencyKeyHeader lets clients retry create requests without creating duplicates
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyTTL is how long a key is remembered after its first use
+const idempotencyKeyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLength matches the idempotency_keys.idempotency_key column
+const maxIdempotencyKeyLength = 255
+
+// idempotentCreate is a create request's hold on its Idempotency-Key. A nil hold,
+// for requests sent without the header, does nothing.
+type idempotentCreate struct {
+	service *models.IdempotencyKeyService
+	userID  uuid.UUID
+	scope   string
+	key     string
+}
+
+// beginIdempotentCreate reserves the request's Idempotency-Key, if it has one. The
+// request body must have been bound with ShouldBindBodyWith so it can be compared
+// with the key's first use. If the same request was already completed, replayID is
+// the entity it created. ok is false, with the error response written, when the key
+// is invalid, was used for a different request or is still being processed.
+func beginIdempotentCreate(c *gin.Context, service *models.IdempotencyKeyService, userID uuid.UUID, scope string) (hold *idempotentCreate, replayID *uuid.UUID, ok bool) {
+	key := strings.TrimSpace(c.GetHeader(idempotencyKeyHeader))
+	if key == "" {
+		return nil, nil, true
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+		return nil, nil, false
+	}
+
+	requestHash := idempotencyRequestHash(c)
+	existing, err := service.Reserve(userID, scope, key, requestHash, idempotencyKeyTTL)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ IDEMPOTENCY: Failed to reserve key for user %s: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+		return nil, nil, false
+	}
+	if existing == nil {
+		return &idempotentCreate{service: service, userID: userID, scope: scope, key: key}, nil, true
+	}
+
+	if existing.RequestHash != requestHash {
+		c.JSON(http.StatusConflict, gin.H{"error": "Idempotency-Key was already used for a different request"})
+		return nil, nil, false
+	}
+	if existing.EntityID == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still being processed"})
+		return nil, nil, false
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	return nil, existing.EntityID, true
+}
+
+// complete records the entity created by the request holding the key
+func (h *idempotentCreate) complete(c *gin.Context, entityID uuid.UUID) {
+	if h == nil {
+		return
+	}
+	if err := h.service.Complete(h.userID, h.scope, h.key, entityID); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ IDEMPOTENCY: Failed to record %s %s for key: %v", h.scope, entityID, err)
+	}
+}
+
+// release frees the key after the request failed, so the client can retry with it
+func (h *idempotentCreate) release(c *gin.Context) {
+	if h == nil {
+		return
+	}
+	if err := h.service.Release(h.userID, h.scope, h.key); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ IDEMPOTENCY: Failed to release key for user %s: %v", h.userID, err)
+	}
+}
+
+// idempotencyRequestHash fingerprints the request path and JSON body. The body is
+// re-encoded so formatting and key order don't make a retry look like a new request.
+func idempotencyRequestHash(c *gin.Context) string {
+	var body []byte
+	if cached, exists := c.Get(gin.BodyBytesKey); exists {
+		body, _ = cached.([]byte)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if canonical, err := json.Marshal(decoded); err == nil {
+			body = canonical
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	hash.Write(bytes.TrimSpace(body))
+	return hex.EncodeToString(hash.Sum(nil))
+}
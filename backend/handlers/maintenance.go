@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler handles maintenance mode requests
+type MaintenanceHandler struct {
+	service *models.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new maintenance handler
+func NewMaintenanceHandler(service *models.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		service: service,
+	}
+}
+
+// UpdateMaintenanceRequest represents a request to toggle maintenance mode
+type UpdateMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Mode    string `json:"mode"` // read_only (default) or offline
+	Message string `json:"message"`
+}
+
+// GetMaintenance handles GET /api/admin/maintenance
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	state, err := h.service.Get()
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_MAINTENANCE: Failed to get maintenance state: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get maintenance state"})
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// UpdateMaintenance handles PUT /api/admin/maintenance
+func (h *MaintenanceHandler) UpdateMaintenance(c *gin.Context) {
+	var req UpdateMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	mode := models.MaintenanceModeType(req.Mode)
+	switch mode {
+	case "":
+		mode = models.MaintenanceModeReadOnly
+	case models.MaintenanceModeReadOnly, models.MaintenanceModeOffline:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be read_only or offline"})
+		return
+	}
+
+	state := &models.MaintenanceState{
+		Enabled:         *req.Enabled,
+		Mode:            mode,
+		Message:         strings.TrimSpace(req.Message),
+		UpdatedByUserID: &userCtx.ID,
+	}
+
+	if err := h.service.Set(state); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_MAINTENANCE: Failed to update maintenance state: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update maintenance state"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🚧 UPDATE_MAINTENANCE: User %s set maintenance enabled=%t mode=%s", userCtx.ID, state.Enabled, state.Mode)
+	c.JSON(http.StatusOK, state)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,7 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 	"civicweave/backend/services"
 )
 
@@ -52,53 +55,15 @@ func (h *SkillMatchingHandler) GetCandidateVolunteers(c *gin.Context) {
 	}
 
 	// Query pre-calculated matches from projects (super fast!)
-	query := `
-		SELECT 
-			v.id, v.name, v.phone, v.location_address,
-			m.match_score, m.jaccard_index, 
-			m.matched_skill_ids, m.matched_skill_count,
-			m.calculated_at
-		FROM volunteer_project_matches m
-		JOIN volunteers v ON m.volunteer_id = v.id
-		WHERE m.project_id = $1 
-			AND m.match_score >= $2
-			AND v.skills_visible = true
-		ORDER BY m.match_score DESC, m.matched_skill_count DESC
-		LIMIT $3
-	`
-
-	rows, err := h.db.Query(query, initiativeID, minScore, limit)
+	volunteers, err := h.matchingService.RankCandidateVolunteers(initiativeID, minScore, limit)
 	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CANDIDATE_VOLUNTEERS: Failed to rank candidates for project %s: %v", initiativeID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get candidate volunteers"})
 		return
 	}
-	defer rows.Close()
 
 	var candidates []gin.H
-	for rows.Next() {
-		var volunteer struct {
-			ID                uuid.UUID `json:"id"`
-			Name              string    `json:"name"`
-			Phone             string    `json:"phone"`
-			LocationAddress   string    `json:"location_address"`
-			MatchScore        float64   `json:"match_score"`
-			JaccardIndex      float64   `json:"jaccard_index"`
-			MatchedSkillIDs   []int     `json:"matched_skill_ids"`
-			MatchedSkillCount int       `json:"matched_skill_count"`
-			CalculatedAt      time.Time `json:"calculated_at"`
-		}
-
-		err := rows.Scan(
-			&volunteer.ID, &volunteer.Name, &volunteer.Phone, &volunteer.LocationAddress,
-			&volunteer.MatchScore, &volunteer.JaccardIndex,
-			&volunteer.MatchedSkillIDs, &volunteer.MatchedSkillCount,
-			&volunteer.CalculatedAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan volunteer data"})
-			return
-		}
-
+	for _, volunteer := range volunteers {
 		candidates = append(candidates, gin.H{
 			"volunteer":        volunteer,
 			"match_percentage": int(volunteer.MatchScore * 100),
@@ -140,18 +105,39 @@ func (h *SkillMatchingHandler) GetRecommendedInitiatives(c *gin.Context) {
 		minScore = 0.2
 	}
 
+	// Serve from the match cache, computing live only when nothing is cached yet
+	computedLive, err := h.matchingService.EnsureVolunteerMatches(volunteerUUID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RECOMMENDED_PROJECTS: Failed to compute matches for volunteer %s: %v", volunteerUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recommended initiatives"})
+		return
+	}
+
 	// Query pre-calculated matches from projects
 	query := `
 		SELECT 
 			p.id, p.title, p.description, p.location_address,
 			p.start_date, p.end_date, p.project_status,
 			m.match_score, m.matched_skill_count,
-			m.matched_skill_ids, m.calculated_at
+			m.matched_skill_ids, m.explanation, m.calculated_at
 		FROM volunteer_project_matches m
 		JOIN projects p ON m.project_id = p.id
 		WHERE m.volunteer_id = $1 
 			AND m.match_score >= $2
 			AND p.project_status = 'active'
+			AND p.deleted_at IS NULL
+			-- Skip projects requiring credentials the volunteer doesn't hold
+			AND NOT EXISTS (
+				SELECT 1 FROM project_required_credentials prc
+				WHERE prc.project_id = p.id
+				  AND NOT EXISTS (
+					SELECT 1 FROM volunteer_credentials vc
+					WHERE vc.volunteer_id = m.volunteer_id
+					  AND vc.credential_type = prc.credential_type
+					  AND vc.status = 'verified'
+					  AND (vc.expires_at IS NULL OR vc.expires_at >= CURRENT_DATE)
+				  )
+			)
 		ORDER BY m.match_score DESC, m.matched_skill_count DESC
 		LIMIT $3
 	`
@@ -164,31 +150,42 @@ func (h *SkillMatchingHandler) GetRecommendedInitiatives(c *gin.Context) {
 	defer rows.Close()
 
 	var recommendations []gin.H
+	// computed_at is the oldest match served, so clients can show how fresh the list is
+	var computedAt *time.Time
 	for rows.Next() {
 		var project struct {
-			ID                uuid.UUID  `json:"id"`
-			Title             string     `json:"title"`
-			Description       string     `json:"description"`
-			LocationAddress   string     `json:"location_address"`
-			StartDate         *time.Time `json:"start_date"`
-			EndDate           *time.Time `json:"end_date"`
-			ProjectStatus     string     `json:"project_status"`
-			MatchScore        float64    `json:"match_score"`
-			MatchedSkillCount int        `json:"matched_skill_count"`
-			MatchedSkillIDs   []int      `json:"matched_skill_ids"`
-			CalculatedAt      time.Time  `json:"calculated_at"`
+			ID                uuid.UUID       `json:"id"`
+			Title             string          `json:"title"`
+			Description       string          `json:"description"`
+			LocationAddress   string          `json:"location_address"`
+			StartDate         *time.Time      `json:"start_date"`
+			EndDate           *time.Time      `json:"end_date"`
+			ProjectStatus     string          `json:"project_status"`
+			MatchScore        float64         `json:"match_score"`
+			MatchedSkillCount int             `json:"matched_skill_count"`
+			MatchedSkillIDs   []int           `json:"matched_skill_ids"`
+			Explanation       json.RawMessage `json:"explanation,omitempty"`
+			CalculatedAt      time.Time       `json:"calculated_at"`
 		}
+		var explanationJSON []byte
 
 		err := rows.Scan(
 			&project.ID, &project.Title, &project.Description, &project.LocationAddress,
 			&project.StartDate, &project.EndDate, &project.ProjectStatus,
 			&project.MatchScore, &project.MatchedSkillCount,
-			&project.MatchedSkillIDs, &project.CalculatedAt,
+			&project.MatchedSkillIDs, &explanationJSON, &project.CalculatedAt,
 		)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan initiative data"})
 			return
 		}
+		if len(explanationJSON) > 0 {
+			project.Explanation = explanationJSON
+		}
+		if computedAt == nil || project.CalculatedAt.Before(*computedAt) {
+			calculatedAt := project.CalculatedAt
+			computedAt = &calculatedAt
+		}
 
 		recommendations = append(recommendations, gin.H{
 			"project":          project,
@@ -201,10 +198,12 @@ func (h *SkillMatchingHandler) GetRecommendedInitiatives(c *gin.Context) {
 		"count":           len(recommendations),
 		"volunteer_id":    volunteerUUID,
 		"min_score":       minScore,
+		"computed_at":     computedAt,
+		"computed_live":   computedLive,
 	})
 }
 
-// GetMatchExplanation handles GET /api/matching/explanation/:volunteerId/:initiativeId
+// GetMatchExplanation handles GET /api/matching/explanation/:volunteerId/:projectId
 func (h *SkillMatchingHandler) GetMatchExplanation(c *gin.Context) {
 	volunteerIDStr := c.Param("volunteerId")
 	volunteerID, err := uuid.Parse(volunteerIDStr)
@@ -213,88 +212,81 @@ func (h *SkillMatchingHandler) GetMatchExplanation(c *gin.Context) {
 		return
 	}
 
-	initiativeIDStr := c.Param("initiativeId")
-	initiativeID, err := uuid.Parse(initiativeIDStr)
+	projectIDStr := c.Param("projectId")
+	projectID, err := uuid.Parse(projectIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid initiative ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
 		return
 	}
 
-	// Get match details
-	var match struct {
-		MatchScore        float64   `json:"match_score"`
-		JaccardIndex      float64   `json:"jaccard_index"`
-		MatchedSkillIDs   []int     `json:"matched_skill_ids"`
-		MatchedSkillCount int       `json:"matched_skill_count"`
-		CalculatedAt      time.Time `json:"calculated_at"`
+	// Recomputed from the same skill vectors the batch matcher ranks with
+	explanation, err := h.matchingService.ExplainProjectMatch(volunteerID, projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ MATCH_EXPLANATION: Failed to explain match for volunteer %s and project %s: %v", volunteerID, projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to explain match"})
+		return
 	}
 
-	err = h.db.QueryRow(`
-		SELECT match_score, jaccard_index, matched_skill_ids, matched_skill_count, calculated_at
-		FROM volunteer_project_matches
-		WHERE volunteer_id = $1 AND project_id = $2
-	`, volunteerID, initiativeID).Scan(
-		&match.MatchScore, &match.JaccardIndex,
-		&match.MatchedSkillIDs, &match.MatchedSkillCount,
-		&match.CalculatedAt,
-	)
+	// Without required skills there is nothing to match against
+	if explanation.TotalRequired == 0 && explanation.StoredScore == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"explanation": explanation})
+}
 
+// GetMyMatches handles GET /api/volunteers/me/matches
+func (h *SkillMatchingHandler) GetMyMatches(c *gin.Context) {
+	// This is an alias for GetRecommendedInitiatives for backward compatibility
+	h.GetRecommendedInitiatives(c)
+}
+
+// GetTeamSkillGap handles GET /api/projects/:id/skill-gap
+func (h *SkillMatchingHandler) GetTeamSkillGap(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Match not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
 		return
 	}
 
-	// Get skill names for matched skills
-	skillNames := make([]string, 0, len(match.MatchedSkillIDs))
-	if len(match.MatchedSkillIDs) > 0 {
-		skillQuery := `
-			SELECT skill_name 
-			FROM skill_taxonomy 
-			WHERE id = ANY($1)
-			ORDER BY skill_name
-		`
-		rows, err := h.db.Query(skillQuery, match.MatchedSkillIDs)
-		if err == nil {
-			defer rows.Close()
-			for rows.Next() {
-				var skillName string
-				if err := rows.Scan(&skillName); err == nil {
-					skillNames = append(skillNames, skillName)
-				}
-			}
+	// Checked before loading the project, so outsiders can't tell whether it exists
+	projectService := models.NewProjectService(h.db)
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) {
+		isTeamLead, err := projectService.IsTeamLead(projectID, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+			return
+		}
+		if !isTeamLead {
+			respondProjectForbidden(c, "Project", projectID, userCtx, projectService, "Only the project team lead or an admin can view its skill gap")
+			return
 		}
 	}
 
-	// Get project required skills
-	projectSkills, err := h.taxonomyService.GetProjectSkills(initiativeID)
+	project, err := projectService.GetByID(projectID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project skills"})
+		logging.Errorf(c.Request.Context(), "❌ SKILL_GAP: Failed to get project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		respondNotFound(c, "Project")
 		return
 	}
 
-	// Get volunteer skills
-	volunteerSkills, err := h.taxonomyService.GetVolunteerSkills(volunteerID)
+	analysis, err := h.matchingService.AnalyzeTeamSkillGap(projectID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get volunteer skills"})
+		logging.Errorf(c.Request.Context(), "❌ SKILL_GAP: Failed to analyze project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to analyze team skill gap"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"match_details":     match,
-		"matched_skills":    skillNames,
-		"project_skills": projectSkills,
-		"volunteer_skills":  volunteerSkills,
-		"explanation": gin.H{
-			"match_percentage":    int(match.MatchScore * 100),
-			"skills_matched":      match.MatchedSkillCount,
-			"total_required":      len(projectSkills),
-			"coverage_percentage": int(float64(match.MatchedSkillCount) / float64(len(projectSkills)) * 100),
-		},
-	})
-}
-
-// GetMyMatches handles GET /api/volunteers/me/matches
-func (h *SkillMatchingHandler) GetMyMatches(c *gin.Context) {
-	// This is an alias for GetRecommendedInitiatives for backward compatibility
-	h.GetRecommendedInitiatives(c)
+	c.JSON(http.StatusOK, analysis)
 }
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MatchingRecalculationHandler handles on-demand match recalculation requests
+type MatchingRecalculationHandler struct {
+	service *models.MatchingRecalculationService
+}
+
+// NewMatchingRecalculationHandler creates a new matching recalculation handler
+func NewMatchingRecalculationHandler(service *models.MatchingRecalculationService) *MatchingRecalculationHandler {
+	return &MatchingRecalculationHandler{
+		service: service,
+	}
+}
+
+// RequestRecalculation handles POST /api/admin/matching/recalculate
+func (h *MatchingRecalculationHandler) RequestRecalculation(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	request, coalesced, err := h.service.Request(&userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REQUEST_MATCH_RECALCULATION: Failed to queue recalculation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue match recalculation"})
+		return
+	}
+
+	message := "Match recalculation queued"
+	if coalesced {
+		message = "A match recalculation is already queued"
+	}
+
+	logging.Printf(c.Request.Context(), "🔄 REQUEST_MATCH_RECALCULATION: User %s requested recalculation %s (coalesced: %t)", userCtx.ID, request.ID, coalesced)
+	c.JSON(http.StatusAccepted, gin.H{
+		"request":   request,
+		"coalesced": coalesced,
+		"message":   message,
+	})
+}
+
+// GetRecalculationStatus handles GET /api/admin/matching/recalculate
+func (h *MatchingRecalculationHandler) GetRecalculationStatus(c *gin.Context) {
+	request, err := h.service.GetLatest()
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_MATCH_RECALCULATION: Failed to get latest recalculation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get match recalculation status"})
+		return
+	}
+	if request == nil {
+		respondNotFound(c, "Match recalculation request")
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
-	"log"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -18,14 +25,21 @@ type MessageHandler struct {
 	messageService *models.MessageService
 	projectService *models.ProjectService
 	userService    *models.UserService
+	streamService  *services.MessageStreamService
+	config         *config.Config
 }
 
+// streamHeartbeatInterval keeps idle message streams from being closed by proxies
+const streamHeartbeatInterval = 30 * time.Second
+
 // NewMessageHandler creates a new message handler
-func NewMessageHandler(messageService *models.MessageService, projectService *models.ProjectService, userService *models.UserService) *MessageHandler {
+func NewMessageHandler(messageService *models.MessageService, projectService *models.ProjectService, userService *models.UserService, streamService *services.MessageStreamService, config *config.Config) *MessageHandler {
 	return &MessageHandler{
 		messageService: messageService,
 		projectService: projectService,
 		userService:    userService,
+		streamService:  streamService,
+		config:         config,
 	}
 }
 
@@ -83,7 +97,7 @@ func (h *MessageHandler) ListMessages(c *gin.Context) {
 	}
 
 	if !isTeamMember && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members and team leads can view messages"})
+		respondNotFound(c, "Project")
 		return
 	}
 
@@ -138,7 +152,7 @@ func (h *MessageHandler) GetRecentMessages(c *gin.Context) {
 	}
 
 	if !isTeamMember && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members and team leads can view messages"})
+		respondNotFound(c, "Project")
 		return
 	}
 
@@ -196,7 +210,7 @@ func (h *MessageHandler) GetNewMessages(c *gin.Context) {
 	}
 
 	if !isTeamMember && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members and team leads can view messages"})
+		respondNotFound(c, "Project")
 		return
 	}
 
@@ -246,7 +260,7 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 	}
 
 	if !isTeamMember && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members and team leads can send messages"})
+		respondNotFound(c, "Project")
 		return
 	}
 
@@ -262,12 +276,160 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
-	// TODO: Publish to Redis Pub/Sub for real-time updates
-	// This will be added in the messaging_service.go
+	// Push to clients connected to the project's message stream
+	h.streamService.PublishProjectMessage(message)
 
 	c.JSON(http.StatusCreated, message)
 }
 
+// StreamMessages handles GET /api/projects/:id/messages/stream
+// It streams messages sent to the project as server-sent events until the client disconnects.
+func (h *MessageHandler) StreamMessages(c *gin.Context) {
+	projectIDStr := c.Param("id")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !h.requireMessageAccess(c, projectID, userCtx.ID) {
+		return
+	}
+
+	if !h.streamService.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Real-time messaging is unavailable; poll /messages/new instead"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	events, closeSubscription, err := h.streamService.SubscribeProject(ctx, projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ STREAM_MESSAGES: Failed to subscribe to project %s: %v", projectID, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Real-time messaging is unavailable; poll /messages/new instead"})
+		return
+	}
+	defer closeSubscription()
+
+	if err := h.streamService.MarkOnline(ctx, projectID, userCtx.ID); err != nil {
+		logging.Errorf(ctx, "❌ STREAM_MESSAGES: Failed to mark user %s online in project %s: %v", userCtx.ID, projectID, err)
+	}
+	defer func() {
+		// The request context is already cancelled once the client disconnects
+		offlineCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := h.streamService.MarkOffline(offlineCtx, projectID, userCtx.ID); err != nil {
+			logging.Errorf(offlineCtx, "❌ STREAM_MESSAGES: Failed to mark user %s offline in project %s: %v", userCtx.ID, projectID, err)
+		}
+	}()
+
+	online, err := h.streamService.OnlineUsers(ctx, projectID)
+	if err != nil {
+		logging.Errorf(ctx, "❌ STREAM_MESSAGES: Failed to list online users for project %s: %v", projectID, err)
+		online = []uuid.UUID{userCtx.ID}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	// Tell the new client who is already here before relaying live events
+	c.SSEvent("presence_snapshot", gin.H{"online_user_ids": online})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	presence := time.NewTicker(services.PresenceHeartbeatInterval)
+	defer presence.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Name, event.Data)
+			return true
+		case <-presence.C:
+			if err := h.streamService.MarkOnline(ctx, projectID, userCtx.ID); err != nil {
+				logging.Errorf(ctx, "❌ STREAM_MESSAGES: Failed to refresh presence for user %s in project %s: %v", userCtx.ID, projectID, err)
+			}
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
+
+// SendTypingIndicator handles POST /api/projects/:id/messages/typing
+// It tells the project's stream subscribers that the user is typing. Nothing is
+// stored; the indicator expires on its own after a few seconds.
+func (h *MessageHandler) SendTypingIndicator(c *gin.Context) {
+	projectIDStr := c.Param("id")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !h.requireMessageAccess(c, projectID, userCtx.ID) {
+		return
+	}
+
+	if !h.streamService.Enabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Real-time messaging is unavailable"})
+		return
+	}
+
+	if err := h.streamService.PublishTyping(projectID, userCtx.ID); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ TYPING_INDICATOR: Failed to publish for project %s: %v", projectID, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Real-time messaging is unavailable"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// requireMessageAccess reports whether the user may read the project's
+// messages, writing an error response when they may not
+func (h *MessageHandler) requireMessageAccess(c *gin.Context, projectID, userID uuid.UUID) bool {
+	isTeamMember, err := h.projectService.IsTeamMember(projectID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return false
+	}
+
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return false
+	}
+
+	if !isTeamMember && !isTeamLead {
+		respondNotFound(c, "Project")
+		return false
+	}
+
+	return true
+}
+
 // EditMessage handles PUT /api/messages/:id
 func (h *MessageHandler) EditMessage(c *gin.Context) {
 	messageIDStr := c.Param("id")
@@ -303,21 +465,43 @@ func (h *MessageHandler) EditMessage(c *gin.Context) {
 		return
 	}
 
-	// Check if user can edit (sender and within 15 minutes)
-	canEdit, err := h.messageService.CanUserEdit(messageID, userCtx.ID)
+	// Messages the caller isn't a participant in are reported as missing
+	canSee, err := canSeeMessage(message, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check message access"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Message")
+		return
+	}
+
+	// Check if user can edit (sender and within the configured edit window)
+	editWindowMinutes := h.config.Messaging.EditWindowMinutes
+	if editWindowMinutes <= 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Message editing is disabled"})
+		return
+	}
+
+	canEdit, err := h.messageService.CanUserEdit(messageID, userCtx.ID, editWindowMinutes)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check edit permissions"})
 		return
 	}
 
 	if !canEdit {
-		c.JSON(http.StatusForbidden, gin.H{"error": "You can only edit your own messages within 15 minutes"})
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("You can only edit your own messages within %d minutes", editWindowMinutes)})
 		return
 	}
 
 	// Update message
 	message.MessageText = req.MessageText
-	if err := h.messageService.Update(message); err != nil {
+	// The update re-checks ownership and the edit window, in case either changed since the check above
+	if err := h.messageService.Update(message, userCtx.ID, editWindowMinutes); err != nil {
+		if err == models.ErrMessageEditConflict {
+			c.JSON(http.StatusConflict, gin.H{"error": "The message was changed, deleted, or can no longer be edited"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update message"})
 		return
 	}
@@ -343,10 +527,15 @@ func (h *MessageHandler) DeleteMessage(c *gin.Context) {
 
 	// Get message
 	message, err := h.messageService.GetByID(messageID)
-	if err != nil || message == nil {
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Message not found"})
 		return
 	}
+	if message == nil {
+		// Not sent yet: deleting a scheduled message cancels it
+		h.cancelScheduledMessage(c, messageID, userCtx.ID)
+		return
+	}
 
 	// Check if message is already deleted
 	if message.DeletedAt != nil {
@@ -354,6 +543,17 @@ func (h *MessageHandler) DeleteMessage(c *gin.Context) {
 		return
 	}
 
+	// Messages the caller isn't a participant in are reported as missing
+	canSee, err := canSeeMessage(message, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check message access"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Message")
+		return
+	}
+
 	// Check permissions (sender, project owner, or admin)
 	isSender := message.SenderID == userCtx.ID
 	var isProjectOwner bool
@@ -365,9 +565,9 @@ func (h *MessageHandler) DeleteMessage(c *gin.Context) {
 		return
 	}
 
-	isAdmin := userCtx.HasRole("admin")
+	canModerate := userCtx.HasPermission(models.PermissionModerateMessages)
 
-	if !isSender && !isProjectOwner && !isAdmin {
+	if !isSender && !isProjectOwner && !canModerate {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Only the sender, project lead, or admin can delete messages"})
 		return
 	}
@@ -381,6 +581,22 @@ func (h *MessageHandler) DeleteMessage(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Message deleted successfully"})
 }
 
+// cancelScheduledMessage cancels one of the caller's messages that hasn't been sent yet.
+// Other users' scheduled messages are reported as missing.
+func (h *MessageHandler) cancelScheduledMessage(c *gin.Context, messageID, userID uuid.UUID) {
+	if err := h.messageService.CancelScheduledMessage(messageID, userID); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Message")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ CANCEL_SCHEDULED_MESSAGE: Failed to cancel message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel scheduled message"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scheduled message cancelled"})
+}
+
 // MarkMessageAsRead handles POST /api/messages/:id/read
 func (h *MessageHandler) MarkMessageAsRead(c *gin.Context) {
 	messageIDStr := c.Param("id")
@@ -406,6 +622,85 @@ func (h *MessageHandler) MarkMessageAsRead(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Message marked as read"})
 }
 
+// GetMessageReceipts handles GET /api/messages/:id/receipts
+// Senders of direct messages see whether the recipient read them; team leads see
+// per-member read status for their project's messages. Admins see both.
+func (h *MessageHandler) GetMessageReceipts(c *gin.Context) {
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	message, err := h.messageService.GetByID(messageID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ MESSAGE_RECEIPTS: Failed to get message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get message"})
+		return
+	}
+	if message == nil || message.DeletedAt != nil {
+		respondNotFound(c, "Message")
+		return
+	}
+
+	canSee, err := canSeeMessage(message, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check message access"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Message")
+		return
+	}
+
+	if !userCtx.HasPermission(models.PermissionModerateMessages) {
+		var allowed bool
+		switch {
+		case message.RecipientUserID != nil:
+			allowed = message.SenderID == userCtx.ID
+		case message.ProjectID != nil:
+			allowed, err = h.projectService.IsTeamLead(*message.ProjectID, userCtx.ID)
+		case message.RecipientTeamID != nil:
+			allowed, err = h.projectService.IsTeamLead(*message.RecipientTeamID, userCtx.ID)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the sender of a direct message or the project's team lead can view read receipts"})
+			return
+		}
+	}
+
+	receipts, err := h.messageService.GetReceipts(message)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ MESSAGE_RECEIPTS: Failed to get receipts for message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get read receipts"})
+		return
+	}
+
+	readCount := 0
+	for _, receipt := range receipts {
+		if receipt.IsRead {
+			readCount++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message_id": message.ID,
+		"receipts":   receipts,
+		"read_count": readCount,
+		"total":      len(receipts),
+	})
+}
+
 // MarkAllAsRead handles POST /api/projects/:id/messages/read-all
 func (h *MessageHandler) MarkAllAsRead(c *gin.Context) {
 	projectIDStr := c.Param("id")
@@ -430,7 +725,7 @@ func (h *MessageHandler) MarkAllAsRead(c *gin.Context) {
 	}
 
 	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can mark messages as read"})
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only team members can mark messages as read")
 		return
 	}
 
@@ -494,8 +789,13 @@ type SendUniversalMessageRequest struct {
 	RecipientID   string  `json:"recipient_id" binding:"required"`
 	Subject       *string `json:"subject,omitempty"`
 	MessageText   string  `json:"message_text" binding:"required"`
+	// ScheduledAt delays delivery until the given time; past or absent times send immediately
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
 }
 
+// maxMessageScheduleAhead bounds how far in the future a message may be scheduled
+const maxMessageScheduleAhead = 365 * 24 * time.Hour
+
 // SendUniversalMessage handles POST /api/messages
 func (h *MessageHandler) SendUniversalMessage(c *gin.Context) {
 	var req SendUniversalMessageRequest
@@ -526,7 +826,7 @@ func (h *MessageHandler) SendUniversalMessage(c *gin.Context) {
 		MessageType: "general",
 	}
 
-	log.Printf("DEBUG: Creating message with recipient type: %s, recipient ID: %s", req.RecipientType, req.RecipientID)
+	logging.Printf(c.Request.Context(), "DEBUG: Creating message with recipient type: %s, recipient ID: %s", req.RecipientType, req.RecipientID)
 
 	switch req.RecipientType {
 	case "user":
@@ -569,18 +869,42 @@ func (h *MessageHandler) SendUniversalMessage(c *gin.Context) {
 		}
 
 		if !isTeamMember {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can send messages to this project/team"})
+			respondProjectForbidden(c, "Project", recipientID, userCtx, h.projectService, "Only team members can send messages to this project/team")
+			return
+		}
+	}
+
+	// Hold future-dated messages until the dispatcher sends them
+	if req.ScheduledAt != nil && req.ScheduledAt.After(time.Now()) {
+		if req.ScheduledAt.After(time.Now().Add(maxMessageScheduleAhead)) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Messages can be scheduled at most one year ahead"})
 			return
 		}
+
+		message.ScheduledAt = req.ScheduledAt
+		if err := h.messageService.ScheduleMessage(message); err != nil {
+			logging.Errorf(c.Request.Context(), "❌ SCHEDULE_MESSAGE: Failed to schedule message: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to schedule message"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message": message,
+			"status":  "scheduled",
+		})
+		return
 	}
 
-	log.Printf("DEBUG: About to call CreateUniversalMessage")
+	logging.Printf(c.Request.Context(), "DEBUG: About to call CreateUniversalMessage")
 	if err := h.messageService.CreateUniversalMessage(message); err != nil {
-		log.Printf("DEBUG: CreateUniversalMessage error: %v", err)
+		logging.Printf(c.Request.Context(), "DEBUG: CreateUniversalMessage error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
 		return
 	}
-	log.Printf("DEBUG: CreateUniversalMessage succeeded")
+	logging.Printf(c.Request.Context(), "DEBUG: CreateUniversalMessage succeeded")
+
+	// Push to clients connected to the project's message stream
+	h.streamService.PublishProjectMessage(message)
 
 	c.JSON(http.StatusCreated, message)
 }
@@ -615,11 +939,18 @@ func (h *MessageHandler) GetInbox(c *gin.Context) {
 		return
 	}
 
+	total, err := h.messageService.CountInbox(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count inbox messages"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"messages": messages,
 		"limit":    limit,
 		"offset":   offset,
 		"count":    len(messages),
+		"total":    total,
 	})
 }
 
@@ -653,14 +984,152 @@ func (h *MessageHandler) GetSentMessages(c *gin.Context) {
 		return
 	}
 
+	total, err := h.messageService.CountSentMessages(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count sent messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"messages": messages,
+		"limit":    limit,
+		"offset":   offset,
+		"count":    len(messages),
+		"total":    total,
+	})
+}
+
+// SearchMessages handles GET /api/messages/search
+func (h *MessageHandler) SearchMessages(c *gin.Context) {
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	filters, ok := parseMessageSearchFilters(c)
+	if !ok {
+		return
+	}
+
+	// Get pagination params
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	messages, err := h.messageService.SearchMessages(userCtx.ID, filters, limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ SEARCH_MESSAGES: Failed to search messages for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search messages"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"messages": messages,
+		"query":    filters.Query,
 		"limit":    limit,
 		"offset":   offset,
 		"count":    len(messages),
 	})
 }
 
+// parseMessageSearchFilters reads the q, sender_id, after, before and message_type
+// query params. Dates may be RFC 3339 timestamps or YYYY-MM-DD; a bare "before"
+// date includes that whole day. It writes a 400 and returns false if a param is
+// invalid or no filter was given.
+func parseMessageSearchFilters(c *gin.Context) (models.MessageSearchFilters, bool) {
+	var filters models.MessageSearchFilters
+
+	filters.Query = strings.TrimSpace(c.Query("q"))
+	if filters.Query != "" && len(filters.Query) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query must be at least 2 characters"})
+		return filters, false
+	}
+
+	if senderIDStr := c.Query("sender_id"); senderIDStr != "" {
+		senderID, err := uuid.Parse(senderIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sender ID"})
+			return filters, false
+		}
+		filters.SenderID = &senderID
+	}
+
+	parseTime := func(param string, endOfDay bool) (*time.Time, bool) {
+		value := c.Query(param)
+		if value == "" {
+			return nil, true
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return &t, true
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + ", expected RFC 3339 or YYYY-MM-DD"})
+			return nil, false
+		}
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return &t, true
+	}
+
+	var ok bool
+	if filters.After, ok = parseTime("after", false); !ok {
+		return filters, false
+	}
+	if filters.Before, ok = parseTime("before", true); !ok {
+		return filters, false
+	}
+	if filters.After != nil && filters.Before != nil && !filters.After.Before(*filters.Before) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "after must be earlier than before"})
+		return filters, false
+	}
+
+	filters.MessageType = c.Query("message_type")
+	switch filters.MessageType {
+	case "", "general", "task_done", "task_blocked", "task_takeover", models.TaskDigestMessageType:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message_type"})
+		return filters, false
+	}
+
+	if filters.Query == "" && filters.SenderID == nil && filters.After == nil && filters.Before == nil && filters.MessageType == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide a search query or at least one filter"})
+		return filters, false
+	}
+
+	return filters, true
+}
+
+// GetScheduledMessages handles GET /api/messages/scheduled
+func (h *MessageHandler) GetScheduledMessages(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	messages, err := h.messageService.ListScheduledMessages(userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_SCHEDULED_MESSAGES: Failed to list scheduled messages for user %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get scheduled messages"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"messages": messages,
+		"count":    len(messages),
+	})
+}
+
 // GetConversations handles GET /api/messages/conversations
 func (h *MessageHandler) GetConversations(c *gin.Context) {
 	// Get user context
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func searchContext(rawQuery string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/messages/search?"+rawQuery, nil)
+	return c, recorder
+}
+
+func TestParseMessageSearchFiltersMessageType(t *testing.T) {
+	tests := []struct {
+		messageType string
+		valid       bool
+	}{
+		{"general", true},
+		{"task_done", true},
+		{"task_blocked", true},
+		{"task_takeover", true},
+		{"task_digest", true},
+		{"broadcast", false},
+	}
+
+	for _, tt := range tests {
+		c, recorder := searchContext("message_type=" + tt.messageType)
+		filters, ok := parseMessageSearchFilters(c)
+		if ok != tt.valid {
+			t.Errorf("message_type=%s: ok = %v, want %v", tt.messageType, ok, tt.valid)
+			continue
+		}
+		if !ok && recorder.Code != http.StatusBadRequest {
+			t.Errorf("message_type=%s: status = %d, want 400", tt.messageType, recorder.Code)
+		}
+		if ok && filters.MessageType != tt.messageType {
+			t.Errorf("message_type=%s: filter = %q", tt.messageType, filters.MessageType)
+		}
+	}
+}
+
+func TestParseMessageSearchFiltersSenderAndDateRange(t *testing.T) {
+	senderID := "7f2c1b8e-4a6d-4c1e-9b3a-2d5e8f1a0c7b"
+	c, _ := searchContext("sender_id=" + senderID + "&after=2024-03-04&before=2024-03-10")
+
+	filters, ok := parseMessageSearchFilters(c)
+	if !ok {
+		t.Fatal("parseMessageSearchFilters() rejected valid filters")
+	}
+	if filters.SenderID == nil || filters.SenderID.String() != senderID {
+		t.Errorf("sender = %v, want %s", filters.SenderID, senderID)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); filters.After == nil || !filters.After.Equal(want) {
+		t.Errorf("after = %v, want %v", filters.After, want)
+	}
+	// A bare before date includes that whole day
+	if want := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC); filters.Before == nil || !filters.Before.Equal(want) {
+		t.Errorf("before = %v, want %v", filters.Before, want)
+	}
+}
+
+func TestParseMessageSearchFiltersRejectsInvalid(t *testing.T) {
+	for _, rawQuery := range []string{
+		"",
+		"q=a",
+		"sender_id=not-a-uuid",
+		"after=yesterday",
+		"after=2024-03-10&before=2024-03-01",
+	} {
+		c, recorder := searchContext(rawQuery)
+		if _, ok := parseMessageSearchFilters(c); ok {
+			t.Errorf("%q: accepted, want rejected", rawQuery)
+		} else if recorder.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400", rawQuery, recorder.Code)
+		}
+	}
+}
+
+func TestEditMessageReturnsConflictWhenEditedConcurrently(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	senderID := uuid.New()
+	messageID := uuid.New()
+	editedAt := time.Now().Add(-2 * time.Minute)
+	recorder.Rows("FROM project_messages WHERE id = $1", []string{
+		"id", "project_id", "sender_id", "recipient_user_id", "recipient_team_id", "subject", "message_text",
+		"task_id", "message_type", "message_scope", "created_at", "edited_at", "deleted_at", "scheduled_at",
+	}, []driver.Value{
+		messageID.String(), uuid.New().String(), senderID.String(), nil, nil, nil, "Meet at noon",
+		nil, "general", "project", time.Now().Add(-5 * time.Minute), editedAt, nil, nil,
+	})
+	recorder.Rows("CASE", []string{"can_edit"}, []driver.Value{true})
+	// The update returns no row, as when the message was edited again after it was read
+
+	h := &MessageHandler{
+		messageService: models.NewMessageService(db),
+		config:         &config.Config{Messaging: config.MessagingConfig{EditWindowMinutes: 15}},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/messages/:id", func(c *gin.Context) {
+		c.Set("user_id", senderID)
+		c.Set("user_email", "sender@example.com")
+		h.EditMessage(c)
+	})
+	response := httptest.NewRecorder()
+	body := strings.NewReader(`{"message_text":"Meet at one"}`)
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodPut, "/api/messages/"+messageID.String(), body))
+
+	if response.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409: %s", response.Code, response.Body.String())
+	}
+	if !recorder.Ran("UPDATE project_messages") {
+		t.Error("the versioned update did not run")
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReactionRequest represents an emoji reaction request
+type ReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+// AddReaction handles POST /api/messages/:id/reactions
+func (h *MessageHandler) AddReaction(c *gin.Context) {
+	var req ReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, message, ok := h.getReactableMessage(c)
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.AddReaction(message.ID, userCtx.ID, req.Emoji); err != nil {
+		if err == models.ErrInvalidReaction {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ ADD_REACTION: Failed to add reaction to message %s: %v", message.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add reaction"})
+		return
+	}
+
+	h.respondWithReactionCounts(c, http.StatusCreated, message.ID)
+}
+
+// GetReactions handles GET /api/messages/:id/reactions
+func (h *MessageHandler) GetReactions(c *gin.Context) {
+	_, message, ok := h.getReactableMessage(c)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.messageService.GetReactions(message.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_REACTIONS: Failed to get reactions for message %s: %v", message.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reactions"})
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, reaction := range reactions {
+		counts[reaction.Emoji]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message_id": message.ID,
+		"reactions":  counts,
+		"reactors":   reactions,
+	})
+}
+
+// RemoveReaction handles DELETE /api/messages/:id/reactions/:emoji
+func (h *MessageHandler) RemoveReaction(c *gin.Context) {
+	userCtx, message, ok := h.getReactableMessage(c)
+	if !ok {
+		return
+	}
+
+	if err := h.messageService.RemoveReaction(message.ID, userCtx.ID, c.Param("emoji")); err != nil {
+		if err == models.ErrInvalidReaction {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Reaction")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ REMOVE_REACTION: Failed to remove reaction from message %s: %v", message.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove reaction"})
+		return
+	}
+
+	h.respondWithReactionCounts(c, http.StatusOK, message.ID)
+}
+
+// getReactableMessage loads the message named by the :id param and checks the
+// caller can react to it: a member or lead of its project, or a party to the
+// direct message. It writes an error response and returns false otherwise.
+func (h *MessageHandler) getReactableMessage(c *gin.Context) (*middleware.UserContext, *models.ProjectMessage, bool) {
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return nil, nil, false
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return nil, nil, false
+	}
+
+	message, err := h.messageService.GetByID(messageID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_REACTABLE_MESSAGE: Failed to get message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get message"})
+		return nil, nil, false
+	}
+	if message == nil || message.DeletedAt != nil {
+		respondNotFound(c, "Message")
+		return nil, nil, false
+	}
+
+	canSee, err := canSeeMessage(message, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check message access"})
+		return nil, nil, false
+	}
+	if !canSee {
+		respondNotFound(c, "Message")
+		return nil, nil, false
+	}
+
+	return userCtx, message, true
+}
+
+// respondWithReactionCounts writes a message's current reaction counts
+func (h *MessageHandler) respondWithReactionCounts(c *gin.Context, status int, messageID uuid.UUID) {
+	counts, err := h.messageService.GetReactionCounts(messageID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_REACTION_COUNTS: Failed to count reactions for message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reactions"})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"message_id": messageID,
+		"reactions":  counts,
+	})
+}
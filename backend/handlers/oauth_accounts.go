@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnlinkOAuthProvider handles DELETE /api/auth/oauth/:provider
+func (h *AuthHandler) UnlinkOAuthProvider(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	provider := strings.ToLower(c.Param("provider"))
+	err := h.OAuthAccountService.Unlink(userCtx.ID, provider)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No " + provider + " account is linked"})
+		return
+	}
+	if errors.Is(err, models.ErrLastLoginMethod) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Set a password or link another account before unlinking your only login method"})
+		return
+	}
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UNLINK_OAUTH: Failed to unlink %s for user %s: %v", provider, userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink account"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔗 UNLINK_OAUTH: Unlinked %s from user %s", provider, userCtx.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlinked", "provider": provider})
+}
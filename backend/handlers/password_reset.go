@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// forgotPasswordResponse is returned whether or not the email belongs to an account,
+// so the endpoint can't be used to discover who is registered
+const forgotPasswordResponse = "If an account exists for that email, a password reset link has been sent"
+
+// ForgotPasswordRequest represents a request for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// ForgotPassword handles POST /api/auth/forgot-password
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.UserService.GetByEmail(strings.TrimSpace(req.Email))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ FORGOT_PASSWORD: Failed to look up user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if user == nil {
+		c.JSON(http.StatusOK, gin.H{"message": forgotPasswordResponse})
+		return
+	}
+
+	expiresIn := h.config.Auth.PasswordResetExpiry
+	token, err := h.PasswordResetTokens.Issue(user.ID, expiresIn)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ FORGOT_PASSWORD: Failed to issue reset token for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reset token"})
+		return
+	}
+
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s",
+		strings.TrimRight(h.config.Notifications.FrontendURL, "/"), url.QueryEscape(token))
+	if err := h.EmailService.SendPasswordResetEmail(user.Email, resetURL, expiresIn); err != nil {
+		// Reported the same way as success so the response doesn't reveal the account exists
+		logging.Errorf(c.Request.Context(), "❌ FORGOT_PASSWORD: Failed to send reset email to user %s: %v", user.ID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": forgotPasswordResponse})
+}
+
+// ResetPassword handles POST /api/auth/reset-password
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	userID, err := h.UserService.ResetPassword(req.Token, string(hashedPassword))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RESET_PASSWORD: Failed to reset password: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+	if userID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired reset token"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔑 RESET_PASSWORD: Password reset for user %s; existing sessions revoked", *userID)
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully. Please log in with your new password."})
+}
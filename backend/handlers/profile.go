@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// emailVerificationExpiry is how long email verification links stay valid
+const emailVerificationExpiry = 24 * time.Hour
+
+// profileEditableFields are the fields users may change through PUT /me. Anything
+// else, such as roles or verification status, is rejected.
+var profileEditableFields = map[string]bool{
+	"name":                true,
+	"phone":               true,
+	"location_address":    true,
+	"email":               true,
+	"email_notifications": true,
+	"task_digest_minutes": true,
+}
+
+// UpdateProfileRequest represents a user's update to their own profile. Omitted
+// fields are left unchanged.
+type UpdateProfileRequest struct {
+	Name               *string `json:"name"`
+	Phone              *string `json:"phone"`
+	LocationAddress    *string `json:"location_address"`
+	Email              *string `json:"email"`
+	EmailNotifications *bool   `json:"email_notifications"`
+	TaskDigestMinutes  *int    `json:"task_digest_minutes"`
+}
+
+// UpdateProfile handles PUT /api/me
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+
+	// Reject fields users can't set themselves rather than silently ignoring them
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Request body must be a JSON object"})
+		return
+	}
+	var rejected []string
+	for field := range fields {
+		if !profileEditableFields[field] {
+			rejected = append(rejected, field)
+		}
+	}
+	if len(rejected) > 0 {
+		sort.Strings(rejected)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":           fmt.Sprintf("These fields cannot be updated: %s", strings.Join(rejected, ", ")),
+			"rejected_fields": rejected,
+		})
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validate everything before changing anything
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		if trimmed == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "name cannot be empty"})
+			return
+		}
+		req.Name = &trimmed
+	}
+	if req.Phone != nil && *req.Phone != "" {
+		if err := models.ValidatePhone(*req.Phone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.TaskDigestMinutes != nil && (*req.TaskDigestMinutes < 0 || *req.TaskDigestMinutes > models.MaxTaskDigestMinutes) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "task_digest_minutes must be between 0 and 1440"})
+		return
+	}
+	if (req.Phone != nil || req.LocationAddress != nil) && !userCtx.HasRole("volunteer") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "phone and location_address are only available on volunteer profiles"})
+		return
+	}
+
+	user, err := h.UserService.GetByID(userCtx.ID)
+	if err != nil || user == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+
+	var newEmail string
+	if req.Email != nil {
+		newEmail = strings.TrimSpace(*req.Email)
+		if err := models.ValidateEmail(newEmail); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.EqualFold(newEmail, user.Email) {
+			newEmail = ""
+		} else {
+			inUse, err := h.UserService.EmailInUse(newEmail, user.ID)
+			if err != nil {
+				logging.Errorf(c.Request.Context(), "❌ UPDATE_PROFILE: Failed to check email for user %s: %v", user.ID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+				return
+			}
+			if inUse {
+				c.JSON(http.StatusConflict, gin.H{"error": "Email is already in use"})
+				return
+			}
+		}
+	}
+
+	update := &models.ProfileUpdate{
+		EmailNotifications:      req.EmailNotifications,
+		TaskDigestMinutes:       req.TaskDigestMinutes,
+		NewEmail:                newEmail,
+		EmailVerificationExpiry: emailVerificationExpiry,
+	}
+
+	// Apply the name and contact details to the volunteer or admin profile
+	if req.Name != nil || req.Phone != nil || req.LocationAddress != nil {
+		if userCtx.HasRole("volunteer") {
+			volunteer, err := h.VolunteerService.GetByUserID(user.ID)
+			if err != nil || volunteer == nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Volunteer profile not found"})
+				return
+			}
+			if req.Name != nil {
+				volunteer.Name = *req.Name
+			}
+			if req.Phone != nil {
+				volunteer.Phone = *req.Phone
+			}
+			if req.LocationAddress != nil && *req.LocationAddress != volunteer.LocationAddress {
+				volunteer.LocationAddress = *req.LocationAddress
+				volunteer.LocationLat, volunteer.LocationLng = nil, nil
+				if volunteer.LocationAddress != "" {
+					if lat, lng, _, err := h.GeocodingService.GeocodeAddress(volunteer.LocationAddress); err == nil {
+						volunteer.LocationLat, volunteer.LocationLng = &lat, &lng
+					}
+				}
+			}
+			update.Volunteer = volunteer
+		} else if userCtx.HasRole("admin") {
+			admin, err := h.AdminService.GetByUserID(user.ID)
+			if err != nil || admin == nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Admin profile not found"})
+				return
+			}
+			admin.Name = *req.Name
+			update.Admin = admin
+		}
+	}
+
+	// Every change commits together, so a failed email change leaves the profile as it was.
+	// When the email system is disabled, addresses aren't verified (as at registration)
+	// and the change applies immediately.
+	message := "Profile updated successfully"
+	var verifyEmail func(token string) error
+	if newEmail != "" && h.config.Features.EmailEnabled {
+		message = "Profile updated. Check your new email address for a link to confirm the change."
+		verifyEmail = func(token string) error {
+			verifyURL := fmt.Sprintf("%s/verify-email?token=%s",
+				strings.TrimRight(h.config.Notifications.FrontendURL, "/"), url.QueryEscape(token))
+			return h.EmailService.SendEmailChangeVerificationEmail(newEmail, verifyURL)
+		}
+	}
+
+	if err := h.UserService.UpdateProfile(user.ID, update, verifyEmail); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROFILE: Failed to update profile for user %s: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		return
+	}
+
+	userProfile, err := h.profileFor(userCtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
+		return
+	}
+	pendingEmail, err := h.UserService.GetPendingEmail(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       message,
+		"profile":       userProfile,
+		"pending_email": pendingEmail,
+	})
+}
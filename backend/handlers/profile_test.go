@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+	"civicweave/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestUpdateProfileRollsBackWhenTheEmailChangeFails(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	userID, volunteerID := uuid.New(), uuid.New()
+	now := time.Now()
+	recorder.Rows("FROM users WHERE id = $1", []string{"id", "email", "password_hash", "email_verified", "created_at", "updated_at"},
+		[]driver.Value{userID.String(), "sam@example.com", "hash", true, now, now})
+	recorder.Rows("SELECT EXISTS(SELECT 1 FROM users WHERE email = $1", []string{"exists"}, []driver.Value{false})
+	recorder.Rows("FROM volunteers WHERE user_id = $1", []string{
+		"id", "user_id", "name", "phone", "location_lat", "location_lng",
+		"location_address", "skills", "availability", "skills_visible", "consent_given", "created_at", "updated_at",
+	}, volunteerRow(volunteerID, userID))
+	recorder.Rows("UPDATE volunteers", []string{"updated_at"}, []driver.Value{now})
+
+	cfg := &config.Config{}
+	cfg.Features.EmailEnabled = true
+	h := &AuthHandler{
+		UserService:        models.NewUserService(db),
+		VolunteerService:   models.NewVolunteerService(db),
+		EmailVerifications: models.NewEmailVerificationTokenService(db),
+		// Mailgun isn't configured, so sending the verification email fails
+		EmailService: services.NewEmailService(&config.MailgunConfig{}),
+		config:       cfg,
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/me", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_email", "sam@example.com")
+		c.Set("user_roles", []string{"volunteer"})
+		h.UpdateProfile(c)
+	})
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodPut, "/api/me",
+		strings.NewReader(`{"name":"Samira","email":"samira@example.com"}`)))
+
+	if response.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500: %s", response.Code, response.Body.String())
+	}
+	// The name change ran in the same transaction as the email change, and was undone with it
+	for _, statement := range recorder.Statements() {
+		if strings.HasPrefix(strings.TrimSpace(statement.Query), "UPDATE") && !statement.InTx {
+			t.Errorf("%q ran outside the profile transaction", statement.Query)
+		}
+	}
+	if !recorder.Ran("UPDATE volunteers") || !recorder.Ran("SET pending_email") {
+		t.Error("the name and email changes were not attempted")
+	}
+	if recorder.Commits() != 0 || recorder.Rollbacks() != 1 {
+		t.Errorf("commits = %d, rollbacks = %d, want 0 and 1", recorder.Commits(), recorder.Rollbacks())
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,25 +13,34 @@ import (
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
 	"civicweave/backend/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
 // ProjectHandler handles project-related requests
 type ProjectHandler struct {
-	service          *models.ProjectService
-	geocodingService *utils.GeocodingService
-	config           *config.Config
+	service              *models.ProjectService
+	skillTaxonomyService *models.SkillTaxonomyService
+	idempotencyKeys      *models.IdempotencyKeyService
+	geocodingService     *utils.GeocodingService
+	webhooks             *services.WebhookDispatcher
+	config               *config.Config
 }
 
 // NewProjectHandler creates a new project handler
 func NewProjectHandler(service *models.ProjectService, geocodingService *utils.GeocodingService, config *config.Config) *ProjectHandler {
 	return &ProjectHandler{
-		service:          service,
-		geocodingService: geocodingService,
-		config:           config,
+		service:              service,
+		skillTaxonomyService: models.NewSkillTaxonomyService(service.GetDB()),
+		idempotencyKeys:      models.NewIdempotencyKeyService(service.GetDB()),
+		geocodingService:     geocodingService,
+		webhooks:             services.NewWebhookDispatcher(models.NewWebhookService(service.GetDB())),
+		config:               config,
 	}
 }
 
@@ -51,21 +62,41 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 		offset = 0
 	}
 
-	log.Printf("📋 LIST_PROJECTS: Fetching projects - limit=%d, offset=%d, status=%v, skills=%v", limit, offset, status, skillsParam)
+	logging.Printf(c.Request.Context(), "📋 LIST_PROJECTS: Fetching projects - limit=%d, offset=%d, status=%v, skills=%v", limit, offset, status, skillsParam)
 
 	// Get projects
 	var statusPtr *string
 	if status != "" {
 		statusPtr = &status
 	}
-	projects, err := h.service.List(limit, offset, statusPtr, skillsParam)
+	projects, err := h.service.List(limit, offset, statusPtr, skillsParam, includeDeletedProjects(c))
 	if err != nil {
-		log.Printf("❌ LIST_PROJECTS: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ LIST_PROJECTS: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects", "details": err.Error()})
 		return
 	}
 
-	log.Printf("✅ LIST_PROJECTS: Successfully fetched %d projects", len(projects))
+	// Drafts and archived projects are left out for callers outside their team
+	if userCtx, exists := middleware.GetUserFromContext(c); !exists || !userCtx.HasPermission(models.PermissionViewAllProjects) {
+		visible := projects[:0]
+		for _, project := range projects {
+			canSee := false
+			if exists {
+				canSee, err = canSeeProject(&project, userCtx, h.service)
+			}
+			if err != nil {
+				logging.Errorf(c.Request.Context(), "❌ LIST_PROJECTS: Failed to check visibility of project %s: %v", project.ID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
+				return
+			}
+			if canSee {
+				visible = append(visible, project)
+			}
+		}
+		projects = visible
+	}
+
+	logging.Printf(c.Request.Context(), "✅ LIST_PROJECTS: Successfully fetched %d projects", len(projects))
 
 	c.JSON(http.StatusOK, gin.H{
 		"projects": projects,
@@ -77,58 +108,62 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 
 // CreateProjectRequest represents project creation request
 type CreateProjectRequest struct {
-	Title           string   `json:"title" binding:"required"`
-	Description     string   `json:"description" binding:"required"`
-	RequiredSkills  []string `json:"required_skills"`
-	LocationAddress string   `json:"location_address"`
-	StartDate       string   `json:"start_date"`
-	EndDate         string   `json:"end_date"`
-	Status          string   `json:"status"`
-	ProjectStatus   string   `json:"project_status"`
-	TeamLeadID      *string  `json:"team_lead_id"`
+	Title                 string   `json:"title" binding:"required"`
+	Description           string   `json:"description" binding:"required"`
+	RequiredSkills        []string `json:"required_skills"`
+	LocationAddress       string   `json:"location_address"`
+	StartDate             string   `json:"start_date"`
+	EndDate               string   `json:"end_date"`
+	Status                string   `json:"status"`
+	ProjectStatus         string   `json:"project_status"`
+	TeamLeadID            *string  `json:"team_lead_id"`
+	MaxTeamSize           *int     `json:"max_team_size"`
+	AutoCloseApplications bool     `json:"auto_close_applications"`
+	TaskDigestMinutes     *int     `json:"task_digest_minutes"` // 0 posts every task notification on its own
 }
 
 // CreateProject handles POST /api/projects
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	var req CreateProjectRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("❌ CREATE_PROJECT: JSON binding error: %v", err)
+	// Bound this way so the body can be compared against an Idempotency-Key's first use
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT: JSON binding error: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	log.Printf("📝 CREATE_PROJECT: Request data - Title=%s, Description length=%d, Skills=%v", req.Title, len(req.Description), req.RequiredSkills)
+	logging.Printf(c.Request.Context(), "📝 CREATE_PROJECT: Request data - Title=%s, Description length=%d, Skills=%v", req.Title, len(req.Description), req.RequiredSkills)
+
+	requiredSkills, ok := h.validateRequiredSkills(c, req.RequiredSkills)
+	if !ok {
+		return
+	}
+
+	if req.MaxTeamSize != nil && *req.MaxTeamSize < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_team_size must be at least 1"})
+		return
+	}
+	if !validTaskDigestMinutes(c, req.TaskDigestMinutes) {
+		return
+	}
 
 	// Get user ID from JWT context
 	userCtx, exists := middleware.GetUserFromContext(c)
 	if !exists {
-		log.Printf("❌ CREATE_PROJECT: User not found in context")
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT: User not found in context")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
 		return
 	}
 
-	log.Printf("👤 CREATE_PROJECT: User=%s, Roles=%v", userCtx.ID, userCtx.Roles)
+	logging.Printf(c.Request.Context(), "👤 CREATE_PROJECT: User=%s, Roles=%v", userCtx.ID, userCtx.Roles)
 
-	// Check if user has permission to create projects (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
-		log.Printf("❌ CREATE_PROJECT: Insufficient permissions for user %s", userCtx.ID)
+	// Check if user has permission to create projects (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT: Insufficient permissions for user %s", userCtx.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to create projects"})
 		return
 	}
 
-	// Geocode location if provided
-	var locationLat, locationLng *float64
-	if req.LocationAddress != "" {
-		lat, lng, _, err := h.geocodingService.GeocodeAddress(req.LocationAddress)
-		if err != nil {
-			// Log error but don't fail creation
-			// Location can be added later
-		} else {
-			locationLat = &lat
-			locationLng = &lng
-		}
-	}
-
 	// Parse dates
 	var startDate, endDate *time.Time
 	if req.StartDate != "" {
@@ -159,28 +194,60 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 		}
 	}
 
+	idempotency, replayID, ok := beginIdempotentCreate(c, h.idempotencyKeys, userCtx.ID, models.IdempotencyScopeCreateProject)
+	if !ok {
+		return
+	}
+	if replayID != nil {
+		h.replayCreatedProject(c, *replayID)
+		return
+	}
+
 	project := &models.Project{
-		Title:            req.Title,
-		Description:      req.Description,
-		RequiredSkills:   req.RequiredSkills,
-		LocationLat:      locationLat,
-		LocationLng:      locationLng,
-		LocationAddress:  req.LocationAddress,
-		StartDate:        startDate,
-		EndDate:          endDate,
-		ProjectStatus:    projectStatus,
-		CreatedByAdminID: userCtx.ID,
-		TeamLeadID:       teamLeadID,
-	}
-
-	log.Printf("💾 CREATE_PROJECT: Attempting to save project to database")
+		Title:                 req.Title,
+		Description:           req.Description,
+		RequiredSkills:        requiredSkills,
+		LocationAddress:       req.LocationAddress,
+		StartDate:             startDate,
+		EndDate:               endDate,
+		ProjectStatus:         projectStatus,
+		CreatedByAdminID:      userCtx.ID,
+		TeamLeadID:            teamLeadID,
+		MaxTeamSize:           req.MaxTeamSize,
+		AutoCloseApplications: req.AutoCloseApplications,
+		TaskDigestMinutes:     req.TaskDigestMinutes,
+	}
+
+	// A failed lookup doesn't block creation; it's recorded in location_status
+	// so the project can be found and re-geocoded later
+	h.geocodeProjectLocation(c.Request.Context(), nil, project)
+
+	logging.Printf(c.Request.Context(), "💾 CREATE_PROJECT: Attempting to save project to database")
 	if err := h.service.Create(project); err != nil {
-		log.Printf("❌ CREATE_PROJECT: Database error: %v", err)
+		idempotency.release(c)
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project", "details": err.Error()})
 		return
 	}
+	idempotency.complete(c, project.ID)
+
+	logging.Printf(c.Request.Context(), "✅ CREATE_PROJECT: Successfully created project ID=%s", project.ID)
+	c.JSON(http.StatusCreated, project)
+}
+
+// replayCreatedProject responds to a retried CreateProject with the project the
+// original request created
+func (h *ProjectHandler) replayCreatedProject(c *gin.Context, projectID uuid.UUID) {
+	project, err := h.service.GetByID(projectID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The project created with this Idempotency-Key no longer exists"})
+		return
+	}
 
-	log.Printf("✅ CREATE_PROJECT: Successfully created project ID=%s", project.ID)
 	c.JSON(http.StatusCreated, project)
 }
 
@@ -193,20 +260,44 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		return
 	}
 
-	project, err := h.service.GetByID(id)
+	var project *models.Project
+	if includeDeletedProjects(c) {
+		project, err = h.service.GetByIDIncludingDeleted(id)
+	} else {
+		project, err = h.service.GetByID(id)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
 		return
 	}
 
 	if project == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		respondNotFound(c, "Project")
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+	if !requireProjectVisible(c, project, userCtx, h.service) {
 		return
 	}
 
 	c.JSON(http.StatusOK, project)
 }
 
+// includeDeletedProjects reports whether an admin asked for soft-deleted projects
+// with ?include_deleted=true. Other users never see them.
+func includeDeletedProjects(c *gin.Context) bool {
+	if c.Query("include_deleted") != "true" {
+		return false
+	}
+	userCtx, exists := middleware.GetUserFromContext(c)
+	return exists && userCtx.HasPermission(models.PermissionViewAllProjects)
+}
+
 // UpdateProject handles PUT /api/projects/:id
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	idStr := c.Param("id")
@@ -224,23 +315,26 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	}
 
 	// Check if user can edit project (team lead, admin, or creator)
-	canEdit, err := h.service.CanEditProject(id, userCtx.ID)
-	if err != nil {
-		log.Printf("❌ UPDATE_PROJECT: Failed to check edit permissions: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
-		return
+	canEdit := userCtx.HasPermission(models.PermissionManageAllProjects)
+	if !canEdit {
+		canEdit, err = h.service.CanEditProject(id, userCtx.ID)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT: Failed to check edit permissions: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
 	}
 
 	if !canEdit {
-		log.Printf("❌ UPDATE_PROJECT: User %s is not authorized to edit project %s", userCtx.ID, id)
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead, admin, or creator can edit this project"})
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT: User %s is not authorized to edit project %s", userCtx.ID, id)
+		respondProjectForbidden(c, "Project", id, userCtx, h.service, "Only project team lead, admin, or creator can edit this project")
 		return
 	}
 
 	// Get current project to check status restrictions
 	currentProject, err := h.service.GetByID(id)
 	if err != nil {
-		log.Printf("❌ UPDATE_PROJECT: Failed to get current project: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT: Failed to get current project: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get current project"})
 		return
 	}
@@ -255,93 +349,207 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	// Apply field restrictions based on current project status
-	restrictedProject := h.applyFieldRestrictions(currentProject, &updateData, userCtx.HasRole("admin"))
-	restrictedProject.ID = id
+	// Only validate required skills when the caller is changing them
+	if updateData.RequiredSkills != nil {
+		requiredSkills, ok := h.validateRequiredSkills(c, updateData.RequiredSkills)
+		if !ok {
+			return
+		}
+		updateData.RequiredSkills = requiredSkills
+	}
 
-	log.Printf("📝 UPDATE_PROJECT: User %s updating project %s (status: %s)", userCtx.ID, id, currentProject.ProjectStatus)
-	if err := h.service.Update(restrictedProject); err != nil {
-		log.Printf("❌ UPDATE_PROJECT: Failed to update project: %v", err)
+	if updateData.MaxTeamSize != nil && *updateData.MaxTeamSize < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_team_size must be at least 1"})
+		return
+	}
+	if !validTaskDigestMinutes(c, updateData.TaskDigestMinutes) {
+		return
+	}
+
+	// Drop changes to fields the project's current status doesn't allow editing
+	canOverride := userCtx.HasPermission(models.PermissionOverrideProjectRules)
+	restrictedProject := h.service.ApplyFieldRestrictions(currentProject, &updateData, canOverride)
+	restrictedProject.ID = id
+	h.geocodeProjectLocation(c.Request.Context(), currentProject, restrictedProject)
+
+	logging.Printf(c.Request.Context(), "📝 UPDATE_PROJECT: User %s updating project %s (status: %s)", userCtx.ID, id, currentProject.ProjectStatus)
+	if err := h.service.UpdateWithRestrictions(restrictedProject, canOverride); err != nil {
+		if errors.Is(err, models.ErrRestrictedField) {
+			// The project's status changed since it was read
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "code": "FIELD_RESTRICTED"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT: Failed to update project: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
 		return
 	}
 
-	log.Printf("✅ UPDATE_PROJECT: Successfully updated project %s", id)
+	logging.Printf(c.Request.Context(), "✅ UPDATE_PROJECT: Successfully updated project %s", id)
+	if restrictedProject.ProjectStatus != "" && restrictedProject.ProjectStatus != currentProject.ProjectStatus {
+		h.publishProjectStatusChanged(id, currentProject.ProjectStatus, restrictedProject.ProjectStatus, userCtx.ID, false)
+	}
+	// Raising max_team_size may have opened places for waitlisted volunteers
+	promoteFromWaitlist(c.Request.Context(), h.service.GetDB(), id, userCtx.ID)
+	closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), id, userCtx.ID)
 	c.JSON(http.StatusOK, restrictedProject)
 }
 
-// applyFieldRestrictions applies field-level restrictions based on project status
-func (h *ProjectHandler) applyFieldRestrictions(currentProject *models.Project, updateData *models.Project, isAdmin bool) *models.Project {
-	// Start with current project as base
-	restricted := *currentProject
-
-	// Admin can override all restrictions
-	if isAdmin {
-		// Admin can update all fields
-		restricted.Title = updateData.Title
-		restricted.Description = updateData.Description
-		restricted.RequiredSkills = updateData.RequiredSkills
-		restricted.LocationLat = updateData.LocationLat
-		restricted.LocationLng = updateData.LocationLng
-		restricted.LocationAddress = updateData.LocationAddress
-		restricted.StartDate = updateData.StartDate
-		restricted.EndDate = updateData.EndDate
-		restricted.TeamLeadID = updateData.TeamLeadID
-		restricted.BudgetTotal = updateData.BudgetTotal
-		restricted.BudgetSpent = updateData.BudgetSpent
-		restricted.AutoNotifyMatches = updateData.AutoNotifyMatches
-		return &restricted
-	}
-
-	// Apply restrictions based on current status
-	switch currentProject.ProjectStatus {
-	case models.ProjectStatusDraft:
-		// All fields editable in draft
-		restricted.Title = updateData.Title
-		restricted.Description = updateData.Description
-		restricted.RequiredSkills = updateData.RequiredSkills
-		restricted.LocationLat = updateData.LocationLat
-		restricted.LocationLng = updateData.LocationLng
-		restricted.LocationAddress = updateData.LocationAddress
-		restricted.StartDate = updateData.StartDate
-		restricted.EndDate = updateData.EndDate
-		restricted.TeamLeadID = updateData.TeamLeadID
-		restricted.BudgetTotal = updateData.BudgetTotal
-		restricted.BudgetSpent = updateData.BudgetSpent
-		restricted.AutoNotifyMatches = updateData.AutoNotifyMatches
-
-	case models.ProjectStatusRecruiting:
-		// Restrict title, description, required_skills
-		restricted.LocationLat = updateData.LocationLat
-		restricted.LocationLng = updateData.LocationLng
-		restricted.LocationAddress = updateData.LocationAddress
-		restricted.StartDate = updateData.StartDate
-		restricted.EndDate = updateData.EndDate
-		restricted.TeamLeadID = updateData.TeamLeadID
-		restricted.BudgetTotal = updateData.BudgetTotal
-		restricted.BudgetSpent = updateData.BudgetSpent
-		restricted.AutoNotifyMatches = updateData.AutoNotifyMatches
-
-	case models.ProjectStatusActive:
-		// Restrict title, required_skills, start_date
-		restricted.Description = updateData.Description
-		restricted.LocationLat = updateData.LocationLat
-		restricted.LocationLng = updateData.LocationLng
-		restricted.LocationAddress = updateData.LocationAddress
-		restricted.EndDate = updateData.EndDate
-		restricted.TeamLeadID = updateData.TeamLeadID
-		restricted.BudgetTotal = updateData.BudgetTotal
-		restricted.BudgetSpent = updateData.BudgetSpent
-		restricted.AutoNotifyMatches = updateData.AutoNotifyMatches
-
-	case models.ProjectStatusCompleted, models.ProjectStatusArchived:
-		// Only team_lead_id, end_date, budget_spent editable
-		restricted.EndDate = updateData.EndDate
-		restricted.TeamLeadID = updateData.TeamLeadID
-		restricted.BudgetSpent = updateData.BudgetSpent
-	}
-
-	return &restricted
+// validTaskDigestMinutes checks a project's task digest window is in range. It writes a
+// 400 and returns false if it isn't.
+func validTaskDigestMinutes(c *gin.Context, minutes *int) bool {
+	if minutes != nil && (*minutes < 0 || *minutes > models.MaxTaskDigestMinutes) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("task_digest_minutes must be between 0 and %d", models.MaxTaskDigestMinutes)})
+		return false
+	}
+	return true
+}
+
+// validateRequiredSkills checks required skills against the configured cap and the taxonomy.
+// It writes a 400 and returns false if they're invalid, otherwise returns the canonical names.
+func (h *ProjectHandler) validateRequiredSkills(c *gin.Context, skills []string) ([]string, bool) {
+	return validateRequiredSkills(c, h.skillTaxonomyService, h.config.Projects.MaxRequiredSkills, skills)
+}
+
+// validateRequiredSkills is shared by handlers that accept a project's required skills
+func validateRequiredSkills(c *gin.Context, skillTaxonomyService *models.SkillTaxonomyService, maxSkills int, skills []string) ([]string, bool) {
+	canonical, err := skillTaxonomyService.ValidateRequiredSkills(skills, maxSkills)
+	if err != nil {
+		respondRequiredSkillsError(c, err, maxSkills)
+		return nil, false
+	}
+	return canonical, true
+}
+
+// respondRequiredSkillsError writes the response for an error validating required skills
+func respondRequiredSkillsError(c *gin.Context, err error, maxSkills int) {
+	switch {
+	case errors.Is(err, models.ErrTooManySkills):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":               err.Error(),
+			"code":                "TOO_MANY_SKILLS",
+			"max_required_skills": maxSkills,
+		})
+	case errors.Is(err, models.ErrDuplicateSkill):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "DUPLICATE_SKILL"})
+	case errors.Is(err, models.ErrUnknownSkill):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "UNKNOWN_SKILL"})
+	case errors.Is(err, models.ErrEmptyField):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "EMPTY_SKILL"})
+	default:
+		logging.Errorf(c.Request.Context(), "❌ VALIDATE_REQUIRED_SKILLS: Failed to validate skills: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate required skills"})
+	}
+}
+
+// geocodeProjectLocation looks up coordinates for a project's address when they're
+// missing and records the outcome in LocationStatus. previous is the project before an
+// update, or nil on create: coordinates it already had are dropped when the address
+// changes, unless the update changed them too.
+func (h *ProjectHandler) geocodeProjectLocation(ctx context.Context, previous, project *models.Project) {
+	if previous != nil && hasStaleCoordinates(previous, project) {
+		project.LocationLat, project.LocationLng = nil, nil
+	}
+
+	switch {
+	case project.LocationLat != nil && project.LocationLng != nil:
+		project.LocationStatus = models.LocationStatusGeocoded
+	case strings.TrimSpace(project.LocationAddress) == "":
+		project.LocationLat, project.LocationLng = nil, nil
+		project.LocationStatus = models.LocationStatusNone
+	default:
+		lat, lng, _, err := h.geocodingService.GeocodeAddress(project.LocationAddress)
+		if err != nil {
+			logging.Warnf(ctx, "⚠️  GEOCODE_PROJECT: Failed to geocode address %q: %v", project.LocationAddress, err)
+			project.LocationLat, project.LocationLng = nil, nil
+			project.LocationStatus = models.LocationStatusFailed
+			return
+		}
+		project.LocationLat, project.LocationLng = &lat, &lng
+		project.LocationStatus = models.LocationStatusGeocoded
+	}
+}
+
+// hasStaleCoordinates reports whether an update moved a project's address but kept the
+// coordinates of the old one
+func hasStaleCoordinates(previous, project *models.Project) bool {
+	if previous.LocationLat == nil && previous.LocationLng == nil {
+		return false
+	}
+	if strings.TrimSpace(previous.LocationAddress) == strings.TrimSpace(project.LocationAddress) {
+		return false
+	}
+	return sameCoordinate(previous.LocationLat, project.LocationLat) && sameCoordinate(previous.LocationLng, project.LocationLng)
+}
+
+// sameCoordinate compares two optional coordinates by value
+func sameCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// RetryGeocoding handles POST /api/projects/:id/geocode
+func (h *ProjectHandler) RetryGeocoding(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	project, err := h.service.GetByID(id)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RETRY_GEOCODING: Failed to get project %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		respondNotFound(c, "Project")
+		return
+	}
+
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) {
+		isTeamLead, err := h.service.IsTeamLead(id, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
+		if !isTeamLead {
+			respondProjectForbidden(c, "Project", id, userCtx, h.service, "Only the project team lead or an admin can re-geocode this project")
+			return
+		}
+	}
+
+	if strings.TrimSpace(project.LocationAddress) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Project has no location address to geocode"})
+		return
+	}
+
+	// Drop any stale coordinates so the address is looked up again
+	project.LocationLat, project.LocationLng = nil, nil
+	h.geocodeProjectLocation(c.Request.Context(), nil, project)
+
+	if err := h.service.UpdateLocation(id, project.LocationLat, project.LocationLng, project.LocationStatus); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RETRY_GEOCODING: Failed to save location for project %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save project location"})
+		return
+	}
+
+	if project.LocationStatus == models.LocationStatusFailed {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Address could not be geocoded",
+			"project": project,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
 }
 
 // DeleteProject handles DELETE /api/projects/:id
@@ -354,6 +562,10 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
 		return
 	}
@@ -361,6 +573,34 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// RestoreProject handles POST /api/projects/:id/restore
+func (h *ProjectHandler) RestoreProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := h.service.Restore(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No deleted project with that ID"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ RESTORE_PROJECT: Failed to restore project %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore project"})
+		return
+	}
+
+	project, err := h.service.GetByID(id)
+	if err != nil || project == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "♻️ RESTORE_PROJECT: Restored project %s", id)
+	c.JSON(http.StatusOK, project)
+}
+
 // GetProjectWithDetails handles GET /api/projects/:id/details
 func (h *ProjectHandler) GetProjectWithDetails(c *gin.Context) {
 	idStr := c.Param("id")
@@ -377,7 +617,16 @@ func (h *ProjectHandler) GetProjectWithDetails(c *gin.Context) {
 	}
 
 	if project == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		respondNotFound(c, "Project")
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+	if !requireProjectVisible(c, &project.Project, userCtx, h.service) {
 		return
 	}
 
@@ -385,6 +634,7 @@ func (h *ProjectHandler) GetProjectWithDetails(c *gin.Context) {
 }
 
 // GetProjectSignups handles GET /api/projects/:id/signups
+// Optional ?status= limits results to one status, e.g. "withdrawn" or "rejected".
 func (h *ProjectHandler) GetProjectSignups(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -393,6 +643,15 @@ func (h *ProjectHandler) GetProjectSignups(c *gin.Context) {
 		return
 	}
 
+	status := c.Query("status")
+	if status != "" && !models.IsValidApplicationStatus(status) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":    "Invalid application status",
+			"statuses": models.ApplicationStatuses,
+		})
+		return
+	}
+
 	// Get user context
 	userCtx, exists := middleware.GetUserFromContext(c)
 	if !exists {
@@ -400,8 +659,8 @@ func (h *ProjectHandler) GetProjectSignups(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view signups (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view signups (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view project signups"})
 		return
 	}
@@ -413,12 +672,12 @@ func (h *ProjectHandler) GetProjectSignups(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view signups for this project"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", id, userCtx, h.service, "Insufficient permissions to view signups for this project")
 		return
 	}
 
-	signups, err := h.service.GetProjectSignups(id)
+	signups, err := h.service.GetProjectSignups(id, status)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project signups"})
 		return
@@ -443,8 +702,8 @@ func (h *ProjectHandler) GetProjectTeamMembers(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view team members (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view team members (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view team members"})
 		return
 	}
@@ -456,8 +715,8 @@ func (h *ProjectHandler) GetProjectTeamMembers(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view team members for this project"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", id, userCtx, h.service, "Insufficient permissions to view team members for this project")
 		return
 	}
 
@@ -486,21 +745,8 @@ func (h *ProjectHandler) GetProjectTeamMembersWithDetails(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view team members (team_lead, admin, or team member)
-	isTeamLead, err := h.service.IsTeamLead(id, userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
-		return
-	}
-
-	isTeamMember, err := h.service.IsTeamMember(id, userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
-		return
-	}
-
-	if !userCtx.HasRole("admin") && !isTeamLead && !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view team members"})
+	// Team leads, admins and team members can view team members
+	if !requireProjectTeam(c, id, userCtx, h.service) {
 		return
 	}
 
@@ -540,8 +786,8 @@ func (h *ProjectHandler) AddTeamMember(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to add team members (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to add team members (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to add team members"})
 		return
 	}
@@ -553,8 +799,8 @@ func (h *ProjectHandler) AddTeamMember(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to add team members to this project"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Insufficient permissions to add team members to this project")
 		return
 	}
 
@@ -564,11 +810,21 @@ func (h *ProjectHandler) AddTeamMember(c *gin.Context) {
 		status = models.TeamMemberStatus(req.Status)
 	}
 
+	// Only volunteers holding the project's required credentials can become active members
+	if status == models.TeamMemberStatusActive && !checkRequiredCredentials(c, h.service.GetDB(), req.VolunteerID, projectID) {
+		return
+	}
+
 	if err := h.service.AddTeamMember(projectID, req.VolunteerID, status); err != nil {
+		if errors.Is(err, models.ErrTeamFull) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Project team is full"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add team member"})
 		return
 	}
 
+	closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), projectID, userCtx.ID)
 	c.JSON(http.StatusOK, gin.H{"message": "Team member added successfully"})
 }
 
@@ -604,8 +860,8 @@ func (h *ProjectHandler) UpdateTeamMemberStatus(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to update team member status (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to update team member status (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to update team member status"})
 		return
 	}
@@ -617,17 +873,27 @@ func (h *ProjectHandler) UpdateTeamMemberStatus(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to update team member status for this project"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Insufficient permissions to update team member status for this project")
 		return
 	}
 
 	status := models.TeamMemberStatus(req.Status)
+	if status == models.TeamMemberStatusActive && !checkRequiredCredentials(c, h.service.GetDB(), volunteerID, projectID) {
+		return
+	}
 	if err := h.service.UpdateTeamMemberStatus(projectID, volunteerID, status); err != nil {
+		if errors.Is(err, models.ErrTeamFull) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Project team is full"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team member status"})
 		return
 	}
 
+	// A member leaving the team opens a place for the next waitlisted volunteer
+	promoteFromWaitlist(c.Request.Context(), h.service.GetDB(), projectID, userCtx.ID)
+	closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), projectID, userCtx.ID)
 	c.JSON(http.StatusOK, gin.H{"message": "Team member status updated successfully"})
 }
 
@@ -636,7 +902,7 @@ func (h *ProjectHandler) AssignTeamLead(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := uuid.Parse(projectIDStr)
 	if err != nil {
-		log.Printf("❌ ASSIGN_TEAM_LEAD: Invalid project ID: %s", projectIDStr)
+		logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: Invalid project ID: %s", projectIDStr)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
 		return
 	}
@@ -646,7 +912,7 @@ func (h *ProjectHandler) AssignTeamLead(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("❌ ASSIGN_TEAM_LEAD: JSON binding error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: JSON binding error: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -654,30 +920,144 @@ func (h *ProjectHandler) AssignTeamLead(c *gin.Context) {
 	// Get user context
 	userCtx, exists := middleware.GetUserFromContext(c)
 	if !exists {
-		log.Printf("❌ ASSIGN_TEAM_LEAD: User not found in context")
+		logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: User not found in context")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
 		return
 	}
 
 	// Check if user has permission to assign team lead (admin only)
-	if !userCtx.HasRole("admin") {
-		log.Printf("❌ ASSIGN_TEAM_LEAD: User %s is not admin", userCtx.ID)
+	if !userCtx.HasPermission(models.PermissionAssignTeamLeads) {
+		logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: User %s may not assign team leads", userCtx.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to assign team lead"})
 		return
 	}
 
-	log.Printf("📝 ASSIGN_TEAM_LEAD: Assigning team lead %s to project %s by admin %s", req.TeamLeadID, projectID, userCtx.ID)
+	project, newLead, ok := h.loadTeamLeadChange(c, "ASSIGN_TEAM_LEAD", projectID, req.TeamLeadID)
+	if !ok {
+		return
+	}
+
+	if h.config.Projects.RequireTeamLeadMembership {
+		isMember, err := h.service.IsUserActiveTeamMember(projectID, req.TeamLeadID)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: Failed to check team membership: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+			return
+		}
+		if !isMember {
+			c.JSON(http.StatusConflict, gin.H{"error": "The new team lead must already be an active member of the project team"})
+			return
+		}
+	}
+
+	logging.Printf(c.Request.Context(), "📝 ASSIGN_TEAM_LEAD: Assigning team lead %s to project %s by admin %s", req.TeamLeadID, projectID, userCtx.ID)
 
 	if err := h.service.AssignTeamLead(projectID, req.TeamLeadID); err != nil {
-		log.Printf("❌ ASSIGN_TEAM_LEAD: Failed to assign team lead: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ ASSIGN_TEAM_LEAD: Failed to assign team lead: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign team lead", "details": err.Error()})
 		return
 	}
 
-	log.Printf("✅ ASSIGN_TEAM_LEAD: Successfully assigned team lead %s to project %s", req.TeamLeadID, projectID)
+	if project.TeamLeadID == nil || *project.TeamLeadID != req.TeamLeadID {
+		h.notifyTeamLeadChange(c.Request.Context(), project, project.TeamLeadID, newLead, userCtx.ID)
+	}
+
+	logging.Printf(c.Request.Context(), "✅ ASSIGN_TEAM_LEAD: Successfully assigned team lead %s to project %s", req.TeamLeadID, projectID)
 	c.JSON(http.StatusOK, gin.H{"message": "Team lead assigned successfully"})
 }
 
+// PreviewTeamLeadReassignment handles GET /api/projects/:id/team-lead/reassign-preview?team_lead_id=
+func (h *ProjectHandler) PreviewTeamLeadReassignment(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	newLeadID, err := uuid.Parse(c.Query("team_lead_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "team_lead_id must be a valid user ID"})
+		return
+	}
+
+	project, newLead, ok := h.loadTeamLeadChange(c, "PREVIEW_TEAM_LEAD", projectID, newLeadID)
+	if !ok {
+		return
+	}
+
+	impact, err := h.service.PreviewTeamLeadReassignment(project, newLead)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ PREVIEW_TEAM_LEAD: Failed to preview reassignment for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview team lead reassignment"})
+		return
+	}
+
+	membershipRequired := h.config.Projects.RequireTeamLeadMembership
+	c.JSON(http.StatusOK, gin.H{
+		"preview":                  impact,
+		"team_membership_required": membershipRequired,
+		"can_reassign":             !membershipRequired || impact.NewLeadIsTeamMember,
+	})
+}
+
+// loadTeamLeadChange loads the project and prospective team lead for a reassignment,
+// writing a 404 when either doesn't exist
+func (h *ProjectHandler) loadTeamLeadChange(c *gin.Context, logTag string, projectID, newLeadID uuid.UUID) (*models.Project, *models.TeamLeadSummary, bool) {
+	project, err := h.service.GetByID(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to get project %s: %v", logTag, projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return nil, nil, false
+	}
+	if project == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return nil, nil, false
+	}
+
+	newLead, err := h.service.GetTeamLeadSummary(newLeadID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to get user %s: %v", logTag, newLeadID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get new team lead"})
+		return nil, nil, false
+	}
+	if newLead == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "New team lead not found"})
+		return nil, nil, false
+	}
+
+	return project, newLead, true
+}
+
+// notifyTeamLeadChange messages the incoming and outgoing team leads of a project.
+// Failures are logged rather than returned: the reassignment has already succeeded.
+func (h *ProjectHandler) notifyTeamLeadChange(ctx context.Context, project *models.Project, previousLeadID *uuid.UUID, newLead *models.TeamLeadSummary, senderID uuid.UUID) {
+	messageService := models.NewMessageService(h.service.GetDB())
+	send := func(recipientID uuid.UUID, subject, messageText string) {
+		message := &models.ProjectMessage{
+			ProjectID:       &project.ID,
+			SenderID:        senderID,
+			RecipientUserID: &recipientID,
+			Subject:         &subject,
+			MessageText:     messageText,
+		}
+		if err := messageService.CreateUniversalMessage(message); err != nil {
+			logging.Errorf(ctx, "❌ ASSIGN_TEAM_LEAD: Failed to notify user %s: %v", recipientID, err)
+		}
+	}
+
+	send(newLead.UserID,
+		fmt.Sprintf("You're now the team lead for %s", project.Title),
+		fmt.Sprintf("You have been made the team lead for %s. Pending applications and task takeover requests "+
+			"for the project now await your review.", project.Title))
+
+	if previousLeadID != nil {
+		send(*previousLeadID,
+			fmt.Sprintf("You're no longer the team lead for %s", project.Title),
+			fmt.Sprintf("%s has taken over as team lead for %s. Tasks you created stay with the project.",
+				newLead.Name, project.Title))
+	}
+}
+
 // GetLogistics handles GET /api/projects/:id/logistics
 func (h *ProjectHandler) GetLogistics(c *gin.Context) {
 	projectIDStr := c.Param("id")
@@ -701,8 +1081,8 @@ func (h *ProjectHandler) GetLogistics(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can view logistics"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Only project team lead can view logistics")
 		return
 	}
 
@@ -722,20 +1102,12 @@ func (h *ProjectHandler) GetLogistics(c *gin.Context) {
 
 	// Get pending applications
 	applicationService := models.NewApplicationService(h.service.GetDB())
-	applications, err := applicationService.GetApplicationsByProject(projectID)
+	pendingApplications, err := applicationService.GetApplicationsByProject(projectID, models.ApplicationStatusPending)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get applications"})
 		return
 	}
 
-	// Filter for pending applications
-	var pendingApplications []models.Application
-	for _, app := range applications {
-		if app.Status == "pending" {
-			pendingApplications = append(pendingApplications, app)
-		}
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"project":              project,
 		"team_members":         teamMembers,
@@ -778,8 +1150,8 @@ func (h *ProjectHandler) UpdateLogistics(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can update logistics"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Only project team lead can update logistics")
 		return
 	}
 
@@ -799,7 +1171,11 @@ func (h *ProjectHandler) UpdateLogistics(c *gin.Context) {
 	}
 
 	// Update project
-	if err := h.service.Update(project); err != nil {
+	if err := h.service.UpdateWithRestrictions(project, userCtx.HasPermission(models.PermissionOverrideProjectRules)); err != nil {
+		if errors.Is(err, models.ErrRestrictedField) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "FIELD_RESTRICTED"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update logistics"})
 		return
 	}
@@ -842,8 +1218,8 @@ func (h *ProjectHandler) ApproveVolunteer(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can approve volunteers"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Only project team lead can approve volunteers")
 		return
 	}
 
@@ -863,8 +1239,13 @@ func (h *ProjectHandler) ApproveVolunteer(c *gin.Context) {
 
 	// Update application status
 	if req.Approve {
+		// Safety-sensitive projects only take volunteers with current, verified credentials
+		if !checkRequiredCredentials(c, h.service.GetDB(), application.VolunteerID, projectID) {
+			return
+		}
 		application.Status = "accepted"
-		// Note: The database trigger will automatically add to project_team_members
+		// Note: The database trigger will automatically add to project_team_members,
+		// or to the waitlist if the team is already full
 	} else {
 		application.Status = "rejected"
 	}
@@ -874,9 +1255,25 @@ func (h *ProjectHandler) ApproveVolunteer(c *gin.Context) {
 		return
 	}
 
+	message := "Application processed successfully"
+	var teamStatus models.TeamMemberStatus
+	if req.Approve {
+		teamStatus, err = h.service.GetTeamMemberStatus(projectID, application.VolunteerID)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ APPROVE_VOLUNTEER: Failed to get team status for volunteer %s: %v", application.VolunteerID, err)
+		}
+		if teamStatus == models.TeamMemberStatusWaitlisted {
+			message = "Application approved; the team is full, so the volunteer has been added to the waitlist"
+		}
+
+		// Accepting may have filled the team
+		closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), projectID, userCtx.ID)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message":     "Application processed successfully",
+		"message":     message,
 		"application": application,
+		"team_status": teamStatus,
 	})
 }
 
@@ -910,8 +1307,8 @@ func (h *ProjectHandler) RemoveVolunteer(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can remove volunteers"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Only project team lead can remove volunteers")
 		return
 	}
 
@@ -921,12 +1318,15 @@ func (h *ProjectHandler) RemoveVolunteer(c *gin.Context) {
 		return
 	}
 
+	promoteFromWaitlist(c.Request.Context(), h.service.GetDB(), projectID, userCtx.ID)
 	c.JSON(http.StatusOK, gin.H{"message": "Volunteer removed successfully"})
 }
 
 // TransitionProjectStatusRequest represents a project status transition request
 type TransitionProjectStatusRequest struct {
 	Status string `json:"status" binding:"required"`
+	Force  bool   `json:"force"`
+	Reason string `json:"reason"`
 }
 
 // TransitionProjectStatus handles PUT /api/projects/:id/status
@@ -974,10 +1374,40 @@ func (h *ProjectHandler) TransitionProjectStatus(c *gin.Context) {
 		return
 	}
 
-	log.Printf("🔄 TRANSITION_PROJECT_STATUS: User %s transitioning project %s to %s", userCtx.ID, id, newStatus)
+	// Forcing bypasses the transition rules, so it is admin-only and must be justified
+	if req.Force {
+		if !userCtx.HasPermission(models.PermissionOverrideProjectRules) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Only admins can force a status transition",
+				"code":  "INSUFFICIENT_PERMISSIONS",
+			})
+			return
+		}
+		if strings.TrimSpace(req.Reason) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "A reason is required when forcing a status transition",
+				"code":  "REASON_REQUIRED",
+			})
+			return
+		}
+	}
+
+	logging.Printf(c.Request.Context(), "🔄 TRANSITION_PROJECT_STATUS: User %s transitioning project %s to %s (force=%t)", userCtx.ID, id, newStatus, req.Force)
+
+	// The previous status is only needed for the webhook payload
+	var previousStatus models.ProjectStatus
+	if previous, err := h.service.GetByID(id); err == nil && previous != nil {
+		previousStatus = previous.ProjectStatus
+	}
 
 	// Transition project status
-	if err := h.service.TransitionProjectStatus(id, newStatus, userCtx.ID); err != nil {
+	var transitionErr error
+	if req.Force {
+		transitionErr = h.service.ForceProjectStatus(id, newStatus, userCtx.ID, req.Reason, userCtx.HasPermission(models.PermissionOverrideProjectRules))
+	} else {
+		transitionErr = h.service.TransitionProjectStatus(id, newStatus, userCtx.ID, userCtx.HasPermission(models.PermissionManageAllProjects))
+	}
+	if err := transitionErr; err != nil {
 		if err == sql.ErrNoRows {
 			if strings.Contains(err.Error(), "permission") {
 				c.JSON(http.StatusForbidden, gin.H{
@@ -990,8 +1420,18 @@ func (h *ProjectHandler) TransitionProjectStatus(c *gin.Context) {
 					"code":  "PROJECT_NOT_FOUND",
 				})
 			}
+		} else if errors.Is(err, models.ErrProjectStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"code":  "STATUS_CHANGED",
+			})
+		} else if errors.Is(err, models.ErrProjectStatusUnchanged) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"code":  "STATUS_UNCHANGED",
+			})
 		} else {
-			log.Printf("❌ TRANSITION_PROJECT_STATUS: Failed to transition project: %v", err)
+			logging.Errorf(c.Request.Context(), "❌ TRANSITION_PROJECT_STATUS: Failed to transition project: %v", err)
 			// Check if it's a validation error
 			if strings.Contains(err.Error(), "cannot transition") {
 				c.JSON(http.StatusBadRequest, gin.H{
@@ -1013,12 +1453,14 @@ func (h *ProjectHandler) TransitionProjectStatus(c *gin.Context) {
 		return
 	}
 
-	log.Printf("✅ TRANSITION_PROJECT_STATUS: Successfully transitioned project %s to %s", id, newStatus)
+	logging.Printf(c.Request.Context(), "✅ TRANSITION_PROJECT_STATUS: Successfully transitioned project %s to %s", id, newStatus)
+	closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), id, userCtx.ID)
+	h.publishProjectStatusChanged(id, previousStatus, newStatus, userCtx.ID, req.Force)
 
 	// Return updated project
 	project, err := h.service.GetByID(id)
 	if err != nil {
-		log.Printf("⚠️ TRANSITION_PROJECT_STATUS: Failed to fetch updated project: %v", err)
+		logging.Warnf(c.Request.Context(), "⚠️ TRANSITION_PROJECT_STATUS: Failed to fetch updated project: %v", err)
 		c.JSON(http.StatusOK, gin.H{
 			"message": "Project status updated successfully",
 			"status":  newStatus,
@@ -1031,3 +1473,142 @@ func (h *ProjectHandler) TransitionProjectStatus(c *gin.Context) {
 		"project": project,
 	})
 }
+
+// publishProjectStatusChanged notifies webhooks that a project moved to status.
+// previousStatus is left out of the payload when it isn't known.
+func (h *ProjectHandler) publishProjectStatusChanged(projectID uuid.UUID, previousStatus, status models.ProjectStatus, changedBy uuid.UUID, forced bool) {
+	data := gin.H{
+		"project_id": projectID,
+		"status":     status,
+		"changed_by": changedBy,
+		"forced":     forced,
+	}
+	if previousStatus != "" {
+		data["previous_status"] = previousStatus
+	}
+	h.webhooks.Publish(models.WebhookEventProjectStatusChanged, data)
+}
+
+// BulkProjectStatusRequest represents a status change applied to many projects
+type BulkProjectStatusRequest struct {
+	ProjectIDs []uuid.UUID `json:"project_ids" binding:"required"`
+	Status     string      `json:"status" binding:"required"`
+}
+
+// BulkTransitionProjectStatus handles POST /api/admin/projects/bulk-status
+func (h *ProjectHandler) BulkTransitionProjectStatus(c *gin.Context) {
+	var req BulkProjectStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	newStatus := models.ProjectStatus(req.Status)
+	switch newStatus {
+	case models.ProjectStatusDraft, models.ProjectStatusRecruiting, models.ProjectStatusActive,
+		models.ProjectStatusCompleted, models.ProjectStatusArchived:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project status"})
+		return
+	}
+
+	if len(req.ProjectIDs) == 0 || len(req.ProjectIDs) > models.MaxBulkStatusProjects {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "project_ids must list between 1 and 500 projects"})
+		return
+	}
+
+	// Each project is reported once, however often it was listed
+	seen := make(map[uuid.UUID]bool)
+	projectIDs := make([]uuid.UUID, 0, len(req.ProjectIDs))
+	for _, projectID := range req.ProjectIDs {
+		if !seen[projectID] {
+			seen[projectID] = true
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+
+	logging.Printf(c.Request.Context(), "🔄 BULK_PROJECT_STATUS: Admin %s moving %d projects to %s", userCtx.ID, len(projectIDs), newStatus)
+	results := h.service.BulkTransitionProjectStatus(projectIDs, newStatus, userCtx.ID)
+
+	summary := make(map[string]int)
+	for _, result := range results {
+		summary[result.Outcome]++
+		if result.Outcome == models.BulkStatusTransitioned {
+			closeApplicationsIfNeeded(c.Request.Context(), h.service.GetDB(), result.ProjectID, userCtx.ID)
+			h.publishProjectStatusChanged(result.ProjectID, "", newStatus, userCtx.ID, false)
+		}
+	}
+	logging.Printf(c.Request.Context(), "✅ BULK_PROJECT_STATUS: %d transitioned, %d skipped, %d not found, %d failed",
+		summary[models.BulkStatusTransitioned], summary[models.BulkStatusSkippedInvalid],
+		summary[models.BulkStatusNotFound], summary[models.BulkStatusFailed])
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  newStatus,
+		"results": results,
+		"summary": summary,
+	})
+}
+
+// GetProjectStatusHistory handles GET /api/projects/:id/status-history
+func (h *ProjectHandler) GetProjectStatusHistory(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	project, err := h.service.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	// Team lead, admin, creator, or team member can view the history
+	canEdit := userCtx.HasPermission(models.PermissionViewAllProjects)
+	if !canEdit {
+		canEdit, err = h.service.CanEditProject(id, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return
+		}
+	}
+
+	if !canEdit {
+		isTeamMember, err := h.service.IsTeamMember(id, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+			return
+		}
+		if !isTeamMember {
+			respondNotFound(c, "Project")
+			return
+		}
+	}
+
+	history, err := h.service.GetStatusHistory(id)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_STATUS_HISTORY: Failed to get status history for project %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get status history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProjectSurveyHandler handles project feedback survey requests
+type ProjectSurveyHandler struct {
+	service        *models.ProjectSurveyService
+	projectService *models.ProjectService
+}
+
+// NewProjectSurveyHandler creates a new project survey handler
+func NewProjectSurveyHandler(service *models.ProjectSurveyService, projectService *models.ProjectService) *ProjectSurveyHandler {
+	return &ProjectSurveyHandler{
+		service:        service,
+		projectService: projectService,
+	}
+}
+
+// CreateSurveyRequest represents a project survey creation request
+type CreateSurveyRequest struct {
+	Title       string                  `json:"title" binding:"required"`
+	Description string                  `json:"description"`
+	Questions   []models.SurveyQuestion `json:"questions" binding:"required"`
+	IsAnonymous bool                    `json:"is_anonymous"`
+}
+
+// SurveyResponseRequest represents a member's answers to a survey
+type SurveyResponseRequest struct {
+	Answers []models.SurveyAnswer `json:"answers" binding:"required"`
+}
+
+// CreateSurvey handles POST /api/projects/:id/surveys
+func (h *ProjectSurveyHandler) CreateSurvey(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var req CreateSurveyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !h.requireProjectLead(c, projectID, userCtx) {
+		return
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" || len(title) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Survey title must be between 1 and 255 characters"})
+		return
+	}
+	if err := models.ValidateSurveyQuestions(req.Questions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	survey := &models.ProjectSurvey{
+		ProjectID:       projectID,
+		Title:           title,
+		Description:     req.Description,
+		Questions:       req.Questions,
+		IsAnonymous:     req.IsAnonymous,
+		CreatedByUserID: &userCtx.ID,
+	}
+
+	if err := h.service.Create(survey); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_SURVEY: Failed to create survey for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create survey"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, survey)
+}
+
+// ListSurveys handles GET /api/projects/:id/surveys
+func (h *ProjectSurveyHandler) ListSurveys(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !requireProjectTeam(c, projectID, userCtx, h.projectService) {
+		return
+	}
+
+	surveys, err := h.service.ListByProject(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_SURVEYS: Failed to list surveys for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list surveys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"surveys": surveys})
+}
+
+// RespondToSurvey handles POST /api/projects/:id/surveys/:sid/respond
+func (h *ProjectSurveyHandler) RespondToSurvey(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	surveyID, err := uuid.Parse(c.Param("sid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid survey ID"})
+		return
+	}
+
+	var req SurveyResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	isMember, err := h.projectService.IsTeamMember(projectID, userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RESPOND_SURVEY: Failed to check team membership for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	if !isMember {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only project team members can respond to its surveys")
+		return
+	}
+
+	survey := h.getProjectSurvey(c, projectID, surveyID)
+	if survey == nil {
+		return
+	}
+
+	if err := survey.ValidateAnswers(req.Answers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.Respond(survey.ID, userCtx.ID, req.Answers)
+	if err != nil {
+		if err == models.ErrSurveyAlreadyResponded {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ RESPOND_SURVEY: Failed to record response to survey %s: %v", surveyID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record survey response"})
+		return
+	}
+
+	// Don't echo the respondent back on anonymous surveys
+	if survey.IsAnonymous {
+		response.RespondentUserID = nil
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetSurveyResults handles GET /api/projects/:id/surveys/:sid/results
+func (h *ProjectSurveyHandler) GetSurveyResults(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	surveyID, err := uuid.Parse(c.Param("sid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid survey ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	if !h.requireProjectLead(c, projectID, userCtx) {
+		return
+	}
+
+	survey := h.getProjectSurvey(c, projectID, surveyID)
+	if survey == nil {
+		return
+	}
+
+	results, err := h.service.GetResults(survey)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_SURVEY_RESULTS: Failed to aggregate survey %s: %v", surveyID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get survey results"})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// requireProjectLead checks that the caller leads the project or is an admin.
+// It responds as the access policy says and returns false otherwise.
+func (h *ProjectSurveyHandler) requireProjectLead(c *gin.Context, projectID uuid.UUID, userCtx *middleware.UserContext) bool {
+	if userCtx.HasPermission(models.PermissionManageAllProjects) {
+		return true
+	}
+
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userCtx.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ PROJECT_SURVEY: Failed to check team lead status for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return false
+	}
+	if !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only the project team lead or an admin can manage surveys")
+		return false
+	}
+
+	return true
+}
+
+// getProjectSurvey loads a survey, treating one from another project as missing.
+// It writes the error response and returns nil if the survey can't be used.
+func (h *ProjectSurveyHandler) getProjectSurvey(c *gin.Context, projectID, surveyID uuid.UUID) *models.ProjectSurvey {
+	survey, err := h.service.GetByID(surveyID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ PROJECT_SURVEY: Failed to get survey %s: %v", surveyID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get survey"})
+		return nil
+	}
+	if survey == nil || survey.ProjectID != projectID {
+		respondNotFound(c, "Survey")
+		return nil
+	}
+	return survey
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProjectTemplateHandler handles project template requests
+type ProjectTemplateHandler struct {
+	service              *models.ProjectTemplateService
+	projectService       *models.ProjectService
+	taskService          *models.TaskService
+	skillTaxonomyService *models.SkillTaxonomyService
+	config               *config.Config
+}
+
+// NewProjectTemplateHandler creates a new project template handler
+func NewProjectTemplateHandler(
+	service *models.ProjectTemplateService,
+	projectService *models.ProjectService,
+	taskService *models.TaskService,
+	config *config.Config,
+) *ProjectTemplateHandler {
+	return &ProjectTemplateHandler{
+		service:              service,
+		projectService:       projectService,
+		taskService:          taskService,
+		skillTaxonomyService: models.NewSkillTaxonomyService(projectService.GetDB()),
+		config:               config,
+	}
+}
+
+// ProjectTemplateRequest represents a template create or update request
+type ProjectTemplateRequest struct {
+	Name           string                     `json:"name" binding:"required"`
+	Description    string                     `json:"description"`
+	Category       string                     `json:"category"`
+	RequiredSkills []string                   `json:"required_skills"`
+	SuggestedTasks []models.TemplateTask      `json:"suggested_tasks"`
+	Milestones     []models.TemplateMilestone `json:"milestones"`
+	IsActive       *bool                      `json:"is_active"`
+}
+
+// CreateProjectFromTemplateRequest represents the optional overrides when instantiating a template
+type CreateProjectFromTemplateRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	StartDate   string `json:"start_date"` // YYYY-MM-DD; task and milestone due dates are offsets from it
+}
+
+// ListTemplates handles GET /api/project-templates
+func (h *ProjectTemplateHandler) ListTemplates(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Only admins can see retired templates
+	includeInactive := c.Query("include_inactive") == "true" && userCtx.HasPermission(models.PermissionManageProjectTemplates)
+
+	templates, err := h.service.List(includeInactive)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_PROJECT_TEMPLATES: Failed to list templates: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list project templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// GetTemplate handles GET /api/project-templates/:id
+func (h *ProjectTemplateHandler) GetTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	template, err := h.service.GetByID(templateID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_PROJECT_TEMPLATE: Failed to get template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project template"})
+		return
+	}
+	if template == nil || (!template.IsActive && !userCtx.HasPermission(models.PermissionManageProjectTemplates)) {
+		respondNotFound(c, "Project template")
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate handles POST /api/admin/project-templates
+func (h *ProjectTemplateHandler) CreateTemplate(c *gin.Context) {
+	var req ProjectTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	template := &models.ProjectTemplate{
+		IsActive:    true,
+		CreatedByID: &userCtx.ID,
+	}
+	if !h.applyTemplateRequest(c, template, &req) {
+		return
+	}
+
+	if err := h.service.Create(template); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT_TEMPLATE: Failed to create template: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /api/admin/project-templates/:id
+func (h *ProjectTemplateHandler) UpdateTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var req ProjectTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.GetByID(templateID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT_TEMPLATE: Failed to get template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project template"})
+		return
+	}
+	if template == nil {
+		respondNotFound(c, "Project template")
+		return
+	}
+
+	if !h.applyTemplateRequest(c, template, &req) {
+		return
+	}
+
+	if err := h.service.Update(template); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_PROJECT_TEMPLATE: Failed to update template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /api/admin/project-templates/:id
+func (h *ProjectTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	if err := h.service.Delete(templateID); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Project template")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ DELETE_PROJECT_TEMPLATE: Failed to delete template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project template deleted successfully"})
+}
+
+// CreateProjectFromTemplate handles POST /api/projects/from-template/:templateId
+func (h *ProjectTemplateHandler) CreateProjectFromTemplate(c *gin.Context) {
+	templateID, err := uuid.Parse(c.Param("templateId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	// All fields are optional, so an empty body is allowed
+	var req CreateProjectFromTemplateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	var startDate *time.Time
+	if req.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date, expected YYYY-MM-DD"})
+			return
+		}
+		startDate = &parsed
+	}
+
+	template, err := h.service.GetByID(templateID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT_FROM_TEMPLATE: Failed to get template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project template"})
+		return
+	}
+	if template == nil || !template.IsActive {
+		respondNotFound(c, "Project template")
+		return
+	}
+
+	title := template.Name
+	if strings.TrimSpace(req.Title) != "" {
+		title = req.Title
+	}
+	description := template.Description
+	if strings.TrimSpace(req.Description) != "" {
+		description = req.Description
+	}
+
+	// The template's skills may have been renamed or merged since it was saved, so
+	// they're resolved against the taxonomy as it is now
+	skills, err := h.skillTaxonomyService.ResolveRequiredSkills(template.RequiredSkills, h.config.Projects.MaxRequiredSkills)
+	if err != nil {
+		respondRequiredSkillsError(c, err, h.config.Projects.MaxRequiredSkills)
+		return
+	}
+	skillIDs := make([]int, len(skills))
+	skillNames := make([]string, len(skills))
+	for i, skill := range skills {
+		skillIDs[i], skillNames[i] = skill.ID, skill.SkillName
+	}
+
+	// Templates always start as drafts led by the caller
+	project := &models.Project{
+		Title:            title,
+		Description:      description,
+		RequiredSkills:   skillNames,
+		StartDate:        startDate,
+		ProjectStatus:    models.ProjectStatusDraft,
+		CreatedByAdminID: userCtx.ID,
+		TeamLeadID:       &userCtx.ID,
+	}
+
+	// The project ID is filled in as the tasks are created
+	tasks := template.PlannedTasks(uuid.Nil, userCtx.ID, startDate)
+	if err := h.projectService.CreateWithTasks(project, skillIDs, tasks); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_PROJECT_FROM_TEMPLATE: Failed to create project from template %s: %v", templateID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "✅ CREATE_PROJECT_FROM_TEMPLATE: Created project %s from template %s with %d tasks", project.ID, templateID, len(tasks))
+	c.JSON(http.StatusCreated, gin.H{
+		"project":     project,
+		"tasks":       tasks,
+		"template_id": templateID,
+	})
+}
+
+// applyTemplateRequest validates req and copies it onto template.
+// It writes a 400 and returns false if the request is invalid.
+func (h *ProjectTemplateHandler) applyTemplateRequest(c *gin.Context, template *models.ProjectTemplate, req *ProjectTemplateRequest) bool {
+	if strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Template name is required"})
+		return false
+	}
+
+	requiredSkills, ok := validateRequiredSkills(c, h.skillTaxonomyService, h.config.Projects.MaxRequiredSkills, req.RequiredSkills)
+	if !ok {
+		return false
+	}
+
+	for i, task := range req.SuggestedTasks {
+		if strings.TrimSpace(task.Title) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Suggested task title is required", "index": i})
+			return false
+		}
+		switch task.Priority {
+		case "", models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suggested task priority", "index": i})
+			return false
+		}
+		if task.DueOffsetDays != nil && *task.DueOffsetDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Suggested task due_offset_days must not be negative", "index": i})
+			return false
+		}
+	}
+
+	for i, milestone := range req.Milestones {
+		if strings.TrimSpace(milestone.Title) == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Milestone title is required", "index": i})
+			return false
+		}
+		if milestone.OffsetDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Milestone offset_days must not be negative", "index": i})
+			return false
+		}
+	}
+
+	template.Name = strings.TrimSpace(req.Name)
+	template.Description = req.Description
+	template.Category = req.Category
+	template.RequiredSkills = requiredSkills
+	template.SuggestedTasks = req.SuggestedTasks
+	template.Milestones = req.Milestones
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+
+	return true
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+	"civicweave/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestHasStaleCoordinates(t *testing.T) {
+	coord := func(v float64) *float64 { return &v }
+	previous := &models.Project{LocationAddress: "1 Main St", LocationLat: coord(45.1), LocationLng: coord(-75.2)}
+
+	tests := []struct {
+		name    string
+		updated *models.Project
+		want    bool
+	}{
+		{
+			name:    "address unchanged",
+			updated: &models.Project{LocationAddress: "1 Main St", LocationLat: coord(45.1), LocationLng: coord(-75.2)},
+			want:    false,
+		},
+		{
+			name:    "only whitespace changed",
+			updated: &models.Project{LocationAddress: " 1 Main St ", LocationLat: coord(45.1), LocationLng: coord(-75.2)},
+			want:    false,
+		},
+		{
+			name:    "address moved, old coordinates kept",
+			updated: &models.Project{LocationAddress: "9 Elm St", LocationLat: coord(45.1), LocationLng: coord(-75.2)},
+			want:    true,
+		},
+		{
+			name:    "address moved with new coordinates",
+			updated: &models.Project{LocationAddress: "9 Elm St", LocationLat: coord(44.0), LocationLng: coord(-76.0)},
+			want:    false,
+		},
+		{
+			name:    "address moved, coordinates cleared",
+			updated: &models.Project{LocationAddress: "9 Elm St"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := hasStaleCoordinates(previous, tt.updated); got != tt.want {
+			t.Errorf("%s: hasStaleCoordinates() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// A project that had no coordinates has none to go stale
+	ungeocoded := &models.Project{LocationAddress: "1 Main St"}
+	if hasStaleCoordinates(ungeocoded, &models.Project{LocationAddress: "9 Elm St"}) {
+		t.Error("hasStaleCoordinates() = true for a project without coordinates")
+	}
+}
+
+func TestUpdateProjectSavesWhenReGeocodingFails(t *testing.T) {
+	nominatim := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer nominatim.Close()
+
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	projectID := uuid.New()
+	leadID := uuid.New()
+	now := time.Now()
+	recorder.Rows("team_lead_id = $2", []string{"count"}, []driver.Value{int64(1)})
+	recorder.Rows("FROM projects WHERE id = $1 AND ($2", []string{
+		"id", "title", "description", "content_json", "required_skills", "location_lat", "location_lng",
+		"location_address", "start_date", "end_date", "project_status", "created_by_admin_id", "team_lead_id",
+		"auto_notify_matches", "max_team_size", "auto_close_applications", "task_digest_minutes",
+		"location_status", "created_at", "updated_at", "deleted_at",
+	}, []driver.Value{
+		projectID.String(), "Park Cleanup", "Pick up litter", nil, "[]", 45.1, -75.2,
+		"1 Main St", nil, nil, "draft", uuid.New().String(), leadID.String(),
+		false, nil, false, nil,
+		"geocoded", now, now, nil,
+	})
+	recorder.Rows("SET title = $2", []string{"updated_at"}, []driver.Value{now})
+
+	geocoder := utils.NewGeocodingService(config.GeocodingConfig{NominatimBaseURL: nominatim.URL, CacheSize: 10}, nil)
+	h := &ProjectHandler{service: models.NewProjectService(db), geocodingService: geocoder, config: &config.Config{}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/projects/:id", func(c *gin.Context) {
+		c.Set("user_id", leadID)
+		c.Set("user_email", "lead@example.com")
+		h.UpdateProject(c)
+	})
+	response := httptest.NewRecorder()
+	body := strings.NewReader(`{"title":"Park Cleanup","description":"Pick up litter","location_address":"9 Elm St"}`)
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodPut, "/api/projects/"+projectID.String(), body))
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", response.Code, response.Body.String())
+	}
+	if !strings.Contains(logs.String(), "GEOCODE_PROJECT: Failed to geocode address") {
+		t.Errorf("the failed lookup was not logged: %s", logs.String())
+	}
+
+	// The project is saved with the new address, no coordinates, and a failed lookup
+	var update *fakesql.Statement
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "SET title = $2") {
+			statement := statement
+			update = &statement
+		}
+	}
+	if update == nil {
+		t.Fatal("the project was not saved")
+	}
+	if update.Args[4] != nil || update.Args[5] != nil {
+		t.Errorf("coordinates = %v, %v, want the old ones dropped", update.Args[4], update.Args[5])
+	}
+	if update.Args[6] != "9 Elm St" || update.Args[12] != string(models.LocationStatusFailed) {
+		t.Errorf("address, status = %v, %v, want the new address with a failed lookup", update.Args[6], update.Args[12])
+	}
+	if recorder.Rollbacks() != 0 {
+		t.Errorf("rollbacks = %d, want 0", recorder.Rollbacks())
+	}
+}
+
+func TestValidateRequiredSkillsResponses(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	now := time.Now()
+	recorder.Rows("FROM skill_taxonomy st", []string{"id", "skill_name", "parent_id", "created_at", "updated_at"},
+		[]driver.Value{int64(7), "Carpentry", nil, now, now})
+	skillTaxonomy := models.NewSkillTaxonomyService(db)
+
+	tests := []struct {
+		name     string
+		skills   []string
+		wantBody []string
+	}{
+		{"over the cap", []string{"Carpentry", "Painting", "Plumbing"}, []string{`"code":"TOO_MANY_SKILLS"`, `"max_required_skills":2`}},
+		{"duplicate", []string{"Carpentry", "carpentry"}, []string{`"code":"DUPLICATE_SKILL"`}},
+		{"blank", []string{" "}, []string{`"code":"EMPTY_SKILL"`}},
+	}
+
+	for _, tt := range tests {
+		c, response := policyContext()
+		if _, ok := validateRequiredSkills(c, skillTaxonomy, 2, tt.skills); ok {
+			t.Errorf("%s: skills were accepted", tt.name)
+			continue
+		}
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", tt.name, response.Code)
+		}
+		for _, want := range tt.wantBody {
+			if !strings.Contains(response.Body.String(), want) {
+				t.Errorf("%s: body %s is missing %s", tt.name, response.Body.String(), want)
+			}
+		}
+	}
+}
+
+func TestValidateRequiredSkillsRejectsUnknownSkills(t *testing.T) {
+	db, _ := fakesql.Open()
+	defer db.Close()
+
+	c, response := policyContext()
+	if _, ok := validateRequiredSkills(c, models.NewSkillTaxonomyService(db), 5, []string{"Juggling"}); ok {
+		t.Fatal("an unknown skill was accepted")
+	}
+	if response.Code != http.StatusBadRequest || !strings.Contains(response.Body.String(), `"code":"UNKNOWN_SKILL"`) {
+		t.Errorf("got %d %s, want a 400 UNKNOWN_SKILL", response.Code, response.Body.String())
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxDisputeReasonLength bounds the reason a volunteer gives for a dispute
+const maxDisputeReasonLength = 2000
+
+// FileDisputeRequest represents a dispute of a rating
+type FileDisputeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ResolveDisputeRequest represents a moderator's decision on a dispute
+type ResolveDisputeRequest struct {
+	Resolution string  `json:"resolution" binding:"required"` // "uphold" hides the rating, "remove" dismisses the dispute
+	Note       *string `json:"note"`
+}
+
+// FileDispute handles POST /api/ratings/:id/dispute
+func (h *VolunteerRatingHandler) FileDispute(c *gin.Context) {
+	ratingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rating ID"})
+		return
+	}
+
+	var req FileDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > maxDisputeReasonLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reason must be between 1 and 2000 characters"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	rating, err := h.ratingService.GetRatingByID(ratingID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get rating"})
+		return
+	}
+	if rating == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Rating not found"})
+		return
+	}
+
+	// Only the volunteer who was rated can dispute it
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get volunteer"})
+		return
+	}
+	if volunteer == nil || volunteer.ID != rating.VolunteerID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the rated volunteer can dispute this rating"})
+		return
+	}
+
+	dispute := &models.RatingDispute{
+		RatingID:      rating.ID,
+		VolunteerID:   rating.VolunteerID,
+		FiledByUserID: userCtx.ID,
+		Reason:        reason,
+	}
+
+	if err := h.ratingService.FileDispute(dispute); err != nil {
+		if err == models.ErrDisputeAlreadyOpen {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ FILE_DISPUTE: Failed to file dispute for rating %s: %v", ratingID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to file dispute"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "⚖️  FILE_DISPUTE: Volunteer %s disputed rating %s", volunteer.ID, ratingID)
+	c.JSON(http.StatusCreated, dispute)
+}
+
+// ListDisputes handles GET /api/admin/rating-disputes
+func (h *VolunteerRatingHandler) ListDisputes(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	// Defaults to the open queue; status=all lists every dispute
+	var status *models.DisputeStatus
+	switch statusParam := c.DefaultQuery("status", string(models.DisputeStatusOpen)); statusParam {
+	case "all":
+	case string(models.DisputeStatusOpen), string(models.DisputeStatusUpheld), string(models.DisputeStatusRemoved):
+		s := models.DisputeStatus(statusParam)
+		status = &s
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status, expected open, upheld, removed, or all"})
+		return
+	}
+
+	disputes, err := h.ratingService.ListDisputes(status, limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_DISPUTES: Failed to list rating disputes: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get rating disputes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"disputes": disputes,
+		"limit":    limit,
+		"offset":   offset,
+		"count":    len(disputes),
+	})
+}
+
+// ResolveDispute handles POST /api/admin/rating-disputes/:id/resolve
+func (h *VolunteerRatingHandler) ResolveDispute(c *gin.Context) {
+	disputeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dispute ID"})
+		return
+	}
+
+	var req ResolveDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var resolution models.DisputeStatus
+	switch req.Resolution {
+	case "uphold":
+		resolution = models.DisputeStatusUpheld
+	case "remove":
+		resolution = models.DisputeStatusRemoved
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Resolution must be uphold or remove"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	dispute, err := h.ratingService.ResolveDispute(disputeID, resolution, userCtx.ID, req.Note)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			respondNotFound(c, "Dispute")
+		case models.ErrDisputeNotOpen:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			logging.Errorf(c.Request.Context(), "❌ RESOLVE_DISPUTE: Failed to resolve dispute %s: %v", disputeID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve dispute"})
+		}
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "⚖️  RESOLVE_DISPUTE: Admin %s resolved dispute %s as %s", userCtx.ID, disputeID, dispute.Status)
+	c.JSON(http.StatusOK, dispute)
+}
@@ -1,30 +1,53 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
-	"log"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// Uploaded files are addressed as uploadsURLPrefix followed by their storage key
+const uploadsURLPrefix = "/uploads/"
+
+// Multipart parsing limits: form fields and headers may add multipartOverheadBytes beyond
+// the file itself, and parts beyond multipartMemoryBytes are spooled to disk
+const (
+	multipartOverheadBytes = 1 << 20
+	multipartMemoryBytes   = 8 << 20
+)
+
 // ResourceHandler handles resource-related requests
 type ResourceHandler struct {
 	service *models.ResourceService
+	storage services.ResourceStorage
+	config  *config.Config
 }
 
 // NewResourceHandler creates a new resource handler
-func NewResourceHandler(service *models.ResourceService) *ResourceHandler {
+func NewResourceHandler(service *models.ResourceService, storage services.ResourceStorage, config *config.Config) *ResourceHandler {
 	return &ResourceHandler{
 		service: service,
+		storage: storage,
+		config:  config,
 	}
 }
 
@@ -94,12 +117,12 @@ func (h *ResourceHandler) ListResources(c *gin.Context) {
 	// Get resources
 	resources, err := h.service.List(filters, limit, offset)
 	if err != nil {
-		log.Printf("❌ LIST_RESOURCES: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ LIST_RESOURCES: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resources"})
 		return
 	}
 
-	log.Printf("✅ LIST_RESOURCES: Successfully fetched %d resources", len(resources))
+	logging.Printf(c.Request.Context(), "✅ LIST_RESOURCES: Successfully fetched %d resources", len(resources))
 
 	c.JSON(http.StatusOK, gin.H{
 		"resources": resources,
@@ -121,7 +144,7 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 	// Get resource
 	resource, err := h.service.GetByID(resourceID)
 	if err != nil {
-		log.Printf("❌ GET_RESOURCE: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_RESOURCE: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource"})
 		return
 	}
@@ -131,7 +154,7 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 		return
 	}
 
-	log.Printf("✅ GET_RESOURCE: Successfully fetched resource %s", resourceID)
+	logging.Printf(c.Request.Context(), "✅ GET_RESOURCE: Successfully fetched resource %s", resourceID)
 
 	c.JSON(http.StatusOK, resource)
 }
@@ -145,24 +168,13 @@ func (h *ResourceHandler) CreateResource(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission (team_lead or admin)
-	userService := models.NewUserService(h.service.GetDB())
-	roles, err := userService.GetUserRoles(userCtx.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user roles"})
+	// Check if user has permission (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only team leads and admins can create resources"})
 		return
 	}
 
-	hasPermission := false
-	for _, role := range roles {
-		if role.Name == "admin" || role.Name == "team_lead" {
-			hasPermission = true
-			break
-		}
-	}
-
-	if !hasPermission {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team leads and admins can create resources"})
+	if !h.parseUploadForm(c) {
 		return
 	}
 
@@ -203,49 +215,21 @@ func (h *ResourceHandler) CreateResource(c *gin.Context) {
 	var fileURL string
 	var fileSize *int64
 	var mimeType *string
+	var fileChecksum *string
 
 	if resourceType == "file" {
-		// Handle file upload
-		file, header, err := c.Request.FormFile("file")
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "File upload required for file type"})
-			return
-		}
-		defer file.Close()
-
-		// Create uploads directory if it doesn't exist
-		uploadDir := "uploads"
-		if err := os.MkdirAll(uploadDir, 0755); err != nil {
-			log.Printf("❌ CREATE_RESOURCE: Failed to create upload directory: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload directory"})
-			return
-		}
-
-		// Generate unique filename
-		ext := filepath.Ext(header.Filename)
-		filename := uuid.New().String() + ext
-		filePath := filepath.Join(uploadDir, filename)
-
-		// Save file
-		dst, err := os.Create(filePath)
-		if err != nil {
-			log.Printf("❌ CREATE_RESOURCE: Failed to create file: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		upload, ok := h.saveUpload(c, "CREATE_RESOURCE")
+		if !ok {
 			return
 		}
-		defer dst.Close()
-
-		if _, err := io.Copy(dst, file); err != nil {
-			log.Printf("❌ CREATE_RESOURCE: Failed to copy file: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		if h.rejectDuplicate(c, upload, uuid.Nil) {
 			return
 		}
 
-		fileURL = "/uploads/" + filename
-		fileSizeInt := header.Size
-		fileSize = &fileSizeInt
-		mimeTypeStr := header.Header.Get("Content-Type")
-		mimeType = &mimeTypeStr
+		fileURL = uploadsURLPrefix + upload.key
+		fileSize = &upload.size
+		mimeType = &upload.mimeType
+		fileChecksum = &upload.checksum
 	} else if resourceType == "link" {
 		// Handle link
 		fileURL = c.PostForm("file_url")
@@ -270,15 +254,19 @@ func (h *ResourceHandler) CreateResource(c *gin.Context) {
 		ProjectID:    projectID,
 		UploadedByID: userCtx.ID,
 		Tags:         tags,
+		FileChecksum: fileChecksum,
 	}
 
 	if err := h.service.Create(resource); err != nil {
-		log.Printf("❌ CREATE_RESOURCE: Database error: %v", err)
+		if resource.FileChecksum != nil {
+			h.discardUpload(c, strings.TrimPrefix(fileURL, uploadsURLPrefix))
+		}
+		logging.Errorf(c.Request.Context(), "❌ CREATE_RESOURCE: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create resource"})
 		return
 	}
 
-	log.Printf("✅ CREATE_RESOURCE: Successfully created resource %s", resource.ID)
+	logging.Printf(c.Request.Context(), "✅ CREATE_RESOURCE: Successfully created resource %s", resource.ID)
 
 	c.JSON(http.StatusCreated, resource)
 }
@@ -292,12 +280,6 @@ func (h *ResourceHandler) UpdateResource(c *gin.Context) {
 		return
 	}
 
-	var req UpdateResourceRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
 	// Get user context
 	userCtx, exists := middleware.GetUserFromContext(c)
 	if !exists {
@@ -345,6 +327,65 @@ func (h *ResourceHandler) UpdateResource(c *gin.Context) {
 		return
 	}
 
+	// A multipart request may carry a replacement file; JSON requests edit metadata or a link
+	var req UpdateResourceRequest
+	isMultipart := c.ContentType() == "multipart/form-data"
+	if isMultipart {
+		if !h.parseUploadForm(c) {
+			return
+		}
+		req = updateRequestFromForm(c)
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var projectID *uuid.UUID
+	if req.ProjectID != nil && *req.ProjectID != "" {
+		parsedID, err := uuid.Parse(*req.ProjectID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+		projectID = &parsedID
+	}
+
+	var upload *storedUpload
+	if isMultipart && c.Request.MultipartForm != nil && len(c.Request.MultipartForm.File["file"]) > 0 {
+		var ok bool
+		if upload, ok = h.saveUpload(c, "UPDATE_RESOURCE"); !ok {
+			return
+		}
+		if h.rejectDuplicate(c, upload, resource.ID) {
+			return
+		}
+	}
+
+	// Replacing the file or link creates a new version instead of overwriting it
+	newVersion := false
+	if upload != nil {
+		resource.ResourceType = "file"
+		resource.FileURL = uploadsURLPrefix + upload.key
+		resource.FileSize = &upload.size
+		resource.MimeType = &upload.mimeType
+		resource.FileChecksum = &upload.checksum
+		newVersion = true
+	} else if req.FileURL != "" && req.FileURL != resource.FileURL {
+		if resource.ResourceType != "link" && req.ResourceType != "link" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Upload a new file to replace a file resource"})
+			return
+		}
+		resource.ResourceType = "link"
+		resource.FileURL = req.FileURL
+		resource.FileSize = nil
+		resource.MimeType = nil
+		resource.FileChecksum = nil
+		newVersion = true
+	} else if req.ResourceType != "" && req.ResourceType != resource.ResourceType {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Changing the resource type requires a new file or link"})
+		return
+	}
+
 	// Update fields
 	if req.Title != "" {
 		resource.Title = req.Title
@@ -352,38 +393,31 @@ func (h *ResourceHandler) UpdateResource(c *gin.Context) {
 	if req.Description != "" {
 		resource.Description = req.Description
 	}
-	if req.ResourceType != "" {
-		resource.ResourceType = req.ResourceType
-	}
 	if req.Scope != "" {
 		resource.Scope = req.Scope
 	}
-	if req.FileURL != "" {
-		resource.FileURL = req.FileURL
-	}
 	if req.Tags != nil {
 		resource.Tags = req.Tags
 	}
 	if req.ProjectID != nil {
-		if *req.ProjectID == "" {
-			resource.ProjectID = nil
-		} else {
-			projectID, err := uuid.Parse(*req.ProjectID)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
-				return
-			}
-			resource.ProjectID = &projectID
-		}
+		resource.ProjectID = projectID
 	}
 
-	if err := h.service.Update(resource); err != nil {
-		log.Printf("❌ UPDATE_RESOURCE: Database error: %v", err)
+	if newVersion {
+		err = h.service.UpdateWithNewVersion(resource, userCtx.ID)
+	} else {
+		err = h.service.Update(resource)
+	}
+	if err != nil {
+		if upload != nil {
+			h.discardUpload(c, upload.key)
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_RESOURCE: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update resource"})
 		return
 	}
 
-	log.Printf("✅ UPDATE_RESOURCE: Successfully updated resource %s", resourceID)
+	logging.Printf(c.Request.Context(), "✅ UPDATE_RESOURCE: Successfully updated resource %s", resourceID)
 
 	c.JSON(http.StatusOK, resource)
 }
@@ -433,12 +467,12 @@ func (h *ResourceHandler) DeleteResource(c *gin.Context) {
 	}
 
 	if err := h.service.SoftDelete(resourceID); err != nil {
-		log.Printf("❌ DELETE_RESOURCE: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ DELETE_RESOURCE: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete resource"})
 		return
 	}
 
-	log.Printf("✅ DELETE_RESOURCE: Successfully deleted resource %s", resourceID)
+	logging.Printf(c.Request.Context(), "✅ DELETE_RESOURCE: Successfully deleted resource %s", resourceID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Resource deleted successfully"})
 }
@@ -455,7 +489,7 @@ func (h *ResourceHandler) DownloadResource(c *gin.Context) {
 	// Get resource
 	resource, err := h.service.GetByID(resourceID)
 	if err != nil {
-		log.Printf("❌ DOWNLOAD_RESOURCE: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_RESOURCE: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource"})
 		return
 	}
@@ -467,26 +501,15 @@ func (h *ResourceHandler) DownloadResource(c *gin.Context) {
 
 	// Increment download count
 	if err := h.service.IncrementDownloadCount(resourceID); err != nil {
-		log.Printf("❌ DOWNLOAD_RESOURCE: Failed to increment download count: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_RESOURCE: Failed to increment download count: %v", err)
 		// Don't fail the request for this
 	}
 
 	// Handle different resource types
 	if resource.ResourceType == "file" {
-		// Serve file
-		filePath := strings.TrimPrefix(resource.FileURL, "/uploads/")
-		fullPath := filepath.Join("uploads", filePath)
-
-		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "File not found on disk"})
+		if !h.serveStoredFile(c, "DOWNLOAD_RESOURCE", resource.FileURL, resource.MimeType, resource.FileSize, resource.Title) {
 			return
 		}
-
-		c.Header("Content-Disposition", "attachment; filename="+resource.Title)
-		if resource.MimeType != nil {
-			c.Header("Content-Type", *resource.MimeType)
-		}
-		c.File(fullPath)
 	} else if resource.ResourceType == "link" {
 		// Redirect to external URL
 		c.Redirect(http.StatusFound, resource.FileURL)
@@ -495,7 +518,94 @@ func (h *ResourceHandler) DownloadResource(c *gin.Context) {
 		return
 	}
 
-	log.Printf("✅ DOWNLOAD_RESOURCE: Successfully served resource %s", resourceID)
+	logging.Printf(c.Request.Context(), "✅ DOWNLOAD_RESOURCE: Successfully served resource %s", resourceID)
+}
+
+// ListResourceVersions handles GET /api/resources/:id/versions
+func (h *ResourceHandler) ListResourceVersions(c *gin.Context) {
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	resource, err := h.service.GetByID(resourceID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_RESOURCE_VERSIONS: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource"})
+		return
+	}
+
+	if resource == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		return
+	}
+
+	versions, err := h.service.ListVersions(resourceID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_RESOURCE_VERSIONS: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list resource versions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resource_id":     resource.ID,
+		"current_version": resource.Version,
+		"versions":        versions,
+	})
+}
+
+// DownloadResourceVersion handles GET /api/resources/:id/versions/:version/download
+func (h *ResourceHandler) DownloadResourceVersion(c *gin.Context) {
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	versionNumber, err := strconv.Atoi(c.Param("version"))
+	if err != nil || versionNumber < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	resource, err := h.service.GetByID(resourceID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_RESOURCE_VERSION: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource"})
+		return
+	}
+
+	if resource == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource not found"})
+		return
+	}
+
+	version, err := h.service.GetVersion(resourceID, versionNumber)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ DOWNLOAD_RESOURCE_VERSION: Database error: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource version"})
+		return
+	}
+
+	if version == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Resource version not found"})
+		return
+	}
+
+	// Earlier versions are served as-is and do not count towards download statistics
+	if version.ResourceType == "file" {
+		if !h.serveStoredFile(c, "DOWNLOAD_RESOURCE_VERSION", version.FileURL, version.MimeType, version.FileSize, resource.Title) {
+			return
+		}
+	} else if version.ResourceType == "link" {
+		c.Redirect(http.StatusFound, version.FileURL)
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource type for download"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "✅ DOWNLOAD_RESOURCE_VERSION: Successfully served version %d of resource %s", versionNumber, resourceID)
 }
 
 // GetResourceStats handles GET /api/resources/stats
@@ -531,12 +641,12 @@ func (h *ResourceHandler) GetResourceStats(c *gin.Context) {
 	// Get stats
 	stats, err := h.service.GetStats()
 	if err != nil {
-		log.Printf("❌ GET_RESOURCE_STATS: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_RESOURCE_STATS: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resource stats"})
 		return
 	}
 
-	log.Printf("✅ GET_RESOURCE_STATS: Successfully fetched stats for user %s", userCtx.ID)
+	logging.Printf(c.Request.Context(), "✅ GET_RESOURCE_STATS: Successfully fetched stats for user %s", userCtx.ID)
 
 	c.JSON(http.StatusOK, stats)
 }
@@ -554,22 +664,287 @@ func (h *ResourceHandler) GetRecentResources(c *gin.Context) {
 	if err != nil {
 		// Check if the error is due to missing table
 		if strings.Contains(err.Error(), "does not exist") {
-			log.Printf("⚠️ GET_RECENT_RESOURCES: Resources table not found, returning empty list")
+			logging.Warnf(c.Request.Context(), "⚠️ GET_RECENT_RESOURCES: Resources table not found, returning empty list")
 			c.JSON(http.StatusOK, gin.H{
 				"resources": []interface{}{},
 				"count":     0,
 			})
 			return
 		}
-		log.Printf("❌ GET_RECENT_RESOURCES: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_RECENT_RESOURCES: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recent resources"})
 		return
 	}
 
-	log.Printf("✅ GET_RECENT_RESOURCES: Successfully fetched %d recent resources", len(resources))
+	logging.Printf(c.Request.Context(), "✅ GET_RECENT_RESOURCES: Successfully fetched %d recent resources", len(resources))
 
 	c.JSON(http.StatusOK, gin.H{
 		"resources": resources,
 		"count":     len(resources),
 	})
 }
+
+// storedUpload describes a file saved to resource storage
+type storedUpload struct {
+	key      string
+	size     int64
+	mimeType string
+	checksum string
+}
+
+// parseUploadForm parses a multipart request, bounding its size so an oversized upload
+// is rejected while it is read. It writes the error response and returns false on failure.
+func (h *ResourceHandler) parseUploadForm(c *gin.Context) bool {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.config.Resources.MaxUploadBytes+multipartOverheadBytes)
+	if err := c.Request.ParseMultipartForm(multipartMemoryBytes); err != nil && err != http.ErrNotMultipart {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Upload exceeds the %d MB limit", h.config.Resources.MaxUploadBytes>>20)})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid form data"})
+		return false
+	}
+	return true
+}
+
+// saveUpload validates the "file" part of a parsed form and writes it to storage.
+// It writes the error response and returns false on failure.
+func (h *ResourceHandler) saveUpload(c *gin.Context, logTag string) (*storedUpload, bool) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File upload required for file type"})
+		return nil, false
+	}
+	defer file.Close()
+
+	maxBytes := h.config.Resources.MaxUploadBytes
+	if header.Size > maxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("File exceeds the %d MB upload limit", maxBytes>>20)})
+		return nil, false
+	}
+
+	uploadedType := uploadMIMEType(header)
+	if !h.mimeTypeAllowed(uploadedType) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("File type %q is not allowed", uploadedType)})
+		return nil, false
+	}
+
+	// Trust the content, not the client's Content-Type header or filename
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read uploaded file"})
+		return nil, false
+	}
+	if !uploadContentMatches(uploadedType, http.DetectContentType(sniff[:n])) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": fmt.Sprintf("File contents don't match file type %q", uploadedType)})
+		return nil, false
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read uploaded file"})
+		return nil, false
+	}
+
+	// Storage keys are generated; only a sanitized extension comes from the client
+	key := "resources/" + uuid.New().String() + uploadExtension(header.Filename)
+	hasher := sha256.New()
+	size, err := h.storage.Save(key, io.TeeReader(file, hasher))
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to store file: %v", logTag, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return nil, false
+	}
+
+	return &storedUpload{
+		key:      key,
+		size:     size,
+		mimeType: uploadedType,
+		checksum: hex.EncodeToString(hasher.Sum(nil)),
+	}, true
+}
+
+// rejectDuplicate discards an upload whose contents match a resource other than
+// resourceID, unless the form sets allow_duplicate. It reports whether it responded.
+func (h *ResourceHandler) rejectDuplicate(c *gin.Context, upload *storedUpload, resourceID uuid.UUID) bool {
+	if c.PostForm("allow_duplicate") == "true" {
+		return false
+	}
+
+	existing, err := h.service.GetByChecksum(upload.checksum)
+	if err != nil {
+		h.discardUpload(c, upload.key)
+		logging.Errorf(c.Request.Context(), "❌ RESOURCE_UPLOAD: Failed to check for duplicates: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for duplicate files"})
+		return true
+	}
+	if existing != nil && existing.ID != resourceID {
+		h.discardUpload(c, upload.key)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":        "This file has already been uploaded",
+			"duplicate_of": existing.ID,
+		})
+		return true
+	}
+	return false
+}
+
+// serveStoredFile streams a stored file as an attachment named after title. It writes
+// the error response and returns false when the file cannot be served.
+func (h *ResourceHandler) serveStoredFile(c *gin.Context, logTag, fileURL string, mimeType *string, fileSize *int64, title string) bool {
+	key, ok := strings.CutPrefix(fileURL, uploadsURLPrefix)
+	if !ok {
+		logging.Errorf(c.Request.Context(), "❌ %s: Unexpected file URL %q", logTag, fileURL)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file path"})
+		return false
+	}
+
+	// The storage backends reject keys that would escape their root
+	reader, err := h.storage.Open(key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidStorageKey) {
+			logging.Errorf(c.Request.Context(), "❌ %s: Rejected file path %q", logTag, fileURL)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file path"})
+			return false
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found in storage"})
+			return false
+		}
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to open file: %v", logTag, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return false
+	}
+	defer reader.Close()
+
+	contentType := "application/octet-stream"
+	if mimeType != nil && *mimeType != "" {
+		contentType = *mimeType
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": downloadFilename(title, key),
+	}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	if fileSize != nil {
+		c.Header("Content-Length", strconv.FormatInt(*fileSize, 10))
+	}
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, reader); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to stream %q: %v", logTag, fileURL, err)
+		return false
+	}
+	return true
+}
+
+// updateRequestFromForm reads resource metadata from multipart form fields. Fields
+// that are absent are left unset so they keep their current values.
+func updateRequestFromForm(c *gin.Context) UpdateResourceRequest {
+	req := UpdateResourceRequest{
+		Title:        c.PostForm("title"),
+		Description:  c.PostForm("description"),
+		ResourceType: c.PostForm("resource_type"),
+		Scope:        c.PostForm("scope"),
+		FileURL:      c.PostForm("file_url"),
+	}
+	if projectID, ok := c.GetPostForm("project_id"); ok {
+		req.ProjectID = &projectID
+	}
+	if tagsStr, ok := c.GetPostForm("tags"); ok {
+		req.Tags = []string{}
+		for _, tag := range strings.Split(tagsStr, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+	}
+	return req
+}
+
+// discardUpload removes a stored upload that will not be attached to a resource
+func (h *ResourceHandler) discardUpload(c *gin.Context, key string) {
+	if err := h.storage.Delete(key); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RESOURCE_UPLOAD: Failed to remove discarded upload %s: %v", key, err)
+	}
+}
+
+// mimeTypeAllowed reports whether an upload of mimeType is permitted
+func (h *ResourceHandler) mimeTypeAllowed(mimeType string) bool {
+	for _, allowed := range h.config.Resources.AllowedMIMETypes {
+		if mimeType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadMIMEType returns the media type of an uploaded file, without parameters. Generic
+// or missing types fall back to the one registered for the file extension.
+func uploadMIMEType(header *multipart.FileHeader) string {
+	mediaType, _, err := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if err != nil || mediaType == "application/octet-stream" {
+		if byExtension := mime.TypeByExtension(filepath.Ext(header.Filename)); byExtension != "" {
+			mediaType, _, _ = mime.ParseMediaType(byExtension)
+		}
+	}
+	return strings.ToLower(mediaType)
+}
+
+// uploadContentMatches reports whether content sniffed by http.DetectContentType fits the
+// declared media type. The sniffer can't tell Office Open XML files from other zip
+// archives or legacy Office files from other binaries, so those only need to be a zip
+// archive or unrecognized binary, and any text type only needs to be plain text.
+func uploadContentMatches(declared, sniffed string) bool {
+	if i := strings.Index(sniffed, ";"); i >= 0 {
+		sniffed = sniffed[:i]
+	}
+
+	switch {
+	case declared == sniffed:
+		return true
+	case strings.HasPrefix(declared, "application/vnd.openxmlformats-officedocument."):
+		return sniffed == "application/zip"
+	case declared == "application/msword", declared == "application/vnd.ms-excel", declared == "application/vnd.ms-powerpoint":
+		return sniffed == "application/octet-stream"
+	case strings.HasPrefix(declared, "text/"):
+		return sniffed == "text/plain"
+	default:
+		return false
+	}
+}
+
+// uploadExtension returns the lowercased extension of an uploaded filename, or "" when
+// it contains anything but letters and digits
+func uploadExtension(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filepath.Base(filename)))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
+
+// downloadFilename builds the filename offered for download from the resource title,
+// dropping path separators and control characters and keeping the stored extension
+func downloadFilename(title, key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < 0x20 || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = "download"
+	}
+
+	ext := path.Ext(key)
+	if ext != "" && !strings.EqualFold(path.Ext(name), ext) {
+		name += ext
+	}
+	return name
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUploadContentMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared string
+		content  []byte
+		want     bool
+	}{
+		{"pdf", "application/pdf", []byte("%PDF-1.7\n"), true},
+		{"png", "image/png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), true},
+		{"html posing as png", "image/png", []byte("<html><script>alert(1)</script></html>"), false},
+		{"html posing as pdf", "application/pdf", []byte("<!DOCTYPE html><html></html>"), false},
+		{"docx is a zip archive", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", []byte("PK\x03\x04\x14\x00\x06\x00"), true},
+		{"pdf posing as docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", []byte("%PDF-1.7\n"), false},
+		{"legacy doc is unrecognized binary", "application/msword", []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1\x00\x00"), true},
+		{"csv is plain text", "text/csv", []byte("name,email\nAnn,ann@example.com\n"), true},
+		{"html posing as csv", "text/csv", []byte("<html><body>hi</body></html>"), false},
+		{"jpeg posing as gif", "image/gif", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uploadContentMatches(tt.declared, http.DetectContentType(tt.content)); got != tt.want {
+				t.Errorf("uploadContentMatches(%q, %q) = %v, want %v", tt.declared, http.DetectContentType(tt.content), got, tt.want)
+			}
+		})
+	}
+}
@@ -74,10 +74,16 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 		return
 	}
 
+	permissions, err := models.ValidatePermissions(req.Permissions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	role := &models.Role{
 		Name:        req.Name,
 		Description: req.Description,
-		Permissions: req.Permissions,
+		Permissions: permissions,
 	}
 
 	if err := h.roleService.CreateRole(role); err != nil {
@@ -85,6 +91,8 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 		return
 	}
 
+	h.reloadAccessControl(c)
+
 	c.JSON(http.StatusCreated, role)
 }
 
@@ -108,11 +116,17 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 		return
 	}
 
+	permissions, err := models.ValidatePermissions(req.Permissions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	role := &models.Role{
 		ID:          id,
 		Name:        req.Name,
 		Description: req.Description,
-		Permissions: req.Permissions,
+		Permissions: permissions,
 	}
 
 	if err := h.roleService.UpdateRole(role); err != nil {
@@ -120,6 +134,8 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 		return
 	}
 
+	h.reloadAccessControl(c)
+
 	c.JSON(http.StatusOK, role)
 }
 
@@ -137,6 +153,9 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 		return
 	}
 
+	// Deleting a role also removes it from the hierarchy
+	h.reloadAccessControl(c)
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
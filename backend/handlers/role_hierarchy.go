@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SetInheritedRolesRequest lists the roles a role should directly inherit
+type SetInheritedRolesRequest struct {
+	RoleIDs []uuid.UUID `json:"role_ids"`
+}
+
+// GetInheritedRoles handles GET /api/admin/roles/:id/inherits
+func (h *RoleHandler) GetInheritedRoles(c *gin.Context) {
+	role, ok := h.roleFromParam(c)
+	if !ok {
+		return
+	}
+
+	inherited, err := h.roleService.GetInheritedRoles(role.ID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_INHERITED_ROLES: Failed to get roles inherited by %s: %v", role.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get inherited roles"})
+		return
+	}
+
+	hierarchy, err := h.roleService.GetHierarchy()
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_INHERITED_ROLES: Failed to load role hierarchy: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get inherited roles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"role":                  role,
+		"inherits":              inherited,
+		"effective_roles":       hierarchy.Expand([]string{role.Name}),
+		"effective_permissions": middleware.EffectivePermissions([]string{role.Name}),
+	})
+}
+
+// SetInheritedRoles handles PUT /api/admin/roles/:id/inherits
+func (h *RoleHandler) SetInheritedRoles(c *gin.Context) {
+	role, ok := h.roleFromParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetInheritedRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, inheritedID := range req.RoleIDs {
+		inherited, err := h.roleService.GetRoleByID(inheritedID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get role"})
+			return
+		}
+		if inherited == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Inherited role not found: " + inheritedID.String()})
+			return
+		}
+	}
+
+	if err := h.roleService.SetInheritedRoles(role.ID, req.RoleIDs); err != nil {
+		if errors.Is(err, models.ErrRoleInheritanceCycle) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ SET_INHERITED_ROLES: Failed to update roles inherited by %s: %v", role.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update inherited roles"})
+		return
+	}
+
+	h.reloadAccessControl(c)
+	h.GetInheritedRoles(c)
+}
+
+// roleFromParam loads the role named by the :id param
+func (h *RoleHandler) roleFromParam(c *gin.Context) (*models.Role, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return nil, false
+	}
+
+	role, err := h.roleService.GetRoleByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get role"})
+		return nil, false
+	}
+	if role == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return nil, false
+	}
+
+	return role, true
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetRolePermissionsRequest lists the permissions a role should grant directly
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// ListPermissions handles GET /api/admin/permissions
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"permissions": models.KnownPermissions})
+}
+
+// SetRolePermissions handles PUT /api/admin/roles/:id/permissions
+func (h *RoleHandler) SetRolePermissions(c *gin.Context) {
+	role, ok := h.roleFromParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	permissions, err := models.ValidatePermissions(req.Permissions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.roleService.SetPermissions(role.ID, permissions); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ SET_ROLE_PERMISSIONS: Failed to update permissions of %s: %v", role.Name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role permissions"})
+		return
+	}
+
+	h.reloadAccessControl(c)
+
+	role.Permissions = permissions
+	c.JSON(http.StatusOK, gin.H{
+		"role":                  role,
+		"effective_permissions": middleware.EffectivePermissions([]string{role.Name}),
+	})
+}
+
+// reloadAccessControl refreshes the hierarchy and permissions role checks use after a
+// role has changed. On failure the previous ones stay in effect until the next refresh.
+func (h *RoleHandler) reloadAccessControl(c *gin.Context) {
+	if err := middleware.LoadAccessControl(h.roleService); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RELOAD_ACCESS_CONTROL: %v", err)
+	}
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"civicweave/backend/config"
+	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/pkg/metrics"
 	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
@@ -17,7 +21,9 @@ type SkillClaimHandler struct {
 	skillClaimService        *models.SkillClaimService
 	vectorAggregationService *services.VectorAggregationService
 	vectorMatchingService    *services.VectorMatchingService
+	skillMatchingService     *services.SkillMatchingService
 	embeddingService         *services.EmbeddingService
+	pendingEmbeddingService  *models.PendingEmbeddingService
 	config                   *config.Config
 	volunteerService         *models.VolunteerService
 }
@@ -27,7 +33,9 @@ func NewSkillClaimHandler(
 	skillClaimService *models.SkillClaimService,
 	vectorAggregationService *services.VectorAggregationService,
 	vectorMatchingService *services.VectorMatchingService,
+	skillMatchingService *services.SkillMatchingService,
 	embeddingService *services.EmbeddingService,
+	pendingEmbeddingService *models.PendingEmbeddingService,
 	config *config.Config,
 	volunteerService *models.VolunteerService,
 ) *SkillClaimHandler {
@@ -35,7 +43,9 @@ func NewSkillClaimHandler(
 		skillClaimService:        skillClaimService,
 		vectorAggregationService: vectorAggregationService,
 		vectorMatchingService:    vectorMatchingService,
+		skillMatchingService:     skillMatchingService,
 		embeddingService:         embeddingService,
+		pendingEmbeddingService:  pendingEmbeddingService,
 		config:                   config,
 		volunteerService:         volunteerService,
 	}
@@ -80,13 +90,31 @@ func (h *SkillClaimHandler) CreateSkillClaim(c *gin.Context) {
 
 	// Generate embedding for the claim text
 	embedding, err := h.embeddingService.GenerateEmbedding(req.ClaimText)
+	if errors.Is(err, services.ErrEmbeddingUnavailable) {
+		// Queue the claim for the matching worker to embed once the API recovers
+		pending, queueErr := h.pendingEmbeddingService.Enqueue(volunteerID, req.ClaimText, err)
+		if queueErr != nil {
+			logging.Errorf(c.Request.Context(), "❌ CREATE_SKILL_CLAIM: Failed to queue claim for embedding: %v", queueErr)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate skill embedding"})
+			return
+		}
+		metrics.ObservePendingEmbeddingQueued()
+		logging.Printf(c.Request.Context(), "⚠️  CREATE_SKILL_CLAIM: Embedding unavailable, queued claim %s: %v", pending.ID, err)
+
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Skill claim received and will be added once it has been processed",
+			"pending": pending,
+		})
+		return
+	}
 	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_SKILL_CLAIM: Failed to generate embedding: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate skill embedding"})
 		return
 	}
 
 	// Create skill claim
-	claim, err := h.skillClaimService.CreateSkillClaim(volunteerID, req.ClaimText, embedding)
+	claim, err := h.skillClaimService.CreateSkillClaim(volunteerID, req.ClaimText, embedding, h.embeddingService.GetEmbeddingModel())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create skill claim"})
 		return
@@ -127,9 +155,28 @@ func (h *SkillClaimHandler) GetMySkillClaims(c *gin.Context) {
 		return
 	}
 
+	// Claims still waiting for an embedding are listed separately, as are those that
+	// couldn't be embedded and need to be resubmitted
+	queued, err := h.pendingEmbeddingService.ListByVolunteer(volunteerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get skill claims"})
+		return
+	}
+	pending := []models.PendingEmbedding{}
+	failed := []models.PendingEmbedding{}
+	for _, entry := range queued {
+		if entry.FailedAt != nil {
+			failed = append(failed, entry)
+		} else {
+			pending = append(pending, entry)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"claims": claims,
-		"count":  len(claims),
+		"claims":  claims,
+		"count":   len(claims),
+		"pending": pending,
+		"failed":  failed,
 	})
 }
 
@@ -309,6 +356,58 @@ func (h *SkillClaimHandler) GetSkillsVisibility(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"visible": visible})
 }
 
+// ReaggregateMySkillVector handles POST /api/volunteers/me/skills/reaggregate
+// It rebuilds the caller's aggregated skill vector now rather than waiting for the
+// matching worker, then recalculates their matches.
+func (h *SkillClaimHandler) ReaggregateMySkillVector(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+		return
+	}
+
+	if err := h.vectorAggregationService.AggregateVolunteerVector(volunteer.ID); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ REAGGREGATE_SKILL_VECTOR: Failed to aggregate vector for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to re-aggregate skill vector"})
+		return
+	}
+
+	vector, err := h.vectorAggregationService.GetVolunteerVector(volunteer.ID)
+	if err != nil || vector == nil {
+		logging.Errorf(c.Request.Context(), "❌ REAGGREGATE_SKILL_VECTOR: Failed to read vector for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to re-aggregate skill vector"})
+		return
+	}
+
+	claims, err := h.skillClaimService.GetActiveClaimsByVolunteer(volunteer.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get skill claims"})
+		return
+	}
+
+	// The vector is already saved, so a failed recalculation is left to the matching worker
+	matchesRecalculated := true
+	if err := h.skillMatchingService.RecalculateForVolunteer(volunteer.ID); err != nil {
+		logging.Warnf(c.Request.Context(), "⚠️ REAGGREGATE_SKILL_VECTOR: Failed to recalculate matches for volunteer %s: %v", volunteer.ID, err)
+		matchesRecalculated = false
+	}
+
+	logging.Printf(c.Request.Context(), "✅ REAGGREGATE_SKILL_VECTOR: Re-aggregated vector for volunteer %s from %d claims", volunteer.ID, len(claims))
+	c.JSON(http.StatusOK, gin.H{
+		"volunteer_id":         vector.VolunteerID,
+		"dimensions":           len(vector.AggregatedVector.Slice()),
+		"active_claims":        len(claims),
+		"last_aggregated_at":   vector.LastAggregatedAt,
+		"matches_recalculated": matchesRecalculated,
+	})
+}
+
 // ListAllSkillClaims handles GET /api/admin/skill-claims (admin only)
 func (h *SkillClaimHandler) ListAllSkillClaims(c *gin.Context) {
 	// Get query parameters
@@ -1,28 +1,68 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
 )
 
 // SkillHandler handles skill-related API endpoints
 type SkillHandler struct {
-	taxonomyService *models.SkillTaxonomyService
+	taxonomyService   *models.SkillTaxonomyService
+	matchingService   *services.SkillMatchingService
+	extractionService *services.SkillExtractionService
 }
 
 // NewSkillHandler creates a new skill handler
-func NewSkillHandler(taxonomyService *models.SkillTaxonomyService) *SkillHandler {
+func NewSkillHandler(taxonomyService *models.SkillTaxonomyService, matchingService *services.SkillMatchingService, extractionService *services.SkillExtractionService) *SkillHandler {
 	return &SkillHandler{
-		taxonomyService: taxonomyService,
+		taxonomyService:   taxonomyService,
+		matchingService:   matchingService,
+		extractionService: extractionService,
 	}
 }
 
+// recalculateMatches refreshes the matches of a volunteer or project whose skills just
+// changed, in the background. If it fails, the entity stays marked and the matching
+// worker picks it up on its next pass.
+func (h *SkillHandler) recalculateMatches(ctx context.Context, entityType models.MatchingEntityType, entityID uuid.UUID) {
+	if h.matchingService == nil {
+		return
+	}
+
+	go func() {
+		var err error
+		if entityType == models.MatchingEntityProject {
+			err = h.matchingService.RecalculateForProject(entityID)
+		} else {
+			err = h.matchingService.RecalculateForVolunteer(entityID)
+		}
+		if err != nil {
+			logging.Errorf(ctx, "❌ RECALCULATE_MATCHES: Failed to recalculate matches for %s %s: %v", entityType, entityID, err)
+		}
+	}()
+}
+
+// TaxonomySkillWithAliases is a taxonomy skill listed with its aliases
+type TaxonomySkillWithAliases struct {
+	models.SkillTaxonomy
+	Aliases []string `json:"aliases"`
+}
+
 // GetTaxonomy handles GET /api/skills/taxonomy
+// Returns the skills as a category tree; ?category= (an ID or name) limits it to
+// that skill's subtree. Pass ?flat=true for the plain list, and with it
+// ?include_aliases=true to list each skill's aliases.
 func (h *SkillHandler) GetTaxonomy(c *gin.Context) {
 	skills, err := h.taxonomyService.GetAllSkills()
 	if err != nil {
@@ -30,12 +70,226 @@ func (h *SkillHandler) GetTaxonomy(c *gin.Context) {
 		return
 	}
 
+	if c.Query("flat") != "true" {
+		var categoryID *int
+		if category := strings.TrimSpace(c.Query("category")); category != "" {
+			id, err := strconv.Atoi(category)
+			if err != nil {
+				skill, err := h.taxonomyService.FindSkill(category)
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up category"})
+					return
+				}
+				if skill == nil {
+					respondNotFound(c, "Category")
+					return
+				}
+				id = skill.ID
+			}
+			categoryID = &id
+		}
+
+		tree := models.BuildSkillTree(skills, categoryID)
+		if categoryID != nil && tree == nil {
+			respondNotFound(c, "Category")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"skills": tree,
+			"count":  len(skills),
+		})
+		return
+	}
+
+	if c.Query("include_aliases") != "true" {
+		c.JSON(http.StatusOK, gin.H{
+			"skills": skills,
+			"count":  len(skills),
+		})
+		return
+	}
+
+	aliases, err := h.taxonomyService.ListAliasesBySkill()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve skill aliases"})
+		return
+	}
+
+	withAliases := make([]TaxonomySkillWithAliases, 0, len(skills))
+	for _, skill := range skills {
+		skillAliases := aliases[skill.ID]
+		if skillAliases == nil {
+			skillAliases = []string{}
+		}
+		withAliases = append(withAliases, TaxonomySkillWithAliases{SkillTaxonomy: skill, Aliases: skillAliases})
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"skills": skills,
-		"count":  len(skills),
+		"skills": withAliases,
+		"count":  len(withAliases),
+	})
+}
+
+// MergeSkill handles POST /api/admin/skills/:id/merge
+// It folds the skill into target_skill_id, repointing volunteer and project skills
+// and keeping the old name as an alias of the target.
+func (h *SkillHandler) MergeSkill(c *gin.Context) {
+	sourceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	type Request struct {
+		TargetSkillID int `json:"target_skill_id" binding:"required"`
+	}
+
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.taxonomyService.MergeSkills(sourceID, req.TargetSkillID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrSkillMergeSelf):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A skill cannot be merged into itself"})
+		case errors.Is(err, sql.ErrNoRows):
+			respondNotFound(c, "Skill")
+		default:
+			logging.Errorf(c.Request.Context(), "❌ MERGE_SKILL: Failed to merge skill %d into %d: %v", sourceID, req.TargetSkillID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge skills"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skills merged successfully",
+		"merge":   result,
+	})
+}
+
+// SetSkillParent handles PUT /api/admin/skills/:id/parent
+// A null parent_id moves the skill back to the top level.
+func (h *SkillHandler) SetSkillParent(c *gin.Context) {
+	skillID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	type Request struct {
+		ParentID *int `json:"parent_id"`
+	}
+
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.taxonomyService.SetSkillParent(skillID, req.ParentID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondNotFound(c, "Skill")
+		case errors.Is(err, models.ErrUnknownSkill):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parent skill does not exist"})
+		case errors.Is(err, models.ErrSkillCategoryCycle):
+			c.JSON(http.StatusConflict, gin.H{"error": "A skill cannot be nested under itself or one of its subcategories"})
+		default:
+			logging.Errorf(c.Request.Context(), "❌ SET_SKILL_PARENT: Failed to set parent of skill %d: %v", skillID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set skill parent"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Skill parent updated successfully",
+		"skill_id":  skillID,
+		"parent_id": req.ParentID,
+	})
+}
+
+// ListSkillAliases handles GET /api/admin/skills/aliases
+func (h *SkillHandler) ListSkillAliases(c *gin.Context) {
+	aliases, err := h.taxonomyService.ListAliases()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve skill aliases"})
+		return
+	}
+	if aliases == nil {
+		aliases = []models.SkillAlias{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"aliases": aliases,
+		"count":   len(aliases),
+	})
+}
+
+// AddSkillAlias handles POST /api/admin/skills/:id/aliases
+func (h *SkillHandler) AddSkillAlias(c *gin.Context) {
+	skillID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	type Request struct {
+		Alias string `json:"alias" binding:"required"`
+	}
+
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.Alias) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Alias must be at most 100 characters"})
+		return
+	}
+
+	alias, err := h.taxonomyService.AddAlias(skillID, req.Alias)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			respondNotFound(c, "Skill")
+		case errors.Is(err, models.ErrEmptyField):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Alias cannot be empty"})
+		case errors.Is(err, models.ErrAliasIsSkillName):
+			c.JSON(http.StatusConflict, gin.H{"error": "Alias matches an existing skill name; merge the skills instead"})
+		case errors.Is(err, models.ErrAliasInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": "Alias already points to another skill"})
+		default:
+			logging.Errorf(c.Request.Context(), "❌ ADD_SKILL_ALIAS: Failed to add alias to skill %d: %v", skillID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add skill alias"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"alias":   alias,
+		"message": "Alias added successfully",
 	})
 }
 
+// RemoveSkillAlias handles DELETE /api/admin/skills/aliases/:alias
+func (h *SkillHandler) RemoveSkillAlias(c *gin.Context) {
+	if err := h.taxonomyService.RemoveAlias(c.Param("alias")); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondNotFound(c, "Alias")
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove skill alias"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alias removed successfully"})
+}
+
 // AddSkill handles POST /api/skills/taxonomy
 func (h *SkillHandler) AddSkill(c *gin.Context) {
 	type Request struct {
@@ -141,6 +395,7 @@ func (h *SkillHandler) UpdateVolunteerSkills(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update volunteer skills"})
 		return
 	}
+	h.recalculateMatches(c.Request.Context(), models.MatchingEntityVolunteer, volunteerUUID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":      "Skills updated successfully",
@@ -198,6 +453,7 @@ func (h *SkillHandler) AddVolunteerSkills(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add volunteer skills"})
 		return
 	}
+	h.recalculateMatches(c.Request.Context(), models.MatchingEntityVolunteer, volunteerUUID)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message":      "Skills added successfully",
@@ -231,12 +487,143 @@ func (h *SkillHandler) RemoveVolunteerSkill(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove volunteer skill"})
 		return
 	}
+	h.recalculateMatches(c.Request.Context(), models.MatchingEntityVolunteer, volunteerUUID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Skill removed successfully",
 	})
 }
 
+// defaultSkillSuggestionLimit and maxSkillSuggestionLimit bound how many skills
+// ExtractSkills suggests
+const (
+	defaultSkillSuggestionLimit = 20
+	maxSkillSuggestionLimit     = 50
+)
+
+// ExtractSkills handles POST /api/volunteers/me/skills/extract
+// It suggests taxonomy skills found in free text such as a pasted résumé. Nothing is
+// added; the volunteer confirms suggestions by adding them with POST /api/volunteers/me/skills.
+func (h *SkillHandler) ExtractSkills(c *gin.Context) {
+	volunteerID, exists := c.Get("volunteer_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Volunteer ID not found"})
+		return
+	}
+
+	volunteerUUID, ok := volunteerID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	type Request struct {
+		Text  string `json:"text" binding:"required"`
+		Limit int    `json:"limit"`
+	}
+
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSkillSuggestionLimit
+	}
+	if limit > maxSkillSuggestionLimit {
+		limit = maxSkillSuggestionLimit
+	}
+
+	currentSkills, err := h.taxonomyService.GetVolunteerSkills(volunteerUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve volunteer skills"})
+		return
+	}
+	existing := make(map[int]bool, len(currentSkills))
+	for _, skill := range currentSkills {
+		existing[skill.SkillID] = true
+	}
+
+	result, err := h.extractionService.Extract(req.Text, existing, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrSkillTextTooLong) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":      "Text is too long",
+				"max_length": services.MaxSkillExtractionTextLength,
+			})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ EXTRACT_SKILLS: Failed to extract skills for volunteer %s: %v", volunteerUUID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to extract skills"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions":   result.Suggestions,
+		"count":         len(result.Suggestions),
+		"semantic_used": result.SemanticUsed,
+	})
+}
+
+// UpdateVolunteerSkillVisibility handles PUT /api/volunteers/me/skills/:skill_id/visibility
+// Public skills are used for matching, team-only skills are shown only to the
+// volunteer's own project teams, and private skills only to the volunteer.
+func (h *SkillHandler) UpdateVolunteerSkillVisibility(c *gin.Context) {
+	volunteerID, exists := c.Get("volunteer_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Volunteer ID not found"})
+		return
+	}
+
+	volunteerUUID, ok := volunteerID.(uuid.UUID)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid volunteer ID"})
+		return
+	}
+
+	skillID, err := strconv.Atoi(c.Param("skill_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		return
+	}
+
+	type Request struct {
+		Visibility string `json:"visibility" binding:"required"`
+	}
+
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !models.IsValidSkillVisibility(req.Visibility) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":          "Invalid visibility",
+			"allowed_values": models.SkillVisibilities,
+		})
+		return
+	}
+
+	if err := h.taxonomyService.SetVolunteerSkillVisibility(volunteerUUID, skillID, req.Visibility); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Skill")
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update skill visibility"})
+		return
+	}
+	h.recalculateMatches(c.Request.Context(), models.MatchingEntityVolunteer, volunteerUUID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Skill visibility updated successfully",
+		"skill_id":   skillID,
+		"visibility": req.Visibility,
+	})
+}
+
 // GetProfileCompletion handles GET /api/volunteers/me/profile-completion
 func (h *SkillHandler) GetProfileCompletion(c *gin.Context) {
 	volunteerID, exists := c.Get("volunteer_id")
@@ -402,6 +789,7 @@ func (h *SkillHandler) UpdateProjectSkills(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project skills"})
 		return
 	}
+	h.recalculateMatches(c.Request.Context(), models.MatchingEntityProject, projectID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":      "Project skills updated successfully",
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSkillTrendSkills bounds how many skills can be compared in one request
+const maxSkillTrendSkills = 10
+
+// GetSkillTrends handles GET /api/admin/analytics/skill-trends
+// Query: skill (repeatable or comma-separated), from and to (YYYY-MM-DD), interval (week|month|quarter)
+func (h *SkillHandler) GetSkillTrends(c *gin.Context) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, param := range c.QueryArray("skill") {
+		for _, name := range strings.Split(param, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one skill is required"})
+		return
+	}
+	if len(names) > maxSkillTrendSkills {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many skills; compare at most 10 at a time"})
+		return
+	}
+
+	interval := models.SkillTrendInterval(c.DefaultQuery("interval", string(models.SkillTrendMonth)))
+	switch interval {
+	case models.SkillTrendWeek, models.SkillTrendMonth, models.SkillTrendQuarter:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be one of week, month, quarter"})
+		return
+	}
+
+	// Defaults to the last 12 months; to is inclusive
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(-1, 0, 0)
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to date must not be before from date"})
+		return
+	}
+	end := to.AddDate(0, 0, 1)
+
+	trends := make([]models.SkillTrend, 0, len(names))
+	for _, name := range names {
+		skill, err := h.taxonomyService.FindSkill(name)
+		if err != nil {
+			logging.Errorf(c.Request.Context(), "❌ SKILL_TRENDS: Failed to resolve skill %q: %v", name, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve skill"})
+			return
+		}
+		if skill == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown skill", "skill": name})
+			return
+		}
+
+		trend, err := h.taxonomyService.GetSkillTrend(skill, from, end, interval)
+		if err != nil {
+			if err == models.ErrTooManyTrendBuckets {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			logging.Errorf(c.Request.Context(), "❌ SKILL_TRENDS: Failed to get trend for skill %d: %v", skill.ID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get skill trends"})
+			return
+		}
+		trends = append(trends, *trend)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trends":   trends,
+		"interval": interval,
+		"from":     from.Format("2006-01-02"),
+		"to":       to.Format("2006-01-02"),
+	})
+}
@@ -1,13 +1,20 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+	"civicweave/backend/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
@@ -19,6 +26,8 @@ type TaskHandler struct {
 	messageService     *models.MessageService
 	taskCommentService *models.TaskCommentService
 	taskTimeLogService *models.TaskTimeLogService
+	idempotencyKeys    *models.IdempotencyKeyService
+	webhooks           *services.WebhookDispatcher
 }
 
 // NewTaskHandler creates a new task handler
@@ -30,6 +39,8 @@ func NewTaskHandler(taskService *models.TaskService, projectService *models.Proj
 		messageService:     messageService,
 		taskCommentService: models.NewTaskCommentService(taskService.GetDB()),
 		taskTimeLogService: models.NewTaskTimeLogService(taskService.GetDB()),
+		idempotencyKeys:    models.NewIdempotencyKeyService(taskService.GetDB()),
+		webhooks:           services.NewWebhookDispatcher(models.NewWebhookService(taskService.GetDB())),
 	}
 }
 
@@ -41,6 +52,8 @@ type CreateTaskRequest struct {
 	Priority    string     `json:"priority"`
 	DueDate     *time.Time `json:"due_date"`
 	Labels      []string   `json:"labels"`
+	// Recurrence makes the task repeat; it becomes the first instance and needs a due date
+	Recurrence *models.TaskRecurrenceRule `json:"recurrence"`
 }
 
 // UpdateTaskRequest represents task update request
@@ -84,7 +97,7 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 
 	// Allow volunteers to see unassigned tasks even if not team members yet
 	// This allows them to self-assign tasks
-	if !isTeamMember && !userCtx.HasRole("admin") {
+	if !isTeamMember && !userCtx.HasPermission(models.PermissionViewAllProjects) {
 		// Check if user is a volunteer
 		volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
 		if err != nil || volunteer == nil {
@@ -100,15 +113,15 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 		return
 	}
 
-	// Admin can see all tasks too
-	if userCtx.HasRole("admin") {
+	// Users who can manage every project see all tasks too
+	if userCtx.HasPermission(models.PermissionManageAllProjects) {
 		isProjectOwner = true
 	}
 
 	// Get volunteer record for task filtering
 	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil && !userCtx.HasRole("admin") {
-		// Non-admin users need a volunteer profile
+	if err != nil && !userCtx.HasPermission(models.PermissionViewAllProjects) {
+		// Users outside the team need a volunteer profile
 		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile required"})
 		return
 	}
@@ -116,7 +129,7 @@ func (h *TaskHandler) ListTasks(c *gin.Context) {
 	// Get tasks (filtered based on permissions)
 	// If user is not a team member but is a volunteer, only show unassigned tasks
 	var tasks []models.ProjectTask
-	if !isTeamMember && !userCtx.HasRole("admin") {
+	if !isTeamMember && !userCtx.HasPermission(models.PermissionViewAllProjects) {
 		// Show only unassigned tasks to volunteers who aren't team members yet
 		tasks, err = h.taskService.ListUnassignedByProject(projectID)
 		if err != nil {
@@ -166,7 +179,7 @@ func (h *TaskHandler) ListUnassignedTasks(c *gin.Context) {
 	}
 
 	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can view tasks"})
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only team members can view tasks")
 		return
 	}
 
@@ -190,7 +203,8 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	}
 
 	var req CreateTaskRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	// Bound this way so the body can be compared against an Idempotency-Key's first use
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -209,8 +223,17 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can create tasks"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only project team lead can create tasks")
+		return
+	}
+
+	idempotency, replayID, ok := beginIdempotentCreate(c, h.idempotencyKeys, userCtx.ID, models.IdempotencyScopeCreateTask)
+	if !ok {
+		return
+	}
+	if replayID != nil {
+		h.replayCreatedTask(c, *replayID)
 		return
 	}
 
@@ -233,10 +256,39 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		Labels:      req.Labels,
 	}
 
-	if err := h.taskService.Create(task); err != nil {
+	if req.Recurrence != nil && req.Recurrence.Frequency != "" && req.Recurrence.Frequency != models.TaskRecurrenceNone {
+		if req.Recurrence.Interval == 0 {
+			req.Recurrence.Interval = 1
+		}
+		if _, err := h.taskService.CreateRecurring(task, *req.Recurrence); err != nil {
+			idempotency.release(c)
+			if !respondTaskRecurrenceError(c, err) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+			}
+			return
+		}
+	} else if err := h.taskService.Create(task); err != nil {
+		idempotency.release(c)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
 		return
 	}
+	idempotency.complete(c, task.ID)
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// replayCreatedTask responds to a retried CreateTask with the task the original
+// request created
+func (h *TaskHandler) replayCreatedTask(c *gin.Context, taskID uuid.UUID) {
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get task"})
+		return
+	}
+	if task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "The task created with this Idempotency-Key no longer exists"})
+		return
+	}
 
 	c.JSON(http.StatusCreated, task)
 }
@@ -258,21 +310,28 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 	}
 
 	// Get task with updates
-	taskWithUpdates, err := h.taskService.GetTaskWithUpdates(taskID)
+	// Linked resources are only loaded on request (?include=resources)
+	includeResources := false
+	for _, include := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(include) == "resources" {
+			includeResources = true
+		}
+	}
+
+	taskWithUpdates, err := h.taskService.GetTaskWithUpdates(taskID, includeResources)
 	if err != nil || taskWithUpdates == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
-	// Check if user has access (team member, project owner, or admin)
-	isTeamMember, err := h.projectService.IsTeamMember(taskWithUpdates.ProjectID, userCtx.ID)
+	// Tasks outside the caller's projects are reported as missing
+	canSee, err := canSeeProjectTeam(taskWithUpdates.ProjectID, userCtx, h.projectService)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
 		return
 	}
-
-	if !isTeamMember && !userCtx.HasRole("admin") {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	if !canSee {
+		respondNotFound(c, "Task")
 		return
 	}
 
@@ -315,25 +374,36 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	isAssignee := task.AssigneeID != nil && *task.AssigneeID == userCtx.ID
-	isAdmin := userCtx.HasRole("admin")
+	canManage := userCtx.HasPermission(models.PermissionManageAllProjects)
 
 	// Team lead and admin can update everything
 	// Assignee can only update status
-	if !isAdmin && !isTeamLead {
+	if !canManage && !isTeamLead {
+		isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
+			return
+		}
 		if !isAssignee {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead or assignee can update task"})
+			respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead or assignee can update task")
 			return
 		}
 		// Assignee can only update status
 		if req.Status != "" {
 			if err := h.taskService.UpdateStatus(taskID, models.TaskStatus(req.Status), userCtx.ID); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task status"})
+				if !h.respondTaskStatusError(c, taskID, err) {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task status"})
+				}
 				return
 			}
+			if task.Status != models.TaskStatusDone && models.TaskStatus(req.Status) == models.TaskStatusDone {
+				h.publishTaskCompleted(task, userCtx.ID, "")
+			}
 			c.JSON(http.StatusOK, gin.H{"message": "Task status updated successfully"})
 			return
 		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "Task assignees can only update the task status"})
+		return
 	}
 
 	// Update fields (for team lead and admin)
@@ -346,6 +416,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	if req.AssigneeID != nil {
 		task.AssigneeID = req.AssigneeID
 	}
+	previousStatus := task.Status
 	if req.Status != "" {
 		task.Status = models.TaskStatus(req.Status)
 	}
@@ -360,9 +431,14 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	}
 
 	if err := h.taskService.Update(task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
+		}
 		return
 	}
+	if previousStatus != models.TaskStatusDone && task.Status == models.TaskStatusDone {
+		h.publishTaskCompleted(task, userCtx.ID, "")
+	}
 
 	c.JSON(http.StatusOK, task)
 }
@@ -398,7 +474,7 @@ func (h *TaskHandler) SelfAssignTask(c *gin.Context) {
 	}
 
 	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can self-assign tasks"})
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only team members can self-assign tasks")
 		return
 	}
 
@@ -418,7 +494,9 @@ func (h *TaskHandler) SelfAssignTask(c *gin.Context) {
 	// Assign task to volunteer
 	task.AssigneeID = &volunteer.ID
 	if err := h.taskService.Update(task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign task"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign task"})
+		}
 		return
 	}
 
@@ -454,23 +532,21 @@ func (h *TaskHandler) AddTaskUpdate(c *gin.Context) {
 		return
 	}
 
-	// Get volunteer ID for user
-	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil || volunteer == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+	// Check if user is the assignee
+	isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
 		return
 	}
-
-	// Check if user is the assignee
-	if task.AssigneeID == nil || *task.AssigneeID != volunteer.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only the assignee can add updates"})
+	if !isAssignee {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only the assignee can add updates")
 		return
 	}
 
 	// Add update
 	update := &models.TaskUpdate{
 		TaskID:      taskID,
-		VolunteerID: volunteer.ID,
+		VolunteerID: *task.AssigneeID, // The caller, checked above
 		UpdateText:  req.UpdateText,
 	}
 
@@ -512,8 +588,8 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can delete tasks"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead can delete tasks")
 		return
 	}
 
@@ -557,15 +633,14 @@ func (h *TaskHandler) AddTaskComment(c *gin.Context) {
 		return
 	}
 
-	// Check if user is team member
-	isTeamMember, err := h.projectService.IsTeamMember(task.ProjectID, userCtx.ID)
+	// Check if user is on the project's team
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
 		return
 	}
-
-	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can comment on tasks"})
+	if !canSee {
+		respondNotFound(c, "Task")
 		return
 	}
 
@@ -607,15 +682,14 @@ func (h *TaskHandler) GetTaskComments(c *gin.Context) {
 		return
 	}
 
-	// Check if user is team member
-	isTeamMember, err := h.projectService.IsTeamMember(task.ProjectID, userCtx.ID)
+	// Check if user is on the project's team
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
 		return
 	}
-
-	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can view task comments"})
+	if !canSee {
+		respondNotFound(c, "Task")
 		return
 	}
 
@@ -662,15 +736,14 @@ func (h *TaskHandler) LogTaskTime(c *gin.Context) {
 		return
 	}
 
-	// Check if user is team member
-	isTeamMember, err := h.projectService.IsTeamMember(task.ProjectID, userCtx.ID)
+	// Check if user is on the project's team
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
 		return
 	}
-
-	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can log time"})
+	if !canSee {
+		respondNotFound(c, "Task")
 		return
 	}
 
@@ -721,15 +794,14 @@ func (h *TaskHandler) GetTaskTimeLogs(c *gin.Context) {
 		return
 	}
 
-	// Check if user is team member
-	isTeamMember, err := h.projectService.IsTeamMember(task.ProjectID, userCtx.ID)
+	// Check if user is on the project's team
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
 		return
 	}
-
-	if !isTeamMember {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only team members can view time logs"})
+	if !canSee {
+		respondNotFound(c, "Task")
 		return
 	}
 
@@ -743,6 +815,67 @@ func (h *TaskHandler) GetTaskTimeLogs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"time_logs": timeLogs})
 }
 
+// GetProjectTimeSummary handles GET /api/projects/:id/time-summary
+func (h *TaskHandler) GetProjectTimeSummary(c *gin.Context) {
+	projectIDStr := c.Param("id")
+	projectID, err := uuid.Parse(projectIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Check if user is project owner or admin
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return
+	}
+
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only project team lead can view time summaries")
+		return
+	}
+
+	// Parse optional date range (inclusive, YYYY-MM-DD)
+	var from, to *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+		to = &parsed
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to date must not be before from date"})
+		return
+	}
+
+	totals, err := h.taskTimeLogService.GetProjectTotals(projectID, from, to)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_PROJECT_TIME_SUMMARY: Failed to get totals for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get time summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
 // MarkTaskBlocked handles POST /api/tasks/:id/mark-blocked
 func (h *TaskHandler) MarkTaskBlocked(c *gin.Context) {
 	taskIDStr := c.Param("id")
@@ -774,22 +907,22 @@ func (h *TaskHandler) MarkTaskBlocked(c *gin.Context) {
 		return
 	}
 
-	// Get volunteer record to check if user is the assignee
-	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil || volunteer == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+	// Check if user is the assignee
+	isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
 		return
 	}
-
-	// Check if user is the assignee
-	if task.AssigneeID == nil || *task.AssigneeID != volunteer.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only the task assignee can mark it as blocked"})
+	if !isAssignee {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only the task assignee can mark it as blocked")
 		return
 	}
 
 	// Mark as blocked with timeline tracking
 	if err := h.taskService.MarkAsBlocked(taskID, req.Reason, userCtx.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark task as blocked"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark task as blocked"})
+		}
 		return
 	}
 
@@ -837,22 +970,22 @@ func (h *TaskHandler) RequestTaskTakeover(c *gin.Context) {
 		return
 	}
 
-	// Get volunteer record to check if user is the assignee
-	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil || volunteer == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+	// Check if user is the assignee
+	isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
 		return
 	}
-
-	// Check if user is the assignee
-	if task.AssigneeID == nil || *task.AssigneeID != volunteer.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only the task assignee can request takeover"})
+	if !isAssignee {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only the task assignee can request takeover")
 		return
 	}
 
 	// Request takeover with timeline tracking
 	if err := h.taskService.RequestTakeover(taskID, req.Reason, userCtx.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request task takeover"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request task takeover"})
+		}
 		return
 	}
 
@@ -900,22 +1033,22 @@ func (h *TaskHandler) MarkTaskDone(c *gin.Context) {
 		return
 	}
 
-	// Get volunteer record to check if user is the assignee
-	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil || volunteer == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+	// Check if user is the assignee
+	isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
 		return
 	}
-
-	// Check if user is the assignee
-	if task.AssigneeID == nil || *task.AssigneeID != volunteer.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only the task assignee can mark it as done"})
+	if !isAssignee {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only the task assignee can mark it as done")
 		return
 	}
 
 	// Mark as done with timeline tracking
 	if err := h.taskService.MarkAsDone(taskID, req.CompletionNote, userCtx.ID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark task as done"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark task as done"})
+		}
 		return
 	}
 
@@ -928,10 +1061,26 @@ func (h *TaskHandler) MarkTaskDone(c *gin.Context) {
 		// Log error but don't fail the request
 		// TODO: Add proper logging
 	}
+	h.publishTaskCompleted(task, userCtx.ID, req.CompletionNote)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Task marked as done"})
 }
 
+// publishTaskCompleted notifies webhooks that a task was marked done
+func (h *TaskHandler) publishTaskCompleted(task *models.ProjectTask, completedBy uuid.UUID, completionNote string) {
+	data := gin.H{
+		"task_id":      task.ID,
+		"project_id":   task.ProjectID,
+		"title":        task.Title,
+		"assignee_id":  task.AssigneeID,
+		"completed_by": completedBy,
+	}
+	if completionNote != "" {
+		data["completion_note"] = completionNote
+	}
+	h.webhooks.Publish(models.WebhookEventTaskCompleted, data)
+}
+
 // StartTask handles POST /api/tasks/:id/start (for volunteers to start working on a task)
 func (h *TaskHandler) StartTask(c *gin.Context) {
 	taskIDStr := c.Param("id")
@@ -948,40 +1097,296 @@ func (h *TaskHandler) StartTask(c *gin.Context) {
 		return
 	}
 
-	// Get user's volunteer profile
-	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
-	if err != nil || volunteer == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Volunteer profile not found"})
+	// Get the task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	// Check if task is assigned to this volunteer
+	isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
+		return
+	}
+	if !isAssignee {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Task is not assigned to you")
+		return
+	}
+
+	// Start task with timeline tracking; the transition rules decide which statuses it can start from
+	err = h.taskService.StartTask(taskID, userCtx.ID)
+	if err != nil {
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start task"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task started successfully"})
+}
+
+// ReopenTask handles POST /api/tasks/:id/reopen
+// Moves a done task back to todo. The project team lead, an admin or the assignee can reopen it.
+func (h *TaskHandler) ReopenTask(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
 		return
 	}
 
-	// Get the task
 	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) {
+		isTeamLead, err := h.projectService.IsTeamLead(task.ProjectID, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+			return
+		}
+		isAssignee, err := h.isTaskAssignee(task, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check task assignee"})
+			return
+		}
+		if !isTeamLead && !isAssignee {
+			respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead or assignee can reopen task")
+			return
+		}
+	}
+
+	if err := h.taskService.ReopenTask(taskID, userCtx.ID); err != nil {
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reopen task"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task reopened"})
+}
+
+// isTaskAssignee checks if a user is the task's assignee. Tasks are assigned to
+// volunteers, so the user's volunteer profile is resolved before comparing.
+func (h *TaskHandler) isTaskAssignee(task *models.ProjectTask, userID uuid.UUID) (bool, error) {
+	if task.AssigneeID == nil {
+		return false, nil
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	if volunteer == nil {
+		return false, nil
+	}
+
+	return *task.AssigneeID == volunteer.ID, nil
+}
+
+// respondTaskStatusError writes 400 for an unknown status and 409 for a disallowed
+// transition, a status changed by another request or starting a task whose dependencies
+// aren't done. It returns false if
+// err is none of these, leaving the response to the caller.
+func (h *TaskHandler) respondTaskStatusError(c *gin.Context, taskID uuid.UUID, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrDependenciesIncomplete):
+		dependencies, _ := h.taskService.GetDependencies(taskID)
+		c.JSON(http.StatusConflict, gin.H{
+			"error":        "Task cannot start until all of its dependencies are done",
+			"dependencies": dependencies,
+		})
+	case errors.Is(err, models.ErrInvalidTaskStatus):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidTaskTransition), errors.Is(err, models.ErrTaskStatusChanged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// AddTaskDependencyRequest represents a request to add a task dependency
+type AddTaskDependencyRequest struct {
+	DependsOnTaskID uuid.UUID `json:"depends_on_task_id" binding:"required"`
+}
+
+// AddTaskDependency handles POST /api/tasks/:id/dependencies
+func (h *TaskHandler) AddTaskDependency(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := uuid.Parse(taskIDStr)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req AddTaskDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
-	// Check if task is assigned to this volunteer
-	if task.AssigneeID == nil || *task.AssigneeID != volunteer.ID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Task is not assigned to you"})
+	// Check if user is project owner or admin
+	isTeamLead, err := h.projectService.IsTeamLead(task.ProjectID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
 		return
 	}
 
-	// Check if task is in todo status
-	if task.Status != "todo" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Task is not in todo status"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead can manage task dependencies")
 		return
 	}
 
-	// Start task with timeline tracking
-	err = h.taskService.StartTask(taskID, userCtx.ID)
+	if err := h.taskService.AddDependency(taskID, req.DependsOnTaskID, userCtx.ID); err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Dependency task not found"})
+		case errors.Is(err, models.ErrDependencyCycle):
+			c.JSON(http.StatusConflict, gin.H{"error": "Dependency would create a cycle"})
+		case errors.Is(err, models.ErrDependencyCrossProject):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dependency must be a task in the same project"})
+		default:
+			logging.Errorf(c.Request.Context(), "❌ ADD_TASK_DEPENDENCY: Failed to add dependency %s -> %s: %v", taskID, req.DependsOnTaskID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add dependency"})
+		}
+		return
+	}
+
+	dependencies, err := h.taskService.GetDependencies(taskID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start task"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get dependencies"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Task started successfully"})
+	c.JSON(http.StatusCreated, gin.H{"dependencies": dependencies})
+}
+
+// GetTaskDependencies handles GET /api/tasks/:id/dependencies
+func (h *TaskHandler) GetTaskDependencies(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	// Check if user has access (team member, team lead, or admin)
+	if !userCtx.HasPermission(models.PermissionViewAllProjects) {
+		isTeamLead, err := h.projectService.IsTeamLead(task.ProjectID, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+			return
+		}
+		isTeamMember, err := h.projectService.IsTeamMember(task.ProjectID, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+			return
+		}
+		if !isTeamLead && !isTeamMember {
+			respondNotFound(c, "Task")
+			return
+		}
+	}
+
+	dependencies, err := h.taskService.GetDependencies(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get dependencies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dependencies": dependencies})
+}
+
+// RemoveTaskDependency handles DELETE /api/tasks/:id/dependencies/:dependsOnId
+func (h *TaskHandler) RemoveTaskDependency(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	dependsOnID, err := uuid.Parse(c.Param("dependsOnId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dependency task ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	// Check if user is project owner or admin
+	isTeamLead, err := h.projectService.IsTeamLead(task.ProjectID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return
+	}
+
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead can manage task dependencies")
+		return
+	}
+
+	if err := h.taskService.RemoveDependency(taskID, dependsOnID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Dependency not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove dependency"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
 }
 
 // AssignTask handles PUT /api/tasks/:id/assign (for TLs to assign tasks to volunteers)
@@ -1022,8 +1427,8 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 		return
 	}
 
-	if !userCtx.HasRole("admin") && !isTeamLead {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only project team lead can assign tasks"})
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Task", task.ProjectID, userCtx, h.projectService, "Only project team lead can assign tasks")
 		return
 	}
 
@@ -1044,9 +1449,278 @@ func (h *TaskHandler) AssignTask(c *gin.Context) {
 	// Assign task
 	task.AssigneeID = req.VolunteerID
 	if err := h.taskService.Update(task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign task"})
+		if !h.respondTaskStatusError(c, taskID, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign task"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Task assignment updated successfully", "task": task})
 }
+
+// LinkTaskResourceRequest represents a request to attach a resource to a task
+type LinkTaskResourceRequest struct {
+	ResourceID uuid.UUID `json:"resource_id" binding:"required"`
+}
+
+// LinkTaskResource handles POST /api/tasks/:id/resources
+func (h *TaskHandler) LinkTaskResource(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var req LinkTaskResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	// Only the project's team can attach resources
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Task")
+		return
+	}
+
+	if err := h.taskService.LinkResource(taskID, req.ResourceID, userCtx.ID); err != nil {
+		if errors.Is(err, models.ErrResourceNotVisible) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Resource not found or not available to this project"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ LINK_TASK_RESOURCE: Failed to link resource %s to task %s: %v", req.ResourceID, taskID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link resource"})
+		return
+	}
+
+	resources, err := h.taskService.ListResources(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get task resources"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"resources": resources})
+}
+
+// GetTaskResources handles GET /api/tasks/:id/resources
+func (h *TaskHandler) GetTaskResources(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Task")
+		return
+	}
+
+	resources, err := h.taskService.ListResources(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get task resources"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"resources": resources})
+}
+
+// UnlinkTaskResource handles DELETE /api/tasks/:id/resources/:resourceId
+func (h *TaskHandler) UnlinkTaskResource(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	resourceID, err := uuid.Parse(c.Param("resourceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid resource ID"})
+		return
+	}
+
+	// Get user context
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Get task
+	task, err := h.taskService.GetByID(taskID)
+	if err != nil || task == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	canSee, err := canSeeProjectTeam(task.ProjectID, userCtx, h.projectService)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team membership"})
+		return
+	}
+	if !canSee {
+		respondNotFound(c, "Task")
+		return
+	}
+
+	if err := h.taskService.UnlinkResource(taskID, resourceID); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Resource is not linked to this task"})
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UNLINK_TASK_RESOURCE: Failed to unlink resource %s from task %s: %v", resourceID, taskID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlink resource"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Resource unlinked successfully"})
+}
+
+// GetMyTasks handles GET /api/volunteers/me/tasks
+func (h *TaskHandler) GetMyTasks(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	volunteer, err := h.volunteerService.GetByUserID(userCtx.ID)
+	if err != nil || volunteer == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Volunteer profile required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	var filters models.VolunteerTaskFilters
+
+	if statusParam := c.Query("status"); statusParam != "" {
+		for _, value := range strings.Split(statusParam, ",") {
+			status := models.TaskStatus(strings.TrimSpace(value))
+			if !status.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task status", "status": status})
+				return
+			}
+			filters.Statuses = append(filters.Statuses, status)
+		}
+	}
+
+	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
+		projectID, err := uuid.Parse(projectIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+			return
+		}
+		filters.ProjectID = &projectID
+	}
+
+	if priorityParam := c.Query("priority"); priorityParam != "" {
+		priority := models.TaskPriority(priorityParam)
+		switch priority {
+		case models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh:
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "priority must be one of low, medium, high"})
+			return
+		}
+		filters.Priority = &priority
+	}
+
+	// Due date range (inclusive, YYYY-MM-DD)
+	if dueFromStr := c.Query("due_from"); dueFromStr != "" {
+		parsed, err := time.Parse("2006-01-02", dueFromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_from date, expected YYYY-MM-DD"})
+			return
+		}
+		filters.DueFrom = &parsed
+	}
+	if dueToStr := c.Query("due_to"); dueToStr != "" {
+		parsed, err := time.Parse("2006-01-02", dueToStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_to date, expected YYYY-MM-DD"})
+			return
+		}
+		filters.DueTo = &parsed
+	}
+	if filters.DueFrom != nil && filters.DueTo != nil && filters.DueTo.Before(*filters.DueFrom) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "due_to date must not be before due_from date"})
+		return
+	}
+
+	filters.OverdueOnly = c.Query("overdue") == "true"
+
+	filters.SortBy = c.DefaultQuery("sort", "due_date")
+	if _, ok := models.VolunteerTaskSortFields[filters.SortBy]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of due_date, priority, status, project, created_at, updated_at"})
+		return
+	}
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		filters.SortDesc = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+		return
+	}
+
+	page, err := h.taskService.ListVolunteerTasks(volunteer.ID, filters, limit, offset)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_MY_TASKS: Failed to list tasks for volunteer %s: %v", volunteer.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks":         page.Tasks,
+		"total":         page.Total,
+		"overdue_count": page.OverdueCount,
+		"limit":         limit,
+		"offset":        offset,
+	})
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// taskRow is a project_tasks row for TaskService.GetByID
+func taskRow(taskID, projectID, assigneeID uuid.UUID, status models.TaskStatus) []driver.Value {
+	now := time.Now()
+	return []driver.Value{
+		taskID.String(), projectID.String(), "Book van", "", assigneeID.String(),
+		uuid.New().String(), string(status), "medium", nil, "[]",
+		now, now, nil,
+	}
+}
+
+// volunteerRow is a volunteers row for VolunteerService.GetByUserID
+func volunteerRow(volunteerID, userID uuid.UUID) []driver.Value {
+	now := time.Now()
+	return []driver.Value{
+		volunteerID.String(), userID.String(), "Sam", "", nil, nil,
+		"", []byte("[]"), []byte("{}"), true, true, now, now,
+	}
+}
+
+// newFakeTaskHandler returns a task handler on a fake database holding one in-progress
+// task, assigned to the volunteer assigneeID, and a volunteer profile for callerID, who
+// is an active member of the task's project but doesn't lead it
+func newFakeTaskHandler(assigneeID, callerID, callerVolunteerID uuid.UUID) (*TaskHandler, *fakesql.Recorder, func()) {
+	return newFakeTaskHandlerWithStatus(assigneeID, callerID, callerVolunteerID, models.TaskStatusInProgress, true)
+}
+
+// newFakeTaskHandlerWithStatus is newFakeTaskHandler for a task in the given status,
+// with the caller on the project's team or not
+func newFakeTaskHandlerWithStatus(assigneeID, callerID, callerVolunteerID uuid.UUID, status models.TaskStatus, callerOnTeam bool) (*TaskHandler, *fakesql.Recorder, func()) {
+	db, recorder := fakesql.Open()
+	recorder.Rows("FROM project_tasks WHERE id = $1", []string{
+		"id", "project_id", "title", "description", "assignee_id", "created_by_id",
+		"status", "priority", "due_date", "labels", "created_at", "updated_at", "recurrence_id",
+	}, taskRow(uuid.New(), uuid.New(), assigneeID, status))
+	recorder.Rows("FROM volunteers WHERE user_id = $1", []string{
+		"id", "user_id", "name", "phone", "location_lat", "location_lng",
+		"location_address", "skills", "availability", "skills_visible", "consent_given", "created_at", "updated_at",
+	}, volunteerRow(callerVolunteerID, callerID))
+	recorder.Rows("team_lead_id = $2", []string{"count"}, []driver.Value{int64(0)})
+	members := int64(0)
+	if callerOnTeam {
+		members = 1
+	}
+	recorder.Rows("v.user_id = $2 AND ptm.status = 'active'", []string{"count"}, []driver.Value{members})
+
+	h := &TaskHandler{
+		taskService:      models.NewTaskService(db),
+		projectService:   models.NewProjectService(db),
+		volunteerService: models.NewVolunteerService(db),
+	}
+	return h, recorder, func() { db.Close() }
+}
+
+func serveTaskRequest(handler gin.HandlerFunc, callerID uuid.UUID, method, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Handle(method, "/api/tasks/:id", func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		c.Set("user_email", "caller@example.com")
+		handler(c)
+	})
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, "/api/tasks/"+uuid.New().String(), strings.NewReader(body)))
+	return recorder
+}
+
+func TestAssigneeOnlyTaskEndpointsRejectNonAssignees(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(*TaskHandler) gin.HandlerFunc
+		method  string
+		body    string
+	}{
+		{"update", func(h *TaskHandler) gin.HandlerFunc { return h.UpdateTask }, http.MethodPut, `{"status":"done"}`},
+		{"add update", func(h *TaskHandler) gin.HandlerFunc { return h.AddTaskUpdate }, http.MethodPost, `{"update_text":"Van booked"}`},
+		{"mark blocked", func(h *TaskHandler) gin.HandlerFunc { return h.MarkTaskBlocked }, http.MethodPost, `{"reason":"No keys"}`},
+		{"request takeover", func(h *TaskHandler) gin.HandlerFunc { return h.RequestTaskTakeover }, http.MethodPost, `{"reason":"Away"}`},
+		{"mark done", func(h *TaskHandler) gin.HandlerFunc { return h.MarkTaskDone }, http.MethodPost, `{"completion_note":"Booked"}`},
+		{"start", func(h *TaskHandler) gin.HandlerFunc { return h.StartTask }, http.MethodPost, ``},
+		{"reopen", func(h *TaskHandler) gin.HandlerFunc { return h.ReopenTask }, http.MethodPost, ``},
+	}
+
+	for _, tt := range tests {
+		for _, onTeam := range []bool{false, true} {
+			// The caller's user ID is the task's assignee ID, but assignee IDs are volunteer
+			// IDs and the caller's volunteer profile is a different one
+			callerID := uuid.New()
+			h, recorder, closeDB := newFakeTaskHandlerWithStatus(callerID, callerID, uuid.New(), models.TaskStatusInProgress, onTeam)
+			response := serveTaskRequest(tt.handler(h), callerID, tt.method, tt.body)
+			closeDB()
+
+			// Outsiders can't tell the task exists; team members are told they can't change it
+			want := http.StatusNotFound
+			if onTeam {
+				want = http.StatusForbidden
+			}
+			if response.Code != want {
+				t.Errorf("%s (on team: %t): status = %d, want %d: %s", tt.name, onTeam, response.Code, want, response.Body.String())
+			}
+			if recorder.Ran("UPDATE project_tasks") || recorder.Ran("INSERT INTO task_updates") {
+				t.Errorf("%s (on team: %t): the task was changed by a non-assignee", tt.name, onTeam)
+			}
+		}
+	}
+}
+
+func TestUpdateTaskLimitsAssigneesToStatus(t *testing.T) {
+	callerID := uuid.New()
+	volunteerID := uuid.New()
+	h, recorder, closeDB := newFakeTaskHandler(volunteerID, callerID, volunteerID)
+	defer closeDB()
+
+	response := serveTaskRequest(h.UpdateTask, callerID, http.MethodPut, `{"title":"Book a bigger van"}`)
+
+	if response.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403: %s", response.Code, response.Body.String())
+	}
+	if recorder.Ran("UPDATE project_tasks") {
+		t.Error("an assignee changed a field other than the status")
+	}
+}
+
+func TestUpdateTaskRejectsInvalidStatusChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  models.TaskStatus
+		body     string
+		wantCode int
+	}{
+		{"unknown status", models.TaskStatusInProgress, `{"status":"banana"}`, http.StatusBadRequest},
+		{"disallowed transition", models.TaskStatusDone, `{"status":"in_progress"}`, http.StatusConflict},
+		{"no-op transition", models.TaskStatusInProgress, `{"status":"in_progress"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		callerID := uuid.New()
+		volunteerID := uuid.New()
+		h, recorder, closeDB := newFakeTaskHandlerWithStatus(volunteerID, callerID, volunteerID, tt.current, true)
+		response := serveTaskRequest(h.UpdateTask, callerID, http.MethodPut, tt.body)
+		closeDB()
+
+		if response.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, response.Code, tt.wantCode, response.Body.String())
+		}
+		if recorder.Ran("UPDATE project_tasks") {
+			t.Errorf("%s: the task status was changed", tt.name)
+		}
+	}
+}
+
+func TestUpdateTaskReportsConcurrentStatusChanges(t *testing.T) {
+	callerID := uuid.New()
+	volunteerID := uuid.New()
+	h, recorder, closeDB := newFakeTaskHandlerWithStatus(volunteerID, callerID, volunteerID, models.TaskStatusTodo, true)
+	defer closeDB()
+	// Another request moved the task out of todo after it was read
+	recorder.Affects("UPDATE project_tasks", 0)
+
+	response := serveTaskRequest(h.UpdateTask, callerID, http.MethodPut, `{"status":"blocked"}`)
+
+	if response.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409: %s", response.Code, response.Body.String())
+	}
+	if recorder.Ran("INSERT INTO task_activity_log") {
+		t.Error("a status change that didn't apply was logged")
+	}
+}
+
+func TestGetMyTasksListsTheCallersVolunteerTasks(t *testing.T) {
+	callerID := uuid.New()
+	volunteerID := uuid.New()
+	h, recorder, closeDB := newFakeTaskHandler(uuid.New(), callerID, volunteerID)
+	defer closeDB()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/volunteers/me/tasks", func(c *gin.Context) {
+		c.Set("user_id", callerID)
+		c.Set("user_email", "caller@example.com")
+		h.GetMyTasks(c)
+	})
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/api/volunteers/me/tasks?status=todo,in_progress", nil))
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", response.Code, response.Body.String())
+	}
+	// Tasks are assigned to volunteers, so the list is keyed on the caller's volunteer ID
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "pt.assignee_id = $1") && statement.Args[0] != volunteerID.String() {
+			t.Errorf("tasks listed for %v, want the caller's volunteer %s", statement.Args[0], volunteerID)
+		}
+	}
+	if !recorder.Ran("pt.assignee_id = $1") {
+		t.Error("the caller's tasks were not listed")
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UpdateTaskRecurrenceRequest represents a recurring task template update. Empty fields
+// are left unchanged; a rule replaces the whole rule, so it can also clear the end
+// conditions. A rule with frequency "none" stops the recurrence.
+type UpdateTaskRecurrenceRequest struct {
+	Title       string                     `json:"title"`
+	Description string                     `json:"description"`
+	AssigneeID  *uuid.UUID                 `json:"assignee_id"`
+	Priority    string                     `json:"priority"`
+	Labels      []string                   `json:"labels"`
+	Rule        *models.TaskRecurrenceRule `json:"rule"`
+	// NextDueAt reschedules the next instance and re-anchors monthly repeats on its day.
+	// Without it the next instance keeps its date and later ones follow the new rule.
+	NextDueAt *time.Time `json:"next_due_at"`
+}
+
+// ListTaskRecurrences handles GET /api/projects/:id/task-recurrences
+func (h *TaskHandler) ListTaskRecurrences(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+	if !h.requireRecurrenceManager(c, projectID, userCtx) {
+		return
+	}
+
+	recurrences, err := h.taskService.ListRecurrencesByProject(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ TASK_RECURRENCE: Failed to list recurring tasks for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recurring tasks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recurrences": recurrences})
+}
+
+// UpdateTaskRecurrence handles PUT /api/task-recurrences/:id
+// Changes apply to instances created from now on; existing instances are unchanged.
+func (h *TaskHandler) UpdateTaskRecurrence(c *gin.Context) {
+	recurrenceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrence ID"})
+		return
+	}
+
+	var req UpdateTaskRecurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	recurrence, err := h.taskService.GetRecurrence(recurrenceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recurring task"})
+		return
+	}
+	if recurrence == nil {
+		respondNotFound(c, "Recurring task")
+		return
+	}
+	if !h.requireRecurrenceManager(c, recurrence.ProjectID, userCtx) {
+		return
+	}
+
+	if req.Rule != nil && req.Rule.Frequency == models.TaskRecurrenceNone {
+		h.stopTaskRecurrence(c, recurrenceID)
+		return
+	}
+
+	if req.Title != "" {
+		recurrence.Title = req.Title
+	}
+	if req.Description != "" {
+		recurrence.Description = req.Description
+	}
+	if req.AssigneeID != nil {
+		recurrence.AssigneeID = req.AssigneeID
+	}
+	if req.Priority != "" {
+		recurrence.Priority = models.TaskPriority(req.Priority)
+	}
+	if req.Labels != nil {
+		recurrence.Labels = req.Labels
+	}
+	if req.Rule != nil {
+		if req.Rule.Interval == 0 {
+			req.Rule.Interval = 1
+		}
+		recurrence.TaskRecurrenceRule = *req.Rule
+	}
+	if req.NextDueAt != nil {
+		recurrence.StartsAt = *req.NextDueAt
+		recurrence.NextDueAt = *req.NextDueAt
+	}
+
+	if err := h.taskService.UpdateRecurrence(recurrence); err != nil {
+		switch {
+		case respondTaskRecurrenceError(c, err):
+		case errors.Is(err, sql.ErrNoRows):
+			respondNotFound(c, "Recurring task")
+		default:
+			logging.Errorf(c.Request.Context(), "❌ TASK_RECURRENCE: Failed to update recurring task %s: %v", recurrenceID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update recurring task"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// StopTaskRecurrence handles DELETE /api/task-recurrences/:id
+// No further instances are created; the current instance and earlier ones remain.
+func (h *TaskHandler) StopTaskRecurrence(c *gin.Context) {
+	recurrenceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrence ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	recurrence, err := h.taskService.GetRecurrence(recurrenceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recurring task"})
+		return
+	}
+	if recurrence == nil {
+		respondNotFound(c, "Recurring task")
+		return
+	}
+	if !h.requireRecurrenceManager(c, recurrence.ProjectID, userCtx) {
+		return
+	}
+
+	h.stopTaskRecurrence(c, recurrenceID)
+}
+
+func (h *TaskHandler) stopTaskRecurrence(c *gin.Context, recurrenceID uuid.UUID) {
+	if err := h.taskService.StopRecurrence(recurrenceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondNotFound(c, "Recurring task")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ TASK_RECURRENCE: Failed to stop recurring task %s: %v", recurrenceID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop recurring task"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Recurring task stopped"})
+}
+
+// requireRecurrenceManager checks the user may manage a project's recurring tasks: its
+// team lead or an admin, as for creating tasks. It responds as the access policy says
+// and returns false otherwise.
+func (h *TaskHandler) requireRecurrenceManager(c *gin.Context, projectID uuid.UUID, userCtx *middleware.UserContext) bool {
+	isTeamLead, err := h.projectService.IsTeamLead(projectID, userCtx.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+		return false
+	}
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) && !isTeamLead {
+		respondProjectForbidden(c, "Project", projectID, userCtx, h.projectService, "Only project team lead can manage recurring tasks")
+		return false
+	}
+	return true
+}
+
+// respondTaskRecurrenceError writes the response for a recurrence validation error and
+// reports whether err was one
+func respondTaskRecurrenceError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrInvalidTaskRecurrence), errors.Is(err, models.ErrRecurrenceNeedsDueDate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
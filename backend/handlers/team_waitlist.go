@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetWaitlist handles GET /api/projects/:id/waitlist
+func (h *ProjectHandler) GetWaitlist(c *gin.Context) {
+	projectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	// Checked before loading the project, so outsiders can't tell whether it exists
+	if !userCtx.HasPermission(models.PermissionManageAllProjects) {
+		isTeamLead, err := h.service.IsTeamLead(projectID, userCtx.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check team lead status"})
+			return
+		}
+		if !isTeamLead {
+			respondProjectForbidden(c, "Project", projectID, userCtx, h.service, "Only the project team lead can view the waitlist")
+			return
+		}
+	}
+
+	project, err := h.service.GetByID(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_WAITLIST: Failed to get project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get project"})
+		return
+	}
+	if project == nil {
+		respondNotFound(c, "Project")
+		return
+	}
+
+	waitlist, err := h.service.GetWaitlist(projectID)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_WAITLIST: Failed to get waitlist for project %s: %v", projectID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get waitlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"waitlist":      waitlist,
+		"max_team_size": project.MaxTeamSize,
+	})
+}
+
+// promoteFromWaitlist fills any open places on a project team from its waitlist, oldest
+// first, and messages each promoted volunteer. senderID is the user whose action opened
+// the places. Failures are logged rather than returned: the action that freed the
+// places has already succeeded.
+func promoteFromWaitlist(ctx context.Context, db *sql.DB, projectID, senderID uuid.UUID) {
+	projectService := models.NewProjectService(db)
+	promoted, err := projectService.PromoteFromWaitlist(projectID)
+	if err != nil {
+		logging.Errorf(ctx, "❌ PROMOTE_WAITLIST: Failed to promote waitlisted volunteers for project %s: %v", projectID, err)
+		return
+	}
+	if len(promoted) == 0 {
+		return
+	}
+	logging.Printf(ctx, "⬆️ PROMOTE_WAITLIST: Promoted %d waitlisted volunteers to the team for project %s", len(promoted), projectID)
+
+	project, err := projectService.GetByID(projectID)
+	if err != nil || project == nil {
+		logging.Errorf(ctx, "❌ PROMOTE_WAITLIST: Failed to get project %s to notify volunteers: %v", projectID, err)
+		return
+	}
+
+	volunteerService := models.NewVolunteerService(db)
+	messageService := models.NewMessageService(db)
+	subject := fmt.Sprintf("You've joined the team for %s", project.Title)
+	messageText := fmt.Sprintf("Good news! A place has opened up on the team for %s, so you've been moved off the "+
+		"waitlist and are now an active team member. Welcome aboard!", project.Title)
+
+	for _, volunteerID := range promoted {
+		volunteer, err := volunteerService.GetByID(volunteerID)
+		if err != nil || volunteer == nil {
+			logging.Warnf(ctx, "⚠️ PROMOTE_WAITLIST: Failed to find volunteer %s to notify: %v", volunteerID, err)
+			continue
+		}
+
+		recipientID := volunteer.UserID
+		message := &models.ProjectMessage{
+			ProjectID:       &project.ID,
+			SenderID:        senderID,
+			RecipientUserID: &recipientID,
+			Subject:         &subject,
+			MessageText:     messageText,
+		}
+		if err := messageService.CreateUniversalMessage(message); err != nil {
+			logging.Warnf(ctx, "⚠️ PROMOTE_WAITLIST: Failed to notify volunteer %s: %v", volunteerID, err)
+		}
+	}
+}
@@ -1,14 +1,15 @@
 package handlers
 
 import (
-	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 )
@@ -46,9 +47,18 @@ type DashboardData struct {
 	Messages   models.UniversalUnreadCount   `json:"messages"`
 	Broadcasts []models.BroadcastWithAuthor  `json:"broadcasts"`
 	Resources  []models.ResourceWithUploader `json:"resources"`
+	Deadlines  DashboardDeadlines            `json:"deadlines"`
 	Stats      DashboardStats                `json:"stats"`
 }
 
+// DashboardDeadlines represents upcoming work within the dashboard's day window
+type DashboardDeadlines struct {
+	WindowDays       int                         `json:"window_days"`
+	DueTasks         []models.ProjectTask        `json:"due_tasks"`
+	StartingProjects []models.ProjectWithDetails `json:"starting_projects"`
+	BlockedTasks     []models.ProjectTask        `json:"blocked_tasks"`
+}
+
 // DashboardStats represents dashboard statistics
 type DashboardStats struct {
 	TotalProjects    int `json:"total_projects"`
@@ -57,6 +67,9 @@ type DashboardStats struct {
 	UnreadMessages   int `json:"unread_messages"`
 	UnreadBroadcasts int `json:"unread_broadcasts"`
 	RecentResources  int `json:"recent_resources"`
+	DueSoonTasks     int `json:"due_soon_tasks"`
+	StartingProjects int `json:"starting_projects"`
+	BlockedTasks     int `json:"blocked_tasks"`
 }
 
 // GetUserProjects handles GET /api/users/me/projects
@@ -71,12 +84,12 @@ func (h *UserDashboardHandler) GetUserProjects(c *gin.Context) {
 	// Get user's enrolled projects
 	projects, err := h.projectService.GetUserEnrolledProjects(userCtx.ID)
 	if err != nil {
-		log.Printf("❌ GET_USER_PROJECTS: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_USER_PROJECTS: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user projects"})
 		return
 	}
 
-	log.Printf("✅ GET_USER_PROJECTS: Successfully fetched %d projects for user %s", len(projects), userCtx.ID)
+	logging.Printf(c.Request.Context(), "✅ GET_USER_PROJECTS: Successfully fetched %d projects for user %s", len(projects), userCtx.ID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"projects": projects,
@@ -104,12 +117,12 @@ func (h *UserDashboardHandler) GetUserTasks(c *gin.Context) {
 	// Get user's assigned tasks
 	tasks, err := h.taskService.ListByAssignee(volunteer.ID)
 	if err != nil {
-		log.Printf("❌ GET_USER_TASKS: Database error: %v", err)
+		logging.Errorf(c.Request.Context(), "❌ GET_USER_TASKS: Database error: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user tasks"})
 		return
 	}
 
-	log.Printf("✅ GET_USER_TASKS: Successfully fetched %d tasks for user %s", len(tasks), userCtx.ID)
+	logging.Printf(c.Request.Context(), "✅ GET_USER_TASKS: Successfully fetched %d tasks for user %s", len(tasks), userCtx.ID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"tasks": tasks,
@@ -131,6 +144,7 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 	tasksLimitStr := c.DefaultQuery("tasks_limit", "10")
 	broadcastsLimitStr := c.DefaultQuery("broadcasts_limit", "5")
 	resourcesLimitStr := c.DefaultQuery("resources_limit", "5")
+	daysStr := c.DefaultQuery("days", "7")
 
 	projectsLimit, _ := strconv.Atoi(projectsLimitStr)
 	if projectsLimit < 1 || projectsLimit > 20 {
@@ -152,6 +166,11 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 		resourcesLimit = 5
 	}
 
+	days, _ := strconv.Atoi(daysStr)
+	if days < 1 || days > 90 {
+		days = 7
+	}
+
 	// Get user's volunteer profile
 	volunteerService := models.NewVolunteerService(h.projectService.GetDB())
 	volunteer, err := volunteerService.GetByUserID(userCtx.ID)
@@ -194,19 +213,23 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 		resources []models.ResourceWithUploader
 		err       error
 	}
+	type unreadBroadcastsResult struct {
+		count int
+		err   error
+	}
 
 	projectsChan := make(chan projectsResult, 1)
 	tasksChan := make(chan tasksResult, 1)
 	messagesChan := make(chan messagesResult, 1)
 	broadcastsChan := make(chan broadcastsResult, 1)
 	resourcesChan := make(chan resourcesResult, 1)
+	unreadBroadcastsChan := make(chan unreadBroadcastsResult, 1)
+	dueTasksChan := make(chan tasksResult, 1)
+	blockedTasksChan := make(chan tasksResult, 1)
 
-	// Fetch projects
+	// Fetch projects; the full list is kept so projects starting soon can be picked out
 	go func() {
 		projects, err := h.projectService.GetUserEnrolledProjects(userCtx.ID)
-		if len(projects) > projectsLimit {
-			projects = projects[:projectsLimit]
-		}
 		projectsChan <- projectsResult{projects: projects, err: err}
 	}()
 
@@ -227,11 +250,7 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 
 	// Fetch broadcasts
 	go func() {
-		userRole := "volunteer" // default
-		if len(roleNames) > 0 {
-			userRole = roleNames[0]
-		}
-		broadcasts, err := h.broadcastService.List(userCtx.ID, userRole, broadcastsLimit, 0)
+		broadcasts, err := h.broadcastService.List(userCtx.ID, middleware.EffectiveRoles(roleNames), broadcastsLimit, 0)
 		broadcastsChan <- broadcastsResult{broadcasts: broadcasts, err: err}
 	}()
 
@@ -242,36 +261,57 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 		resourcesChan <- resourcesResult{resources: resources, err: err}
 	}()
 
+	// Fetch unread broadcast count
+	go func() {
+		count, err := h.broadcastService.GetUnreadCount(userCtx.ID, middleware.EffectiveRoles(roleNames))
+		unreadBroadcastsChan <- unreadBroadcastsResult{count: count, err: err}
+	}()
+
+	// Fetch tasks due within the window
+	go func() {
+		tasks, err := h.taskService.ListDueForUser(userCtx.ID, time.Now().AddDate(0, 0, days), tasksLimit)
+		dueTasksChan <- tasksResult{tasks: tasks, err: err}
+	}()
+
+	// Fetch blocked tasks needing attention
+	go func() {
+		tasks, err := h.taskService.ListBlockedForUser(userCtx.ID, tasksLimit)
+		blockedTasksChan <- tasksResult{tasks: tasks, err: err}
+	}()
+
 	// Collect results
 	projectsRes := <-projectsChan
 	tasksRes := <-tasksChan
 	messagesRes := <-messagesChan
 	broadcastsRes := <-broadcastsChan
 	resourcesRes := <-resourcesChan
+	unreadBroadcastsRes := <-unreadBroadcastsChan
+	dueTasksRes := <-dueTasksChan
+	blockedTasksRes := <-blockedTasksChan
 
 	// Check for errors
 	if projectsRes.err != nil {
-		log.Printf("❌ GET_DASHBOARD_DATA: Projects error: %v", projectsRes.err)
+		logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Projects error: %v", projectsRes.err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
 		return
 	}
 	if tasksRes.err != nil {
-		log.Printf("❌ GET_DASHBOARD_DATA: Tasks error: %v", tasksRes.err)
+		logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Tasks error: %v", tasksRes.err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tasks"})
 		return
 	}
 	if messagesRes.err != nil {
-		log.Printf("❌ GET_DASHBOARD_DATA: Messages error: %v", messagesRes.err)
+		logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Messages error: %v", messagesRes.err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get messages"})
 		return
 	}
 	if broadcastsRes.err != nil {
 		// Check if the error is due to missing table
 		if strings.Contains(broadcastsRes.err.Error(), "does not exist") {
-			log.Printf("⚠️ GET_DASHBOARD_DATA: Broadcast table not found, using empty list")
+			logging.Warnf(c.Request.Context(), "⚠️ GET_DASHBOARD_DATA: Broadcast table not found, using empty list")
 			broadcastsRes.broadcasts = []models.BroadcastWithAuthor{}
 		} else {
-			log.Printf("❌ GET_DASHBOARD_DATA: Broadcasts error: %v", broadcastsRes.err)
+			logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Broadcasts error: %v", broadcastsRes.err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcasts"})
 			return
 		}
@@ -279,22 +319,73 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 	if resourcesRes.err != nil {
 		// Check if the error is due to missing table
 		if strings.Contains(resourcesRes.err.Error(), "does not exist") {
-			log.Printf("⚠️ GET_DASHBOARD_DATA: Resources table not found, using empty list")
+			logging.Warnf(c.Request.Context(), "⚠️ GET_DASHBOARD_DATA: Resources table not found, using empty list")
 			resourcesRes.resources = []models.ResourceWithUploader{}
 		} else {
-			log.Printf("❌ GET_DASHBOARD_DATA: Resources error: %v", resourcesRes.err)
+			logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Resources error: %v", resourcesRes.err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resources"})
 			return
 		}
 	}
 
+	if unreadBroadcastsRes.err != nil {
+		// Check if the error is due to missing table
+		if strings.Contains(unreadBroadcastsRes.err.Error(), "does not exist") {
+			unreadBroadcastsRes.count = 0
+		} else {
+			logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Unread broadcasts error: %v", unreadBroadcastsRes.err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broadcasts"})
+			return
+		}
+	}
+	if dueTasksRes.err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Due tasks error: %v", dueTasksRes.err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get upcoming tasks"})
+		return
+	}
+	if blockedTasksRes.err != nil {
+		logging.Errorf(c.Request.Context(), "❌ GET_DASHBOARD_DATA: Blocked tasks error: %v", blockedTasksRes.err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get blocked tasks"})
+		return
+	}
+
+	// Pick out enrolled projects starting within the window
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	windowEnd := today.AddDate(0, 0, days)
+	startingProjects := []models.ProjectWithDetails{}
+	for _, project := range projectsRes.projects {
+		if project.StartDate != nil && !project.StartDate.Before(today) && !project.StartDate.After(windowEnd) {
+			startingProjects = append(startingProjects, project)
+		}
+	}
+	sort.Slice(startingProjects, func(i, j int) bool {
+		return startingProjects[i].StartDate.Before(*startingProjects[j].StartDate)
+	})
+
+	totalProjects := len(projectsRes.projects)
+	if totalProjects > projectsLimit {
+		projectsRes.projects = projectsRes.projects[:projectsLimit]
+	}
+
+	dueTasks := dueTasksRes.tasks
+	if dueTasks == nil {
+		dueTasks = []models.ProjectTask{}
+	}
+	blockedTasks := blockedTasksRes.tasks
+	if blockedTasks == nil {
+		blockedTasks = []models.ProjectTask{}
+	}
+
 	// Calculate stats
 	stats := DashboardStats{
-		TotalProjects:    len(projectsRes.projects),
+		TotalProjects:    totalProjects,
 		ActiveTasks:      len(tasksRes.tasks),
 		UnreadMessages:   messagesRes.messages.Total,
-		UnreadBroadcasts: len(broadcastsRes.broadcasts),
+		UnreadBroadcasts: unreadBroadcastsRes.count,
 		RecentResources:  len(resourcesRes.resources),
+		DueSoonTasks:     len(dueTasks),
+		StartingProjects: len(startingProjects),
+		BlockedTasks:     len(blockedTasks),
 	}
 
 	// Count overdue tasks
@@ -313,10 +404,16 @@ func (h *UserDashboardHandler) GetDashboardData(c *gin.Context) {
 		Messages:   *messagesRes.messages,
 		Broadcasts: broadcastsRes.broadcasts,
 		Resources:  resourcesRes.resources,
-		Stats:      stats,
+		Deadlines: DashboardDeadlines{
+			WindowDays:       days,
+			DueTasks:         dueTasks,
+			StartingProjects: startingProjects,
+			BlockedTasks:     blockedTasks,
+		},
+		Stats: stats,
 	}
 
-	log.Printf("✅ GET_DASHBOARD_DATA: Successfully fetched dashboard data for user %s", userCtx.ID)
+	logging.Printf(c.Request.Context(), "✅ GET_DASHBOARD_DATA: Successfully fetched dashboard data for user %s", userCtx.ID)
 
 	c.JSON(http.StatusOK, dashboardData)
 }
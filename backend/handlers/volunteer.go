@@ -14,15 +14,17 @@ import (
 
 // VolunteerHandler handles volunteer-related requests
 type VolunteerHandler struct {
-	service *models.VolunteerService
-	config  *config.Config
+	service      *models.VolunteerService
+	auditService *models.AdminAuditService
+	config       *config.Config
 }
 
 // NewVolunteerHandler creates a new volunteer handler
-func NewVolunteerHandler(service *models.VolunteerService, config *config.Config) *VolunteerHandler {
+func NewVolunteerHandler(service *models.VolunteerService, auditService *models.AdminAuditService, config *config.Config) *VolunteerHandler {
 	return &VolunteerHandler{
-		service: service,
-		config:  config,
+		service:      service,
+		auditService: auditService,
+		config:       config,
 	}
 }
 
@@ -121,7 +123,7 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 	}
 
 	// Check permission: user can only update their own profile (unless admin)
-	if existingVolunteer.UserID != userCtx.ID && !userCtx.HasRole("admin") {
+	if existingVolunteer.UserID != userCtx.ID && !userCtx.HasPermission(models.PermissionManageVolunteers) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
 		return
 	}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// volunteerExportFlushEvery is how many rows are written between flushes to the client
+const volunteerExportFlushEvery = 100
+
+// volunteerExportColumns are the CSV header columns, in the order exportCSVRecord writes them
+var volunteerExportColumns = []string{
+	"id", "user_id", "name", "email", "phone", "location_address", "email_verified", "created_at",
+	"skills", "total_ratings", "up_ratings", "down_ratings", "neutral_ratings",
+	"active_projects", "total_projects",
+}
+
+// ExportVolunteers handles GET /api/admin/volunteers/export
+func (h *VolunteerHandler) ExportVolunteers(c *gin.Context) {
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+		return
+	}
+
+	// The export contains contact details, so record who took it before any data is sent
+	// and refuse the export if that can't be recorded
+	ctx := c.Request.Context()
+	entry := &models.AdminAuditEntry{
+		ActorID:    userCtx.ID,
+		ActorEmail: userCtx.Email,
+		Action:     models.AdminAuditActionExportVolunteers,
+	}
+	if err := h.auditService.Record(entry, nil, map[string]interface{}{"format": format, "ip": c.ClientIP()}); err != nil {
+		logging.Errorf(ctx, "❌ EXPORT_VOLUNTEERS: Failed to record export by admin %s: %v", userCtx.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export volunteers"})
+		return
+	}
+
+	filename := fmt.Sprintf("volunteers-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	var csvWriter *csv.Writer
+	rows := 0
+
+	// Headers are only sent with the first row, so a failing query can still return a 500
+	start := func() {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		c.Header("Cache-Control", "no-store")
+		if format == "csv" {
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Status(http.StatusOK)
+			csvWriter = csv.NewWriter(c.Writer)
+			csvWriter.Write(volunteerExportColumns)
+		} else {
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			c.Status(http.StatusOK)
+			c.Writer.WriteString("[")
+		}
+	}
+
+	err := h.service.StreamExport(func(row *models.VolunteerExportRow) error {
+		if rows == 0 {
+			start()
+		}
+
+		if format == "csv" {
+			if err := csvWriter.Write(exportCSVRecord(row)); err != nil {
+				return err
+			}
+		} else {
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if rows > 0 {
+				c.Writer.WriteString(",")
+			}
+			if _, err := c.Writer.Write(data); err != nil {
+				return err
+			}
+		}
+
+		rows++
+		if rows%volunteerExportFlushEvery == 0 {
+			if csvWriter != nil {
+				csvWriter.Flush()
+				if err := csvWriter.Error(); err != nil {
+					return err
+				}
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Errorf(ctx, "❌ EXPORT_VOLUNTEERS: Export failed after %d rows: %v", rows, err)
+		if rows == 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export volunteers"})
+		}
+		// Once rows have been sent the status can't change, so the download is left truncated
+		return
+	}
+
+	if rows == 0 {
+		start()
+	}
+	if csvWriter != nil {
+		csvWriter.Flush()
+	} else {
+		c.Writer.WriteString("]")
+	}
+	c.Writer.Flush()
+
+	logging.Printf(ctx, "🔐 AUDIT: Volunteer export (%s) by admin %s completed with %d rows", format, userCtx.ID, rows)
+}
+
+// exportCSVRecord formats a volunteer export row as CSV fields, joining skills with semicolons.
+// Fields volunteers can write are passed through csvSafeField.
+func exportCSVRecord(row *models.VolunteerExportRow) []string {
+	return []string{
+		row.ID.String(),
+		row.UserID.String(),
+		csvSafeField(row.Name),
+		csvSafeField(row.Email),
+		csvSafeField(row.Phone),
+		csvSafeField(row.LocationAddress),
+		strconv.FormatBool(row.EmailVerified),
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		csvSafeField(strings.Join(row.Skills, ";")),
+		strconv.Itoa(row.TotalRatings),
+		strconv.Itoa(row.UpRatings),
+		strconv.Itoa(row.DownRatings),
+		strconv.Itoa(row.NeutralRatings),
+		strconv.Itoa(row.ActiveProjects),
+		strconv.Itoa(row.TotalProjects),
+	}
+}
+
+// csvSafeField stops spreadsheet apps running a field as a formula by prefixing fields
+// that start with a formula character with an apostrophe
+func csvSafeField(field string) string {
+	if field != "" && strings.ContainsRune("=+-@\t\r", rune(field[0])) {
+		return "'" + field
+	}
+	return field
+}
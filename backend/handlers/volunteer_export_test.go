@@ -0,0 +1,25 @@
+package handlers
+
+import "testing"
+
+func TestCSVSafeField(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"", ""},
+		{"Ann Lee", "Ann Lee"},
+		{"=HYPERLINK(\"http://evil.example\")", "'=HYPERLINK(\"http://evil.example\")"},
+		{"+1 555 0100", "'+1 555 0100"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"first aid;=cmd", "first aid;=cmd"},
+	}
+
+	for _, tt := range tests {
+		if got := csvSafeField(tt.field); got != tt.want {
+			t.Errorf("csvSafeField(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
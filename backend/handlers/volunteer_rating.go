@@ -3,28 +3,39 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"civicweave/backend/config"
 	"civicweave/backend/middleware"
 	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// Minimum-ratings threshold bounds for the top-rated leaderboard. The floor keeps
+// a volunteer with a single glowing rating from topping the board.
+const (
+	defaultTopRatedMinRatings = 3
+	maxTopRatedMinRatings     = 100
+)
+
 // VolunteerRatingHandler handles volunteer rating requests
 type VolunteerRatingHandler struct {
-	ratingService    *models.VolunteerRatingService
-	volunteerService *models.VolunteerService
-	config           *config.Config
+	ratingService        *models.VolunteerRatingService
+	volunteerService     *models.VolunteerService
+	skillTaxonomyService *models.SkillTaxonomyService
+	config               *config.Config
 }
 
 // NewVolunteerRatingHandler creates a new volunteer rating handler
-func NewVolunteerRatingHandler(ratingService *models.VolunteerRatingService, volunteerService *models.VolunteerService, config *config.Config) *VolunteerRatingHandler {
+func NewVolunteerRatingHandler(ratingService *models.VolunteerRatingService, volunteerService *models.VolunteerService, skillTaxonomyService *models.SkillTaxonomyService, config *config.Config) *VolunteerRatingHandler {
 	return &VolunteerRatingHandler{
-		ratingService:    ratingService,
-		volunteerService: volunteerService,
-		config:           config,
+		ratingService:        ratingService,
+		volunteerService:     volunteerService,
+		skillTaxonomyService: skillTaxonomyService,
+		config:               config,
 	}
 }
 
@@ -62,8 +73,8 @@ func (h *VolunteerRatingHandler) CreateRating(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to rate (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to rate (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to rate volunteers"})
 		return
 	}
@@ -96,7 +107,7 @@ func (h *VolunteerRatingHandler) CreateRating(c *gin.Context) {
 	c.JSON(http.StatusCreated, rating)
 }
 
-// GetVolunteerScorecard handles GET /api/volunteers/:id/scorecard
+// GetVolunteerScorecard handles GET /api/volunteers/:id/scorecard (?weighting=recency adds a recency-weighted score)
 func (h *VolunteerRatingHandler) GetVolunteerScorecard(c *gin.Context) {
 	volunteerIDStr := c.Param("id")
 	volunteerID, err := uuid.Parse(volunteerIDStr)
@@ -112,8 +123,8 @@ func (h *VolunteerRatingHandler) GetVolunteerScorecard(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view scorecard (team_lead, admin, or the volunteer themselves)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view scorecard (team_lead or a role that inherits it, or the volunteer themselves)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		// Check if the user is viewing their own scorecard
 		volunteer, err := h.volunteerService.GetByID(volunteerID)
 		if err != nil {
@@ -126,6 +137,21 @@ func (h *VolunteerRatingHandler) GetVolunteerScorecard(c *gin.Context) {
 		}
 	}
 
+	switch c.Query("weighting") {
+	case "":
+	case "recency":
+		scorecard, err := h.ratingService.GetWeightedScorecard(volunteerID, h.config.Ratings.RecencyHalfLifeDays)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get volunteer scorecard"})
+			return
+		}
+		c.JSON(http.StatusOK, scorecard)
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid weighting, expected recency"})
+		return
+	}
+
 	scorecard, err := h.ratingService.GetVolunteerScorecard(volunteerID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get volunteer scorecard"})
@@ -165,8 +191,8 @@ func (h *VolunteerRatingHandler) ListRatingsForVolunteer(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view ratings (team_lead, admin, or the volunteer themselves)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view ratings (team_lead or a role that inherits it, or the volunteer themselves)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		// Check if the user is viewing their own ratings
 		volunteer, err := h.volunteerService.GetByID(volunteerID)
 		if err != nil {
@@ -216,8 +242,8 @@ func (h *VolunteerRatingHandler) ListRatingsByRater(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view their ratings (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view their ratings (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view ratings"})
 		return
 	}
@@ -280,7 +306,7 @@ func (h *VolunteerRatingHandler) UpdateRating(c *gin.Context) {
 	}
 
 	// Check if user has permission to update this rating (admin or the original rater)
-	if !userCtx.HasRole("admin") && rating.RatedByUserID != userCtx.ID {
+	if !userCtx.HasPermission(models.PermissionManageRatings) && rating.RatedByUserID != userCtx.ID {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to update this rating"})
 		return
 	}
@@ -324,7 +350,7 @@ func (h *VolunteerRatingHandler) DeleteRating(c *gin.Context) {
 	}
 
 	// Check if user has permission to delete this rating (admin or the original rater)
-	if !userCtx.HasRole("admin") && rating.RatedByUserID != userCtx.ID {
+	if !userCtx.HasPermission(models.PermissionManageRatings) && rating.RatedByUserID != userCtx.ID {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to delete this rating"})
 		return
 	}
@@ -353,21 +379,89 @@ func (h *VolunteerRatingHandler) GetTopRatedVolunteers(c *gin.Context) {
 		return
 	}
 
-	// Check if user has permission to view top-rated volunteers (team_lead or admin)
-	if !userCtx.HasAnyRole("team_lead", "admin") {
+	// Check if user has permission to view top-rated volunteers (team_lead or a role that inherits it, such as admin)
+	if !userCtx.HasEffectiveRole("team_lead") {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions to view top-rated volunteers"})
 		return
 	}
 
-	volunteers, err := h.ratingService.GetTopRatedVolunteers(limit)
+	minRatings := defaultTopRatedMinRatings
+	if minRatingsStr := c.Query("min_ratings"); minRatingsStr != "" {
+		parsed, err := strconv.Atoi(minRatingsStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_ratings"})
+			return
+		}
+		minRatings = parsed
+	}
+	if minRatings < defaultTopRatedMinRatings {
+		minRatings = defaultTopRatedMinRatings
+	}
+	if minRatings > maxTopRatedMinRatings {
+		minRatings = maxTopRatedMinRatings
+	}
+
+	if skillName := strings.TrimSpace(c.Query("skill")); skillName != "" {
+		skill, err := h.skillTaxonomyService.FindSkill(skillName)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up skill"})
+			return
+		}
+		if skill == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown skill", "skill": skillName})
+			return
+		}
+
+		volunteers, err := h.ratingService.GetTopRatedBySkill(skill.ID, limit, minRatings)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get top-rated volunteers"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"volunteers":  volunteers,
+			"count":       len(volunteers),
+			"limit":       limit,
+			"min_ratings": minRatings,
+			"skill":       skill.SkillName,
+		})
+		return
+	}
+
+	volunteers, err := h.ratingService.GetTopRatedVolunteers(limit, minRatings)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get top-rated volunteers"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"volunteers": volunteers,
-		"count":      len(volunteers),
-		"limit":      limit,
+		"volunteers":  volunteers,
+		"count":       len(volunteers),
+		"limit":       limit,
+		"min_ratings": minRatings,
 	})
 }
+
+// RecomputeScorecards handles POST /api/admin/ratings/recompute-scorecards
+func (h *VolunteerRatingHandler) RecomputeScorecards(c *gin.Context) {
+	batchSize, err := strconv.Atoi(c.DefaultQuery("batch_size", "100"))
+	if err != nil || batchSize < 1 || batchSize > 1000 {
+		batchSize = 100
+	}
+
+	logging.Printf(c.Request.Context(), "🔄 RECOMPUTE_SCORECARDS: Starting recompute with batch size %d", batchSize)
+	result, err := h.ratingService.RecomputeAllScorecards(batchSize, func(processed, total int) {
+		logging.Printf(c.Request.Context(), "🔄 RECOMPUTE_SCORECARDS: %d/%d volunteers processed", processed, total)
+	})
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ RECOMPUTE_SCORECARDS: Failed after %d volunteers: %v", result.Processed, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":     "Failed to recompute scorecards",
+			"processed": result.Processed,
+		})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "✅ RECOMPUTE_SCORECARDS: Recomputed %d scorecards in %d batches (%d failed)", result.Processed, result.Batches, len(result.Failed))
+	c.JSON(http.StatusOK, result)
+}
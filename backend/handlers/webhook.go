@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"civicweave/backend/middleware"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookHandler handles admin management of outbound webhooks
+type WebhookHandler struct {
+	service *models.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(service *models.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhookRequest represents a webhook registration request
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	EventTypes  []string `json:"event_types" binding:"required"`
+	Description string   `json:"description"`
+	IsActive    *bool    `json:"is_active"` // Defaults to true
+}
+
+// UpdateWebhookRequest represents a webhook update request; omitted fields are unchanged
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description *string  `json:"description"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// ListWebhooks handles GET /api/admin/webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhooks, err := h.service.List()
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_WEBHOOKS: Failed to list webhooks: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list webhooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks":    webhooks,
+		"event_types": models.WebhookEventTypes,
+	})
+}
+
+// CreateWebhook handles POST /api/admin/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userCtx, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+		return
+	}
+
+	webhookURL, ok := validateWebhookURL(c, req.URL)
+	if !ok {
+		return
+	}
+	eventTypes, ok := validateWebhookEventTypes(c, req.EventTypes)
+	if !ok {
+		return
+	}
+
+	webhook := &models.Webhook{
+		URL:         webhookURL,
+		EventTypes:  eventTypes,
+		Description: strings.TrimSpace(req.Description),
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		CreatedBy:   &userCtx.ID,
+	}
+	if err := h.service.Create(webhook); err != nil {
+		logging.Errorf(c.Request.Context(), "❌ CREATE_WEBHOOK: Failed to create webhook: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook"})
+		return
+	}
+
+	logging.Printf(c.Request.Context(), "🔗 CREATE_WEBHOOK: Admin %s registered webhook %s for %v", userCtx.ID, webhook.ID, eventTypes)
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": webhook,
+		"secret":  webhook.Secret,
+		"message": "Store this secret now; it is used to verify payload signatures and will not be shown again",
+	})
+}
+
+// GetWebhook handles GET /api/admin/webhooks/:id
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, ok := h.loadWebhook(c, "GET_WEBHOOK")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook handles PUT /api/admin/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhook, ok := h.loadWebhook(c, "UPDATE_WEBHOOK")
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.URL != nil {
+		webhookURL, ok := validateWebhookURL(c, *req.URL)
+		if !ok {
+			return
+		}
+		webhook.URL = webhookURL
+	}
+	if req.EventTypes != nil {
+		eventTypes, ok := validateWebhookEventTypes(c, req.EventTypes)
+		if !ok {
+			return
+		}
+		webhook.EventTypes = eventTypes
+	}
+	if req.Description != nil {
+		webhook.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	if err := h.service.Update(webhook); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Webhook")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ UPDATE_WEBHOOK: Failed to update webhook %s: %v", webhook.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook handles DELETE /api/admin/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Webhook")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ DELETE_WEBHOOK: Failed to delete webhook %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// RotateWebhookSecret handles POST /api/admin/webhooks/:id/rotate-secret
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	secret, err := h.service.RotateSecret(id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondNotFound(c, "Webhook")
+			return
+		}
+		logging.Errorf(c.Request.Context(), "❌ ROTATE_WEBHOOK_SECRET: Failed to rotate secret for webhook %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate webhook secret"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret":  secret,
+		"message": "Store this secret now; deliveries from now on are signed with it",
+	})
+}
+
+// ListWebhookDeliveries handles GET /api/admin/webhooks/:id/deliveries
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	webhook, ok := h.loadWebhook(c, "LIST_WEBHOOK_DELIVERIES")
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	deliveries, err := h.service.ListDeliveries(webhook.ID, limit)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ LIST_WEBHOOK_DELIVERIES: Failed to list deliveries for webhook %s: %v", webhook.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list webhook deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhook_id": webhook.ID,
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+// loadWebhook fetches the webhook named by the :id param, writing the error response
+// and returning false if it can't
+func (h *WebhookHandler) loadWebhook(c *gin.Context, logTag string) (*models.Webhook, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return nil, false
+	}
+
+	webhook, err := h.service.GetByID(id)
+	if err != nil {
+		logging.Errorf(c.Request.Context(), "❌ %s: Failed to get webhook %s: %v", logTag, id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get webhook"})
+		return nil, false
+	}
+	if webhook == nil {
+		respondNotFound(c, "Webhook")
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// validateWebhookURL checks that a webhook URL is an absolute http or https URL
+func validateWebhookURL(c *gin.Context, rawURL string) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url must be an absolute http or https URL"})
+		return "", false
+	}
+	return rawURL, true
+}
+
+// validateWebhookEventTypes checks that at least one known event type is given and
+// drops duplicates
+func validateWebhookEventTypes(c *gin.Context, eventTypes []string) ([]string, bool) {
+	seen := make(map[string]bool)
+	valid := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !models.IsValidWebhookEventType(eventType) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       "Unknown event type",
+				"event_type":  eventType,
+				"event_types": models.WebhookEventTypes,
+			})
+			return nil, false
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			valid = append(valid, eventType)
+		}
+	}
+
+	if len(valid) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one event type is required"})
+		return nil, false
+	}
+	return valid, true
+}
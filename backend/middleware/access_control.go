@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+)
+
+// accessControlRefreshInterval is how often the role hierarchy and permissions are
+// reloaded, so a role edited through another server instance takes effect everywhere
+const accessControlRefreshInterval = 30 * time.Second
+
+// LoadAccessControl replaces the role hierarchy and permissions role checks use with
+// the ones configured in the database. Nothing changes if either fails to load.
+func LoadAccessControl(roleService *models.RoleService) error {
+	hierarchy, err := roleService.GetHierarchy()
+	if err != nil {
+		return fmt.Errorf("failed to load role hierarchy: %w", err)
+	}
+	permissions, err := roleService.GetRolePermissions()
+	if err != nil {
+		return fmt.Errorf("failed to load role permissions: %w", err)
+	}
+
+	SetRoleHierarchy(hierarchy)
+	SetRolePermissions(permissions)
+	return nil
+}
+
+// RefreshAccessControl reloads the role hierarchy and permissions until ctx is cancelled
+func RefreshAccessControl(ctx context.Context, roleService *models.RoleService) {
+	ticker := time.NewTicker(accessControlRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := LoadAccessControl(roleService); err != nil {
+				log.Printf("❌ ACCESS_CONTROL: Failed to refresh roles, keeping the previous ones: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+// resetAccessControl restores the default hierarchy and permissions once a test ends
+func resetAccessControl(t *testing.T) {
+	t.Cleanup(func() {
+		SetRoleHierarchy(models.DefaultRoleHierarchy())
+		SetRolePermissions(models.DefaultRolePermissions())
+	})
+}
+
+func TestLoadAccessControlAppliesConfiguredRoles(t *testing.T) {
+	resetAccessControl(t)
+	db, recorder := fakesql.Open()
+	recorder.Rows("FROM role_inheritance", []string{"name", "name"},
+		[]driver.Value{"coordinator", "volunteer"},
+	)
+	recorder.Rows("FROM roles ORDER BY name", []string{"id", "name", "description", "permissions", "created_at"},
+		[]driver.Value{uuid.New().String(), "coordinator", "Coordinates projects", `["manage_all_projects"]`, time.Now()},
+		[]driver.Value{uuid.New().String(), "volunteer", "Volunteer", `["view_projects"]`, time.Now()},
+	)
+
+	if err := LoadAccessControl(models.NewRoleService(db)); err != nil {
+		t.Fatalf("LoadAccessControl() error = %v", err)
+	}
+
+	coordinator := &UserContext{ID: uuid.New(), Roles: []string{"coordinator"}}
+	if !coordinator.HasPermission(models.PermissionManageAllProjects) {
+		t.Error("coordinator should be granted manage_all_projects")
+	}
+	if !coordinator.HasPermission(models.PermissionViewProjects) {
+		t.Error("coordinator should inherit view_projects from volunteer")
+	}
+	if coordinator.HasPermission(models.PermissionModerateMessages) {
+		t.Error("coordinator should not be granted moderate_messages")
+	}
+}
+
+func TestLoadAccessControlKeepsPreviousRolesOnFailure(t *testing.T) {
+	resetAccessControl(t)
+	db, recorder := fakesql.Open()
+	recorder.Rows("FROM role_inheritance", []string{"name", "name"})
+	recorder.Fail("FROM roles ORDER BY name", errors.New("connection reset"))
+
+	if err := LoadAccessControl(models.NewRoleService(db)); err == nil {
+		t.Fatal("LoadAccessControl() should fail when roles can't be loaded")
+	}
+
+	admin := &UserContext{ID: uuid.New(), Roles: []string{"admin"}}
+	if !admin.HasEffectiveRole("team_lead") {
+		t.Error("the default hierarchy should still be in effect")
+	}
+	if !admin.HasPermission(models.PermissionManageAllProjects) {
+		t.Error("the default permissions should still be in effect")
+	}
+}
@@ -1,10 +1,13 @@
 package middleware
 
 import (
+	"database/sql"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"civicweave/backend/config"
 	"civicweave/backend/models"
 
 	"github.com/gin-gonic/gin"
@@ -20,8 +23,16 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-// AuthRequired middleware checks for valid JWT token
-func AuthRequired(jwtSecret string) gin.HandlerFunc {
+// Authentication methods recorded in the request context
+const (
+	AuthMethodJWT      = "jwt"
+	AuthMethodAPIToken = "api_token"
+)
+
+// AuthRequired middleware checks for a valid JWT or, when apiTokenService is
+// non-nil, a personal access token. When userService is non-nil, JWTs issued
+// before the user's sessions were revoked (e.g. by a password reset) are rejected.
+func AuthRequired(jwtSecret string, apiTokenService *models.APITokenService, userService *models.UserService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -39,6 +50,12 @@ func AuthRequired(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		// Personal access tokens are looked up rather than parsed
+		if models.IsAPIToken(tokenString) {
+			authenticateAPIToken(c, apiTokenService, tokenString)
+			return
+		}
+
 		// Parse and validate token
 		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 			return []byte(jwtSecret), nil
@@ -72,21 +89,88 @@ func AuthRequired(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		// Check the token wasn't issued before the user's sessions were revoked
+		if userService != nil {
+			revokedAt, err := userService.GetSessionsRevokedAt(claims.UserID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+				} else {
+					log.Printf("❌ AUTH: Failed to check session revocation for user %s: %v", claims.UserID, err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate token"})
+				}
+				c.Abort()
+				return
+			}
+			// JWT timestamps have one-second precision
+			if revokedAt != nil && (claims.IssuedAt == nil || claims.IssuedAt.Time.Before(revokedAt.Truncate(time.Second))) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Session revoked"})
+				c.Abort()
+				return
+			}
+		}
+
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_roles", claims.Roles)
+		c.Set("auth_method", AuthMethodJWT)
 
 		c.Next()
 	}
 }
 
-// RequireRole middleware checks if user has required role (deprecated - use RequireAnyRole)
+// authenticateAPIToken authenticates the request with a personal access token
+func authenticateAPIToken(c *gin.Context, apiTokenService *models.APITokenService, tokenString string) {
+	if apiTokenService == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return
+	}
+
+	identity, err := apiTokenService.Authenticate(tokenString)
+	if err != nil {
+		switch err {
+		case models.ErrAPITokenRevoked:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+		case models.ErrAPITokenExpired:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+		case models.ErrAPITokenNoRoles:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token grants no current roles"})
+		case models.ErrAPITokenLocked:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Account locked"})
+		default:
+			log.Printf("❌ API_TOKEN_AUTH: Failed to authenticate token: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate token"})
+		}
+		c.Abort()
+		return
+	}
+	if identity == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return
+	}
+
+	log.Printf("🔑 API_TOKEN_AUTH: Token %s used by user %s for %s %s", identity.TokenID, identity.UserID, c.Request.Method, c.Request.URL.Path)
+
+	// Set user context
+	c.Set("user_id", identity.UserID)
+	c.Set("user_email", identity.Email)
+	c.Set("user_roles", identity.Roles)
+	c.Set("auth_method", AuthMethodAPIToken)
+	c.Set("api_token_id", identity.TokenID)
+
+	c.Next()
+}
+
+// RequireRole middleware checks if user has required role, directly or by inheritance
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return RequireAnyRole(requiredRole)
 }
 
-// RequireAnyRole middleware checks if user has any of the specified roles
+// RequireAnyRole middleware checks if user has any of the specified roles, directly
+// or by inheritance
 func RequireAnyRole(requiredRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRoles, exists := c.Get("user_roles")
@@ -104,8 +188,9 @@ func RequireAnyRole(requiredRoles ...string) gin.HandlerFunc {
 		}
 
 		hasRole := false
+		effectiveRoles := EffectiveRoles(roles)
 		for _, requiredRole := range requiredRoles {
-			for _, userRole := range roles {
+			for _, userRole := range effectiveRoles {
 				if userRole == requiredRole {
 					hasRole = true
 					break
@@ -126,7 +211,8 @@ func RequireAnyRole(requiredRoles ...string) gin.HandlerFunc {
 	}
 }
 
-// RequireAllRoles middleware checks if user has all of the specified roles
+// RequireAllRoles middleware checks if user has all of the specified roles, directly
+// or by inheritance
 func RequireAllRoles(requiredRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRoles, exists := c.Get("user_roles")
@@ -138,7 +224,7 @@ func RequireAllRoles(requiredRoles ...string) gin.HandlerFunc {
 
 		roles := userRoles.([]string)
 		roleMap := make(map[string]bool)
-		for _, role := range roles {
+		for _, role := range EffectiveRoles(roles) {
 			roleMap[role] = true
 		}
 
@@ -193,8 +279,8 @@ func OptionalAuth(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
-// GenerateJWT generates a JWT token for a user
-func GenerateJWT(user *models.User, userService *models.UserService, jwtSecret string) (string, error) {
+// GenerateJWT generates a JWT token for a user, valid for the lifetime configured for their roles
+func GenerateJWT(user *models.User, userService *models.UserService, jwtConfig config.JWTConfig) (string, error) {
 	// Get user roles
 	roles, err := userService.GetUserRoles(user.ID)
 	if err != nil {
@@ -216,7 +302,7 @@ func GenerateJWT(user *models.User, userService *models.UserService, jwtSecret s
 		Email:  user.Email,
 		Roles:  roleNames,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 24 hours
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtConfig.ExpiryForRoles(roleNames))),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "civicweave",
@@ -225,7 +311,7 @@ func GenerateJWT(user *models.User, userService *models.UserService, jwtSecret s
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(jwtSecret))
+	return token.SignedString([]byte(jwtConfig.Secret))
 }
 
 // GetUserFromContext extracts user information from Gin context
@@ -243,18 +329,27 @@ func GetUserFromContext(c *gin.Context) (*UserContext, bool) {
 		rolesList = roles.([]string)
 	}
 
-	return &UserContext{
-		ID:    userID.(uuid.UUID),
-		Email: email.(string),
-		Roles: rolesList,
-	}, true
+	userCtx := &UserContext{
+		ID:         userID.(uuid.UUID),
+		Email:      email.(string),
+		Roles:      rolesList,
+		AuthMethod: c.GetString("auth_method"),
+	}
+	if tokenID, ok := c.Get("api_token_id"); ok {
+		id := tokenID.(uuid.UUID)
+		userCtx.APITokenID = &id
+	}
+
+	return userCtx, true
 }
 
 // UserContext represents user information from JWT context
 type UserContext struct {
-	ID    uuid.UUID
-	Email string
-	Roles []string
+	ID         uuid.UUID
+	Email      string
+	Roles      []string
+	AuthMethod string     // AuthMethodJWT or AuthMethodAPIToken
+	APITokenID *uuid.UUID // Set when authenticated with a personal access token
 }
 
 // HasRole checks if user has a specific role
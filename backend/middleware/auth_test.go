@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestAuthRequiredAPITokens(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	created := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name              string
+		expiresAt         interface{}
+		revokedAt         interface{}
+		lockedUntil       interface{}
+		sessionsRevokedAt interface{}
+		want              int
+	}{
+		{name: "valid", want: http.StatusOK},
+		{name: "revoked", revokedAt: past, want: http.StatusUnauthorized},
+		{name: "expired", expiresAt: past, want: http.StatusUnauthorized},
+		{name: "sessions revoked after creation", sessionsRevokedAt: past, want: http.StatusUnauthorized},
+		{name: "sessions revoked before creation", sessionsRevokedAt: created.Add(-time.Hour), want: http.StatusOK},
+		{name: "account locked", lockedUntil: future, want: http.StatusUnauthorized},
+		{name: "lock expired", lockedUntil: past, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := fakesql.Open()
+			defer db.Close()
+			recorder.Rows("FROM api_tokens t",
+				[]string{"id", "user_id", "email", "scopes", "expires_at", "revoked_at", "created_at", "locked_until", "sessions_revoked_at"},
+				[]driver.Value{uuid.New().String(), uuid.New().String(), "pat@example.com", `["volunteer"]`,
+					tt.expiresAt, tt.revokedAt, created, tt.lockedUntil, tt.sessionsRevokedAt})
+			recorder.Rows("FROM roles r", []string{"name"}, []driver.Value{"volunteer"})
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/api/me", AuthRequired("secret", models.NewAPITokenService(db), nil), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+			request.Header.Set("Authorization", "Bearer "+models.APITokenPrefix+"abc123")
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			if response.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", response.Code, tt.want, response.Body.String())
+			}
+		})
+	}
+}
+
+func TestGenerateJWTUsesTheLifetimeForTheUsersRoles(t *testing.T) {
+	jwtConfig := config.JWTConfig{
+		Secret:     "secret",
+		Expiry:     72 * time.Hour,
+		RoleExpiry: map[string]time.Duration{"admin": 8 * time.Hour},
+	}
+
+	tests := []struct {
+		name  string
+		roles []string
+		want  time.Duration
+	}{
+		{"admin", []string{"admin"}, 8 * time.Hour},
+		{"volunteer", []string{"volunteer"}, 72 * time.Hour},
+		{"admin and volunteer", []string{"admin", "volunteer"}, 8 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, recorder := fakesql.Open()
+			defer db.Close()
+			var rows [][]driver.Value
+			for _, role := range tt.roles {
+				rows = append(rows, []driver.Value{uuid.New().String(), role, "", "[]", time.Now()})
+			}
+			recorder.Rows("FROM roles r", []string{"id", "name", "description", "permissions", "created_at"}, rows...)
+			user := &models.User{ID: uuid.New(), Email: "pat@example.com"}
+
+			signed, err := GenerateJWT(user, models.NewUserService(db), jwtConfig)
+			if err != nil {
+				t.Fatalf("GenerateJWT() error = %v", err)
+			}
+
+			claims := &Claims{}
+			if _, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (interface{}, error) {
+				return []byte(jwtConfig.Secret), nil
+			}); err != nil {
+				t.Fatalf("token does not verify: %v", err)
+			}
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != tt.want {
+				t.Errorf("token lifetime = %s, want %s", lifetime, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// maintenanceCacheTTL is how long the maintenance state is reused before it is
+// reloaded, so toggling maintenance takes effect within this window
+const maintenanceCacheTTL = 5 * time.Second
+
+// defaultMaintenanceMessage is returned when an admin didn't give a reason
+const defaultMaintenanceMessage = "The platform is undergoing maintenance. Please try again shortly."
+
+// maintenanceAllowedRoutes are the writes that stay available during maintenance in
+// either mode, keyed by method and route: signing in and out, so admins can reach the
+// platform and users aren't left with stale sessions, and unsubscribing from mail.
+var maintenanceAllowedRoutes = map[string]bool{
+	"POST /api/auth/login":                   true,
+	"POST /api/auth/google":                  true,
+	"POST /api/auth/refresh":                 true,
+	"POST /api/auth/logout":                  true,
+	"POST /api/auth/forgot-password":         true,
+	"POST /api/auth/reset-password":          true,
+	"POST /api/campaigns/unsubscribe/:token": true,
+}
+
+// MaintenanceMode middleware rejects requests with 503 while maintenance mode
+// is on. In read-only mode only writes are rejected; in offline mode everything
+// is. Routes in maintenanceAllowedRoutes are never rejected, and admins always
+// pass so they can operate the platform and switch it back. It's mounted on the
+// whole API ahead of authentication, so it recognises admins by their JWT itself.
+// A nil service disables it.
+func MaintenanceMode(service *models.MaintenanceService, jwtSecret string) gin.HandlerFunc {
+	var (
+		mu        sync.Mutex
+		state     = &models.MaintenanceState{}
+		fetchedAt time.Time
+	)
+
+	currentState := func() *models.MaintenanceState {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if time.Since(fetchedAt) < maintenanceCacheTTL {
+			return state
+		}
+
+		// Keep serving the last known state if the lookup fails
+		latest, err := service.Get()
+		if err != nil {
+			log.Printf("❌ MAINTENANCE_MODE: Failed to load maintenance state: %v", err)
+		} else {
+			state = latest
+		}
+		fetchedAt = time.Now()
+		return state
+	}
+
+	return func(c *gin.Context) {
+		if service == nil {
+			c.Next()
+			return
+		}
+
+		current := currentState()
+		if !current.Enabled {
+			c.Next()
+			return
+		}
+
+		if maintenanceAllows(current.Mode, c.Request.Method, c.FullPath()) || isMaintenanceAdmin(c, jwtSecret) {
+			c.Next()
+			return
+		}
+
+		message := current.Message
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+
+		c.Header("Retry-After", "300")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":       message,
+			"maintenance": true,
+			"mode":        current.Mode,
+		})
+		c.Abort()
+	}
+}
+
+// maintenanceAllows reports whether a request to route may go ahead in mode
+func maintenanceAllows(mode models.MaintenanceModeType, method, route string) bool {
+	if maintenanceAllowedRoutes[method+" "+route] {
+		return true
+	}
+	return mode != models.MaintenanceModeOffline && isReadOnlyMethod(method)
+}
+
+// isMaintenanceAdmin reports whether the request is from a user whose roles grant
+// bypass_maintenance (admins by default), either already authenticated or carrying a
+// valid JWT
+func isMaintenanceAdmin(c *gin.Context, jwtSecret string) bool {
+	if userCtx, exists := GetUserFromContext(c); exists {
+		return userCtx.HasPermission(models.PermissionBypassMaintenance)
+	}
+
+	tokenString, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || tokenString == "" || models.IsAPIToken(tokenString) {
+		return false
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return false
+	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return false
+	}
+	return (&UserContext{ID: claims.UserID, Roles: claims.Roles}).HasPermission(models.PermissionBypassMaintenance)
+}
+
+// isReadOnlyMethod reports whether an HTTP method doesn't modify state
+func isReadOnlyMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
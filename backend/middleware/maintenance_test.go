@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{http.MethodGet, true},
+		{http.MethodHead, true},
+		{http.MethodOptions, true},
+		{http.MethodPost, false},
+		{http.MethodPut, false},
+		{http.MethodPatch, false},
+		{http.MethodDelete, false},
+	}
+
+	for _, tt := range tests {
+		if got := isReadOnlyMethod(tt.method); got != tt.want {
+			t.Errorf("isReadOnlyMethod(%s) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestMaintenanceAllows(t *testing.T) {
+	readOnly, offline := models.MaintenanceModeReadOnly, models.MaintenanceModeOffline
+
+	tests := []struct {
+		name   string
+		mode   models.MaintenanceModeType
+		method string
+		route  string
+		want   bool
+	}{
+		{"read-only allows reads", readOnly, http.MethodGet, "/api/projects", true},
+		{"read-only blocks writes", readOnly, http.MethodPost, "/api/projects", false},
+		{"read-only blocks registration", readOnly, http.MethodPost, "/api/auth/register", false},
+		{"read-only allows login", readOnly, http.MethodPost, "/api/auth/login", true},
+		{"read-only allows refresh", readOnly, http.MethodPost, "/api/auth/refresh", true},
+		{"read-only allows logout", readOnly, http.MethodPost, "/api/auth/logout", true},
+		{"read-only allows forgot password", readOnly, http.MethodPost, "/api/auth/forgot-password", true},
+		{"read-only allows reset password", readOnly, http.MethodPost, "/api/auth/reset-password", true},
+		{"read-only allows unsubscribing", readOnly, http.MethodPost, "/api/campaigns/unsubscribe/:token", true},
+		{"offline blocks reads", offline, http.MethodGet, "/api/projects", false},
+		{"offline still allows login", offline, http.MethodPost, "/api/auth/login", true},
+		{"allowlist is per method", readOnly, http.MethodDelete, "/api/auth/login", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maintenanceAllows(tt.mode, tt.method, tt.route); got != tt.want {
+				t.Errorf("maintenanceAllows(%s, %s, %s) = %v, want %v", tt.mode, tt.method, tt.route, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMaintenanceAdmin(t *testing.T) {
+	const secret = "test-secret"
+	sign := func(secret string, roles []string) string {
+		claims := &Claims{
+			UserID: uuid.New(),
+			Roles:  roles,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return "Bearer " + token
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          bool
+	}{
+		{"no credentials", "", false},
+		{"admin JWT", sign(secret, []string{"admin"}), true},
+		{"volunteer JWT", sign(secret, []string{"volunteer"}), false},
+		{"admin JWT with the wrong secret", sign("other-secret", []string{"admin"}), false},
+		{"malformed header", "admin", false},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/projects", nil)
+			if tt.authorization != "" {
+				c.Request.Header.Set("Authorization", tt.authorization)
+			}
+			if got := isMaintenanceAdmin(c, secret); got != tt.want {
+				t.Errorf("isMaintenanceAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
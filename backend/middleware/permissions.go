@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+
+	"civicweave/backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	rolePermissionsMu sync.RWMutex
+	rolePermissions   = models.DefaultRolePermissions()
+)
+
+// SetRolePermissions replaces the role permissions permission checks use, e.g. once
+// they have been loaded from the database at startup or after an admin edits a role
+func SetRolePermissions(permissions models.RolePermissions) {
+	rolePermissionsMu.Lock()
+	defer rolePermissionsMu.Unlock()
+	rolePermissions = permissions
+}
+
+// EffectivePermissions returns every permission granted by roles and the roles they inherit
+func EffectivePermissions(roles []string) []string {
+	effectiveRoles := EffectiveRoles(roles)
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+	return rolePermissions.Effective(effectiveRoles)
+}
+
+// grantsPermission reports whether roles, or the roles they inherit, grant permission
+func grantsPermission(roles []string, permission string) bool {
+	effectiveRoles := EffectiveRoles(roles)
+	rolePermissionsMu.RLock()
+	defer rolePermissionsMu.RUnlock()
+	return rolePermissions.Grants(effectiveRoles, permission)
+}
+
+// HasPermission checks if any of the user's roles, or the roles they inherit, grant a permission
+func (uc *UserContext) HasPermission(permission string) bool {
+	return grantsPermission(uc.Roles, permission)
+}
+
+// RequirePermission middleware checks if any of the user's roles grant a permission
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRoles, exists := c.Get("user_roles")
+		roles, ok := userRoles.([]string)
+		if !exists || !ok || !grantsPermission(roles, permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
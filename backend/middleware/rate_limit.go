@@ -35,10 +35,48 @@ var (
 		Requests: 100,
 		Period:   time.Minute,
 	}
+
+	// Geocoding lookups: 30 per minute per user, as each miss is a call to Nominatim
+	GeocodeRateLimit = RateLimiterConfig{
+		Requests: 30,
+		Period:   time.Minute,
+	}
+
+	// On-demand skill vector re-aggregation: 5 per 10 minutes per user, as each one
+	// also recalculates the volunteer's matches
+	SkillReaggregateRateLimit = RateLimiterConfig{
+		Requests: 5,
+		Period:   10 * time.Minute,
+	}
+
+	// Skill extraction from free text: 10 per 10 minutes per user, as each one may
+	// call the embeddings API
+	SkillExtractRateLimit = RateLimiterConfig{
+		Requests: 10,
+		Period:   10 * time.Minute,
+	}
 )
 
-// RateLimiter creates a rate limiting middleware
+// RateLimiter creates a rate limiting middleware keyed by client IP
 func RateLimiter(config RateLimiterConfig) gin.HandlerFunc {
+	return newRateLimiter(config, func(c *gin.Context) string {
+		return c.ClientIP()
+	})
+}
+
+// UserRateLimiter creates a rate limiting middleware keyed by the authenticated user,
+// falling back to the client IP for anonymous requests
+func UserRateLimiter(config RateLimiterConfig) gin.HandlerFunc {
+	return newRateLimiter(config, func(c *gin.Context) string {
+		if userCtx, exists := GetUserFromContext(c); exists {
+			return "user:" + userCtx.ID.String()
+		}
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// newRateLimiter creates a rate limiting middleware that counts requests per key
+func newRateLimiter(config RateLimiterConfig, key func(c *gin.Context) string) gin.HandlerFunc {
 	// Create a rate limiter instance
 	store := memory.NewStore()
 	rate := limiter.Rate{
@@ -49,11 +87,8 @@ func RateLimiter(config RateLimiterConfig) gin.HandlerFunc {
 	instance := limiter.New(store, rate)
 
 	return func(c *gin.Context) {
-		// Get client IP
-		clientIP := c.ClientIP()
-
 		// Get rate limit context
-		context, err := instance.Get(c, clientIP)
+		context, err := instance.Get(c, key(c))
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Rate limit error"})
 			c.Abort()
@@ -98,3 +133,18 @@ func RegistrationRateLimiter() gin.HandlerFunc {
 func APIRateLimiter() gin.HandlerFunc {
 	return RateLimiter(APIRateLimit)
 }
+
+// GeocodeRateLimiter returns rate limiter for geocoding lookups
+func GeocodeRateLimiter() gin.HandlerFunc {
+	return UserRateLimiter(GeocodeRateLimit)
+}
+
+// SkillReaggregateRateLimiter returns rate limiter for on-demand skill vector re-aggregation
+func SkillReaggregateRateLimiter() gin.HandlerFunc {
+	return UserRateLimiter(SkillReaggregateRateLimit)
+}
+
+// SkillExtractRateLimiter returns rate limiter for extracting skills from free text
+func SkillExtractRateLimiter() gin.HandlerFunc {
+	return UserRateLimiter(SkillExtractRateLimit)
+}
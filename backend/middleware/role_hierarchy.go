@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"sync"
+
+	"civicweave/backend/models"
+)
+
+var (
+	roleHierarchyMu sync.RWMutex
+	roleHierarchy   = models.DefaultRoleHierarchy()
+)
+
+// SetRoleHierarchy replaces the hierarchy role checks use, e.g. once it has been loaded
+// from the database at startup or after an admin changes it
+func SetRoleHierarchy(hierarchy models.RoleHierarchy) {
+	roleHierarchyMu.Lock()
+	defer roleHierarchyMu.Unlock()
+	roleHierarchy = hierarchy
+}
+
+// EffectiveRoles returns roles along with every role they inherit
+func EffectiveRoles(roles []string) []string {
+	roleHierarchyMu.RLock()
+	defer roleHierarchyMu.RUnlock()
+	return roleHierarchy.Expand(roles)
+}
+
+// HasEffectiveRole checks if the user holds a role directly or by inheritance, so an
+// admin satisfies a team_lead check
+func (uc *UserContext) HasEffectiveRole(roleName string) bool {
+	for _, role := range EffectiveRoles(uc.Roles) {
+		if role == roleName {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyEffectiveRole checks if the user holds any of the roles directly or by inheritance
+func (uc *UserContext) HasAnyEffectiveRole(roleNames ...string) bool {
+	effective := EffectiveRoles(uc.Roles)
+	for _, requiredRole := range roleNames {
+		for _, role := range effective {
+			if role == requiredRole {
+				return true
+			}
+		}
+	}
+	return false
+}
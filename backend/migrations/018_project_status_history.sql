@@ -0,0 +1,21 @@
+-- UP
+-- Project Status History
+-- Records every project status transition so auditors can see who changed what and when
+
+CREATE TABLE project_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_project_status_history_project_id ON project_status_history(project_id, changed_at DESC);
+CREATE INDEX idx_project_status_history_changed_by ON project_status_history(changed_by);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_status_history_changed_by;
+DROP INDEX IF EXISTS idx_project_status_history_project_id;
+DROP TABLE IF EXISTS project_status_history;
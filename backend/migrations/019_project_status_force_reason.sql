@@ -0,0 +1,10 @@
+-- UP
+-- Forced Project Status Transitions
+-- Records whether a status change bypassed the transition rules and the admin's reason for it
+
+ALTER TABLE project_status_history ADD COLUMN forced BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE project_status_history ADD COLUMN reason TEXT;
+
+-- DOWN
+ALTER TABLE project_status_history DROP COLUMN IF EXISTS reason;
+ALTER TABLE project_status_history DROP COLUMN IF EXISTS forced;
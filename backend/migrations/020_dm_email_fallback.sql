@@ -0,0 +1,16 @@
+-- UP
+-- Direct Message Email Fallback
+-- Adds a per-user email notification preference and tracks which unread direct messages have already been emailed
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_notifications BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE message_email_fallbacks (
+    message_id UUID NOT NULL REFERENCES project_messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- DOWN
+DROP TABLE IF EXISTS message_email_fallbacks;
+ALTER TABLE users DROP COLUMN IF EXISTS email_notifications;
@@ -0,0 +1,19 @@
+-- UP
+-- Task Dependencies
+-- Lets a task declare other tasks in the same project that must be done before it can start
+
+CREATE TABLE task_dependencies (
+    task_id UUID NOT NULL REFERENCES project_tasks(id) ON DELETE CASCADE,
+    depends_on_task_id UUID NOT NULL REFERENCES project_tasks(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, depends_on_task_id),
+    CHECK (task_id <> depends_on_task_id)
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_task_dependencies_depends_on ON task_dependencies(depends_on_task_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_task_dependencies_depends_on;
+DROP TABLE IF EXISTS task_dependencies;
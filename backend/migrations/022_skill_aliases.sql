@@ -0,0 +1,17 @@
+-- UP
+-- Skill Aliases
+-- Maps alternate spellings and synonyms (e.g. "JS") onto canonical taxonomy skills
+
+CREATE TABLE skill_aliases (
+    alias VARCHAR(100) PRIMARY KEY,
+    skill_id INTEGER NOT NULL REFERENCES skill_taxonomy(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (alias = LOWER(alias))
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_skill_aliases_skill_id ON skill_aliases(skill_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_skill_aliases_skill_id;
+DROP TABLE IF EXISTS skill_aliases;
@@ -0,0 +1,17 @@
+-- UP
+-- Volunteer Scorecards
+-- Caches aggregated rating data per volunteer; rows are invalidated on rating changes and rebuilt on read or by an admin recompute
+
+CREATE TABLE volunteer_scorecards (
+    volunteer_id UUID PRIMARY KEY REFERENCES volunteers(id) ON DELETE CASCADE,
+    total_ratings INTEGER NOT NULL DEFAULT 0,
+    up_ratings INTEGER NOT NULL DEFAULT 0,
+    down_ratings INTEGER NOT NULL DEFAULT 0,
+    neutral_ratings INTEGER NOT NULL DEFAULT 0,
+    overall_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    skills JSONB NOT NULL DEFAULT '[]',
+    calculated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- DOWN
+DROP TABLE IF EXISTS volunteer_scorecards;
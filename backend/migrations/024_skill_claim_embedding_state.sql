@@ -0,0 +1,20 @@
+-- UP
+-- Skill Claim Embedding State
+-- Tracks which model produced each claim embedding so claims can be re-embedded when the model changes
+
+ALTER TABLE skill_claims ADD COLUMN needs_embedding BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE skill_claims ADD COLUMN embedding_model VARCHAR(100);
+ALTER TABLE skill_claims ADD COLUMN embedded_at TIMESTAMP;
+
+-- Claims created before this migration have no recorded model (embedding_model IS NULL)
+
+-- Add indexes for performance
+CREATE INDEX idx_skill_claims_needs_embedding ON skill_claims(id) WHERE needs_embedding = true;
+CREATE INDEX idx_skill_claims_embedding_model ON skill_claims(embedding_model);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_skill_claims_embedding_model;
+DROP INDEX IF EXISTS idx_skill_claims_needs_embedding;
+ALTER TABLE skill_claims DROP COLUMN IF EXISTS embedded_at;
+ALTER TABLE skill_claims DROP COLUMN IF EXISTS embedding_model;
+ALTER TABLE skill_claims DROP COLUMN IF EXISTS needs_embedding;
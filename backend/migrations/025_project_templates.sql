@@ -0,0 +1,59 @@
+-- UP
+-- Project Templates
+-- Reusable presets (required skills, suggested tasks, milestones) for recurring civic project types
+
+CREATE TABLE project_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    required_skills JSONB NOT NULL DEFAULT '[]',
+    suggested_tasks JSONB NOT NULL DEFAULT '[]',
+    milestones JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_project_templates_active ON project_templates(is_active, name);
+
+-- Seed the catalog with common civic activities
+INSERT INTO project_templates (name, description, category, required_skills, suggested_tasks, milestones) VALUES
+(
+    'Food Drive',
+    'Collect and distribute non-perishable food donations for a local food bank.',
+    'community',
+    '["Event Planning", "Community Outreach", "Social Media"]',
+    '[
+        {"title": "Confirm partner food bank", "description": "Agree on accepted items, drop-off times and delivery logistics.", "priority": "high", "due_offset_days": 3},
+        {"title": "Set up collection points", "description": "Arrange bins and signage at each collection location.", "priority": "medium", "due_offset_days": 7},
+        {"title": "Promote the drive", "description": "Post on social media and share flyers with neighbourhood groups.", "priority": "medium", "due_offset_days": 7},
+        {"title": "Sort and deliver donations", "description": "Sort collected items and deliver them to the food bank.", "priority": "high", "due_offset_days": 21}
+    ]',
+    '[
+        {"title": "Collection opens", "offset_days": 7},
+        {"title": "Final delivery to food bank", "offset_days": 21}
+    ]'
+),
+(
+    'Voter Registration',
+    'Help eligible residents register to vote ahead of an upcoming election.',
+    'civic',
+    '["Community Outreach", "Public Speaking", "Translation"]',
+    '[
+        {"title": "Review registration rules", "description": "Confirm deadlines, eligibility and accepted ID with the local election office.", "priority": "high", "due_offset_days": 2},
+        {"title": "Train volunteers", "description": "Run a short session on the registration form and nonpartisan conduct.", "priority": "high", "due_offset_days": 5},
+        {"title": "Schedule registration tables", "description": "Book tables at libraries, markets and campuses.", "priority": "medium", "due_offset_days": 7},
+        {"title": "Submit completed forms", "description": "Deliver collected forms to the election office before the deadline.", "priority": "high", "due_offset_days": 28}
+    ]',
+    '[
+        {"title": "First registration table", "offset_days": 8},
+        {"title": "Registration deadline", "offset_days": 28}
+    ]'
+);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_templates_active;
+DROP TABLE IF EXISTS project_templates;
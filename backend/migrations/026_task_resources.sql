@@ -0,0 +1,18 @@
+-- UP
+-- Task Resources
+-- Links resource-library entries to the tasks they support
+
+CREATE TABLE task_resources (
+    task_id UUID NOT NULL REFERENCES project_tasks(id) ON DELETE CASCADE,
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    linked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    linked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, resource_id)
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_task_resources_resource_id ON task_resources(resource_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_task_resources_resource_id;
+DROP TABLE IF EXISTS task_resources;
@@ -0,0 +1,18 @@
+-- UP
+-- Message Reactions
+-- Emoji reactions on project and direct messages, one per user per emoji
+
+CREATE TABLE message_reactions (
+    message_id UUID NOT NULL REFERENCES project_messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+-- Add indexes for performance
+CREATE INDEX idx_message_reactions_message_id ON message_reactions(message_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_message_reactions_message_id;
+DROP TABLE IF EXISTS message_reactions;
@@ -0,0 +1,16 @@
+-- UP
+-- Message Search
+-- Full-text search over message subjects and bodies
+
+ALTER TABLE project_messages ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(subject, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(message_text, '')), 'B')
+    ) STORED;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_project_messages_search_vector ON project_messages USING GIN(search_vector);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_messages_search_vector;
+ALTER TABLE project_messages DROP COLUMN IF EXISTS search_vector;
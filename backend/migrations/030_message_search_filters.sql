@@ -0,0 +1,10 @@
+-- UP
+-- Message Search Filters
+-- Composite indexes for filtering messages by sender or type within a date range
+
+CREATE INDEX IF NOT EXISTS idx_project_messages_sender_created ON project_messages(sender_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_project_messages_type_created ON project_messages(message_type, created_at);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_messages_type_created;
+DROP INDEX IF EXISTS idx_project_messages_sender_created;
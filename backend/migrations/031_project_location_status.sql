@@ -0,0 +1,21 @@
+-- UP
+-- Project Location Status
+-- Records whether a project's address was geocoded so ungeocodable projects are visible
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS location_status VARCHAR(20) NOT NULL DEFAULT 'none'
+    CHECK (location_status IN ('geocoded', 'failed', 'none'));
+
+-- Backfill from existing coordinates and addresses
+UPDATE projects SET location_status = 'geocoded'
+WHERE location_lat IS NOT NULL AND location_lng IS NOT NULL;
+
+UPDATE projects SET location_status = 'failed'
+WHERE (location_lat IS NULL OR location_lng IS NULL)
+  AND COALESCE(TRIM(location_address), '') <> '';
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_projects_location_status ON projects(location_status);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_projects_location_status;
+ALTER TABLE projects DROP COLUMN IF EXISTS location_status;
@@ -0,0 +1,31 @@
+-- UP
+-- Scheduled Messages
+-- Messages held back until their scheduled time, then moved into project_messages
+
+CREATE TABLE scheduled_messages (
+    id UUID PRIMARY KEY,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    recipient_team_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    subject VARCHAR(255),
+    message_text TEXT NOT NULL,
+    message_type VARCHAR(50) NOT NULL DEFAULT 'general',
+    message_scope VARCHAR(20) NOT NULL DEFAULT 'project',
+    scheduled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (project_id IS NOT NULL OR recipient_user_id IS NOT NULL OR recipient_team_id IS NOT NULL)
+);
+
+-- Delivered messages remember when they were scheduled for
+ALTER TABLE project_messages ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP;
+
+-- Add indexes for performance
+CREATE INDEX idx_scheduled_messages_scheduled_at ON scheduled_messages(scheduled_at);
+CREATE INDEX idx_scheduled_messages_sender_id ON scheduled_messages(sender_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_scheduled_messages_sender_id;
+DROP INDEX IF EXISTS idx_scheduled_messages_scheduled_at;
+ALTER TABLE project_messages DROP COLUMN IF EXISTS scheduled_at;
+DROP TABLE IF EXISTS scheduled_messages;
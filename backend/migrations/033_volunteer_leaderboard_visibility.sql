@@ -0,0 +1,8 @@
+-- UP
+-- Volunteer Leaderboard Visibility
+-- Lets volunteers opt out of appearing on the hours leaderboard
+
+ALTER TABLE volunteers ADD COLUMN IF NOT EXISTS show_on_leaderboard BOOLEAN NOT NULL DEFAULT true;
+
+-- DOWN
+ALTER TABLE volunteers DROP COLUMN IF EXISTS show_on_leaderboard;
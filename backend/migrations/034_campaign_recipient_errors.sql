@@ -0,0 +1,8 @@
+-- UP
+-- Campaign Recipient Errors
+-- Records why a campaign email could not be sent to a recipient
+
+ALTER TABLE campaign_recipients ADD COLUMN IF NOT EXISTS error_message TEXT;
+
+-- DOWN
+ALTER TABLE campaign_recipients DROP COLUMN IF EXISTS error_message;
@@ -0,0 +1,17 @@
+-- UP
+-- Maintenance Mode
+-- Single-row table holding the admin-controlled maintenance switch
+
+CREATE TABLE IF NOT EXISTS maintenance_mode (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    mode VARCHAR(20) NOT NULL DEFAULT 'read_only' CHECK (mode IN ('read_only', 'offline')),
+    message TEXT NOT NULL DEFAULT '',
+    updated_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO maintenance_mode (id) VALUES (true) ON CONFLICT (id) DO NOTHING;
+
+-- DOWN
+DROP TABLE IF EXISTS maintenance_mode;
@@ -0,0 +1,24 @@
+-- UP
+-- Campaign Tracking
+-- Per-recipient tracking tokens and the open/click events recorded with them
+
+ALTER TABLE campaign_recipients ADD COLUMN IF NOT EXISTS tracking_token VARCHAR(64) UNIQUE;
+
+CREATE TABLE IF NOT EXISTS campaign_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES campaign_recipients(id) ON DELETE CASCADE,
+    event_type VARCHAR(10) NOT NULL CHECK (event_type IN ('open', 'click')),
+    url TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_campaign_events_campaign_type ON campaign_events(campaign_id, event_type);
+CREATE INDEX IF NOT EXISTS idx_campaign_events_recipient_id ON campaign_events(recipient_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_campaign_events_recipient_id;
+DROP INDEX IF EXISTS idx_campaign_events_campaign_type;
+DROP TABLE IF EXISTS campaign_events;
+ALTER TABLE campaign_recipients DROP COLUMN IF EXISTS tracking_token;
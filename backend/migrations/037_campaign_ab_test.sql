@@ -0,0 +1,15 @@
+-- UP
+-- Campaign A/B Subject Test
+-- Optional second subject line sent to a share of recipients, and which variant each recipient got
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS email_subject_b VARCHAR(255);
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS ab_test_percent INTEGER NOT NULL DEFAULT 0
+    CHECK (ab_test_percent = 0 OR ab_test_percent BETWEEN 1 AND 50);
+
+ALTER TABLE campaign_recipients ADD COLUMN IF NOT EXISTS variant VARCHAR(1) NOT NULL DEFAULT 'A'
+    CHECK (variant IN ('A', 'B'));
+
+-- DOWN
+ALTER TABLE campaign_recipients DROP COLUMN IF EXISTS variant;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS ab_test_percent;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS email_subject_b;
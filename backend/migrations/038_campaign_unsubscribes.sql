@@ -0,0 +1,20 @@
+-- UP
+-- Campaign Unsubscribes
+-- Suppression list of people who opted out of campaign emails
+
+CREATE TABLE IF NOT EXISTS campaign_unsubscribes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    email VARCHAR(255) NOT NULL,
+    campaign_id UUID REFERENCES campaigns(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_campaign_unsubscribes_email ON campaign_unsubscribes(LOWER(email));
+CREATE INDEX IF NOT EXISTS idx_campaign_unsubscribes_user_id ON campaign_unsubscribes(user_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_campaign_unsubscribes_user_id;
+DROP INDEX IF EXISTS idx_campaign_unsubscribes_email;
+DROP TABLE IF EXISTS campaign_unsubscribes;
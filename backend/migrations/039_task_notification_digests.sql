@@ -0,0 +1,25 @@
+-- UP
+-- Task Notification Digests
+-- Merges rapid non-urgent task notifications into a per-project digest message, with a per-user window
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS task_digest_minutes INTEGER NOT NULL DEFAULT 5
+    CHECK (task_digest_minutes >= 0 AND task_digest_minutes <= 1440);
+
+ALTER TABLE project_messages ADD COLUMN IF NOT EXISTS digest_count INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE project_messages DROP CONSTRAINT IF EXISTS project_messages_message_type_check;
+ALTER TABLE project_messages ADD CONSTRAINT project_messages_message_type_check
+    CHECK (message_type IN ('general', 'task_done', 'task_blocked', 'task_takeover', 'task_digest'));
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_project_messages_task_digest ON project_messages(project_id, sender_id, created_at)
+    WHERE task_id IS NOT NULL;
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_messages_task_digest;
+UPDATE project_messages SET message_type = 'task_done' WHERE message_type = 'task_digest';
+ALTER TABLE project_messages DROP CONSTRAINT IF EXISTS project_messages_message_type_check;
+ALTER TABLE project_messages ADD CONSTRAINT project_messages_message_type_check
+    CHECK (message_type IN ('general', 'task_done', 'task_blocked', 'task_takeover'));
+ALTER TABLE project_messages DROP COLUMN IF EXISTS digest_count;
+ALTER TABLE users DROP COLUMN IF EXISTS task_digest_minutes;
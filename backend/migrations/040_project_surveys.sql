@@ -0,0 +1,31 @@
+-- UP
+-- Project Surveys
+-- Retrospective feedback surveys attached to projects, with one response per team member
+
+CREATE TABLE IF NOT EXISTS project_surveys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    questions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    is_anonymous BOOLEAN NOT NULL DEFAULT false,
+    created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS project_survey_responses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    survey_id UUID NOT NULL REFERENCES project_surveys(id) ON DELETE CASCADE,
+    respondent_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    answers JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (survey_id, respondent_user_id)
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_project_surveys_project_id ON project_surveys(project_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_surveys_project_id;
+DROP TABLE IF EXISTS project_survey_responses;
+DROP TABLE IF EXISTS project_surveys;
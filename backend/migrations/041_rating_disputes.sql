@@ -0,0 +1,29 @@
+-- UP
+-- Rating Disputes
+-- Lets rated volunteers dispute a rating; upheld disputes hide the rating from scorecards
+
+ALTER TABLE volunteer_ratings ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS rating_disputes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rating_id UUID NOT NULL REFERENCES volunteer_ratings(id) ON DELETE CASCADE,
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    filed_by_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'upheld', 'removed')),
+    resolution_note TEXT,
+    resolved_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+-- A rating can only have one open dispute at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rating_disputes_open_rating ON rating_disputes(rating_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_rating_disputes_status ON rating_disputes(status, created_at);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_rating_disputes_status;
+DROP INDEX IF EXISTS idx_rating_disputes_open_rating;
+DROP TABLE IF EXISTS rating_disputes;
+ALTER TABLE volunteer_ratings DROP COLUMN IF EXISTS hidden_at;
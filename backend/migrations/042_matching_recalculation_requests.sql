@@ -0,0 +1,23 @@
+-- UP
+-- Matching Recalculation Requests
+-- Lets admins ask the matching worker for an immediate recalculation; at most one request waits at a time
+
+CREATE TABLE IF NOT EXISTS matching_recalculation_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    requested_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    error TEXT,
+    requested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+-- Add indexes for performance
+-- Triggers that arrive while a request is still pending coalesce into it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_matching_recalculation_pending ON matching_recalculation_requests(status) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_matching_recalculation_requested_at ON matching_recalculation_requests(requested_at DESC);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_matching_recalculation_requested_at;
+DROP INDEX IF EXISTS idx_matching_recalculation_pending;
+DROP TABLE IF EXISTS matching_recalculation_requests;
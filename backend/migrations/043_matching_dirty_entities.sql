@@ -0,0 +1,17 @@
+-- UP
+-- Matching Dirty Entities
+-- Marks volunteers and projects whose skills changed so their matches can be recalculated incrementally
+
+CREATE TABLE IF NOT EXISTS matching_dirty_entities (
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('volunteer', 'project')),
+    entity_id UUID NOT NULL,
+    marked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_matching_dirty_entities_marked_at ON matching_dirty_entities(marked_at);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_matching_dirty_entities_marked_at;
+DROP TABLE IF EXISTS matching_dirty_entities;
@@ -0,0 +1,8 @@
+-- UP
+-- Match Result Explanations
+-- Stores the score breakdown alongside each cached volunteer-project match so reads don't recompute it
+
+ALTER TABLE volunteer_project_matches ADD COLUMN IF NOT EXISTS explanation JSONB;
+
+-- DOWN
+ALTER TABLE volunteer_project_matches DROP COLUMN IF EXISTS explanation;
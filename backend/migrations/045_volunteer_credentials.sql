@@ -0,0 +1,39 @@
+-- UP
+-- Volunteer Credentials
+-- Lets volunteers upload credential documents for admin verification, and projects require credential types
+
+CREATE TABLE IF NOT EXISTS volunteer_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    credential_type VARCHAR(50) NOT NULL,
+    issuer VARCHAR(255),
+    issued_at DATE,
+    expires_at DATE,
+    document_key TEXT NOT NULL,
+    document_name VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    file_size BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'verified', 'rejected')),
+    review_note TEXT,
+    reviewed_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS project_required_credentials (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    credential_type VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, credential_type)
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_volunteer_credentials_volunteer ON volunteer_credentials(volunteer_id, credential_type);
+CREATE INDEX IF NOT EXISTS idx_volunteer_credentials_status ON volunteer_credentials(status, created_at);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_volunteer_credentials_status;
+DROP INDEX IF EXISTS idx_volunteer_credentials_volunteer;
+DROP TABLE IF EXISTS project_required_credentials;
+DROP TABLE IF EXISTS volunteer_credentials;
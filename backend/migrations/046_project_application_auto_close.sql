@@ -0,0 +1,14 @@
+-- UP
+-- Project Application Auto-Close
+-- Adds an optional team size cap and an opt-in setting that rejects pending applications once a project is full or stops recruiting
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS max_team_size INTEGER CHECK (max_team_size IS NULL OR max_team_size > 0);
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS auto_close_applications BOOLEAN NOT NULL DEFAULT false;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_applications_project_status ON applications(project_id, status);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_applications_project_status;
+ALTER TABLE projects DROP COLUMN IF EXISTS auto_close_applications;
+ALTER TABLE projects DROP COLUMN IF EXISTS max_team_size;
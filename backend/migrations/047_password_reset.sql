@@ -0,0 +1,20 @@
+-- UP
+-- Password Reset
+-- Stores reset tokens hashed and single-use, and records when a user's sessions were revoked so older JWTs stop working
+
+-- Tokens were stored in plaintext and never issued, so existing rows are discarded
+DELETE FROM password_reset_tokens;
+ALTER TABLE password_reset_tokens RENAME COLUMN token TO token_hash;
+ALTER TABLE password_reset_tokens ADD COLUMN IF NOT EXISTS used_at TIMESTAMP;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sessions_revoked_at TIMESTAMP;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_revoked_at;
+ALTER TABLE password_reset_tokens DROP COLUMN IF EXISTS used_at;
+DELETE FROM password_reset_tokens;
+ALTER TABLE password_reset_tokens RENAME COLUMN token_hash TO token;
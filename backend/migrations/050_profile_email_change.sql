@@ -0,0 +1,21 @@
+-- UP
+-- Profile Email Change
+-- Holds a changed email address until it's verified, and stores email verification tokens hashed with the address they verify
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email VARCHAR(255);
+
+-- Tokens were stored in plaintext and never issued, so existing rows are discarded
+DELETE FROM email_verification_tokens;
+ALTER TABLE email_verification_tokens RENAME COLUMN token TO token_hash;
+-- NULL verifies the account's current email; otherwise the address being changed to
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS email VARCHAR(255);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens(user_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id;
+ALTER TABLE email_verification_tokens DROP COLUMN IF EXISTS email;
+DELETE FROM email_verification_tokens;
+ALTER TABLE email_verification_tokens RENAME COLUMN token_hash TO token;
+ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
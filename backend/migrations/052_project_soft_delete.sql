@@ -0,0 +1,12 @@
+-- UP
+-- Project Soft Delete
+-- Deleting a project marks it deleted instead of removing it, so its tasks, messages and team stay intact and an admin can restore it
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_projects_not_deleted ON projects(created_at DESC) WHERE deleted_at IS NULL;
+
+-- DOWN
+DROP INDEX IF EXISTS idx_projects_not_deleted;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
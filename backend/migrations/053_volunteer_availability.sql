@@ -0,0 +1,29 @@
+-- UP
+-- Volunteer Availability
+-- Records when volunteers can help, as recurring weekly slots or one-off date ranges, so matching can weigh it against project dates
+
+CREATE TABLE IF NOT EXISTS volunteer_availability (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('weekly', 'date_range')),
+    day_of_week SMALLINT CHECK (day_of_week BETWEEN 0 AND 6),
+    start_time TIME,
+    end_time TIME,
+    start_date DATE,
+    end_date DATE,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (
+        (kind = 'weekly' AND day_of_week IS NOT NULL AND start_time IS NOT NULL AND end_time IS NOT NULL
+            AND end_time > start_time AND start_date IS NULL AND end_date IS NULL)
+        OR (kind = 'date_range' AND start_date IS NOT NULL AND end_date IS NOT NULL
+            AND end_date >= start_date AND day_of_week IS NULL AND start_time IS NULL AND end_time IS NULL)
+    )
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_volunteer_availability_volunteer_id ON volunteer_availability(volunteer_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_volunteer_availability_volunteer_id;
+DROP TABLE IF EXISTS volunteer_availability;
@@ -0,0 +1,66 @@
+-- UP
+-- Team Waitlist
+-- Volunteers approved once a project's team has reached max_team_size join a waitlist instead of the team, and are promoted in order as places open up
+
+ALTER TABLE project_team_members DROP CONSTRAINT IF EXISTS project_team_members_status_check;
+ALTER TABLE project_team_members ADD CONSTRAINT project_team_members_status_check
+    CHECK (status IN ('invited', 'active', 'completed', 'removed', 'waitlisted'));
+ALTER TABLE project_team_members ADD COLUMN IF NOT EXISTS waitlisted_at TIMESTAMP;
+
+-- Accepted applications join the team while there's room and the waitlist otherwise.
+-- Locking the project row serializes approvals so two can't take the last place.
+CREATE OR REPLACE FUNCTION auto_enroll_volunteer()
+RETURNS TRIGGER AS $$
+DECLARE
+    team_cap INTEGER;
+    active_count INTEGER;
+BEGIN
+    IF NEW.status = 'accepted' AND (OLD.status IS NULL OR OLD.status != 'accepted') THEN
+        SELECT max_team_size INTO team_cap FROM projects WHERE id = NEW.project_id FOR UPDATE;
+
+        SELECT COUNT(*) INTO active_count
+        FROM project_team_members
+        WHERE project_id = NEW.project_id AND status = 'active' AND volunteer_id != NEW.volunteer_id;
+
+        IF team_cap IS NOT NULL AND active_count >= team_cap THEN
+            INSERT INTO project_team_members (project_id, volunteer_id, status, joined_at, waitlisted_at)
+            VALUES (NEW.project_id, NEW.volunteer_id, 'waitlisted', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+            ON CONFLICT (project_id, volunteer_id) DO UPDATE
+            SET status = 'waitlisted', waitlisted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP;
+        ELSE
+            INSERT INTO project_team_members (project_id, volunteer_id, status, joined_at)
+            VALUES (NEW.project_id, NEW.volunteer_id, 'active', CURRENT_TIMESTAMP)
+            ON CONFLICT (project_id, volunteer_id) DO UPDATE
+            SET status = 'active', waitlisted_at = NULL, updated_at = CURRENT_TIMESTAMP;
+        END IF;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_project_team_members_waitlist ON project_team_members(project_id, waitlisted_at) WHERE status = 'waitlisted';
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_team_members_waitlist;
+
+CREATE OR REPLACE FUNCTION auto_enroll_volunteer()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = 'accepted' AND (OLD.status IS NULL OR OLD.status != 'accepted') THEN
+        INSERT INTO project_team_members (project_id, volunteer_id, status, joined_at)
+        VALUES (NEW.project_id, NEW.volunteer_id, 'active', CURRENT_TIMESTAMP)
+        ON CONFLICT (project_id, volunteer_id) DO UPDATE
+        SET status = 'active', updated_at = CURRENT_TIMESTAMP;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE project_team_members SET status = 'invited' WHERE status = 'waitlisted';
+ALTER TABLE project_team_members DROP COLUMN IF EXISTS waitlisted_at;
+ALTER TABLE project_team_members DROP CONSTRAINT IF EXISTS project_team_members_status_check;
+ALTER TABLE project_team_members ADD CONSTRAINT project_team_members_status_check
+    CHECK (status IN ('invited', 'active', 'completed', 'removed'));
@@ -0,0 +1,28 @@
+-- UP
+-- Role Hierarchy
+-- Lets a role inherit other roles, so holders of admin satisfy team_lead and campaign_manager checks and team leads satisfy volunteer checks
+
+CREATE TABLE IF NOT EXISTS role_inheritance (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    inherits_role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (role_id, inherits_role_id),
+    CHECK (role_id != inherits_role_id)
+);
+
+INSERT INTO role_inheritance (role_id, inherits_role_id)
+SELECT r.id, inherited.id
+FROM roles r
+JOIN roles inherited ON (r.name, inherited.name) IN (
+    ('admin', 'team_lead'),
+    ('admin', 'campaign_manager'),
+    ('team_lead', 'volunteer')
+)
+ON CONFLICT DO NOTHING;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_role_inheritance_inherits_role_id ON role_inheritance(inherits_role_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_role_inheritance_inherits_role_id;
+DROP TABLE IF EXISTS role_inheritance;
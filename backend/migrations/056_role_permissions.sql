@@ -0,0 +1,20 @@
+-- UP
+-- Role Permissions
+-- Makes sure the built-in roles grant the permissions that broadcast and campaign routes now check instead of role names
+
+UPDATE roles
+SET permissions = (
+    SELECT COALESCE(jsonb_agg(DISTINCT permission ORDER BY permission), '[]'::jsonb)
+    FROM jsonb_array_elements_text(COALESCE(roles.permissions, '[]'::jsonb) || '["view_projects", "view_volunteers", "create_campaigns", "send_campaigns", "view_campaigns"]'::jsonb) AS permission
+)
+WHERE name = 'campaign_manager';
+
+UPDATE roles
+SET permissions = (
+    SELECT COALESCE(jsonb_agg(DISTINCT permission ORDER BY permission), '[]'::jsonb)
+    FROM jsonb_array_elements_text(COALESCE(roles.permissions, '[]'::jsonb) || '["*"]'::jsonb) AS permission
+)
+WHERE name = 'admin';
+
+-- DOWN
+-- Permissions added here may have been edited by admins since, so they are left in place
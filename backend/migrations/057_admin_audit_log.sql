@@ -0,0 +1,28 @@
+-- UP
+-- Admin Audit Log
+-- Records admin user-management actions. IDs and email are copied rather than referenced so entries outlive deleted users.
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID NOT NULL,
+    actor_email VARCHAR(255),
+    target_user_id UUID NOT NULL,
+    target_email VARCHAR(255),
+    action VARCHAR(50) NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_actor_id ON admin_audit_log(actor_id);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target_user_id ON admin_audit_log(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_action ON admin_audit_log(action);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_created_at ON admin_audit_log(created_at DESC);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_admin_audit_log_created_at;
+DROP INDEX IF EXISTS idx_admin_audit_log_action;
+DROP INDEX IF EXISTS idx_admin_audit_log_target_user_id;
+DROP INDEX IF EXISTS idx_admin_audit_log_actor_id;
+DROP TABLE IF EXISTS admin_audit_log;
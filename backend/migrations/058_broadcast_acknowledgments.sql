@@ -0,0 +1,20 @@
+-- UP
+-- Broadcast Acknowledgments
+-- Lets a broadcast require explicit acknowledgment, tracked separately from read receipts so opening it does not count
+
+ALTER TABLE broadcast_messages ADD COLUMN IF NOT EXISTS acknowledgment_required BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS broadcast_acknowledgments (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    broadcast_id UUID NOT NULL REFERENCES broadcast_messages(id) ON DELETE CASCADE,
+    acknowledged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, broadcast_id)
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_broadcast_acknowledgments_broadcast_id ON broadcast_acknowledgments(broadcast_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_broadcast_acknowledgments_broadcast_id;
+DROP TABLE IF EXISTS broadcast_acknowledgments;
+ALTER TABLE broadcast_messages DROP COLUMN IF EXISTS acknowledgment_required;
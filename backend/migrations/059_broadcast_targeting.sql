@@ -0,0 +1,34 @@
+-- UP
+-- Broadcast Targeting
+-- Scopes broadcasts to all users, specific roles, or specific projects' team members, replacing the fixed target_audience values
+
+ALTER TABLE broadcast_messages ADD COLUMN IF NOT EXISTS target_type VARCHAR(20) NOT NULL DEFAULT 'all_users'
+    CHECK (target_type IN ('all_users', 'roles', 'projects'));
+ALTER TABLE broadcast_messages ADD COLUMN IF NOT EXISTS target_ids JSONB NOT NULL DEFAULT '[]';
+
+-- target_audience is kept for older clients but is no longer required
+ALTER TABLE broadcast_messages ALTER COLUMN target_audience DROP NOT NULL;
+
+-- Carry existing role audiences over to role targets
+UPDATE broadcast_messages bm
+SET target_type = 'roles',
+    target_ids = jsonb_build_array(r.id::text)
+FROM roles r
+WHERE r.name = CASE bm.target_audience
+        WHEN 'volunteers_only' THEN 'volunteer'
+        WHEN 'admins_only' THEN 'admin'
+        WHEN 'team_leads_only' THEN 'team_lead'
+    END
+AND bm.target_type = 'all_users';
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_broadcast_messages_target_type ON broadcast_messages(target_type);
+CREATE INDEX IF NOT EXISTS idx_broadcast_messages_target_ids ON broadcast_messages USING GIN (target_ids);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_broadcast_messages_target_ids;
+DROP INDEX IF EXISTS idx_broadcast_messages_target_type;
+UPDATE broadcast_messages SET target_audience = 'all_users' WHERE target_audience IS NULL;
+ALTER TABLE broadcast_messages ALTER COLUMN target_audience SET NOT NULL;
+ALTER TABLE broadcast_messages DROP COLUMN IF EXISTS target_ids;
+ALTER TABLE broadcast_messages DROP COLUMN IF EXISTS target_type;
@@ -0,0 +1,12 @@
+-- UP
+-- Resource Checksums
+-- Records a SHA-256 checksum for uploaded resource files so duplicate uploads can be detected
+
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS file_checksum VARCHAR(64);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_resources_file_checksum ON resources(file_checksum) WHERE deleted_at IS NULL;
+
+-- DOWN
+DROP INDEX IF EXISTS idx_resources_file_checksum;
+ALTER TABLE resources DROP COLUMN IF EXISTS file_checksum;
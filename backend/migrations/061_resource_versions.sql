@@ -0,0 +1,32 @@
+-- UP
+-- Resource Versions
+-- Keeps every file or link a resource has pointed to, so replacing it on update does not lose earlier versions
+
+ALTER TABLE resources ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS resource_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    resource_type VARCHAR(20) NOT NULL,
+    file_url TEXT NOT NULL,
+    file_size BIGINT,
+    mime_type VARCHAR(100),
+    file_checksum VARCHAR(64),
+    uploaded_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    UNIQUE(resource_id, version)
+);
+
+-- Existing resources start at version 1
+INSERT INTO resource_versions (resource_id, version, resource_type, file_url, file_size, mime_type,
+                               file_checksum, uploaded_by_id, created_at, deleted_at)
+SELECT id, version, resource_type, file_url, file_size, mime_type,
+       file_checksum, uploaded_by_id, created_at, deleted_at
+FROM resources
+ON CONFLICT (resource_id, version) DO NOTHING;
+
+-- DOWN
+DROP TABLE IF EXISTS resource_versions;
+ALTER TABLE resources DROP COLUMN IF EXISTS version;
@@ -0,0 +1,22 @@
+-- UP
+-- Pending Embeddings
+-- Queues skill claims whose embedding could not be generated when they were submitted, for the matching worker to embed later
+
+CREATE TABLE IF NOT EXISTS pending_embeddings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    volunteer_id UUID NOT NULL REFERENCES volunteers(id) ON DELETE CASCADE,
+    claim_text TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_pending_embeddings_next_attempt ON pending_embeddings(next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_pending_embeddings_volunteer ON pending_embeddings(volunteer_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_pending_embeddings_volunteer;
+DROP INDEX IF EXISTS idx_pending_embeddings_next_attempt;
+DROP TABLE IF EXISTS pending_embeddings;
@@ -0,0 +1,31 @@
+-- UP
+-- Application Withdrawal
+-- Adds the withdrawn application status and replaces the one-application-per-project constraint with one active application per project, so volunteers can re-apply while earlier applications are kept as history
+
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_status_check;
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('pending', 'accepted', 'rejected', 'withdrawn'));
+
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_volunteer_id_initiative_id_key;
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_volunteer_id_project_id_key;
+
+-- Add indexes for performance
+CREATE UNIQUE INDEX IF NOT EXISTS idx_applications_one_active_per_project
+    ON applications(volunteer_id, project_id) WHERE status IN ('pending', 'accepted');
+CREATE INDEX IF NOT EXISTS idx_applications_volunteer_project ON applications(volunteer_id, project_id, applied_at DESC);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_applications_volunteer_project;
+DROP INDEX IF EXISTS idx_applications_one_active_per_project;
+
+-- Keep only each volunteer's latest application per project so the unique constraint can return
+DELETE FROM applications a
+USING applications newer
+WHERE a.volunteer_id = newer.volunteer_id AND a.project_id = newer.project_id
+AND (a.applied_at < newer.applied_at OR (a.applied_at = newer.applied_at AND a.id < newer.id));
+ALTER TABLE applications ADD CONSTRAINT applications_volunteer_id_project_id_key UNIQUE (volunteer_id, project_id);
+
+UPDATE applications SET status = 'rejected' WHERE status = 'withdrawn';
+ALTER TABLE applications DROP CONSTRAINT IF EXISTS applications_status_check;
+ALTER TABLE applications ADD CONSTRAINT applications_status_check
+    CHECK (status IN ('pending', 'accepted', 'rejected'));
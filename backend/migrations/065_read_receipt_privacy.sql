@@ -0,0 +1,8 @@
+-- UP
+-- Read Receipt Privacy
+-- Lets users hide when they read messages from the senders' read receipts
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS hide_read_receipts BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- DOWN
+ALTER TABLE users DROP COLUMN IF EXISTS hide_read_receipts;
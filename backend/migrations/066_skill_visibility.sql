@@ -0,0 +1,15 @@
+-- UP
+-- Per-Skill Visibility
+-- Lets volunteers mark each skill public (used for matching), team-only (seen by their own teams) or private
+
+-- Existing skills default to public so current matches are unchanged
+ALTER TABLE volunteer_skills ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'team_only', 'private'));
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_volunteer_skills_public ON volunteer_skills(volunteer_id, skill_id)
+    WHERE visibility = 'public';
+
+-- DOWN
+DROP INDEX IF EXISTS idx_volunteer_skills_public;
+ALTER TABLE volunteer_skills DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,16 @@
+-- UP
+-- Skill Categories
+-- Lets taxonomy skills be nested under a parent skill acting as their category
+
+ALTER TABLE skill_taxonomy ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES skill_taxonomy(id) ON DELETE SET NULL;
+
+ALTER TABLE skill_taxonomy DROP CONSTRAINT IF EXISTS skill_taxonomy_parent_not_self;
+ALTER TABLE skill_taxonomy ADD CONSTRAINT skill_taxonomy_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_skill_taxonomy_parent_id ON skill_taxonomy(parent_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_skill_taxonomy_parent_id;
+ALTER TABLE skill_taxonomy DROP CONSTRAINT IF EXISTS skill_taxonomy_parent_not_self;
+ALTER TABLE skill_taxonomy DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,8 @@
+-- UP
+-- Calendar Feed Tokens
+-- Versions each user's signed calendar feed token so resetting it revokes old feed links
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_feed_version INTEGER NOT NULL DEFAULT 1;
+
+-- DOWN
+ALTER TABLE users DROP COLUMN IF EXISTS calendar_feed_version;
@@ -0,0 +1,43 @@
+-- UP
+-- Recurring Tasks
+-- Templates that repeat a project task daily, weekly or monthly; the worker creates the next instance once the current one is done
+
+CREATE TABLE IF NOT EXISTS task_recurrences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    assignee_id UUID REFERENCES volunteers(id) ON DELETE SET NULL,
+    created_by_id UUID NOT NULL REFERENCES users(id),
+    priority VARCHAR(20) NOT NULL DEFAULT 'medium',
+    labels JSONB NOT NULL DEFAULT '[]',
+    frequency VARCHAR(20) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    interval_count INT NOT NULL DEFAULT 1 CHECK (interval_count BETWEEN 1 AND 365),
+    ends_on DATE,
+    max_occurrences INT CHECK (max_occurrences IS NULL OR max_occurrences > 0),
+    starts_at TIMESTAMP NOT NULL,
+    next_due_at TIMESTAMP NOT NULL,
+    occurrence_count INT NOT NULL DEFAULT 0,
+    current_task_id UUID,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE project_tasks ADD COLUMN IF NOT EXISTS recurrence_id UUID REFERENCES task_recurrences(id) ON DELETE SET NULL;
+
+ALTER TABLE task_recurrences DROP CONSTRAINT IF EXISTS task_recurrences_current_task_fk;
+ALTER TABLE task_recurrences ADD CONSTRAINT task_recurrences_current_task_fk
+    FOREIGN KEY (current_task_id) REFERENCES project_tasks(id) ON DELETE SET NULL;
+
+-- Add indexes for performance
+CREATE INDEX IF NOT EXISTS idx_task_recurrences_project ON task_recurrences(project_id);
+CREATE INDEX IF NOT EXISTS idx_task_recurrences_active ON task_recurrences(next_due_at) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_project_tasks_recurrence ON project_tasks(recurrence_id);
+
+-- DOWN
+DROP INDEX IF EXISTS idx_project_tasks_recurrence;
+DROP INDEX IF EXISTS idx_task_recurrences_active;
+DROP INDEX IF EXISTS idx_task_recurrences_project;
+ALTER TABLE project_tasks DROP COLUMN IF EXISTS recurrence_id;
+DROP TABLE IF EXISTS task_recurrences;
@@ -0,0 +1,15 @@
+-- UP
+-- Failed Pending Embeddings
+-- Stops retrying queued skill claims that can't be embedded and keeps them so volunteers can see which claims failed
+
+ALTER TABLE pending_embeddings ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+
+-- Only claims still being retried are polled
+DROP INDEX IF EXISTS idx_pending_embeddings_next_attempt;
+CREATE INDEX IF NOT EXISTS idx_pending_embeddings_next_attempt ON pending_embeddings(next_attempt_at)
+    WHERE failed_at IS NULL;
+
+-- DOWN
+DROP INDEX IF EXISTS idx_pending_embeddings_next_attempt;
+CREATE INDEX IF NOT EXISTS idx_pending_embeddings_next_attempt ON pending_embeddings(next_attempt_at);
+ALTER TABLE pending_embeddings DROP COLUMN IF EXISTS failed_at;
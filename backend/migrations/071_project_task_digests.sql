@@ -0,0 +1,18 @@
+-- UP
+-- Project Task Digests
+-- Moves the task notification digest window from users to projects and anchors each digest's window on its first notification
+
+-- NULL uses the default window
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS task_digest_minutes INTEGER
+    CHECK (task_digest_minutes >= 0 AND task_digest_minutes <= 1440);
+
+-- When the digest's first notification was posted; created_at moves with each merge
+ALTER TABLE project_messages ADD COLUMN IF NOT EXISTS digest_started_at TIMESTAMP;
+
+ALTER TABLE users DROP COLUMN IF EXISTS task_digest_minutes;
+
+-- DOWN
+ALTER TABLE users ADD COLUMN IF NOT EXISTS task_digest_minutes INTEGER NOT NULL DEFAULT 5
+    CHECK (task_digest_minutes >= 0 AND task_digest_minutes <= 1440);
+ALTER TABLE project_messages DROP COLUMN IF EXISTS digest_started_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS task_digest_minutes;
@@ -0,0 +1,8 @@
+-- UP
+-- Flag Legacy Skill Claims
+-- Claims embedded before the model was recorded need re-embedding, so flag them for embed-backfill
+
+UPDATE skill_claims SET needs_embedding = true WHERE embedding_model IS NULL AND needs_embedding = false;
+
+-- DOWN
+UPDATE skill_claims SET needs_embedding = false WHERE embedding_model IS NULL;
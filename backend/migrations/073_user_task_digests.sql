@@ -0,0 +1,10 @@
+-- UP
+-- User Task Digests
+-- Brings back each user's own task notification digest window; the project's window is the default
+
+-- NULL uses the project's window
+ALTER TABLE users ADD COLUMN IF NOT EXISTS task_digest_minutes INTEGER
+    CHECK (task_digest_minutes >= 0 AND task_digest_minutes <= 1440);
+
+-- DOWN
+ALTER TABLE users DROP COLUMN IF EXISTS task_digest_minutes;
@@ -0,0 +1,17 @@
+-- UP
+-- Email Fallback Attempts
+-- Caps and spaces out retries of unread direct message emails instead of retrying a failed send on every run
+
+ALTER TABLE message_email_fallbacks ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE message_email_fallbacks ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP;
+
+-- NULL until the email has gone out. Failed sends used to delete their row, so every existing one was sent.
+ALTER TABLE message_email_fallbacks ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP;
+UPDATE message_email_fallbacks SET delivered_at = sent_at WHERE delivered_at IS NULL;
+
+-- DOWN
+-- Undelivered rows would otherwise read as sent
+DELETE FROM message_email_fallbacks WHERE delivered_at IS NULL;
+ALTER TABLE message_email_fallbacks DROP COLUMN IF EXISTS delivered_at;
+ALTER TABLE message_email_fallbacks DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE message_email_fallbacks DROP COLUMN IF EXISTS attempts;
@@ -93,7 +93,14 @@ func (s *AdminService) List() ([]*Admin, error) {
 
 // Update updates an admin
 func (s *AdminService) Update(admin *Admin) error {
-	_, err := s.db.Exec(adminUpdateQuery, admin.ID, admin.Name)
+	return updateAdmin(s.db, admin)
+}
+
+// updateAdmin updates an admin with db, which may be a transaction
+func updateAdmin(db interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}, admin *Admin) error {
+	_, err := db.Exec(adminUpdateQuery, admin.ID, admin.Name)
 	return err
 }
 
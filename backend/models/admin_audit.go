@@ -0,0 +1,163 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Admin audit actions
+const (
+	AdminAuditActionDeleteUser       = "delete_user"
+	AdminAuditActionSetVerification  = "set_verification"
+	AdminAuditActionResetPassword    = "reset_password"
+	AdminAuditActionRevokeSessions   = "revoke_sessions"
+	AdminAuditActionUnlockUser       = "unlock_user"
+	AdminAuditActionExportVolunteers = "export_volunteers"
+)
+
+// AdminAuditEntry records one admin action taken against a user. Before and After hold
+// the fields the action changed; secrets such as passwords are never recorded. Actions
+// covering every user, such as exports, have uuid.Nil as their target.
+type AdminAuditEntry struct {
+	ID           uuid.UUID       `json:"id" db:"id"`
+	ActorID      uuid.UUID       `json:"actor_id" db:"actor_id"`
+	ActorEmail   string          `json:"actor_email" db:"actor_email"`
+	TargetUserID uuid.UUID       `json:"target_user_id" db:"target_user_id"`
+	TargetEmail  string          `json:"target_email" db:"target_email"`
+	Action       string          `json:"action" db:"action"`
+	Before       json.RawMessage `json:"before,omitempty" db:"before_state"`
+	After        json.RawMessage `json:"after,omitempty" db:"after_state"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AdminAuditFilters represents filters for audit log queries
+type AdminAuditFilters struct {
+	ActorID      *uuid.UUID `json:"actor_id,omitempty"`
+	TargetUserID *uuid.UUID `json:"target_user_id,omitempty"`
+	Action       *string    `json:"action,omitempty"`
+}
+
+// AdminAuditService handles admin audit log operations
+type AdminAuditService struct {
+	db *sql.DB
+}
+
+// NewAdminAuditService creates a new admin audit service
+func NewAdminAuditService(db *sql.DB) *AdminAuditService {
+	return &AdminAuditService{db: db}
+}
+
+// Record stores an audit entry. before and after are marshalled to JSON and may be nil.
+func (s *AdminAuditService) Record(entry *AdminAuditEntry, before, after map[string]interface{}) error {
+	beforeJSON, err := marshalAuditState(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := marshalAuditState(after)
+	if err != nil {
+		return err
+	}
+	entry.Before = beforeJSON
+	entry.After = afterJSON
+
+	return s.db.QueryRow(adminAuditCreateQuery,
+		entry.ActorID,
+		sql.NullString{String: entry.ActorEmail, Valid: entry.ActorEmail != ""},
+		entry.TargetUserID,
+		sql.NullString{String: entry.TargetEmail, Valid: entry.TargetEmail != ""},
+		entry.Action,
+		auditStateValue(beforeJSON),
+		auditStateValue(afterJSON),
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// List retrieves audit entries matching filters, newest first
+func (s *AdminAuditService) List(filters AdminAuditFilters, limit, offset int) ([]AdminAuditEntry, error) {
+	query := adminAuditListQuery
+	args := []interface{}{}
+	argIndex := 1
+
+	whereConditions := []string{}
+
+	if filters.ActorID != nil {
+		whereConditions = append(whereConditions, "actor_id = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.ActorID)
+		argIndex++
+	}
+
+	if filters.TargetUserID != nil {
+		whereConditions = append(whereConditions, "target_user_id = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.TargetUserID)
+		argIndex++
+	}
+
+	if filters.Action != nil {
+		whereConditions = append(whereConditions, "action = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.Action)
+		argIndex++
+	}
+
+	if len(whereConditions) > 0 {
+		query += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC LIMIT $" + fmt.Sprintf("%d", argIndex) + " OFFSET $" + fmt.Sprintf("%d", argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []AdminAuditEntry{}
+	for rows.Next() {
+		var entry AdminAuditEntry
+		var actorEmail, targetEmail sql.NullString
+		var before, after []byte
+
+		if err := rows.Scan(
+			&entry.ID, &entry.ActorID, &actorEmail, &entry.TargetUserID, &targetEmail,
+			&entry.Action, &before, &after, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		entry.ActorEmail = actorEmail.String
+		entry.TargetEmail = targetEmail.String
+		if before != nil {
+			entry.Before = json.RawMessage(before)
+		}
+		if after != nil {
+			entry.After = json.RawMessage(after)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// marshalAuditState encodes an audit before/after state, leaving it empty when there is none
+func marshalAuditState(state map[string]interface{}) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+	return data, nil
+}
+
+// auditStateValue converts an encoded state to a query argument, storing NULL when empty
+func auditStateValue(state json.RawMessage) interface{} {
+	if state == nil {
+		return nil
+	}
+	return []byte(state)
+}
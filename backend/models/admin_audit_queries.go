@@ -0,0 +1,14 @@
+package models
+
+// Query constants for the admin audit log
+const (
+	adminAuditCreateQuery = `
+		INSERT INTO admin_audit_log (actor_id, actor_email, target_user_id, target_email, action, before_state, after_state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	adminAuditListQuery = `
+		SELECT id, actor_id, actor_email, target_user_id, target_email, action,
+			before_state, after_state, created_at
+		FROM admin_audit_log`
+)
@@ -0,0 +1,198 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"civicweave/backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix marks personal access tokens so they can be told apart from JWTs
+const APITokenPrefix = "cwpat_"
+
+// API token errors
+var (
+	ErrAPITokenRevoked = fmt.Errorf("API token has been revoked")
+	ErrAPITokenExpired = fmt.Errorf("API token has expired")
+	ErrAPITokenNoRoles = fmt.Errorf("API token grants no roles the user still holds")
+	ErrAPITokenLocked  = fmt.Errorf("API token's account is locked")
+)
+
+// APIToken represents a personal access token. The token itself is only
+// returned once, when issued; afterwards only its prefix is shown.
+type APIToken struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	TokenPrefix string     `json:"token_prefix" db:"token_prefix"`
+	Scopes      []string   `json:"scopes" db:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// APITokenIdentity is the caller an API token authenticates as
+type APITokenIdentity struct {
+	TokenID uuid.UUID
+	UserID  uuid.UUID
+	Email   string
+	Roles   []string
+}
+
+// APITokenService handles personal access token operations
+type APITokenService struct {
+	db *sql.DB
+}
+
+// NewAPITokenService creates a new API token service
+func NewAPITokenService(db *sql.DB) *APITokenService {
+	return &APITokenService{db: db}
+}
+
+// IsAPIToken reports whether a bearer token is a personal access token rather than a JWT
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// Issue creates a token for userID scoped to the given role names and returns
+// the plaintext token alongside its metadata. The plaintext is not stored.
+func (s *APITokenService) Issue(userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (string, *APIToken, error) {
+	rawToken := APITokenPrefix + utils.GenerateRandomToken()
+
+	token := &APIToken{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        name,
+		TokenPrefix: rawToken[:len(APITokenPrefix)+8],
+		Scopes:      scopes,
+		ExpiresAt:   expiresAt,
+	}
+
+	scopesJSON, err := ToJSONArray(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = s.db.QueryRow(apiTokenCreateQuery, token.ID, token.UserID, token.Name, utils.HashToken(rawToken),
+		token.TokenPrefix, scopesJSON, token.ExpiresAt).Scan(&token.CreatedAt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return rawToken, token, nil
+}
+
+// ListByUser retrieves a user's tokens, including revoked and expired ones
+func (s *APITokenService) ListByUser(userID uuid.UUID) ([]APIToken, error) {
+	rows, err := s.db.Query(apiTokenListByUserQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []APIToken{}
+	for rows.Next() {
+		var token APIToken
+		var scopesJSON string
+
+		err := rows.Scan(
+			&token.ID, &token.UserID, &token.Name, &token.TokenPrefix, &scopesJSON,
+			&token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ParseJSONArray(scopesJSON, &token.Scopes); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// Revoke revokes one of a user's tokens. It returns sql.ErrNoRows if the
+// token doesn't exist, belongs to someone else, or is already revoked.
+func (s *APITokenService) Revoke(tokenID, userID uuid.UUID) error {
+	result, err := s.db.Exec(apiTokenRevokeQuery, tokenID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Authenticate resolves a plaintext token to the identity it acts as. The
+// identity's roles are the token's scopes intersected with the roles the user
+// currently holds, so removing a role from a user also removes it from their tokens.
+// Tokens created before the user's sessions were last revoked count as revoked, and
+// tokens of locked accounts are refused like a login would be.
+// It returns nil, nil if no such token exists.
+func (s *APITokenService) Authenticate(rawToken string) (*APITokenIdentity, error) {
+	identity := &APITokenIdentity{}
+	var scopesJSON string
+	var expiresAt, revokedAt, lockedUntil, sessionsRevokedAt *time.Time
+	var createdAt time.Time
+
+	err := s.db.QueryRow(apiTokenGetByHashQuery, utils.HashToken(rawToken)).Scan(
+		&identity.TokenID, &identity.UserID, &identity.Email, &scopesJSON, &expiresAt, &revokedAt, &createdAt,
+		&lockedUntil, &sessionsRevokedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if revokedAt != nil || (sessionsRevokedAt != nil && !createdAt.After(*sessionsRevokedAt)) {
+		return nil, ErrAPITokenRevoked
+	}
+	if expiresAt != nil && expiresAt.Before(now) {
+		return nil, ErrAPITokenExpired
+	}
+	if lockedUntil != nil && lockedUntil.After(now) {
+		return nil, ErrAPITokenLocked
+	}
+
+	rows, err := s.db.Query(apiTokenCurrentRolesQuery, identity.UserID, scopesJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		identity.Roles = append(identity.Roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(identity.Roles) == 0 {
+		return nil, ErrAPITokenNoRoles
+	}
+
+	if _, err := s.db.Exec(apiTokenTouchQuery, identity.TokenID); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
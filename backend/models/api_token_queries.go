@@ -0,0 +1,46 @@
+package models
+
+// Query constants for APITokenService
+const (
+	apiTokenCreateQuery = `
+		INSERT INTO api_tokens (id, user_id, name, token_hash, token_prefix, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	apiTokenListByUserQuery = `
+		SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	apiTokenRevokeQuery = `
+		UPDATE api_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	apiTokenRevokeAllForUserQuery = `
+		UPDATE api_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	apiTokenGetByHashQuery = `
+		SELECT t.id, t.user_id, u.email, t.scopes, t.expires_at, t.revoked_at, t.created_at,
+		       u.locked_until, u.sessions_revoked_at
+		FROM api_tokens t
+		JOIN users u ON t.user_id = u.id
+		WHERE t.token_hash = $1`
+
+	// Effective roles are the token's scopes the user still holds
+	apiTokenCurrentRolesQuery = `
+		SELECT r.name
+		FROM roles r
+		JOIN user_roles ur ON r.id = ur.role_id
+		WHERE ur.user_id = $1 AND r.name IN (SELECT jsonb_array_elements_text($2::jsonb))
+		ORDER BY r.name`
+
+	// Only touch last_used_at once a minute to avoid a write per request
+	apiTokenTouchQuery = `
+		UPDATE api_tokens
+		SET last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')`
+)
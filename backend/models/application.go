@@ -2,11 +2,44 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Application statuses. Pending and accepted applications are active; a volunteer can
+// have at most one active application per project.
+const (
+	ApplicationStatusPending   = "pending"
+	ApplicationStatusAccepted  = "accepted"
+	ApplicationStatusRejected  = "rejected"
+	ApplicationStatusWithdrawn = "withdrawn"
+)
+
+// ApplicationStatuses lists every application status
+var ApplicationStatuses = []string{
+	ApplicationStatusPending,
+	ApplicationStatusAccepted,
+	ApplicationStatusRejected,
+	ApplicationStatusWithdrawn,
+}
+
+// IsValidApplicationStatus reports whether status is a known application status
+func IsValidApplicationStatus(status string) bool {
+	for _, valid := range ApplicationStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Application errors
+var (
+	ErrActiveApplicationExists = fmt.Errorf("volunteer already has an active application for this project")
+)
+
 // Application represents a volunteer application for a project
 type Application struct {
 	ID          uuid.UUID `json:"id" db:"id"`
@@ -33,12 +66,17 @@ func (s *ApplicationService) GetDB() *sql.DB {
 	return s.db
 }
 
-// Create creates a new application
+// Create creates a new application. It returns ErrActiveApplicationExists if the
+// volunteer already has a pending or accepted application for the project.
 func (s *ApplicationService) Create(application *Application) error {
 	application.ID = uuid.New()
-	return s.db.QueryRow(applicationCreateQuery, application.ID, application.VolunteerID,
+	err := s.db.QueryRow(applicationCreateQuery, application.ID, application.VolunteerID,
 		application.ProjectID, application.Status, application.AdminNotes).
 		Scan(&application.AppliedAt, &application.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrActiveApplicationExists
+	}
+	return err
 }
 
 // GetByID retrieves an application by ID
@@ -70,7 +108,7 @@ func (s *ApplicationService) GetByInitiativeAndVolunteer(initiativeID, volunteer
 	return s.GetByProjectAndVolunteer(initiativeID, volunteerID)
 }
 
-// GetByVolunteerAndProject retrieves an application by volunteer and project IDs
+// GetByVolunteerAndProject retrieves a volunteer's most recent application for a project
 func (s *ApplicationService) GetByVolunteerAndProject(volunteerID, projectID uuid.UUID) (*Application, error) {
 	application := &Application{}
 
@@ -119,15 +157,54 @@ func (s *ApplicationService) Update(application *Application) error {
 		Scan(&application.UpdatedAt)
 }
 
+// BulkUpdateStatusForProject moves every application for a project that is in
+// fromStatus to toStatus, recording notes as the admin notes, and returns the
+// applications it changed
+func (s *ApplicationService) BulkUpdateStatusForProject(projectID uuid.UUID, fromStatus, toStatus, notes string) ([]Application, error) {
+	rows, err := s.db.Query(applicationBulkUpdateStatusForProjectQuery, projectID, fromStatus, toStatus, notes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applications := []Application{}
+	for rows.Next() {
+		var application Application
+		err := rows.Scan(&application.ID, &application.VolunteerID, &application.ProjectID,
+			&application.Status, &application.AppliedAt, &application.UpdatedAt, &application.AdminNotes)
+		if err != nil {
+			return nil, err
+		}
+		applications = append(applications, application)
+	}
+
+	return applications, rows.Err()
+}
+
+// Withdraw marks a volunteer's pending application as withdrawn, keeping it as history.
+// It returns sql.ErrNoRows if the application isn't the volunteer's or is no longer pending.
+func (s *ApplicationService) Withdraw(id, volunteerID uuid.UUID) (*Application, error) {
+	application := &Application{}
+	err := s.db.QueryRow(applicationWithdrawQuery, id, volunteerID).Scan(
+		&application.ID, &application.VolunteerID, &application.ProjectID,
+		&application.Status, &application.AppliedAt, &application.UpdatedAt, &application.AdminNotes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
 // Delete deletes an application
 func (s *ApplicationService) Delete(id uuid.UUID) error {
 	_, err := s.db.Exec(applicationDeleteQuery, id)
 	return err
 }
 
-// GetApplicationsByProject retrieves applications for a specific project
-func (s *ApplicationService) GetApplicationsByProject(projectID uuid.UUID) ([]Application, error) {
-	rows, err := s.db.Query(applicationGetByProjectQuery, projectID)
+// GetApplicationsByProject retrieves applications for a specific project, optionally
+// only those with the given status ("" for all)
+func (s *ApplicationService) GetApplicationsByProject(projectID uuid.UUID, status string) ([]Application, error) {
+	rows, err := s.db.Query(applicationGetByProjectQuery, projectID, status)
 	if err != nil {
 		return nil, err
 	}
@@ -2,9 +2,14 @@ package models
 
 // Query constants for ApplicationService
 const (
+	// Inserts nothing if the volunteer already has an active application for the project
 	applicationCreateQuery = `
 		INSERT INTO applications (id, volunteer_id, project_id, status, admin_notes)
-		VALUES ($1, $2, $3, $4, $5)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM applications
+			WHERE volunteer_id = $2 AND project_id = $3 AND status IN ('pending', 'accepted')
+		)
 		RETURNING applied_at, updated_at`
 
 	applicationGetByIDQuery = `
@@ -13,7 +18,9 @@ const (
 
 	applicationGetByVolunteerAndProjectQuery = `
 		SELECT id, volunteer_id, project_id, status, applied_at, updated_at, admin_notes
-		FROM applications WHERE volunteer_id = $1 AND project_id = $2`
+		FROM applications WHERE volunteer_id = $1 AND project_id = $2
+		ORDER BY applied_at DESC
+		LIMIT 1`
 
 	applicationListQuery = `
 		SELECT id, volunteer_id, project_id, status, applied_at, updated_at, admin_notes
@@ -30,11 +37,24 @@ const (
 		WHERE id = $1
 		RETURNING updated_at`
 
+	applicationWithdrawQuery = `
+		UPDATE applications
+		SET status = 'withdrawn', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND volunteer_id = $2 AND status = 'pending'
+		RETURNING id, volunteer_id, project_id, status, applied_at, updated_at, admin_notes`
+
 	applicationDeleteQuery = `DELETE FROM applications WHERE id = $1`
 
 	applicationGetByProjectQuery = `
 		SELECT id, volunteer_id, project_id, status, applied_at, updated_at, admin_notes
 		FROM applications 
 		WHERE project_id = $1
+		  AND ($2 = '' OR status = $2)
 		ORDER BY applied_at DESC`
+
+	applicationBulkUpdateStatusForProjectQuery = `
+		UPDATE applications
+		SET status = $3, admin_notes = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE project_id = $1 AND status = $2
+		RETURNING id, volunteer_id, project_id, status, applied_at, updated_at, admin_notes`
 )
@@ -2,23 +2,46 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// BroadcastMessage represents a system-wide announcement
+// Broadcast target types
+const (
+	BroadcastTargetAllUsers = "all_users"
+	BroadcastTargetRoles    = "roles"
+	BroadcastTargetProjects = "projects"
+)
+
+// legacyAudienceRoles maps the original target_audience values to the role they target
+var legacyAudienceRoles = map[string]string{
+	"volunteers_only": "volunteer",
+	"admins_only":     "admin",
+	"team_leads_only": "team_lead",
+}
+
+// ErrInvalidBroadcastTarget is returned when a broadcast's target is unknown or names missing roles or projects
+var ErrInvalidBroadcastTarget = fmt.Errorf("invalid broadcast target")
+
+// BroadcastMessage represents an announcement to all users, specific roles, or specific projects' teams
 type BroadcastMessage struct {
-	ID             uuid.UUID  `json:"id" db:"id"`
-	Title          string     `json:"title" db:"title"`
-	Content        string     `json:"content" db:"content"`
-	AuthorID       uuid.UUID  `json:"author_id" db:"author_id"`
-	TargetAudience string     `json:"target_audience" db:"target_audience"`
-	Priority       string     `json:"priority" db:"priority"`
-	ExpiresAt      *time.Time `json:"expires_at,omitempty" db:"expires_at"`
-	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID       uuid.UUID `json:"id" db:"id"`
+	Title    string    `json:"title" db:"title"`
+	Content  string    `json:"content" db:"content"`
+	AuthorID uuid.UUID `json:"author_id" db:"author_id"`
+	// TargetAudience is the original fixed audience, kept for older clients; TargetType and TargetIDs decide who sees it
+	TargetAudience string      `json:"target_audience,omitempty" db:"target_audience"`
+	TargetType     string      `json:"target_type" db:"target_type"`
+	TargetIDs      []uuid.UUID `json:"target_ids" db:"target_ids"`
+	Priority       string      `json:"priority" db:"priority"`
+	ExpiresAt      *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+	// AcknowledgmentRequired broadcasts must be explicitly acknowledged; reading is not enough
+	AcknowledgmentRequired bool       `json:"acknowledgment_required" db:"acknowledgment_required"`
+	CreatedAt              time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt              *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // BroadcastRead represents a read receipt for a broadcast
@@ -31,9 +54,19 @@ type BroadcastRead struct {
 // BroadcastWithAuthor includes broadcast and author info
 type BroadcastWithAuthor struct {
 	BroadcastMessage
-	AuthorName  string `json:"author_name"`
-	AuthorEmail string `json:"author_email"`
-	IsRead      bool   `json:"is_read"`
+	AuthorName     string `json:"author_name"`
+	AuthorEmail    string `json:"author_email"`
+	IsRead         bool   `json:"is_read"`
+	IsAcknowledged bool   `json:"is_acknowledged"`
+}
+
+// BroadcastRecipientStatus reports whether one audience member has read and acknowledged a broadcast
+type BroadcastRecipientStatus struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
 }
 
 // BroadcastStats represents statistics for broadcasts
@@ -42,6 +75,11 @@ type BroadcastStats struct {
 	UnreadBroadcasts  int `json:"unread_broadcasts"`
 	HighPriorityCount int `json:"high_priority_count"`
 	UrgentCount       int `json:"urgent_count"`
+	// Acknowledgment is tracked separately from reads
+	AcknowledgmentRequiredCount int     `json:"acknowledgment_required_count"`
+	AcknowledgedCount           int     `json:"acknowledged_count"`
+	PendingAcknowledgmentCount  int     `json:"pending_acknowledgment_count"`
+	AcknowledgmentRate          float64 `json:"acknowledgment_rate"`
 }
 
 // BroadcastService handles broadcast operations
@@ -56,19 +94,29 @@ func NewBroadcastService(db *sql.DB) *BroadcastService {
 
 // Create creates a new broadcast
 func (s *BroadcastService) Create(broadcast *BroadcastMessage) error {
+	if err := s.resolveTarget(broadcast); err != nil {
+		return err
+	}
+	targetIDsJSON, err := uuidsJSON(broadcast.TargetIDs)
+	if err != nil {
+		return err
+	}
+
 	broadcast.ID = uuid.New()
 	return s.db.QueryRow(broadcastCreateQuery, broadcast.ID, broadcast.Title, broadcast.Content,
-		broadcast.AuthorID, broadcast.TargetAudience, broadcast.Priority, broadcast.ExpiresAt).
+		broadcast.AuthorID, broadcast.TargetAudience, broadcast.TargetType, targetIDsJSON,
+		broadcast.Priority, broadcast.ExpiresAt, broadcast.AcknowledgmentRequired).
 		Scan(&broadcast.CreatedAt, &broadcast.UpdatedAt)
 }
 
 // GetByID retrieves a broadcast by ID
 func (s *BroadcastService) GetByID(id uuid.UUID) (*BroadcastMessage, error) {
 	broadcast := &BroadcastMessage{}
+	var targetIDsJSON string
 	err := s.db.QueryRow(broadcastGetByIDQuery, id).Scan(
 		&broadcast.ID, &broadcast.Title, &broadcast.Content, &broadcast.AuthorID,
-		&broadcast.TargetAudience, &broadcast.Priority, &broadcast.ExpiresAt,
-		&broadcast.CreatedAt, &broadcast.UpdatedAt, &broadcast.DeletedAt,
+		&broadcast.TargetAudience, &broadcast.TargetType, &targetIDsJSON, &broadcast.Priority, &broadcast.ExpiresAt,
+		&broadcast.AcknowledgmentRequired, &broadcast.CreatedAt, &broadcast.UpdatedAt, &broadcast.DeletedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -76,33 +124,27 @@ func (s *BroadcastService) GetByID(id uuid.UUID) (*BroadcastMessage, error) {
 		}
 		return nil, err
 	}
+	if broadcast.TargetIDs, err = parseBroadcastTargetIDs(targetIDsJSON); err != nil {
+		return nil, err
+	}
 	return broadcast, nil
 }
 
-// List retrieves broadcasts for a user based on their role and target audience
-func (s *BroadcastService) List(userID uuid.UUID, userRole string, limit, offset int) ([]BroadcastWithAuthor, error) {
-	rows, err := s.db.Query(broadcastListQuery, userID, userRole, limit, offset)
+// List retrieves broadcasts targeted at a user. userRoles should already be expanded
+// through the role hierarchy.
+func (s *BroadcastService) List(userID uuid.UUID, userRoles []string, limit, offset int) ([]BroadcastWithAuthor, error) {
+	rolesJSON, err := ToJSONArray(userRoles)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var broadcasts []BroadcastWithAuthor
-	for rows.Next() {
-		var broadcast BroadcastWithAuthor
-		err := rows.Scan(
-			&broadcast.ID, &broadcast.Title, &broadcast.Content, &broadcast.AuthorID,
-			&broadcast.TargetAudience, &broadcast.Priority, &broadcast.ExpiresAt,
-			&broadcast.CreatedAt, &broadcast.UpdatedAt, &broadcast.DeletedAt,
-			&broadcast.AuthorName, &broadcast.AuthorEmail, &broadcast.IsRead,
-		)
-		if err != nil {
-			return nil, err
-		}
-		broadcasts = append(broadcasts, broadcast)
+	rows, err := s.db.Query(broadcastListQuery, userID, rolesJSON, limit, offset)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return broadcasts, rows.Err()
+	return scanBroadcastsWithAuthor(rows)
 }
 
 // ListAll retrieves all broadcasts (admin only)
@@ -113,18 +155,27 @@ func (s *BroadcastService) ListAll(limit, offset int) ([]BroadcastWithAuthor, er
 	}
 	defer rows.Close()
 
+	return scanBroadcastsWithAuthor(rows)
+}
+
+// scanBroadcastsWithAuthor scans the rows of a broadcast list query
+func scanBroadcastsWithAuthor(rows *sql.Rows) ([]BroadcastWithAuthor, error) {
 	var broadcasts []BroadcastWithAuthor
 	for rows.Next() {
 		var broadcast BroadcastWithAuthor
+		var targetIDsJSON string
 		err := rows.Scan(
 			&broadcast.ID, &broadcast.Title, &broadcast.Content, &broadcast.AuthorID,
-			&broadcast.TargetAudience, &broadcast.Priority, &broadcast.ExpiresAt,
-			&broadcast.CreatedAt, &broadcast.UpdatedAt, &broadcast.DeletedAt,
-			&broadcast.AuthorName, &broadcast.AuthorEmail, &broadcast.IsRead,
+			&broadcast.TargetAudience, &broadcast.TargetType, &targetIDsJSON, &broadcast.Priority, &broadcast.ExpiresAt,
+			&broadcast.AcknowledgmentRequired, &broadcast.CreatedAt, &broadcast.UpdatedAt, &broadcast.DeletedAt,
+			&broadcast.AuthorName, &broadcast.AuthorEmail, &broadcast.IsRead, &broadcast.IsAcknowledged,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if broadcast.TargetIDs, err = parseBroadcastTargetIDs(targetIDsJSON); err != nil {
+			return nil, err
+		}
 		broadcasts = append(broadcasts, broadcast)
 	}
 
@@ -133,8 +184,17 @@ func (s *BroadcastService) ListAll(limit, offset int) ([]BroadcastWithAuthor, er
 
 // Update updates a broadcast
 func (s *BroadcastService) Update(broadcast *BroadcastMessage) error {
+	if err := s.resolveTarget(broadcast); err != nil {
+		return err
+	}
+	targetIDsJSON, err := uuidsJSON(broadcast.TargetIDs)
+	if err != nil {
+		return err
+	}
+
 	return s.db.QueryRow(broadcastUpdateQuery, broadcast.ID, broadcast.Title, broadcast.Content,
-		broadcast.TargetAudience, broadcast.Priority, broadcast.ExpiresAt).
+		broadcast.TargetAudience, broadcast.TargetType, targetIDsJSON,
+		broadcast.Priority, broadcast.ExpiresAt, broadcast.AcknowledgmentRequired).
 		Scan(&broadcast.UpdatedAt)
 }
 
@@ -163,28 +223,86 @@ func (s *BroadcastService) MarkAsRead(broadcastID, userID uuid.UUID) error {
 	return err
 }
 
-// GetUnreadCount returns the count of unread broadcasts for a user
+// GetUnreadCount returns the count of unread broadcasts targeted at a user
 func (s *BroadcastService) GetUnreadCount(userID uuid.UUID, userRoles []string) (int, error) {
+	rolesJSON, err := ToJSONArray(userRoles)
+	if err != nil {
+		return 0, err
+	}
+
 	var count int
-	err := s.db.QueryRow(broadcastGetUnreadCountQuery, userID, userRoles).Scan(&count)
+	err = s.db.QueryRow(broadcastGetUnreadCountQuery, userID, rolesJSON).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-// GetStats returns broadcast statistics for a user
+// GetStats returns statistics over the broadcasts targeted at a user
 func (s *BroadcastService) GetStats(userID uuid.UUID, userRoles []string) (*BroadcastStats, error) {
+	rolesJSON, err := ToJSONArray(userRoles)
+	if err != nil {
+		return nil, err
+	}
+
 	stats := &BroadcastStats{}
-	err := s.db.QueryRow(broadcastGetStatsQuery, userID, userRoles).Scan(
+	err = s.db.QueryRow(broadcastGetStatsQuery, userID, rolesJSON).Scan(
 		&stats.TotalBroadcasts, &stats.UnreadBroadcasts, &stats.HighPriorityCount, &stats.UrgentCount,
+		&stats.AcknowledgmentRequiredCount, &stats.AcknowledgedCount,
 	)
 	if err != nil {
 		return nil, err
 	}
+
+	stats.PendingAcknowledgmentCount = stats.AcknowledgmentRequiredCount - stats.AcknowledgedCount
+	stats.AcknowledgmentRate = acknowledgmentRate(stats.AcknowledgedCount, stats.AcknowledgmentRequiredCount)
 	return stats, nil
 }
 
+// Acknowledge records that a user has explicitly acknowledged a broadcast. Acknowledging
+// also marks it read, but marking it read never acknowledges it.
+func (s *BroadcastService) Acknowledge(broadcastID, userID uuid.UUID) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(broadcastAcknowledgeQuery, userID, broadcastID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(broadcastMarkAsReadQuery, userID, broadcastID)
+		return err
+	})
+}
+
+// GetAcknowledgmentStatus lists everyone a broadcast targets with when they read and
+// acknowledged it, acknowledged recipients first
+func (s *BroadcastService) GetAcknowledgmentStatus(broadcast *BroadcastMessage) ([]BroadcastRecipientStatus, error) {
+	rows, err := s.db.Query(broadcastAcknowledgmentStatusQuery, broadcast.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipients := []BroadcastRecipientStatus{}
+	for rows.Next() {
+		var recipient BroadcastRecipientStatus
+		if err := rows.Scan(
+			&recipient.UserID, &recipient.Email, &recipient.Name,
+			&recipient.ReadAt, &recipient.AcknowledgedAt,
+		); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// acknowledgmentRate is the share of required acknowledgments given, 1 when none are required
+func acknowledgmentRate(acknowledged, required int) float64 {
+	if required == 0 {
+		return 1
+	}
+	return float64(acknowledged) / float64(required)
+}
+
 // IsAuthor checks if a user is the author of a broadcast
 func (s *BroadcastService) IsAuthor(broadcastID, userID uuid.UUID) (bool, error) {
 	var count int
@@ -195,7 +313,114 @@ func (s *BroadcastService) IsAuthor(broadcastID, userID uuid.UUID) (bool, error)
 	return count > 0, nil
 }
 
+// IsTargetedAt checks if a broadcast is targeted at a user. userRoles should already be
+// expanded through the role hierarchy.
+func (s *BroadcastService) IsTargetedAt(broadcastID, userID uuid.UUID, userRoles []string) (bool, error) {
+	rolesJSON, err := ToJSONArray(userRoles)
+	if err != nil {
+		return false, err
+	}
+
+	var targeted bool
+	err = s.db.QueryRow(broadcastIsTargetedAtQuery, userID, rolesJSON, broadcastID).Scan(&targeted)
+	return targeted, err
+}
+
 // GetDB returns the database connection (needed for creating other services)
 func (s *BroadcastService) GetDB() *sql.DB {
 	return s.db
 }
+
+// resolveTarget validates a broadcast's target, deriving it from TargetAudience when
+// only the original fixed audience was given
+func (s *BroadcastService) resolveTarget(broadcast *BroadcastMessage) error {
+	if broadcast.TargetType == "" {
+		audience := broadcast.TargetAudience
+		if audience == "" || audience == BroadcastTargetAllUsers {
+			broadcast.TargetAudience = BroadcastTargetAllUsers
+			broadcast.TargetType = BroadcastTargetAllUsers
+		} else {
+			roleName, ok := legacyAudienceRoles[audience]
+			if !ok {
+				return fmt.Errorf("%w: unknown target audience %q", ErrInvalidBroadcastTarget, audience)
+			}
+			var roleID uuid.UUID
+			if err := s.db.QueryRow(broadcastRoleIDByNameQuery, roleName).Scan(&roleID); err != nil {
+				if err == sql.ErrNoRows {
+					return fmt.Errorf("%w: role %q not found", ErrInvalidBroadcastTarget, roleName)
+				}
+				return err
+			}
+			broadcast.TargetType = BroadcastTargetRoles
+			broadcast.TargetIDs = []uuid.UUID{roleID}
+		}
+	} else if broadcast.TargetType == BroadcastTargetAllUsers {
+		broadcast.TargetAudience = BroadcastTargetAllUsers
+	} else {
+		// Role and project targets have no equivalent fixed audience
+		broadcast.TargetAudience = ""
+	}
+
+	var countQuery string
+	switch broadcast.TargetType {
+	case BroadcastTargetAllUsers:
+		broadcast.TargetIDs = nil
+		return nil
+	case BroadcastTargetRoles:
+		countQuery = broadcastCountRolesQuery
+	case BroadcastTargetProjects:
+		countQuery = broadcastCountProjectsQuery
+	default:
+		return fmt.Errorf("%w: unknown target type %q", ErrInvalidBroadcastTarget, broadcast.TargetType)
+	}
+
+	broadcast.TargetIDs = uniqueUUIDs(broadcast.TargetIDs)
+	if len(broadcast.TargetIDs) == 0 {
+		return fmt.Errorf("%w: %s target needs at least one ID", ErrInvalidBroadcastTarget, broadcast.TargetType)
+	}
+
+	idsJSON, err := uuidsJSON(broadcast.TargetIDs)
+	if err != nil {
+		return err
+	}
+	var found int
+	if err := s.db.QueryRow(countQuery, idsJSON).Scan(&found); err != nil {
+		return err
+	}
+	if found != len(broadcast.TargetIDs) {
+		return fmt.Errorf("%w: some targeted %s do not exist", ErrInvalidBroadcastTarget, broadcast.TargetType)
+	}
+
+	return nil
+}
+
+// parseBroadcastTargetIDs decodes the target_ids JSONB column
+func parseBroadcastTargetIDs(targetIDsJSON string) ([]uuid.UUID, error) {
+	var rawIDs []string
+	if err := ParseJSONArray(targetIDsJSON, &rawIDs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid broadcast target ID %q: %w", rawID, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// uniqueUUIDs drops repeated IDs, keeping the first occurrence of each
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -1,37 +1,55 @@
 package models
 
+// broadcastTargetsUserCondition matches broadcasts targeted at user $1, whose role names,
+// already expanded through the role hierarchy, are the JSON array $2
+const broadcastTargetsUserCondition = `(
+			bm.target_type = 'all_users'
+			OR (bm.target_type = 'roles' AND EXISTS (
+				SELECT 1 FROM roles r
+				WHERE bm.target_ids ? r.id::text
+				AND r.name IN (SELECT jsonb_array_elements_text($2::jsonb))
+			))
+			OR (bm.target_type = 'projects' AND EXISTS (
+				SELECT 1 FROM projects p
+				WHERE bm.target_ids ? p.id::text
+				AND (p.team_lead_id = $1 OR EXISTS (
+					SELECT 1 FROM project_team_members ptm
+					JOIN volunteers tv ON ptm.volunteer_id = tv.id
+					WHERE ptm.project_id = p.id AND tv.user_id = $1 AND ptm.status = 'active'
+				))
+			))
+		)`
+
 // Query constants for BroadcastService
 const (
 	broadcastCreateQuery = `
-		INSERT INTO broadcast_messages (id, title, content, author_id, target_audience, priority, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO broadcast_messages (id, title, content, author_id, target_audience, target_type, target_ids,
+			priority, expires_at, acknowledgment_required)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10)
 		RETURNING created_at, updated_at`
 
 	broadcastGetByIDQuery = `
-		SELECT id, title, content, author_id, target_audience, priority, expires_at, 
-		       created_at, updated_at, deleted_at
+		SELECT id, title, content, author_id, COALESCE(target_audience, ''), target_type, target_ids,
+		       priority, expires_at, acknowledgment_required, created_at, updated_at, deleted_at
 		FROM broadcast_messages WHERE id = $1 AND deleted_at IS NULL`
 
 	broadcastListQuery = `
 		SELECT 
-			bm.id, bm.title, bm.content, bm.author_id, bm.target_audience, bm.priority, 
-			bm.expires_at, bm.created_at, bm.updated_at, bm.deleted_at,
+			bm.id, bm.title, bm.content, bm.author_id, COALESCE(bm.target_audience, ''), bm.target_type,
+			bm.target_ids, bm.priority, bm.expires_at, bm.acknowledgment_required, bm.created_at, bm.updated_at, bm.deleted_at,
 			COALESCE(v.name, a.name, u.email) as author_name,
 			u.email as author_email,
-			CASE WHEN br.user_id IS NOT NULL THEN true ELSE false END as is_read
+			CASE WHEN br.user_id IS NOT NULL THEN true ELSE false END as is_read,
+			CASE WHEN ba.user_id IS NOT NULL THEN true ELSE false END as is_acknowledged
 		FROM broadcast_messages bm
 		JOIN users u ON bm.author_id = u.id
 		LEFT JOIN volunteers v ON u.id = v.user_id
 		LEFT JOIN admins a ON u.id = a.user_id
 		LEFT JOIN broadcast_reads br ON bm.id = br.broadcast_id AND br.user_id = $1
+		LEFT JOIN broadcast_acknowledgments ba ON bm.id = ba.broadcast_id AND ba.user_id = $1
 		WHERE bm.deleted_at IS NULL
 		AND (bm.expires_at IS NULL OR bm.expires_at > NOW())
-		AND (
-			bm.target_audience = 'all_users' OR
-			(bm.target_audience = 'volunteers_only' AND $2 = 'volunteer') OR
-			(bm.target_audience = 'admins_only' AND $2 = 'admin') OR
-			(bm.target_audience = 'team_leads_only' AND $2 = 'team_lead')
-		)
+		AND ` + broadcastTargetsUserCondition + `
 		ORDER BY 
 			CASE bm.priority 
 				WHEN 'urgent' THEN 1 
@@ -44,11 +62,12 @@ const (
 
 	broadcastListAllQuery = `
 		SELECT 
-			bm.id, bm.title, bm.content, bm.author_id, bm.target_audience, bm.priority, 
-			bm.expires_at, bm.created_at, bm.updated_at, bm.deleted_at,
+			bm.id, bm.title, bm.content, bm.author_id, COALESCE(bm.target_audience, ''), bm.target_type,
+			bm.target_ids, bm.priority, bm.expires_at, bm.acknowledgment_required, bm.created_at, bm.updated_at, bm.deleted_at,
 			COALESCE(v.name, a.name, u.email) as author_name,
 			u.email as author_email,
-			false as is_read
+			false as is_read,
+			false as is_acknowledged
 		FROM broadcast_messages bm
 		JOIN users u ON bm.author_id = u.id
 		LEFT JOIN volunteers v ON u.id = v.user_id
@@ -59,8 +78,8 @@ const (
 
 	broadcastUpdateQuery = `
 		UPDATE broadcast_messages 
-		SET title = $2, content = $3, target_audience = $4, priority = $5, 
-		    expires_at = $6, updated_at = CURRENT_TIMESTAMP
+		SET title = $2, content = $3, target_audience = NULLIF($4, ''), target_type = $5, target_ids = $6,
+		    priority = $7, expires_at = $8, acknowledgment_required = $9, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING updated_at`
 
@@ -81,28 +100,78 @@ const (
 		WHERE bm.deleted_at IS NULL
 		AND (bm.expires_at IS NULL OR bm.expires_at > NOW())
 		AND br.user_id IS NULL
-		AND (
-			bm.target_audience = 'all_users' OR
-			(bm.target_audience = 'volunteers_only' AND 'volunteer' = ANY($2)) OR
-			(bm.target_audience = 'admins_only' AND 'admin' = ANY($2)) OR
-			(bm.target_audience = 'team_leads_only' AND 'team_lead' = ANY($2))
-		)`
+		AND ` + broadcastTargetsUserCondition
 
 	broadcastGetStatsQuery = `
 		SELECT 
 			COUNT(*) as total_broadcasts,
 			COUNT(CASE WHEN br.user_id IS NULL THEN 1 END) as unread_broadcasts,
 			COUNT(CASE WHEN bm.priority = 'high' THEN 1 END) as high_priority_count,
-			COUNT(CASE WHEN bm.priority = 'urgent' THEN 1 END) as urgent_count
+			COUNT(CASE WHEN bm.priority = 'urgent' THEN 1 END) as urgent_count,
+			COUNT(CASE WHEN bm.acknowledgment_required THEN 1 END) as acknowledgment_required_count,
+			COUNT(CASE WHEN bm.acknowledgment_required AND ba.user_id IS NOT NULL THEN 1 END) as acknowledged_count
 		FROM broadcast_messages bm
 		LEFT JOIN broadcast_reads br ON bm.id = br.broadcast_id AND br.user_id = $1
+		LEFT JOIN broadcast_acknowledgments ba ON bm.id = ba.broadcast_id AND ba.user_id = $1
 		WHERE bm.deleted_at IS NULL
 		AND (bm.expires_at IS NULL OR bm.expires_at > NOW())
+		AND ` + broadcastTargetsUserCondition
+
+	broadcastAcknowledgeQuery = `
+		INSERT INTO broadcast_acknowledgments (user_id, broadcast_id, acknowledged_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, broadcast_id) DO NOTHING`
+
+	// Everyone a broadcast targets with when they read and acknowledged it
+	broadcastAcknowledgmentStatusQuery = `
+		WITH RECURSIVE effective_roles (user_id, role_id) AS (
+			SELECT user_id, role_id FROM user_roles
+			UNION
+			SELECT er.user_id, ri.inherits_role_id
+			FROM effective_roles er
+			JOIN role_inheritance ri ON er.role_id = ri.role_id
+		)
+		SELECT u.id, u.email, COALESCE(v.name, a.name, u.email) as name, br.read_at, ba.acknowledged_at
+		FROM broadcast_messages bm
+		CROSS JOIN users u
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		LEFT JOIN broadcast_reads br ON u.id = br.user_id AND br.broadcast_id = bm.id
+		LEFT JOIN broadcast_acknowledgments ba ON u.id = ba.user_id AND ba.broadcast_id = bm.id
+		WHERE bm.id = $1
 		AND (
-			bm.target_audience = 'all_users' OR
-			(bm.target_audience = 'volunteers_only' AND 'volunteer' = ANY($2)) OR
-			(bm.target_audience = 'admins_only' AND 'admin' = ANY($2)) OR
-			(bm.target_audience = 'team_leads_only' AND 'team_lead' = ANY($2))
+			bm.target_type = 'all_users'
+			OR (bm.target_type = 'roles' AND EXISTS (
+				SELECT 1 FROM effective_roles er
+				WHERE er.user_id = u.id AND bm.target_ids ? er.role_id::text
+			))
+			OR (bm.target_type = 'projects' AND EXISTS (
+				SELECT 1 FROM projects p
+				WHERE bm.target_ids ? p.id::text
+				AND (p.team_lead_id = u.id OR EXISTS (
+					SELECT 1 FROM project_team_members ptm
+					JOIN volunteers tv ON ptm.volunteer_id = tv.id
+					WHERE ptm.project_id = p.id AND tv.user_id = u.id AND ptm.status = 'active'
+				))
+			))
+		)
+		ORDER BY ba.acknowledged_at DESC NULLS LAST, name`
+
+	broadcastCountRolesQuery = `
+		SELECT COUNT(*) FROM roles
+		WHERE id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)`
+
+	broadcastCountProjectsQuery = `
+		SELECT COUNT(*) FROM projects
+		WHERE id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid) AND deleted_at IS NULL`
+
+	broadcastRoleIDByNameQuery = `SELECT id FROM roles WHERE name = $1`
+
+	broadcastIsTargetedAtQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM broadcast_messages bm
+			WHERE bm.id = $3 AND bm.deleted_at IS NULL
+			AND ` + broadcastTargetsUserCondition + `
 		)`
 
 	broadcastIsAuthorQuery = `
@@ -2,8 +2,14 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 
+	"civicweave/backend/utils"
+
 	"github.com/google/uuid"
 )
 
@@ -30,6 +36,26 @@ const (
 	RecipientStatusFailed    RecipientStatus = "failed"
 )
 
+// CampaignVariant identifies which subject line a recipient was sent
+type CampaignVariant string
+
+const (
+	CampaignVariantA CampaignVariant = "A"
+	CampaignVariantB CampaignVariant = "B"
+)
+
+// A/B test bounds for the share of recipients getting subject B
+const (
+	MinABTestPercent = 1
+	MaxABTestPercent = 50
+)
+
+// Campaign validation errors
+var (
+	ErrInvalidABTestPercent = fmt.Errorf("ab_test_percent must be between %d and %d", MinABTestPercent, MaxABTestPercent)
+	ErrMissingSubjectB      = fmt.Errorf("email_subject_b is required when A/B testing")
+)
+
 // Campaign represents an email campaign
 type Campaign struct {
 	ID              uuid.UUID      `json:"id" db:"id"`
@@ -39,6 +65,8 @@ type Campaign struct {
 	Status          CampaignStatus `json:"status" db:"status"`
 	EmailSubject    string         `json:"email_subject" db:"email_subject"`
 	EmailBody       string         `json:"email_body" db:"email_body"`
+	EmailSubjectB   *string        `json:"email_subject_b,omitempty" db:"email_subject_b"`
+	ABTestPercent   int            `json:"ab_test_percent" db:"ab_test_percent"` // Share of recipients getting subject B; 0 disables the test
 	CreatedByUserID uuid.UUID      `json:"created_by_user_id" db:"created_by_user_id"`
 	ScheduledAt     *time.Time     `json:"scheduled_at" db:"scheduled_at"`
 	SentAt          *time.Time     `json:"sent_at" db:"sent_at"`
@@ -55,9 +83,20 @@ type CampaignRecipient struct {
 	OpenedAt   *time.Time      `json:"opened_at" db:"opened_at"`
 	ClickedAt  *time.Time      `json:"clicked_at" db:"clicked_at"`
 	Status     RecipientStatus `json:"status" db:"status"`
+	Variant    CampaignVariant `json:"variant" db:"variant"`
+	Error      *string         `json:"error,omitempty" db:"error_message"`
 	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
 }
 
+// CampaignDelivery is a pending campaign recipient with the address to send to
+type CampaignDelivery struct {
+	RecipientID   uuid.UUID
+	UserID        uuid.UUID
+	Email         string
+	TrackingToken string
+	Variant       CampaignVariant
+}
+
 // CampaignWithStats represents a campaign with delivery statistics
 type CampaignWithStats struct {
 	Campaign
@@ -67,9 +106,25 @@ type CampaignWithStats struct {
 	OpenedCount     int     `json:"opened_count"`
 	ClickedCount    int     `json:"clicked_count"`
 	FailedCount     int     `json:"failed_count"`
+	TotalOpens      int     `json:"total_opens"`  // Including repeat opens
+	TotalClicks     int     `json:"total_clicks"` // Including repeat clicks
 	DeliveryRate    float64 `json:"delivery_rate"`
 	OpenRate        float64 `json:"open_rate"`
 	ClickRate       float64 `json:"click_rate"`
+	// Variants breaks engagement down by subject line; only set for A/B tests
+	Variants []CampaignVariantStats `json:"variants,omitempty"`
+}
+
+// CampaignVariantStats represents engagement with one subject line of an A/B test
+type CampaignVariantStats struct {
+	Variant      CampaignVariant `json:"variant"`
+	Subject      string          `json:"subject"`
+	Recipients   int             `json:"recipients"`
+	SentCount    int             `json:"sent_count"`
+	OpenedCount  int             `json:"opened_count"`
+	ClickedCount int             `json:"clicked_count"`
+	OpenRate     float64         `json:"open_rate"`
+	ClickRate    float64         `json:"click_rate"`
 }
 
 // CampaignService handles campaign operations
@@ -77,6 +132,34 @@ type CampaignService struct {
 	db *sql.DB
 }
 
+// IsABTest reports whether the campaign splits recipients between two subject lines
+func (c *Campaign) IsABTest() bool {
+	return c.ABTestPercent > 0
+}
+
+// SubjectFor returns the subject line sent to recipients of the given variant
+func (c *Campaign) SubjectFor(variant CampaignVariant) string {
+	if variant == CampaignVariantB && c.IsABTest() && c.EmailSubjectB != nil {
+		return *c.EmailSubjectB
+	}
+	return c.EmailSubject
+}
+
+// ValidateABTest checks the campaign's A/B test settings. A zero ABTestPercent
+// disables the test.
+func (c *Campaign) ValidateABTest() error {
+	if c.ABTestPercent == 0 {
+		return nil
+	}
+	if c.ABTestPercent < MinABTestPercent || c.ABTestPercent > MaxABTestPercent {
+		return ErrInvalidABTestPercent
+	}
+	if c.EmailSubjectB == nil || strings.TrimSpace(*c.EmailSubjectB) == "" {
+		return ErrMissingSubjectB
+	}
+	return nil
+}
+
 // NewCampaignService creates a new campaign service
 func NewCampaignService(db *sql.DB) *CampaignService {
 	return &CampaignService{db: db}
@@ -85,8 +168,9 @@ func NewCampaignService(db *sql.DB) *CampaignService {
 // CreateCampaign creates a new campaign
 func (s *CampaignService) CreateCampaign(campaign *Campaign) error {
 	query := `
-		INSERT INTO campaigns (id, title, description, target_roles, status, email_subject, email_body, created_by_user_id, scheduled_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO campaigns (id, title, description, target_roles, status, email_subject, email_body,
+		                       email_subject_b, ab_test_percent, created_by_user_id, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at`
 
 	campaign.ID = uuid.New()
@@ -97,7 +181,7 @@ func (s *CampaignService) CreateCampaign(campaign *Campaign) error {
 
 	return s.db.QueryRow(query, campaign.ID, campaign.Title, campaign.Description,
 		targetRolesJSON, campaign.Status, campaign.EmailSubject, campaign.EmailBody,
-		campaign.CreatedByUserID, campaign.ScheduledAt).
+		campaign.EmailSubjectB, campaign.ABTestPercent, campaign.CreatedByUserID, campaign.ScheduledAt).
 		Scan(&campaign.CreatedAt, &campaign.UpdatedAt)
 }
 
@@ -107,12 +191,12 @@ func (s *CampaignService) GetCampaignByID(id uuid.UUID) (*Campaign, error) {
 	var targetRolesJSON string
 	query := `
 		SELECT id, title, description, target_roles, status, email_subject, email_body, 
-		       created_by_user_id, scheduled_at, sent_at, created_at, updated_at
+		       email_subject_b, ab_test_percent, created_by_user_id, scheduled_at, sent_at, created_at, updated_at
 		FROM campaigns WHERE id = $1`
 
 	err := s.db.QueryRow(query, id).Scan(&campaign.ID, &campaign.Title, &campaign.Description,
 		&targetRolesJSON, &campaign.Status, &campaign.EmailSubject, &campaign.EmailBody,
-		&campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
+		&campaign.EmailSubjectB, &campaign.ABTestPercent, &campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
 		&campaign.CreatedAt, &campaign.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -133,7 +217,7 @@ func (s *CampaignService) GetCampaignByID(id uuid.UUID) (*Campaign, error) {
 func (s *CampaignService) ListCampaigns(limit, offset int, status *CampaignStatus, createdByUserID *uuid.UUID) ([]Campaign, error) {
 	query := `
 		SELECT id, title, description, target_roles, status, email_subject, email_body, 
-		       created_by_user_id, scheduled_at, sent_at, created_at, updated_at
+		       email_subject_b, ab_test_percent, created_by_user_id, scheduled_at, sent_at, created_at, updated_at
 		FROM campaigns
 		WHERE ($1 IS NULL OR status = $1)
 		AND ($2 IS NULL OR created_by_user_id = $2)
@@ -152,7 +236,7 @@ func (s *CampaignService) ListCampaigns(limit, offset int, status *CampaignStatu
 		var targetRolesJSON string
 		err := rows.Scan(&campaign.ID, &campaign.Title, &campaign.Description,
 			&targetRolesJSON, &campaign.Status, &campaign.EmailSubject, &campaign.EmailBody,
-			&campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
+			&campaign.EmailSubjectB, &campaign.ABTestPercent, &campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
 			&campaign.CreatedAt, &campaign.UpdatedAt)
 		if err != nil {
 			return nil, err
@@ -175,7 +259,7 @@ func (s *CampaignService) UpdateCampaign(campaign *Campaign) error {
 		UPDATE campaigns 
 		SET title = $2, description = $3, target_roles = $4, status = $5, 
 		    email_subject = $6, email_body = $7, scheduled_at = $8, sent_at = $9,
-		    updated_at = CURRENT_TIMESTAMP
+		    email_subject_b = $10, ab_test_percent = $11, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 		RETURNING updated_at`
 
@@ -186,7 +270,7 @@ func (s *CampaignService) UpdateCampaign(campaign *Campaign) error {
 
 	return s.db.QueryRow(query, campaign.ID, campaign.Title, campaign.Description,
 		targetRolesJSON, campaign.Status, campaign.EmailSubject, campaign.EmailBody,
-		campaign.ScheduledAt, campaign.SentAt).Scan(&campaign.UpdatedAt)
+		campaign.ScheduledAt, campaign.SentAt, campaign.EmailSubjectB, campaign.ABTestPercent).Scan(&campaign.UpdatedAt)
 }
 
 // DeleteCampaign deletes a campaign
@@ -199,7 +283,7 @@ func (s *CampaignService) DeleteCampaign(id uuid.UUID) error {
 // GetCampaignRecipients retrieves all recipients for a campaign
 func (s *CampaignService) GetCampaignRecipients(campaignID uuid.UUID) ([]CampaignRecipient, error) {
 	query := `
-		SELECT id, campaign_id, user_id, sent_at, opened_at, clicked_at, status, created_at
+		SELECT id, campaign_id, user_id, sent_at, opened_at, clicked_at, status, variant, error_message, created_at
 		FROM campaign_recipients 
 		WHERE campaign_id = $1
 		ORDER BY created_at`
@@ -215,7 +299,7 @@ func (s *CampaignService) GetCampaignRecipients(campaignID uuid.UUID) ([]Campaig
 		var recipient CampaignRecipient
 		err := rows.Scan(&recipient.ID, &recipient.CampaignID, &recipient.UserID,
 			&recipient.SentAt, &recipient.OpenedAt, &recipient.ClickedAt,
-			&recipient.Status, &recipient.CreatedAt)
+			&recipient.Status, &recipient.Variant, &recipient.Error, &recipient.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -258,28 +342,96 @@ func (s *CampaignService) GetCampaignStats(campaignID uuid.UUID) (*CampaignWithS
 		return nil, err
 	}
 
-	// Calculate rates
+	// Get raw engagement counts from tracking events
+	eventQuery := `
+		SELECT 
+			COUNT(CASE WHEN event_type = 'open' THEN 1 END) as total_opens,
+			COUNT(CASE WHEN event_type = 'click' THEN 1 END) as total_clicks
+		FROM campaign_events 
+		WHERE campaign_id = $1`
+
+	err = s.db.QueryRow(eventQuery, campaignID).Scan(&stats.TotalOpens, &stats.TotalClicks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate rates. Delivery confirmations aren't always reported, so open and
+	// click rates are unique engagements per email sent.
 	if stats.TotalRecipients > 0 {
 		stats.DeliveryRate = float64(stats.DeliveredCount) / float64(stats.TotalRecipients)
-		if stats.DeliveredCount > 0 {
-			stats.OpenRate = float64(stats.OpenedCount) / float64(stats.DeliveredCount)
-			if stats.OpenedCount > 0 {
-				stats.ClickRate = float64(stats.ClickedCount) / float64(stats.OpenedCount)
-			}
+	}
+	if stats.SentCount > 0 {
+		stats.OpenRate = float64(stats.OpenedCount) / float64(stats.SentCount)
+		stats.ClickRate = float64(stats.ClickedCount) / float64(stats.SentCount)
+	}
+
+	if campaign.IsABTest() {
+		stats.Variants, err = s.getVariantStats(campaign)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return stats, nil
 }
 
+// getVariantStats retrieves per-subject engagement for an A/B test campaign
+func (s *CampaignService) getVariantStats(campaign *Campaign) ([]CampaignVariantStats, error) {
+	query := `
+		SELECT 
+			variant,
+			COUNT(*) as recipients,
+			COUNT(CASE WHEN status IN ('sent', 'delivered', 'opened', 'clicked') THEN 1 END) as sent_count,
+			COUNT(CASE WHEN status IN ('opened', 'clicked') THEN 1 END) as opened_count,
+			COUNT(CASE WHEN status = 'clicked' THEN 1 END) as clicked_count
+		FROM campaign_recipients 
+		WHERE campaign_id = $1
+		GROUP BY variant`
+
+	rows, err := s.db.Query(query, campaign.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Report both variants, even before anyone has been sent either
+	variants := []CampaignVariantStats{
+		{Variant: CampaignVariantA, Subject: campaign.SubjectFor(CampaignVariantA)},
+		{Variant: CampaignVariantB, Subject: campaign.SubjectFor(CampaignVariantB)},
+	}
+	for rows.Next() {
+		var variant CampaignVariant
+		var recipients, sent, opened, clicked int
+		if err := rows.Scan(&variant, &recipients, &sent, &opened, &clicked); err != nil {
+			return nil, err
+		}
+
+		for i := range variants {
+			if variants[i].Variant != variant {
+				continue
+			}
+			variants[i].Recipients = recipients
+			variants[i].SentCount = sent
+			variants[i].OpenedCount = opened
+			variants[i].ClickedCount = clicked
+			if sent > 0 {
+				variants[i].OpenRate = float64(opened) / float64(sent)
+				variants[i].ClickRate = float64(clicked) / float64(sent)
+			}
+		}
+	}
+
+	return variants, rows.Err()
+}
+
 // AddCampaignRecipient adds a recipient to a campaign
 func (s *CampaignService) AddCampaignRecipient(campaignID, userID uuid.UUID) error {
 	query := `
-		INSERT INTO campaign_recipients (id, campaign_id, user_id, status)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO campaign_recipients (id, campaign_id, user_id, status, tracking_token)
+		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (campaign_id, user_id) DO NOTHING`
 
-	_, err := s.db.Exec(query, uuid.New(), campaignID, userID, RecipientStatusPending)
+	_, err := s.db.Exec(query, uuid.New(), campaignID, userID, RecipientStatusPending, utils.GenerateRandomToken())
 	return err
 }
 
@@ -340,29 +492,42 @@ func (s *CampaignService) MarkRecipientAsClicked(recipientID uuid.UUID) error {
 
 // GetTargetUsersForCampaign retrieves users who match the campaign's target roles
 func (s *CampaignService) GetTargetUsersForCampaign(targetRoles []string) ([]User, error) {
-	if len(targetRoles) == 0 {
+	return s.ResolveRecipients(&Campaign{TargetRoles: targetRoles})
+}
+
+// ResolveRecipients expands a campaign's target roles into the verified users
+// holding any of them, leaving out anyone on the suppression list. Each user
+// appears once, however many roles match.
+func (s *CampaignService) ResolveRecipients(campaign *Campaign) ([]User, error) {
+	if len(campaign.TargetRoles) == 0 {
 		return []User{}, nil
 	}
 
 	query := `
-		SELECT DISTINCT u.id, u.email, u.password_hash, u.email_verified, u.created_at, u.updated_at
+		SELECT DISTINCT u.id, u.email, u.email_verified, u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN user_roles ur ON u.id = ur.user_id
 		INNER JOIN roles r ON ur.role_id = r.id
-		WHERE r.name = ANY($1)
+		WHERE r.name IN (SELECT jsonb_array_elements_text($1::jsonb))
 		AND u.email_verified = true
+		AND ` + campaignSuppressedCondition + `
 		ORDER BY u.email`
 
-	rows, err := s.db.Query(query, targetRoles)
+	targetRolesJSON, err := ToJSONArray(campaign.TargetRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(query, targetRolesJSON)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var users []User
+	users := []User{}
 	for rows.Next() {
 		var user User
-		err := rows.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.EmailVerified,
+		err := rows.Scan(&user.ID, &user.Email, &user.EmailVerified,
 			&user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			return nil, err
@@ -370,7 +535,216 @@ func (s *CampaignService) GetTargetUsersForCampaign(targetRoles []string) ([]Use
 		users = append(users, user)
 	}
 
-	return users, nil
+	return users, rows.Err()
+}
+
+// BeginSending moves a draft or scheduled campaign to sending. It returns
+// sql.ErrNoRows if the campaign doesn't exist or has already been sent, so
+// concurrent send requests can't both go through.
+func (s *CampaignService) BeginSending(campaignID uuid.UUID) error {
+	query := `
+		UPDATE campaigns 
+		SET status = 'sending', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ('draft', 'scheduled')`
+
+	result, err := s.db.Exec(query, campaignID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// FinishSending records the outcome of a send: sent (with sent_at) or failed
+func (s *CampaignService) FinishSending(campaignID uuid.UUID, status CampaignStatus) error {
+	query := `
+		UPDATE campaigns 
+		SET status = $2::varchar,
+		    sent_at = CASE WHEN $2::varchar = 'sent' THEN CURRENT_TIMESTAMP ELSE sent_at END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'sending'`
+
+	_, err := s.db.Exec(query, campaignID, status)
+	return err
+}
+
+// TouchSending records progress on a campaign being sent, so ClaimStaleSending
+// leaves it alone
+func (s *CampaignService) TouchSending(campaignID uuid.UUID) error {
+	query := `
+		UPDATE campaigns SET updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'sending'`
+
+	_, err := s.db.Exec(query, campaignID)
+	return err
+}
+
+// ClaimStaleSending returns campaigns left in the sending state with no progress since
+// before, e.g. because the server stopped mid-send. Claiming bumps their updated_at,
+// so another instance sweeping at the same time doesn't claim them too.
+func (s *CampaignService) ClaimStaleSending(before time.Time) ([]Campaign, error) {
+	query := `
+		UPDATE campaigns SET updated_at = CURRENT_TIMESTAMP
+		WHERE status = 'sending' AND updated_at < $1
+		RETURNING id, title, description, target_roles, status, email_subject, email_body,
+		          email_subject_b, ab_test_percent, created_by_user_id, scheduled_at, sent_at, created_at, updated_at`
+
+	rows, err := s.db.Query(query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var campaigns []Campaign
+	for rows.Next() {
+		var campaign Campaign
+		var targetRolesJSON string
+		err := rows.Scan(&campaign.ID, &campaign.Title, &campaign.Description,
+			&targetRolesJSON, &campaign.Status, &campaign.EmailSubject, &campaign.EmailBody,
+			&campaign.EmailSubjectB, &campaign.ABTestPercent, &campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
+			&campaign.CreatedAt, &campaign.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := ParseJSONArray(targetRolesJSON, &campaign.TargetRoles); err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// CountRecipients returns how many recipients have been recorded for a campaign
+func (s *CampaignService) CountRecipients(campaignID uuid.UUID) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM campaign_recipients WHERE campaign_id = $1`, campaignID).Scan(&count)
+	return count, err
+}
+
+// AddCampaignRecipients adds users to a campaign as pending recipients, each with
+// its own tracking token, skipping any already added. For A/B tests a random
+// ABTestPercent share of the users is assigned subject B and the rest subject A.
+func (s *CampaignService) AddCampaignRecipients(campaign *Campaign, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO campaign_recipients (campaign_id, user_id, status, tracking_token, variant)
+		SELECT $1, r.user_id, $3, r.tracking_token, r.variant
+		FROM jsonb_to_recordset($2::jsonb) AS r(user_id uuid, tracking_token text, variant text)
+		ON CONFLICT (campaign_id, user_id) DO NOTHING`
+
+	// Shuffle so the B share is a random sample rather than, say, the first emails alphabetically
+	shuffled := make([]uuid.UUID, len(userIDs))
+	copy(shuffled, userIDs)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	variantBCount := 0
+	if campaign.IsABTest() {
+		variantBCount = (len(shuffled)*campaign.ABTestPercent + 50) / 100
+	}
+
+	type newRecipient struct {
+		UserID        uuid.UUID       `json:"user_id"`
+		TrackingToken string          `json:"tracking_token"`
+		Variant       CampaignVariant `json:"variant"`
+	}
+	recipients := make([]newRecipient, len(shuffled))
+	for i, userID := range shuffled {
+		variant := CampaignVariantA
+		if i < variantBCount {
+			variant = CampaignVariantB
+		}
+		recipients[i] = newRecipient{UserID: userID, TrackingToken: utils.GenerateRandomToken(), Variant: variant}
+	}
+	recipientsJSON, err := json.Marshal(recipients)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(query, campaign.ID, string(recipientsJSON), RecipientStatusPending)
+	return err
+}
+
+// GetPendingDeliveries retrieves a campaign's recipients that haven't been sent to
+// yet. Recipients who unsubscribed after being added are skipped.
+func (s *CampaignService) GetPendingDeliveries(campaignID uuid.UUID) ([]CampaignDelivery, error) {
+	query := `
+		SELECT cr.id, cr.user_id, u.email, COALESCE(cr.tracking_token, ''), cr.variant
+		FROM campaign_recipients cr
+		INNER JOIN users u ON cr.user_id = u.id
+		WHERE cr.campaign_id = $1 AND cr.status = 'pending'
+		AND ` + campaignSuppressedCondition + `
+		ORDER BY u.email`
+
+	rows, err := s.db.Query(query, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []CampaignDelivery{}
+	for rows.Next() {
+		var delivery CampaignDelivery
+		if err := rows.Scan(&delivery.RecipientID, &delivery.UserID, &delivery.Email, &delivery.TrackingToken, &delivery.Variant); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// MarkRecipientsSent marks a batch of recipients as sent
+func (s *CampaignService) MarkRecipientsSent(recipientIDs []uuid.UUID) error {
+	query := `
+		UPDATE campaign_recipients 
+		SET status = 'sent', sent_at = CURRENT_TIMESTAMP, error_message = NULL
+		WHERE id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)`
+
+	idsJSON, err := uuidsJSON(recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(query, idsJSON)
+	return err
+}
+
+// MarkRecipientsFailed marks a batch of recipients as failed with the reason
+func (s *CampaignService) MarkRecipientsFailed(recipientIDs []uuid.UUID, reason string) error {
+	query := `
+		UPDATE campaign_recipients 
+		SET status = 'failed', error_message = $2
+		WHERE id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)`
+
+	idsJSON, err := uuidsJSON(recipientIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(query, idsJSON, reason)
+	return err
+}
+
+// uuidsJSON encodes IDs as a JSON array for batch queries
+func uuidsJSON(uuids []uuid.UUID) (string, error) {
+	ids := make([]string, len(uuids))
+	for i, id := range uuids {
+		ids[i] = id.String()
+	}
+	return ToJSONArray(ids)
 }
 
 // ScheduleCampaign schedules a campaign for future sending
@@ -412,7 +786,7 @@ func (s *CampaignService) MarkCampaignAsSent(campaignID uuid.UUID) error {
 func (s *CampaignService) GetScheduledCampaigns() ([]Campaign, error) {
 	query := `
 		SELECT id, title, description, target_roles, status, email_subject, email_body, 
-		       created_by_user_id, scheduled_at, sent_at, created_at, updated_at
+		       email_subject_b, ab_test_percent, created_by_user_id, scheduled_at, sent_at, created_at, updated_at
 		FROM campaigns
 		WHERE status = 'scheduled' AND scheduled_at <= CURRENT_TIMESTAMP
 		ORDER BY scheduled_at`
@@ -429,7 +803,7 @@ func (s *CampaignService) GetScheduledCampaigns() ([]Campaign, error) {
 		var targetRolesJSON string
 		err := rows.Scan(&campaign.ID, &campaign.Title, &campaign.Description,
 			&targetRolesJSON, &campaign.Status, &campaign.EmailSubject, &campaign.EmailBody,
-			&campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
+			&campaign.EmailSubjectB, &campaign.ABTestPercent, &campaign.CreatedByUserID, &campaign.ScheduledAt, &campaign.SentAt,
 			&campaign.CreatedAt, &campaign.UpdatedAt)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CampaignEventType represents a kind of campaign engagement
+type CampaignEventType string
+
+const (
+	CampaignEventOpen  CampaignEventType = "open"
+	CampaignEventClick CampaignEventType = "click"
+)
+
+// CampaignTrackingTokenVariable is the per-recipient Mailgun variable carrying the
+// recipient's tracking token. Tracked links reference it as %recipient.tracking_token%.
+const CampaignTrackingTokenVariable = "tracking_token"
+
+// campaignTrackingTokenPlaceholder is substituted with each recipient's token by Mailgun
+const campaignTrackingTokenPlaceholder = "%recipient." + CampaignTrackingTokenVariable + "%"
+
+// campaignLinkPattern matches the links in a campaign body that get click tracking
+var campaignLinkPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// findCampaignLinks returns the start and end of each link in body. Trailing
+// punctuation is left out so a link ending a sentence stays intact.
+func findCampaignLinks(body string) [][]int {
+	matches := campaignLinkPattern.FindAllStringIndex(body, -1)
+	for _, match := range matches {
+		for match[1] > match[0] && strings.ContainsRune(".,;:!?)", rune(body[match[1]-1])) {
+			match[1]--
+		}
+	}
+	return matches
+}
+
+// CampaignLinks returns the distinct links in a campaign body
+func CampaignLinks(body string) []string {
+	seen := make(map[string]bool)
+	links := []string{}
+	for _, match := range findCampaignLinks(body) {
+		link := body[match[0]:match[1]]
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// trackedLink returns the redirect URL that records a click before sending the reader on to link
+func trackedLink(trackingBaseURL, link string) string {
+	return fmt.Sprintf("%s/api/track/click/%s?url=%s", trackingBaseURL, campaignTrackingTokenPlaceholder, url.QueryEscape(link))
+}
+
+// CampaignUnsubscribeLink returns the per-recipient unsubscribe URL, containing
+// Mailgun's token placeholder
+func CampaignUnsubscribeLink(trackingBaseURL string) string {
+	return fmt.Sprintf("%s/api/campaigns/unsubscribe/%s", trackingBaseURL, campaignTrackingTokenPlaceholder)
+}
+
+// TrackedEmailContent renders a campaign's body for sending with every link
+// rewritten to go through the click redirect. The HTML fragment also carries the
+// open-tracking pixel. Both contain Mailgun's per-recipient token placeholder.
+func (s *CampaignService) TrackedEmailContent(campaign *Campaign, trackingBaseURL string) (htmlBody, textBody string) {
+	body := campaign.EmailBody
+
+	var htmlBuilder, textBuilder strings.Builder
+	last := 0
+	for _, match := range findCampaignLinks(body) {
+		link := body[match[0]:match[1]]
+		tracked := trackedLink(trackingBaseURL, link)
+
+		htmlBuilder.WriteString(html.EscapeString(body[last:match[0]]))
+		htmlBuilder.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(tracked), html.EscapeString(link)))
+
+		textBuilder.WriteString(body[last:match[0]])
+		textBuilder.WriteString(tracked)
+
+		last = match[1]
+	}
+	htmlBuilder.WriteString(html.EscapeString(body[last:]))
+	textBuilder.WriteString(body[last:])
+
+	pixel := fmt.Sprintf(`<img src="%s/api/track/open/%s" width="1" height="1" alt="" style="display:none">`,
+		trackingBaseURL, campaignTrackingTokenPlaceholder)
+
+	htmlBody = strings.ReplaceAll(htmlBuilder.String(), "\n", "<br>\n") + "\n" + pixel
+	return htmlBody, textBuilder.String()
+}
+
+// RecordOpen records that the recipient with the given tracking token opened the
+// campaign email. Unknown tokens are ignored and reported as false.
+func (s *CampaignService) RecordOpen(trackingToken string) (bool, error) {
+	recipientID, campaignID, _, err := s.getRecipientByToken(trackingToken)
+	if err != nil || recipientID == nil {
+		return false, err
+	}
+
+	if err := s.recordEvent(*campaignID, *recipientID, CampaignEventOpen, nil); err != nil {
+		return false, err
+	}
+
+	return true, s.MarkRecipientAsOpened(*recipientID)
+}
+
+// RecordClick records that the recipient with the given tracking token followed
+// link, and returns link if it is one of the campaign's own links. It returns an
+// empty string for unknown tokens and for links the campaign doesn't contain, so
+// the redirect can't be used to send people to arbitrary sites.
+func (s *CampaignService) RecordClick(trackingToken, link string) (string, error) {
+	recipientID, campaignID, body, err := s.getRecipientByToken(trackingToken)
+	if err != nil || recipientID == nil {
+		return "", err
+	}
+
+	known := false
+	for _, campaignLink := range CampaignLinks(body) {
+		if campaignLink == link {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return "", nil
+	}
+
+	if err := s.recordEvent(*campaignID, *recipientID, CampaignEventClick, &link); err != nil {
+		return "", err
+	}
+
+	return link, s.MarkRecipientAsClicked(*recipientID)
+}
+
+// getRecipientByToken looks up a recipient, its campaign and the campaign body by
+// tracking token. It returns nil IDs if the token is unknown.
+func (s *CampaignService) getRecipientByToken(trackingToken string) (*uuid.UUID, *uuid.UUID, string, error) {
+	query := `
+		SELECT cr.id, cr.campaign_id, c.email_body
+		FROM campaign_recipients cr
+		INNER JOIN campaigns c ON cr.campaign_id = c.id
+		WHERE cr.tracking_token = $1`
+
+	var recipientID, campaignID uuid.UUID
+	var body string
+	err := s.db.QueryRow(query, trackingToken).Scan(&recipientID, &campaignID, &body)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, "", nil
+		}
+		return nil, nil, "", err
+	}
+
+	return &recipientID, &campaignID, body, nil
+}
+
+// recordEvent stores a single engagement event
+func (s *CampaignService) recordEvent(campaignID, recipientID uuid.UUID, eventType CampaignEventType, link *string) error {
+	query := `
+		INSERT INTO campaign_events (campaign_id, recipient_id, event_type, url)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := s.db.Exec(query, campaignID, recipientID, eventType, link)
+	return err
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignUnsubscribe represents an entry on the campaign suppression list.
+// Suppressed users and addresses are left out of every future campaign.
+type CampaignUnsubscribe struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	UserID        *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Email         string     `json:"email" db:"email"`
+	CampaignID    *uuid.UUID `json:"campaign_id,omitempty" db:"campaign_id"`
+	CampaignTitle *string    `json:"campaign_title,omitempty" db:"campaign_title"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// campaignSuppressedCondition excludes users on the suppression list, by account or by address.
+// It expects the users table aliased as u.
+const campaignSuppressedCondition = `NOT EXISTS (
+			SELECT 1 FROM campaign_unsubscribes cu
+			WHERE cu.user_id = u.id OR LOWER(cu.email) = LOWER(u.email)
+		)`
+
+// UnsubscribeByToken adds the recipient with the given tracking token to the
+// suppression list. Unsubscribing again is harmless and returns the existing
+// entry. It returns nil, nil if the token is unknown.
+func (s *CampaignService) UnsubscribeByToken(trackingToken string) (*CampaignUnsubscribe, error) {
+	query := `
+		INSERT INTO campaign_unsubscribes (user_id, email, campaign_id)
+		SELECT cr.user_id, u.email, cr.campaign_id
+		FROM campaign_recipients cr
+		INNER JOIN users u ON cr.user_id = u.id
+		WHERE cr.tracking_token = $1
+		ON CONFLICT (LOWER(email)) DO UPDATE SET user_id = COALESCE(campaign_unsubscribes.user_id, EXCLUDED.user_id)
+		RETURNING id, user_id, email, campaign_id, created_at`
+
+	unsubscribe := &CampaignUnsubscribe{}
+	err := s.db.QueryRow(query, trackingToken).Scan(
+		&unsubscribe.ID, &unsubscribe.UserID, &unsubscribe.Email, &unsubscribe.CampaignID, &unsubscribe.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return unsubscribe, nil
+}
+
+// ListUnsubscribes retrieves the suppression list, newest first, with its total size
+func (s *CampaignService) ListUnsubscribes(limit, offset int) ([]CampaignUnsubscribe, int, error) {
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM campaign_unsubscribes`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT cu.id, cu.user_id, cu.email, cu.campaign_id, c.title as campaign_title, cu.created_at
+		FROM campaign_unsubscribes cu
+		LEFT JOIN campaigns c ON cu.campaign_id = c.id
+		ORDER BY cu.created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := s.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	unsubscribes := []CampaignUnsubscribe{}
+	for rows.Next() {
+		var unsubscribe CampaignUnsubscribe
+		err := rows.Scan(&unsubscribe.ID, &unsubscribe.UserID, &unsubscribe.Email,
+			&unsubscribe.CampaignID, &unsubscribe.CampaignTitle, &unsubscribe.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+
+	return unsubscribes, total, rows.Err()
+}
+
+// Resubscribe removes an entry from the suppression list so its user receives
+// campaigns again. It returns sql.ErrNoRows if the entry doesn't exist.
+func (s *CampaignService) Resubscribe(unsubscribeID uuid.UUID) error {
+	result, err := s.db.Exec(`DELETE FROM campaign_unsubscribes WHERE id = $1`, unsubscribeID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
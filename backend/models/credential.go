@@ -0,0 +1,337 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CredentialStatus represents where a credential is in verification
+type CredentialStatus string
+
+const (
+	CredentialStatusPending  CredentialStatus = "pending"
+	CredentialStatusVerified CredentialStatus = "verified"
+	CredentialStatusRejected CredentialStatus = "rejected"
+)
+
+// Credential errors
+var (
+	ErrInvalidCredentialType = fmt.Errorf("credential type must be 2-50 lowercase letters, digits or underscores")
+	ErrCredentialNotPending  = fmt.Errorf("credential has already been reviewed")
+)
+
+// credentialTypePattern matches normalized credential types such as "first_aid"
+var credentialTypePattern = regexp.MustCompile(`^[a-z0-9_]{2,50}$`)
+
+// VolunteerCredential represents a certification or check a volunteer has uploaded
+// proof of, e.g. a first-aid certificate or background check
+type VolunteerCredential struct {
+	ID               uuid.UUID        `json:"id" db:"id"`
+	VolunteerID      uuid.UUID        `json:"volunteer_id" db:"volunteer_id"`
+	CredentialType   string           `json:"credential_type" db:"credential_type"`
+	Issuer           *string          `json:"issuer,omitempty" db:"issuer"`
+	IssuedAt         *time.Time       `json:"issued_at,omitempty" db:"issued_at"`
+	ExpiresAt        *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+	DocumentKey      string           `json:"-" db:"document_key"`
+	DocumentName     string           `json:"document_name" db:"document_name"`
+	MimeType         string           `json:"mime_type" db:"mime_type"`
+	FileSize         int64            `json:"file_size" db:"file_size"`
+	Status           CredentialStatus `json:"status" db:"status"`
+	ReviewNote       *string          `json:"review_note,omitempty" db:"review_note"`
+	ReviewedByUserID *uuid.UUID       `json:"reviewed_by_user_id,omitempty" db:"reviewed_by_user_id"`
+	ReviewedAt       *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at" db:"updated_at"`
+	IsValid          bool             `json:"is_valid"`                 // Verified and not expired
+	VolunteerName    string           `json:"volunteer_name,omitempty"` // Only set when listing for review
+}
+
+// ValidOn reports whether the credential satisfies a requirement on the given day:
+// it must be verified and expire no earlier than that day
+func (c *VolunteerCredential) ValidOn(day time.Time) bool {
+	if c.Status != CredentialStatusVerified {
+		return false
+	}
+	if c.ExpiresAt == nil {
+		return true
+	}
+	y, m, d := day.Date()
+	return !c.ExpiresAt.Before(time.Date(y, m, d, 0, 0, 0, 0, c.ExpiresAt.Location()))
+}
+
+// NormalizeCredentialType lowercases a credential type and joins words with underscores,
+// so "First Aid" and "first_aid" name the same requirement
+func NormalizeCredentialType(credentialType string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(credentialType)), "_")
+	if !credentialTypePattern.MatchString(normalized) {
+		return "", ErrInvalidCredentialType
+	}
+	return normalized, nil
+}
+
+// CredentialService handles volunteer credential operations
+type CredentialService struct {
+	db *sql.DB
+}
+
+// NewCredentialService creates a new credential service
+func NewCredentialService(db *sql.DB) *CredentialService {
+	return &CredentialService{db: db}
+}
+
+// Create records an uploaded credential awaiting verification
+func (s *CredentialService) Create(credential *VolunteerCredential) error {
+	if credential.ID == uuid.Nil {
+		credential.ID = uuid.New()
+	}
+	err := s.db.QueryRow(credentialCreateQuery, credential.ID, credential.VolunteerID, credential.CredentialType,
+		credential.Issuer, credential.IssuedAt, credential.ExpiresAt, credential.DocumentKey,
+		credential.DocumentName, credential.MimeType, credential.FileSize).
+		Scan(&credential.Status, &credential.CreatedAt, &credential.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	credential.IsValid = credential.ValidOn(time.Now())
+	return nil
+}
+
+// GetByID retrieves a credential by ID
+func (s *CredentialService) GetByID(id uuid.UUID) (*VolunteerCredential, error) {
+	credential, err := scanCredential(s.db.QueryRow(credentialGetByIDQuery, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return credential, nil
+}
+
+// ListByVolunteer retrieves a volunteer's credentials
+func (s *CredentialService) ListByVolunteer(volunteerID uuid.UUID) ([]VolunteerCredential, error) {
+	rows, err := s.db.Query(credentialListByVolunteerQuery, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentials := []VolunteerCredential{}
+	for rows.Next() {
+		credential, err := scanCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, *credential)
+	}
+
+	return credentials, rows.Err()
+}
+
+// ListForReview retrieves credentials across all volunteers, oldest first, optionally
+// filtered by status
+func (s *CredentialService) ListForReview(status CredentialStatus, limit, offset int) ([]VolunteerCredential, error) {
+	rows, err := s.db.Query(credentialListForReviewQuery, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentials := []VolunteerCredential{}
+	for rows.Next() {
+		var volunteerName string
+		credential, err := scanCredential(rows, &volunteerName)
+		if err != nil {
+			return nil, err
+		}
+		credential.VolunteerName = volunteerName
+		credentials = append(credentials, *credential)
+	}
+
+	return credentials, rows.Err()
+}
+
+// UpdateDetails updates a volunteer's own credential. Any change sends it back for
+// verification. It returns sql.ErrNoRows if the credential isn't the volunteer's.
+func (s *CredentialService) UpdateDetails(credential *VolunteerCredential) error {
+	err := s.db.QueryRow(credentialUpdateDetailsQuery, credential.ID, credential.VolunteerID,
+		credential.CredentialType, credential.Issuer, credential.IssuedAt, credential.ExpiresAt).
+		Scan(&credential.Status, &credential.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	credential.ReviewNote = nil
+	credential.ReviewedByUserID = nil
+	credential.ReviewedAt = nil
+	credential.IsValid = false
+	return nil
+}
+
+// Delete deletes a volunteer's own credential and returns the storage key of its
+// document so the caller can remove it. It returns sql.ErrNoRows if the credential
+// isn't the volunteer's.
+func (s *CredentialService) Delete(id, volunteerID uuid.UUID) (string, error) {
+	var documentKey string
+	if err := s.db.QueryRow(credentialDeleteQuery, id, volunteerID).Scan(&documentKey); err != nil {
+		return "", err
+	}
+	return documentKey, nil
+}
+
+// Review verifies or rejects a pending credential. It returns ErrCredentialNotPending
+// if it was already reviewed and sql.ErrNoRows if it doesn't exist.
+func (s *CredentialService) Review(id uuid.UUID, approve bool, reviewerID uuid.UUID, note *string) (*VolunteerCredential, error) {
+	status := CredentialStatusRejected
+	if approve {
+		status = CredentialStatusVerified
+	}
+
+	result, err := s.db.Exec(credentialReviewQuery, id, status, note, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		if err := s.db.QueryRow(credentialExistsQuery, id).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrCredentialNotPending
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	return s.GetByID(id)
+}
+
+// GetRequiredCredentialTypes retrieves the credential types a project requires
+func (s *CredentialService) GetRequiredCredentialTypes(projectID uuid.UUID) ([]string, error) {
+	return queryCredentialTypes(s.db, projectRequiredCredentialsQuery, projectID)
+}
+
+// SetRequiredCredentialTypes replaces the credential types a project requires.
+// Types must already be normalized.
+func (s *CredentialService) SetRequiredCredentialTypes(projectID uuid.UUID, credentialTypes []string) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(projectClearRequiredCredentialsQuery, projectID); err != nil {
+			return fmt.Errorf("failed to clear required credentials: %w", err)
+		}
+		for _, credentialType := range credentialTypes {
+			if _, err := tx.Exec(projectAddRequiredCredentialQuery, projectID, credentialType); err != nil {
+				return fmt.Errorf("failed to add required credential %s: %w", credentialType, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MissingRequiredCredentials lists the credential types a project requires that the
+// volunteer has no verified, unexpired credential for. An empty result means the
+// volunteer may join the project.
+func (s *CredentialService) MissingRequiredCredentials(volunteerID, projectID uuid.UUID) ([]string, error) {
+	return missingRequiredCredentials(s.db, volunteerID, projectID, time.Now())
+}
+
+// missingRequiredCredentials lists the credential types a project requires that the
+// volunteer has no credential valid on day for
+func missingRequiredCredentials(q queryer, volunteerID, projectID uuid.UUID, day time.Time) ([]string, error) {
+	required, err := queryCredentialTypes(q, projectRequiredCredentialsQuery, projectID)
+	if err != nil || len(required) == 0 {
+		return required, err
+	}
+
+	rows, err := q.Query(credentialListByVolunteerQuery, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var held []VolunteerCredential
+	for rows.Next() {
+		credential, err := scanCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		held = append(held, *credential)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return MissingCredentialTypes(required, held, day), nil
+}
+
+// MissingCredentialTypes lists the required credential types that none of the held
+// credentials satisfies on day, in the order they're required
+func MissingCredentialTypes(required []string, held []VolunteerCredential, day time.Time) []string {
+	missing := []string{}
+	for _, credentialType := range required {
+		satisfied := false
+		for i := range held {
+			if held[i].CredentialType == credentialType && held[i].ValidOn(day) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			missing = append(missing, credentialType)
+		}
+	}
+	return missing
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryCredentialTypes runs a query returning a single column of credential types
+func queryCredentialTypes(q queryer, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentialTypes := []string{}
+	for rows.Next() {
+		var credentialType string
+		if err := rows.Scan(&credentialType); err != nil {
+			return nil, err
+		}
+		credentialTypes = append(credentialTypes, credentialType)
+	}
+
+	return credentialTypes, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCredential scans a credential row followed by any extra columns into extra
+func scanCredential(row rowScanner, extra ...interface{}) (*VolunteerCredential, error) {
+	credential := &VolunteerCredential{}
+	dest := []interface{}{
+		&credential.ID, &credential.VolunteerID, &credential.CredentialType, &credential.Issuer,
+		&credential.IssuedAt, &credential.ExpiresAt, &credential.DocumentKey, &credential.DocumentName,
+		&credential.MimeType, &credential.FileSize, &credential.Status, &credential.ReviewNote,
+		&credential.ReviewedByUserID, &credential.ReviewedAt, &credential.CreatedAt, &credential.UpdatedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	credential.IsValid = credential.ValidOn(time.Now())
+	return credential, nil
+}
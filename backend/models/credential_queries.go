@@ -0,0 +1,73 @@
+package models
+
+// Query constants for CredentialService
+const (
+	credentialCreateQuery = `
+		INSERT INTO volunteer_credentials (id, volunteer_id, credential_type, issuer, issued_at, expires_at,
+		                                   document_key, document_name, mime_type, file_size)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING status, created_at, updated_at`
+
+	credentialGetByIDQuery = `
+		SELECT id, volunteer_id, credential_type, issuer, issued_at, expires_at,
+		       document_key, document_name, mime_type, file_size,
+		       status, review_note, reviewed_by_user_id, reviewed_at, created_at, updated_at
+		FROM volunteer_credentials
+		WHERE id = $1`
+
+	credentialListByVolunteerQuery = `
+		SELECT id, volunteer_id, credential_type, issuer, issued_at, expires_at,
+		       document_key, document_name, mime_type, file_size,
+		       status, review_note, reviewed_by_user_id, reviewed_at, created_at, updated_at
+		FROM volunteer_credentials
+		WHERE volunteer_id = $1
+		ORDER BY credential_type, created_at DESC`
+
+	credentialListForReviewQuery = `
+		SELECT vc.id, vc.volunteer_id, vc.credential_type, vc.issuer, vc.issued_at, vc.expires_at,
+		       vc.document_key, vc.document_name, vc.mime_type, vc.file_size,
+		       vc.status, vc.review_note, vc.reviewed_by_user_id, vc.reviewed_at, vc.created_at, vc.updated_at,
+		       v.name
+		FROM volunteer_credentials vc
+		JOIN volunteers v ON vc.volunteer_id = v.id
+		WHERE ($1 = '' OR vc.status = $1)
+		ORDER BY vc.created_at
+		LIMIT $2 OFFSET $3`
+
+	// Changing a credential's details sends it back for verification
+	credentialUpdateDetailsQuery = `
+		UPDATE volunteer_credentials
+		SET credential_type = $3, issuer = $4, issued_at = $5, expires_at = $6,
+		    status = 'pending', review_note = NULL, reviewed_by_user_id = NULL, reviewed_at = NULL,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND volunteer_id = $2
+		RETURNING status, updated_at`
+
+	credentialDeleteQuery = `
+		DELETE FROM volunteer_credentials
+		WHERE id = $1 AND volunteer_id = $2
+		RETURNING document_key`
+
+	credentialReviewQuery = `
+		UPDATE volunteer_credentials
+		SET status = $2, review_note = $3, reviewed_by_user_id = $4, reviewed_at = CURRENT_TIMESTAMP,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'pending'`
+
+	credentialExistsQuery = `
+		SELECT EXISTS(SELECT 1 FROM volunteer_credentials WHERE id = $1)`
+
+	projectRequiredCredentialsQuery = `
+		SELECT credential_type
+		FROM project_required_credentials
+		WHERE project_id = $1
+		ORDER BY credential_type`
+
+	projectClearRequiredCredentialsQuery = `
+		DELETE FROM project_required_credentials WHERE project_id = $1`
+
+	projectAddRequiredCredentialQuery = `
+		INSERT INTO project_required_credentials (project_id, credential_type)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+)
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVolunteerCredentialValidOn(t *testing.T) {
+	day := time.Date(2026, 3, 15, 14, 30, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) *time.Time {
+		t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name       string
+		credential VolunteerCredential
+		want       bool
+	}{
+		{name: "verified without expiry", credential: VolunteerCredential{Status: CredentialStatusVerified}, want: true},
+		{name: "verified expiring later", credential: VolunteerCredential{Status: CredentialStatusVerified, ExpiresAt: date(2026, 3, 16)}, want: true},
+		{name: "verified expiring that day", credential: VolunteerCredential{Status: CredentialStatusVerified, ExpiresAt: date(2026, 3, 15)}, want: true},
+		{name: "verified but expired", credential: VolunteerCredential{Status: CredentialStatusVerified, ExpiresAt: date(2026, 3, 14)}, want: false},
+		{name: "pending", credential: VolunteerCredential{Status: CredentialStatusPending}, want: false},
+		{name: "rejected", credential: VolunteerCredential{Status: CredentialStatusRejected}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.credential.ValidOn(day); got != tt.want {
+				t.Errorf("ValidOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingCredentialTypes(t *testing.T) {
+	day := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	expired := day.AddDate(0, 0, -1)
+	current := day.AddDate(1, 0, 0)
+
+	tests := []struct {
+		name     string
+		required []string
+		held     []VolunteerCredential
+		want     []string
+	}{
+		{
+			name:     "nothing required",
+			required: nil,
+			held:     nil,
+			want:     []string{},
+		},
+		{
+			name:     "valid credential satisfies the requirement",
+			required: []string{"first_aid"},
+			held:     []VolunteerCredential{{CredentialType: "first_aid", Status: CredentialStatusVerified, ExpiresAt: &current}},
+			want:     []string{},
+		},
+		{
+			name:     "expired credential blocks approval",
+			required: []string{"first_aid"},
+			held:     []VolunteerCredential{{CredentialType: "first_aid", Status: CredentialStatusVerified, ExpiresAt: &expired}},
+			want:     []string{"first_aid"},
+		},
+		{
+			name:     "unverified credential blocks approval",
+			required: []string{"background_check"},
+			held:     []VolunteerCredential{{CredentialType: "background_check", Status: CredentialStatusPending}},
+			want:     []string{"background_check"},
+		},
+		{
+			name:     "a renewed credential outweighs an expired one",
+			required: []string{"first_aid"},
+			held: []VolunteerCredential{
+				{CredentialType: "first_aid", Status: CredentialStatusVerified, ExpiresAt: &expired},
+				{CredentialType: "first_aid", Status: CredentialStatusVerified, ExpiresAt: &current},
+			},
+			want: []string{},
+		},
+		{
+			name:     "credential of another type doesn't count",
+			required: []string{"background_check", "first_aid"},
+			held:     []VolunteerCredential{{CredentialType: "first_aid", Status: CredentialStatusVerified}},
+			want:     []string{"background_check"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MissingCredentialTypes(tt.required, tt.held, day); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingCredentialTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
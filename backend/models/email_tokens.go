@@ -4,28 +4,37 @@ import (
 	"database/sql"
 	"time"
 
+	"civicweave/backend/utils"
+
 	"github.com/google/uuid"
 )
 
-// EmailVerificationToken represents an email verification token
+// EmailVerificationToken represents an email verification token. Only a hash of
+// the token is stored.
 type EmailVerificationToken struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	UserID    uuid.UUID `json:"user_id" db:"user_id"`
-	Token     string    `json:"token" db:"token"`
+	TokenHash string    `json:"-" db:"token_hash"`
+	// Email is the new address being verified for an email change, or nil when
+	// verifying the account's current address
+	Email     *string   `json:"email,omitempty" db:"email"`
 	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
-// PasswordResetToken represents a password reset token
+// PasswordResetToken represents a password reset token. Only a hash of the token
+// is stored.
 type PasswordResetToken struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	UserID    uuid.UUID `json:"user_id" db:"user_id"`
-	Token     string    `json:"token" db:"token"`
-	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 }
 
-// EmailVerificationTokenService handles email verification token operations
+// EmailVerificationTokenService handles email verification token operations. Tokens
+// are stored hashed and can be used once.
 type EmailVerificationTokenService struct {
 	db *sql.DB
 }
@@ -35,27 +44,55 @@ func NewEmailVerificationTokenService(db *sql.DB) *EmailVerificationTokenService
 	return &EmailVerificationTokenService{db: db}
 }
 
-// Create creates a new email verification token
-func (s *EmailVerificationTokenService) Create(token *EmailVerificationToken) error {
+// Issue creates a verification token for userID that expires after expiresIn and
+// returns the plaintext token. email is the new address for an email change, or nil
+// to verify the current one. Tokens the user already has for the same purpose are
+// replaced.
+func (s *EmailVerificationTokenService) Issue(userID uuid.UUID, email *string, expiresIn time.Duration) (string, error) {
+	var rawToken string
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		var err error
+		rawToken, err = issueEmailVerificationToken(tx, userID, email, expiresIn)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return rawToken, nil
+}
+
+// issueEmailVerificationToken replaces the user's verification token for the same
+// purpose within tx and returns the new plaintext token
+func issueEmailVerificationToken(tx *sql.Tx, userID uuid.UUID, email *string, expiresIn time.Duration) (string, error) {
+	rawToken := utils.GenerateRandomToken()
+
+	deleteQuery := `DELETE FROM email_verification_tokens WHERE user_id = $1 AND (email IS NULL) = ($2::text IS NULL)`
+	if _, err := tx.Exec(deleteQuery, userID, email); err != nil {
+		return "", err
+	}
+
 	query := `
-		INSERT INTO email_verification_tokens (id, user_id, token, expires_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING created_at`
+		INSERT INTO email_verification_tokens (id, user_id, token_hash, email, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	if _, err := tx.Exec(query, uuid.New(), userID, utils.HashToken(rawToken), email, time.Now().Add(expiresIn)); err != nil {
+		return "", err
+	}
 
-	token.ID = uuid.New()
-	return s.db.QueryRow(query, token.ID, token.UserID, token.Token, token.ExpiresAt).
-		Scan(&token.CreatedAt)
+	return rawToken, nil
 }
 
-// GetByToken retrieves a token by its value
-func (s *EmailVerificationTokenService) GetByToken(tokenStr string) (*EmailVerificationToken, error) {
+// Consume deletes an unexpired token and returns it, or nil if the token is unknown,
+// expired or already used
+func (s *EmailVerificationTokenService) Consume(rawToken string) (*EmailVerificationToken, error) {
 	token := &EmailVerificationToken{}
 	query := `
-		SELECT id, user_id, token, expires_at, created_at
-		FROM email_verification_tokens WHERE token = $1`
+		DELETE FROM email_verification_tokens
+		WHERE token_hash = $1 AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id, user_id, token_hash, email, expires_at, created_at`
 
-	err := s.db.QueryRow(query, tokenStr).Scan(
-		&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.CreatedAt,
+	err := s.db.QueryRow(query, utils.HashToken(rawToken)).Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &token.Email, &token.ExpiresAt, &token.CreatedAt,
 	)
 
 	if err != nil {
@@ -68,13 +105,6 @@ func (s *EmailVerificationTokenService) GetByToken(tokenStr string) (*EmailVerif
 	return token, nil
 }
 
-// Delete deletes a token
-func (s *EmailVerificationTokenService) Delete(tokenStr string) error {
-	query := `DELETE FROM email_verification_tokens WHERE token = $1`
-	_, err := s.db.Exec(query, tokenStr)
-	return err
-}
-
 // CleanupExpiredTokens removes expired tokens
 func (s *EmailVerificationTokenService) CleanupExpiredTokens() error {
 	query := `DELETE FROM email_verification_tokens WHERE expires_at < NOW()`
@@ -82,7 +112,8 @@ func (s *EmailVerificationTokenService) CleanupExpiredTokens() error {
 	return err
 }
 
-// PasswordResetTokenService handles password reset token operations
+// PasswordResetTokenService handles password reset token operations. Tokens are
+// stored hashed and can be used once.
 type PasswordResetTokenService struct {
 	db *sql.DB
 }
@@ -92,27 +123,43 @@ func NewPasswordResetTokenService(db *sql.DB) *PasswordResetTokenService {
 	return &PasswordResetTokenService{db: db}
 }
 
-// Create creates a new password reset token
-func (s *PasswordResetTokenService) Create(token *PasswordResetToken) error {
-	query := `
-		INSERT INTO password_reset_tokens (id, user_id, token, expires_at)
-		VALUES ($1, $2, $3, $4)
-		RETURNING created_at`
+// Issue creates a reset token for userID that expires after expiresIn, replacing any
+// the user already has, and returns the plaintext token. The plaintext is not stored.
+func (s *PasswordResetTokenService) Issue(userID uuid.UUID, expiresIn time.Duration) (string, error) {
+	rawToken := utils.GenerateRandomToken()
 
-	token.ID = uuid.New()
-	return s.db.QueryRow(query, token.ID, token.UserID, token.Token, token.ExpiresAt).
-		Scan(&token.CreatedAt)
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM password_reset_tokens WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at)
+			VALUES ($1, $2, $3, $4)`
+		_, err := tx.Exec(query, uuid.New(), userID, utils.HashToken(rawToken), time.Now().Add(expiresIn))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return rawToken, nil
 }
 
-// GetByToken retrieves a token by its value
-func (s *PasswordResetTokenService) GetByToken(tokenStr string) (*PasswordResetToken, error) {
+// consumePasswordResetToken marks an unused, unexpired token as used with db, which
+// may be a transaction, and returns it, or nil if the token is unknown, expired or
+// already used
+func consumePasswordResetToken(db interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}, rawToken string) (*PasswordResetToken, error) {
 	token := &PasswordResetToken{}
 	query := `
-		SELECT id, user_id, token, expires_at, created_at
-		FROM password_reset_tokens WHERE token = $1`
+		UPDATE password_reset_tokens SET used_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id, user_id, expires_at, used_at, created_at`
 
-	err := s.db.QueryRow(query, tokenStr).Scan(
-		&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.CreatedAt,
+	err := db.QueryRow(query, utils.HashToken(rawToken)).Scan(
+		&token.ID, &token.UserID, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt,
 	)
 
 	if err != nil {
@@ -125,16 +172,9 @@ func (s *PasswordResetTokenService) GetByToken(tokenStr string) (*PasswordResetT
 	return token, nil
 }
 
-// Delete deletes a token
-func (s *PasswordResetTokenService) Delete(tokenStr string) error {
-	query := `DELETE FROM password_reset_tokens WHERE token = $1`
-	_, err := s.db.Exec(query, tokenStr)
-	return err
-}
-
-// CleanupExpiredTokens removes expired tokens
+// CleanupExpiredTokens removes expired and used tokens
 func (s *PasswordResetTokenService) CleanupExpiredTokens() error {
-	query := `DELETE FROM password_reset_tokens WHERE expires_at < NOW()`
+	query := `DELETE FROM password_reset_tokens WHERE expires_at < NOW() OR used_at IS NOT NULL`
 	_, err := s.db.Exec(query)
 	return err
 }
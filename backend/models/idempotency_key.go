@@ -0,0 +1,116 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Idempotency key scopes, one per operation that accepts an Idempotency-Key header
+const (
+	IdempotencyScopeCreateProject = "create_project"
+	IdempotencyScopeCreateTask    = "create_task"
+)
+
+// idempotencyReservationLease is how long a request may hold a key without completing
+// or releasing it. After that the request is assumed lost and the key can be reserved
+// again, so a crash mid-request doesn't block retries until the key expires.
+const idempotencyReservationLease = 2 * time.Minute
+
+// IdempotencyKey records a request sent with an Idempotency-Key header. EntityID is
+// nil while the request is still being processed.
+type IdempotencyKey struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Scope       string     `json:"scope" db:"scope"`
+	Key         string     `json:"idempotency_key" db:"idempotency_key"`
+	RequestHash string     `json:"-" db:"request_hash"`
+	EntityID    *uuid.UUID `json:"entity_id,omitempty" db:"entity_id"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IdempotencyKeyService handles idempotency key operations. Keys are scoped to the
+// user and operation, so different users can't collide by choosing the same key.
+type IdempotencyKeyService struct {
+	db *sql.DB
+}
+
+// NewIdempotencyKeyService creates a new idempotency key service
+func NewIdempotencyKeyService(db *sql.DB) *IdempotencyKeyService {
+	return &IdempotencyKeyService{db: db}
+}
+
+// Reserve claims key for a request with the given hash until it expires after ttl.
+// It returns nil if the key was reserved for this request, or the existing record if
+// an earlier request already holds it. A reservation left incomplete for longer than
+// idempotencyReservationLease is reclaimed.
+func (s *IdempotencyKeyService) Reserve(userID uuid.UUID, scope, key, requestHash string, ttl time.Duration) (*IdempotencyKey, error) {
+	if _, err := s.db.Exec(idempotencyKeyDeleteExpiredQuery, userID, scope, key, idempotencyReservationLease.Seconds()); err != nil {
+		return nil, err
+	}
+
+	// The holder may release the key between the insert and the lookup, so try twice
+	for attempt := 0; attempt < 2; attempt++ {
+		var id uuid.UUID
+		err := s.db.QueryRow(idempotencyKeyReserveQuery, uuid.New(), userID, scope, key, requestHash, time.Now().Add(ttl)).Scan(&id)
+		if err == nil {
+			return nil, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		existing, err := s.get(userID, scope, key)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	return nil, fmt.Errorf("idempotency key %q is being reserved concurrently", key)
+}
+
+// get retrieves the record for a key
+func (s *IdempotencyKeyService) get(userID uuid.UUID, scope, key string) (*IdempotencyKey, error) {
+	record := &IdempotencyKey{}
+
+	err := s.db.QueryRow(idempotencyKeyGetQuery, userID, scope, key).Scan(
+		&record.ID, &record.UserID, &record.Scope, &record.Key, &record.RequestHash,
+		&record.EntityID, &record.ExpiresAt, &record.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Complete records the entity created by the request holding key
+func (s *IdempotencyKeyService) Complete(userID uuid.UUID, scope, key string, entityID uuid.UUID) error {
+	_, err := s.db.Exec(idempotencyKeyCompleteQuery, userID, scope, key, entityID)
+	return err
+}
+
+// Release frees a key whose request failed, so it can be retried with the same key
+func (s *IdempotencyKeyService) Release(userID uuid.UUID, scope, key string) error {
+	_, err := s.db.Exec(idempotencyKeyReleaseQuery, userID, scope, key)
+	return err
+}
+
+// CleanupExpired removes expired keys and abandoned reservations, returning how many
+// were removed
+func (s *IdempotencyKeyService) CleanupExpired() (int64, error) {
+	result, err := s.db.Exec(idempotencyKeyCleanupQuery, idempotencyReservationLease.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
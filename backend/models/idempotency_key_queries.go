@@ -0,0 +1,38 @@
+package models
+
+// Query constants for IdempotencyKeyService
+const (
+	// Also frees a reservation whose request never completed or released it, such as
+	// one held by a server that crashed mid-request, once its lease has run out
+	idempotencyKeyDeleteExpiredQuery = `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND scope = $2 AND idempotency_key = $3
+		  AND (expires_at <= CURRENT_TIMESTAMP
+		       OR (entity_id IS NULL AND created_at <= CURRENT_TIMESTAMP - make_interval(secs => $4::float8)))`
+
+	// Inserts nothing if the key is already in use, so concurrent requests with the
+	// same key can't both reserve it
+	idempotencyKeyReserveQuery = `
+		INSERT INTO idempotency_keys (id, user_id, scope, idempotency_key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, scope, idempotency_key) DO NOTHING
+		RETURNING id`
+
+	idempotencyKeyGetQuery = `
+		SELECT id, user_id, scope, idempotency_key, request_hash, entity_id, expires_at, created_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND scope = $2 AND idempotency_key = $3`
+
+	idempotencyKeyCompleteQuery = `
+		UPDATE idempotency_keys SET entity_id = $4
+		WHERE user_id = $1 AND scope = $2 AND idempotency_key = $3`
+
+	idempotencyKeyReleaseQuery = `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND scope = $2 AND idempotency_key = $3 AND entity_id IS NULL`
+
+	idempotencyKeyCleanupQuery = `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= CURRENT_TIMESTAMP
+		   OR (entity_id IS NULL AND created_at <= CURRENT_TIMESTAMP - make_interval(secs => $1::float8))`
+)
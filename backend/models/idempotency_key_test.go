@@ -0,0 +1,64 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestReserveReclaimsAbandonedReservations(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("INSERT INTO idempotency_keys", []string{"id"}, []driver.Value{uuid.New().String()})
+
+	userID := uuid.New()
+	existing, err := NewIdempotencyKeyService(db).Reserve(userID, IdempotencyScopeCreateTask, "retry-1", "hash", time.Hour)
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if existing != nil {
+		t.Fatalf("Reserve() = %+v, want the key reserved for this request", existing)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) == 0 || !strings.Contains(statements[0].Query, "DELETE FROM idempotency_keys") {
+		t.Fatalf("first statement = %+v, want the stale key cleanup", statements)
+	}
+	cleanup := statements[0]
+	if !strings.Contains(cleanup.Query, "entity_id IS NULL AND created_at <=") {
+		t.Errorf("cleanup doesn't free incomplete reservations: %s", cleanup.Query)
+	}
+	wantArgs := []interface{}{userID.String(), IdempotencyScopeCreateTask, "retry-1", idempotencyReservationLease.Seconds()}
+	if len(cleanup.Args) != len(wantArgs) {
+		t.Fatalf("cleanup args = %v, want %v", cleanup.Args, wantArgs)
+	}
+	for i, want := range wantArgs {
+		if cleanup.Args[i] != want {
+			t.Errorf("cleanup arg %d = %v, want %v", i+1, cleanup.Args[i], want)
+		}
+	}
+}
+
+func TestCleanupExpiredReportsRemovedKeys(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Affects("DELETE FROM idempotency_keys", 3)
+
+	removed, err := NewIdempotencyKeyService(db).CleanupExpired()
+	if err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("removed = %d, want 3", removed)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 || statements[0].Args[0] != idempotencyReservationLease.Seconds() {
+		t.Errorf("statements = %+v, want one cleanup with the reservation lease", statements)
+	}
+}
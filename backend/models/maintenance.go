@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceModeType controls what maintenance mode blocks
+type MaintenanceModeType string
+
+const (
+	// MaintenanceModeReadOnly blocks writes but keeps reads available
+	MaintenanceModeReadOnly MaintenanceModeType = "read_only"
+	// MaintenanceModeOffline blocks every request
+	MaintenanceModeOffline MaintenanceModeType = "offline"
+)
+
+// MaintenanceState represents the platform's maintenance switch. Admins are never blocked.
+type MaintenanceState struct {
+	Enabled         bool                `json:"enabled" db:"enabled"`
+	Mode            MaintenanceModeType `json:"mode" db:"mode"`
+	Message         string              `json:"message" db:"message"`
+	UpdatedByUserID *uuid.UUID          `json:"updated_by_user_id,omitempty" db:"updated_by_user_id"`
+	UpdatedAt       *time.Time          `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// MaintenanceService handles maintenance mode operations
+type MaintenanceService struct {
+	db *sql.DB
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService(db *sql.DB) *MaintenanceService {
+	return &MaintenanceService{db: db}
+}
+
+// Get retrieves the current maintenance state. It reports maintenance as off if it has never been set.
+func (s *MaintenanceService) Get() (*MaintenanceState, error) {
+	state := &MaintenanceState{}
+	err := s.db.QueryRow(maintenanceGetQuery).Scan(
+		&state.Enabled, &state.Mode, &state.Message, &state.UpdatedByUserID, &state.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &MaintenanceState{Mode: MaintenanceModeReadOnly}, nil
+		}
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Set replaces the maintenance state
+func (s *MaintenanceService) Set(state *MaintenanceState) error {
+	return s.db.QueryRow(maintenanceUpsertQuery, state.Enabled, state.Mode, state.Message, state.UpdatedByUserID).
+		Scan(&state.UpdatedAt)
+}
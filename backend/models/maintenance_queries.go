@@ -0,0 +1,15 @@
+package models
+
+const (
+	maintenanceGetQuery = `
+		SELECT enabled, mode, message, updated_by_user_id, updated_at
+		FROM maintenance_mode WHERE id = true`
+
+	maintenanceUpsertQuery = `
+		INSERT INTO maintenance_mode (id, enabled, mode, message, updated_by_user_id, updated_at)
+		VALUES (true, $1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, mode = EXCLUDED.mode, message = EXCLUDED.message,
+		    updated_by_user_id = EXCLUDED.updated_by_user_id, updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+)
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MatchingRecalculationStatus represents where a recalculation request is in its lifecycle
+type MatchingRecalculationStatus string
+
+const (
+	MatchingRecalculationPending   MatchingRecalculationStatus = "pending"
+	MatchingRecalculationRunning   MatchingRecalculationStatus = "running"
+	MatchingRecalculationCompleted MatchingRecalculationStatus = "completed"
+	MatchingRecalculationFailed    MatchingRecalculationStatus = "failed"
+)
+
+// MatchingRecalculationRequest represents an on-demand request for the matching worker
+// to recalculate volunteer-project matches
+type MatchingRecalculationRequest struct {
+	ID                uuid.UUID                   `json:"id" db:"id"`
+	RequestedByUserID *uuid.UUID                  `json:"requested_by_user_id" db:"requested_by_user_id"`
+	Status            MatchingRecalculationStatus `json:"status" db:"status"`
+	Error             *string                     `json:"error,omitempty" db:"error"`
+	RequestedAt       time.Time                   `json:"requested_at" db:"requested_at"`
+	StartedAt         *time.Time                  `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt       *time.Time                  `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// MatchingRecalculationService queues recalculation requests between the API and the matching worker
+type MatchingRecalculationService struct {
+	db *sql.DB
+}
+
+// NewMatchingRecalculationService creates a new matching recalculation service
+func NewMatchingRecalculationService(db *sql.DB) *MatchingRecalculationService {
+	return &MatchingRecalculationService{db: db}
+}
+
+// Request queues a recalculation. If one is already waiting, that request is returned
+// instead and coalesced is true, so triggers arriving close together share a single run.
+func (s *MatchingRecalculationService) Request(requestedByUserID *uuid.UUID) (*MatchingRecalculationRequest, bool, error) {
+	request, err := s.scanRequest(s.db.QueryRow(matchingRecalculationRequestQuery, uuid.New(), requestedByUserID))
+	if err == nil {
+		return request, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	// Another trigger got there first
+	request, err = s.scanRequest(s.db.QueryRow(matchingRecalculationGetPendingQuery))
+	if err == sql.ErrNoRows {
+		// The pending request was claimed in between; queue a fresh one
+		return s.Request(requestedByUserID)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return request, true, nil
+}
+
+// GetLatest retrieves the most recent recalculation request, or nil if there has never been one
+func (s *MatchingRecalculationService) GetLatest() (*MatchingRecalculationRequest, error) {
+	request, err := s.scanRequest(s.db.QueryRow(matchingRecalculationGetLatestQuery))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return request, err
+}
+
+// ClaimPending marks the waiting request as running and returns it, or nil if none is waiting
+func (s *MatchingRecalculationService) ClaimPending() (*MatchingRecalculationRequest, error) {
+	request, err := s.scanRequest(s.db.QueryRow(matchingRecalculationClaimQuery))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return request, err
+}
+
+// Finish records the outcome of a claimed request
+func (s *MatchingRecalculationService) Finish(id uuid.UUID, runErr error) error {
+	status := MatchingRecalculationCompleted
+	var errorMessage *string
+	if runErr != nil {
+		status = MatchingRecalculationFailed
+		message := runErr.Error()
+		errorMessage = &message
+	}
+
+	_, err := s.db.Exec(matchingRecalculationFinishQuery, id, status, errorMessage)
+	return err
+}
+
+// scanRequest scans a single recalculation request row
+func (s *MatchingRecalculationService) scanRequest(row *sql.Row) (*MatchingRecalculationRequest, error) {
+	request := &MatchingRecalculationRequest{}
+	err := row.Scan(
+		&request.ID, &request.RequestedByUserID, &request.Status, &request.Error,
+		&request.RequestedAt, &request.StartedAt, &request.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// MatchingEntityType identifies what kind of entity changed in a way that affects matches
+type MatchingEntityType string
+
+const (
+	MatchingEntityVolunteer MatchingEntityType = "volunteer"
+	MatchingEntityProject   MatchingEntityType = "project"
+)
+
+// DirtyMatchingEntity is a volunteer or project whose matches are out of date
+type DirtyMatchingEntity struct {
+	EntityType MatchingEntityType `json:"entity_type" db:"entity_type"`
+	EntityID   uuid.UUID          `json:"entity_id" db:"entity_id"`
+	MarkedAt   time.Time          `json:"marked_at" db:"marked_at"`
+}
+
+// markMatchingDirty records that an entity's matches need recalculating and drops its
+// cached matches, so reads never serve scores computed from the old skills. It takes the
+// caller's transaction so both commit together with the skill change.
+func markMatchingDirty(tx *sql.Tx, entityType MatchingEntityType, entityID uuid.UUID) error {
+	if _, err := tx.Exec(matchingMarkDirtyQuery, entityType, entityID); err != nil {
+		return fmt.Errorf("failed to mark %s %s for matching: %w", entityType, entityID, err)
+	}
+
+	invalidateQuery := matchingInvalidateVolunteerQuery
+	if entityType == MatchingEntityProject {
+		invalidateQuery = matchingInvalidateProjectQuery
+	}
+	if _, err := tx.Exec(invalidateQuery, entityID); err != nil {
+		return fmt.Errorf("failed to invalidate cached matches for %s %s: %w", entityType, entityID, err)
+	}
+	return nil
+}
+
+// ListDirty retrieves up to limit entities whose matches are out of date, oldest first.
+// A limit of 0 retrieves them all.
+func (s *MatchingRecalculationService) ListDirty(limit int) ([]DirtyMatchingEntity, error) {
+	var limitArg interface{} // LIMIT NULL means no limit
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := s.db.Query(matchingListDirtyQuery, limitArg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entities := []DirtyMatchingEntity{}
+	for rows.Next() {
+		var entity DirtyMatchingEntity
+		if err := rows.Scan(&entity.EntityType, &entity.EntityID, &entity.MarkedAt); err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, rows.Err()
+}
+
+// GetDirty retrieves an entity's dirty mark, or nil if its matches are up to date
+func (s *MatchingRecalculationService) GetDirty(entityType MatchingEntityType, entityID uuid.UUID) (*DirtyMatchingEntity, error) {
+	entity := &DirtyMatchingEntity{}
+	err := s.db.QueryRow(matchingGetDirtyQuery, entityType, entityID).Scan(&entity.EntityType, &entity.EntityID, &entity.MarkedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity, nil
+}
+
+// ClearDirty removes a dirty mark once the entity's matches have been recalculated.
+// Marks newer than the one recalculated are kept.
+func (s *MatchingRecalculationService) ClearDirty(entity DirtyMatchingEntity) error {
+	_, err := s.db.Exec(matchingClearDirtyQuery, entity.EntityType, entity.EntityID, entity.MarkedAt)
+	return err
+}
@@ -0,0 +1,64 @@
+package models
+
+// Query constants for MatchingRecalculationService
+const (
+	// Does nothing when a request is already pending, so close triggers coalesce
+	matchingRecalculationRequestQuery = `
+		INSERT INTO matching_recalculation_requests (id, requested_by_user_id, status)
+		VALUES ($1, $2, 'pending')
+		ON CONFLICT (status) WHERE status = 'pending' DO NOTHING
+		RETURNING id, requested_by_user_id, status, error, requested_at, started_at, completed_at`
+
+	matchingRecalculationGetPendingQuery = `
+		SELECT id, requested_by_user_id, status, error, requested_at, started_at, completed_at
+		FROM matching_recalculation_requests
+		WHERE status = 'pending'`
+
+	matchingRecalculationGetLatestQuery = `
+		SELECT id, requested_by_user_id, status, error, requested_at, started_at, completed_at
+		FROM matching_recalculation_requests
+		ORDER BY requested_at DESC
+		LIMIT 1`
+
+	matchingRecalculationClaimQuery = `
+		UPDATE matching_recalculation_requests
+		SET status = 'running', started_at = CURRENT_TIMESTAMP
+		WHERE status = 'pending'
+		RETURNING id, requested_by_user_id, status, error, requested_at, started_at, completed_at`
+
+	matchingRecalculationFinishQuery = `
+		UPDATE matching_recalculation_requests
+		SET status = $2, error = $3, completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running'`
+)
+
+// Query constants for incremental matching
+const (
+	// Re-marking bumps marked_at so a recalculation already in flight doesn't clear the newer change
+	matchingMarkDirtyQuery = `
+		INSERT INTO matching_dirty_entities (entity_type, entity_id)
+		VALUES ($1, $2)
+		ON CONFLICT (entity_type, entity_id) DO UPDATE SET marked_at = CURRENT_TIMESTAMP`
+
+	matchingListDirtyQuery = `
+		SELECT entity_type, entity_id, marked_at
+		FROM matching_dirty_entities
+		ORDER BY marked_at
+		LIMIT $1`
+
+	matchingGetDirtyQuery = `
+		SELECT entity_type, entity_id, marked_at
+		FROM matching_dirty_entities
+		WHERE entity_type = $1 AND entity_id = $2`
+
+	// Only clears the mark that was seen; a newer mark survives for the next pass
+	matchingClearDirtyQuery = `
+		DELETE FROM matching_dirty_entities
+		WHERE entity_type = $1 AND entity_id = $2 AND marked_at <= $3`
+
+	matchingInvalidateVolunteerQuery = `
+		DELETE FROM volunteer_project_matches WHERE volunteer_id = $1`
+
+	matchingInvalidateProjectQuery = `
+		DELETE FROM volunteer_project_matches WHERE project_id = $1`
+)
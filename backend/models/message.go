@@ -2,12 +2,18 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrMessageEditConflict is returned when a message changed between being read and
+// being edited: it was edited or deleted concurrently, or its edit window closed
+var ErrMessageEditConflict = fmt.Errorf("message was modified, deleted, or its edit window closed")
+
 // ProjectMessage represents a message (expanded for universal messaging)
 type ProjectMessage struct {
 	ID              uuid.UUID  `json:"id" db:"id"`
@@ -23,6 +29,7 @@ type ProjectMessage struct {
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	EditedAt        *time.Time `json:"edited_at,omitempty" db:"edited_at"`
 	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ScheduledAt     *time.Time `json:"scheduled_at,omitempty" db:"scheduled_at"` // Set on messages sent later than they were written
 }
 
 // MessageRead represents a read receipt for a message
@@ -32,15 +39,26 @@ type MessageRead struct {
 	ReadAt    time.Time `json:"read_at" db:"read_at"`
 }
 
+// MessageReceipt is one recipient's read status for a message. ReadAt is left
+// empty when the recipient hides their read receipts.
+type MessageReceipt struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	Name         string     `json:"name"`
+	IsRead       bool       `json:"is_read"`
+	ReadAt       *time.Time `json:"read_at,omitempty"`
+	ReadAtHidden bool       `json:"read_at_hidden,omitempty"`
+}
+
 // MessageWithSender includes message and sender info
 type MessageWithSender struct {
 	ProjectMessage
-	SenderName     string  `json:"sender_name"`
-	SenderEmail    string  `json:"sender_email"`
-	RecipientName  *string `json:"recipient_name,omitempty"`
-	RecipientEmail *string `json:"recipient_email,omitempty"`
-	ProjectTitle   *string `json:"project_title,omitempty"`
-	IsRead         bool    `json:"is_read"`
+	SenderName     string         `json:"sender_name"`
+	SenderEmail    string         `json:"sender_email"`
+	RecipientName  *string        `json:"recipient_name,omitempty"`
+	RecipientEmail *string        `json:"recipient_email,omitempty"`
+	ProjectTitle   *string        `json:"project_title,omitempty"`
+	IsRead         bool           `json:"is_read"`
+	Reactions      map[string]int `json:"reactions"` // Emoji -> number of users who reacted with it
 }
 
 // Conversation represents a message conversation/thread
@@ -68,6 +86,20 @@ type UniversalUnreadCount struct {
 	Total           int `json:"total"`
 }
 
+// UnreadDirectMessage is a direct message its recipient has not read, with the
+// details needed to email them about it
+type UnreadDirectMessage struct {
+	MessageID      uuid.UUID `json:"message_id"`
+	SenderID       uuid.UUID `json:"sender_id"`
+	SenderName     string    `json:"sender_name"`
+	Subject        *string   `json:"subject,omitempty"`
+	MessageText    string    `json:"message_text"`
+	CreatedAt      time.Time `json:"created_at"`
+	RecipientID    uuid.UUID `json:"recipient_id"`
+	RecipientEmail string    `json:"recipient_email"`
+	RecipientName  string    `json:"recipient_name"`
+}
+
 // MessageService handles message operations
 type MessageService struct {
 	db *sql.DB
@@ -86,28 +118,17 @@ func (s *MessageService) Create(message *ProjectMessage) error {
 		message.MessageType = "general"
 	}
 
-	// Start transaction to create message and auto-record sender read receipt
-	tx, err := s.db.Begin()
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
+	// Create the message and auto-record the sender's read receipt together
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(messageCreateQuery, message.ID, message.ProjectID, message.SenderID, message.MessageText, message.TaskID, message.MessageType).
+			Scan(&message.CreatedAt)
+		if err != nil {
+			return err
+		}
 
-	// Insert the message
-	err = tx.QueryRow(messageCreateQuery, message.ID, message.ProjectID, message.SenderID, message.MessageText, message.TaskID, message.MessageType).
-		Scan(&message.CreatedAt)
-	if err != nil {
+		_, err = tx.Exec(messageMarkAsReadQuery, message.SenderID, message.ID)
 		return err
-	}
-
-	// Auto-record read receipt for sender
-	_, err = tx.Exec(messageMarkAsReadQuery, message.SenderID, message.ID)
-	if err != nil {
-		return err
-	}
-
-	// Commit transaction
-	return tx.Commit()
+	})
 }
 
 // GetByID retrieves a message by ID
@@ -115,8 +136,9 @@ func (s *MessageService) GetByID(id uuid.UUID) (*ProjectMessage, error) {
 	message := &ProjectMessage{}
 
 	err := s.db.QueryRow(messageGetByIDQuery, id).Scan(
-		&message.ID, &message.ProjectID, &message.SenderID, &message.MessageText,
-		&message.CreatedAt, &message.EditedAt, &message.DeletedAt,
+		&message.ID, &message.ProjectID, &message.SenderID, &message.RecipientUserID, &message.RecipientTeamID,
+		&message.Subject, &message.MessageText, &message.TaskID, &message.MessageType, &message.MessageScope,
+		&message.CreatedAt, &message.EditedAt, &message.DeletedAt, &message.ScheduledAt,
 	)
 
 	if err != nil {
@@ -152,7 +174,15 @@ func (s *MessageService) ListByProject(projectID uuid.UUID, limit, offset int, u
 		messages = append(messages, msg)
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
 }
 
 // ListRecentByProject retrieves recent messages for a project (last N messages)
@@ -182,12 +212,33 @@ func (s *MessageService) ListRecentByProject(projectID uuid.UUID, count int, use
 		messages[i], messages[j] = messages[j], messages[i]
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
 }
 
-// Update updates a message (for editing)
-func (s *MessageService) Update(message *ProjectMessage) error {
-	return s.db.QueryRow(messageUpdateQuery, message.ID, message.MessageText).Scan(&message.EditedAt)
+// Update saves an edit to a message. The write only succeeds if editorID sent the
+// message, it is still within editWindowMinutes of being sent, it isn't deleted, and
+// it hasn't been edited since it was read (message.EditedAt acts as the version).
+// Otherwise it returns ErrMessageEditConflict.
+func (s *MessageService) Update(message *ProjectMessage, editorID uuid.UUID, editWindowMinutes int) error {
+	if editWindowMinutes <= 0 {
+		return ErrMessageEditConflict
+	}
+
+	// The window is measured with the database clock, the same clock that set created_at
+	err := s.db.QueryRow(messageUpdateQuery, message.ID, message.MessageText, editorID, editWindowMinutes, message.EditedAt).
+		Scan(&message.EditedAt)
+	if err == sql.ErrNoRows {
+		return ErrMessageEditConflict
+	}
+	return err
 }
 
 // SoftDelete soft-deletes a message
@@ -215,6 +266,48 @@ func (s *MessageService) MarkAsRead(messageID, userID uuid.UUID) error {
 	return err
 }
 
+// GetReceipts returns read receipts for a message. Direct messages report their
+// recipient; project and team messages report every team member but the sender.
+func (s *MessageService) GetReceipts(message *ProjectMessage) ([]MessageReceipt, error) {
+	var rows *sql.Rows
+	var err error
+	switch {
+	case message.RecipientUserID != nil:
+		rows, err = s.db.Query(messageReceiptsForUserQuery, message.ID, *message.RecipientUserID)
+	case message.ProjectID != nil:
+		rows, err = s.db.Query(messageReceiptsForProjectQuery, message.ID, *message.ProjectID, message.SenderID)
+	case message.RecipientTeamID != nil:
+		rows, err = s.db.Query(messageReceiptsForProjectQuery, message.ID, *message.RecipientTeamID, message.SenderID)
+	default:
+		return []MessageReceipt{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []MessageReceipt{}
+	for rows.Next() {
+		var receipt MessageReceipt
+		var hidden bool
+		var readAt sql.NullTime
+		if err := rows.Scan(&receipt.UserID, &receipt.Name, &hidden, &readAt); err != nil {
+			return nil, err
+		}
+		receipt.IsRead = readAt.Valid
+		if readAt.Valid {
+			if hidden {
+				receipt.ReadAtHidden = true
+			} else {
+				receipt.ReadAt = &readAt.Time
+			}
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, rows.Err()
+}
+
 // MarkAllAsRead marks all messages in a project as read for a user
 func (s *MessageService) MarkAllAsRead(projectID, userID uuid.UUID) error {
 	_, err := s.db.Exec(messageMarkAllAsReadQuery, userID, projectID)
@@ -275,13 +368,26 @@ func (s *MessageService) GetMessagesAfter(projectID uuid.UUID, after time.Time,
 		messages = append(messages, msg)
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
 }
 
-// CanUserEdit checks if a user can edit a message (sender and within 15 minutes)
-func (s *MessageService) CanUserEdit(messageID, userID uuid.UUID) (bool, error) {
+// CanUserEdit checks if a user can edit a message: they sent it less than
+// editWindowMinutes ago. A window of zero or less disables editing.
+func (s *MessageService) CanUserEdit(messageID, userID uuid.UUID, editWindowMinutes int) (bool, error) {
+	if editWindowMinutes <= 0 {
+		return false, nil
+	}
+
 	var canEdit bool
-	err := s.db.QueryRow(messageCanUserEditQuery, messageID, userID).Scan(&canEdit)
+	err := s.db.QueryRow(messageCanUserEditQuery, messageID, userID, editWindowMinutes).Scan(&canEdit)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -292,7 +398,34 @@ func (s *MessageService) CanUserEdit(messageID, userID uuid.UUID) (bool, error)
 	return canEdit, nil
 }
 
-// CreateTaskNotification creates a task-related notification message
+// TaskDigestMessageType is the message type of merged task notifications
+const TaskDigestMessageType = "task_digest"
+
+// Task digest windows. A project's non-urgent task notifications from one sender are
+// merged for this many minutes after the first. The sender's own setting wins over the
+// project's, DefaultTaskDigestMinutes applies when neither is set, and 0 turns digests off.
+const (
+	DefaultTaskDigestMinutes = 5
+	MaxTaskDigestMinutes     = 1440
+)
+
+// urgentTaskNotificationTypes are task notifications that are never merged into a digest
+var urgentTaskNotificationTypes = []string{"task_blocked", "task_takeover"}
+
+// isUrgentTaskNotification reports whether a task notification must be posted immediately
+func isUrgentTaskNotification(messageType string) bool {
+	for _, urgent := range urgentTaskNotificationTypes {
+		if messageType == urgent {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTaskNotification creates a task-related notification message. Urgent
+// notifications are always posted on their own. Others are merged into a digest
+// ("3 tasks updated in Project X") when the sender started one in the project
+// within their digest window, so bulk task updates don't flood the project.
 func (s *MessageService) CreateTaskNotification(projectID, senderID, taskID uuid.UUID, messageType, messageText string) error {
 	message := &ProjectMessage{
 		ProjectID:    &projectID,
@@ -302,12 +435,75 @@ func (s *MessageService) CreateTaskNotification(projectID, senderID, taskID uuid
 		MessageType:  messageType,
 		MessageScope: "project",
 	}
+	if isUrgentTaskNotification(messageType) {
+		return s.Create(message)
+	}
+
+	merged, err := s.mergeIntoTaskDigest(projectID, senderID, taskID, messageText)
+	if err != nil {
+		return err
+	}
+	if merged {
+		return nil
+	}
 	return s.Create(message)
 }
 
-// CreateUniversalMessage creates a message for universal messaging
-func (s *MessageService) CreateUniversalMessage(message *ProjectMessage) error {
-	message.ID = uuid.New()
+// mergeIntoTaskDigest appends a task notification to the sender's open digest for
+// the project, bumping it to the top and marking it unread again. It reports
+// false if there is no digest to merge into.
+func (s *MessageService) mergeIntoTaskDigest(projectID, senderID, taskID uuid.UUID, messageText string) (bool, error) {
+	urgentJSON, err := ToJSONArray(urgentTaskNotificationTypes)
+	if err != nil {
+		return false, err
+	}
+
+	merged := false
+	err = WithTransaction(s.db, func(tx *sql.Tx) error {
+		var digestID uuid.UUID
+		var digestCount int
+		var digestText, projectTitle string
+		err := tx.QueryRow(messageFindTaskDigestQuery, projectID, senderID, urgentJSON, DefaultTaskDigestMinutes).
+			Scan(&digestID, &digestCount, &digestText, &projectTitle)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		digestText = appendToTaskDigest(digestText, digestCount, projectTitle, messageText)
+		if _, err := tx.Exec(messageMergeTaskDigestQuery, digestID, digestCount+1, digestText, taskID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(messageResetReadsQuery, digestID, senderID); err != nil {
+			return err
+		}
+
+		merged = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return merged, nil
+}
+
+// appendToTaskDigest returns the text of a digest holding digestCount notifications once
+// newText is added: a summary line followed by each notification's text. A digest of
+// one notification is just that notification's text.
+func appendToTaskDigest(digestText string, digestCount int, projectTitle, newText string) string {
+	notifications := digestText
+	if digestCount > 1 {
+		// Drop the old summary line
+		_, notifications, _ = strings.Cut(digestText, "\n")
+	}
+	return fmt.Sprintf("📋 %d tasks updated in %s\n%s\n%s", digestCount+1, projectTitle, notifications, newText)
+}
+
+// setUniversalMessageDefaults fills in the scope and type of a message when unset
+func setUniversalMessageDefaults(message *ProjectMessage) {
 	// Set default message scope if not specified
 	if message.MessageScope == "" {
 		if message.RecipientUserID != nil {
@@ -322,6 +518,12 @@ func (s *MessageService) CreateUniversalMessage(message *ProjectMessage) error {
 	if message.MessageType == "" {
 		message.MessageType = "general"
 	}
+}
+
+// CreateUniversalMessage creates a message for universal messaging
+func (s *MessageService) CreateUniversalMessage(message *ProjectMessage) error {
+	message.ID = uuid.New()
+	setUniversalMessageDefaults(message)
 
 	subjectStr := "nil"
 	if message.Subject != nil {
@@ -330,36 +532,25 @@ func (s *MessageService) CreateUniversalMessage(message *ProjectMessage) error {
 	log.Printf("DEBUG: CreateUniversalMessage - ID: %s, ProjectID: %v, SenderID: %s, RecipientUserID: %v, RecipientTeamID: %v, Subject: %s, MessageText: %s, TaskID: %v, MessageType: %s, MessageScope: %s",
 		message.ID, message.ProjectID, message.SenderID, message.RecipientUserID, message.RecipientTeamID, subjectStr, message.MessageText, message.TaskID, message.MessageType, message.MessageScope)
 
-	// Start transaction to create message and auto-record sender read receipt
-	tx, err := s.db.Begin()
-	if err != nil {
-		log.Printf("DEBUG: Failed to begin transaction in CreateUniversalMessage: %v", err)
-		return err
-	}
-	defer tx.Rollback()
-
-	// Insert the message
-	err = tx.QueryRow(messageCreateUniversalQuery, message.ID, message.ProjectID, message.SenderID,
-		message.RecipientUserID, message.RecipientTeamID, message.Subject, message.MessageText,
-		message.TaskID, message.MessageType, message.MessageScope).
-		Scan(&message.CreatedAt)
-
-	if err != nil {
-		log.Printf("DEBUG: Database error in CreateUniversalMessage: %v", err)
-		return err
-	}
+	// Create the message and auto-record the sender's read receipt together
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(messageCreateUniversalQuery, message.ID, message.ProjectID, message.SenderID,
+			message.RecipientUserID, message.RecipientTeamID, message.Subject, message.MessageText,
+			message.TaskID, message.MessageType, message.MessageScope).
+			Scan(&message.CreatedAt)
+		if err != nil {
+			log.Printf("DEBUG: Database error in CreateUniversalMessage: %v", err)
+			return err
+		}
 
-	// Auto-record read receipt for sender
-	_, err = tx.Exec(messageMarkAsReadQuery, message.SenderID, message.ID)
-	if err != nil {
-		log.Printf("DEBUG: Failed to record sender read receipt in CreateUniversalMessage: %v", err)
+		_, err = tx.Exec(messageMarkAsReadQuery, message.SenderID, message.ID)
+		if err != nil {
+			log.Printf("DEBUG: Failed to record sender read receipt in CreateUniversalMessage: %v", err)
+		}
 		return err
-	}
-
-	// Commit transaction
-	err = tx.Commit()
+	})
 	if err != nil {
-		log.Printf("DEBUG: Failed to commit transaction in CreateUniversalMessage: %v", err)
+		log.Printf("DEBUG: CreateUniversalMessage transaction failed: %v", err)
 		return err
 	}
 
@@ -391,7 +582,15 @@ func (s *MessageService) GetInbox(userID uuid.UUID, limit, offset int) ([]Messag
 		messages = append(messages, msg)
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
 }
 
 // GetSentMessages retrieves messages sent by user
@@ -418,7 +617,124 @@ func (s *MessageService) GetSentMessages(userID uuid.UUID, limit, offset int) ([
 		messages = append(messages, msg)
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MessageSearchFilters narrows a message search. Zero values are ignored.
+type MessageSearchFilters struct {
+	Query       string     // Full-text query over subject and body
+	SenderID    *uuid.UUID // Only messages from this sender
+	After       *time.Time // Only messages sent at or after this time
+	Before      *time.Time // Only messages sent before this time
+	MessageType string     // Only messages of this type, e.g. "task_done"
+}
+
+// SearchMessages searches messages the user can see: ones they sent or received,
+// and ones in projects they lead or belong to. With a full-text query results are
+// ordered by relevance, otherwise newest first. Deleted messages are excluded.
+func (s *MessageService) SearchMessages(userID uuid.UUID, filters MessageSearchFilters, limit, offset int) ([]MessageWithSender, error) {
+	query, args := buildMessageSearchQuery(userID, filters, limit, offset)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []MessageWithSender{}
+	for rows.Next() {
+		var msg MessageWithSender
+		err := rows.Scan(
+			&msg.ID, &msg.ProjectID, &msg.SenderID, &msg.RecipientUserID, &msg.RecipientTeamID,
+			&msg.Subject, &msg.MessageText, &msg.TaskID, &msg.MessageType, &msg.MessageScope,
+			&msg.CreatedAt, &msg.EditedAt, &msg.DeletedAt,
+			&msg.SenderName, &msg.SenderEmail, &msg.RecipientName, &msg.RecipientEmail,
+			&msg.ProjectTitle, &msg.IsRead,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// buildMessageSearchQuery builds the search query and its args. Every search is limited
+// to messages userID ($1) can access, whatever the filters.
+func buildMessageSearchQuery(userID uuid.UUID, filters MessageSearchFilters, limit, offset int) (string, []interface{}) {
+	query := messageSearchQuery
+	args := []interface{}{userID}
+	argIndex := 2
+
+	whereConditions := []string{"pm.deleted_at IS NULL", messageSearchAccessCondition}
+	orderBy := "pm.created_at DESC"
+
+	if filters.Query != "" {
+		tsQuery := "websearch_to_tsquery('english', $" + fmt.Sprintf("%d", argIndex) + ")"
+		whereConditions = append(whereConditions, "pm.search_vector @@ "+tsQuery)
+		orderBy = "ts_rank(pm.search_vector, " + tsQuery + ") DESC, pm.created_at DESC"
+		args = append(args, filters.Query)
+		argIndex++
+	}
+
+	if filters.SenderID != nil {
+		whereConditions = append(whereConditions, "pm.sender_id = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.SenderID)
+		argIndex++
+	}
+
+	if filters.After != nil {
+		whereConditions = append(whereConditions, "pm.created_at >= $"+fmt.Sprintf("%d", argIndex)+"::timestamptz")
+		args = append(args, *filters.After)
+		argIndex++
+	}
+
+	if filters.Before != nil {
+		whereConditions = append(whereConditions, "pm.created_at < $"+fmt.Sprintf("%d", argIndex)+"::timestamptz")
+		args = append(args, *filters.Before)
+		argIndex++
+	}
+
+	if filters.MessageType != "" {
+		whereConditions = append(whereConditions, "pm.message_type = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, filters.MessageType)
+		argIndex++
+	}
+
+	query += " WHERE " + strings.Join(whereConditions, " AND ")
+	query += " ORDER BY " + orderBy + " LIMIT $" + fmt.Sprintf("%d", argIndex) + " OFFSET $" + fmt.Sprintf("%d", argIndex+1)
+	args = append(args, limit, offset)
+	return query, args
+}
+
+// CountInbox returns how many messages are in a user's inbox, matching GetInbox
+func (s *MessageService) CountInbox(userID uuid.UUID) (int, error) {
+	var count int
+	err := s.db.QueryRow(messageCountInboxQuery, userID).Scan(&count)
+	return count, err
+}
+
+// CountSentMessages returns how many messages a user has sent, matching GetSentMessages
+func (s *MessageService) CountSentMessages(userID uuid.UUID) (int, error) {
+	var count int
+	err := s.db.QueryRow(messageCountSentQuery, userID).Scan(&count)
+	return count, err
 }
 
 // GetConversations retrieves user's conversations grouped by thread
@@ -490,7 +806,15 @@ func (s *MessageService) GetConversation(conversationID uuid.UUID, userID uuid.U
 		messages = append(messages, msg)
 	}
 
-	return messages, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
 }
 
 // GetUniversalUnreadCount returns unread message counts across all contexts
@@ -563,3 +887,51 @@ func (s *MessageService) SearchUserProjects(userID uuid.UUID, query string, limi
 
 	return projects, rows.Err()
 }
+
+// GetUnreadDirectMessagesForEmail retrieves direct messages sent between since and before that
+// their recipient has not read and has email notifications enabled for, and that are due an
+// email: never attempted, or failed fewer than maxAttempts times and past their backoff
+func (s *MessageService) GetUnreadDirectMessagesForEmail(before, since time.Time, maxAttempts, limit int) ([]UnreadDirectMessage, error) {
+	rows, err := s.db.Query(messageGetUnreadDirectForEmailQuery, before, since, limit, maxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []UnreadDirectMessage
+	for rows.Next() {
+		var msg UnreadDirectMessage
+		err := rows.Scan(
+			&msg.MessageID, &msg.SenderID, &msg.Subject, &msg.MessageText, &msg.CreatedAt,
+			&msg.SenderName, &msg.RecipientID, &msg.RecipientEmail, &msg.RecipientName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+// ClaimEmailFallback starts an attempt at emailing a user about a message, so each
+// recipient is emailed at most once. It returns false if the user has read the message,
+// the email already went out, it has failed maxAttempts times, or the last attempt is too
+// recent: retryAfter must pass after the first, doubling after each attempt since.
+func (s *MessageService) ClaimEmailFallback(messageID, userID uuid.UUID, maxAttempts int, retryAfter time.Duration) (bool, error) {
+	result, err := s.db.Exec(messageClaimEmailFallbackQuery, messageID, userID, maxAttempts, retryAfter.Seconds())
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// MarkEmailFallbackDelivered records that the email for a claimed message went out
+func (s *MessageService) MarkEmailFallbackDelivered(messageID, userID uuid.UUID) error {
+	_, err := s.db.Exec(messageEmailFallbackDeliveredQuery, messageID, userID)
+	return err
+}
@@ -8,7 +8,8 @@ const (
 		RETURNING created_at`
 
 	messageGetByIDQuery = `
-		SELECT id, project_id, sender_id, message_text, task_id, message_type, created_at, edited_at, deleted_at
+		SELECT id, project_id, sender_id, recipient_user_id, recipient_team_id, subject, message_text,
+			task_id, message_type, message_scope, created_at, edited_at, deleted_at, scheduled_at
 		FROM project_messages WHERE id = $1`
 
 	messageListByProjectQuery = `
@@ -47,6 +48,10 @@ const (
 		UPDATE project_messages 
 		SET message_text = $2, edited_at = CURRENT_TIMESTAMP
 		WHERE id = $1
+		  AND sender_id = $3
+		  AND created_at > now() - make_interval(mins => $4)
+		  AND deleted_at IS NULL
+		  AND edited_at IS NOT DISTINCT FROM $5::timestamp
 		RETURNING edited_at`
 
 	messageSoftDeleteQuery = `
@@ -126,6 +131,71 @@ const (
 		ORDER BY pm.created_at DESC
 		LIMIT $2 OFFSET $3`
 
+	// messageSearchQuery is extended with WHERE, ORDER BY and LIMIT clauses by SearchMessages
+	messageSearchQuery = `
+		SELECT 
+			pm.id, pm.project_id, pm.sender_id, pm.recipient_user_id, pm.recipient_team_id,
+			pm.subject, pm.message_text, pm.task_id, pm.message_type, pm.message_scope,
+			pm.created_at, pm.edited_at, pm.deleted_at,
+			COALESCE(sender_v.name, sender_a.name, sender_u.email) as sender_name,
+			sender_u.email as sender_email,
+			COALESCE(recipient_v.name, recipient_a.name, recipient_u.email) as recipient_name,
+			recipient_u.email as recipient_email,
+			p.title as project_title,
+			CASE WHEN pm.sender_id = $1 OR mr.user_id IS NOT NULL THEN true ELSE false END as is_read
+		FROM project_messages pm
+		JOIN users sender_u ON pm.sender_id = sender_u.id
+		LEFT JOIN volunteers sender_v ON sender_u.id = sender_v.user_id
+		LEFT JOIN admins sender_a ON sender_u.id = sender_a.user_id
+		LEFT JOIN users recipient_u ON pm.recipient_user_id = recipient_u.id
+		LEFT JOIN volunteers recipient_v ON recipient_u.id = recipient_v.user_id
+		LEFT JOIN admins recipient_a ON recipient_u.id = recipient_a.user_id
+		LEFT JOIN projects p ON pm.project_id = p.id
+		LEFT JOIN message_reads mr ON pm.id = mr.message_id AND mr.user_id = $1`
+
+	// messageSearchAccessCondition limits search results to messages user $1 can see
+	messageSearchAccessCondition = `(
+			pm.sender_id = $1 OR
+			pm.recipient_user_id = $1 OR
+			(pm.recipient_team_id IS NOT NULL AND EXISTS (
+				SELECT 1 FROM project_team_members ptm 
+				JOIN volunteers v ON ptm.volunteer_id = v.id 
+				WHERE ptm.project_id = pm.recipient_team_id AND v.user_id = $1 AND ptm.status = 'active'
+			)) OR
+			(pm.project_id IS NOT NULL AND (
+				p.team_lead_id = $1 OR
+				EXISTS (
+					SELECT 1 FROM project_team_members ptm 
+					JOIN volunteers v ON ptm.volunteer_id = v.id 
+					WHERE ptm.project_id = pm.project_id AND v.user_id = $1 AND ptm.status = 'active'
+				)
+			))
+		)`
+
+	messageCountInboxQuery = `
+		SELECT COUNT(*)
+		FROM project_messages pm
+		WHERE pm.deleted_at IS NULL
+		AND (
+			pm.recipient_user_id = $1 OR
+			(pm.recipient_team_id IS NOT NULL AND EXISTS (
+				SELECT 1 FROM project_team_members ptm 
+				JOIN volunteers v ON ptm.volunteer_id = v.id 
+				WHERE ptm.project_id = pm.recipient_team_id AND v.user_id = $1 AND ptm.status = 'active'
+			)) OR
+			(pm.project_id IS NOT NULL AND EXISTS (
+				SELECT 1 FROM project_team_members ptm 
+				JOIN volunteers v ON ptm.volunteer_id = v.id 
+				WHERE ptm.project_id = pm.project_id AND v.user_id = $1 AND ptm.status = 'active'
+			))
+		)`
+
+	messageCountSentQuery = `
+		SELECT COUNT(*)
+		FROM project_messages pm
+		WHERE pm.deleted_at IS NULL
+		AND pm.sender_id = $1`
+
 	messageGetConversationsQuery = `
 		WITH conversation_summary AS (
 			SELECT 
@@ -309,7 +379,7 @@ const (
 	messageCanUserEditQuery = `
 		SELECT 
 			CASE 
-				WHEN sender_id = $2 AND created_at > (CURRENT_TIMESTAMP - INTERVAL '15 minutes') 
+				WHEN sender_id = $2 AND created_at > now() - make_interval(mins => $3)
 				THEN true 
 				ELSE false 
 			END
@@ -338,4 +408,116 @@ const (
 		  AND LOWER(p.title) LIKE LOWER('%' || $2 || '%')
 		ORDER BY p.title
 		LIMIT $3`
+
+	messageGetUnreadDirectForEmailQuery = `
+		SELECT 
+			pm.id, pm.sender_id, pm.subject, pm.message_text, pm.created_at,
+			COALESCE(sender_v.name, sender_a.name, sender_u.email) as sender_name,
+			recipient_u.id, recipient_u.email,
+			COALESCE(recipient_v.name, recipient_a.name, recipient_u.email) as recipient_name
+		FROM project_messages pm
+		JOIN users sender_u ON pm.sender_id = sender_u.id
+		LEFT JOIN volunteers sender_v ON sender_u.id = sender_v.user_id
+		LEFT JOIN admins sender_a ON sender_u.id = sender_a.user_id
+		JOIN users recipient_u ON pm.recipient_user_id = recipient_u.id
+		LEFT JOIN volunteers recipient_v ON recipient_u.id = recipient_v.user_id
+		LEFT JOIN admins recipient_a ON recipient_u.id = recipient_a.user_id
+		WHERE pm.deleted_at IS NULL
+		  AND pm.message_scope = 'user_to_user'
+		  AND pm.created_at <= $1
+		  AND pm.created_at > $2
+		  AND recipient_u.email_notifications = true
+		  AND NOT EXISTS (
+			SELECT 1 FROM message_reads mr
+			WHERE mr.message_id = pm.id AND mr.user_id = pm.recipient_user_id
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM message_email_fallbacks mef
+			WHERE mef.message_id = pm.id AND mef.user_id = pm.recipient_user_id
+			  AND (mef.delivered_at IS NOT NULL
+			       OR mef.attempts >= $4
+			       OR mef.next_attempt_at > CURRENT_TIMESTAMP)
+		  )
+		ORDER BY pm.created_at ASC
+		LIMIT $3`
+
+	// messageClaimEmailFallbackQuery starts an attempt at emailing a still-unread message,
+	// unless it was delivered, ran out of attempts ($3) or is waiting out its backoff. Each
+	// attempt doubles the wait before the next one, starting from $4 seconds.
+	messageClaimEmailFallbackQuery = `
+		INSERT INTO message_email_fallbacks (message_id, user_id, attempts, next_attempt_at)
+		SELECT $1::uuid, $2::uuid, 1, CURRENT_TIMESTAMP + make_interval(secs => $4::float8)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM message_reads mr WHERE mr.message_id = $1::uuid AND mr.user_id = $2::uuid
+		)
+		ON CONFLICT (message_id, user_id) DO UPDATE
+		SET attempts = message_email_fallbacks.attempts + 1,
+		    sent_at = CURRENT_TIMESTAMP,
+		    next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $4::float8 * power(2, message_email_fallbacks.attempts))
+		WHERE message_email_fallbacks.delivered_at IS NULL
+		  AND message_email_fallbacks.attempts < $3
+		  AND message_email_fallbacks.next_attempt_at <= CURRENT_TIMESTAMP`
+
+	messageEmailFallbackDeliveredQuery = `
+		UPDATE message_email_fallbacks SET delivered_at = CURRENT_TIMESTAMP
+		WHERE message_id = $1 AND user_id = $2`
+
+	// messageFindTaskDigestQuery finds the sender's latest non-urgent task notification
+	// in a project whose digest started within the sender's digest window (the project's
+	// when they have none, and $4 when neither does), locking it for merging
+	messageFindTaskDigestQuery = `
+		SELECT pm.id, pm.digest_count, pm.message_text, p.title
+		FROM project_messages pm
+		INNER JOIN users u ON pm.sender_id = u.id
+		INNER JOIN projects p ON pm.project_id = p.id
+		WHERE pm.project_id = $1
+		  AND pm.sender_id = $2
+		  AND pm.task_id IS NOT NULL
+		  AND pm.deleted_at IS NULL
+		  AND pm.message_type NOT IN (SELECT jsonb_array_elements_text($3::jsonb))
+		  AND COALESCE(u.task_digest_minutes, p.task_digest_minutes, $4) > 0
+		  AND COALESCE(pm.digest_started_at, pm.created_at) >
+		      CURRENT_TIMESTAMP - make_interval(mins => COALESCE(u.task_digest_minutes, p.task_digest_minutes, $4))
+		ORDER BY pm.created_at DESC
+		LIMIT 1
+		FOR UPDATE OF pm`
+
+	messageMergeTaskDigestQuery = `
+		UPDATE project_messages
+		SET message_type = 'task_digest', digest_count = $2, message_text = $3, task_id = $4,
+		    digest_started_at = COALESCE(digest_started_at, created_at), created_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	// messageReceiptsForUserQuery returns a direct message recipient's read status
+	messageReceiptsForUserQuery = `
+		SELECT u.id, COALESCE(v.name, a.name, u.email), u.hide_read_receipts, mr.read_at
+		FROM users u
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		LEFT JOIN message_reads mr ON mr.message_id = $1 AND mr.user_id = u.id
+		WHERE u.id = $2`
+
+	// messageReceiptsForProjectQuery returns the read status of every active team
+	// member and the team lead, other than the sender
+	messageReceiptsForProjectQuery = `
+		WITH recipients AS (
+			SELECT v.user_id
+			FROM project_team_members ptm
+			JOIN volunteers v ON ptm.volunteer_id = v.id
+			WHERE ptm.project_id = $2 AND ptm.status = 'active'
+			UNION
+			SELECT team_lead_id FROM projects WHERE id = $2 AND team_lead_id IS NOT NULL
+		)
+		SELECT u.id, COALESCE(v.name, a.name, u.email), u.hide_read_receipts, mr.read_at
+		FROM recipients r
+		JOIN users u ON u.id = r.user_id
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		LEFT JOIN message_reads mr ON mr.message_id = $1 AND mr.user_id = u.id
+		WHERE u.id <> $3
+		ORDER BY mr.read_at IS NULL, mr.read_at, 2`
+
+	// messageResetReadsQuery marks a message unread for everyone but its sender
+	messageResetReadsQuery = `
+		DELETE FROM message_reads WHERE message_id = $1 AND user_id <> $2`
 )
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxReactionEmojiLength bounds a reaction in bytes; it covers multi-codepoint emoji
+// such as flags and skin-tone or ZWJ sequences
+const maxReactionEmojiLength = 32
+
+// ErrInvalidReaction is returned when a reaction isn't a short, whitespace-free emoji
+var ErrInvalidReaction = fmt.Errorf("reaction must be a single emoji of at most %d bytes", maxReactionEmojiLength)
+
+// MessageReaction represents one user's emoji reaction to a message
+type MessageReaction struct {
+	MessageID uuid.UUID `json:"message_id" db:"message_id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	UserName  string    `json:"user_name"`
+	Emoji     string    `json:"emoji" db:"emoji"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NormalizeReaction trims a reaction and checks it is short enough to be an emoji
+func NormalizeReaction(emoji string) (string, error) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || len(emoji) > maxReactionEmojiLength || !utf8.ValidString(emoji) {
+		return "", ErrInvalidReaction
+	}
+	if strings.IndexFunc(emoji, unicode.IsSpace) >= 0 {
+		return "", ErrInvalidReaction
+	}
+	return emoji, nil
+}
+
+// AddReaction records a user's reaction to a message. Reacting twice with the
+// same emoji is a no-op.
+func (s *MessageService) AddReaction(messageID, userID uuid.UUID, emoji string) error {
+	emoji, err := NormalizeReaction(emoji)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(messageReactionAddQuery, messageID, userID, emoji)
+	return err
+}
+
+// RemoveReaction removes a user's reaction from a message. It returns
+// sql.ErrNoRows if the user hadn't reacted with that emoji.
+func (s *MessageService) RemoveReaction(messageID, userID uuid.UUID, emoji string) error {
+	emoji, err := NormalizeReaction(emoji)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(messageReactionRemoveQuery, messageID, userID, emoji)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetReactions retrieves every reaction to a message, oldest first
+func (s *MessageService) GetReactions(messageID uuid.UUID) ([]MessageReaction, error) {
+	rows, err := s.db.Query(messageReactionListQuery, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := []MessageReaction{}
+	for rows.Next() {
+		var reaction MessageReaction
+		err := rows.Scan(&reaction.MessageID, &reaction.UserID, &reaction.Emoji, &reaction.CreatedAt, &reaction.UserName)
+		if err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, rows.Err()
+}
+
+// GetReactionCounts returns the reaction count map for a single message
+func (s *MessageService) GetReactionCounts(messageID uuid.UUID) (map[string]int, error) {
+	counts, err := s.getReactionCounts([]uuid.UUID{messageID})
+	if err != nil {
+		return nil, err
+	}
+	if counts[messageID] == nil {
+		return map[string]int{}, nil
+	}
+	return counts[messageID], nil
+}
+
+// getReactionCounts aggregates reactions per emoji for a batch of messages
+func (s *MessageService) getReactionCounts(messageIDs []uuid.UUID) (map[uuid.UUID]map[string]int, error) {
+	idsJSON, err := json.Marshal(messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(messageReactionCountsQuery, string(idsJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]map[string]int)
+	for rows.Next() {
+		var messageID uuid.UUID
+		var emoji string
+		var count int
+		if err := rows.Scan(&messageID, &emoji, &count); err != nil {
+			return nil, err
+		}
+		if counts[messageID] == nil {
+			counts[messageID] = make(map[string]int)
+		}
+		counts[messageID][emoji] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// attachReactions fills in the reaction counts of listed messages with a single query
+func (s *MessageService) attachReactions(messages []MessageWithSender) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(messages))
+	for i := range messages {
+		messageIDs[i] = messages[i].ID
+	}
+
+	counts, err := s.getReactionCounts(messageIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].Reactions = counts[messages[i].ID]
+		if messages[i].Reactions == nil {
+			messages[i].Reactions = map[string]int{}
+		}
+	}
+
+	return nil
+}
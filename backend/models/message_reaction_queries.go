@@ -0,0 +1,29 @@
+package models
+
+// Query constants for message reactions
+const (
+	messageReactionAddQuery = `
+		INSERT INTO message_reactions (message_id, user_id, emoji)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (message_id, user_id, emoji) DO NOTHING`
+
+	messageReactionRemoveQuery = `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2 AND emoji = $3`
+
+	messageReactionListQuery = `
+		SELECT mr.message_id, mr.user_id, mr.emoji, mr.created_at,
+			COALESCE(v.name, a.name, u.email) as user_name
+		FROM message_reactions mr
+		JOIN users u ON mr.user_id = u.id
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		WHERE mr.message_id = $1
+		ORDER BY mr.created_at ASC`
+
+	messageReactionCountsQuery = `
+		SELECT message_id, emoji, COUNT(*)
+		FROM message_reactions
+		WHERE message_id IN (SELECT jsonb_array_elements_text($1::jsonb)::uuid)
+		GROUP BY message_id, emoji`
+)
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildMessageSearchQuerySenderAndDateRange(t *testing.T) {
+	userID := uuid.New()
+	senderID := uuid.New()
+	after := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	query, args := buildMessageSearchQuery(userID, MessageSearchFilters{
+		SenderID: &senderID,
+		After:    &after,
+		Before:   &before,
+	}, 20, 40)
+
+	// The caller's access check always applies, bound to the caller as $1
+	if !strings.Contains(query, messageSearchAccessCondition) {
+		t.Error("query is not scoped to messages the caller can access")
+	}
+	if !strings.Contains(query, "pm.deleted_at IS NULL") {
+		t.Error("query includes deleted messages")
+	}
+
+	for _, want := range []string{
+		"pm.sender_id = $2",
+		"pm.created_at >= $3::timestamptz",
+		"pm.created_at < $4::timestamptz",
+		"LIMIT $5 OFFSET $6",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query is missing %q", want)
+		}
+	}
+	if strings.Contains(query, "pm.search_vector @@") || strings.Contains(query, "pm.message_type =") {
+		t.Error("query filters on a field that wasn't given")
+	}
+
+	wantArgs := []interface{}{userID, senderID, after, before, 20, 40}
+	if len(args) != len(wantArgs) {
+		t.Fatalf("got %d args, want %d", len(args), len(wantArgs))
+	}
+	for i := range wantArgs {
+		if args[i] != wantArgs[i] {
+			t.Errorf("arg $%d = %v, want %v", i+1, args[i], wantArgs[i])
+		}
+	}
+}
+
+func TestBuildMessageSearchQueryCombinesTextAndType(t *testing.T) {
+	query, args := buildMessageSearchQuery(uuid.New(), MessageSearchFilters{
+		Query:       "ladder",
+		MessageType: TaskDigestMessageType,
+	}, 10, 0)
+
+	for _, want := range []string{
+		"pm.search_vector @@ websearch_to_tsquery('english', $2)",
+		"pm.message_type = $3",
+		"ORDER BY ts_rank(pm.search_vector, websearch_to_tsquery('english', $2)) DESC",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query is missing %q", want)
+		}
+	}
+	if args[1] != "ladder" || args[2] != TaskDigestMessageType {
+		t.Errorf("args = %v, want the text query then the message type", args)
+	}
+}
+
+func TestAppendToTaskDigestCoalescesRapidUpdates(t *testing.T) {
+	// The first notification is posted on its own; the next ones merge into it
+	digest := "✅ Task 'Order mulch' marked done"
+	digest = appendToTaskDigest(digest, 1, "Park Cleanup", "✅ Task 'Book van' marked done")
+	digest = appendToTaskDigest(digest, 2, "Park Cleanup", "✅ Task 'Print flyers' marked done")
+
+	want := "📋 3 tasks updated in Park Cleanup\n" +
+		"✅ Task 'Order mulch' marked done\n" +
+		"✅ Task 'Book van' marked done\n" +
+		"✅ Task 'Print flyers' marked done"
+	if digest != want {
+		t.Errorf("digest =\n%s\nwant\n%s", digest, want)
+	}
+}
+
+func TestAppendToTaskDigestKeepsMultilineNotifications(t *testing.T) {
+	digest := appendToTaskDigest("✅ Done\nwith notes", 1, "Food Drive", "✅ Also done")
+	digest = appendToTaskDigest(digest, 2, "Food Drive", "✅ Done too")
+
+	want := "📋 3 tasks updated in Food Drive\n✅ Done\nwith notes\n✅ Also done\n✅ Done too"
+	if digest != want {
+		t.Errorf("digest =\n%s\nwant\n%s", digest, want)
+	}
+}
+
+func TestIsUrgentTaskNotification(t *testing.T) {
+	for messageType, want := range map[string]bool{
+		"task_blocked":        true,
+		"task_takeover":       true,
+		"task_done":           false,
+		TaskDigestMessageType: false,
+	} {
+		if got := isUrgentTaskNotification(messageType); got != want {
+			t.Errorf("isUrgentTaskNotification(%q) = %v, want %v", messageType, got, want)
+		}
+	}
+}
+
+func TestMessageUpdateSavesAnEditAndBumpsTheVersion(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	editedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	recorder.Rows("UPDATE project_messages", []string{"edited_at"}, []driver.Value{editedAt})
+
+	editorID := uuid.New()
+	message := &ProjectMessage{ID: uuid.New(), MessageText: "Meet at the north gate"}
+	if err := NewMessageService(db).Update(message, editorID, 15); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if message.EditedAt == nil || !message.EditedAt.Equal(editedAt) {
+		t.Errorf("EditedAt = %v, want %v", message.EditedAt, editedAt)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(statements))
+	}
+	// The edit window is checked against the database clock, not the app's
+	if !strings.Contains(statements[0].Query, "created_at > now() - make_interval(mins => $4)") {
+		t.Error("update does not measure the edit window with the database clock")
+	}
+	args := statements[0].Args
+	if args[2] != editorID.String() || args[3] != int64(15) {
+		t.Errorf("args = %v, want the editor then the window in minutes", args)
+	}
+}
+
+func TestMessageUpdateConflictsWhenEditedConcurrently(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	// Someone else edited the message after this copy was read, so the version no
+	// longer matches and the update returns no row
+	staleEditedAt := time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)
+	message := &ProjectMessage{ID: uuid.New(), MessageText: "Meet at the south gate", EditedAt: &staleEditedAt}
+	err := NewMessageService(db).Update(message, uuid.New(), 15)
+	if err != ErrMessageEditConflict {
+		t.Fatalf("Update() error = %v, want ErrMessageEditConflict", err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("ran %d statements, want 1", len(statements))
+	}
+	if !strings.Contains(statements[0].Query, "edited_at IS NOT DISTINCT FROM $5::timestamp") {
+		t.Error("update does not check the message version")
+	}
+	if version, ok := statements[0].Args[4].(time.Time); !ok || !version.Equal(staleEditedAt) {
+		t.Errorf("version arg = %v, want %v", statements[0].Args[4], staleEditedAt)
+	}
+	if !message.EditedAt.Equal(staleEditedAt) {
+		t.Errorf("EditedAt = %v, want it left at %v", message.EditedAt, staleEditedAt)
+	}
+}
+
+func TestMessageUpdateConflictsWhenTheEditWindowIsClosed(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	service := NewMessageService(db)
+
+	// A window of zero disables editing without touching the database
+	if err := service.Update(&ProjectMessage{ID: uuid.New()}, uuid.New(), 0); err != ErrMessageEditConflict {
+		t.Errorf("Update() with no window error = %v, want ErrMessageEditConflict", err)
+	}
+	if len(recorder.Statements()) != 0 {
+		t.Errorf("ran %d statements with editing disabled, want 0", len(recorder.Statements()))
+	}
+
+	// Past the window the update matches no row
+	if err := service.Update(&ProjectMessage{ID: uuid.New()}, uuid.New(), 15); err != ErrMessageEditConflict {
+		t.Errorf("Update() past the window error = %v, want ErrMessageEditConflict", err)
+	}
+}
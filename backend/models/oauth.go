@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,10 @@ type OAuthAccount struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 }
 
+// ErrLastLoginMethod is returned when unlinking a provider would leave a user with no
+// way to sign in
+var ErrLastLoginMethod = fmt.Errorf("cannot unlink the only remaining login method")
+
 // OAuthAccountService handles OAuth account operations
 type OAuthAccountService struct {
 	db *sql.DB
@@ -90,3 +95,50 @@ func (s *OAuthAccountService) Delete(id uuid.UUID) error {
 	_, err := s.db.Exec(oauthDeleteQuery, id)
 	return err
 }
+
+// LinkedProviders returns the providers linked to a user, such as "google"
+func (s *OAuthAccountService) LinkedProviders(userID uuid.UUID) ([]string, error) {
+	rows, err := s.db.Query(oauthLinkedProvidersQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	providers := []string{}
+	for rows.Next() {
+		var provider string
+		if err := rows.Scan(&provider); err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, rows.Err()
+}
+
+// Unlink removes a user's accounts for a provider. It returns sql.ErrNoRows if the
+// provider isn't linked, and ErrLastLoginMethod if the user has no password and no
+// other provider to sign in with.
+func (s *OAuthAccountService) Unlink(userID uuid.UUID, provider string) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Lock the user so concurrent unlinks can't each see the other provider remaining
+		var passwordHash string
+		if err := tx.QueryRow(oauthLockUserPasswordQuery, userID).Scan(&passwordHash); err != nil {
+			return err
+		}
+
+		var linked, others int
+		if err := tx.QueryRow(oauthCountByProviderQuery, userID, provider).Scan(&linked, &others); err != nil {
+			return err
+		}
+		if linked == 0 {
+			return sql.ErrNoRows
+		}
+		if passwordHash == "" && others == 0 {
+			return ErrLastLoginMethod
+		}
+
+		_, err := tx.Exec(oauthDeleteByUserAndProviderQuery, userID, provider)
+		return err
+	})
+}
@@ -21,4 +21,17 @@ const (
 		WHERE id = $1`
 
 	oauthDeleteQuery = `DELETE FROM oauth_accounts WHERE id = $1`
+
+	oauthLinkedProvidersQuery = `
+		SELECT DISTINCT provider FROM oauth_accounts WHERE user_id = $1 ORDER BY provider`
+
+	oauthLockUserPasswordQuery = `SELECT COALESCE(password_hash, '') FROM users WHERE id = $1 FOR UPDATE`
+
+	oauthCountByProviderQuery = `
+		SELECT
+			COUNT(*) FILTER (WHERE provider = $2),
+			COUNT(*) FILTER (WHERE provider <> $2)
+		FROM oauth_accounts WHERE user_id = $1`
+
+	oauthDeleteByUserAndProviderQuery = `DELETE FROM oauth_accounts WHERE user_id = $1 AND provider = $2`
 )
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pgvector/pgvector-go"
+)
+
+// PendingEmbedding is a skill claim waiting for its embedding. Claims are queued when the
+// embedding API is unavailable at submission and become skill claims once embedded.
+// A claim that can't be embedded is kept with FailedAt set and no longer retried.
+type PendingEmbedding struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	VolunteerID   uuid.UUID  `json:"volunteer_id" db:"volunteer_id"`
+	ClaimText     string     `json:"claim_text" db:"claim_text"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	LastError     *string    `json:"-" db:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	FailedAt      *time.Time `json:"failed_at,omitempty" db:"failed_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PendingEmbeddingService queues skill claims between the API and the matching worker
+type PendingEmbeddingService struct {
+	db *sql.DB
+}
+
+// NewPendingEmbeddingService creates a new pending embedding service
+func NewPendingEmbeddingService(db *sql.DB) *PendingEmbeddingService {
+	return &PendingEmbeddingService{db: db}
+}
+
+// Enqueue queues a claim whose first embedding attempt failed with cause
+func (s *PendingEmbeddingService) Enqueue(volunteerID uuid.UUID, claimText string, cause error) (*PendingEmbedding, error) {
+	var lastError *string
+	if cause != nil {
+		message := cause.Error()
+		lastError = &message
+	}
+	return scanPendingEmbedding(s.db.QueryRow(pendingEmbeddingEnqueueQuery, volunteerID, claimText, lastError))
+}
+
+// ListDue lists up to limit queued claims whose next attempt is due, oldest first.
+// Failed claims are never due.
+func (s *PendingEmbeddingService) ListDue(limit int) ([]PendingEmbedding, error) {
+	return s.list(pendingEmbeddingListDueQuery, limit)
+}
+
+// ListByVolunteer lists a volunteer's queued claims, including failed ones
+func (s *PendingEmbeddingService) ListByVolunteer(volunteerID uuid.UUID) ([]PendingEmbedding, error) {
+	return s.list(pendingEmbeddingListByVolunteerQuery, volunteerID)
+}
+
+// Complete creates the skill claim for a queued entry and removes it from the queue in
+// one transaction. It returns sql.ErrNoRows if the entry was already completed.
+func (s *PendingEmbeddingService) Complete(pending *PendingEmbedding, embedding pgvector.Vector, model string) (*SkillClaim, error) {
+	claim := &SkillClaim{
+		ID:          uuid.New(),
+		VolunteerID: pending.VolunteerID,
+		ClaimText:   pending.ClaimText,
+		Embedding:   embedding,
+		IsActive:    true,
+	}
+
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(pendingEmbeddingDeleteQuery, pending.ID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+		return insertSkillClaim(tx, claim, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// RecordFailure counts a failed attempt and schedules the next one for retryAt
+func (s *PendingEmbeddingService) RecordFailure(id uuid.UUID, cause error, retryAt time.Time) error {
+	_, err := s.db.Exec(pendingEmbeddingRecordFailureQuery, id, cause.Error(), retryAt)
+	return err
+}
+
+// MarkFailed counts a final failed attempt and stops retrying the claim
+func (s *PendingEmbeddingService) MarkFailed(id uuid.UUID, cause error) error {
+	_, err := s.db.Exec(pendingEmbeddingMarkFailedQuery, id, cause.Error())
+	return err
+}
+
+// list runs a query returning pending embedding rows
+func (s *PendingEmbeddingService) list(query string, args ...interface{}) ([]PendingEmbedding, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pending := []PendingEmbedding{}
+	for rows.Next() {
+		entry, err := scanPendingEmbedding(rows)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, *entry)
+	}
+	return pending, rows.Err()
+}
+
+// scanPendingEmbedding scans a single pending embedding row
+func scanPendingEmbedding(row rowScanner) (*PendingEmbedding, error) {
+	entry := &PendingEmbedding{}
+	err := row.Scan(
+		&entry.ID, &entry.VolunteerID, &entry.ClaimText, &entry.Attempts,
+		&entry.LastError, &entry.NextAttemptAt, &entry.FailedAt, &entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
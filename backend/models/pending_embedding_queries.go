@@ -0,0 +1,35 @@
+package models
+
+const (
+	pendingEmbeddingColumns = `
+		id, volunteer_id, claim_text, attempts, last_error, next_attempt_at, failed_at, created_at`
+
+	pendingEmbeddingEnqueueQuery = `
+		INSERT INTO pending_embeddings (volunteer_id, claim_text, attempts, last_error)
+		VALUES ($1, $2, 1, $3)
+		RETURNING` + pendingEmbeddingColumns
+
+	pendingEmbeddingListDueQuery = `SELECT` + pendingEmbeddingColumns + `
+		FROM pending_embeddings
+		WHERE failed_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+		ORDER BY next_attempt_at, created_at
+		LIMIT $1`
+
+	pendingEmbeddingListByVolunteerQuery = `SELECT` + pendingEmbeddingColumns + `
+		FROM pending_embeddings
+		WHERE volunteer_id = $1
+		ORDER BY created_at`
+
+	pendingEmbeddingDeleteQuery = `
+		DELETE FROM pending_embeddings WHERE id = $1`
+
+	pendingEmbeddingRecordFailureQuery = `
+		UPDATE pending_embeddings
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1`
+
+	pendingEmbeddingMarkFailedQuery = `
+		UPDATE pending_embeddings
+		SET attempts = attempts + 1, last_error = $2, failed_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+)
@@ -3,8 +3,10 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +23,40 @@ const (
 	ProjectStatusArchived   ProjectStatus = "archived"
 )
 
+// ErrInvalidProjectTransition is returned when a status change breaks the transition rules
+var ErrInvalidProjectTransition = fmt.Errorf("invalid project status transition")
+
+// ErrProjectStatusChanged is returned when another request changed a project's status
+// between reading it and applying a transition
+var ErrProjectStatusChanged = fmt.Errorf("project status was changed by another request")
+
+// ErrProjectStatusUnchanged is returned when a project is moved to the status it's already
+// in, which would record a no-op history entry and notify webhooks of nothing
+var ErrProjectStatusUnchanged = fmt.Errorf("project status unchanged")
+
+// ErrTeamFull is returned when making a volunteer an active team member would take the
+// team past the project's max_team_size
+var ErrTeamFull = fmt.Errorf("project team is full")
+
+// MaxBulkStatusProjects caps how many projects one bulk status change may touch
+const MaxBulkStatusProjects = 500
+
+// Outcomes of one project in a bulk status change
+const (
+	BulkStatusTransitioned   = "transitioned"
+	BulkStatusSkippedInvalid = "skipped_invalid"
+	BulkStatusNotFound       = "not_found"
+	BulkStatusFailed         = "failed"
+)
+
+// BulkStatusResult reports what a bulk status change did to one project
+type BulkStatusResult struct {
+	ProjectID  uuid.UUID      `json:"project_id"`
+	Outcome    string         `json:"outcome"`
+	FromStatus *ProjectStatus `json:"from_status,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+}
+
 // TeamMemberStatus represents the status of a team member
 type TeamMemberStatus string
 
@@ -29,29 +65,45 @@ const (
 	TeamMemberStatusActive    TeamMemberStatus = "active"
 	TeamMemberStatusCompleted TeamMemberStatus = "completed"
 	TeamMemberStatusRemoved   TeamMemberStatus = "removed"
+	// TeamMemberStatusWaitlisted is for volunteers approved after the team filled up
+	TeamMemberStatusWaitlisted TeamMemberStatus = "waitlisted"
+)
+
+// LocationStatus records whether a project's address could be geocoded
+type LocationStatus string
+
+const (
+	LocationStatusGeocoded LocationStatus = "geocoded"
+	LocationStatusFailed   LocationStatus = "failed"
+	LocationStatusNone     LocationStatus = "none"
 )
 
 // Project represents a project (formerly initiative)
 type Project struct {
-	ID                uuid.UUID              `json:"id" db:"id"`
-	Title             string                 `json:"title" db:"title"`
-	Description       string                 `json:"description" db:"description"`
-	ContentJSON       map[string]interface{} `json:"content_json,omitempty" db:"content_json"`
-	RequiredSkills    []string               `json:"required_skills"`
-	LocationLat       *float64               `json:"location_lat" db:"location_lat"`
-	LocationLng       *float64               `json:"location_lng" db:"location_lng"`
-	LocationAddress   string                 `json:"location_address" db:"location_address"`
-	StartDate         *time.Time             `json:"start_date" db:"start_date"`
-	EndDate           *time.Time             `json:"end_date" db:"end_date"`
-	ProjectStatus     ProjectStatus          `json:"project_status" db:"project_status"`
-	CreatedByAdminID  uuid.UUID              `json:"created_by_admin_id" db:"created_by_admin_id"`
-	TeamLeadID        *uuid.UUID             `json:"team_lead_id" db:"team_lead_id"`
-	BudgetTotal       *float64               `json:"budget_total,omitempty" db:"budget_total"`
-	BudgetSpent       *float64               `json:"budget_spent,omitempty" db:"budget_spent"`
-	Permissions       map[string]interface{} `json:"permissions,omitempty" db:"permissions"`
-	AutoNotifyMatches bool                   `json:"auto_notify_matches" db:"auto_notify_matches"`
-	CreatedAt         time.Time              `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time              `json:"updated_at" db:"updated_at"`
+	ID                    uuid.UUID              `json:"id" db:"id"`
+	Title                 string                 `json:"title" db:"title"`
+	Description           string                 `json:"description" db:"description"`
+	ContentJSON           map[string]interface{} `json:"content_json,omitempty" db:"content_json"`
+	RequiredSkills        []string               `json:"required_skills"`
+	LocationLat           *float64               `json:"location_lat" db:"location_lat"`
+	LocationLng           *float64               `json:"location_lng" db:"location_lng"`
+	LocationAddress       string                 `json:"location_address" db:"location_address"`
+	StartDate             *time.Time             `json:"start_date" db:"start_date"`
+	EndDate               *time.Time             `json:"end_date" db:"end_date"`
+	ProjectStatus         ProjectStatus          `json:"project_status" db:"project_status"`
+	CreatedByAdminID      uuid.UUID              `json:"created_by_admin_id" db:"created_by_admin_id"`
+	TeamLeadID            *uuid.UUID             `json:"team_lead_id" db:"team_lead_id"`
+	BudgetTotal           *float64               `json:"budget_total,omitempty" db:"budget_total"`
+	BudgetSpent           *float64               `json:"budget_spent,omitempty" db:"budget_spent"`
+	Permissions           map[string]interface{} `json:"permissions,omitempty" db:"permissions"`
+	AutoNotifyMatches     bool                   `json:"auto_notify_matches" db:"auto_notify_matches"`
+	MaxTeamSize           *int                   `json:"max_team_size,omitempty" db:"max_team_size"`
+	AutoCloseApplications bool                   `json:"auto_close_applications" db:"auto_close_applications"`
+	TaskDigestMinutes     *int                   `json:"task_digest_minutes,omitempty" db:"task_digest_minutes"`
+	LocationStatus        LocationStatus         `json:"location_status" db:"location_status"`
+	CreatedAt             time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time              `json:"updated_at" db:"updated_at"`
+	DeletedAt             *time.Time             `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // ProjectTeamMember represents a team member in a project
@@ -79,6 +131,18 @@ type ProjectWithDetails struct {
 	OverdueTasksCount  int                 `json:"overdue_tasks_count"`
 }
 
+// ProjectStatusChange represents a single entry in a project's status history
+type ProjectStatusChange struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	ProjectID  uuid.UUID     `json:"project_id" db:"project_id"`
+	FromStatus ProjectStatus `json:"from_status" db:"from_status"`
+	ToStatus   ProjectStatus `json:"to_status" db:"to_status"`
+	ChangedBy  *uuid.UUID    `json:"changed_by" db:"changed_by"`
+	Forced     bool          `json:"forced" db:"forced"`
+	Reason     *string       `json:"reason,omitempty" db:"reason"`
+	ChangedAt  time.Time     `json:"changed_at" db:"changed_at"`
+}
+
 // ProjectService handles project operations
 type ProjectService struct {
 	db *sql.DB
@@ -91,10 +155,37 @@ func NewProjectService(db *sql.DB) *ProjectService {
 
 // Create creates a new project
 func (s *ProjectService) Create(project *Project) error {
+	return insertProject(s.db, project)
+}
+
+// CreateWithTasks creates a project with its required skills and tasks in one
+// transaction, so a failure leaves nothing behind. Each task's ProjectID is set to
+// the new project's ID.
+func (s *ProjectService) CreateWithTasks(project *Project, skillIDs []int, tasks []ProjectTask) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if err := insertProject(tx, project); err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+		if err := setProjectSkills(tx, project.ID, skillIDs); err != nil {
+			return err
+		}
+		for i := range tasks {
+			tasks[i].ProjectID = project.ID
+			if err := insertTask(tx, &tasks[i]); err != nil {
+				return fmt.Errorf("failed to create task %q: %w", tasks[i].Title, err)
+			}
+		}
+		return nil
+	})
+}
+
+// insertProject creates a project with db, which may be a transaction
+func insertProject(db interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}, project *Project) error {
 	project.ID = uuid.New()
-	skillsJSON, err := ToJSONArray(project.RequiredSkills)
-	if err != nil {
-		return err
+	if project.LocationStatus == "" {
+		project.LocationStatus = LocationStatusNone
 	}
 
 	contentJSON, err := ToJSON(project.ContentJSON)
@@ -102,22 +193,33 @@ func (s *ProjectService) Create(project *Project) error {
 		return err
 	}
 
-	return s.db.QueryRow(projectCreateQuery, project.ID, project.Title, project.Description, contentJSON, skillsJSON,
+	return db.QueryRow(projectCreateQuery, project.ID, project.Title, project.Description, contentJSON,
 		project.LocationLat, project.LocationLng, project.LocationAddress, project.StartDate,
 		project.EndDate, project.ProjectStatus, project.CreatedByAdminID,
-		project.TeamLeadID, project.AutoNotifyMatches).Scan(&project.CreatedAt, &project.UpdatedAt)
+		project.TeamLeadID, project.AutoNotifyMatches, project.LocationStatus,
+		project.MaxTeamSize, project.AutoCloseApplications, project.TaskDigestMinutes).Scan(&project.CreatedAt, &project.UpdatedAt)
 }
 
-// GetByID retrieves a project by ID
+// GetByID retrieves a project by ID. Soft-deleted projects are treated as not found.
 func (s *ProjectService) GetByID(id uuid.UUID) (*Project, error) {
+	return s.getByID(id, false)
+}
+
+// GetByIDIncludingDeleted retrieves a project by ID even if it has been soft-deleted
+func (s *ProjectService) GetByIDIncludingDeleted(id uuid.UUID) (*Project, error) {
+	return s.getByID(id, true)
+}
+
+// getByID retrieves a project by ID, optionally including soft-deleted projects
+func (s *ProjectService) getByID(id uuid.UUID, includeDeleted bool) (*Project, error) {
 	project := &Project{}
 	var skillsJSON string
 
 	var contentJSON sql.NullString
-	err := s.db.QueryRow(projectGetByIDQuery, id).Scan(&project.ID, &project.Title, &project.Description,
+	err := s.db.QueryRow(projectGetByIDQuery, id, includeDeleted).Scan(&project.ID, &project.Title, &project.Description,
 		&contentJSON, &skillsJSON, &project.LocationLat, &project.LocationLng, &project.LocationAddress,
 		&project.StartDate, &project.EndDate, &project.ProjectStatus,
-		&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.CreatedAt, &project.UpdatedAt)
+		&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.MaxTeamSize, &project.AutoCloseApplications, &project.TaskDigestMinutes, &project.LocationStatus, &project.CreatedAt, &project.UpdatedAt, &project.DeletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -203,7 +305,7 @@ func (s *ProjectService) GetByIDWithDetails(id uuid.UUID) (*ProjectWithDetails,
 
 	// Get applications count
 	applicationService := NewApplicationService(s.db)
-	applications, err := applicationService.GetApplicationsByProject(id)
+	applications, err := applicationService.GetApplicationsByProject(id, "")
 	if err == nil {
 		details.Applications = applications
 		details.SignupCount = len(applications)
@@ -212,8 +314,9 @@ func (s *ProjectService) GetByIDWithDetails(id uuid.UUID) (*ProjectWithDetails,
 	return details, nil
 }
 
-// List retrieves projects with optional filtering
-func (s *ProjectService) List(limit, offset int, status *string, skills []string) ([]Project, error) {
+// List retrieves projects with optional filtering. Soft-deleted projects are only
+// included if includeDeleted is set.
+func (s *ProjectService) List(limit, offset int, status *string, skills []string, includeDeleted bool) ([]Project, error) {
 	// Build query based on whether skills filter is provided
 	var query string
 	var args []interface{}
@@ -221,11 +324,11 @@ func (s *ProjectService) List(limit, offset int, status *string, skills []string
 	if len(skills) > 0 {
 		// Query with skills filter
 		query = projectListWithSkillsQuery
-		args = []interface{}{status, skills, limit, offset}
+		args = []interface{}{status, skills, limit, offset, includeDeleted}
 	} else {
 		// Query without skills filter
 		query = projectListQuery
-		args = []interface{}{status, limit, offset}
+		args = []interface{}{status, limit, offset, includeDeleted}
 	}
 
 	log.Printf("🔍 PROJECT_LIST_QUERY: Executing query with params - status=%v, skills=%v, limit=%d, offset=%d", status, skills, limit, offset)
@@ -246,7 +349,7 @@ func (s *ProjectService) List(limit, offset int, status *string, skills []string
 		err := rows.Scan(&project.ID, &project.Title, &project.Description, &project.ContentJSON,
 			&project.LocationLat, &project.LocationLng, &project.LocationAddress,
 			&project.StartDate, &project.EndDate, &project.ProjectStatus,
-			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.CreatedAt, &project.UpdatedAt,
+			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.MaxTeamSize, &project.AutoCloseApplications, &project.TaskDigestMinutes, &project.LocationStatus, &project.CreatedAt, &project.UpdatedAt, &project.DeletedAt,
 			&skillsJSON)
 		if err != nil {
 			log.Printf("❌ PROJECT_LIST_SCAN: Row %d scan failed: %v", rowCount, err)
@@ -298,7 +401,7 @@ func (s *ProjectService) ListByTeamLead(teamLeadID uuid.UUID, limit, offset int)
 		err := rows.Scan(&project.ID, &project.Title, &project.Description, &project.ContentJSON,
 			&project.LocationLat, &project.LocationLng, &project.LocationAddress,
 			&project.StartDate, &project.EndDate, &project.ProjectStatus,
-			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.CreatedAt, &project.UpdatedAt,
+			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.MaxTeamSize, &project.AutoCloseApplications, &project.TaskDigestMinutes, &project.LocationStatus, &project.CreatedAt, &project.UpdatedAt,
 			&skillsJSON)
 		if err != nil {
 			return nil, err
@@ -334,9 +437,8 @@ func (s *ProjectService) ListByTeamLead(teamLeadID uuid.UUID, limit, offset int)
 
 // Update updates a project
 func (s *ProjectService) Update(project *Project) error {
-	skillsJSON, err := ToJSONArray(project.RequiredSkills)
-	if err != nil {
-		return err
+	if project.LocationStatus == "" {
+		project.LocationStatus = LocationStatusNone
 	}
 
 	contentJSON, err := ToJSON(project.ContentJSON)
@@ -344,16 +446,61 @@ func (s *ProjectService) Update(project *Project) error {
 		return err
 	}
 
-	return s.db.QueryRow(projectUpdateQuery, project.ID, project.Title, project.Description, contentJSON, skillsJSON,
+	return s.db.QueryRow(projectUpdateQuery, project.ID, project.Title, project.Description, contentJSON,
 		project.LocationLat, project.LocationLng, project.LocationAddress, project.StartDate,
-		project.EndDate, project.ProjectStatus, project.TeamLeadID, project.AutoNotifyMatches).
+		project.EndDate, project.ProjectStatus, project.TeamLeadID, project.AutoNotifyMatches, project.LocationStatus,
+		project.MaxTeamSize, project.AutoCloseApplications, project.TaskDigestMinutes).
 		Scan(&project.UpdatedAt)
 }
 
-// Delete deletes a project
+// UpdateLocation records the outcome of geocoding a project's address
+func (s *ProjectService) UpdateLocation(projectID uuid.UUID, lat, lng *float64, status LocationStatus) error {
+	result, err := s.db.Exec(projectUpdateLocationQuery, projectID, lat, lng, status)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a project, hiding it while keeping its tasks, messages and team
+// so it can be restored. Its matches are dropped. It returns sql.ErrNoRows if the
+// project doesn't exist or is already deleted.
 func (s *ProjectService) Delete(id uuid.UUID) error {
-	_, err := s.db.Exec(projectDeleteQuery, id)
-	return err
+	return s.setDeleted(projectDeleteQuery, id)
+}
+
+// Restore undoes a soft delete and queues the project's matches to be recalculated. It
+// returns sql.ErrNoRows if the project doesn't exist or isn't deleted.
+func (s *ProjectService) Restore(id uuid.UUID) error {
+	return s.setDeleted(projectRestoreQuery, id)
+}
+
+// setDeleted runs a soft delete or restore of a single project, returning
+// sql.ErrNoRows if it matched nothing, and marks the project's matches out of date
+func (s *ProjectService) setDeleted(query string, id uuid.UUID) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, id)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+		return markMatchingDirty(tx, MatchingEntityProject, id)
+	})
 }
 
 // GetProjectTeamMembers retrieves team members for a project
@@ -423,28 +570,68 @@ func (s *ProjectService) GetProjectTeamMembersWithDetails(projectID uuid.UUID) (
 	return members, nil
 }
 
-// AddTeamMember adds a volunteer to a project team
+// AddTeamMember adds a volunteer to a project team. Returns ErrTeamFull if adding them
+// as active would take the team past max_team_size.
 func (s *ProjectService) AddTeamMember(projectID, volunteerID uuid.UUID, status TeamMemberStatus) error {
-	_, err := s.db.Exec(projectAddTeamMemberQuery, uuid.New(), projectID, volunteerID, status)
-	return err
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if status == TeamMemberStatusActive {
+			if err := checkTeamHasRoom(tx, projectID, volunteerID); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(projectAddTeamMemberQuery, uuid.New(), projectID, volunteerID, status)
+		return err
+	})
 }
 
-// UpdateTeamMemberStatus updates a team member's status
+// UpdateTeamMemberStatus updates a team member's status. Returns ErrTeamFull if making
+// them active would take the team past max_team_size.
 func (s *ProjectService) UpdateTeamMemberStatus(projectID, volunteerID uuid.UUID, status TeamMemberStatus) error {
-	result, err := s.db.Exec(projectUpdateTeamMemberStatusQuery, projectID, volunteerID, status)
-	if err != nil {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if status == TeamMemberStatusActive {
+			if err := checkTeamHasRoom(tx, projectID, volunteerID); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.Exec(projectUpdateTeamMemberStatusQuery, projectID, volunteerID, status)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return sql.ErrNoRows // Team member not found
+		}
+
+		return nil
+	})
+}
+
+// checkTeamHasRoom locks a project and checks its team has room for one more active
+// member besides volunteerID, who may already be one. The lock is the one approvals and
+// waitlist promotion take, so concurrent additions can't both take the last place.
+// Returns sql.ErrNoRows if the project doesn't exist.
+func checkTeamHasRoom(tx *sql.Tx, projectID, volunteerID uuid.UUID) error {
+	var maxTeamSize sql.NullInt64
+	if err := tx.QueryRow(waitlistLockProjectQuery, projectID).Scan(&maxTeamSize); err != nil {
 		return err
 	}
+	if !maxTeamSize.Valid {
+		return nil
+	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	var otherActive int64
+	if err := tx.QueryRow(projectOtherActiveTeamCountQuery, projectID, volunteerID).Scan(&otherActive); err != nil {
 		return err
 	}
-
-	if rowsAffected == 0 {
-		return sql.ErrNoRows // Team member not found
+	if otherActive >= maxTeamSize.Int64 {
+		return ErrTeamFull
 	}
-
 	return nil
 }
 
@@ -454,10 +641,11 @@ func (s *ProjectService) RemoveTeamMember(projectID, volunteerID uuid.UUID) erro
 	return err
 }
 
-// GetProjectSignups retrieves all applications/signups for a project
-func (s *ProjectService) GetProjectSignups(projectID uuid.UUID) ([]Application, error) {
+// GetProjectSignups retrieves applications/signups for a project, optionally only
+// those with the given status ("" for all)
+func (s *ProjectService) GetProjectSignups(projectID uuid.UUID, status string) ([]Application, error) {
 	applicationService := NewApplicationService(s.db)
-	return applicationService.GetApplicationsByProject(projectID)
+	return applicationService.GetApplicationsByProject(projectID, status)
 }
 
 // IsTeamLead checks if a user is the team lead for a project
@@ -524,7 +712,7 @@ func (s *ProjectService) GetActiveProjects() ([]Project, error) {
 		err := rows.Scan(&project.ID, &project.Title, &project.Description, &project.ContentJSON,
 			&project.LocationLat, &project.LocationLng, &project.LocationAddress,
 			&project.StartDate, &project.EndDate, &project.ProjectStatus,
-			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.CreatedAt, &project.UpdatedAt,
+			&project.CreatedByAdminID, &project.TeamLeadID, &project.AutoNotifyMatches, &project.MaxTeamSize, &project.AutoCloseApplications, &project.TaskDigestMinutes, &project.LocationStatus, &project.CreatedAt, &project.UpdatedAt,
 			&skillsJSON)
 		if err != nil {
 			return nil, err
@@ -573,21 +761,9 @@ func (s *ProjectService) IsProjectCreator(projectID, userID uuid.UUID) (bool, er
 	return count > 0, nil
 }
 
-// CanEditProject checks if a user can edit a project (team lead, admin, or creator)
+// CanEditProject checks if a user can edit a project as its team lead or creator.
+// Callers let users with PermissionManageAllProjects edit any project.
 func (s *ProjectService) CanEditProject(projectID, userID uuid.UUID) (bool, error) {
-	// Check if user is admin
-	userService := NewUserService(s.db)
-	roles, err := userService.GetUserRoles(userID)
-	if err != nil {
-		return false, err
-	}
-
-	for _, role := range roles {
-		if role.Name == "admin" {
-			return true, nil
-		}
-	}
-
 	// Check if user is team lead
 	isTeamLead, err := s.IsTeamLead(projectID, userID)
 	if err != nil {
@@ -630,7 +806,7 @@ func (s *ProjectService) GetUserEnrolledProjects(userID uuid.UUID) ([]ProjectWit
 		err := rows.Scan(
 			&project.ID, &project.Title, &project.Description, &project.LocationLat, &project.LocationLng,
 			&project.LocationAddress, &project.StartDate, &project.EndDate, &project.ProjectStatus,
-			&project.CreatedByAdminID, &teamLeadID, &project.AutoNotifyMatches, &project.CreatedAt, &project.UpdatedAt,
+			&project.CreatedByAdminID, &teamLeadID, &project.AutoNotifyMatches, &project.MaxTeamSize, &project.AutoCloseApplications, &project.TaskDigestMinutes, &project.LocationStatus, &project.CreatedAt, &project.UpdatedAt,
 			&skillsJSON, &project.SignupCount, &project.ActiveTeamCount,
 			&teamLeadName, &teamLeadEmail, &createdByAdminName, &createdByAdminEmail,
 			&unreadCount, &assignedTasks, &overdueTasks,
@@ -692,8 +868,10 @@ func (s *ProjectService) GetUserEnrolledProjects(userID uuid.UUID) ([]ProjectWit
 	return projects, rows.Err()
 }
 
-// TransitionProjectStatus transitions a project to a new status with validation
-func (s *ProjectService) TransitionProjectStatus(projectID uuid.UUID, newStatus ProjectStatus, userID uuid.UUID) error {
+// TransitionProjectStatus transitions a project to a new status with validation. Only
+// the project's team lead or creator may, unless canManageAll is set for a caller with
+// PermissionManageAllProjects.
+func (s *ProjectService) TransitionProjectStatus(projectID uuid.UUID, newStatus ProjectStatus, userID uuid.UUID, canManageAll bool) error {
 	// Get current project
 	project, err := s.GetByID(projectID)
 	if err != nil {
@@ -704,29 +882,151 @@ func (s *ProjectService) TransitionProjectStatus(projectID uuid.UUID, newStatus
 	}
 
 	// Check if user can edit project
-	canEdit, err := s.CanEditProject(projectID, userID)
+	if !canManageAll {
+		canEdit, err := s.CanEditProject(projectID, userID)
+		if err != nil {
+			return err
+		}
+		if !canEdit {
+			return sql.ErrNoRows // Permission denied
+		}
+	}
+
+	// Validate transition
+	if err := s.validateStatusTransition(project.ProjectStatus, newStatus, projectID); err != nil {
+		return err
+	}
+
+	return s.applyStatusChange(projectID, project.ProjectStatus, newStatus, userID, nil)
+}
+
+// BulkTransitionProjectStatus moves each project to newStatus under the normal
+// transition rules, reporting a result per project instead of stopping at the first
+// failure. Each transition and its history entry commit in their own transaction.
+// Projects already in newStatus are skipped.
+func (s *ProjectService) BulkTransitionProjectStatus(projectIDs []uuid.UUID, newStatus ProjectStatus, userID uuid.UUID) []BulkStatusResult {
+	results := make([]BulkStatusResult, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		result := BulkStatusResult{ProjectID: projectID}
+
+		project, err := s.GetByID(projectID)
+		switch {
+		case err != nil:
+			log.Printf("❌ BULK_PROJECT_STATUS: Failed to get project %s: %v", projectID, err)
+			result.Outcome = BulkStatusFailed
+			result.Reason = "failed to load project"
+		case project == nil:
+			result.Outcome = BulkStatusNotFound
+		case project.ProjectStatus == newStatus:
+			result.FromStatus = &project.ProjectStatus
+			result.Outcome = BulkStatusSkippedInvalid
+			result.Reason = fmt.Sprintf("project is already %s", newStatus)
+		default:
+			result.FromStatus = &project.ProjectStatus
+			err := s.validateStatusTransition(project.ProjectStatus, newStatus, projectID)
+			if err == nil {
+				err = s.applyStatusChange(projectID, project.ProjectStatus, newStatus, userID, nil)
+			}
+			switch {
+			case err == nil:
+				result.Outcome = BulkStatusTransitioned
+			case errors.Is(err, ErrInvalidProjectTransition), errors.Is(err, ErrProjectStatusChanged):
+				result.Outcome = BulkStatusSkippedInvalid
+				result.Reason = err.Error()
+			default:
+				log.Printf("❌ BULK_PROJECT_STATUS: Failed to transition project %s: %v", projectID, err)
+				result.Outcome = BulkStatusFailed
+				result.Reason = "failed to apply transition"
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ForceProjectStatus moves a project to any status, bypassing the transition rules.
+// canOverride must be set for a caller with PermissionOverrideProjectRules, and the
+// reason is recorded in the status history.
+func (s *ProjectService) ForceProjectStatus(projectID uuid.UUID, newStatus ProjectStatus, userID uuid.UUID, reason string, canOverride bool) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("reason: %w", ErrEmptyField)
+	}
+
+	project, err := s.GetByID(projectID)
 	if err != nil {
 		return err
 	}
-	if !canEdit {
+	if project == nil {
+		return sql.ErrNoRows
+	}
+
+	if !canOverride {
 		return sql.ErrNoRows // Permission denied
 	}
 
-	// Validate transition
-	if err := s.validateStatusTransition(project.ProjectStatus, newStatus, projectID); err != nil {
-		return err
+	if project.ProjectStatus == newStatus {
+		return fmt.Errorf("%w: project is already %s", ErrProjectStatusUnchanged, newStatus)
 	}
 
-	// Update project status
-	_, err = s.db.Exec(projectTransitionStatusQuery, projectID, newStatus)
-	return err
+	return s.applyStatusChange(projectID, project.ProjectStatus, newStatus, userID, &reason)
+}
+
+// applyStatusChange updates the status and records history atomically so a failed
+// history write rolls back the transition. A non-nil reason marks the change as forced.
+// The update only applies while the project is still in fromStatus, so a concurrent
+// transition returns ErrProjectStatusChanged instead of recording the wrong from-status.
+func (s *ProjectService) applyStatusChange(projectID uuid.UUID, fromStatus, toStatus ProjectStatus, userID uuid.UUID, reason *string) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(projectTransitionStatusQuery, projectID, toStatus, fromStatus)
+		if err != nil {
+			return err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return ErrProjectStatusChanged
+		}
+
+		forced := reason != nil
+		if _, err := tx.Exec(projectInsertStatusHistoryQuery, uuid.New(), projectID, fromStatus, toStatus, userID, forced, reason); err != nil {
+			return fmt.Errorf("failed to record status history: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetStatusHistory retrieves the status change history for a project, newest first
+func (s *ProjectService) GetStatusHistory(projectID uuid.UUID) ([]ProjectStatusChange, error) {
+	rows, err := s.db.Query(projectGetStatusHistoryQuery, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []ProjectStatusChange{}
+	for rows.Next() {
+		var change ProjectStatusChange
+		err := rows.Scan(&change.ID, &change.ProjectID, &change.FromStatus, &change.ToStatus,
+			&change.ChangedBy, &change.Forced, &change.Reason, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
 }
 
 // validateStatusTransition validates if a status transition is allowed
 func (s *ProjectService) validateStatusTransition(currentStatus, newStatus ProjectStatus, projectID uuid.UUID) error {
-	// Same status is always allowed
+	// Moving to the current status is not a transition
 	if currentStatus == newStatus {
-		return nil
+		return fmt.Errorf("%w: project is already %s", ErrProjectStatusUnchanged, newStatus)
 	}
 
 	// Define valid transitions
@@ -753,7 +1053,7 @@ func (s *ProjectService) validateStatusTransition(currentStatus, newStatus Proje
 	}
 
 	if !validTransition {
-		return fmt.Errorf("invalid transition from %s to %s", currentStatus, newStatus)
+		return fmt.Errorf("%w from %s to %s", ErrInvalidProjectTransition, currentStatus, newStatus)
 	}
 
 	// Get project details for validation
@@ -767,7 +1067,7 @@ func (s *ProjectService) validateStatusTransition(currentStatus, newStatus Proje
 	case ProjectStatusRecruiting:
 		// Must have team lead assigned
 		if project.TeamLeadID == nil {
-			return fmt.Errorf("cannot transition to recruiting: team lead must be assigned")
+			return fmt.Errorf("%w: cannot transition to recruiting: team lead must be assigned", ErrInvalidProjectTransition)
 		}
 	case ProjectStatusActive:
 		// Must have at least one active team member
@@ -776,13 +1076,37 @@ func (s *ProjectService) validateStatusTransition(currentStatus, newStatus Proje
 			return err
 		}
 		if activeCount == 0 {
-			return fmt.Errorf("cannot transition to active: must have at least one active team member")
+			return fmt.Errorf("%w: cannot transition to active: must have at least one active team member", ErrInvalidProjectTransition)
 		}
 	}
 
 	return nil
 }
 
+// ApplicationCloseReason reports why a project that opted in to auto-closing should
+// stop taking applications: it has left recruiting or its team is full. It returns
+// "" if applications should stay open.
+func (s *ProjectService) ApplicationCloseReason(project *Project) (string, error) {
+	if !project.AutoCloseApplications {
+		return "", nil
+	}
+	if project.ProjectStatus != ProjectStatusRecruiting {
+		return "the project is no longer recruiting", nil
+	}
+	if project.MaxTeamSize == nil {
+		return "", nil
+	}
+
+	activeCount, err := s.getActiveTeamMemberCount(project.ID)
+	if err != nil {
+		return "", err
+	}
+	if activeCount >= *project.MaxTeamSize {
+		return "the team is now full", nil
+	}
+	return "", nil
+}
+
 // getActiveTeamMemberCount returns the count of active team members for a project
 func (s *ProjectService) getActiveTeamMemberCount(projectID uuid.UUID) (int, error) {
 	var count int
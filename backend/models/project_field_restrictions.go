@@ -0,0 +1,213 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrRestrictedField is returned when an update changes a field that can't be
+// edited while the project is in its current status
+var ErrRestrictedField = fmt.Errorf("field cannot be edited in the project's current status")
+
+// projectField is a group of project fields that are restricted together
+type projectField struct {
+	name  string
+	copy  func(dst, src *Project)
+	equal func(current, updated *Project) bool
+}
+
+// projectFields lists every field subject to status-based edit restrictions
+var projectFields = []projectField{
+	{
+		name:  "title",
+		copy:  func(dst, src *Project) { dst.Title = src.Title },
+		equal: func(a, b *Project) bool { return a.Title == b.Title },
+	},
+	{
+		name:  "description",
+		copy:  func(dst, src *Project) { dst.Description = src.Description },
+		equal: func(a, b *Project) bool { return a.Description == b.Description },
+	},
+	{
+		name:  "required_skills",
+		copy:  func(dst, src *Project) { dst.RequiredSkills = src.RequiredSkills },
+		equal: func(a, b *Project) bool { return equalStringSets(a.RequiredSkills, b.RequiredSkills) },
+	},
+	{
+		name: "location",
+		copy: func(dst, src *Project) {
+			dst.LocationLat = src.LocationLat
+			dst.LocationLng = src.LocationLng
+			dst.LocationAddress = src.LocationAddress
+		},
+		// Filling in coordinates the stored address was missing is geocoding, not an edit
+		equal: func(a, b *Project) bool {
+			if a.LocationAddress != b.LocationAddress {
+				return false
+			}
+			if a.LocationLat == nil && a.LocationLng == nil {
+				return true
+			}
+			return equalFloatPtr(a.LocationLat, b.LocationLat) && equalFloatPtr(a.LocationLng, b.LocationLng)
+		},
+	},
+	{
+		name:  "start_date",
+		copy:  func(dst, src *Project) { dst.StartDate = src.StartDate },
+		equal: func(a, b *Project) bool { return equalTimePtr(a.StartDate, b.StartDate) },
+	},
+	{
+		name:  "end_date",
+		copy:  func(dst, src *Project) { dst.EndDate = src.EndDate },
+		equal: func(a, b *Project) bool { return equalTimePtr(a.EndDate, b.EndDate) },
+	},
+	{
+		name:  "team_lead_id",
+		copy:  func(dst, src *Project) { dst.TeamLeadID = src.TeamLeadID },
+		equal: func(a, b *Project) bool { return equalUUIDPtr(a.TeamLeadID, b.TeamLeadID) },
+	},
+	{
+		name:  "budget_total",
+		copy:  func(dst, src *Project) { dst.BudgetTotal = src.BudgetTotal },
+		equal: func(a, b *Project) bool { return equalFloatPtr(a.BudgetTotal, b.BudgetTotal) },
+	},
+	{
+		name:  "budget_spent",
+		copy:  func(dst, src *Project) { dst.BudgetSpent = src.BudgetSpent },
+		equal: func(a, b *Project) bool { return equalFloatPtr(a.BudgetSpent, b.BudgetSpent) },
+	},
+	{
+		name:  "auto_notify_matches",
+		copy:  func(dst, src *Project) { dst.AutoNotifyMatches = src.AutoNotifyMatches },
+		equal: func(a, b *Project) bool { return a.AutoNotifyMatches == b.AutoNotifyMatches },
+	},
+	{
+		name:  "max_team_size",
+		copy:  func(dst, src *Project) { dst.MaxTeamSize = src.MaxTeamSize },
+		equal: func(a, b *Project) bool { return equalIntPtr(a.MaxTeamSize, b.MaxTeamSize) },
+	},
+	{
+		name:  "auto_close_applications",
+		copy:  func(dst, src *Project) { dst.AutoCloseApplications = src.AutoCloseApplications },
+		equal: func(a, b *Project) bool { return a.AutoCloseApplications == b.AutoCloseApplications },
+	},
+	{
+		name:  "task_digest_minutes",
+		copy:  func(dst, src *Project) { dst.TaskDigestMinutes = src.TaskDigestMinutes },
+		equal: func(a, b *Project) bool { return equalIntPtr(a.TaskDigestMinutes, b.TaskDigestMinutes) },
+	},
+}
+
+// restrictedProjectFields lists the fields that can't be edited in each status.
+// Statuses not listed here allow no edits at all.
+var restrictedProjectFields = map[ProjectStatus]map[string]bool{
+	ProjectStatusDraft:      {},
+	ProjectStatusRecruiting: {"title": true, "description": true, "required_skills": true},
+	ProjectStatusActive:     {"title": true, "required_skills": true, "start_date": true},
+	ProjectStatusCompleted:  {"title": true, "description": true, "required_skills": true, "location": true, "start_date": true, "budget_total": true, "auto_notify_matches": true, "max_team_size": true, "auto_close_applications": true, "task_digest_minutes": true},
+	ProjectStatusArchived:   {"title": true, "description": true, "required_skills": true, "location": true, "start_date": true, "budget_total": true, "auto_notify_matches": true, "max_team_size": true, "auto_close_applications": true, "task_digest_minutes": true},
+}
+
+// IsFieldEditable reports whether a field can be edited while a project is in status
+func IsFieldEditable(status ProjectStatus, field string) bool {
+	restricted, ok := restrictedProjectFields[status]
+	return ok && !restricted[field]
+}
+
+// ApplyFieldRestrictions returns a copy of current with the fields of update that
+// are editable in current's status. Restricted changes are silently dropped.
+// Admins can edit every field regardless of status.
+func (s *ProjectService) ApplyFieldRestrictions(current, update *Project, isAdmin bool) *Project {
+	restricted := *current
+	for _, field := range projectFields {
+		if isAdmin || IsFieldEditable(current.ProjectStatus, field.name) {
+			field.copy(&restricted, update)
+		}
+	}
+	return &restricted
+}
+
+// RestrictedFieldChanges returns the names of the fields updated changes from
+// current that can't be edited in current's status
+func (s *ProjectService) RestrictedFieldChanges(current, updated *Project) []string {
+	var changed []string
+	for _, field := range projectFields {
+		if !IsFieldEditable(current.ProjectStatus, field.name) && !field.equal(current, updated) {
+			changed = append(changed, field.name)
+		}
+	}
+	return changed
+}
+
+// UpdateWithRestrictions updates a project after checking it against the stored
+// project's status. Unlike Update, it rejects changes to restricted fields with
+// ErrRestrictedField unless isAdmin is set, so callers can't bypass the edit rules.
+// It returns sql.ErrNoRows if the project doesn't exist.
+func (s *ProjectService) UpdateWithRestrictions(project *Project, isAdmin bool) error {
+	if !isAdmin {
+		current, err := s.GetByID(project.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return sql.ErrNoRows
+		}
+
+		if changed := s.RestrictedFieldChanges(current, project); len(changed) > 0 {
+			return fmt.Errorf("%w: %s (status %s)", ErrRestrictedField, strings.Join(changed, ", "), current.ProjectStatus)
+		}
+	}
+
+	return s.Update(project)
+}
+
+// equalStringSets reports whether a and b hold the same strings, ignoring order and case
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, value := range a {
+		counts[strings.ToLower(value)]++
+	}
+	for _, value := range b {
+		key := strings.ToLower(value)
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalFloatPtr(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func equalUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
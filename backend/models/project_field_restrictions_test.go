@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdateWithRestrictionsOnAnActiveProject(t *testing.T) {
+	leadID := uuid.New()
+	// The stored project, as projectRow returns it
+	stored := Project{ID: uuid.New(), Title: "Park Cleanup", Description: "Pick up litter", TeamLeadID: &leadID, ProjectStatus: ProjectStatusActive}
+
+	tests := []struct {
+		name       string
+		edit       func(*Project)
+		isAdmin    bool
+		wantErr    error
+		wantUpdate bool
+	}{
+		{"restricted field", func(p *Project) { p.Title = "River Cleanup" }, false, ErrRestrictedField, false},
+		{"restricted field by an admin", func(p *Project) { p.Title = "River Cleanup" }, true, nil, true},
+		{"editable field", func(p *Project) { p.Description = "Pick up litter and recycling" }, false, nil, true},
+	}
+
+	for _, tt := range tests {
+		db, recorder := fakesql.Open()
+		recorder.Rows("FROM projects WHERE id = $1 AND ($2", projectColumns, projectRow(stored.ID, ProjectStatusActive, &leadID))
+		recorder.Rows("SET title = $2", []string{"updated_at"}, []driver.Value{time.Now()})
+		updated := stored
+		tt.edit(&updated)
+
+		err := NewProjectService(db).UpdateWithRestrictions(&updated, tt.isAdmin)
+		db.Close()
+
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: UpdateWithRestrictions() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if recorder.Ran("SET title = $2") != tt.wantUpdate {
+			t.Errorf("%s: project updated = %t, want %t", tt.name, !tt.wantUpdate, tt.wantUpdate)
+		}
+	}
+}
@@ -5,20 +5,28 @@ const (
 	projectCreateQuery = `
 		INSERT INTO projects (id, title, description, content_json, location_lat, location_lng, 
 		                     location_address, start_date, end_date, project_status, 
-		                     created_by_admin_id, team_lead_id, auto_notify_matches)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		                     created_by_admin_id, team_lead_id, auto_notify_matches, location_status,
+		                     max_team_size, auto_close_applications, task_digest_minutes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		RETURNING created_at, updated_at`
 
 	projectGetByIDQuery = `
-		SELECT id, title, description, content_json, location_lat, location_lng, 
+		SELECT id, title, description, content_json,
+		       COALESCE((
+		           SELECT JSON_AGG(JSON_BUILD_OBJECT('id', st.id, 'name', st.skill_name))
+		           FROM project_required_skills prs
+		           JOIN skill_taxonomy st ON prs.skill_id = st.id
+		           WHERE prs.project_id = projects.id
+		       ), '[]'::json) as required_skills,
+		       location_lat, location_lng, 
 		       location_address, start_date, end_date, project_status, 
-		       created_by_admin_id, team_lead_id, auto_notify_matches, created_at, updated_at
-		FROM projects WHERE id = $1`
+		       created_by_admin_id, team_lead_id, auto_notify_matches, max_team_size, auto_close_applications, task_digest_minutes, location_status, created_at, updated_at, deleted_at
+		FROM projects WHERE id = $1 AND ($2 OR deleted_at IS NULL)`
 
 	projectListWithSkillsQuery = `
 		SELECT p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		       p.location_address, p.start_date, p.end_date, p.project_status, 
-		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at, p.deleted_at,
 		       CASE 
 		           WHEN COUNT(prs.skill_id) > 0 THEN
 		               JSON_AGG(
@@ -29,10 +37,10 @@ const (
 		FROM projects p
 		LEFT JOIN project_required_skills prs ON p.id = prs.project_id
 		LEFT JOIN skill_taxonomy st ON prs.skill_id = st.id
-		WHERE ($1::text IS NULL OR p.project_status::text = $1)
+		WHERE ($1::text IS NULL OR p.project_status::text = $1) AND ($5 OR p.deleted_at IS NULL)
 		GROUP BY p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		         p.location_address, p.start_date, p.end_date, p.project_status, 
-		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at
+		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at, p.deleted_at
 		HAVING ($2::jsonb IS NULL OR $2::jsonb = '[]'::jsonb OR 
 		        EXISTS (
 		            SELECT 1 FROM project_required_skills prs2 
@@ -50,7 +58,7 @@ const (
 	projectListQuery = `
 		SELECT p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		       p.location_address, p.start_date, p.end_date, p.project_status, 
-		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at, p.deleted_at,
 		       CASE 
 		           WHEN COUNT(prs.skill_id) > 0 THEN
 		               JSON_AGG(
@@ -61,17 +69,17 @@ const (
 		FROM projects p
 		LEFT JOIN project_required_skills prs ON p.id = prs.project_id
 		LEFT JOIN skill_taxonomy st ON prs.skill_id = st.id
-		WHERE ($1::text IS NULL OR p.project_status::text = $1)
+		WHERE ($1::text IS NULL OR p.project_status::text = $1) AND ($4 OR p.deleted_at IS NULL)
 		GROUP BY p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		         p.location_address, p.start_date, p.end_date, p.project_status, 
-		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at
+		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at, p.deleted_at
 		ORDER BY p.created_at DESC
 		LIMIT $2 OFFSET $3`
 
 	projectListByTeamLeadQuery = `
 		SELECT p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		       p.location_address, p.start_date, p.end_date, p.project_status, 
-		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at,
 		       CASE 
 		           WHEN COUNT(prs.skill_id) > 0 THEN
 		               JSON_AGG(
@@ -82,10 +90,10 @@ const (
 		FROM projects p
 		LEFT JOIN project_required_skills prs ON p.id = prs.project_id
 		LEFT JOIN skill_taxonomy st ON prs.skill_id = st.id
-		WHERE p.team_lead_id = $1
+		WHERE p.team_lead_id = $1 AND p.deleted_at IS NULL
 		GROUP BY p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		         p.location_address, p.start_date, p.end_date, p.project_status, 
-		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at
+		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at
 		ORDER BY p.created_at DESC
 		LIMIT $2 OFFSET $3`
 
@@ -94,11 +102,20 @@ const (
 		SET title = $2, description = $3, content_json = $4, location_lat = $5, 
 		    location_lng = $6, location_address = $7, start_date = $8, end_date = $9, 
 		    project_status = $10, team_lead_id = $11, auto_notify_matches = $12, 
+		    location_status = $13, max_team_size = $14, auto_close_applications = $15,
+		    task_digest_minutes = $16,
 		    updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 		RETURNING updated_at`
 
-	projectDeleteQuery = `DELETE FROM projects WHERE id = $1`
+	// Projects are soft-deleted so their tasks, messages and team survive for a restore
+	projectDeleteQuery = `
+		UPDATE projects SET deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	projectRestoreQuery = `
+		UPDATE projects SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NOT NULL`
 
 	projectGetTeamMembersQuery = `
 		SELECT id, project_id, volunteer_id, joined_at, status, created_at, updated_at
@@ -137,10 +154,15 @@ const (
 		SET team_lead_id = $2, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1`
 
+	projectUpdateLocationQuery = `
+		UPDATE projects 
+		SET location_lat = $2, location_lng = $3, location_status = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
 	projectGetActiveProjectsQuery = `
 		SELECT p.id, p.title, p.description, p.location_lat, p.location_lng, 
 		       p.location_address, p.start_date, p.end_date, p.project_status, 
-		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+		       p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at,
 		       CASE 
 		           WHEN COUNT(prs.skill_id) > 0 THEN
 		               JSON_AGG(
@@ -151,10 +173,10 @@ const (
 		FROM projects p
 		LEFT JOIN project_required_skills prs ON p.id = prs.project_id
 		LEFT JOIN skill_taxonomy st ON prs.skill_id = st.id
-		WHERE p.project_status IN ('recruiting', 'active')
+		WHERE p.project_status IN ('recruiting', 'active') AND p.deleted_at IS NULL
 		GROUP BY p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		         p.location_address, p.start_date, p.end_date, p.project_status, 
-		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at
+		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at
 		ORDER BY p.created_at DESC`
 
 	projectIsCreatorQuery = `SELECT COUNT(1) FROM projects WHERE id = $1 AND created_by_admin_id = $2`
@@ -162,18 +184,33 @@ const (
 	projectTransitionStatusQuery = `
 		UPDATE projects 
 		SET project_status = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1`
+		WHERE id = $1 AND project_status = $3`
+
+	projectInsertStatusHistoryQuery = `
+		INSERT INTO project_status_history (id, project_id, from_status, to_status, changed_by, forced, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	projectGetStatusHistoryQuery = `
+		SELECT id, project_id, from_status, to_status, changed_by, forced, reason, changed_at
+		FROM project_status_history
+		WHERE project_id = $1
+		ORDER BY changed_at DESC`
 
 	projectActiveTeamCountQuery = `
 		SELECT COUNT(1) 
 		FROM project_team_members 
 		WHERE project_id = $1 AND status = 'active'`
 
+	projectOtherActiveTeamCountQuery = `
+		SELECT COUNT(1)
+		FROM project_team_members
+		WHERE project_id = $1 AND status = 'active' AND volunteer_id != $2`
+
 	projectGetUserEnrolledQuery = `
 		SELECT 
 			p.id, p.title, p.description, p.location_lat, p.location_lng, 
 			p.location_address, p.start_date, p.end_date, p.project_status, 
-			p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+			p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at,
 			CASE 
 				WHEN COUNT(prs.skill_id) > 0 THEN
 					JSON_AGG(
@@ -225,9 +262,10 @@ const (
 		WHERE ptm.volunteer_id = v.id
 		AND v.user_id = $1
 		AND ptm.status = 'active'
+		AND p.deleted_at IS NULL
 		GROUP BY p.id, p.title, p.description, p.content_json, p.location_lat, p.location_lng, 
 		         p.location_address, p.start_date, p.end_date, p.project_status, 
-		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.created_at, p.updated_at,
+		         p.created_by_admin_id, p.team_lead_id, p.auto_notify_matches, p.max_team_size, p.auto_close_applications, p.task_digest_minutes, p.location_status, p.created_at, p.updated_at,
 		         tl_v.name, tl_a.name, tl_u.email, admin_v.name, admin_a.name, admin_u.email,
 		         msg_stats.unread_count, task_stats.assigned_tasks, task_stats.overdue_tasks
 		ORDER BY p.created_at DESC`
@@ -0,0 +1,345 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SurveyQuestionType represents the kind of answer a survey question takes
+type SurveyQuestionType string
+
+const (
+	SurveyQuestionRating SurveyQuestionType = "rating" // 1 to MaxSurveyRating
+	SurveyQuestionText   SurveyQuestionType = "text"
+)
+
+// Survey limits
+const (
+	MinSurveyRating       = 1
+	MaxSurveyRating       = 5
+	MaxSurveyQuestions    = 20
+	MaxSurveyAnswerLength = 2000
+)
+
+// Survey errors
+var (
+	ErrSurveyAlreadyResponded = fmt.Errorf("you have already responded to this survey")
+	ErrInvalidSurveyQuestions = fmt.Errorf("a survey needs 1 to 20 questions, each with a prompt and a type of rating or text")
+)
+
+// SurveyQuestion represents one question in a project survey
+type SurveyQuestion struct {
+	Prompt   string             `json:"prompt"`
+	Type     SurveyQuestionType `json:"type"`
+	Required bool               `json:"required"`
+}
+
+// SurveyAnswer represents the answer to one question, by its position in the survey
+type SurveyAnswer struct {
+	QuestionIndex int     `json:"question_index"`
+	Rating        *int    `json:"rating,omitempty"`
+	Text          *string `json:"text,omitempty"`
+}
+
+// ProjectSurvey represents a feedback survey attached to a project
+type ProjectSurvey struct {
+	ID              uuid.UUID        `json:"id" db:"id"`
+	ProjectID       uuid.UUID        `json:"project_id" db:"project_id"`
+	Title           string           `json:"title" db:"title"`
+	Description     string           `json:"description" db:"description"`
+	Questions       []SurveyQuestion `json:"questions" db:"questions"`
+	IsAnonymous     bool             `json:"is_anonymous" db:"is_anonymous"` // Hides who gave each response from the results
+	CreatedByUserID *uuid.UUID       `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
+	ResponseCount   int              `json:"response_count" db:"response_count"`
+}
+
+// SurveyResponse represents one member's answers. Respondent and timing are
+// left out of anonymous survey results.
+type SurveyResponse struct {
+	ID               uuid.UUID      `json:"id" db:"id"`
+	RespondentUserID *uuid.UUID     `json:"respondent_user_id,omitempty" db:"respondent_user_id"`
+	RespondentName   string         `json:"respondent_name,omitempty" db:"respondent_name"`
+	Answers          []SurveyAnswer `json:"answers" db:"answers"`
+	CreatedAt        *time.Time     `json:"created_at,omitempty" db:"created_at"`
+}
+
+// SurveyQuestionResult aggregates the answers to one question
+type SurveyQuestionResult struct {
+	QuestionIndex int                `json:"question_index"`
+	Prompt        string             `json:"prompt"`
+	Type          SurveyQuestionType `json:"type"`
+	AnswerCount   int                `json:"answer_count"`
+	AverageRating *float64           `json:"average_rating,omitempty"`
+	Distribution  map[int]int        `json:"distribution,omitempty"` // Rating -> number of answers
+	TextAnswers   []string           `json:"text_answers,omitempty"`
+}
+
+// SurveyResults represents a survey's aggregated and individual responses
+type SurveyResults struct {
+	Survey    *ProjectSurvey         `json:"survey"`
+	Questions []SurveyQuestionResult `json:"questions"`
+	Responses []SurveyResponse       `json:"responses"`
+}
+
+// ProjectSurveyService handles project survey operations
+type ProjectSurveyService struct {
+	db *sql.DB
+}
+
+// NewProjectSurveyService creates a new project survey service
+func NewProjectSurveyService(db *sql.DB) *ProjectSurveyService {
+	return &ProjectSurveyService{db: db}
+}
+
+// ValidateSurveyQuestions checks that a survey has a usable set of questions
+func ValidateSurveyQuestions(questions []SurveyQuestion) error {
+	if len(questions) == 0 || len(questions) > MaxSurveyQuestions {
+		return ErrInvalidSurveyQuestions
+	}
+	for _, question := range questions {
+		if strings.TrimSpace(question.Prompt) == "" {
+			return ErrInvalidSurveyQuestions
+		}
+		if question.Type != SurveyQuestionRating && question.Type != SurveyQuestionText {
+			return ErrInvalidSurveyQuestions
+		}
+	}
+	return nil
+}
+
+// ValidateAnswers checks a response against the survey's questions: each answer
+// must match its question's type, no question may be answered twice, and every
+// required question must be answered.
+func (survey *ProjectSurvey) ValidateAnswers(answers []SurveyAnswer) error {
+	answered := make(map[int]bool)
+	for _, answer := range answers {
+		if answer.QuestionIndex < 0 || answer.QuestionIndex >= len(survey.Questions) {
+			return fmt.Errorf("question_index %d is out of range", answer.QuestionIndex)
+		}
+		if answered[answer.QuestionIndex] {
+			return fmt.Errorf("question %d is answered more than once", answer.QuestionIndex)
+		}
+		answered[answer.QuestionIndex] = true
+
+		switch survey.Questions[answer.QuestionIndex].Type {
+		case SurveyQuestionRating:
+			if answer.Rating == nil || answer.Text != nil {
+				return fmt.Errorf("question %d takes a rating", answer.QuestionIndex)
+			}
+			if *answer.Rating < MinSurveyRating || *answer.Rating > MaxSurveyRating {
+				return fmt.Errorf("question %d rating must be between %d and %d", answer.QuestionIndex, MinSurveyRating, MaxSurveyRating)
+			}
+		case SurveyQuestionText:
+			if answer.Text == nil || answer.Rating != nil {
+				return fmt.Errorf("question %d takes a text answer", answer.QuestionIndex)
+			}
+			if len(*answer.Text) > MaxSurveyAnswerLength {
+				return fmt.Errorf("question %d answer must be at most %d characters", answer.QuestionIndex, MaxSurveyAnswerLength)
+			}
+		}
+	}
+
+	for i, question := range survey.Questions {
+		if question.Required && !answered[i] {
+			return fmt.Errorf("question %d is required", i)
+		}
+	}
+
+	return nil
+}
+
+// Create creates a new project survey
+func (s *ProjectSurveyService) Create(survey *ProjectSurvey) error {
+	survey.ID = uuid.New()
+
+	questionsJSON, err := json.Marshal(survey.Questions)
+	if err != nil {
+		return err
+	}
+
+	return s.db.QueryRow(projectSurveyCreateQuery, survey.ID, survey.ProjectID, survey.Title, survey.Description,
+		string(questionsJSON), survey.IsAnonymous, survey.CreatedByUserID).Scan(&survey.CreatedAt)
+}
+
+// GetByID retrieves a project survey by ID
+func (s *ProjectSurveyService) GetByID(id uuid.UUID) (*ProjectSurvey, error) {
+	survey := &ProjectSurvey{}
+	var questionsJSON []byte
+
+	err := s.db.QueryRow(projectSurveyGetByIDQuery, id).Scan(
+		&survey.ID, &survey.ProjectID, &survey.Title, &survey.Description, &questionsJSON,
+		&survey.IsAnonymous, &survey.CreatedByUserID, &survey.CreatedAt, &survey.ResponseCount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(questionsJSON, &survey.Questions); err != nil {
+		return nil, err
+	}
+
+	return survey, nil
+}
+
+// ListByProject retrieves a project's surveys, newest first
+func (s *ProjectSurveyService) ListByProject(projectID uuid.UUID) ([]ProjectSurvey, error) {
+	rows, err := s.db.Query(projectSurveyListByProjectQuery, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	surveys := []ProjectSurvey{}
+	for rows.Next() {
+		var survey ProjectSurvey
+		var questionsJSON []byte
+
+		err := rows.Scan(
+			&survey.ID, &survey.ProjectID, &survey.Title, &survey.Description, &questionsJSON,
+			&survey.IsAnonymous, &survey.CreatedByUserID, &survey.CreatedAt, &survey.ResponseCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(questionsJSON, &survey.Questions); err != nil {
+			return nil, err
+		}
+		surveys = append(surveys, survey)
+	}
+
+	return surveys, rows.Err()
+}
+
+// Respond records a member's answers. Each member can respond once; a second
+// response returns ErrSurveyAlreadyResponded.
+func (s *ProjectSurveyService) Respond(surveyID, userID uuid.UUID, answers []SurveyAnswer) (*SurveyResponse, error) {
+	if answers == nil {
+		answers = []SurveyAnswer{}
+	}
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SurveyResponse{
+		ID:               uuid.New(),
+		RespondentUserID: &userID,
+		Answers:          answers,
+	}
+
+	var createdAt time.Time
+	err = s.db.QueryRow(projectSurveyRespondQuery, response.ID, surveyID, userID, string(answersJSON)).Scan(&createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSurveyAlreadyResponded
+		}
+		return nil, err
+	}
+	response.CreatedAt = &createdAt
+
+	return response, nil
+}
+
+// GetResults aggregates a survey's responses per question. For anonymous
+// surveys the individual responses carry no respondent or timestamp, and are
+// ordered by their random IDs so submission order can't identify anyone.
+func (s *ProjectSurveyService) GetResults(survey *ProjectSurvey) (*SurveyResults, error) {
+	rows, err := s.db.Query(projectSurveyListResponsesQuery, survey.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	responses := []SurveyResponse{}
+	for rows.Next() {
+		var response SurveyResponse
+		var answersJSON []byte
+		var createdAt time.Time
+
+		err := rows.Scan(&response.ID, &response.RespondentUserID, &response.RespondentName, &answersJSON, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(answersJSON, &response.Answers); err != nil {
+			return nil, err
+		}
+
+		if survey.IsAnonymous {
+			response.RespondentUserID = nil
+			response.RespondentName = ""
+		} else {
+			response.CreatedAt = &createdAt
+		}
+		responses = append(responses, response)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if survey.IsAnonymous {
+		sort.Slice(responses, func(i, j int) bool {
+			return responses[i].ID.String() < responses[j].ID.String()
+		})
+	}
+
+	return &SurveyResults{
+		Survey:    survey,
+		Questions: aggregateSurveyAnswers(survey.Questions, responses),
+		Responses: responses,
+	}, nil
+}
+
+// aggregateSurveyAnswers summarizes the answers to each question
+func aggregateSurveyAnswers(questions []SurveyQuestion, responses []SurveyResponse) []SurveyQuestionResult {
+	results := make([]SurveyQuestionResult, len(questions))
+	ratingTotals := make([]int, len(questions))
+	for i, question := range questions {
+		results[i] = SurveyQuestionResult{
+			QuestionIndex: i,
+			Prompt:        question.Prompt,
+			Type:          question.Type,
+		}
+		if question.Type == SurveyQuestionRating {
+			results[i].Distribution = make(map[int]int)
+		}
+	}
+
+	for _, response := range responses {
+		for _, answer := range response.Answers {
+			// Answers were validated on submission, but questions are stored alongside
+			if answer.QuestionIndex < 0 || answer.QuestionIndex >= len(results) {
+				continue
+			}
+			result := &results[answer.QuestionIndex]
+			switch {
+			case result.Type == SurveyQuestionRating && answer.Rating != nil:
+				result.AnswerCount++
+				result.Distribution[*answer.Rating]++
+				ratingTotals[answer.QuestionIndex] += *answer.Rating
+			case result.Type == SurveyQuestionText && answer.Text != nil && strings.TrimSpace(*answer.Text) != "":
+				result.AnswerCount++
+				result.TextAnswers = append(result.TextAnswers, *answer.Text)
+			}
+		}
+	}
+
+	for i := range results {
+		if results[i].Type == SurveyQuestionRating && results[i].AnswerCount > 0 {
+			average := float64(ratingTotals[i]) / float64(results[i].AnswerCount)
+			results[i].AverageRating = &average
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,41 @@
+package models
+
+// Query constants for ProjectSurveyService
+const (
+	projectSurveyCreateQuery = `
+		INSERT INTO project_surveys (id, project_id, title, description, questions, is_anonymous, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	projectSurveyGetByIDQuery = `
+		SELECT s.id, s.project_id, s.title, COALESCE(s.description, ''), s.questions, s.is_anonymous,
+		       s.created_by_user_id, s.created_at,
+		       (SELECT COUNT(*) FROM project_survey_responses r WHERE r.survey_id = s.id) as response_count
+		FROM project_surveys s
+		WHERE s.id = $1`
+
+	projectSurveyListByProjectQuery = `
+		SELECT s.id, s.project_id, s.title, COALESCE(s.description, ''), s.questions, s.is_anonymous,
+		       s.created_by_user_id, s.created_at,
+		       (SELECT COUNT(*) FROM project_survey_responses r WHERE r.survey_id = s.id) as response_count
+		FROM project_surveys s
+		WHERE s.project_id = $1
+		ORDER BY s.created_at DESC`
+
+	// A second response from the same member inserts nothing, so no row is returned
+	projectSurveyRespondQuery = `
+		INSERT INTO project_survey_responses (id, survey_id, respondent_user_id, answers)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (survey_id, respondent_user_id) DO NOTHING
+		RETURNING created_at`
+
+	projectSurveyListResponsesQuery = `
+		SELECT r.id, r.respondent_user_id, COALESCE(v.name, a.name, u.email) as respondent_name,
+		       r.answers, r.created_at
+		FROM project_survey_responses r
+		INNER JOIN users u ON r.respondent_user_id = u.id
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		WHERE r.survey_id = $1
+		ORDER BY r.created_at`
+)
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+var surveyResponseColumns = []string{"id", "respondent_user_id", "respondent_name", "answers", "created_at"}
+
+func TestRespondAcceptsOneResponsePerMember(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	// The unique (survey_id, respondent_user_id) constraint turns a repeat insert into a no-op
+	if !strings.Contains(projectSurveyRespondQuery, "ON CONFLICT (survey_id, respondent_user_id) DO NOTHING") {
+		t.Fatal("respond query does not skip a member's repeat response")
+	}
+	recorder.RowsOnce("INSERT INTO project_survey_responses", []string{"created_at"}, []driver.Value{time.Now()})
+	service := NewProjectSurveyService(db)
+	surveyID, userID := uuid.New(), uuid.New()
+	rating := 4
+	answers := []SurveyAnswer{{QuestionIndex: 0, Rating: &rating}}
+
+	response, err := service.Respond(surveyID, userID, answers)
+	if err != nil {
+		t.Fatalf("first Respond() error = %v", err)
+	}
+	if response.CreatedAt == nil || *response.RespondentUserID != userID {
+		t.Errorf("response = %+v, want the respondent and a timestamp", *response)
+	}
+
+	if _, err := service.Respond(surveyID, userID, answers); err != ErrSurveyAlreadyResponded {
+		t.Errorf("second Respond() error = %v, want ErrSurveyAlreadyResponded", err)
+	}
+}
+
+func TestGetResultsHidesRespondentsOfAnonymousSurveys(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	now := time.Now()
+	first, second := uuid.MustParse("f0000000-0000-0000-0000-000000000000"), uuid.MustParse("10000000-0000-0000-0000-000000000000")
+	recorder.Rows("FROM project_survey_responses r", surveyResponseColumns,
+		[]driver.Value{first.String(), uuid.New().String(), "Ana", []byte(`[{"question_index":0,"rating":5},{"question_index":1,"text":"Great"}]`), now},
+		[]driver.Value{second.String(), uuid.New().String(), "Ben", []byte(`[{"question_index":0,"rating":2}]`), now.Add(time.Minute)},
+	)
+	survey := &ProjectSurvey{
+		ID:          uuid.New(),
+		IsAnonymous: true,
+		Questions: []SurveyQuestion{
+			{Prompt: "How was it?", Type: SurveyQuestionRating},
+			{Prompt: "Anything else?", Type: SurveyQuestionText},
+		},
+	}
+
+	results, err := NewProjectSurveyService(db).GetResults(survey)
+	if err != nil {
+		t.Fatalf("GetResults() error = %v", err)
+	}
+
+	if len(results.Responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(results.Responses))
+	}
+	for _, response := range results.Responses {
+		if response.RespondentUserID != nil || response.RespondentName != "" || response.CreatedAt != nil {
+			t.Errorf("anonymous response %s identifies its respondent: %+v", response.ID, response)
+		}
+	}
+	// Ordered by ID rather than submission time
+	if results.Responses[0].ID != second || results.Responses[1].ID != first {
+		t.Errorf("responses ordered %s, %s, want them ordered by ID", results.Responses[0].ID, results.Responses[1].ID)
+	}
+
+	rating := results.Questions[0]
+	if rating.AnswerCount != 2 || rating.AverageRating == nil || *rating.AverageRating != 3.5 || rating.Distribution[5] != 1 || rating.Distribution[2] != 1 {
+		t.Errorf("rating result = %+v, want 2 answers averaging 3.5", rating)
+	}
+	if text := results.Questions[1]; text.AnswerCount != 1 || len(text.TextAnswers) != 1 || text.TextAnswers[0] != "Great" {
+		t.Errorf("text result = %+v, want the one text answer", text)
+	}
+}
+
+func TestGetResultsShowsRespondentsOfNamedSurveys(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	respondentID := uuid.New()
+	recorder.Rows("FROM project_survey_responses r", surveyResponseColumns,
+		[]driver.Value{uuid.New().String(), respondentID.String(), "Ana", []byte(`[{"question_index":0,"rating":5}]`), time.Now()},
+	)
+	survey := &ProjectSurvey{ID: uuid.New(), Questions: []SurveyQuestion{{Prompt: "How was it?", Type: SurveyQuestionRating}}}
+
+	results, err := NewProjectSurveyService(db).GetResults(survey)
+	if err != nil {
+		t.Fatalf("GetResults() error = %v", err)
+	}
+
+	response := results.Responses[0]
+	if response.RespondentUserID == nil || *response.RespondentUserID != respondentID || response.RespondentName != "Ana" || response.CreatedAt == nil {
+		t.Errorf("response = %+v, want the respondent and timestamp", response)
+	}
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MilestoneTaskLabel marks tasks created from a template milestone
+const MilestoneTaskLabel = "milestone"
+
+// TemplateTask represents a task suggested by a project template
+type TemplateTask struct {
+	Title         string       `json:"title"`
+	Description   string       `json:"description,omitempty"`
+	Priority      TaskPriority `json:"priority,omitempty"`
+	Labels        []string     `json:"labels,omitempty"`
+	DueOffsetDays *int         `json:"due_offset_days,omitempty"` // Days after the project start date
+}
+
+// TemplateMilestone represents a milestone suggested by a project template
+type TemplateMilestone struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	OffsetDays  int    `json:"offset_days"` // Days after the project start date
+}
+
+// ProjectTemplate represents a reusable preset for a common type of project
+type ProjectTemplate struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	Name           string              `json:"name" db:"name"`
+	Description    string              `json:"description" db:"description"`
+	Category       string              `json:"category" db:"category"`
+	RequiredSkills []string            `json:"required_skills" db:"required_skills"`
+	SuggestedTasks []TemplateTask      `json:"suggested_tasks" db:"suggested_tasks"`
+	Milestones     []TemplateMilestone `json:"milestones" db:"milestones"`
+	IsActive       bool                `json:"is_active" db:"is_active"`
+	CreatedByID    *uuid.UUID          `json:"created_by_id,omitempty" db:"created_by_id"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// ProjectTemplateService handles project template operations
+type ProjectTemplateService struct {
+	db *sql.DB
+}
+
+// NewProjectTemplateService creates a new project template service
+func NewProjectTemplateService(db *sql.DB) *ProjectTemplateService {
+	return &ProjectTemplateService{db: db}
+}
+
+// Create creates a new project template
+func (s *ProjectTemplateService) Create(template *ProjectTemplate) error {
+	template.ID = uuid.New()
+	skillsJSON, tasksJSON, milestonesJSON, err := template.marshalPresets()
+	if err != nil {
+		return err
+	}
+
+	return s.db.QueryRow(projectTemplateCreateQuery, template.ID, template.Name, template.Description,
+		template.Category, skillsJSON, tasksJSON, milestonesJSON, template.IsActive, template.CreatedByID).
+		Scan(&template.CreatedAt, &template.UpdatedAt)
+}
+
+// GetByID retrieves a project template by ID
+func (s *ProjectTemplateService) GetByID(id uuid.UUID) (*ProjectTemplate, error) {
+	template := &ProjectTemplate{}
+	var skillsJSON string
+	var tasksJSON, milestonesJSON []byte
+
+	err := s.db.QueryRow(projectTemplateGetByIDQuery, id).Scan(
+		&template.ID, &template.Name, &template.Description, &template.Category,
+		&skillsJSON, &tasksJSON, &milestonesJSON,
+		&template.IsActive, &template.CreatedByID, &template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := template.parsePresets(skillsJSON, tasksJSON, milestonesJSON); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// List retrieves project templates, optionally including inactive ones
+func (s *ProjectTemplateService) List(includeInactive bool) ([]ProjectTemplate, error) {
+	rows, err := s.db.Query(projectTemplateListQuery, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []ProjectTemplate{}
+	for rows.Next() {
+		var template ProjectTemplate
+		var skillsJSON string
+		var tasksJSON, milestonesJSON []byte
+
+		err := rows.Scan(
+			&template.ID, &template.Name, &template.Description, &template.Category,
+			&skillsJSON, &tasksJSON, &milestonesJSON,
+			&template.IsActive, &template.CreatedByID, &template.CreatedAt, &template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := template.parsePresets(skillsJSON, tasksJSON, milestonesJSON); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
+
+// Update updates a project template
+func (s *ProjectTemplateService) Update(template *ProjectTemplate) error {
+	skillsJSON, tasksJSON, milestonesJSON, err := template.marshalPresets()
+	if err != nil {
+		return err
+	}
+
+	return s.db.QueryRow(projectTemplateUpdateQuery, template.ID, template.Name, template.Description,
+		template.Category, skillsJSON, tasksJSON, milestonesJSON, template.IsActive).
+		Scan(&template.UpdatedAt)
+}
+
+// Delete deletes a project template. Projects created from it are unaffected.
+func (s *ProjectTemplateService) Delete(id uuid.UUID) error {
+	result, err := s.db.Exec(projectTemplateDeleteQuery, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// PlannedTasks builds the tasks a new project gets from this template: its suggested
+// tasks followed by one task per milestone, labelled MilestoneTaskLabel. Due dates are
+// offsets from startDate and are left unset when the project has no start date.
+func (t *ProjectTemplate) PlannedTasks(projectID, createdByID uuid.UUID, startDate *time.Time) []ProjectTask {
+	dueDate := func(offsetDays int) *time.Time {
+		if startDate == nil {
+			return nil
+		}
+		due := startDate.AddDate(0, 0, offsetDays)
+		return &due
+	}
+
+	tasks := make([]ProjectTask, 0, len(t.SuggestedTasks)+len(t.Milestones))
+	for _, suggested := range t.SuggestedTasks {
+		priority := suggested.Priority
+		if priority == "" {
+			priority = TaskPriorityMedium
+		}
+
+		task := ProjectTask{
+			ProjectID:   projectID,
+			Title:       suggested.Title,
+			Description: suggested.Description,
+			CreatedByID: createdByID,
+			Status:      TaskStatusTodo,
+			Priority:    priority,
+			Labels:      suggested.Labels,
+		}
+		if suggested.DueOffsetDays != nil {
+			task.DueDate = dueDate(*suggested.DueOffsetDays)
+		}
+		tasks = append(tasks, task)
+	}
+
+	for _, milestone := range t.Milestones {
+		tasks = append(tasks, ProjectTask{
+			ProjectID:   projectID,
+			Title:       milestone.Title,
+			Description: milestone.Description,
+			CreatedByID: createdByID,
+			Status:      TaskStatusTodo,
+			Priority:    TaskPriorityHigh,
+			DueDate:     dueDate(milestone.OffsetDays),
+			Labels:      []string{MilestoneTaskLabel},
+		})
+	}
+
+	return tasks
+}
+
+// marshalPresets serializes the template's JSONB columns
+func (t *ProjectTemplate) marshalPresets() (string, string, string, error) {
+	skillsJSON, err := ToJSONArray(t.RequiredSkills)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if t.SuggestedTasks == nil {
+		t.SuggestedTasks = []TemplateTask{}
+	}
+	tasksJSON, err := json.Marshal(t.SuggestedTasks)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if t.Milestones == nil {
+		t.Milestones = []TemplateMilestone{}
+	}
+	milestonesJSON, err := json.Marshal(t.Milestones)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return skillsJSON, string(tasksJSON), string(milestonesJSON), nil
+}
+
+// parsePresets decodes the template's JSONB columns
+func (t *ProjectTemplate) parsePresets(skillsJSON string, tasksJSON, milestonesJSON []byte) error {
+	if err := ParseJSONArray(skillsJSON, &t.RequiredSkills); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(tasksJSON, &t.SuggestedTasks); err != nil {
+		return err
+	}
+	return json.Unmarshal(milestonesJSON, &t.Milestones)
+}
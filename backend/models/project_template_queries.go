@@ -0,0 +1,31 @@
+package models
+
+// Query constants for ProjectTemplateService
+const (
+	projectTemplateCreateQuery = `
+		INSERT INTO project_templates (id, name, description, category, required_skills,
+		                               suggested_tasks, milestones, is_active, created_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at`
+
+	projectTemplateGetByIDQuery = `
+		SELECT id, name, description, category, required_skills, suggested_tasks, milestones,
+		       is_active, created_by_id, created_at, updated_at
+		FROM project_templates WHERE id = $1`
+
+	projectTemplateListQuery = `
+		SELECT id, name, description, category, required_skills, suggested_tasks, milestones,
+		       is_active, created_by_id, created_at, updated_at
+		FROM project_templates
+		WHERE ($1 OR is_active = true)
+		ORDER BY name`
+
+	projectTemplateUpdateQuery = `
+		UPDATE project_templates
+		SET name = $2, description = $3, category = $4, required_skills = $5,
+		    suggested_tasks = $6, milestones = $7, is_active = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	projectTemplateDeleteQuery = `DELETE FROM project_templates WHERE id = $1`
+)
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateWithTasksCommitsProjectSkillsAndTasks(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	now := time.Now()
+	recorder.Rows("INSERT INTO projects", []string{"created_at", "updated_at"}, []driver.Value{now, now})
+	recorder.Rows("INSERT INTO project_tasks", []string{"created_at", "updated_at"}, []driver.Value{now, now})
+
+	project := &Project{Title: "Park cleanup", CreatedByAdminID: uuid.New()}
+	tasks := []ProjectTask{{Title: "Collect litter"}, {Title: "Sort recycling"}}
+	if err := NewProjectService(db).CreateWithTasks(project, nil, tasks); err != nil {
+		t.Fatalf("CreateWithTasks() error = %v", err)
+	}
+
+	if recorder.Commits() != 1 || recorder.Rollbacks() != 0 {
+		t.Errorf("commits = %d, rollbacks = %d, want 1 and 0", recorder.Commits(), recorder.Rollbacks())
+	}
+	for _, task := range tasks {
+		if task.ProjectID != project.ID {
+			t.Errorf("task %q project = %s, want %s", task.Title, task.ProjectID, project.ID)
+		}
+	}
+	if !recorder.Ran("DELETE FROM project_required_skills") {
+		t.Error("required skills were not replaced")
+	}
+	for _, statement := range recorder.Statements() {
+		if !statement.InTx {
+			t.Errorf("statement ran outside the transaction: %s", statement.Query)
+		}
+	}
+}
+
+func TestCreateWithTasksRollsBackWhenATaskFails(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	now := time.Now()
+	recorder.Rows("INSERT INTO projects", []string{"created_at", "updated_at"}, []driver.Value{now, now})
+	recorder.Fail("INSERT INTO project_tasks", errors.New("connection reset"))
+
+	project := &Project{Title: "Park cleanup", CreatedByAdminID: uuid.New()}
+	tasks := []ProjectTask{{Title: "Collect litter"}}
+	if err := NewProjectService(db).CreateWithTasks(project, nil, tasks); err == nil {
+		t.Fatal("CreateWithTasks() succeeded after a task insert failed")
+	}
+
+	if recorder.Commits() != 0 || recorder.Rollbacks() != 1 {
+		t.Errorf("commits = %d, rollbacks = %d, want 0 and 1", recorder.Commits(), recorder.Rollbacks())
+	}
+	if !recorder.Ran("INSERT INTO projects") {
+		t.Error("project insert did not run before the task insert")
+	}
+}
+
+func TestApplyStatusChangeRecordsTheStatusItReplaced(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	err := NewProjectService(db).applyStatusChange(uuid.New(), ProjectStatusRecruiting, ProjectStatusActive, uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("applyStatusChange() error = %v", err)
+	}
+
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "UPDATE projects") && statement.Args[2] != string(ProjectStatusRecruiting) {
+			t.Errorf("update guarded on status %v, want %s", statement.Args[2], ProjectStatusRecruiting)
+		}
+		if strings.Contains(statement.Query, "INSERT INTO project_status_history") && statement.Args[2] != string(ProjectStatusRecruiting) {
+			t.Errorf("history from_status = %v, want %s", statement.Args[2], ProjectStatusRecruiting)
+		}
+	}
+	if recorder.Commits() != 1 {
+		t.Errorf("commits = %d, want 1", recorder.Commits())
+	}
+}
+
+func TestApplyStatusChangeRejectsAConcurrentTransition(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Affects("UPDATE projects", 0)
+
+	err := NewProjectService(db).applyStatusChange(uuid.New(), ProjectStatusRecruiting, ProjectStatusActive, uuid.New(), nil)
+	if !errors.Is(err, ErrProjectStatusChanged) {
+		t.Fatalf("applyStatusChange() error = %v, want ErrProjectStatusChanged", err)
+	}
+
+	if recorder.Ran("INSERT INTO project_status_history") {
+		t.Error("history was recorded for a transition that did not apply")
+	}
+	if recorder.Rollbacks() != 1 {
+		t.Errorf("rollbacks = %d, want 1", recorder.Rollbacks())
+	}
+}
+
+var projectColumns = []string{
+	"id", "title", "description", "content_json", "required_skills", "location_lat", "location_lng",
+	"location_address", "start_date", "end_date", "project_status", "created_by_admin_id", "team_lead_id",
+	"auto_notify_matches", "max_team_size", "auto_close_applications", "task_digest_minutes",
+	"location_status", "created_at", "updated_at", "deleted_at",
+}
+
+// projectRow is a projects row for ProjectService.GetByID
+func projectRow(projectID uuid.UUID, status ProjectStatus, teamLeadID *uuid.UUID) []driver.Value {
+	now := time.Now()
+	var lead driver.Value
+	if teamLeadID != nil {
+		lead = teamLeadID.String()
+	}
+	return []driver.Value{
+		projectID.String(), "Park Cleanup", "Pick up litter", nil, "[]", nil, nil,
+		"", nil, nil, string(status), uuid.New().String(), lead,
+		false, nil, false, nil,
+		"pending", now, now, nil,
+	}
+}
+
+func TestBulkTransitionProjectStatusReportsEachProject(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	leadID := uuid.New()
+	ready, active, recruiting, missing := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	// Projects are loaded in order; the ready draft is loaded again to check its team lead
+	for _, row := range [][]driver.Value{
+		projectRow(ready, ProjectStatusDraft, &leadID),
+		projectRow(ready, ProjectStatusDraft, &leadID),
+		projectRow(active, ProjectStatusActive, &leadID),
+		projectRow(recruiting, ProjectStatusRecruiting, &leadID),
+	} {
+		recorder.RowsOnce("FROM projects WHERE id = $1 AND ($2", projectColumns, row)
+	}
+	recorder.Rows("FROM projects WHERE id = $1 AND ($2", projectColumns)
+
+	results := NewProjectService(db).BulkTransitionProjectStatus(
+		[]uuid.UUID{ready, active, recruiting, missing}, ProjectStatusRecruiting, uuid.New())
+
+	want := []struct {
+		projectID  uuid.UUID
+		outcome    string
+		fromStatus ProjectStatus
+	}{
+		{ready, BulkStatusTransitioned, ProjectStatusDraft},
+		{active, BulkStatusSkippedInvalid, ProjectStatusActive},
+		{recruiting, BulkStatusSkippedInvalid, ProjectStatusRecruiting},
+		{missing, BulkStatusNotFound, ""},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		result := results[i]
+		if result.ProjectID != w.projectID || result.Outcome != w.outcome {
+			t.Errorf("result %d = %s %s, want %s %s", i, result.ProjectID, result.Outcome, w.projectID, w.outcome)
+		}
+		if (result.FromStatus == nil && w.fromStatus != "") || (result.FromStatus != nil && *result.FromStatus != w.fromStatus) {
+			t.Errorf("result %d from_status = %v, want %q", i, result.FromStatus, w.fromStatus)
+		}
+		if w.outcome == BulkStatusSkippedInvalid && result.Reason == "" {
+			t.Errorf("result %d was skipped without a reason", i)
+		}
+	}
+
+	// Only the valid transition is applied, in its own transaction
+	var updated []driver.Value
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "UPDATE projects") {
+			updated = append(updated, statement.Args[0])
+		}
+	}
+	if len(updated) != 1 || updated[0] != ready.String() {
+		t.Errorf("updated projects %v, want only %s", updated, ready)
+	}
+	if recorder.Commits() != 1 || recorder.Rollbacks() != 0 {
+		t.Errorf("commits = %d, rollbacks = %d, want 1 and 0", recorder.Commits(), recorder.Rollbacks())
+	}
+}
+
+func TestTransitionProjectStatusRejectsTheCurrentStatus(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	projectID, leadID := uuid.New(), uuid.New()
+	recorder.Rows("FROM projects WHERE id = $1 AND ($2", projectColumns, projectRow(projectID, ProjectStatusActive, &leadID))
+	recorder.Rows("team_lead_id = $2", []string{"count"}, []driver.Value{int64(1)})
+
+	err := NewProjectService(db).TransitionProjectStatus(projectID, ProjectStatusActive, leadID, false)
+	if !errors.Is(err, ErrProjectStatusUnchanged) {
+		t.Fatalf("TransitionProjectStatus() error = %v, want ErrProjectStatusUnchanged", err)
+	}
+	if recorder.Ran("UPDATE projects") || recorder.Ran("INSERT INTO project_status_history") {
+		t.Error("a transition to the current status was recorded")
+	}
+}
+
+func TestForceProjectStatusFollowsTheCallersOverridePermission(t *testing.T) {
+	for _, canOverride := range []bool{false, true} {
+		db, recorder := fakesql.Open()
+		projectID := uuid.New()
+		recorder.Rows("FROM projects WHERE id = $1 AND ($2", projectColumns, projectRow(projectID, ProjectStatusArchived, nil))
+
+		// The caller holds no role named admin; only the permission decides
+		err := NewProjectService(db).ForceProjectStatus(projectID, ProjectStatusActive, uuid.New(), "Archived by mistake", canOverride)
+		db.Close()
+
+		if canOverride && (err != nil || !recorder.Ran("INSERT INTO project_status_history")) {
+			t.Errorf("with the permission: error = %v, want the forced change recorded", err)
+		}
+		if !canOverride && (err != sql.ErrNoRows || recorder.Ran("UPDATE projects")) {
+			t.Errorf("without the permission: error = %v, want sql.ErrNoRows and no change", err)
+		}
+	}
+}
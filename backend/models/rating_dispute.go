@@ -0,0 +1,175 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DisputeStatus represents the state of a rating dispute
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen    DisputeStatus = "open"
+	DisputeStatusUpheld  DisputeStatus = "upheld"  // The rating is hidden from scorecards
+	DisputeStatusRemoved DisputeStatus = "removed" // The dispute is dismissed and the rating stands
+)
+
+// Rating dispute errors
+var (
+	ErrDisputeAlreadyOpen = fmt.Errorf("this rating already has an open dispute")
+	ErrDisputeNotOpen     = fmt.Errorf("dispute has already been resolved")
+)
+
+// RatingDispute represents a volunteer's dispute of a rating they received
+type RatingDispute struct {
+	ID               uuid.UUID     `json:"id" db:"id"`
+	RatingID         uuid.UUID     `json:"rating_id" db:"rating_id"`
+	VolunteerID      uuid.UUID     `json:"volunteer_id" db:"volunteer_id"`
+	FiledByUserID    uuid.UUID     `json:"filed_by_user_id" db:"filed_by_user_id"`
+	Reason           string        `json:"reason" db:"reason"`
+	Status           DisputeStatus `json:"status" db:"status"`
+	ResolutionNote   *string       `json:"resolution_note,omitempty" db:"resolution_note"`
+	ResolvedByUserID *uuid.UUID    `json:"resolved_by_user_id,omitempty" db:"resolved_by_user_id"`
+	ResolvedAt       *time.Time    `json:"resolved_at,omitempty" db:"resolved_at"`
+	CreatedAt        time.Time     `json:"created_at" db:"created_at"`
+}
+
+// RatingDisputeWithDetails represents a dispute with the rating it concerns, for moderators
+type RatingDisputeWithDetails struct {
+	RatingDispute
+	VolunteerName string     `json:"volunteer_name"`
+	Rating        RatingType `json:"rating"`
+	RatingNotes   string     `json:"rating_notes"`
+	RatedByUserID uuid.UUID  `json:"rated_by_user_id"`
+}
+
+// FileDispute opens a dispute against a rating. It returns ErrDisputeAlreadyOpen
+// if the rating already has one.
+func (s *VolunteerRatingService) FileDispute(dispute *RatingDispute) error {
+	query := `
+		INSERT INTO rating_disputes (id, rating_id, volunteer_id, filed_by_user_id, reason, status)
+		VALUES ($1, $2, $3, $4, $5, 'open')
+		ON CONFLICT (rating_id) WHERE status = 'open' DO NOTHING
+		RETURNING created_at`
+
+	dispute.ID = uuid.New()
+	dispute.Status = DisputeStatusOpen
+	err := s.db.QueryRow(query, dispute.ID, dispute.RatingID, dispute.VolunteerID,
+		dispute.FiledByUserID, dispute.Reason).Scan(&dispute.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrDisputeAlreadyOpen
+	}
+	return err
+}
+
+// GetDisputeByID retrieves a rating dispute by ID
+func (s *VolunteerRatingService) GetDisputeByID(id uuid.UUID) (*RatingDispute, error) {
+	dispute := &RatingDispute{}
+	query := `
+		SELECT id, rating_id, volunteer_id, filed_by_user_id, reason, status, resolution_note,
+		       resolved_by_user_id, resolved_at, created_at
+		FROM rating_disputes WHERE id = $1`
+
+	err := s.db.QueryRow(query, id).Scan(&dispute.ID, &dispute.RatingID, &dispute.VolunteerID,
+		&dispute.FiledByUserID, &dispute.Reason, &dispute.Status, &dispute.ResolutionNote,
+		&dispute.ResolvedByUserID, &dispute.ResolvedAt, &dispute.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+// ListDisputes retrieves disputes with the ratings they concern, oldest first so
+// moderators work through the queue in order. A nil status lists all of them.
+func (s *VolunteerRatingService) ListDisputes(status *DisputeStatus, limit, offset int) ([]RatingDisputeWithDetails, error) {
+	query := `
+		SELECT d.id, d.rating_id, d.volunteer_id, d.filed_by_user_id, d.reason, d.status, d.resolution_note,
+		       d.resolved_by_user_id, d.resolved_at, d.created_at,
+		       v.name, vr.rating, COALESCE(vr.notes, ''), vr.rated_by_user_id
+		FROM rating_disputes d
+		INNER JOIN volunteer_ratings vr ON d.rating_id = vr.id
+		INNER JOIN volunteers v ON d.volunteer_id = v.id
+		WHERE ($1::varchar IS NULL OR d.status = $1::varchar)
+		ORDER BY d.created_at ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := s.db.Query(query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disputes := []RatingDisputeWithDetails{}
+	for rows.Next() {
+		var dispute RatingDisputeWithDetails
+		err := rows.Scan(&dispute.ID, &dispute.RatingID, &dispute.VolunteerID, &dispute.FiledByUserID,
+			&dispute.Reason, &dispute.Status, &dispute.ResolutionNote, &dispute.ResolvedByUserID,
+			&dispute.ResolvedAt, &dispute.CreatedAt,
+			&dispute.VolunteerName, &dispute.Rating, &dispute.RatingNotes, &dispute.RatedByUserID)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	return disputes, rows.Err()
+}
+
+// ResolveDispute closes an open dispute as upheld or removed. Upholding hides the
+// rating from scorecard aggregation. It returns ErrDisputeNotOpen if the dispute
+// was already resolved and sql.ErrNoRows if it doesn't exist.
+func (s *VolunteerRatingService) ResolveDispute(id uuid.UUID, resolution DisputeStatus, resolverID uuid.UUID, note *string) (*RatingDispute, error) {
+	if resolution != DisputeStatusUpheld && resolution != DisputeStatusRemoved {
+		return nil, fmt.Errorf("invalid dispute resolution: %s", resolution)
+	}
+
+	dispute := &RatingDispute{}
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`
+			UPDATE rating_disputes
+			SET status = $2, resolution_note = $3, resolved_by_user_id = $4, resolved_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND status = 'open'
+			RETURNING id, rating_id, volunteer_id, filed_by_user_id, reason, status, resolution_note,
+			          resolved_by_user_id, resolved_at, created_at`,
+			id, resolution, note, resolverID).Scan(&dispute.ID, &dispute.RatingID, &dispute.VolunteerID,
+			&dispute.FiledByUserID, &dispute.Reason, &dispute.Status, &dispute.ResolutionNote,
+			&dispute.ResolvedByUserID, &dispute.ResolvedAt, &dispute.CreatedAt)
+		if err == sql.ErrNoRows {
+			var exists bool
+			if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM rating_disputes WHERE id = $1)`, id).Scan(&exists); err != nil {
+				return err
+			}
+			if exists {
+				return ErrDisputeNotOpen
+			}
+			return sql.ErrNoRows
+		}
+		if err != nil {
+			return err
+		}
+
+		if resolution == DisputeStatusUpheld {
+			_, err := tx.Exec(`UPDATE volunteer_ratings SET hidden_at = CURRENT_TIMESTAMP WHERE id = $1`, dispute.RatingID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resolution == DisputeStatusUpheld {
+		if err := s.invalidateScorecard(dispute.VolunteerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return dispute, nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"civicweave/backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenService handles refresh tokens, which are exchanged for new access
+// tokens. Tokens are stored hashed, and each can be used once.
+type RefreshTokenService struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenService creates a new refresh token service
+func NewRefreshTokenService(db *sql.DB) *RefreshTokenService {
+	return &RefreshTokenService{db: db}
+}
+
+// Issue creates a refresh token for userID that expires after expiresIn and returns
+// the plaintext token. The plaintext is not stored.
+func (s *RefreshTokenService) Issue(userID uuid.UUID, expiresIn time.Duration) (string, error) {
+	rawToken := utils.GenerateRandomToken()
+	_, err := s.db.Exec(refreshTokenCreateQuery, uuid.New(), userID, utils.HashToken(rawToken), time.Now().Add(expiresIn))
+	if err != nil {
+		return "", err
+	}
+	return rawToken, nil
+}
+
+// Rotate revokes a valid refresh token and issues a replacement for the same user.
+// It returns a nil user ID if the token is unknown, expired or already revoked.
+func (s *RefreshTokenService) Rotate(rawToken string, expiresIn time.Duration) (*uuid.UUID, string, error) {
+	var userID uuid.UUID
+	newToken := utils.GenerateRandomToken()
+
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		if err := tx.QueryRow(refreshTokenUseQuery, utils.HashToken(rawToken)).Scan(&userID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(refreshTokenCreateQuery, uuid.New(), userID, utils.HashToken(newToken), time.Now().Add(expiresIn))
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	return &userID, newToken, nil
+}
+
+// Revoke revokes a refresh token and returns the user it belonged to. Revoking an
+// unknown or already revoked token is not an error; it returns a nil user ID.
+func (s *RefreshTokenService) Revoke(rawToken string) (*uuid.UUID, error) {
+	var userID uuid.UUID
+	err := s.db.QueryRow(refreshTokenRevokeQuery, utils.HashToken(rawToken)).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &userID, nil
+}
+
+// CleanupExpiredTokens removes expired and revoked tokens
+func (s *RefreshTokenService) CleanupExpiredTokens() error {
+	_, err := s.db.Exec(refreshTokenCleanupQuery)
+	return err
+}
@@ -0,0 +1,28 @@
+package models
+
+// Query constants for RefreshTokenService
+const (
+	refreshTokenCreateQuery = `
+		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)`
+
+	// Revoking the presented token as it's used makes each refresh token single-use
+	refreshTokenUseQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING user_id`
+
+	refreshTokenRevokeQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE token_hash = $1 AND revoked_at IS NULL
+		RETURNING user_id`
+
+	refreshTokenRevokeAllForUserQuery = `
+		UPDATE refresh_tokens
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND revoked_at IS NULL`
+
+	refreshTokenCleanupQuery = `DELETE FROM refresh_tokens WHERE expires_at < NOW() OR revoked_at IS NOT NULL`
+)
@@ -26,6 +26,10 @@ type Resource struct {
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt     *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// FileChecksum is the hex SHA-256 of an uploaded file, used to detect duplicate uploads
+	FileChecksum *string `json:"file_checksum,omitempty" db:"file_checksum"`
+	// Version is the number of the current file or link; earlier ones are kept as ResourceVersions
+	Version int `json:"version" db:"version"`
 }
 
 // ResourceWithUploader includes resource and uploader info
@@ -64,7 +68,7 @@ func NewResourceService(db *sql.DB) *ResourceService {
 	return &ResourceService{db: db}
 }
 
-// Create creates a new resource
+// Create creates a new resource and records its file or link as version 1
 func (s *ResourceService) Create(resource *Resource) error {
 	resource.ID = uuid.New()
 	tagsJSON, err := ToJSONArray(resource.Tags)
@@ -72,22 +76,38 @@ func (s *ResourceService) Create(resource *Resource) error {
 		return err
 	}
 
-	return s.db.QueryRow(resourceCreateQuery, resource.ID, resource.Title, resource.Description,
-		resource.ResourceType, resource.FileURL, resource.FileSize, resource.MimeType,
-		resource.Scope, resource.ProjectID, resource.UploadedByID, tagsJSON).
-		Scan(&resource.CreatedAt, &resource.UpdatedAt)
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(resourceCreateQuery, resource.ID, resource.Title, resource.Description,
+			resource.ResourceType, resource.FileURL, resource.FileSize, resource.MimeType,
+			resource.Scope, resource.ProjectID, resource.UploadedByID, tagsJSON, resource.FileChecksum).
+			Scan(&resource.Version, &resource.CreatedAt, &resource.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return insertResourceVersion(tx, resource, resource.UploadedByID)
+	})
 }
 
 // GetByID retrieves a resource by ID
 func (s *ResourceService) GetByID(id uuid.UUID) (*Resource, error) {
+	return s.getOne(resourceGetByIDQuery, id)
+}
+
+// GetByChecksum retrieves the earliest resource whose uploaded file has the given checksum
+func (s *ResourceService) GetByChecksum(checksum string) (*Resource, error) {
+	return s.getOne(resourceGetByChecksumQuery, checksum)
+}
+
+// getOne retrieves the single resource selected by query
+func (s *ResourceService) getOne(query string, arg interface{}) (*Resource, error) {
 	resource := &Resource{}
 	var tagsJSON string
 
-	err := s.db.QueryRow(resourceGetByIDQuery, id).Scan(
+	err := s.db.QueryRow(query, arg).Scan(
 		&resource.ID, &resource.Title, &resource.Description, &resource.ResourceType,
 		&resource.FileURL, &resource.FileSize, &resource.MimeType, &resource.Scope,
 		&resource.ProjectID, &resource.UploadedByID, &tagsJSON, &resource.DownloadCount,
-		&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt,
+		&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt, &resource.FileChecksum, &resource.Version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -169,7 +189,7 @@ func (s *ResourceService) List(filters ResourceFilters, limit, offset int) ([]Re
 			&resource.ID, &resource.Title, &resource.Description, &resource.ResourceType,
 			&resource.FileURL, &resource.FileSize, &resource.MimeType, &resource.Scope,
 			&resource.ProjectID, &resource.UploadedByID, &tagsJSON, &resource.DownloadCount,
-			&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt,
+			&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt, &resource.FileChecksum, &resource.Version,
 			&resource.UploaderName, &resource.UploaderEmail, &resource.ProjectTitle,
 		)
 		if err != nil {
@@ -200,23 +220,26 @@ func (s *ResourceService) Update(resource *Resource) error {
 		Scan(&resource.UpdatedAt)
 }
 
-// SoftDelete soft-deletes a resource
+// SoftDelete soft-deletes a resource together with all of its versions
 func (s *ResourceService) SoftDelete(id uuid.UUID) error {
-	result, err := s.db.Exec(resourceSoftDeleteQuery, id)
-	if err != nil {
-		return err
-	}
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(resourceSoftDeleteQuery, id)
+		if err != nil {
+			return err
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
-	}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
 
-	return nil
+		_, err = tx.Exec(resourceVersionSoftDeleteQuery, id)
+		return err
+	})
 }
 
 // IncrementDownloadCount increments the download count for a resource
@@ -255,7 +278,7 @@ func (s *ResourceService) GetRecentResources(limit int) ([]ResourceWithUploader,
 			&resource.ID, &resource.Title, &resource.Description, &resource.ResourceType,
 			&resource.FileURL, &resource.FileSize, &resource.MimeType, &resource.Scope,
 			&resource.ProjectID, &resource.UploadedByID, &tagsJSON, &resource.DownloadCount,
-			&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt,
+			&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt, &resource.FileChecksum, &resource.Version,
 			&resource.UploaderName, &resource.UploaderEmail, &resource.ProjectTitle,
 		)
 		if err != nil {
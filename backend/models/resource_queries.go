@@ -4,21 +4,29 @@ package models
 const (
 	resourceCreateQuery = `
 		INSERT INTO resources (id, title, description, resource_type, file_url, file_size, 
-		                       mime_type, scope, project_id, uploaded_by_id, tags)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING created_at, updated_at`
+		                       mime_type, scope, project_id, uploaded_by_id, tags, file_checksum)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING version, created_at, updated_at`
 
 	resourceGetByIDQuery = `
 		SELECT id, title, description, resource_type, file_url, file_size, mime_type, 
 		       scope, project_id, uploaded_by_id, tags, download_count, 
-		       created_at, updated_at, deleted_at
+		       created_at, updated_at, deleted_at, file_checksum, version
 		FROM resources WHERE id = $1 AND deleted_at IS NULL`
 
+	resourceGetByChecksumQuery = `
+		SELECT id, title, description, resource_type, file_url, file_size, mime_type, 
+		       scope, project_id, uploaded_by_id, tags, download_count, 
+		       created_at, updated_at, deleted_at, file_checksum, version
+		FROM resources WHERE file_checksum = $1 AND deleted_at IS NULL
+		ORDER BY created_at
+		LIMIT 1`
+
 	resourceListQuery = `
 		SELECT 
 			r.id, r.title, r.description, r.resource_type, r.file_url, r.file_size, 
 			r.mime_type, r.scope, r.project_id, r.uploaded_by_id, r.tags, r.download_count,
-			r.created_at, r.updated_at, r.deleted_at,
+			r.created_at, r.updated_at, r.deleted_at, r.file_checksum, r.version,
 			COALESCE(v.name, a.name, u.email) as uploader_name,
 			u.email as uploader_email,
 			p.title as project_title
@@ -60,7 +68,7 @@ const (
 		SELECT 
 			r.id, r.title, r.description, r.resource_type, r.file_url, r.file_size, 
 			r.mime_type, r.scope, r.project_id, r.uploaded_by_id, r.tags, r.download_count,
-			r.created_at, r.updated_at, r.deleted_at,
+			r.created_at, r.updated_at, r.deleted_at, r.file_checksum, r.version,
 			COALESCE(v.name, a.name, u.email) as uploader_name,
 			u.email as uploader_email,
 			p.title as project_title
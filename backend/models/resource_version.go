@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResourceVersion is one file or link a resource has pointed to. The resource row
+// always mirrors its latest version.
+type ResourceVersion struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	ResourceID   uuid.UUID  `json:"resource_id" db:"resource_id"`
+	Version      int        `json:"version" db:"version"`
+	ResourceType string     `json:"resource_type" db:"resource_type"`
+	FileURL      string     `json:"file_url" db:"file_url"`
+	FileSize     *int64     `json:"file_size,omitempty" db:"file_size"`
+	MimeType     *string    `json:"mime_type,omitempty" db:"mime_type"`
+	FileChecksum *string    `json:"file_checksum,omitempty" db:"file_checksum"`
+	UploadedByID *uuid.UUID `json:"uploaded_by_id,omitempty" db:"uploaded_by_id"`
+	UploaderName string     `json:"uploader_name"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// UpdateWithNewVersion saves a resource whose file or link has been replaced, recording
+// the replacement as a new version uploaded by uploadedByID. Earlier versions are kept.
+func (s *ResourceService) UpdateWithNewVersion(resource *Resource, uploadedByID uuid.UUID) error {
+	tagsJSON, err := ToJSONArray(resource.Tags)
+	if err != nil {
+		return err
+	}
+
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(resourceUpdateWithVersionQuery, resource.ID, resource.Title, resource.Description,
+			resource.ResourceType, resource.FileURL, resource.FileSize, resource.MimeType,
+			resource.Scope, resource.ProjectID, tagsJSON, resource.FileChecksum).
+			Scan(&resource.Version, &resource.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return insertResourceVersion(tx, resource, uploadedByID)
+	})
+}
+
+// ListVersions returns a resource's versions, newest first
+func (s *ResourceService) ListVersions(resourceID uuid.UUID) ([]ResourceVersion, error) {
+	rows, err := s.db.Query(resourceVersionListQuery, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []ResourceVersion{}
+	for rows.Next() {
+		version, err := scanResourceVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+
+	return versions, rows.Err()
+}
+
+// GetVersion retrieves one version of a resource
+func (s *ResourceService) GetVersion(resourceID uuid.UUID, version int) (*ResourceVersion, error) {
+	resourceVersion, err := scanResourceVersion(s.db.QueryRow(resourceVersionGetQuery, resourceID, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resourceVersion, nil
+}
+
+// insertResourceVersion records the resource's current file or link as its current version
+func insertResourceVersion(tx *sql.Tx, resource *Resource, uploadedByID uuid.UUID) error {
+	_, err := tx.Exec(resourceVersionCreateQuery, uuid.New(), resource.ID, resource.Version,
+		resource.ResourceType, resource.FileURL, resource.FileSize, resource.MimeType,
+		resource.FileChecksum, uploadedByID)
+	return err
+}
+
+// scanResourceVersion scans a row of a resource version query
+func scanResourceVersion(row rowScanner) (*ResourceVersion, error) {
+	version := &ResourceVersion{}
+	err := row.Scan(
+		&version.ID, &version.ResourceID, &version.Version, &version.ResourceType, &version.FileURL,
+		&version.FileSize, &version.MimeType, &version.FileChecksum, &version.UploadedByID,
+		&version.CreatedAt, &version.UploaderName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
@@ -0,0 +1,44 @@
+package models
+
+// Query constants for resource versions
+const (
+	resourceVersionCreateQuery = `
+		INSERT INTO resource_versions (id, resource_id, version, resource_type, file_url, file_size,
+		                               mime_type, file_checksum, uploaded_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	// Bumps the version while replacing the file or link along with any other edited fields
+	resourceUpdateWithVersionQuery = `
+		UPDATE resources 
+		SET title = $2, description = $3, resource_type = $4, file_url = $5, 
+		    file_size = $6, mime_type = $7, scope = $8, project_id = $9, 
+		    tags = $10, file_checksum = $11, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING version, updated_at`
+
+	resourceVersionListQuery = `
+		SELECT rv.id, rv.resource_id, rv.version, rv.resource_type, rv.file_url, rv.file_size,
+		       rv.mime_type, rv.file_checksum, rv.uploaded_by_id, rv.created_at,
+		       COALESCE(v.name, a.name, u.email, '') as uploader_name
+		FROM resource_versions rv
+		LEFT JOIN users u ON rv.uploaded_by_id = u.id
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		WHERE rv.resource_id = $1 AND rv.deleted_at IS NULL
+		ORDER BY rv.version DESC`
+
+	resourceVersionGetQuery = `
+		SELECT rv.id, rv.resource_id, rv.version, rv.resource_type, rv.file_url, rv.file_size,
+		       rv.mime_type, rv.file_checksum, rv.uploaded_by_id, rv.created_at,
+		       COALESCE(v.name, a.name, u.email, '') as uploader_name
+		FROM resource_versions rv
+		LEFT JOIN users u ON rv.uploaded_by_id = u.id
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		WHERE rv.resource_id = $1 AND rv.version = $2 AND rv.deleted_at IS NULL`
+
+	resourceVersionSoftDeleteQuery = `
+		UPDATE resource_versions
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE resource_id = $1 AND deleted_at IS NULL`
+)
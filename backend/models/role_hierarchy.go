@@ -0,0 +1,131 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ErrRoleInheritanceCycle is returned when a role would end up inheriting itself
+var ErrRoleInheritanceCycle = fmt.Errorf("role cannot inherit itself, directly or through another role")
+
+// RoleHierarchy maps each role name to the names of the roles it directly inherits.
+// A user holding a role also holds, in effect, every role it inherits transitively.
+type RoleHierarchy map[string][]string
+
+// DefaultRoleHierarchy is the hierarchy seeded by the role_hierarchy migration, used
+// until the configured one has been loaded
+func DefaultRoleHierarchy() RoleHierarchy {
+	return RoleHierarchy{
+		"admin":     {"team_lead", "campaign_manager"},
+		"team_lead": {"volunteer"},
+	}
+}
+
+// Expand returns roles along with every role they inherit, sorted by name
+func (h RoleHierarchy) Expand(roles []string) []string {
+	seen := make(map[string]bool, len(roles))
+	queue := append([]string(nil), roles...)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+		queue = append(queue, h[role]...)
+	}
+
+	effective := make([]string, 0, len(seen))
+	for role := range seen {
+		effective = append(effective, role)
+	}
+	sort.Strings(effective)
+	return effective
+}
+
+// Includes reports whether holding roles grants role, directly or by inheritance
+func (h RoleHierarchy) Includes(roles []string, role string) bool {
+	for _, effective := range h.Expand(roles) {
+		if effective == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHierarchy loads the role hierarchy
+func (s *RoleService) GetHierarchy() (RoleHierarchy, error) {
+	rows, err := s.db.Query(roleHierarchyQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hierarchy := RoleHierarchy{}
+	for rows.Next() {
+		var role, inherited string
+		if err := rows.Scan(&role, &inherited); err != nil {
+			return nil, err
+		}
+		hierarchy[role] = append(hierarchy[role], inherited)
+	}
+	return hierarchy, rows.Err()
+}
+
+// GetInheritedRoles returns the roles a role directly inherits
+func (s *RoleService) GetInheritedRoles(roleID uuid.UUID) ([]Role, error) {
+	rows, err := s.db.Query(roleInheritedRolesQuery, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []Role{}
+	for rows.Next() {
+		var role Role
+		var permissionsJSON string
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &permissionsJSON, &role.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := ParseJSONArray(permissionsJSON, &role.Permissions); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// SetInheritedRoles replaces the roles a role directly inherits. It returns
+// ErrRoleInheritanceCycle if any of them already inherits the role.
+func (s *RoleService) SetInheritedRoles(roleID uuid.UUID, inheritedRoleIDs []uuid.UUID) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Serialize hierarchy changes so two concurrent edits can't form a cycle
+		if _, err := tx.Exec("LOCK TABLE role_inheritance IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(roleClearInheritanceQuery, roleID); err != nil {
+			return err
+		}
+
+		for _, inheritedID := range inheritedRoleIDs {
+			if inheritedID == roleID {
+				return ErrRoleInheritanceCycle
+			}
+			var cycle bool
+			if err := tx.QueryRow(roleInheritsQuery, inheritedID, roleID).Scan(&cycle); err != nil {
+				return err
+			}
+			if cycle {
+				return ErrRoleInheritanceCycle
+			}
+			if _, err := tx.Exec(roleAddInheritanceQuery, roleID, inheritedID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
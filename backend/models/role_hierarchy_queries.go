@@ -0,0 +1,36 @@
+package models
+
+// Query constants for the role hierarchy
+const (
+	roleHierarchyQuery = `
+		SELECT r.name, inherited.name
+		FROM role_inheritance ri
+		JOIN roles r ON ri.role_id = r.id
+		JOIN roles inherited ON ri.inherits_role_id = inherited.id
+		ORDER BY r.name, inherited.name`
+
+	roleInheritedRolesQuery = `
+		SELECT r.id, r.name, r.description, r.permissions, r.created_at
+		FROM role_inheritance ri
+		JOIN roles r ON ri.inherits_role_id = r.id
+		WHERE ri.role_id = $1
+		ORDER BY r.name`
+
+	roleClearInheritanceQuery = `DELETE FROM role_inheritance WHERE role_id = $1`
+
+	roleAddInheritanceQuery = `
+		INSERT INTO role_inheritance (role_id, inherits_role_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	// Reports whether role $1 inherits role $2, directly or transitively
+	roleInheritsQuery = `
+		WITH RECURSIVE inherited(role_id) AS (
+		    SELECT inherits_role_id FROM role_inheritance WHERE role_id = $1
+		    UNION
+		    SELECT ri.inherits_role_id
+		    FROM role_inheritance ri
+		    JOIN inherited a ON ri.role_id = a.role_id
+		)
+		SELECT EXISTS(SELECT 1 FROM inherited WHERE role_id = $2)`
+)
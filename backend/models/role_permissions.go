@@ -0,0 +1,178 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Permissions granted through roles. Routes that need a narrow capability check one of
+// these rather than a role, so it can be granted without the rest of a role.
+const (
+	// PermissionAll grants every permission
+	PermissionAll = "*"
+
+	PermissionViewProjects     = "view_projects"
+	PermissionApplyToProjects  = "apply_to_projects"
+	PermissionViewOwnProfile   = "view_own_profile"
+	PermissionRateVolunteers   = "rate_volunteers"
+	PermissionCreateProjects   = "create_projects"
+	PermissionEditProjects     = "edit_projects"
+	PermissionManageTeams      = "manage_teams"
+	PermissionViewVolunteers   = "view_volunteers"
+	PermissionViewCampaigns    = "view_campaigns"
+	PermissionCreateCampaigns  = "create_campaigns"
+	PermissionSendCampaigns    = "send_campaigns"
+	PermissionDeleteCampaigns  = "delete_campaigns"
+	PermissionSendBroadcasts   = "send_broadcasts"
+	PermissionManageBroadcasts = "manage_broadcasts"
+
+	// Permissions that reach past the caller's own teams and records
+	PermissionViewAllProjects        = "view_all_projects"
+	PermissionManageAllProjects      = "manage_all_projects"
+	PermissionOverrideProjectRules   = "override_project_rules"
+	PermissionAssignTeamLeads        = "assign_team_leads"
+	PermissionModerateMessages       = "moderate_messages"
+	PermissionManageAllCampaigns     = "manage_all_campaigns"
+	PermissionManageRatings          = "manage_ratings"
+	PermissionManageVolunteers       = "manage_volunteers"
+	PermissionManageProjectTemplates = "manage_project_templates"
+	PermissionBypassMaintenance      = "bypass_maintenance"
+)
+
+// KnownPermissions lists every permission a role can be granted
+var KnownPermissions = []string{
+	PermissionAll,
+	PermissionViewProjects,
+	PermissionApplyToProjects,
+	PermissionViewOwnProfile,
+	PermissionRateVolunteers,
+	PermissionCreateProjects,
+	PermissionEditProjects,
+	PermissionManageTeams,
+	PermissionViewVolunteers,
+	PermissionViewCampaigns,
+	PermissionCreateCampaigns,
+	PermissionSendCampaigns,
+	PermissionDeleteCampaigns,
+	PermissionSendBroadcasts,
+	PermissionManageBroadcasts,
+	PermissionViewAllProjects,
+	PermissionManageAllProjects,
+	PermissionOverrideProjectRules,
+	PermissionAssignTeamLeads,
+	PermissionModerateMessages,
+	PermissionManageAllCampaigns,
+	PermissionManageRatings,
+	PermissionManageVolunteers,
+	PermissionManageProjectTemplates,
+	PermissionBypassMaintenance,
+}
+
+// ErrUnknownPermission is returned when a role is given a permission nothing checks
+var ErrUnknownPermission = fmt.Errorf("unknown permission")
+
+// RolePermissions maps each role name to the permissions it grants directly
+type RolePermissions map[string][]string
+
+// DefaultRolePermissions are the permissions seeded for the built-in roles, used until
+// the configured ones have been loaded
+func DefaultRolePermissions() RolePermissions {
+	return RolePermissions{
+		"volunteer":        {PermissionViewProjects, PermissionApplyToProjects, PermissionViewOwnProfile, PermissionRateVolunteers},
+		"team_lead":        {PermissionViewProjects, PermissionCreateProjects, PermissionEditProjects, PermissionManageTeams, PermissionRateVolunteers, PermissionViewVolunteers},
+		"campaign_manager": {PermissionViewProjects, PermissionViewVolunteers, PermissionCreateCampaigns, PermissionSendCampaigns, PermissionViewCampaigns},
+		"admin":            {PermissionAll},
+	}
+}
+
+// Effective returns every permission granted by roles, sorted. Callers pass roles
+// already expanded through the role hierarchy.
+func (p RolePermissions) Effective(roles []string) []string {
+	seen := make(map[string]bool)
+	for _, role := range roles {
+		for _, permission := range p[role] {
+			seen[permission] = true
+		}
+	}
+
+	permissions := make([]string, 0, len(seen))
+	for permission := range seen {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// Grants reports whether roles grant permission, directly or through PermissionAll
+func (p RolePermissions) Grants(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, granted := range p[role] {
+			if granted == permission || granted == PermissionAll {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidatePermissions checks that every permission is known, returning them
+// de-duplicated and sorted
+func ValidatePermissions(permissions []string) ([]string, error) {
+	known := make(map[string]bool, len(KnownPermissions))
+	for _, permission := range KnownPermissions {
+		known[permission] = true
+	}
+
+	seen := make(map[string]bool, len(permissions))
+	validated := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if !known[permission] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			validated = append(validated, permission)
+		}
+	}
+	sort.Strings(validated)
+	return validated, nil
+}
+
+// GetRolePermissions loads the permissions each role grants directly
+func (s *RoleService) GetRolePermissions() (RolePermissions, error) {
+	roles, err := s.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(RolePermissions, len(roles))
+	for _, role := range roles {
+		permissions[role.Name] = role.Permissions
+	}
+	return permissions, nil
+}
+
+// SetPermissions replaces the permissions a role grants, returning sql.ErrNoRows if the
+// role doesn't exist. The permissions must already be validated.
+func (s *RoleService) SetPermissions(roleID uuid.UUID, permissions []string) error {
+	permissionsJSON, err := ToJSONArray(permissions)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(roleSetPermissionsQuery, roleID, permissionsJSON)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+package models
+
+// Query constants for role permissions
+const (
+	roleSetPermissionsQuery = `UPDATE roles SET permissions = $2 WHERE id = $1`
+)
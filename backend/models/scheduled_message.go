@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleMessage stores a message to be delivered at message.ScheduledAt instead
+// of sending it now. Until then it is only visible to its sender.
+func (s *MessageService) ScheduleMessage(message *ProjectMessage) error {
+	message.ID = uuid.New()
+	setUniversalMessageDefaults(message)
+
+	return s.db.QueryRow(scheduledMessageCreateQuery, message.ID, message.ProjectID, message.SenderID,
+		message.RecipientUserID, message.RecipientTeamID, message.Subject, message.MessageText,
+		message.MessageType, message.MessageScope, message.ScheduledAt).
+		Scan(&message.ScheduledAt, &message.CreatedAt)
+}
+
+// GetScheduledMessage retrieves a message that is still waiting to be sent
+func (s *MessageService) GetScheduledMessage(id uuid.UUID) (*ProjectMessage, error) {
+	message := &ProjectMessage{}
+
+	err := s.db.QueryRow(scheduledMessageGetByIDQuery, id).Scan(
+		&message.ID, &message.ProjectID, &message.SenderID, &message.RecipientUserID, &message.RecipientTeamID,
+		&message.Subject, &message.MessageText, &message.MessageType, &message.MessageScope,
+		&message.ScheduledAt, &message.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// ListScheduledMessages retrieves a user's messages that are still waiting to be sent, soonest first
+func (s *MessageService) ListScheduledMessages(senderID uuid.UUID) ([]ProjectMessage, error) {
+	rows, err := s.db.Query(scheduledMessageListBySenderQuery, senderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []ProjectMessage{}
+	for rows.Next() {
+		var message ProjectMessage
+		err := rows.Scan(
+			&message.ID, &message.ProjectID, &message.SenderID, &message.RecipientUserID, &message.RecipientTeamID,
+			&message.Subject, &message.MessageText, &message.MessageType, &message.MessageScope,
+			&message.ScheduledAt, &message.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// CancelScheduledMessage cancels a pending scheduled message. It returns
+// sql.ErrNoRows if the message has already been sent or isn't the sender's.
+func (s *MessageService) CancelScheduledMessage(id, senderID uuid.UUID) error {
+	result, err := s.db.Exec(scheduledMessageCancelQuery, id, senderID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeliverDueScheduledMessages moves up to limit scheduled messages whose time has
+// come into project_messages, keeping their IDs, and returns them. Delivery and
+// removal from the schedule happen in one transaction, so each is sent once.
+func (s *MessageService) DeliverDueScheduledMessages(limit int) ([]ProjectMessage, error) {
+	var due []ProjectMessage
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		rows, err := tx.Query(scheduledMessageClaimDueQuery, limit)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var message ProjectMessage
+			err := rows.Scan(
+				&message.ID, &message.ProjectID, &message.SenderID, &message.RecipientUserID, &message.RecipientTeamID,
+				&message.Subject, &message.MessageText, &message.MessageType, &message.MessageScope,
+				&message.ScheduledAt, &message.CreatedAt,
+			)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			due = append(due, message)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for i := range due {
+			message := &due[i]
+			err := tx.QueryRow(scheduledMessageDeliverQuery, message.ID, message.ProjectID, message.SenderID,
+				message.RecipientUserID, message.RecipientTeamID, message.Subject, message.MessageText,
+				message.MessageType, message.MessageScope, message.ScheduledAt).
+				Scan(&message.CreatedAt)
+			if err != nil {
+				return err
+			}
+
+			// Auto-record read receipt for sender, as for messages sent immediately
+			if _, err := tx.Exec(messageMarkAsReadQuery, message.SenderID, message.ID); err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(scheduledMessageDeleteQuery, message.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
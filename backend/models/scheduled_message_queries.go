@@ -0,0 +1,43 @@
+package models
+
+// Query constants for scheduled messages
+const (
+	scheduledMessageCreateQuery = `
+		INSERT INTO scheduled_messages (id, project_id, sender_id, recipient_user_id, recipient_team_id,
+		                                subject, message_text, message_type, message_scope, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::timestamptz)
+		RETURNING scheduled_at, created_at`
+
+	scheduledMessageGetByIDQuery = `
+		SELECT id, project_id, sender_id, recipient_user_id, recipient_team_id, subject,
+		       message_text, message_type, message_scope, scheduled_at, created_at
+		FROM scheduled_messages WHERE id = $1`
+
+	scheduledMessageListBySenderQuery = `
+		SELECT id, project_id, sender_id, recipient_user_id, recipient_team_id, subject,
+		       message_text, message_type, message_scope, scheduled_at, created_at
+		FROM scheduled_messages
+		WHERE sender_id = $1
+		ORDER BY scheduled_at ASC`
+
+	scheduledMessageCancelQuery = `
+		DELETE FROM scheduled_messages WHERE id = $1 AND sender_id = $2`
+
+	// Skip rows another server instance is already delivering
+	scheduledMessageClaimDueQuery = `
+		SELECT id, project_id, sender_id, recipient_user_id, recipient_team_id, subject,
+		       message_text, message_type, message_scope, scheduled_at, created_at
+		FROM scheduled_messages
+		WHERE scheduled_at <= CURRENT_TIMESTAMP
+		ORDER BY scheduled_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	scheduledMessageDeliverQuery = `
+		INSERT INTO project_messages (id, project_id, sender_id, recipient_user_id, recipient_team_id,
+		                              subject, message_text, message_type, message_scope, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at`
+
+	scheduledMessageDeleteQuery = `DELETE FROM scheduled_messages WHERE id = $1`
+)
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrSkillCategoryCycle is returned when a skill would end up nested under itself
+var ErrSkillCategoryCycle = fmt.Errorf("skill cannot be nested under itself, directly or through another skill")
+
+// SkillTreeNode is a taxonomy skill together with the skills nested under it
+type SkillTreeNode struct {
+	SkillTaxonomy
+	Children []*SkillTreeNode `json:"children"`
+}
+
+// skillIsAncestorQuery reports whether $1 is $2 or one of $2's ancestors
+const skillIsAncestorQuery = `
+	WITH RECURSIVE ancestors(id) AS (
+	    SELECT $2::int
+	    UNION
+	    SELECT st.parent_id
+	    FROM skill_taxonomy st
+	    JOIN ancestors a ON st.id = a.id
+	    WHERE st.parent_id IS NOT NULL
+	)
+	SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = $1)
+`
+
+// SetSkillParent nests a skill under parentID, or makes it top level when parentID
+// is nil. Returns sql.ErrNoRows if the skill doesn't exist, ErrUnknownSkill if the
+// parent doesn't, and ErrSkillCategoryCycle if the parent is the skill or nested under it.
+func (s *SkillTaxonomyService) SetSkillParent(skillID int, parentID *int) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Serialize taxonomy changes so two concurrent moves can't form a cycle
+		if _, err := tx.Exec("LOCK TABLE skill_taxonomy IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM skill_taxonomy WHERE id = $1)`, skillID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return sql.ErrNoRows
+		}
+
+		if parentID != nil {
+			if *parentID == skillID {
+				return ErrSkillCategoryCycle
+			}
+			if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM skill_taxonomy WHERE id = $1)`, *parentID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return ErrUnknownSkill
+			}
+
+			var cycle bool
+			if err := tx.QueryRow(skillIsAncestorQuery, skillID, *parentID).Scan(&cycle); err != nil {
+				return err
+			}
+			if cycle {
+				return ErrSkillCategoryCycle
+			}
+		}
+
+		_, err := tx.Exec(`
+			UPDATE skill_taxonomy
+			SET parent_id = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1
+		`, skillID, parentID)
+		return err
+	})
+}
+
+// GetSkillParents returns the parent of every nested skill keyed by skill ID
+func (s *SkillTaxonomyService) GetSkillParents() (map[int]int, error) {
+	rows, err := s.db.Query(`SELECT id, parent_id FROM skill_taxonomy WHERE parent_id IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill parents: %w", err)
+	}
+	defer rows.Close()
+
+	parents := make(map[int]int)
+	for rows.Next() {
+		var skillID, parentID int
+		if err := rows.Scan(&skillID, &parentID); err != nil {
+			return nil, fmt.Errorf("failed to scan skill parent: %w", err)
+		}
+		parents[skillID] = parentID
+	}
+	return parents, rows.Err()
+}
+
+// GetSkillTree returns the taxonomy as a tree of categories. When categoryID is set
+// only that skill's subtree is returned, with the category as the single root; a
+// category that doesn't exist yields nil.
+func (s *SkillTaxonomyService) GetSkillTree(categoryID *int) ([]*SkillTreeNode, error) {
+	skills, err := s.GetAllSkills()
+	if err != nil {
+		return nil, err
+	}
+	return BuildSkillTree(skills, categoryID), nil
+}
+
+// BuildSkillTree arranges a flat list of skills into a tree, children sorted by name.
+// Skills whose parent isn't in the list are treated as roots.
+func BuildSkillTree(skills []SkillTaxonomy, categoryID *int) []*SkillTreeNode {
+	nodes := make(map[int]*SkillTreeNode, len(skills))
+	for _, skill := range skills {
+		nodes[skill.ID] = &SkillTreeNode{SkillTaxonomy: skill, Children: []*SkillTreeNode{}}
+	}
+
+	roots := []*SkillTreeNode{}
+	for _, skill := range skills {
+		node := nodes[skill.ID]
+		if skill.ParentID != nil {
+			if parent, ok := nodes[*skill.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	for _, node := range nodes {
+		sortSkillNodes(node.Children)
+	}
+	sortSkillNodes(roots)
+
+	if categoryID == nil {
+		return roots
+	}
+	category, ok := nodes[*categoryID]
+	if !ok {
+		return nil
+	}
+	return []*SkillTreeNode{category}
+}
+
+func sortSkillNodes(nodes []*SkillTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].SkillName) < strings.ToLower(nodes[j].SkillName)
+	})
+}
@@ -68,16 +68,9 @@ func NewSkillClaimService(db *sql.DB) *SkillClaimService {
 	return &SkillClaimService{db: db}
 }
 
-// CreateSkillClaim creates a new skill claim with embedding and initial weight
-func (s *SkillClaimService) CreateSkillClaim(volunteerID uuid.UUID, claimText string, embedding pgvector.Vector) (*SkillClaim, error) {
-	// Start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Create skill claim
+// CreateSkillClaim creates a new skill claim with embedding and initial weight.
+// model records which embedding model produced the vector.
+func (s *SkillClaimService) CreateSkillClaim(volunteerID uuid.UUID, claimText string, embedding pgvector.Vector, model string) (*SkillClaim, error) {
 	claim := &SkillClaim{
 		ID:          uuid.New(),
 		VolunteerID: volunteerID,
@@ -86,15 +79,30 @@ func (s *SkillClaimService) CreateSkillClaim(volunteerID uuid.UUID, claimText st
 		IsActive:    true,
 	}
 
+	// Create the claim and its initial weight together
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		return insertSkillClaim(tx, claim, model)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// insertSkillClaim inserts claim with its initial weight inside the caller's transaction.
+// model records which embedding model produced the vector; a claim with no recorded
+// model is flagged needs_embedding.
+func insertSkillClaim(tx *sql.Tx, claim *SkillClaim, model string) error {
 	query := `
-		INSERT INTO skill_claims (id, volunteer_id, claim_text, embedding, is_active)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO skill_claims (id, volunteer_id, claim_text, embedding, is_active, embedding_model, embedded_at, needs_embedding)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6::text, ''), CURRENT_TIMESTAMP, $6::text = '')
 		RETURNING created_at, updated_at`
 
-	err = tx.QueryRow(query, claim.ID, claim.VolunteerID, claim.ClaimText, claim.Embedding, claim.IsActive).
+	err := tx.QueryRow(query, claim.ID, claim.VolunteerID, claim.ClaimText, claim.Embedding, claim.IsActive, model).
 		Scan(&claim.CreatedAt, &claim.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create skill claim: %w", err)
+		return fmt.Errorf("failed to create skill claim: %w", err)
 	}
 
 	// Create initial weight
@@ -113,15 +121,9 @@ func (s *SkillClaimService) CreateSkillClaim(volunteerID uuid.UUID, claimText st
 	err = tx.QueryRow(weightQuery, weight.ID, weight.SkillClaimID, weight.Weight, weight.UpdateReason).
 		Scan(&weight.CreatedAt, &weight.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create skill weight: %w", err)
-	}
-
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return fmt.Errorf("failed to create skill weight: %w", err)
 	}
-
-	return claim, nil
+	return nil
 }
 
 // GetActiveClaimsByVolunteer retrieves all active skill claims for a volunteer
@@ -295,6 +297,119 @@ func (s *SkillClaimService) ListAllSkillClaims(limit, offset int, activeOnly boo
 	return claims, rows.Err()
 }
 
+// LegacyEmbeddingModel selects claims embedded before the model was recorded
+const LegacyEmbeddingModel = "legacy"
+
+// PendingSkillClaim is an active skill claim selected for (re)embedding
+type PendingSkillClaim struct {
+	ID             uuid.UUID `json:"id"`
+	ClaimText      string    `json:"claim_text"`
+	EmbeddingModel *string   `json:"embedding_model"`
+}
+
+// claimsToEmbedFilter selects active claims flagged with needs_embedding, or,
+// when a model is given, active claims whose embedding came from that model
+const claimsToEmbedFilter = `
+		WHERE sc.is_active = true
+		  AND (($1 = '' AND sc.needs_embedding = true)
+		    OR ($1 <> '' AND COALESCE(sc.embedding_model, '` + LegacyEmbeddingModel + `') = $1))`
+
+// staleClaimsFilter selects active, unflagged claims embedded with a model other than $1
+const staleClaimsFilter = `
+		WHERE sc.is_active = true AND sc.needs_embedding = false
+		  AND COALESCE(sc.embedding_model, '` + LegacyEmbeddingModel + `') <> $1`
+
+// CountStaleClaims counts the claims FlagStaleClaims would flag for currentModel
+func (s *SkillClaimService) CountStaleClaims(currentModel string) (int, error) {
+	query := `SELECT COUNT(*) FROM skill_claims sc` + staleClaimsFilter
+
+	var count int
+	if err := s.db.QueryRow(query, currentModel).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count stale skill claims: %w", err)
+	}
+
+	return count, nil
+}
+
+// FlagStaleClaims sets needs_embedding on active claims embedded with a model other
+// than currentModel, so a model change is picked up by the next backfill. It returns
+// how many claims were flagged.
+func (s *SkillClaimService) FlagStaleClaims(currentModel string) (int64, error) {
+	query := `UPDATE skill_claims sc SET needs_embedding = true` + staleClaimsFilter
+
+	result, err := s.db.Exec(query, currentModel)
+	if err != nil {
+		return 0, fmt.Errorf("failed to flag stale skill claims: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// CountClaimsToEmbed counts the claims GetClaimsToEmbed would return across all pages
+func (s *SkillClaimService) CountClaimsToEmbed(model string) (int, error) {
+	query := `SELECT COUNT(*) FROM skill_claims sc` + claimsToEmbedFilter
+
+	var count int
+	if err := s.db.QueryRow(query, model).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count skill claims to embed: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetClaimsToEmbed retrieves a page of claims needing (re)embedding, ordered by ID.
+// Pass uuid.Nil as afterID for the first page and the last returned ID thereafter.
+func (s *SkillClaimService) GetClaimsToEmbed(model string, afterID uuid.UUID, limit int) ([]PendingSkillClaim, error) {
+	query := `
+		SELECT sc.id, sc.claim_text, sc.embedding_model
+		FROM skill_claims sc` + claimsToEmbedFilter + `
+		  AND sc.id > $2
+		ORDER BY sc.id
+		LIMIT $3`
+
+	rows, err := s.db.Query(query, model, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill claims to embed: %w", err)
+	}
+	defer rows.Close()
+
+	var claims []PendingSkillClaim
+	for rows.Next() {
+		var claim PendingSkillClaim
+		if err := rows.Scan(&claim.ID, &claim.ClaimText, &claim.EmbeddingModel); err != nil {
+			return nil, fmt.Errorf("failed to scan skill claim: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims, rows.Err()
+}
+
+// UpdateClaimEmbedding stores a new embedding for a claim and clears its needs_embedding flag
+func (s *SkillClaimService) UpdateClaimEmbedding(claimID uuid.UUID, embedding pgvector.Vector, model string) error {
+	query := `
+		UPDATE skill_claims
+		SET embedding = $1, embedding_model = $2, needs_embedding = false,
+		    embedded_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3`
+
+	result, err := s.db.Exec(query, embedding, model, claimID)
+	if err != nil {
+		return fmt.Errorf("failed to update skill claim embedding: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("skill claim not found")
+	}
+
+	return nil
+}
+
 // UpdateVolunteerSkillsVisibility updates the skills_visible flag for a volunteer
 func (s *SkillClaimService) UpdateVolunteerSkillsVisibility(volunteerID uuid.UUID, visible bool) error {
 	query := `
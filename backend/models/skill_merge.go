@@ -0,0 +1,295 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Skill alias and merge errors
+var (
+	ErrSkillMergeSelf   = fmt.Errorf("a skill cannot be merged into itself")
+	ErrAliasIsSkillName = fmt.Errorf("alias matches an existing skill name")
+	ErrAliasInUse       = fmt.Errorf("alias already points to another skill")
+)
+
+// SkillMergeResult summarises what a skill merge moved onto the surviving skill
+type SkillMergeResult struct {
+	Source              SkillTaxonomy `json:"source"`
+	Target              SkillTaxonomy `json:"target"`
+	VolunteerSkills     int64         `json:"volunteer_skills"`
+	ProjectSkills       int64         `json:"project_skills"`
+	AliasesMoved        int64         `json:"aliases_moved"`
+	ProjectsToRematch   int           `json:"projects_to_rematch"`
+	VolunteersToRematch int           `json:"volunteers_to_rematch"`
+}
+
+// ListAliasesBySkill returns every skill's aliases keyed by skill ID
+func (s *SkillTaxonomyService) ListAliasesBySkill() (map[int][]string, error) {
+	aliases, err := s.ListAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	bySkill := make(map[int][]string)
+	for _, alias := range aliases {
+		bySkill[alias.SkillID] = append(bySkill[alias.SkillID], alias.Alias)
+	}
+	return bySkill, nil
+}
+
+// AddAlias makes alias resolve to a skill. Aliases are stored in lower case. It
+// returns ErrAliasIsSkillName when another skill already has that name and
+// ErrAliasInUse when the alias points to a different skill; adding an alias a
+// skill already has is a no-op. Returns sql.ErrNoRows if the skill doesn't exist.
+func (s *SkillTaxonomyService) AddAlias(skillID int, alias string) (*SkillAlias, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias == "" {
+		return nil, ErrEmptyField
+	}
+
+	var result *SkillAlias
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		var skillName string
+		err := tx.QueryRow(`SELECT skill_name FROM skill_taxonomy WHERE id = $1`, skillID).Scan(&skillName)
+		if err != nil {
+			return err
+		}
+
+		var nameTaken bool
+		err = tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM skill_taxonomy WHERE LOWER(skill_name) = $1)`, alias).Scan(&nameTaken)
+		if err != nil {
+			return fmt.Errorf("failed to check skill names: %w", err)
+		}
+		if nameTaken {
+			return ErrAliasIsSkillName
+		}
+
+		var existingSkillID int
+		err = tx.QueryRow(`
+			INSERT INTO skill_aliases (alias, skill_id)
+			VALUES ($1, $2)
+			ON CONFLICT (alias) DO UPDATE SET alias = EXCLUDED.alias
+			RETURNING skill_id
+		`, alias, skillID).Scan(&existingSkillID)
+		if err != nil {
+			return fmt.Errorf("failed to add skill alias: %w", err)
+		}
+		if existingSkillID != skillID {
+			return ErrAliasInUse
+		}
+
+		result = &SkillAlias{Alias: alias, SkillID: skillID}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveAlias deletes an alias. Returns sql.ErrNoRows if it doesn't exist.
+func (s *SkillTaxonomyService) RemoveAlias(alias string) error {
+	result, err := s.db.Exec(`DELETE FROM skill_aliases WHERE alias = $1`, strings.ToLower(strings.TrimSpace(alias)))
+	if err != nil {
+		return fmt.Errorf("failed to remove skill alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MergeSkills folds the source skill into the target in one transaction: volunteer
+// and project references move to the target (volunteers holding both keep the
+// higher weight), the source's aliases move too, the source name becomes an alias
+// of the target, and the source is deleted. Projects that required the source and
+// volunteers who held it are marked for match recalculation. Returns sql.ErrNoRows if either skill doesn't exist.
+func (s *SkillTaxonomyService) MergeSkills(sourceID, targetID int) (*SkillMergeResult, error) {
+	if sourceID == targetID {
+		return nil, ErrSkillMergeSelf
+	}
+
+	result := &SkillMergeResult{}
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Serialize taxonomy changes so concurrent merges or category edits can't
+		// interleave or form a cycle
+		if _, err := tx.Exec("LOCK TABLE skill_taxonomy IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`
+			SELECT id, skill_name, parent_id, created_at, updated_at
+			FROM skill_taxonomy
+			WHERE id IN ($1, $2)
+			ORDER BY id
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to lock skills: %w", err)
+		}
+		found := 0
+		for rows.Next() {
+			var skill SkillTaxonomy
+			if err := rows.Scan(&skill.ID, &skill.SkillName, &skill.ParentID, &skill.CreatedAt, &skill.UpdatedAt); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan skill: %w", err)
+			}
+			if skill.ID == sourceID {
+				result.Source = skill
+			} else {
+				result.Target = skill
+			}
+			found++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if found != 2 {
+			return sql.ErrNoRows
+		}
+
+		projectIDs, err := queryUUIDs(tx, `SELECT project_id FROM project_required_skills WHERE skill_id = $1`, sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to list projects requiring skill: %w", err)
+		}
+		volunteerIDs, err := queryUUIDs(tx, `SELECT volunteer_id FROM volunteer_skills WHERE skill_id = $1`, sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to list volunteers with skill: %w", err)
+		}
+
+		moved, err := execCount(tx, `
+			INSERT INTO volunteer_skills (volunteer_id, skill_id, skill_weight, proficiency_level,
+			                              years_experience, last_used_year, visibility, added_at)
+			SELECT volunteer_id, $2, skill_weight, proficiency_level,
+			       years_experience, last_used_year, visibility, added_at
+			FROM volunteer_skills
+			WHERE skill_id = $1
+			ON CONFLICT (volunteer_id, skill_id) DO UPDATE
+			SET skill_weight = GREATEST(volunteer_skills.skill_weight, EXCLUDED.skill_weight),
+			    updated_at = CURRENT_TIMESTAMP
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move volunteer skills: %w", err)
+		}
+		result.VolunteerSkills = moved
+
+		moved, err = execCount(tx, `
+			INSERT INTO project_required_skills (project_id, skill_id)
+			SELECT project_id, $2 FROM project_required_skills WHERE skill_id = $1
+			ON CONFLICT (project_id, skill_id) DO NOTHING
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move project skills: %w", err)
+		}
+		result.ProjectSkills = moved
+
+		if _, err := tx.Exec(`UPDATE volunteer_skill_weight_overrides SET skill_id = $2 WHERE skill_id = $1`, sourceID, targetID); err != nil {
+			return fmt.Errorf("failed to move skill weight overrides: %w", err)
+		}
+
+		moved, err = execCount(tx, `UPDATE skill_aliases SET skill_id = $2 WHERE skill_id = $1`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move skill aliases: %w", err)
+		}
+		result.AliasesMoved = moved
+
+		// The old name keeps resolving, now to the target
+		_, err = tx.Exec(`
+			INSERT INTO skill_aliases (alias, skill_id)
+			VALUES (LOWER($1), $2)
+			ON CONFLICT (alias) DO UPDATE SET skill_id = EXCLUDED.skill_id
+		`, result.Source.SkillName, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to record skill alias: %w", err)
+		}
+
+		// Skills nested under the source move under the target, except the target and
+		// its ancestors, which move up to the source's parent instead of forming a cycle
+		_, err = tx.Exec(`
+			WITH RECURSIVE target_ancestors(id) AS (
+			    SELECT $2::int
+			    UNION
+			    SELECT st.parent_id
+			    FROM skill_taxonomy st
+			    JOIN target_ancestors ta ON st.id = ta.id
+			    WHERE st.parent_id IS NOT NULL
+			)
+			UPDATE skill_taxonomy
+			SET parent_id = CASE WHEN id IN (SELECT id FROM target_ancestors) THEN $3 ELSE $2 END,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE parent_id = $1
+		`, sourceID, targetID, result.Source.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to move nested skills: %w", err)
+		}
+
+		// Remaining source rows are duplicates of rows the target already had
+		if _, err := tx.Exec(`DELETE FROM volunteer_skills WHERE skill_id = $1`, sourceID); err != nil {
+			return fmt.Errorf("failed to remove merged volunteer skills: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM project_required_skills WHERE skill_id = $1`, sourceID); err != nil {
+			return fmt.Errorf("failed to remove merged project skills: %w", err)
+		}
+		if _, err := tx.Exec(`DELETE FROM skill_taxonomy WHERE id = $1`, sourceID); err != nil {
+			return fmt.Errorf("failed to delete merged skill: %w", err)
+		}
+
+		for _, projectID := range projectIDs {
+			if err := markMatchingDirty(tx, MatchingEntityProject, projectID); err != nil {
+				return err
+			}
+		}
+		result.ProjectsToRematch = len(projectIDs)
+
+		for _, volunteerID := range volunteerIDs {
+			if err := markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID); err != nil {
+				return err
+			}
+		}
+		result.VolunteersToRematch = len(volunteerIDs)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// queryUUIDs runs a query returning a single UUID column inside tx
+func queryUUIDs(tx *sql.Tx, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// execCount runs a statement inside tx and returns how many rows it affected
+func execCount(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
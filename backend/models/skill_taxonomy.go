@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -13,10 +14,32 @@ import (
 type SkillTaxonomy struct {
 	ID        int       `json:"id" db:"id"`
 	SkillName string    `json:"skill_name" db:"skill_name"`
+	ParentID  *int      `json:"parent_id" db:"parent_id"` // The category skill this one is nested under
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Skill visibility levels. Only public skills are used for matching; team-only
+// skills are also shown to the volunteer's own project teams.
+const (
+	SkillVisibilityPublic   = "public"
+	SkillVisibilityTeamOnly = "team_only"
+	SkillVisibilityPrivate  = "private"
+)
+
+// SkillVisibilities lists the valid skill visibility levels
+var SkillVisibilities = []string{SkillVisibilityPublic, SkillVisibilityTeamOnly, SkillVisibilityPrivate}
+
+// IsValidSkillVisibility reports whether visibility is a known skill visibility level
+func IsValidSkillVisibility(visibility string) bool {
+	for _, v := range SkillVisibilities {
+		if v == visibility {
+			return true
+		}
+	}
+	return false
+}
+
 // VolunteerSkill represents a volunteer's skill with weight
 type VolunteerSkill struct {
 	VolunteerID      uuid.UUID `json:"volunteer_id" db:"volunteer_id"`
@@ -25,6 +48,7 @@ type VolunteerSkill struct {
 	ProficiencyLevel *string   `json:"proficiency_level" db:"proficiency_level"`
 	YearsExperience  *int      `json:"years_experience" db:"years_experience"`
 	LastUsedYear     *int      `json:"last_used_year" db:"last_used_year"`
+	Visibility       string    `json:"visibility" db:"visibility"`
 	AddedAt          time.Time `json:"added_at" db:"added_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 	// Joined fields
@@ -60,6 +84,13 @@ type VolunteerInitiativeMatch struct {
 	CalculatedAt      time.Time `json:"calculated_at" db:"calculated_at"`
 }
 
+// Required skill validation errors
+var (
+	ErrTooManySkills  = fmt.Errorf("too many required skills")
+	ErrDuplicateSkill = fmt.Errorf("duplicate skill")
+	ErrUnknownSkill   = fmt.Errorf("unknown skill")
+)
+
 // SkillTaxonomyService handles skill taxonomy operations
 type SkillTaxonomyService struct {
 	db *sql.DB
@@ -73,7 +104,7 @@ func NewSkillTaxonomyService(db *sql.DB) *SkillTaxonomyService {
 // GetAllSkills retrieves all skills in the taxonomy
 func (s *SkillTaxonomyService) GetAllSkills() ([]SkillTaxonomy, error) {
 	query := `
-		SELECT id, skill_name, created_at, updated_at
+		SELECT id, skill_name, parent_id, created_at, updated_at
 		FROM skill_taxonomy
 		ORDER BY skill_name
 	`
@@ -92,7 +123,7 @@ func (s *SkillTaxonomyService) GetAllSkills() ([]SkillTaxonomy, error) {
 	var skills []SkillTaxonomy
 	for rows.Next() {
 		var skill SkillTaxonomy
-		err := rows.Scan(&skill.ID, &skill.SkillName, &skill.CreatedAt, &skill.UpdatedAt)
+		err := rows.Scan(&skill.ID, &skill.SkillName, &skill.ParentID, &skill.CreatedAt, &skill.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan skill: %w", err)
 		}
@@ -102,23 +133,17 @@ func (s *SkillTaxonomyService) GetAllSkills() ([]SkillTaxonomy, error) {
 	return skills, rows.Err()
 }
 
-// AddSkill adds a new skill to the taxonomy (if it doesn't exist)
+// AddSkill adds a new skill to the taxonomy (if it doesn't exist). A name matching an
+// existing skill or one of its aliases resolves to that skill.
 func (s *SkillTaxonomyService) AddSkill(name string) (*SkillTaxonomy, error) {
 	// First, try to find existing skill (case-insensitive)
-	var existing SkillTaxonomy
-	err := s.db.QueryRow(`
-		SELECT id, skill_name, created_at, updated_at
-		FROM skill_taxonomy
-		WHERE LOWER(skill_name) = LOWER($1)
-	`, name).Scan(&existing.ID, &existing.SkillName, &existing.CreatedAt, &existing.UpdatedAt)
-
-	if err == nil {
-		return &existing, nil // Skill already exists
-	}
-
-	if err != sql.ErrNoRows {
+	existing, err := s.FindSkill(name)
+	if err != nil {
 		return nil, fmt.Errorf("failed to check existing skill: %w", err)
 	}
+	if existing != nil {
+		return existing, nil // Skill already exists
+	}
 
 	// Create new skill
 	skill := &SkillTaxonomy{
@@ -144,7 +169,7 @@ func (s *SkillTaxonomyService) GetVolunteerSkills(volunteerID uuid.UUID) ([]Volu
 	query := `
 		SELECT vs.volunteer_id, vs.skill_id, vs.skill_weight, 
 		       vs.proficiency_level, vs.years_experience, vs.last_used_year,
-		       vs.added_at, vs.updated_at, st.skill_name
+		       vs.visibility, vs.added_at, vs.updated_at, st.skill_name
 		FROM volunteer_skills vs
 		JOIN skill_taxonomy st ON vs.skill_id = st.id
 		WHERE vs.volunteer_id = $1
@@ -163,7 +188,7 @@ func (s *SkillTaxonomyService) GetVolunteerSkills(volunteerID uuid.UUID) ([]Volu
 		err := rows.Scan(
 			&skill.VolunteerID, &skill.SkillID, &skill.SkillWeight,
 			&skill.ProficiencyLevel, &skill.YearsExperience, &skill.LastUsedYear,
-			&skill.AddedAt, &skill.UpdatedAt, &skill.SkillName,
+			&skill.Visibility, &skill.AddedAt, &skill.UpdatedAt, &skill.SkillName,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan volunteer skill: %w", err)
@@ -174,63 +199,100 @@ func (s *SkillTaxonomyService) GetVolunteerSkills(volunteerID uuid.UUID) ([]Volu
 	return skills, rows.Err()
 }
 
-// UpdateVolunteerSkills replaces all skills for a volunteer
+// UpdateVolunteerSkills replaces all skills for a volunteer. Skills that are kept
+// retain their visibility.
 func (s *SkillTaxonomyService) UpdateVolunteerSkills(volunteerID uuid.UUID, skillIDs []int) error {
-	// Start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Delete existing skills
-	_, err = tx.Exec(`
-		DELETE FROM volunteer_skills WHERE volunteer_id = $1
-	`, volunteerID)
+	skillIDsJSON, err := json.Marshal(append([]int{}, skillIDs...))
 	if err != nil {
-		return fmt.Errorf("failed to delete existing skills: %w", err)
+		return fmt.Errorf("failed to encode skill IDs: %w", err)
 	}
 
-	// Insert new skills with default weight 0.5
-	for _, skillID := range skillIDs {
-		_, err = tx.Exec(`
-			INSERT INTO volunteer_skills (volunteer_id, skill_id, skill_weight)
-			VALUES ($1, $2, $3)
-		`, volunteerID, skillID, 0.5)
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Delete skills that are no longer listed
+		_, err := tx.Exec(`
+			DELETE FROM volunteer_skills
+			WHERE volunteer_id = $1
+			  AND skill_id NOT IN (SELECT jsonb_array_elements_text($2::jsonb)::int)
+		`, volunteerID, string(skillIDsJSON))
 		if err != nil {
-			return fmt.Errorf("failed to insert skill %d: %w", skillID, err)
+			return fmt.Errorf("failed to delete existing skills: %w", err)
+		}
+
+		// Insert new skills and reset kept ones to the default weight 0.5
+		for _, skillID := range skillIDs {
+			_, err = tx.Exec(`
+				INSERT INTO volunteer_skills (volunteer_id, skill_id, skill_weight)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (volunteer_id, skill_id)
+				DO UPDATE SET skill_weight = EXCLUDED.skill_weight, updated_at = CURRENT_TIMESTAMP
+			`, volunteerID, skillID, 0.5)
+			if err != nil {
+				return fmt.Errorf("failed to insert skill %d: %w", skillID, err)
+			}
 		}
-	}
 
-	return tx.Commit()
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
 }
 
 // AddVolunteerSkills adds new skills to a volunteer (without removing existing)
 func (s *SkillTaxonomyService) AddVolunteerSkills(volunteerID uuid.UUID, skillIDs []int) error {
-	for _, skillID := range skillIDs {
-		// Use INSERT ... ON CONFLICT DO NOTHING to avoid duplicates
-		_, err := s.db.Exec(`
-			INSERT INTO volunteer_skills (volunteer_id, skill_id, skill_weight)
-			VALUES ($1, $2, $3)
-			ON CONFLICT (volunteer_id, skill_id) DO NOTHING
-		`, volunteerID, skillID, 0.5)
-		if err != nil {
-			return fmt.Errorf("failed to add skill %d: %w", skillID, err)
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		for _, skillID := range skillIDs {
+			// Use INSERT ... ON CONFLICT DO NOTHING to avoid duplicates
+			_, err := tx.Exec(`
+				INSERT INTO volunteer_skills (volunteer_id, skill_id, skill_weight)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (volunteer_id, skill_id) DO NOTHING
+			`, volunteerID, skillID, 0.5)
+			if err != nil {
+				return fmt.Errorf("failed to add skill %d: %w", skillID, err)
+			}
 		}
-	}
-	return nil
+
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
 }
 
 // RemoveVolunteerSkill removes a specific skill from a volunteer
 func (s *SkillTaxonomyService) RemoveVolunteerSkill(volunteerID uuid.UUID, skillID int) error {
-	_, err := s.db.Exec(`
-		DELETE FROM volunteer_skills 
-		WHERE volunteer_id = $1 AND skill_id = $2
-	`, volunteerID, skillID)
-	if err != nil {
-		return fmt.Errorf("failed to remove volunteer skill: %w", err)
-	}
-	return nil
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			DELETE FROM volunteer_skills 
+			WHERE volunteer_id = $1 AND skill_id = $2
+		`, volunteerID, skillID)
+		if err != nil {
+			return fmt.Errorf("failed to remove volunteer skill: %w", err)
+		}
+
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
+}
+
+// SetVolunteerSkillVisibility changes who can see one of a volunteer's skills and
+// marks the volunteer for match recalculation, since only public skills are
+// matched. It returns sql.ErrNoRows when the volunteer doesn't have the skill.
+func (s *SkillTaxonomyService) SetVolunteerSkillVisibility(volunteerID uuid.UUID, skillID int, visibility string) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE volunteer_skills
+			SET visibility = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE volunteer_id = $1 AND skill_id = $2
+		`, volunteerID, skillID, visibility)
+		if err != nil {
+			return fmt.Errorf("failed to update skill visibility: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
 }
 
 // GetInitiativeSkills retrieves all required skills for an initiative
@@ -264,33 +326,27 @@ func (s *SkillTaxonomyService) GetInitiativeSkills(initiativeID uuid.UUID) ([]In
 
 // UpdateInitiativeSkills replaces all required skills for an initiative
 func (s *SkillTaxonomyService) UpdateInitiativeSkills(initiativeID uuid.UUID, skillIDs []int) error {
-	// Start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Delete existing required skills
-	_, err = tx.Exec(`
-		DELETE FROM initiative_required_skills WHERE initiative_id = $1
-	`, initiativeID)
-	if err != nil {
-		return fmt.Errorf("failed to delete existing initiative skills: %w", err)
-	}
-
-	// Insert new required skills
-	for _, skillID := range skillIDs {
-		_, err = tx.Exec(`
-			INSERT INTO initiative_required_skills (initiative_id, skill_id)
-			VALUES ($1, $2)
-		`, initiativeID, skillID)
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Delete existing required skills
+		_, err := tx.Exec(`
+			DELETE FROM initiative_required_skills WHERE initiative_id = $1
+		`, initiativeID)
 		if err != nil {
-			return fmt.Errorf("failed to insert initiative skill %d: %w", skillID, err)
+			return fmt.Errorf("failed to delete existing initiative skills: %w", err)
 		}
-	}
 
-	return tx.Commit()
+		// Insert new required skills
+		for _, skillID := range skillIDs {
+			_, err = tx.Exec(`
+				INSERT INTO initiative_required_skills (initiative_id, skill_id)
+				VALUES ($1, $2)
+			`, initiativeID, skillID)
+			if err != nil {
+				return fmt.Errorf("failed to insert initiative skill %d: %w", skillID, err)
+			}
+		}
+		return nil
+	})
 }
 
 // GetProjectSkills retrieves all required skills for a project
@@ -324,13 +380,14 @@ func (s *SkillTaxonomyService) GetProjectSkills(projectID uuid.UUID) ([]ProjectR
 
 // UpdateProjectSkills replaces all required skills for a project
 func (s *SkillTaxonomyService) UpdateProjectSkills(projectID uuid.UUID, skillIDs []int) error {
-	// Start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		return setProjectSkills(tx, projectID, skillIDs)
+	})
+}
 
+// setProjectSkills replaces a project's required skills in tx, keeping the legacy
+// projects.required_skills names in step, and queues the project for rematching
+func setProjectSkills(tx *sql.Tx, projectID uuid.UUID, skillIDs []int) error {
 	// Get skill names for the JSONB field
 	var skillNames []string
 	if len(skillIDs) > 0 {
@@ -387,7 +444,7 @@ func (s *SkillTaxonomyService) UpdateProjectSkills(projectID uuid.UUID, skillIDs
 		}
 	}
 
-	return tx.Commit()
+	return markMatchingDirty(tx, MatchingEntityProject, projectID)
 }
 
 // ResolveSkillNames converts skill names to IDs, adding new skills to taxonomy if needed
@@ -409,6 +466,110 @@ func (s *SkillTaxonomyService) ResolveSkillNames(skillNames []string) ([]int, er
 	return skillIDs, nil
 }
 
+// SkillAlias maps an alternate name onto a canonical taxonomy skill
+type SkillAlias struct {
+	Alias   string `json:"alias" db:"alias"`
+	SkillID int    `json:"skill_id" db:"skill_id"`
+}
+
+// ListAliases retrieves every skill alias
+func (s *SkillTaxonomyService) ListAliases() ([]SkillAlias, error) {
+	rows, err := s.db.Query(`SELECT alias, skill_id FROM skill_aliases ORDER BY alias`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []SkillAlias
+	for rows.Next() {
+		var alias SkillAlias
+		if err := rows.Scan(&alias.Alias, &alias.SkillID); err != nil {
+			return nil, fmt.Errorf("failed to scan skill alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// FindSkill resolves a skill name or alias to its taxonomy entry (case-insensitive).
+// Returns nil if nothing matches.
+func (s *SkillTaxonomyService) FindSkill(name string) (*SkillTaxonomy, error) {
+	var skill SkillTaxonomy
+	err := s.db.QueryRow(`
+		SELECT st.id, st.skill_name, st.parent_id, st.created_at, st.updated_at
+		FROM skill_taxonomy st
+		WHERE LOWER(st.skill_name) = LOWER($1)
+		UNION ALL
+		SELECT st.id, st.skill_name, st.parent_id, st.created_at, st.updated_at
+		FROM skill_aliases sa
+		JOIN skill_taxonomy st ON sa.skill_id = st.id
+		WHERE sa.alias = LOWER($1)
+		LIMIT 1
+	`, name).Scan(&skill.ID, &skill.SkillName, &skill.ParentID, &skill.CreatedAt, &skill.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up skill: %w", err)
+	}
+
+	return &skill, nil
+}
+
+// ValidateRequiredSkills checks a project's required skills against the cap and the
+// taxonomy, rejecting unknown skills and duplicates (including two aliases of the same
+// skill). It returns the canonical taxonomy names in the order given.
+func (s *SkillTaxonomyService) ValidateRequiredSkills(names []string, maxSkills int) ([]string, error) {
+	skills, err := s.ResolveRequiredSkills(names, maxSkills)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make([]string, len(skills))
+	for i, skill := range skills {
+		canonical[i] = skill.SkillName
+	}
+	return canonical, nil
+}
+
+// ResolveRequiredSkills is ValidateRequiredSkills returning the taxonomy entries, for
+// callers that need the skill IDs too
+func (s *SkillTaxonomyService) ResolveRequiredSkills(names []string, maxSkills int) ([]SkillTaxonomy, error) {
+	if maxSkills > 0 && len(names) > maxSkills {
+		return nil, fmt.Errorf("%w: %d given, maximum is %d", ErrTooManySkills, len(names), maxSkills)
+	}
+
+	skills := make([]SkillTaxonomy, 0, len(names))
+	seen := make(map[int]string)
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("skill %d: %w", i, ErrEmptyField)
+		}
+
+		skill, err := s.FindSkill(name)
+		if err != nil {
+			return nil, err
+		}
+		if skill == nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSkill, name)
+		}
+
+		if previous, ok := seen[skill.ID]; ok {
+			if strings.EqualFold(previous, name) {
+				return nil, fmt.Errorf("%w: %q", ErrDuplicateSkill, name)
+			}
+			return nil, fmt.Errorf("%w: %q and %q are both %q", ErrDuplicateSkill, previous, name, skill.SkillName)
+		}
+		seen[skill.ID] = name
+		skills = append(skills, *skill)
+	}
+
+	return skills, nil
+}
+
 // GetVolunteerProfileCompletion calculates completion percentage for a volunteer
 func (s *SkillTaxonomyService) GetVolunteerProfileCompletion(volunteerID uuid.UUID) (int, error) {
 	// Check if volunteer has location
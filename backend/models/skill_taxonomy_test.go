@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+)
+
+// skillRow is a skill_taxonomy row for FindSkill
+func skillRow(id int, name string) []driver.Value {
+	now := time.Now()
+	return []driver.Value{int64(id), name, nil, now, now}
+}
+
+var skillColumns = []string{"id", "skill_name", "parent_id", "created_at", "updated_at"}
+
+func TestValidateRequiredSkillsReturnsCanonicalNames(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("FROM skill_taxonomy st", skillColumns, skillRow(7, "Carpentry"))
+
+	canonical, err := NewSkillTaxonomyService(db).ValidateRequiredSkills([]string{" woodworking "}, 3)
+	if err != nil {
+		t.Fatalf("ValidateRequiredSkills() error = %v", err)
+	}
+	if len(canonical) != 1 || canonical[0] != "Carpentry" {
+		t.Errorf("canonical = %v, want [Carpentry]", canonical)
+	}
+}
+
+func TestValidateRequiredSkillsRejectsMoreThanTheCap(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	_, err := NewSkillTaxonomyService(db).ValidateRequiredSkills([]string{"Carpentry", "Painting", "Plumbing"}, 2)
+	if !errors.Is(err, ErrTooManySkills) {
+		t.Fatalf("ValidateRequiredSkills() error = %v, want ErrTooManySkills", err)
+	}
+	if len(recorder.Statements()) != 0 {
+		t.Error("skills were looked up even though the list is over the cap")
+	}
+}
+
+func TestValidateRequiredSkillsRejectsUnknownSkills(t *testing.T) {
+	db, _ := fakesql.Open()
+	defer db.Close()
+
+	_, err := NewSkillTaxonomyService(db).ValidateRequiredSkills([]string{"Juggling"}, 5)
+	if !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("ValidateRequiredSkills() error = %v, want ErrUnknownSkill", err)
+	}
+}
+
+func TestValidateRequiredSkillsRejectsDuplicates(t *testing.T) {
+	tests := []struct {
+		name   string
+		skills []string
+	}{
+		{"same name twice", []string{"Carpentry", "carpentry"}},
+		// Every lookup resolves to the same taxonomy entry, as two of its aliases would
+		{"two aliases of one skill", []string{"Carpentry", "Woodworking"}},
+	}
+
+	for _, tt := range tests {
+		db, recorder := fakesql.Open()
+		recorder.Rows("FROM skill_taxonomy st", skillColumns, skillRow(7, "Carpentry"))
+
+		_, err := NewSkillTaxonomyService(db).ValidateRequiredSkills(tt.skills, 5)
+		db.Close()
+		if !errors.Is(err, ErrDuplicateSkill) {
+			t.Errorf("%s: error = %v, want ErrDuplicateSkill", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SkillTrendInterval is the width of the buckets in a skill trend
+type SkillTrendInterval string
+
+const (
+	SkillTrendWeek    SkillTrendInterval = "week"
+	SkillTrendMonth   SkillTrendInterval = "month"
+	SkillTrendQuarter SkillTrendInterval = "quarter"
+)
+
+// MaxSkillTrendBuckets bounds how many buckets one trend may span
+const MaxSkillTrendBuckets = 260
+
+// ErrTooManyTrendBuckets is returned when a date range is too long for its interval
+var ErrTooManyTrendBuckets = fmt.Errorf("date range spans more than %d buckets; use a wider interval", MaxSkillTrendBuckets)
+
+// SkillTrendBucket counts demand for and supply of a skill within one bucket
+type SkillTrendBucket struct {
+	BucketStart     time.Time `json:"bucket_start"`
+	ProjectDemand   int       `json:"project_demand"`   // Projects that started requiring the skill
+	VolunteerSupply int       `json:"volunteer_supply"` // Volunteers who added the skill
+}
+
+// SkillTrend is a skill's demand and supply over time
+type SkillTrend struct {
+	SkillID   int                `json:"skill_id"`
+	SkillName string             `json:"skill_name"`
+	Buckets   []SkillTrendBucket `json:"buckets"`
+}
+
+// truncate returns the start of the bucket containing t, matching Postgres date_trunc:
+// weeks start on Monday, quarters in January, April, July and October
+func (interval SkillTrendInterval) truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch interval {
+	case SkillTrendWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday)
+	case SkillTrendQuarter:
+		firstMonth := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), firstMonth, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// next returns the start of the bucket after the one starting at bucketStart
+func (interval SkillTrendInterval) next(bucketStart time.Time) time.Time {
+	switch interval {
+	case SkillTrendWeek:
+		return bucketStart.AddDate(0, 0, 7)
+	case SkillTrendQuarter:
+		return bucketStart.AddDate(0, 3, 0)
+	default:
+		return bucketStart.AddDate(0, 1, 0)
+	}
+}
+
+// SkillTrendBuckets returns the start of every bucket overlapping [from, to), oldest first
+func SkillTrendBuckets(from, to time.Time, interval SkillTrendInterval) ([]time.Time, error) {
+	var buckets []time.Time
+	for start := interval.truncate(from); start.Before(to); start = interval.next(start) {
+		if len(buckets) == MaxSkillTrendBuckets {
+			return nil, ErrTooManyTrendBuckets
+		}
+		buckets = append(buckets, start)
+	}
+	return buckets, nil
+}
+
+// GetSkillTrend counts, per bucket of [from, to), the projects that started requiring
+// a skill and the volunteers who added it. Buckets with no activity are included as zeros.
+func (s *SkillTaxonomyService) GetSkillTrend(skill *SkillTaxonomy, from, to time.Time, interval SkillTrendInterval) (*SkillTrend, error) {
+	bucketStarts, err := SkillTrendBuckets(from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	trend := &SkillTrend{
+		SkillID:   skill.ID,
+		SkillName: skill.SkillName,
+		Buckets:   make([]SkillTrendBucket, len(bucketStarts)),
+	}
+	index := make(map[string]int, len(bucketStarts))
+	for i, start := range bucketStarts {
+		trend.Buckets[i].BucketStart = start
+		index[start.Format("2006-01-02")] = i
+	}
+
+	demandQuery := `
+		SELECT date_trunc($2, prs.added_at) as bucket, COUNT(DISTINCT prs.project_id)
+		FROM project_required_skills prs
+		WHERE prs.skill_id = $1 AND prs.added_at >= $3 AND prs.added_at < $4
+		GROUP BY bucket`
+
+	supplyQuery := `
+		SELECT date_trunc($2, vs.added_at) as bucket, COUNT(DISTINCT vs.volunteer_id)
+		FROM volunteer_skills vs
+		WHERE vs.skill_id = $1 AND vs.added_at >= $3 AND vs.added_at < $4
+		GROUP BY bucket`
+
+	for _, series := range []struct {
+		query string
+		apply func(bucket *SkillTrendBucket, count int)
+	}{
+		{demandQuery, func(bucket *SkillTrendBucket, count int) { bucket.ProjectDemand = count }},
+		{supplyQuery, func(bucket *SkillTrendBucket, count int) { bucket.VolunteerSupply = count }},
+	} {
+		rows, err := s.db.Query(series.query, skill.ID, string(interval), from.UTC(), to.UTC())
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate skill trend: %w", err)
+		}
+
+		for rows.Next() {
+			var bucket time.Time
+			var count int
+			if err := rows.Scan(&bucket, &count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if i, ok := index[bucket.Format("2006-01-02")]; ok {
+				series.apply(&trend.Buckets[i], count)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return trend, nil
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+)
+
+func trendDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSkillTrendBuckets(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		interval SkillTrendInterval
+		want     []time.Time
+	}{
+		// 2024-01-03 is a Wednesday; weeks start on Monday like date_trunc('week')
+		{"week", trendDate(2024, 1, 3), trendDate(2024, 1, 16), SkillTrendWeek,
+			[]time.Time{trendDate(2024, 1, 1), trendDate(2024, 1, 8), trendDate(2024, 1, 15)}},
+		{"month", trendDate(2024, 1, 31), trendDate(2024, 3, 1), SkillTrendMonth,
+			[]time.Time{trendDate(2024, 1, 1), trendDate(2024, 2, 1)}},
+		{"quarter", trendDate(2024, 2, 15), trendDate(2024, 7, 2), SkillTrendQuarter,
+			[]time.Time{trendDate(2024, 1, 1), trendDate(2024, 4, 1), trendDate(2024, 7, 1)}},
+		{"empty range", trendDate(2024, 1, 1), trendDate(2024, 1, 1), SkillTrendMonth, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := SkillTrendBuckets(tt.from, tt.to, tt.interval)
+		if err != nil {
+			t.Errorf("%s: SkillTrendBuckets() error = %v", tt.name, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: buckets = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if !got[i].Equal(tt.want[i]) {
+				t.Errorf("%s: bucket %d = %s, want %s", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSkillTrendBucketsRejectsTooManyBuckets(t *testing.T) {
+	if _, err := SkillTrendBuckets(trendDate(2000, 1, 1), trendDate(2010, 1, 1), SkillTrendWeek); err != ErrTooManyTrendBuckets {
+		t.Errorf("ten years of weeks: error = %v, want ErrTooManyTrendBuckets", err)
+	}
+	if _, err := SkillTrendBuckets(trendDate(2000, 1, 1), trendDate(2010, 1, 1), SkillTrendQuarter); err != nil {
+		t.Errorf("ten years of quarters: error = %v", err)
+	}
+}
+
+func TestGetSkillTrendFillsEveryBucket(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("FROM project_required_skills prs", []string{"bucket", "count"},
+		[]driver.Value{trendDate(2024, 1, 1), int64(3)},
+		[]driver.Value{trendDate(2024, 3, 1), int64(1)},
+	)
+	recorder.Rows("FROM volunteer_skills vs", []string{"bucket", "count"},
+		[]driver.Value{trendDate(2024, 2, 1), int64(5)},
+	)
+
+	trend, err := NewSkillTaxonomyService(db).GetSkillTrend(&SkillTaxonomy{ID: 7, SkillName: "First Aid"},
+		trendDate(2024, 1, 10), trendDate(2024, 4, 1), SkillTrendMonth)
+	if err != nil {
+		t.Fatalf("GetSkillTrend() error = %v", err)
+	}
+
+	want := []SkillTrendBucket{
+		{BucketStart: trendDate(2024, 1, 1), ProjectDemand: 3},
+		{BucketStart: trendDate(2024, 2, 1), VolunteerSupply: 5},
+		{BucketStart: trendDate(2024, 3, 1), ProjectDemand: 1},
+	}
+	if len(trend.Buckets) != len(want) {
+		t.Fatalf("buckets = %+v, want %+v", trend.Buckets, want)
+	}
+	for i := range want {
+		if trend.Buckets[i] != want[i] {
+			t.Errorf("bucket %d = %+v, want %+v", i, trend.Buckets[i], want[i])
+		}
+	}
+
+	args := recorder.Statements()[0].Args
+	if args[0] != int64(7) || args[1] != "month" {
+		t.Errorf("args = %v, want the skill and the interval", args)
+	}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +20,60 @@ const (
 	TaskStatusTakeoverRequested TaskStatus = "takeover_requested"
 )
 
+// Task status errors
+var (
+	ErrInvalidTaskStatus     = fmt.Errorf("invalid task status")
+	ErrInvalidTaskTransition = fmt.Errorf("invalid task status transition")
+	// ErrTaskStatusChanged is returned when another request changed a task's status
+	// between reading it and applying an update
+	ErrTaskStatusChanged = fmt.Errorf("task status was changed by another request")
+)
+
+// taskStatusTransitions lists the statuses each status may move to. Done is final here;
+// a done task only leaves it by being reopened with ReopenTask.
+var taskStatusTransitions = map[TaskStatus][]TaskStatus{
+	TaskStatusTodo:              {TaskStatusInProgress, TaskStatusBlocked, TaskStatusTakeoverRequested},
+	TaskStatusInProgress:        {TaskStatusTodo, TaskStatusDone, TaskStatusBlocked, TaskStatusTakeoverRequested},
+	TaskStatusBlocked:           {TaskStatusTodo, TaskStatusInProgress, TaskStatusTakeoverRequested},
+	TaskStatusTakeoverRequested: {TaskStatusTodo, TaskStatusInProgress, TaskStatusBlocked},
+	TaskStatusDone:              {},
+}
+
+// IsValid checks if the status is one of the known task statuses
+func (s TaskStatus) IsValid() bool {
+	_, ok := taskStatusTransitions[s]
+	return ok
+}
+
+// ValidateTaskStatusTransition checks that a task may move from one status to another.
+// Moving to the status it's already in is not a transition and is rejected.
+func ValidateTaskStatusTransition(from, to TaskStatus) error {
+	if !to.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidTaskStatus, to)
+	}
+	if from == to {
+		return fmt.Errorf("%w: task is already %s", ErrInvalidTaskTransition, to)
+	}
+	for _, allowed := range taskStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	if from == TaskStatusDone {
+		return fmt.Errorf("%w: a done task must be reopened before it can move to %s", ErrInvalidTaskTransition, to)
+	}
+	return fmt.Errorf("%w: cannot move from %s to %s", ErrInvalidTaskTransition, from, to)
+}
+
+// ValidateTaskReopen checks that a task in the given status may be reopened; only done
+// tasks can be
+func ValidateTaskReopen(from TaskStatus) error {
+	if from != TaskStatusDone {
+		return fmt.Errorf("%w: only done tasks can be reopened, task is %s", ErrInvalidTaskTransition, from)
+	}
+	return nil
+}
+
 // TaskPriority represents the priority level of a task
 type TaskPriority string
 
@@ -51,6 +107,7 @@ type ProjectTask struct {
 	TakeoverRequestedAt *time.Time   `json:"takeover_requested_at,omitempty" db:"takeover_requested_at"`
 	TakeoverReason      *string      `json:"takeover_reason,omitempty" db:"takeover_reason"`
 	LastStatusChangedBy *uuid.UUID   `json:"last_status_changed_by,omitempty" db:"last_status_changed_by"`
+	RecurrenceID        *uuid.UUID   `json:"recurrence_id,omitempty" db:"recurrence_id"` // The recurring task this is an instance of
 	CreatedAt           time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time    `json:"updated_at" db:"updated_at"`
 }
@@ -67,7 +124,43 @@ type TaskUpdate struct {
 // TaskWithUpdates includes the task and its updates
 type TaskWithUpdates struct {
 	ProjectTask
-	Updates []TaskUpdate `json:"updates,omitempty"`
+	Updates   []TaskUpdate `json:"updates,omitempty"`
+	Resources []Resource   `json:"resources,omitempty"`
+}
+
+// VolunteerTask is a task in a volunteer's cross-project "my work" view
+type VolunteerTask struct {
+	ProjectTask
+	IsOverdue bool `json:"is_overdue"`
+}
+
+// VolunteerTaskFilters narrows a volunteer's task list. Nil or empty fields are ignored.
+type VolunteerTaskFilters struct {
+	Statuses    []TaskStatus
+	ProjectID   *uuid.UUID
+	Priority    *TaskPriority
+	DueFrom     *time.Time // Inclusive
+	DueTo       *time.Time // Inclusive of the whole day
+	OverdueOnly bool
+	SortBy      string // One of VolunteerTaskSortFields; defaults to due_date
+	SortDesc    bool
+}
+
+// VolunteerTaskSortFields maps the accepted sort keys to their ORDER BY expressions
+var VolunteerTaskSortFields = map[string]string{
+	"due_date":   "pt.due_date",
+	"priority":   "CASE pt.priority WHEN 'high' THEN 3 WHEN 'medium' THEN 2 ELSE 1 END",
+	"status":     "pt.status",
+	"project":    "p.title",
+	"created_at": "pt.created_at",
+	"updated_at": "pt.updated_at",
+}
+
+// VolunteerTaskPage is one page of a volunteer's tasks with totals across all pages
+type VolunteerTaskPage struct {
+	Tasks        []VolunteerTask `json:"tasks"`
+	Total        int             `json:"total"`
+	OverdueCount int             `json:"overdue_count"`
 }
 
 // TaskService handles task operations
@@ -87,14 +180,21 @@ func NewTaskService(db *sql.DB) *TaskService {
 
 // Create creates a new task
 func (s *TaskService) Create(task *ProjectTask) error {
+	return insertTask(s.db, task)
+}
+
+// insertTask creates a task with db, which may be a transaction
+func insertTask(db interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}, task *ProjectTask) error {
 	task.ID = uuid.New()
 	labelsJSON, err := ToJSONArray(task.Labels)
 	if err != nil {
 		return err
 	}
 
-	return s.db.QueryRow(taskCreateQuery, task.ID, task.ProjectID, task.Title, task.Description,
-		task.AssigneeID, task.CreatedByID, task.Status, task.Priority, task.DueDate, labelsJSON).
+	return db.QueryRow(taskCreateQuery, task.ID, task.ProjectID, task.Title, task.Description,
+		task.AssigneeID, task.CreatedByID, task.Status, task.Priority, task.DueDate, labelsJSON, task.RecurrenceID).
 		Scan(&task.CreatedAt, &task.UpdatedAt)
 }
 
@@ -106,7 +206,7 @@ func (s *TaskService) GetByID(id uuid.UUID) (*ProjectTask, error) {
 	err := s.db.QueryRow(taskGetByIDQuery, id).Scan(
 		&task.ID, &task.ProjectID, &task.Title, &task.Description, &task.AssigneeID,
 		&task.CreatedByID, &task.Status, &task.Priority, &task.DueDate, &labelsJSON,
-		&task.CreatedAt, &task.UpdatedAt,
+		&task.CreatedAt, &task.UpdatedAt, &task.RecurrenceID,
 	)
 
 	if err != nil {
@@ -157,6 +257,7 @@ func (s *TaskService) ListByProject(projectID uuid.UUID, assigneeID *uuid.UUID,
 			&task.ProjectTitle, &task.ProjectStatus, &task.StartedAt, &task.BlockedAt,
 			&task.BlockedReason, &task.CompletedAt, &task.CompletionNote,
 			&task.TakeoverRequestedAt, &task.TakeoverReason, &task.LastStatusChangedBy,
+			&task.RecurrenceID,
 		)
 		if err != nil {
 			return nil, err
@@ -214,6 +315,50 @@ func (s *TaskService) ListByAssignee(assigneeID uuid.UUID) ([]ProjectTask, error
 	}
 	defer rows.Close()
 
+	return scanTasksWithProject(rows)
+}
+
+// ListDueForUser retrieves up to limit unfinished tasks assigned to a user that are due
+// by dueBefore, including overdue ones, soonest first. Only tasks in projects the user
+// leads or is an active team member of are included.
+func (s *TaskService) ListDueForUser(userID uuid.UUID, dueBefore time.Time, limit int) ([]ProjectTask, error) {
+	rows, err := s.db.Query(taskListDueForUserQuery, userID, dueBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTasksWithProject(rows)
+}
+
+// ListCalendarForUser retrieves the tasks with a due date assigned to a user in one
+// project, soonest first. Nothing is returned unless the user leads or is an active
+// team member of the project.
+func (s *TaskService) ListCalendarForUser(userID, projectID uuid.UUID) ([]ProjectTask, error) {
+	rows, err := s.db.Query(taskListCalendarForUserQuery, userID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTasksWithProject(rows)
+}
+
+// ListBlockedForUser retrieves up to limit blocked tasks and takeover requests that need
+// a user's attention: those assigned to them, and any in projects they lead. Only
+// projects the user leads or is an active team member of are included.
+func (s *TaskService) ListBlockedForUser(userID uuid.UUID, limit int) ([]ProjectTask, error) {
+	rows, err := s.db.Query(taskListBlockedForUserQuery, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTasksWithProject(rows)
+}
+
+// scanTasksWithProject scans task rows that include assignee and project details
+func scanTasksWithProject(rows *sql.Rows) ([]ProjectTask, error) {
 	var tasks []ProjectTask
 	for rows.Next() {
 		var task ProjectTask
@@ -241,15 +386,147 @@ func (s *TaskService) ListByAssignee(assigneeID uuid.UUID) ([]ProjectTask, error
 	return tasks, rows.Err()
 }
 
-// Update updates a task
+// ListVolunteerTasks retrieves the tasks assigned to a volunteer across all
+// projects, filtered, sorted and paginated in SQL. Tasks past their due date
+// that aren't done are flagged as overdue.
+func (s *TaskService) ListVolunteerTasks(assigneeID uuid.UUID, filters VolunteerTaskFilters, limit, offset int) (*VolunteerTaskPage, error) {
+	query := taskListVolunteerTasksQuery
+	args := []interface{}{assigneeID}
+	argIndex := 2
+
+	whereConditions := []string{"pt.assignee_id = $1"}
+
+	if len(filters.Statuses) > 0 {
+		statuses := make([]string, len(filters.Statuses))
+		for i, status := range filters.Statuses {
+			statuses[i] = string(status)
+		}
+		statusesJSON, err := ToJSONArray(statuses)
+		if err != nil {
+			return nil, err
+		}
+		whereConditions = append(whereConditions, "pt.status IN (SELECT jsonb_array_elements_text($"+fmt.Sprintf("%d", argIndex)+"::jsonb))")
+		args = append(args, statusesJSON)
+		argIndex++
+	}
+
+	if filters.ProjectID != nil {
+		whereConditions = append(whereConditions, "pt.project_id = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.ProjectID)
+		argIndex++
+	}
+
+	if filters.Priority != nil {
+		whereConditions = append(whereConditions, "pt.priority = $"+fmt.Sprintf("%d", argIndex))
+		args = append(args, *filters.Priority)
+		argIndex++
+	}
+
+	if filters.DueFrom != nil {
+		whereConditions = append(whereConditions, "pt.due_date >= $"+fmt.Sprintf("%d", argIndex)+"::date")
+		args = append(args, *filters.DueFrom)
+		argIndex++
+	}
+
+	if filters.DueTo != nil {
+		whereConditions = append(whereConditions, "pt.due_date < $"+fmt.Sprintf("%d", argIndex)+"::date + 1")
+		args = append(args, *filters.DueTo)
+		argIndex++
+	}
+
+	if filters.OverdueOnly {
+		whereConditions = append(whereConditions, "pt.due_date < CURRENT_DATE AND pt.status <> 'done'")
+	}
+
+	query += " WHERE " + strings.Join(whereConditions, " AND ")
+
+	sortExpr, ok := VolunteerTaskSortFields[filters.SortBy]
+	if !ok {
+		sortExpr = VolunteerTaskSortFields["due_date"]
+	}
+	direction := "ASC"
+	if filters.SortDesc {
+		direction = "DESC"
+	}
+	query += " ORDER BY " + sortExpr + " " + direction + " NULLS LAST, pt.created_at DESC"
+
+	query += " LIMIT $" + fmt.Sprintf("%d", argIndex) + " OFFSET $" + fmt.Sprintf("%d", argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &VolunteerTaskPage{Tasks: []VolunteerTask{}}
+	for rows.Next() {
+		var task VolunteerTask
+		var labelsJSON string
+		err := rows.Scan(
+			&task.ID, &task.ProjectID, &task.Title, &task.Description, &task.AssigneeID,
+			&task.CreatedByID, &task.Status, &task.Priority, &task.DueDate, &labelsJSON,
+			&task.CreatedAt, &task.UpdatedAt,
+			&task.ProjectTitle, &task.ProjectStatus, &task.StartedAt, &task.BlockedAt,
+			&task.BlockedReason, &task.CompletedAt, &task.CompletionNote,
+			&task.TakeoverRequestedAt, &task.TakeoverReason, &task.LastStatusChangedBy,
+			&task.IsOverdue, &page.Total, &page.OverdueCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ParseJSONArray(labelsJSON, &task.Labels); err != nil {
+			return nil, err
+		}
+
+		page.Tasks = append(page.Tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Past the last page no rows come back to carry the totals
+	if len(page.Tasks) == 0 && offset > 0 {
+		countPage, err := s.ListVolunteerTasks(assigneeID, filters, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = countPage.Total
+		page.OverdueCount = countPage.OverdueCount
+	}
+
+	return page, nil
+}
+
+// Update updates a task. A change of status is checked the same way as UpdateStatus
+// checks it, and the update only applies while the task is still in the status it was
+// checked against.
 func (s *TaskService) Update(task *ProjectTask) error {
+	current, err := s.GetByID(task.ID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return sql.ErrNoRows
+	}
+	if task.Status != current.Status {
+		if err := s.checkStatusTransition(current, task.Status); err != nil {
+			return err
+		}
+	}
+
 	labelsJSON, err := ToJSONArray(task.Labels)
 	if err != nil {
 		return err
 	}
 
-	return s.db.QueryRow(taskUpdateQuery, task.ID, task.Title, task.Description, task.AssigneeID,
-		task.Status, task.Priority, task.DueDate, labelsJSON).Scan(&task.UpdatedAt)
+	err = s.db.QueryRow(taskUpdateQuery, task.ID, task.Title, task.Description, task.AssigneeID,
+		task.Status, task.Priority, task.DueDate, labelsJSON, current.Status).Scan(&task.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrTaskStatusChanged
+	}
+	return err
 }
 
 // UpdateStatus updates only the status of a task with activity logging
@@ -263,27 +540,16 @@ func (s *TaskService) UpdateStatus(taskID uuid.UUID, status TaskStatus, actorUse
 		return sql.ErrNoRows
 	}
 
-	// Update status
-	result, err := s.db.Exec(taskUpdateStatusQuery, taskID, status, &actorUserID)
-	if err != nil {
+	if err := s.checkStatusTransition(task, status); err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
+	// Update status
+	if err := s.applyStatusUpdate(taskUpdateStatusQuery, taskID, status, &actorUserID, task.Status); err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
-	}
-
-	// Log activity if status changed
-	if task.Status != status {
-		return s.insertActivityLog(taskID, actorUserID, task.Status, status, map[string]interface{}{})
-	}
-
-	return nil
+	return s.insertActivityLog(taskID, actorUserID, task.Status, status, map[string]interface{}{})
 }
 
 // AssignToVolunteer assigns a task to a volunteer
@@ -352,8 +618,9 @@ func (s *TaskService) GetTaskUpdates(taskID uuid.UUID) ([]TaskUpdate, error) {
 	return updates, rows.Err()
 }
 
-// GetTaskWithUpdates retrieves a task with all its updates
-func (s *TaskService) GetTaskWithUpdates(taskID uuid.UUID) (*TaskWithUpdates, error) {
+// GetTaskWithUpdates retrieves a task with all its updates, and its linked
+// resources when includeResources is set
+func (s *TaskService) GetTaskWithUpdates(taskID uuid.UUID, includeResources bool) (*TaskWithUpdates, error) {
 	task, err := s.GetByID(taskID)
 	if err != nil || task == nil {
 		return nil, err
@@ -364,10 +631,19 @@ func (s *TaskService) GetTaskWithUpdates(taskID uuid.UUID) (*TaskWithUpdates, er
 		return nil, err
 	}
 
-	return &TaskWithUpdates{
+	taskWithUpdates := &TaskWithUpdates{
 		ProjectTask: *task,
 		Updates:     updates,
-	}, nil
+	}
+
+	if includeResources {
+		taskWithUpdates.Resources, err = s.ListResources(taskID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return taskWithUpdates, nil
 }
 
 // MarkAsBlocked marks a task as blocked
@@ -381,9 +657,13 @@ func (s *TaskService) MarkAsBlocked(taskID uuid.UUID, reason string, actorUserID
 		return sql.ErrNoRows
 	}
 
+	if err := s.checkStatusTransition(task, TaskStatusBlocked); err != nil {
+		return err
+	}
+
 	// Update timeline fields and status
 	now := time.Now()
-	_, err = s.db.Exec(taskUpdateTimelineQuery, taskID, nil, &now, &reason, nil, nil, nil, nil, TaskStatusBlocked, &actorUserID)
+	err = s.applyStatusUpdate(taskUpdateTimelineQuery, taskID, nil, &now, &reason, nil, nil, nil, nil, TaskStatusBlocked, &actorUserID, task.Status)
 	if err != nil {
 		return err
 	}
@@ -405,9 +685,13 @@ func (s *TaskService) RequestTakeover(taskID uuid.UUID, reason string, actorUser
 		return sql.ErrNoRows
 	}
 
+	if err := s.checkStatusTransition(task, TaskStatusTakeoverRequested); err != nil {
+		return err
+	}
+
 	// Update timeline fields and status
 	now := time.Now()
-	_, err = s.db.Exec(taskUpdateTimelineQuery, taskID, nil, nil, nil, nil, nil, &now, &reason, TaskStatusTakeoverRequested, &actorUserID)
+	err = s.applyStatusUpdate(taskUpdateTimelineQuery, taskID, nil, nil, nil, nil, nil, &now, &reason, TaskStatusTakeoverRequested, &actorUserID, task.Status)
 	if err != nil {
 		return err
 	}
@@ -429,9 +713,13 @@ func (s *TaskService) MarkAsDone(taskID uuid.UUID, completionNote string, actorU
 		return sql.ErrNoRows
 	}
 
+	if err := s.checkStatusTransition(task, TaskStatusDone); err != nil {
+		return err
+	}
+
 	// Update timeline fields and status
 	now := time.Now()
-	_, err = s.db.Exec(taskUpdateTimelineQuery, taskID, nil, nil, nil, &now, &completionNote, nil, nil, TaskStatusDone, &actorUserID)
+	err = s.applyStatusUpdate(taskUpdateTimelineQuery, taskID, nil, nil, nil, &now, &completionNote, nil, nil, TaskStatusDone, &actorUserID, task.Status)
 	if err != nil {
 		return err
 	}
@@ -453,9 +741,13 @@ func (s *TaskService) StartTask(taskID uuid.UUID, actorUserID uuid.UUID) error {
 		return sql.ErrNoRows
 	}
 
+	if err := s.checkStatusTransition(task, TaskStatusInProgress); err != nil {
+		return err
+	}
+
 	// Update timeline fields and status
 	now := time.Now()
-	_, err = s.db.Exec(taskUpdateTimelineQuery, taskID, &now, nil, nil, nil, nil, nil, nil, TaskStatusInProgress, &actorUserID)
+	err = s.applyStatusUpdate(taskUpdateTimelineQuery, taskID, &now, nil, nil, nil, nil, nil, nil, TaskStatusInProgress, &actorUserID, task.Status)
 	if err != nil {
 		return err
 	}
@@ -466,6 +758,75 @@ func (s *TaskService) StartTask(taskID uuid.UUID, actorUserID uuid.UUID) error {
 	})
 }
 
+// ReopenTask moves a done task back to todo so work on it can resume. Its completion
+// time and note are cleared.
+func (s *TaskService) ReopenTask(taskID uuid.UUID, actorUserID uuid.UUID) error {
+	// Get current task to determine previous status
+	task, err := s.GetByID(taskID)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return sql.ErrNoRows
+	}
+
+	if err := ValidateTaskReopen(task.Status); err != nil {
+		return err
+	}
+
+	// Update timeline fields and status
+	err = s.applyStatusUpdate(taskUpdateTimelineQuery, taskID, nil, nil, nil, nil, nil, nil, nil, TaskStatusTodo, &actorUserID, task.Status)
+	if err != nil {
+		return err
+	}
+
+	// Log activity
+	return s.insertActivityLog(taskID, actorUserID, task.Status, TaskStatusTodo, map[string]interface{}{
+		"reopened":        true,
+		"completed_at":    task.CompletedAt,
+		"completion_note": task.CompletionNote,
+	})
+}
+
+// checkStatusTransition checks a task may move to a status. Every status change goes
+// through it: the transition must be allowed, and work can't start on a task until
+// everything it depends on is done.
+func (s *TaskService) checkStatusTransition(task *ProjectTask, to TaskStatus) error {
+	if err := ValidateTaskStatusTransition(task.Status, to); err != nil {
+		return err
+	}
+	if to != TaskStatusInProgress {
+		return nil
+	}
+
+	blocked, err := s.HasIncompleteDependencies(task.ID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrDependenciesIncomplete
+	}
+	return nil
+}
+
+// applyStatusUpdate runs a status-changing update whose last argument is the status the
+// task was read in. The update only applies while the task is still in that status, so a
+// concurrent change returns ErrTaskStatusChanged instead of being overwritten.
+func (s *TaskService) applyStatusUpdate(query string, args ...interface{}) error {
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrTaskStatusChanged
+	}
+	return nil
+}
+
 // insertActivityLog logs a task status change to the activity log
 func (s *TaskService) insertActivityLog(taskID, actorUserID uuid.UUID, fromStatus, toStatus TaskStatus, context map[string]interface{}) error {
 	// Look up volunteer ID from user ID
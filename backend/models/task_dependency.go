@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task dependency errors
+var (
+	ErrDependencyCycle        = fmt.Errorf("dependency would create a cycle")
+	ErrDependencyCrossProject = fmt.Errorf("dependencies must belong to the same project")
+	ErrDependenciesIncomplete = fmt.Errorf("task has dependencies that are not done")
+)
+
+// TaskDependency represents a task that must be done before another task can start
+type TaskDependency struct {
+	TaskID          uuid.UUID  `json:"task_id" db:"task_id"`
+	DependsOnTaskID uuid.UUID  `json:"depends_on_task_id" db:"depends_on_task_id"`
+	DependsOnTitle  string     `json:"depends_on_title" db:"depends_on_title"`
+	DependsOnStatus TaskStatus `json:"depends_on_status" db:"depends_on_status"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// AddDependency records that taskID cannot start until dependsOnID is done.
+// Both tasks must be in the same project, and the dependency must not create a cycle.
+func (s *TaskService) AddDependency(taskID, dependsOnID, actorUserID uuid.UUID) error {
+	if taskID == dependsOnID {
+		return ErrDependencyCycle
+	}
+
+	task, err := s.GetByID(taskID)
+	if err != nil {
+		return err
+	}
+	dependsOn, err := s.GetByID(dependsOnID)
+	if err != nil {
+		return err
+	}
+	if task == nil || dependsOn == nil {
+		return sql.ErrNoRows
+	}
+	if task.ProjectID != dependsOn.ProjectID {
+		return ErrDependencyCrossProject
+	}
+
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Serialize dependency changes within the project so two concurrent inserts
+		// can't each pass the cycle check and together form a cycle
+		if _, err := tx.Exec(taskDependencyLockProjectQuery, task.ProjectID.String()); err != nil {
+			return err
+		}
+
+		// Adding taskID -> dependsOnID closes a cycle if dependsOnID already reaches taskID
+		var createsCycle bool
+		if err := tx.QueryRow(taskDependencyReachableQuery, dependsOnID, taskID).Scan(&createsCycle); err != nil {
+			return err
+		}
+		if createsCycle {
+			return ErrDependencyCycle
+		}
+
+		_, err := tx.Exec(taskDependencyInsertQuery, taskID, dependsOnID, actorUserID)
+		return err
+	})
+}
+
+// RemoveDependency removes a dependency between two tasks
+func (s *TaskService) RemoveDependency(taskID, dependsOnID uuid.UUID) error {
+	result, err := s.db.Exec(taskDependencyDeleteQuery, taskID, dependsOnID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetDependencies retrieves the tasks a task directly depends on
+func (s *TaskService) GetDependencies(taskID uuid.UUID) ([]TaskDependency, error) {
+	rows, err := s.db.Query(taskDependencyListQuery, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dependencies := []TaskDependency{}
+	for rows.Next() {
+		var dep TaskDependency
+		err := rows.Scan(&dep.TaskID, &dep.DependsOnTaskID, &dep.DependsOnTitle, &dep.DependsOnStatus,
+			&dep.CreatedBy, &dep.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dep)
+	}
+
+	return dependencies, rows.Err()
+}
+
+// HasIncompleteDependencies checks if any of a task's dependencies are not yet done
+func (s *TaskService) HasIncompleteDependencies(taskID uuid.UUID) (bool, error) {
+	var count int
+	if err := s.db.QueryRow(taskDependencyCountIncompleteQuery, taskID).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
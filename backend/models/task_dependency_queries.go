@@ -0,0 +1,39 @@
+package models
+
+// Query constants for task dependencies
+const (
+	taskDependencyInsertQuery = `
+		INSERT INTO task_dependencies (task_id, depends_on_task_id, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (task_id, depends_on_task_id) DO NOTHING`
+
+	taskDependencyDeleteQuery = `
+		DELETE FROM task_dependencies WHERE task_id = $1 AND depends_on_task_id = $2`
+
+	taskDependencyLockProjectQuery = `SELECT pg_advisory_xact_lock(hashtext($1))`
+
+	// Walks the dependency graph from $1 and reports whether $2 is reachable,
+	// i.e. whether $1 already (transitively) depends on $2
+	taskDependencyReachableQuery = `
+		WITH RECURSIVE deps(task_id) AS (
+			SELECT depends_on_task_id FROM task_dependencies WHERE task_id = $1
+			UNION
+			SELECT td.depends_on_task_id
+			FROM task_dependencies td
+			JOIN deps ON td.task_id = deps.task_id
+		)
+		SELECT EXISTS (SELECT 1 FROM deps WHERE task_id = $2)`
+
+	taskDependencyListQuery = `
+		SELECT td.task_id, td.depends_on_task_id, t.title, t.status, td.created_by, td.created_at
+		FROM task_dependencies td
+		JOIN project_tasks t ON td.depends_on_task_id = t.id
+		WHERE td.task_id = $1
+		ORDER BY td.created_at ASC`
+
+	taskDependencyCountIncompleteQuery = `
+		SELECT COUNT(1)
+		FROM task_dependencies td
+		JOIN project_tasks t ON td.depends_on_task_id = t.id
+		WHERE td.task_id = $1 AND t.status <> 'done'`
+)
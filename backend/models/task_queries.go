@@ -4,13 +4,13 @@ package models
 const (
 	taskCreateQuery = `
 		INSERT INTO project_tasks (id, project_id, title, description, assignee_id, 
-		                          created_by_id, status, priority, due_date, labels)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		                          created_by_id, status, priority, due_date, labels, recurrence_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at`
 
 	taskGetByIDQuery = `
 		SELECT id, project_id, title, description, assignee_id, created_by_id, 
-		       status, priority, due_date, labels, created_at, updated_at
+		       status, priority, due_date, labels, created_at, updated_at, recurrence_id
 		FROM project_tasks WHERE id = $1`
 
 	taskListByProjectOwnerQuery = `
@@ -19,7 +19,8 @@ const (
 		       v.name as assignee_name, u.email as assignee_email,
 		       p.title as project_title, p.project_status,
 		       pt.started_at, pt.blocked_at, pt.blocked_reason, pt.completed_at, pt.completion_note,
-		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by
+		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by,
+		       pt.recurrence_id
 		FROM project_tasks pt
 		LEFT JOIN volunteers v ON pt.assignee_id = v.id
 		LEFT JOIN users u ON v.user_id = u.id
@@ -39,7 +40,8 @@ const (
 		       v.name as assignee_name, u.email as assignee_email,
 		       p.title as project_title, p.project_status,
 		       pt.started_at, pt.blocked_at, pt.blocked_reason, pt.completed_at, pt.completion_note,
-		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by
+		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by,
+		       pt.recurrence_id
 		FROM project_tasks pt
 		LEFT JOIN volunteers v ON pt.assignee_id = v.id
 		LEFT JOIN users u ON v.user_id = u.id
@@ -71,6 +73,20 @@ const (
 			END,
 			pt.created_at DESC`
 
+	// Filters, sorting and pagination are appended by ListVolunteerTasks. The
+	// window counts report totals across every page.
+	taskListVolunteerTasksQuery = `
+		SELECT pt.id, pt.project_id, pt.title, pt.description, pt.assignee_id, pt.created_by_id, 
+		       pt.status, pt.priority, pt.due_date, pt.labels, pt.created_at, pt.updated_at,
+		       p.title as project_title, p.project_status,
+		       pt.started_at, pt.blocked_at, pt.blocked_reason, pt.completed_at, pt.completion_note,
+		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by,
+		       (pt.due_date < CURRENT_DATE AND pt.status <> 'done') as is_overdue,
+		       COUNT(*) OVER () as total_count,
+		       COUNT(*) FILTER (WHERE pt.due_date < CURRENT_DATE AND pt.status <> 'done') OVER () as overdue_count
+		FROM project_tasks pt
+		JOIN projects p ON pt.project_id = p.id`
+
 	taskListByAssigneeQuery = `
 		SELECT pt.id, pt.project_id, pt.title, pt.description, pt.assignee_id, pt.created_by_id, 
 		       pt.status, pt.priority, pt.due_date, pt.labels, pt.created_at, pt.updated_at,
@@ -98,17 +114,69 @@ const (
 			END,
 			pt.due_date ASC NULLS LAST`
 
+	// taskDashboardSelect lists tasks with their assignee and project for a user's
+	// dashboard. taskDashboardMembershipCondition limits them to projects the user ($1)
+	// leads or is an active team member of.
+	taskDashboardSelect = `
+		SELECT pt.id, pt.project_id, pt.title, pt.description, pt.assignee_id, pt.created_by_id, 
+		       pt.status, pt.priority, pt.due_date, pt.labels, pt.created_at, pt.updated_at,
+		       v.name as assignee_name, u.email as assignee_email,
+		       p.title as project_title, p.project_status,
+		       pt.started_at, pt.blocked_at, pt.blocked_reason, pt.completed_at, pt.completion_note,
+		       pt.takeover_requested_at, pt.takeover_reason, pt.last_status_changed_by
+		FROM project_tasks pt
+		JOIN projects p ON pt.project_id = p.id
+		LEFT JOIN volunteers v ON pt.assignee_id = v.id
+		LEFT JOIN users u ON v.user_id = u.id`
+
+	taskDashboardMembershipCondition = `
+		p.deleted_at IS NULL
+		AND (p.team_lead_id = $1 OR EXISTS (
+			SELECT 1
+			FROM project_team_members ptm
+			JOIN volunteers mv ON ptm.volunteer_id = mv.id
+			WHERE ptm.project_id = p.id AND mv.user_id = $1 AND ptm.status = 'active'
+		))`
+
+	taskListDueForUserQuery = taskDashboardSelect + `
+		WHERE v.user_id = $1
+		AND pt.status <> 'done'
+		AND pt.due_date IS NOT NULL
+		AND pt.due_date <= $2
+		AND` + taskDashboardMembershipCondition + `
+		ORDER BY pt.due_date ASC,
+			CASE pt.priority 
+				WHEN 'high' THEN 1 
+				WHEN 'medium' THEN 2 
+				WHEN 'low' THEN 3 
+			END
+		LIMIT $3`
+
+	taskListCalendarForUserQuery = taskDashboardSelect + `
+		WHERE v.user_id = $1
+		AND pt.project_id = $2
+		AND pt.due_date IS NOT NULL
+		AND` + taskDashboardMembershipCondition + `
+		ORDER BY pt.due_date ASC`
+
+	taskListBlockedForUserQuery = taskDashboardSelect + `
+		WHERE pt.status IN ('blocked', 'takeover_requested')
+		AND (v.user_id = $1 OR p.team_lead_id = $1)
+		AND` + taskDashboardMembershipCondition + `
+		ORDER BY COALESCE(pt.takeover_requested_at, pt.blocked_at, pt.updated_at) ASC
+		LIMIT $2`
+
 	taskUpdateQuery = `
 		UPDATE project_tasks 
 		SET title = $2, description = $3, assignee_id = $4, status = $5, 
 		    priority = $6, due_date = $7, labels = $8, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1
+		WHERE id = $1 AND status = $9
 		RETURNING updated_at`
 
 	taskUpdateStatusQuery = `
 		UPDATE project_tasks 
 		SET status = $2, last_status_changed_by = $3, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1`
+		WHERE id = $1 AND status = $4`
 
 	taskAssignToVolunteerQuery = `
 		UPDATE project_tasks 
@@ -137,5 +205,5 @@ const (
 		SET started_at = $2, blocked_at = $3, blocked_reason = $4, completed_at = $5, 
 		    completion_note = $6, takeover_requested_at = $7, takeover_reason = $8, 
 		    status = $9, last_status_changed_by = $10, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1`
+		WHERE id = $1 AND status = $11`
 )
@@ -0,0 +1,332 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskRecurrenceFrequency is how often a recurring task repeats
+type TaskRecurrenceFrequency string
+
+const (
+	TaskRecurrenceNone    TaskRecurrenceFrequency = "none"
+	TaskRecurrenceDaily   TaskRecurrenceFrequency = "daily"
+	TaskRecurrenceWeekly  TaskRecurrenceFrequency = "weekly"
+	TaskRecurrenceMonthly TaskRecurrenceFrequency = "monthly"
+)
+
+// MaxTaskRecurrenceInterval bounds how many days, weeks or months apart occurrences may be
+const MaxTaskRecurrenceInterval = 365
+
+// Recurring task errors
+var (
+	ErrInvalidTaskRecurrence  = fmt.Errorf("invalid task recurrence")
+	ErrRecurrenceNeedsDueDate = fmt.Errorf("a recurring task needs a due date")
+)
+
+// TaskRecurrenceRule describes when a recurring task repeats and when it stops. Either
+// end condition may be set; without one the task repeats until it's stopped.
+type TaskRecurrenceRule struct {
+	Frequency      TaskRecurrenceFrequency `json:"frequency"`
+	Interval       int                     `json:"interval"` // Repeat every Interval days, weeks or months
+	EndsOn         *time.Time              `json:"ends_on,omitempty"`
+	MaxOccurrences *int                    `json:"max_occurrences,omitempty"`
+}
+
+// Validate checks the rule repeats at a known frequency and has sensible bounds.
+// A "none" rule is valid; it means the task doesn't repeat.
+func (r TaskRecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case TaskRecurrenceNone:
+		return nil
+	case TaskRecurrenceDaily, TaskRecurrenceWeekly, TaskRecurrenceMonthly:
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidTaskRecurrence, r.Frequency)
+	}
+	if r.Interval < 1 || r.Interval > MaxTaskRecurrenceInterval {
+		return fmt.Errorf("%w: interval must be between 1 and %d", ErrInvalidTaskRecurrence, MaxTaskRecurrenceInterval)
+	}
+	if r.MaxOccurrences != nil && *r.MaxOccurrences < 1 {
+		return fmt.Errorf("%w: max_occurrences must be at least 1", ErrInvalidTaskRecurrence)
+	}
+	return nil
+}
+
+// Next returns the occurrence after due. Monthly occurrences fall on anchorDay, or the
+// last day of months too short for it, so a task starting on the 31st lands on the
+// 28th or 30th of shorter months without drifting.
+func (r TaskRecurrenceRule) Next(due time.Time, anchorDay int) time.Time {
+	switch r.Frequency {
+	case TaskRecurrenceDaily:
+		return due.AddDate(0, 0, r.Interval)
+	case TaskRecurrenceWeekly:
+		return due.AddDate(0, 0, 7*r.Interval)
+	default:
+		first := time.Date(due.Year(), due.Month()+time.Month(r.Interval), 1,
+			due.Hour(), due.Minute(), due.Second(), due.Nanosecond(), due.Location())
+		day := anchorDay
+		if lastDay := first.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+		return first.AddDate(0, 0, day-1)
+	}
+}
+
+// TaskRecurrence is the template a recurring task's instances are created from. Only
+// its current instance exists as a project task; the next one is created from the
+// template once that's done, so editing the template never changes instances already
+// created.
+type TaskRecurrence struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	ProjectID   uuid.UUID    `json:"project_id" db:"project_id"`
+	Title       string       `json:"title" db:"title"`
+	Description string       `json:"description" db:"description"`
+	AssigneeID  *uuid.UUID   `json:"assignee_id" db:"assignee_id"`
+	CreatedByID uuid.UUID    `json:"created_by_id" db:"created_by_id"`
+	Priority    TaskPriority `json:"priority" db:"priority"`
+	Labels      []string     `json:"labels" db:"labels"`
+	TaskRecurrenceRule
+	StartsAt        time.Time  `json:"starts_at" db:"starts_at"`     // First due date; its day anchors monthly repeats
+	NextDueAt       time.Time  `json:"next_due_at" db:"next_due_at"` // Due date of the next instance to create
+	OccurrenceCount int        `json:"occurrence_count" db:"occurrence_count"`
+	CurrentTaskID   *uuid.UUID `json:"current_task_id" db:"current_task_id"`
+	IsActive        bool       `json:"is_active" db:"is_active"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// nextAfter returns the occurrence after due under the recurrence's rule
+func (r *TaskRecurrence) nextAfter(due time.Time) time.Time {
+	return r.Next(due, r.StartsAt.Day())
+}
+
+// finishedBy reports whether an occurrence due at due would fall past the
+// recurrence's end date or occurrence limit
+func (r *TaskRecurrence) finishedBy(due time.Time) bool {
+	if r.MaxOccurrences != nil && r.OccurrenceCount >= *r.MaxOccurrences {
+		return true
+	}
+	if r.EndsOn != nil {
+		y, m, d := r.EndsOn.Date()
+		return due.After(time.Date(y, m, d, 23, 59, 59, 0, due.Location()))
+	}
+	return false
+}
+
+// CreateRecurring creates a recurring task: the template and, from task, its first
+// instance due on task's due date. Returns ErrRecurrenceNeedsDueDate if task has none.
+func (s *TaskService) CreateRecurring(task *ProjectTask, rule TaskRecurrenceRule) (*TaskRecurrence, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	if rule.Frequency == TaskRecurrenceNone {
+		return nil, fmt.Errorf("%w: a recurring task needs a frequency", ErrInvalidTaskRecurrence)
+	}
+	if task.DueDate == nil {
+		return nil, ErrRecurrenceNeedsDueDate
+	}
+
+	recurrence := &TaskRecurrence{
+		ID:                 uuid.New(),
+		ProjectID:          task.ProjectID,
+		Title:              task.Title,
+		Description:        task.Description,
+		AssigneeID:         task.AssigneeID,
+		CreatedByID:        task.CreatedByID,
+		Priority:           task.Priority,
+		Labels:             task.Labels,
+		TaskRecurrenceRule: rule,
+		StartsAt:           *task.DueDate,
+		IsActive:           true,
+	}
+	recurrence.NextDueAt = recurrence.nextAfter(recurrence.StartsAt)
+
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		labelsJSON, err := ToJSONArray(recurrence.Labels)
+		if err != nil {
+			return err
+		}
+		err = tx.QueryRow(taskRecurrenceCreateQuery, recurrence.ID, recurrence.ProjectID, recurrence.Title,
+			recurrence.Description, recurrence.AssigneeID, recurrence.CreatedByID, recurrence.Priority,
+			labelsJSON, recurrence.Frequency, recurrence.Interval, recurrence.EndsOn,
+			recurrence.MaxOccurrences, recurrence.StartsAt, recurrence.NextDueAt).
+			Scan(&recurrence.CreatedAt, &recurrence.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create task recurrence: %w", err)
+		}
+
+		task.RecurrenceID = &recurrence.ID
+		if err := insertTask(tx, task); err != nil {
+			return fmt.Errorf("failed to create first occurrence: %w", err)
+		}
+
+		_, err = tx.Exec(taskRecurrenceSetCurrentQuery, recurrence.ID, task.ID, recurrence.NextDueAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence.CurrentTaskID = &task.ID
+	recurrence.OccurrenceCount = 1
+	return recurrence, nil
+}
+
+// GetRecurrence retrieves a recurring task template by ID. Returns nil, nil if not found.
+func (s *TaskService) GetRecurrence(id uuid.UUID) (*TaskRecurrence, error) {
+	recurrence, err := scanTaskRecurrence(s.db.QueryRow(taskRecurrenceGetByIDQuery, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return recurrence, err
+}
+
+// ListRecurrencesByProject retrieves a project's recurring task templates, active ones first
+func (s *TaskService) ListRecurrencesByProject(projectID uuid.UUID) ([]TaskRecurrence, error) {
+	rows, err := s.db.Query(taskRecurrenceListByProjectQuery, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recurrences := []TaskRecurrence{}
+	for rows.Next() {
+		recurrence, err := scanTaskRecurrence(rows)
+		if err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, *recurrence)
+	}
+	return recurrences, rows.Err()
+}
+
+// UpdateRecurrence saves changes to a recurring task template. They apply to instances
+// created from now on; existing instances keep the values they were created with.
+// Returns sql.ErrNoRows if the template doesn't exist.
+func (s *TaskService) UpdateRecurrence(recurrence *TaskRecurrence) error {
+	if err := recurrence.Validate(); err != nil {
+		return err
+	}
+	if recurrence.Frequency == TaskRecurrenceNone {
+		return fmt.Errorf("%w: stop the recurrence instead of removing its frequency", ErrInvalidTaskRecurrence)
+	}
+
+	labelsJSON, err := ToJSONArray(recurrence.Labels)
+	if err != nil {
+		return err
+	}
+	return s.db.QueryRow(taskRecurrenceUpdateQuery, recurrence.ID, recurrence.Title, recurrence.Description,
+		recurrence.AssigneeID, recurrence.Priority, labelsJSON, recurrence.Frequency, recurrence.Interval,
+		recurrence.EndsOn, recurrence.MaxOccurrences, recurrence.StartsAt, recurrence.NextDueAt).
+		Scan(&recurrence.UpdatedAt)
+}
+
+// StopRecurrence stops a recurring task so no further instances are created. Instances
+// already created, including the current one, are left as they are. Returns
+// sql.ErrNoRows if the template doesn't exist.
+func (s *TaskService) StopRecurrence(id uuid.UUID) error {
+	result, err := s.db.Exec(taskRecurrenceStopQuery, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GenerateRecurringTasks creates the next instance of up to limit recurring tasks whose
+// current instance is done (or was deleted), and returns how many it created. Occurrences
+// that fell due before today while the previous instance was still open are skipped
+// rather than created already overdue. Recurrences past their end date or occurrence
+// limit are stopped instead.
+func (s *TaskService) GenerateRecurringTasks(now time.Time, limit int) (int, error) {
+	generated := 0
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		rows, err := tx.Query(taskRecurrenceClaimDueQuery, limit)
+		if err != nil {
+			return err
+		}
+		var due []*TaskRecurrence
+		for rows.Next() {
+			recurrence, err := scanTaskRecurrence(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			due = append(due, recurrence)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		y, m, d := now.Date()
+		today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+		for _, recurrence := range due {
+			dueAt := recurrence.NextDueAt
+			for dueAt.Before(today) {
+				dueAt = recurrence.nextAfter(dueAt)
+			}
+
+			if recurrence.finishedBy(dueAt) {
+				if _, err := tx.Exec(taskRecurrenceStopQuery, recurrence.ID); err != nil {
+					return fmt.Errorf("failed to stop finished recurrence %s: %w", recurrence.ID, err)
+				}
+				continue
+			}
+
+			task := &ProjectTask{
+				ProjectID:    recurrence.ProjectID,
+				Title:        recurrence.Title,
+				Description:  recurrence.Description,
+				AssigneeID:   recurrence.AssigneeID,
+				CreatedByID:  recurrence.CreatedByID,
+				Status:       TaskStatusTodo,
+				Priority:     recurrence.Priority,
+				DueDate:      &dueAt,
+				Labels:       recurrence.Labels,
+				RecurrenceID: &recurrence.ID,
+			}
+			if err := insertTask(tx, task); err != nil {
+				return fmt.Errorf("failed to create occurrence of recurrence %s: %w", recurrence.ID, err)
+			}
+			if _, err := tx.Exec(taskRecurrenceSetCurrentQuery, recurrence.ID, task.ID, recurrence.nextAfter(dueAt)); err != nil {
+				return err
+			}
+			generated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return generated, nil
+}
+
+// scanTaskRecurrence scans a row selected with taskRecurrenceColumns
+func scanTaskRecurrence(row rowScanner) (*TaskRecurrence, error) {
+	recurrence := &TaskRecurrence{}
+	var labelsJSON string
+	err := row.Scan(
+		&recurrence.ID, &recurrence.ProjectID, &recurrence.Title, &recurrence.Description,
+		&recurrence.AssigneeID, &recurrence.CreatedByID, &recurrence.Priority, &labelsJSON,
+		&recurrence.Frequency, &recurrence.Interval, &recurrence.EndsOn, &recurrence.MaxOccurrences,
+		&recurrence.StartsAt, &recurrence.NextDueAt, &recurrence.OccurrenceCount,
+		&recurrence.CurrentTaskID, &recurrence.IsActive, &recurrence.CreatedAt, &recurrence.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := ParseJSONArray(labelsJSON, &recurrence.Labels); err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
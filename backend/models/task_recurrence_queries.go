@@ -0,0 +1,56 @@
+package models
+
+// Query constants for recurring tasks
+const (
+	taskRecurrenceColumns = `
+		id, project_id, title, description, assignee_id, created_by_id, priority, labels,
+		frequency, interval_count, ends_on, max_occurrences, starts_at, next_due_at,
+		occurrence_count, current_task_id, is_active, created_at, updated_at`
+
+	taskRecurrenceCreateQuery = `
+		INSERT INTO task_recurrences (id, project_id, title, description, assignee_id, created_by_id,
+		                              priority, labels, frequency, interval_count, ends_on,
+		                              max_occurrences, starts_at, next_due_at, occurrence_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 0)
+		RETURNING created_at, updated_at`
+
+	taskRecurrenceGetByIDQuery = `SELECT` + taskRecurrenceColumns + `
+		FROM task_recurrences WHERE id = $1`
+
+	taskRecurrenceListByProjectQuery = `SELECT` + taskRecurrenceColumns + `
+		FROM task_recurrences
+		WHERE project_id = $1
+		ORDER BY is_active DESC, next_due_at ASC`
+
+	taskRecurrenceUpdateQuery = `
+		UPDATE task_recurrences
+		SET title = $2, description = $3, assignee_id = $4, priority = $5, labels = $6,
+		    frequency = $7, interval_count = $8, ends_on = $9, max_occurrences = $10,
+		    starts_at = $11, next_due_at = $12, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	taskRecurrenceStopQuery = `
+		UPDATE task_recurrences SET is_active = FALSE, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	taskRecurrenceSetCurrentQuery = `
+		UPDATE task_recurrences
+		SET current_task_id = $2, next_due_at = $3, occurrence_count = occurrence_count + 1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	// Recurrences due a new instance: active, in a live project, and with no current
+	// instance left open. Locked rows are skipped so concurrent workers don't both
+	// generate the same occurrence.
+	taskRecurrenceClaimDueQuery = `SELECT` + taskRecurrenceColumns + `
+		FROM task_recurrences
+		WHERE is_active
+		AND (current_task_id IS NULL OR EXISTS (
+			SELECT 1 FROM project_tasks pt WHERE pt.id = current_task_id AND pt.status = 'done'
+		))
+		AND EXISTS (SELECT 1 FROM projects p WHERE p.id = project_id AND p.deleted_at IS NULL)
+		ORDER BY next_due_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+)
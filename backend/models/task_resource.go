@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Task resource errors
+var (
+	ErrResourceNotVisible = fmt.Errorf("resource is not available to the task's project")
+)
+
+// LinkResource attaches a resource-library entry to a task. The resource must be
+// global or belong to the task's project. Linking an already linked resource is a no-op.
+func (s *TaskService) LinkResource(taskID, resourceID, linkedByID uuid.UUID) error {
+	var visible bool
+	if err := s.db.QueryRow(taskResourceVisibleQuery, taskID, resourceID).Scan(&visible); err != nil {
+		return err
+	}
+	if !visible {
+		return ErrResourceNotVisible
+	}
+
+	_, err := s.db.Exec(taskResourceInsertQuery, taskID, resourceID, linkedByID)
+	return err
+}
+
+// UnlinkResource detaches a resource from a task
+func (s *TaskService) UnlinkResource(taskID, resourceID uuid.UUID) error {
+	result, err := s.db.Exec(taskResourceDeleteQuery, taskID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListResources retrieves the resources linked to a task, skipping deleted ones
+func (s *TaskService) ListResources(taskID uuid.UUID) ([]Resource, error) {
+	rows, err := s.db.Query(taskResourceListQuery, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resources := []Resource{}
+	for rows.Next() {
+		var resource Resource
+		var tagsJSON string
+
+		err := rows.Scan(
+			&resource.ID, &resource.Title, &resource.Description, &resource.ResourceType,
+			&resource.FileURL, &resource.FileSize, &resource.MimeType, &resource.Scope,
+			&resource.ProjectID, &resource.UploadedByID, &tagsJSON, &resource.DownloadCount,
+			&resource.CreatedAt, &resource.UpdatedAt, &resource.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ParseJSONArray(tagsJSON, &resource.Tags); err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, rows.Err()
+}
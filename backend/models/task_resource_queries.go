@@ -0,0 +1,32 @@
+package models
+
+// Query constants for task resources
+const (
+	// A resource is visible to a task's project if it is global or belongs to that project
+	taskResourceVisibleQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM project_tasks pt
+			JOIN resources r ON r.id = $2
+			WHERE pt.id = $1
+			  AND r.deleted_at IS NULL
+			  AND (r.scope = 'global' OR r.project_id = pt.project_id)
+		)`
+
+	taskResourceInsertQuery = `
+		INSERT INTO task_resources (task_id, resource_id, linked_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (task_id, resource_id) DO NOTHING`
+
+	taskResourceDeleteQuery = `
+		DELETE FROM task_resources WHERE task_id = $1 AND resource_id = $2`
+
+	taskResourceListQuery = `
+		SELECT r.id, r.title, r.description, r.resource_type, r.file_url, r.file_size, r.mime_type,
+		       r.scope, r.project_id, r.uploaded_by_id, r.tags, r.download_count,
+		       r.created_at, r.updated_at, r.deleted_at
+		FROM task_resources tr
+		JOIN resources r ON tr.resource_id = r.id
+		WHERE tr.task_id = $1 AND r.deleted_at IS NULL
+		ORDER BY tr.linked_at`
+)
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateTaskStatusTransition(t *testing.T) {
+	statuses := []TaskStatus{
+		TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusBlocked, TaskStatusTakeoverRequested,
+	}
+	// allowed[from][to] lists every transition that should succeed; all others should fail
+	allowed := map[TaskStatus]map[TaskStatus]bool{
+		TaskStatusTodo: {
+			TaskStatusInProgress: true, TaskStatusBlocked: true, TaskStatusTakeoverRequested: true,
+		},
+		TaskStatusInProgress: {
+			TaskStatusTodo: true, TaskStatusDone: true, TaskStatusBlocked: true, TaskStatusTakeoverRequested: true,
+		},
+		TaskStatusBlocked: {
+			TaskStatusTodo: true, TaskStatusInProgress: true, TaskStatusTakeoverRequested: true,
+		},
+		TaskStatusTakeoverRequested: {
+			TaskStatusTodo: true, TaskStatusInProgress: true, TaskStatusBlocked: true,
+		},
+		TaskStatusDone: {},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			err := ValidateTaskStatusTransition(from, to)
+			if allowed[from][to] {
+				if err != nil {
+					t.Errorf("%s -> %s: unexpected error %v", from, to, err)
+				}
+				continue
+			}
+			if !errors.Is(err, ErrInvalidTaskTransition) {
+				t.Errorf("%s -> %s: error = %v, want ErrInvalidTaskTransition", from, to, err)
+			}
+		}
+	}
+}
+
+func TestValidateTaskStatusTransitionUnknownStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		from TaskStatus
+		to   TaskStatus
+	}{
+		{name: "unknown target", from: TaskStatusTodo, to: "banana"},
+		{name: "empty target", from: TaskStatusTodo, to: ""},
+		{name: "unknown target from done", from: TaskStatusDone, to: "archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTaskStatusTransition(tt.from, tt.to); !errors.Is(err, ErrInvalidTaskStatus) {
+				t.Errorf("ValidateTaskStatusTransition(%q, %q) = %v, want ErrInvalidTaskStatus", tt.from, tt.to, err)
+			}
+		})
+	}
+}
+
+func TestValidateTaskReopen(t *testing.T) {
+	tests := []struct {
+		from    TaskStatus
+		wantErr bool
+	}{
+		{from: TaskStatusDone, wantErr: false},
+		{from: TaskStatusTodo, wantErr: true},
+		{from: TaskStatusInProgress, wantErr: true},
+		{from: TaskStatusBlocked, wantErr: true},
+		{from: TaskStatusTakeoverRequested, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			err := ValidateTaskReopen(tt.from)
+			if tt.wantErr && !errors.Is(err, ErrInvalidTaskTransition) {
+				t.Errorf("ValidateTaskReopen(%q) = %v, want ErrInvalidTaskTransition", tt.from, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("ValidateTaskReopen(%q) = %v, want nil", tt.from, err)
+			}
+		})
+	}
+}
+
+// taskByIDColumns are the columns of taskGetByIDQuery
+var taskByIDColumns = []string{
+	"id", "project_id", "title", "description", "assignee_id", "created_by_id",
+	"status", "priority", "due_date", "labels", "created_at", "updated_at", "recurrence_id",
+}
+
+// taskByIDRow is a row of taskGetByIDQuery
+func taskByIDRow(taskID uuid.UUID, status TaskStatus) []driver.Value {
+	now := time.Now()
+	return []driver.Value{
+		taskID.String(), uuid.New().String(), "Book van", "", nil, uuid.New().String(),
+		string(status), "medium", nil, "[]", now, now, nil,
+	}
+}
+
+func TestUpdateStatusOnlyAppliesFromTheStatusItWasRead(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	taskID := uuid.New()
+	recorder.Rows("FROM project_tasks WHERE id = $1", taskByIDColumns, taskByIDRow(taskID, TaskStatusTodo))
+	recorder.Affects("UPDATE project_tasks", 0)
+
+	err := NewTaskService(db).UpdateStatus(taskID, TaskStatusBlocked, uuid.New())
+	if !errors.Is(err, ErrTaskStatusChanged) {
+		t.Fatalf("UpdateStatus() error = %v, want ErrTaskStatusChanged", err)
+	}
+
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "UPDATE project_tasks") {
+			if !strings.Contains(statement.Query, "AND status = $4") || statement.Args[3] != string(TaskStatusTodo) {
+				t.Errorf("update isn't guarded on the todo status: %s %v", statement.Query, statement.Args)
+			}
+		}
+	}
+	if recorder.Ran("INSERT INTO task_activity_log") {
+		t.Error("a status change that didn't apply was logged")
+	}
+}
+
+func TestUpdateReportsConcurrentStatusChanges(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	taskID := uuid.New()
+	recorder.Rows("FROM project_tasks WHERE id = $1", taskByIDColumns, taskByIDRow(taskID, TaskStatusTodo))
+	// No row comes back from the guarded update
+
+	task := &ProjectTask{ID: taskID, Title: "Book a bigger van", Status: TaskStatusBlocked, Priority: TaskPriorityMedium}
+	if err := NewTaskService(db).Update(task); !errors.Is(err, ErrTaskStatusChanged) {
+		t.Fatalf("Update() error = %v, want ErrTaskStatusChanged", err)
+	}
+
+	statements := recorder.Statements()
+	update := statements[len(statements)-1]
+	if !strings.Contains(update.Query, "AND status = $9") || update.Args[8] != string(TaskStatusTodo) {
+		t.Errorf("update isn't guarded on the todo status: %s %v", update.Query, update.Args)
+	}
+}
+
+// volunteerTaskRow is a row of taskListVolunteerTasksQuery
+func volunteerTaskRow(projectID, assigneeID uuid.UUID, projectTitle string, overdue bool, total, overdueCount int64) []driver.Value {
+	now := time.Now()
+	return []driver.Value{
+		uuid.New().String(), projectID.String(), "Task", "", assigneeID.String(), uuid.New().String(),
+		"todo", "medium", now.AddDate(0, 0, -1), "[]", now, now,
+		projectTitle, "active",
+		nil, nil, nil, nil, nil,
+		nil, nil, nil,
+		overdue, total, overdueCount,
+	}
+}
+
+func TestListVolunteerTasksOnlyReturnsTheVolunteersTasks(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	volunteerID := uuid.New()
+	parkID, libraryID := uuid.New(), uuid.New()
+	recorder.Rows("FROM project_tasks pt", []string{
+		"id", "project_id", "title", "description", "assignee_id", "created_by_id",
+		"status", "priority", "due_date", "labels", "created_at", "updated_at",
+		"project_title", "project_status",
+		"started_at", "blocked_at", "blocked_reason", "completed_at", "completion_note",
+		"takeover_requested_at", "takeover_reason", "last_status_changed_by",
+		"is_overdue", "total_count", "overdue_count",
+	},
+		volunteerTaskRow(parkID, volunteerID, "Park Cleanup", true, 2, 1),
+		volunteerTaskRow(libraryID, volunteerID, "Library Drive", false, 2, 1),
+	)
+
+	priority := TaskPriorityHigh
+	page, err := NewTaskService(db).ListVolunteerTasks(volunteerID, VolunteerTaskFilters{
+		Statuses: []TaskStatus{TaskStatusTodo},
+		Priority: &priority,
+		SortBy:   "project",
+	}, 20, 0)
+	if err != nil {
+		t.Fatalf("ListVolunteerTasks() error = %v", err)
+	}
+
+	// Every filter narrows the volunteer's own tasks; none can widen the list to others'
+	statement := recorder.Statements()[0]
+	where := statement.Query[strings.Index(statement.Query, " WHERE "):strings.Index(statement.Query, " ORDER BY ")]
+	if !strings.HasPrefix(where, " WHERE pt.assignee_id = $1 AND ") || strings.Contains(where, " OR ") {
+		t.Errorf("tasks are not limited to the volunteer: %s", where)
+	}
+	if statement.Args[0] != volunteerID.String() {
+		t.Errorf("assignee arg = %v, want %s", statement.Args[0], volunteerID)
+	}
+
+	// Tasks come back from both projects with their project context and overdue flag
+	if len(page.Tasks) != 2 || page.Total != 2 || page.OverdueCount != 1 {
+		t.Fatalf("page = %d tasks, total %d, overdue %d; want 2, 2, 1", len(page.Tasks), page.Total, page.OverdueCount)
+	}
+	if page.Tasks[0].ProjectID != parkID || page.Tasks[0].ProjectTitle != "Park Cleanup" || !page.Tasks[0].IsOverdue {
+		t.Errorf("first task = %+v, want the overdue Park Cleanup task", page.Tasks[0])
+	}
+	if page.Tasks[1].ProjectID != libraryID || page.Tasks[1].ProjectTitle != "Library Drive" || page.Tasks[1].IsOverdue {
+		t.Errorf("second task = %+v, want the Library Drive task", page.Tasks[1])
+	}
+}
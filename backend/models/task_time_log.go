@@ -30,6 +30,43 @@ type TimeSummary struct {
 	LogCount   int     `json:"log_count"`
 }
 
+// VolunteerTimeTotal represents hours a volunteer logged on a project
+type VolunteerTimeTotal struct {
+	VolunteerID   uuid.UUID `json:"volunteer_id"`
+	VolunteerName string    `json:"volunteer_name"`
+	TotalHours    float64   `json:"total_hours"`
+	LogCount      int       `json:"log_count"`
+}
+
+// HoursLeaderboardEntry represents a volunteer's rank by hours logged
+type HoursLeaderboardEntry struct {
+	Rank          int       `json:"rank"`
+	VolunteerID   uuid.UUID `json:"volunteer_id"`
+	VolunteerName string    `json:"volunteer_name"`
+	TotalHours    float64   `json:"total_hours"`
+	LogCount      int       `json:"log_count"`
+	ProjectCount  int       `json:"project_count"`
+}
+
+// TaskTimeTotal represents hours logged against a single task
+type TaskTimeTotal struct {
+	TaskID     uuid.UUID `json:"task_id"`
+	TaskTitle  string    `json:"task_title"`
+	TotalHours float64   `json:"total_hours"`
+	LogCount   int       `json:"log_count"`
+}
+
+// ProjectTimeTotals represents a project's logged hours broken down by volunteer and by task
+type ProjectTimeTotals struct {
+	ProjectID   uuid.UUID            `json:"project_id"`
+	From        *time.Time           `json:"from,omitempty"`
+	To          *time.Time           `json:"to,omitempty"`
+	TotalHours  float64              `json:"total_hours"`
+	LogCount    int                  `json:"log_count"`
+	ByVolunteer []VolunteerTimeTotal `json:"by_volunteer"`
+	ByTask      []TaskTimeTotal      `json:"by_task"`
+}
+
 // TaskTimeLogService handles time log operations
 type TaskTimeLogService struct {
 	db *sql.DB
@@ -43,7 +80,7 @@ func NewTaskTimeLogService(db *sql.DB) *TaskTimeLogService {
 // Create creates a new time log entry
 func (s *TaskTimeLogService) Create(timeLog *TaskTimeLog) error {
 	timeLog.ID = uuid.New()
-	return s.db.QueryRow(timeLogCreateQuery, timeLog.ID, timeLog.TaskID, timeLog.VolunteerID, 
+	return s.db.QueryRow(timeLogCreateQuery, timeLog.ID, timeLog.TaskID, timeLog.VolunteerID,
 		timeLog.Hours, timeLog.LogDate, timeLog.Description).Scan(&timeLog.CreatedAt)
 }
 
@@ -59,7 +96,7 @@ func (s *TaskTimeLogService) GetByTask(taskID uuid.UUID) ([]TimeLogWithVolunteer
 	for rows.Next() {
 		var log TimeLogWithVolunteer
 		err := rows.Scan(
-			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours, 
+			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours,
 			&log.LogDate, &log.Description, &log.CreatedAt, &log.VolunteerName,
 		)
 		if err != nil {
@@ -83,7 +120,7 @@ func (s *TaskTimeLogService) GetByVolunteer(volunteerID uuid.UUID) ([]TaskTimeLo
 	for rows.Next() {
 		var log TaskTimeLog
 		err := rows.Scan(
-			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours, 
+			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours,
 			&log.LogDate, &log.Description, &log.CreatedAt,
 		)
 		if err != nil {
@@ -107,7 +144,7 @@ func (s *TaskTimeLogService) GetByProject(projectID uuid.UUID) ([]TimeLogWithVol
 	for rows.Next() {
 		var log TimeLogWithVolunteer
 		err := rows.Scan(
-			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours, 
+			&log.ID, &log.TaskID, &log.VolunteerID, &log.Hours,
 			&log.LogDate, &log.Description, &log.CreatedAt, &log.VolunteerName,
 		)
 		if err != nil {
@@ -179,6 +216,84 @@ func (s *TaskTimeLogService) GetTimeSummaryByProject(projectID uuid.UUID) (*Time
 	return summary, nil
 }
 
+// GetProjectTotals returns hours logged on a project grouped by volunteer and by task,
+// plus a grand total. from and to are inclusive log dates; nil leaves that side open.
+func (s *TaskTimeLogService) GetProjectTotals(projectID uuid.UUID, from, to *time.Time) (*ProjectTimeTotals, error) {
+	totals := &ProjectTimeTotals{
+		ProjectID:   projectID,
+		From:        from,
+		To:          to,
+		ByVolunteer: []VolunteerTimeTotal{},
+		ByTask:      []TaskTimeTotal{},
+	}
+
+	err := s.db.QueryRow(timeLogGetProjectTotalQuery, projectID, from, to).Scan(&totals.TotalHours, &totals.LogCount)
+	if err != nil {
+		return nil, err
+	}
+
+	volunteerRows, err := s.db.Query(timeLogGetProjectTotalsByVolunteerQuery, projectID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer volunteerRows.Close()
+
+	for volunteerRows.Next() {
+		var total VolunteerTimeTotal
+		if err := volunteerRows.Scan(&total.VolunteerID, &total.VolunteerName, &total.TotalHours, &total.LogCount); err != nil {
+			return nil, err
+		}
+		totals.ByVolunteer = append(totals.ByVolunteer, total)
+	}
+	if err := volunteerRows.Err(); err != nil {
+		return nil, err
+	}
+
+	taskRows, err := s.db.Query(timeLogGetProjectTotalsByTaskQuery, projectID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer taskRows.Close()
+
+	for taskRows.Next() {
+		var total TaskTimeTotal
+		if err := taskRows.Scan(&total.TaskID, &total.TaskTitle, &total.TotalHours, &total.LogCount); err != nil {
+			return nil, err
+		}
+		totals.ByTask = append(totals.ByTask, total)
+	}
+
+	return totals, taskRows.Err()
+}
+
+// GetHoursLeaderboard ranks volunteers by hours logged, optionally within a single
+// project and an inclusive date range. Volunteers who opted out are excluded.
+// Volunteers with equal hours share a rank.
+func (s *TaskTimeLogService) GetHoursLeaderboard(projectID *uuid.UUID, from, to *time.Time, limit int) ([]HoursLeaderboardEntry, error) {
+	rows, err := s.db.Query(timeLogHoursLeaderboardQuery, projectID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []HoursLeaderboardEntry{}
+	for rows.Next() {
+		var entry HoursLeaderboardEntry
+		err := rows.Scan(&entry.VolunteerID, &entry.VolunteerName, &entry.TotalHours, &entry.LogCount, &entry.ProjectCount)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Rank = len(entries) + 1
+		if len(entries) > 0 && entries[len(entries)-1].TotalHours == entry.TotalHours {
+			entry.Rank = entries[len(entries)-1].Rank
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
 // Delete deletes a time log entry
 func (s *TaskTimeLogService) Delete(id uuid.UUID) error {
 	result, err := s.db.Exec(timeLogDeleteQuery, id)
@@ -64,5 +64,52 @@ const (
 		JOIN project_tasks pt ON ttl.task_id = pt.id
 		WHERE pt.project_id = $1`
 
+	// Date bounds are optional; NULL leaves that side of the range open
+	timeLogGetProjectTotalQuery = `
+		SELECT COALESCE(SUM(ttl.hours), 0) as total_hours, COUNT(*) as log_count
+		FROM task_time_logs ttl
+		JOIN project_tasks pt ON ttl.task_id = pt.id
+		WHERE pt.project_id = $1
+		  AND ($2::date IS NULL OR ttl.log_date >= $2::date)
+		  AND ($3::date IS NULL OR ttl.log_date <= $3::date)`
+
+	timeLogGetProjectTotalsByVolunteerQuery = `
+		SELECT ttl.volunteer_id, v.name as volunteer_name,
+		       SUM(ttl.hours) as total_hours, COUNT(*) as log_count
+		FROM task_time_logs ttl
+		JOIN volunteers v ON ttl.volunteer_id = v.id
+		JOIN project_tasks pt ON ttl.task_id = pt.id
+		WHERE pt.project_id = $1
+		  AND ($2::date IS NULL OR ttl.log_date >= $2::date)
+		  AND ($3::date IS NULL OR ttl.log_date <= $3::date)
+		GROUP BY ttl.volunteer_id, v.name
+		ORDER BY total_hours DESC, v.name`
+
+	timeLogGetProjectTotalsByTaskQuery = `
+		SELECT pt.id, pt.title, SUM(ttl.hours) as total_hours, COUNT(*) as log_count
+		FROM task_time_logs ttl
+		JOIN project_tasks pt ON ttl.task_id = pt.id
+		WHERE pt.project_id = $1
+		  AND ($2::date IS NULL OR ttl.log_date >= $2::date)
+		  AND ($3::date IS NULL OR ttl.log_date <= $3::date)
+		GROUP BY pt.id, pt.title
+		ORDER BY total_hours DESC, pt.title`
+
+	// Project and date bounds are optional; volunteers who opted out are never ranked
+	timeLogHoursLeaderboardQuery = `
+		SELECT ttl.volunteer_id, v.name as volunteer_name,
+		       SUM(ttl.hours) as total_hours, COUNT(*) as log_count,
+		       COUNT(DISTINCT pt.project_id) as project_count
+		FROM task_time_logs ttl
+		JOIN volunteers v ON ttl.volunteer_id = v.id
+		JOIN project_tasks pt ON ttl.task_id = pt.id
+		WHERE v.show_on_leaderboard = true
+		  AND ($1::uuid IS NULL OR pt.project_id = $1::uuid)
+		  AND ($2::date IS NULL OR ttl.log_date >= $2::date)
+		  AND ($3::date IS NULL OR ttl.log_date <= $3::date)
+		GROUP BY ttl.volunteer_id, v.name
+		ORDER BY total_hours DESC, v.name
+		LIMIT $4`
+
 	timeLogDeleteQuery = `DELETE FROM task_time_logs WHERE id = $1`
 )
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestHoursLeaderboardExcludesOptedOutVolunteers(t *testing.T) {
+	// The opt-out is applied by the query itself, before grouping and the limit, so an
+	// opted-out volunteer with the most hours can't take a place on the board
+	where := timeLogHoursLeaderboardQuery[strings.Index(timeLogHoursLeaderboardQuery, "WHERE"):strings.Index(timeLogHoursLeaderboardQuery, "GROUP BY")]
+	if !strings.Contains(where, "v.show_on_leaderboard = true") {
+		t.Error("leaderboard query does not filter out volunteers who opted out")
+	}
+	if strings.Contains(where, "OR v.show_on_leaderboard") {
+		t.Error("leaderboard query lets the opt-out be bypassed")
+	}
+}
+
+func TestGetHoursLeaderboardScopesAndRanks(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("FROM task_time_logs ttl", []string{"volunteer_id", "volunteer_name", "total_hours", "log_count", "project_count"},
+		[]driver.Value{uuid.New().String(), "Ana", 12.5, int64(4), int64(2)},
+		[]driver.Value{uuid.New().String(), "Ben", 8.0, int64(3), int64(1)},
+		[]driver.Value{uuid.New().String(), "Cai", 8.0, int64(2), int64(1)},
+		[]driver.Value{uuid.New().String(), "Dee", 3.0, int64(1), int64(1)},
+	)
+	projectID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries, err := NewTaskTimeLogService(db).GetHoursLeaderboard(&projectID, &from, nil, 10)
+	if err != nil {
+		t.Fatalf("GetHoursLeaderboard() error = %v", err)
+	}
+
+	// Volunteers with the same hours share a rank
+	wantRanks := []int{1, 2, 2, 4}
+	if len(entries) != len(wantRanks) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(wantRanks))
+	}
+	for i, want := range wantRanks {
+		if entries[i].Rank != want {
+			t.Errorf("%s: rank = %d, want %d", entries[i].VolunteerName, entries[i].Rank, want)
+		}
+	}
+
+	args := recorder.Statements()[0].Args
+	if args[0] != projectID.String() || args[2] != nil || args[3] != int64(10) {
+		t.Errorf("args = %v, want the project, no end date and the limit", args)
+	}
+}
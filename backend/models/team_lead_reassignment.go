@@ -0,0 +1,124 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TeamLeadSummary identifies a current or prospective project team lead
+type TeamLeadSummary struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	Email  string    `json:"email"`
+}
+
+// TeamLeadTaskSummary is a task created by a project's team lead
+type TeamLeadTaskSummary struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+}
+
+// TeamLeadReassignmentImpact describes what changes when a project's team lead is
+// replaced. Pending applications and task takeover requests await the lead's decision,
+// so they pass to the new lead.
+type TeamLeadReassignmentImpact struct {
+	ProjectID                 uuid.UUID             `json:"project_id"`
+	CurrentTeamLead           *TeamLeadSummary      `json:"current_team_lead"`
+	NewTeamLead               *TeamLeadSummary      `json:"new_team_lead"`
+	NoChange                  bool                  `json:"no_change"`
+	NewLeadIsTeamMember       bool                  `json:"new_lead_is_team_member"`
+	TasksCreatedByCurrentLead []TeamLeadTaskSummary `json:"tasks_created_by_current_lead"`
+	OpenTasksCreated          int                   `json:"open_tasks_created"`
+	PendingApplications       int                   `json:"pending_applications"`
+	PendingTakeoverRequests   int                   `json:"pending_takeover_requests"`
+	// CurrentLeadOtherProjects counts other projects the outgoing lead still leads; at
+	// zero they keep no team lead responsibilities
+	CurrentLeadOtherProjects int `json:"current_lead_other_projects"`
+}
+
+// GetTeamLeadSummary retrieves a user's name and email, or nil if the user doesn't exist
+func (s *ProjectService) GetTeamLeadSummary(userID uuid.UUID) (*TeamLeadSummary, error) {
+	summary := &TeamLeadSummary{}
+	err := s.db.QueryRow(teamLeadUserSummaryQuery, userID).Scan(&summary.UserID, &summary.Name, &summary.Email)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// IsUserActiveTeamMember checks if the volunteer profile of a user is an active team
+// member of a project
+func (s *ProjectService) IsUserActiveTeamMember(projectID, userID uuid.UUID) (bool, error) {
+	var isMember bool
+	err := s.db.QueryRow(teamLeadIsActiveMemberQuery, projectID, userID).Scan(&isMember)
+	return isMember, err
+}
+
+// PreviewTeamLeadReassignment reports the impact of making newLead the team lead of
+// project without changing anything
+func (s *ProjectService) PreviewTeamLeadReassignment(project *Project, newLead *TeamLeadSummary) (*TeamLeadReassignmentImpact, error) {
+	impact := &TeamLeadReassignmentImpact{
+		ProjectID:                 project.ID,
+		NewTeamLead:               newLead,
+		TasksCreatedByCurrentLead: []TeamLeadTaskSummary{},
+	}
+
+	isMember, err := s.IsUserActiveTeamMember(project.ID, newLead.UserID)
+	if err != nil {
+		return nil, err
+	}
+	impact.NewLeadIsTeamMember = isMember
+
+	if err := s.db.QueryRow(teamLeadPendingApplicationsQuery, project.ID).Scan(&impact.PendingApplications); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRow(teamLeadPendingTakeoversQuery, project.ID).Scan(&impact.PendingTakeoverRequests); err != nil {
+		return nil, err
+	}
+
+	if project.TeamLeadID == nil {
+		return impact, nil
+	}
+	currentLeadID := *project.TeamLeadID
+	impact.NoChange = currentLeadID == newLead.UserID
+
+	currentLead, err := s.GetTeamLeadSummary(currentLeadID)
+	if err != nil {
+		return nil, err
+	}
+	impact.CurrentTeamLead = currentLead
+
+	rows, err := s.db.Query(teamLeadTasksCreatedQuery, project.ID, currentLeadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task TeamLeadTaskSummary
+		if err := rows.Scan(&task.ID, &task.Title, &task.Status, &task.AssigneeID, &task.DueDate); err != nil {
+			return nil, err
+		}
+		if task.Status != "done" {
+			impact.OpenTasksCreated++
+		}
+		impact.TasksCreatedByCurrentLead = append(impact.TasksCreatedByCurrentLead, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.QueryRow(teamLeadOtherProjectsQuery, currentLeadID, project.ID).Scan(&impact.CurrentLeadOtherProjects); err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
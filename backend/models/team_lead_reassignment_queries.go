@@ -0,0 +1,33 @@
+package models
+
+const (
+	teamLeadUserSummaryQuery = `
+		SELECT u.id, COALESCE(v.name, a.name, u.email) AS name, u.email
+		FROM users u
+		LEFT JOIN volunteers v ON u.id = v.user_id
+		LEFT JOIN admins a ON u.id = a.user_id
+		WHERE u.id = $1`
+
+	teamLeadIsActiveMemberQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM project_team_members ptm
+			JOIN volunteers v ON v.id = ptm.volunteer_id
+			WHERE ptm.project_id = $1 AND v.user_id = $2 AND ptm.status = 'active'
+		)`
+
+	teamLeadTasksCreatedQuery = `
+		SELECT t.id, t.title, t.status, t.assignee_id, t.due_date
+		FROM project_tasks t
+		WHERE t.project_id = $1 AND t.created_by_id = $2
+		ORDER BY t.created_at`
+
+	teamLeadPendingApplicationsQuery = `
+		SELECT COUNT(*) FROM applications WHERE project_id = $1 AND status = 'pending'`
+
+	teamLeadPendingTakeoversQuery = `
+		SELECT COUNT(*) FROM project_tasks WHERE project_id = $1 AND status = 'takeover_requested'`
+
+	teamLeadOtherProjectsQuery = `
+		SELECT COUNT(*) FROM projects WHERE team_lead_id = $1 AND id <> $2 AND deleted_at IS NULL`
+)
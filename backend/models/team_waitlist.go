@@ -0,0 +1,107 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistEntry is a volunteer waiting for a place on a full project team
+type WaitlistEntry struct {
+	Position       int       `json:"position"` // 1 is next in line
+	VolunteerID    uuid.UUID `json:"volunteer_id"`
+	VolunteerName  string    `json:"volunteer_name"`
+	VolunteerEmail string    `json:"volunteer_email"`
+	WaitlistedAt   time.Time `json:"waitlisted_at"`
+}
+
+// GetWaitlist returns a project's waitlisted volunteers in the order they'll be promoted
+func (s *ProjectService) GetWaitlist(projectID uuid.UUID) ([]WaitlistEntry, error) {
+	rows, err := s.db.Query(waitlistListQuery, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []WaitlistEntry{}
+	for rows.Next() {
+		entry := WaitlistEntry{Position: len(entries) + 1}
+		if err := rows.Scan(&entry.VolunteerID, &entry.VolunteerName, &entry.VolunteerEmail, &entry.WaitlistedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// PromoteFromWaitlist moves the oldest waitlisted volunteers onto the team while it has
+// room, e.g. after a member leaves or max_team_size is raised, and returns the IDs of
+// the volunteers promoted. Projects without a team size cap promote everyone waiting.
+// Volunteers whose required credentials have lapsed since they were approved are
+// skipped and stay on the waitlist.
+func (s *ProjectService) PromoteFromWaitlist(projectID uuid.UUID) ([]uuid.UUID, error) {
+	var promoted []uuid.UUID
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Locking the project serializes this with approvals, which take the same lock
+		var maxTeamSize sql.NullInt64
+		if err := tx.QueryRow(waitlistLockProjectQuery, projectID).Scan(&maxTeamSize); err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		openPlaces := -1 // No limit
+		if maxTeamSize.Valid {
+			var activeCount int64
+			if err := tx.QueryRow(projectActiveTeamCountQuery, projectID).Scan(&activeCount); err != nil {
+				return err
+			}
+			if activeCount >= maxTeamSize.Int64 {
+				return nil
+			}
+			openPlaces = int(maxTeamSize.Int64 - activeCount)
+		}
+
+		candidates, err := queryUUIDs(tx, waitlistCandidatesQuery, projectID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, volunteerID := range candidates {
+			if openPlaces == 0 {
+				break
+			}
+			missing, err := missingRequiredCredentials(tx, volunteerID, projectID, now)
+			if err != nil {
+				return err
+			}
+			if len(missing) > 0 {
+				continue
+			}
+			if _, err := tx.Exec(waitlistPromoteQuery, projectID, volunteerID); err != nil {
+				return err
+			}
+			promoted = append(promoted, volunteerID)
+			openPlaces--
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// GetTeamMemberStatus returns a volunteer's status on a project team, or "" if they
+// aren't on it
+func (s *ProjectService) GetTeamMemberStatus(projectID, volunteerID uuid.UUID) (TeamMemberStatus, error) {
+	var status TeamMemberStatus
+	err := s.db.QueryRow(teamMemberStatusQuery, projectID, volunteerID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return status, err
+}
@@ -0,0 +1,32 @@
+package models
+
+// Query constants for project team waitlists
+const (
+	waitlistListQuery = `
+		SELECT ptm.volunteer_id, v.name, u.email, COALESCE(ptm.waitlisted_at, ptm.joined_at)
+		FROM project_team_members ptm
+		JOIN volunteers v ON ptm.volunteer_id = v.id
+		JOIN users u ON v.user_id = u.id
+		WHERE ptm.project_id = $1 AND ptm.status = 'waitlisted'
+		ORDER BY COALESCE(ptm.waitlisted_at, ptm.joined_at), ptm.id`
+
+	waitlistLockProjectQuery = `
+		SELECT max_team_size FROM projects
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`
+
+	// Waitlisted volunteers in the order they're promoted
+	waitlistCandidatesQuery = `
+		SELECT volunteer_id FROM project_team_members
+		WHERE project_id = $1 AND status = 'waitlisted'
+		ORDER BY COALESCE(waitlisted_at, joined_at), id`
+
+	waitlistPromoteQuery = `
+		UPDATE project_team_members
+		SET status = 'active', waitlisted_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE project_id = $1 AND volunteer_id = $2 AND status = 'waitlisted'`
+
+	teamMemberStatusQuery = `
+		SELECT status FROM project_team_members
+		WHERE project_id = $1 AND volunteer_id = $2`
+)
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// WithTransaction runs fn in a transaction, committing if it returns nil and rolling back
+// if it returns an error or panics. A panic is re-raised once the rollback is done, so
+// multi-step operations never leave some of their writes behind.
+func WithTransaction(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
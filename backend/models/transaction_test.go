@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"civicweave/backend/pkg/fakesql"
+)
+
+func TestWithTransactionCommitsWhenEveryStepSucceeds(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	err := WithTransaction(db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec("INSERT INTO first_step"); err != nil {
+			return err
+		}
+		_, err := tx.Exec("INSERT INTO second_step")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+
+	for _, statement := range recorder.Statements() {
+		if !statement.InTx {
+			t.Errorf("%q ran outside the transaction", statement.Query)
+		}
+	}
+	if recorder.Commits() != 1 || recorder.Rollbacks() != 0 {
+		t.Errorf("commits = %d, rollbacks = %d, want 1 and 0", recorder.Commits(), recorder.Rollbacks())
+	}
+}
+
+func TestWithTransactionRollsBackWhenAStepFails(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	stepErr := errors.New("constraint violated")
+	recorder.Fail("INSERT INTO second_step", stepErr)
+
+	thirdRan := false
+	err := WithTransaction(db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec("INSERT INTO first_step"); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("INSERT INTO second_step"); err != nil {
+			return err
+		}
+		thirdRan = true
+		return nil
+	})
+
+	if !errors.Is(err, stepErr) {
+		t.Errorf("WithTransaction() error = %v, want the failing step's error", err)
+	}
+	if thirdRan {
+		t.Error("steps after the failing one still ran")
+	}
+	if recorder.Commits() != 0 || recorder.Rollbacks() != 1 {
+		t.Errorf("commits = %d, rollbacks = %d, want 0 and 1", recorder.Commits(), recorder.Rollbacks())
+	}
+}
+
+func TestWithTransactionRollsBackAndRepanics(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	defer func() {
+		if p := recover(); p != "step panicked" {
+			t.Errorf("recovered %v, want the step's panic", p)
+		}
+		if recorder.Commits() != 0 || recorder.Rollbacks() != 1 {
+			t.Errorf("commits = %d, rollbacks = %d, want 0 and 1", recorder.Commits(), recorder.Rollbacks())
+		}
+	}()
+
+	WithTransaction(db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec("INSERT INTO first_step"); err != nil {
+			return err
+		}
+		panic("step panicked")
+	})
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -110,6 +111,341 @@ func (s *UserService) Delete(id uuid.UUID) error {
 	return err
 }
 
+// ErrEmailChangeNotPending is returned when confirming an email change that has been
+// superseded or whose address has since been taken by another account
+var ErrEmailChangeNotPending = fmt.Errorf("email change is no longer pending")
+
+// EmailInUse reports whether an email address belongs to an account other than userID
+func (s *UserService) EmailInUse(email string, userID uuid.UUID) (bool, error) {
+	var inUse bool
+	err := s.db.QueryRow(userEmailInUseQuery, email, userID).Scan(&inUse)
+	return inUse, err
+}
+
+// GetPendingEmail returns the unverified address a user is changing their email to,
+// or nil if they have no change pending
+func (s *UserService) GetPendingEmail(userID uuid.UUID) (*string, error) {
+	var pendingEmail sql.NullString
+	if err := s.db.QueryRow(userGetPendingEmailQuery, userID).Scan(&pendingEmail); err != nil {
+		return nil, err
+	}
+	if !pendingEmail.Valid {
+		return nil, nil
+	}
+	return &pendingEmail.String, nil
+}
+
+// SetPendingEmail records an address the user is changing their email to. The
+// change doesn't take effect until ConfirmEmailChange is called.
+func (s *UserService) SetPendingEmail(userID uuid.UUID, email string) error {
+	return updateUser(s.db, sql.ErrNoRows, userSetPendingEmailQuery, userID, email)
+}
+
+// ConfirmEmailChange makes a verified pending email the user's email. It returns
+// ErrEmailChangeNotPending if email is no longer the pending change or has been
+// taken by another account.
+func (s *UserService) ConfirmEmailChange(userID uuid.UUID, email string) error {
+	return updateUser(s.db, ErrEmailChangeNotPending, userConfirmEmailChangeQuery, userID, email)
+}
+
+// ProfileUpdate is a user's change to their own profile. Nil fields are left unchanged.
+type ProfileUpdate struct {
+	Volunteer          *Volunteer // The user's volunteer profile, with its changes applied
+	Admin              *Admin     // The user's admin profile, with its changes applied
+	EmailNotifications *bool
+	TaskDigestMinutes  *int
+	// NewEmail, if set, is the address the user is changing their email to
+	NewEmail string
+	// EmailVerificationExpiry is how long the link confirming NewEmail stays valid
+	EmailVerificationExpiry time.Duration
+}
+
+// UpdateProfile applies a user's change to their own profile in one transaction, so
+// the profile is left as it was if any part fails. A new email is recorded as pending
+// and, if verifyEmail is set, it's called with a token confirming the change before
+// the transaction commits; an error from it rolls back the whole update. Without
+// verifyEmail the new email applies immediately.
+func (s *UserService) UpdateProfile(userID uuid.UUID, update *ProfileUpdate, verifyEmail func(token string) error) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if update.Volunteer != nil {
+			if err := updateVolunteer(tx, update.Volunteer); err != nil {
+				return fmt.Errorf("failed to update volunteer profile: %w", err)
+			}
+		}
+		if update.Admin != nil {
+			if err := updateAdmin(tx, update.Admin); err != nil {
+				return fmt.Errorf("failed to update admin profile: %w", err)
+			}
+		}
+		if update.EmailNotifications != nil {
+			if err := updateUser(tx, sql.ErrNoRows, userSetEmailNotificationsQuery, userID, *update.EmailNotifications); err != nil {
+				return fmt.Errorf("failed to update notification preferences: %w", err)
+			}
+		}
+		if update.TaskDigestMinutes != nil {
+			if err := updateUser(tx, sql.ErrNoRows, userSetTaskDigestMinutesQuery, userID, *update.TaskDigestMinutes); err != nil {
+				return fmt.Errorf("failed to update notification preferences: %w", err)
+			}
+		}
+
+		if update.NewEmail == "" {
+			return nil
+		}
+		if err := updateUser(tx, sql.ErrNoRows, userSetPendingEmailQuery, userID, update.NewEmail); err != nil {
+			return fmt.Errorf("failed to record email change: %w", err)
+		}
+		if verifyEmail == nil {
+			return updateUser(tx, ErrEmailChangeNotPending, userConfirmEmailChangeQuery, userID, update.NewEmail)
+		}
+		token, err := issueEmailVerificationToken(tx, userID, &update.NewEmail, update.EmailVerificationExpiry)
+		if err != nil {
+			return fmt.Errorf("failed to issue email verification token: %w", err)
+		}
+		return verifyEmail(token)
+	})
+}
+
+// updateUser runs an update of one user's row with db, which may be a transaction.
+// It returns notFound if no row was updated.
+func updateUser(db interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}, notFound error, query string, args ...interface{}) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// ResetPassword consumes a password reset token and sets the new password hash for
+// its user, unlocking the account and revoking the user's sessions, refresh tokens and
+// personal access tokens. Everything happens in one transaction, so a failed update
+// leaves the token usable and a token can't be spent on two updates. It returns the
+// user whose password was reset, or nil if the token is unknown, expired or used.
+func (s *UserService) ResetPassword(rawToken, passwordHash string) (*uuid.UUID, error) {
+	var userID *uuid.UUID
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		token, err := consumePasswordResetToken(tx, rawToken)
+		if err != nil || token == nil {
+			return err
+		}
+
+		// Recorded in UTC so it compares correctly with JWT issue times
+		result, err := tx.Exec(userResetPasswordQuery, token.UserID, passwordHash, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		if _, err := tx.Exec(refreshTokenRevokeAllForUserQuery, token.UserID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(apiTokenRevokeAllForUserQuery, token.UserID); err != nil {
+			return err
+		}
+
+		userID = &token.UserID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userID, nil
+}
+
+// RevokedSessions counts the tokens revoked when a user's sessions are revoked
+type RevokedSessions struct {
+	RefreshTokens int64
+	APITokens     int64
+}
+
+// RevokeSessions signs a user out everywhere: access tokens issued until now stop
+// working and their refresh tokens and personal access tokens are revoked.
+func (s *UserService) RevokeSessions(userID uuid.UUID) (*RevokedSessions, error) {
+	revoked := &RevokedSessions{}
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(userRevokeSessionsQuery, userID, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		result, err = tx.Exec(refreshTokenRevokeAllForUserQuery, userID)
+		if err != nil {
+			return err
+		}
+		if revoked.RefreshTokens, err = result.RowsAffected(); err != nil {
+			return err
+		}
+
+		result, err = tx.Exec(apiTokenRevokeAllForUserQuery, userID)
+		if err != nil {
+			return err
+		}
+		revoked.APITokens, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
+
+// GetSessionsRevokedAt returns when the user's sessions were last revoked, or nil if
+// they never have been
+func (s *UserService) GetSessionsRevokedAt(userID uuid.UUID) (*time.Time, error) {
+	var revokedAt sql.NullTime
+	if err := s.db.QueryRow(userGetSessionsRevokedAtQuery, userID).Scan(&revokedAt); err != nil {
+		return nil, err
+	}
+	if !revokedAt.Valid {
+		return nil, nil
+	}
+	return &revokedAt.Time, nil
+}
+
+// RecordFailedLogin counts a failed login for a user. Once maxAttempts failures have
+// happened within lockout of each other, the account is locked for lockout and the
+// time it unlocks is returned; otherwise the returned time is nil.
+func (s *UserService) RecordFailedLogin(userID uuid.UUID, maxAttempts int, lockout time.Duration) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := WithTransaction(s.db, func(tx *sql.Tx) error {
+		// Times are recorded in UTC so they compare correctly when read back
+		now := time.Now().UTC()
+		var attempts int
+		if err := tx.QueryRow(userRecordFailedLoginQuery, userID, now, now.Add(-lockout)).Scan(&attempts); err != nil {
+			return err
+		}
+		if maxAttempts <= 0 || attempts < maxAttempts {
+			return nil
+		}
+
+		until := now.Add(lockout)
+		if _, err := tx.Exec(userLockQuery, userID, until); err != nil {
+			return err
+		}
+		lockedUntil = &until
+		return nil
+	})
+	return lockedUntil, err
+}
+
+// GetLockedUntil returns when a locked account unlocks, or nil if it isn't locked
+func (s *UserService) GetLockedUntil(userID uuid.UUID) (*time.Time, error) {
+	var lockedUntil sql.NullTime
+	if err := s.db.QueryRow(userGetLockedUntilQuery, userID).Scan(&lockedUntil); err != nil {
+		return nil, err
+	}
+	if !lockedUntil.Valid || !lockedUntil.Time.After(time.Now()) {
+		return nil, nil
+	}
+	return &lockedUntil.Time, nil
+}
+
+// ResetFailedLogins clears a user's failed login count and unlocks their account
+func (s *UserService) ResetFailedLogins(userID uuid.UUID) error {
+	result, err := s.db.Exec(userResetFailedLoginsQuery, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetEmailNotifications reports whether a user wants to receive notification emails
+func (s *UserService) GetEmailNotifications(userID uuid.UUID) (bool, error) {
+	var enabled bool
+	err := s.db.QueryRow(userGetEmailNotificationsQuery, userID).Scan(&enabled)
+	return enabled, err
+}
+
+// SetEmailNotifications updates a user's notification email preference
+func (s *UserService) SetEmailNotifications(userID uuid.UUID, enabled bool) error {
+	return updateUser(s.db, sql.ErrNoRows, userSetEmailNotificationsQuery, userID, enabled)
+}
+
+// GetTaskDigestMinutes retrieves how long a user's non-urgent task notifications
+// are merged into a digest. Nil means the user follows each project's window.
+func (s *UserService) GetTaskDigestMinutes(userID uuid.UUID) (*int, error) {
+	var minutes sql.NullInt64
+	if err := s.db.QueryRow(userGetTaskDigestMinutesQuery, userID).Scan(&minutes); err != nil {
+		return nil, err
+	}
+	if !minutes.Valid {
+		return nil, nil
+	}
+	value := int(minutes.Int64)
+	return &value, nil
+}
+
+// SetTaskDigestMinutes updates a user's task notification digest window
+func (s *UserService) SetTaskDigestMinutes(userID uuid.UUID, minutes int) error {
+	return updateUser(s.db, sql.ErrNoRows, userSetTaskDigestMinutesQuery, userID, minutes)
+}
+
+// GetHideReadReceipts reports whether a user hides when they read messages
+func (s *UserService) GetHideReadReceipts(userID uuid.UUID) (bool, error) {
+	var hidden bool
+	err := s.db.QueryRow(userGetHideReadReceiptsQuery, userID).Scan(&hidden)
+	return hidden, err
+}
+
+// SetHideReadReceipts updates whether a user hides when they read messages
+func (s *UserService) SetHideReadReceipts(userID uuid.UUID, hidden bool) error {
+	result, err := s.db.Exec(userSetHideReadReceiptsQuery, userID, hidden)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetCalendarFeedVersion returns the version a user's calendar feed token must carry
+func (s *UserService) GetCalendarFeedVersion(userID uuid.UUID) (int, error) {
+	var version int
+	err := s.db.QueryRow(userGetCalendarFeedVersionQuery, userID).Scan(&version)
+	return version, err
+}
+
+// RotateCalendarFeedVersion bumps a user's calendar feed version, invalidating every
+// feed token issued before, and returns the new version
+func (s *UserService) RotateCalendarFeedVersion(userID uuid.UUID) (int, error) {
+	var version int
+	err := s.db.QueryRow(userRotateCalendarFeedVersionQuery, userID).Scan(&version)
+	return version, err
+}
+
 // GetUserRoles retrieves all roles for a user
 func (s *UserService) GetUserRoles(userID uuid.UUID) ([]Role, error) {
 	roleService := NewRoleService(s.db)
@@ -25,6 +25,68 @@ const (
 
 	userDeleteQuery = `DELETE FROM users WHERE id = $1`
 
+	userResetPasswordQuery = `
+		UPDATE users
+		SET password_hash = $2, sessions_revoked_at = $3, updated_at = CURRENT_TIMESTAMP,
+			failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL
+		WHERE id = $1`
+
+	userRevokeSessionsQuery = `UPDATE users SET sessions_revoked_at = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	// Failures older than the window ($3) no longer count towards a lockout
+	userRecordFailedLoginQuery = `
+		UPDATE users
+		SET failed_login_attempts = CASE
+				WHEN last_failed_login_at IS NULL OR last_failed_login_at < $3 THEN 1
+				ELSE failed_login_attempts + 1
+			END,
+			last_failed_login_at = $2
+		WHERE id = $1
+		RETURNING failed_login_attempts`
+
+	userLockQuery = `UPDATE users SET locked_until = $2, failed_login_attempts = 0 WHERE id = $1`
+
+	userGetLockedUntilQuery = `SELECT locked_until FROM users WHERE id = $1`
+
+	userResetFailedLoginsQuery = `
+		UPDATE users
+		SET failed_login_attempts = 0, last_failed_login_at = NULL, locked_until = NULL
+		WHERE id = $1`
+
+	userGetPendingEmailQuery = `SELECT pending_email FROM users WHERE id = $1`
+
+	userSetPendingEmailQuery = `UPDATE users SET pending_email = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	// The change only applies if it's still the pending one and no one else has taken the address
+	userConfirmEmailChangeQuery = `
+		UPDATE users
+		SET email = pending_email, pending_email = NULL, email_verified = true, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND pending_email = $2
+			AND NOT EXISTS (SELECT 1 FROM users other WHERE other.email = $2 AND other.id <> $1)`
+
+	userEmailInUseQuery = `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1 AND id <> $2)`
+
+	userGetSessionsRevokedAtQuery = `SELECT sessions_revoked_at FROM users WHERE id = $1`
+
+	userGetEmailNotificationsQuery = `SELECT email_notifications FROM users WHERE id = $1`
+
+	userSetEmailNotificationsQuery = `UPDATE users SET email_notifications = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	userGetTaskDigestMinutesQuery = `SELECT task_digest_minutes FROM users WHERE id = $1`
+
+	userSetTaskDigestMinutesQuery = `UPDATE users SET task_digest_minutes = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	userGetHideReadReceiptsQuery = `SELECT hide_read_receipts FROM users WHERE id = $1`
+
+	userSetHideReadReceiptsQuery = `UPDATE users SET hide_read_receipts = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	userGetCalendarFeedVersionQuery = `SELECT calendar_feed_version FROM users WHERE id = $1`
+
+	userRotateCalendarFeedVersionQuery = `
+		UPDATE users SET calendar_feed_version = calendar_feed_version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING calendar_feed_version`
+
 	userListAllQuery = `SELECT id, email, password_hash, email_verified, created_at, updated_at FROM users ORDER BY created_at DESC`
 
 	userListAllWithNamesQuery = `
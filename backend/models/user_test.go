@@ -0,0 +1,102 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestRevokeSessionsRevokesRefreshAndAPITokensTogether(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	revoked, err := NewUserService(db).RevokeSessions(uuid.New())
+	if err != nil {
+		t.Fatalf("RevokeSessions() error = %v", err)
+	}
+
+	if revoked.RefreshTokens != 1 || revoked.APITokens != 1 {
+		t.Errorf("revoked = %+v, want one refresh and one API token", *revoked)
+	}
+	for _, match := range []string{"UPDATE users", "UPDATE refresh_tokens", "UPDATE api_tokens"} {
+		if !recorder.Ran(match) {
+			t.Errorf("%s did not run", match)
+		}
+	}
+	for _, statement := range recorder.Statements() {
+		if !statement.InTx {
+			t.Errorf("statement ran outside the transaction: %s", statement.Query)
+		}
+	}
+}
+
+func resetTokenRow(userID uuid.UUID) []driver.Value {
+	now := time.Now()
+	return []driver.Value{uuid.New().String(), userID.String(), now.Add(time.Hour), now, now}
+}
+
+func TestResetPasswordConsumesTokenAndRevokesEverythingInOneTransaction(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	userID := uuid.New()
+	recorder.Rows("UPDATE password_reset_tokens", []string{"id", "user_id", "expires_at", "used_at", "created_at"}, resetTokenRow(userID))
+
+	resetUserID, err := NewUserService(db).ResetPassword("raw-token", "new-hash")
+	if err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	if resetUserID == nil || *resetUserID != userID {
+		t.Errorf("ResetPassword() user = %v, want %s", resetUserID, userID)
+	}
+	for _, match := range []string{"UPDATE password_reset_tokens", "UPDATE users", "UPDATE refresh_tokens", "UPDATE api_tokens"} {
+		if !recorder.Ran(match) {
+			t.Errorf("%s did not run", match)
+		}
+	}
+	for _, statement := range recorder.Statements() {
+		if !statement.InTx {
+			t.Errorf("statement ran outside the transaction: %s", statement.Query)
+		}
+	}
+	if recorder.Commits() != 1 {
+		t.Errorf("commits = %d, want 1", recorder.Commits())
+	}
+}
+
+func TestResetPasswordKeepsTheTokenWhenTheUpdateFails(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("UPDATE password_reset_tokens", []string{"id", "user_id", "expires_at", "used_at", "created_at"}, resetTokenRow(uuid.New()))
+	recorder.Fail("UPDATE users", errors.New("connection reset"))
+
+	if _, err := NewUserService(db).ResetPassword("raw-token", "new-hash"); err == nil {
+		t.Fatal("ResetPassword() succeeded after the password update failed")
+	}
+
+	if recorder.Commits() != 0 || recorder.Rollbacks() != 1 {
+		t.Errorf("commits = %d, rollbacks = %d, want the token use rolled back", recorder.Commits(), recorder.Rollbacks())
+	}
+}
+
+func TestResetPasswordRejectsAnUnknownToken(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+
+	userID, err := NewUserService(db).ResetPassword("raw-token", "new-hash")
+	if err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	if userID != nil {
+		t.Errorf("ResetPassword() user = %s, want nil", *userID)
+	}
+	if recorder.Ran("UPDATE users") {
+		t.Error("password was updated for an unknown token")
+	}
+}
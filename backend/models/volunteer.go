@@ -176,14 +176,47 @@ func (s *VolunteerService) GetVolunteerWithScore(volunteerID uuid.UUID) (*Volunt
 
 // Update updates a volunteer
 func (s *VolunteerService) Update(volunteer *Volunteer) error {
+	return updateVolunteer(s.db, volunteer)
+}
+
+// updateVolunteer updates a volunteer with db, which may be a transaction
+func updateVolunteer(db interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}, volunteer *Volunteer) error {
 	skillsJSON, _ := json.Marshal(volunteer.Skills)
 
-	return s.db.QueryRow(volunteerUpdateQuery, volunteer.ID, volunteer.Name, volunteer.Phone,
+	return db.QueryRow(volunteerUpdateQuery, volunteer.ID, volunteer.Name, volunteer.Phone,
 		volunteer.LocationLat, volunteer.LocationLng, volunteer.LocationAddress,
 		skillsJSON, volunteer.Availability, volunteer.SkillsVisible, volunteer.ConsentGiven).
 		Scan(&volunteer.UpdatedAt)
 }
 
+// GetLeaderboardVisibility reports whether a volunteer appears on the hours leaderboard.
+// It returns sql.ErrNoRows if the volunteer doesn't exist.
+func (s *VolunteerService) GetLeaderboardVisibility(volunteerID uuid.UUID) (bool, error) {
+	var visible bool
+	err := s.db.QueryRow(volunteerGetLeaderboardVisibilityQuery, volunteerID).Scan(&visible)
+	return visible, err
+}
+
+// UpdateLeaderboardVisibility opts a volunteer in to or out of the hours leaderboard
+func (s *VolunteerService) UpdateLeaderboardVisibility(volunteerID uuid.UUID, visible bool) error {
+	result, err := s.db.Exec(volunteerUpdateLeaderboardVisibilityQuery, volunteerID, visible)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
 // Delete deletes a volunteer
 func (s *VolunteerService) Delete(id uuid.UUID) error {
 	_, err := s.db.Exec(volunteerDeleteQuery, id)
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvailabilityKind is the shape of an availability slot
+type AvailabilityKind string
+
+const (
+	// AvailabilityWeekly recurs every week on one day between two times
+	AvailabilityWeekly AvailabilityKind = "weekly"
+	// AvailabilityDateRange covers whole days from one date to another
+	AvailabilityDateRange AvailabilityKind = "date_range"
+)
+
+// MaxAvailabilitySlots bounds how many slots one volunteer can record
+const MaxAvailabilitySlots = 50
+
+// Availability errors
+var (
+	ErrInvalidAvailabilityKind  = fmt.Errorf("kind must be weekly or date_range")
+	ErrInvalidAvailabilityDay   = fmt.Errorf("weekly slots need a day_of_week from 0 (Sunday) to 6 (Saturday)")
+	ErrInvalidAvailabilityTimes = fmt.Errorf("weekly slots need a start_time before end_time, as HH:MM")
+	ErrInvalidAvailabilityDates = fmt.Errorf("date ranges need a start_date no later than end_date, as YYYY-MM-DD")
+	ErrTooManyAvailabilitySlots = fmt.Errorf("a volunteer can have at most %d availability slots", MaxAvailabilitySlots)
+)
+
+// VolunteerAvailability is one slot when a volunteer can help. Weekly slots use
+// DayOfWeek, StartTime and EndTime; date ranges use StartDate and EndDate.
+type VolunteerAvailability struct {
+	ID          uuid.UUID        `json:"id" db:"id"`
+	VolunteerID uuid.UUID        `json:"volunteer_id" db:"volunteer_id"`
+	Kind        AvailabilityKind `json:"kind" db:"kind"`
+	DayOfWeek   *int             `json:"day_of_week,omitempty" db:"day_of_week"` // 0 = Sunday
+	StartTime   *string          `json:"start_time,omitempty" db:"start_time"`   // HH:MM
+	EndTime     *string          `json:"end_time,omitempty" db:"end_time"`       // HH:MM
+	StartDate   *time.Time       `json:"start_date,omitempty" db:"start_date"`
+	EndDate     *time.Time       `json:"end_date,omitempty" db:"end_date"`
+	Note        *string          `json:"note,omitempty" db:"note"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+}
+
+// Validate checks that the slot has the fields its kind needs and no others
+func (a *VolunteerAvailability) Validate() error {
+	switch a.Kind {
+	case AvailabilityWeekly:
+		if a.DayOfWeek == nil || *a.DayOfWeek < 0 || *a.DayOfWeek > 6 {
+			return ErrInvalidAvailabilityDay
+		}
+		if a.StartTime == nil || a.EndTime == nil {
+			return ErrInvalidAvailabilityTimes
+		}
+		start, err := time.Parse("15:04", *a.StartTime)
+		if err != nil {
+			return ErrInvalidAvailabilityTimes
+		}
+		end, err := time.Parse("15:04", *a.EndTime)
+		if err != nil || !end.After(start) {
+			return ErrInvalidAvailabilityTimes
+		}
+		a.StartDate, a.EndDate = nil, nil
+	case AvailabilityDateRange:
+		if a.StartDate == nil || a.EndDate == nil || a.EndDate.Before(*a.StartDate) {
+			return ErrInvalidAvailabilityDates
+		}
+		a.DayOfWeek, a.StartTime, a.EndTime = nil, nil, nil
+	default:
+		return ErrInvalidAvailabilityKind
+	}
+
+	if a.Note != nil {
+		note := strings.TrimSpace(*a.Note)
+		if note == "" {
+			a.Note = nil
+		} else {
+			a.Note = &note
+		}
+	}
+	return nil
+}
+
+// VolunteerAvailabilityService handles volunteer availability operations
+type VolunteerAvailabilityService struct {
+	db *sql.DB
+}
+
+// NewVolunteerAvailabilityService creates a new volunteer availability service
+func NewVolunteerAvailabilityService(db *sql.DB) *VolunteerAvailabilityService {
+	return &VolunteerAvailabilityService{db: db}
+}
+
+// ListByVolunteer returns a volunteer's availability, weekly slots first. An empty list
+// means the volunteer hasn't said, and matching treats them as always available.
+func (s *VolunteerAvailabilityService) ListByVolunteer(volunteerID uuid.UUID) ([]VolunteerAvailability, error) {
+	rows, err := s.db.Query(availabilityListByVolunteerQuery, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	slots := []VolunteerAvailability{}
+	for rows.Next() {
+		slot, err := scanAvailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, *slot)
+	}
+	return slots, rows.Err()
+}
+
+// ListAll returns every volunteer's availability keyed by volunteer ID, for batch matching
+func (s *VolunteerAvailabilityService) ListAll() (map[uuid.UUID][]VolunteerAvailability, error) {
+	rows, err := s.db.Query(availabilityListAllQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byVolunteer := make(map[uuid.UUID][]VolunteerAvailability)
+	for rows.Next() {
+		slot, err := scanAvailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		byVolunteer[slot.VolunteerID] = append(byVolunteer[slot.VolunteerID], *slot)
+	}
+	return byVolunteer, rows.Err()
+}
+
+// Create adds a slot to a volunteer's availability and queues their matches to be
+// recalculated. The slot must already be validated.
+func (s *VolunteerAvailabilityService) Create(slot *VolunteerAvailability) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM volunteer_availability WHERE volunteer_id = $1", slot.VolunteerID).Scan(&count); err != nil {
+			return err
+		}
+		if count >= MaxAvailabilitySlots {
+			return ErrTooManyAvailabilitySlots
+		}
+
+		if err := insertAvailability(tx, slot); err != nil {
+			return err
+		}
+		return markMatchingDirty(tx, MatchingEntityVolunteer, slot.VolunteerID)
+	})
+}
+
+// Replace swaps a volunteer's whole availability for slots, which must already be
+// validated. An empty list clears it, so the volunteer counts as always available.
+func (s *VolunteerAvailabilityService) Replace(volunteerID uuid.UUID, slots []VolunteerAvailability) error {
+	if len(slots) > MaxAvailabilitySlots {
+		return ErrTooManyAvailabilitySlots
+	}
+
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(availabilityDeleteAllQuery, volunteerID); err != nil {
+			return err
+		}
+		for i := range slots {
+			slots[i].VolunteerID = volunteerID
+			if err := insertAvailability(tx, &slots[i]); err != nil {
+				return err
+			}
+		}
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
+}
+
+// Delete removes one of a volunteer's slots, returning sql.ErrNoRows if they have no
+// slot with that ID
+func (s *VolunteerAvailabilityService) Delete(id, volunteerID uuid.UUID) error {
+	return WithTransaction(s.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(availabilityDeleteQuery, id, volunteerID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+		return markMatchingDirty(tx, MatchingEntityVolunteer, volunteerID)
+	})
+}
+
+// insertAvailability inserts a slot, assigning its ID
+func insertAvailability(tx *sql.Tx, slot *VolunteerAvailability) error {
+	slot.ID = uuid.New()
+	return tx.QueryRow(availabilityCreateQuery, slot.ID, slot.VolunteerID, slot.Kind, slot.DayOfWeek,
+		slot.StartTime, slot.EndTime, slot.StartDate, slot.EndDate, slot.Note).
+		Scan(&slot.CreatedAt)
+}
+
+// scanAvailability scans a row selected with availabilityColumns
+func scanAvailability(rows *sql.Rows) (*VolunteerAvailability, error) {
+	slot := &VolunteerAvailability{}
+	err := rows.Scan(&slot.ID, &slot.VolunteerID, &slot.Kind, &slot.DayOfWeek, &slot.StartTime, &slot.EndTime,
+		&slot.StartDate, &slot.EndDate, &slot.Note, &slot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
@@ -0,0 +1,33 @@
+package models
+
+// Query constants for VolunteerAvailabilityService
+const (
+	availabilityColumns = `
+		id, volunteer_id, kind, day_of_week, TO_CHAR(start_time, 'HH24:MI'), TO_CHAR(end_time, 'HH24:MI'),
+		start_date, end_date, note, created_at`
+
+	availabilityListByVolunteerQuery = `
+		SELECT` + availabilityColumns + `
+		FROM volunteer_availability
+		WHERE volunteer_id = $1
+		ORDER BY kind DESC, day_of_week, start_time, start_date`
+
+	availabilityListAllQuery = `
+		SELECT` + availabilityColumns + `
+		FROM volunteer_availability
+		ORDER BY volunteer_id`
+
+	availabilityCreateQuery = `
+		INSERT INTO volunteer_availability (id, volunteer_id, kind, day_of_week, start_time, end_time,
+		                                    start_date, end_date, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at`
+
+	availabilityDeleteQuery = `
+		DELETE FROM volunteer_availability
+		WHERE id = $1 AND volunteer_id = $2`
+
+	availabilityDeleteAllQuery = `
+		DELETE FROM volunteer_availability
+		WHERE volunteer_id = $1`
+)
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VolunteerExportRow is one volunteer in an admin export, with their skills, a summary
+// of their ratings and how many projects they've joined
+type VolunteerExportRow struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"user_id"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	Phone           string    `json:"phone"`
+	LocationAddress string    `json:"location_address"`
+	EmailVerified   bool      `json:"email_verified"`
+	CreatedAt       time.Time `json:"created_at"`
+	Skills          []string  `json:"skills"`
+	TotalRatings    int       `json:"total_ratings"`
+	UpRatings       int       `json:"up_ratings"`
+	DownRatings     int       `json:"down_ratings"`
+	NeutralRatings  int       `json:"neutral_ratings"`
+	ActiveProjects  int       `json:"active_projects"`
+	TotalProjects   int       `json:"total_projects"`
+}
+
+// StreamExport calls fn for every volunteer in sign-up order, reading rows as they're
+// needed rather than loading them all. Iteration stops at the first error fn returns.
+func (s *VolunteerService) StreamExport(fn func(row *VolunteerExportRow) error) error {
+	rows, err := s.db.Query(volunteerExportQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &VolunteerExportRow{}
+		var skillsJSON []byte
+		err := rows.Scan(&row.ID, &row.UserID, &row.Name, &row.Email, &row.Phone, &row.LocationAddress,
+			&row.EmailVerified, &row.CreatedAt, &skillsJSON,
+			&row.TotalRatings, &row.UpRatings, &row.DownRatings, &row.NeutralRatings,
+			&row.ActiveProjects, &row.TotalProjects)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(skillsJSON, &row.Skills); err != nil {
+			return err
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
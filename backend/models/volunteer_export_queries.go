@@ -0,0 +1,40 @@
+package models
+
+// Query constants for volunteer exports
+const (
+	// Hidden ratings (upheld disputes) are left out, as on scorecards, and team
+	// memberships only count projects that haven't been deleted
+	volunteerExportQuery = `
+		SELECT v.id, v.user_id, v.name, u.email, COALESCE(v.phone, ''), COALESCE(v.location_address, ''),
+		       u.email_verified, v.created_at,
+		       COALESCE((
+		           SELECT JSON_AGG(st.skill_name ORDER BY st.skill_name)
+		           FROM volunteer_skills vs
+		           JOIN skill_taxonomy st ON vs.skill_id = st.id
+		           WHERE vs.volunteer_id = v.id
+		       ), '[]'::json) AS skills,
+		       COALESCE(r.total_ratings, 0), COALESCE(r.up_ratings, 0),
+		       COALESCE(r.down_ratings, 0), COALESCE(r.neutral_ratings, 0),
+		       COALESCE(pc.active_projects, 0), COALESCE(pc.total_projects, 0)
+		FROM volunteers v
+		JOIN users u ON v.user_id = u.id
+		LEFT JOIN (
+		    SELECT volunteer_id,
+		           COUNT(*) AS total_ratings,
+		           COUNT(*) FILTER (WHERE rating = 'up') AS up_ratings,
+		           COUNT(*) FILTER (WHERE rating = 'down') AS down_ratings,
+		           COUNT(*) FILTER (WHERE rating = 'neutral') AS neutral_ratings
+		    FROM volunteer_ratings
+		    WHERE hidden_at IS NULL
+		    GROUP BY volunteer_id
+		) r ON r.volunteer_id = v.id
+		LEFT JOIN (
+		    SELECT ptm.volunteer_id,
+		           COUNT(*) FILTER (WHERE ptm.status = 'active') AS active_projects,
+		           COUNT(*) AS total_projects
+		    FROM project_team_members ptm
+		    JOIN projects p ON ptm.project_id = p.id AND p.deleted_at IS NULL
+		    GROUP BY ptm.volunteer_id
+		) pc ON pc.volunteer_id = v.id
+		ORDER BY v.created_at, v.id`
+)
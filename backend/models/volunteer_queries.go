@@ -34,5 +34,12 @@ const (
 		WHERE id = $1
 		RETURNING updated_at`
 
+	volunteerGetLeaderboardVisibilityQuery = `SELECT show_on_leaderboard FROM volunteers WHERE id = $1`
+
+	volunteerUpdateLeaderboardVisibilityQuery = `
+		UPDATE volunteers
+		SET show_on_leaderboard = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
 	volunteerDeleteQuery = `DELETE FROM volunteers WHERE id = $1`
 )
@@ -2,6 +2,10 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -37,6 +41,31 @@ type VolunteerScorecard struct {
 	NeutralRatings int           `json:"neutral_ratings"`
 	OverallScore   float64       `json:"overall_score"` // Calculated score from -1 to 1
 	Skills         []SkillRating `json:"skills"`
+	CalculatedAt   time.Time     `json:"calculated_at"`
+}
+
+// Project outcome weights for recency-weighted scorecards: ratings earned on
+// completed projects count more than those on projects archived unfinished
+const (
+	CompletedProjectRatingWeight = 1.5
+	AbandonedProjectRatingWeight = 0.5
+)
+
+// WeightedScorecard is a scorecard with a recency-weighted score alongside the raw one.
+// Each rating's weight halves every HalfLifeDays and is scaled by its project's outcome.
+type WeightedScorecard struct {
+	VolunteerScorecard
+	Weighting     string  `json:"weighting"`
+	RawScore      float64 `json:"raw_score"`
+	WeightedScore float64 `json:"weighted_score"` // -1 to 1; equals RawScore when every weight has decayed to zero
+	HalfLifeDays  int     `json:"half_life_days"`
+}
+
+// ScorecardRecomputeResult summarizes a bulk scorecard recompute
+type ScorecardRecomputeResult struct {
+	Processed int         `json:"processed"`
+	Failed    []uuid.UUID `json:"failed"`
+	Batches   int         `json:"batches"`
 }
 
 // SkillRating represents rating data for a specific skill
@@ -68,9 +97,14 @@ func (s *VolunteerRatingService) CreateRating(rating *VolunteerRating) error {
 		RETURNING created_at`
 
 	rating.ID = uuid.New()
-	return s.db.QueryRow(query, rating.ID, rating.VolunteerID, rating.SkillClaimID,
+	err := s.db.QueryRow(query, rating.ID, rating.VolunteerID, rating.SkillClaimID,
 		rating.RatedByUserID, rating.ProjectID, rating.Rating, rating.Notes).
 		Scan(&rating.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return s.invalidateScorecard(rating.VolunteerID)
 }
 
 // GetRatingByID retrieves a rating by ID
@@ -150,8 +184,228 @@ func (s *VolunteerRatingService) ListRatingsByRater(raterID uuid.UUID, limit, of
 	return ratings, nil
 }
 
-// GetVolunteerScorecard retrieves aggregated rating data for a volunteer
+// GetVolunteerScorecard retrieves aggregated rating data for a volunteer,
+// serving the cached scorecard when there is one and calculating it otherwise
 func (s *VolunteerRatingService) GetVolunteerScorecard(volunteerID uuid.UUID) (*VolunteerScorecard, error) {
+	scorecard, err := s.getCachedScorecard(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	if scorecard != nil {
+		return scorecard, nil
+	}
+
+	return s.RecomputeScorecard(volunteerID)
+}
+
+// GetWeightedScorecard retrieves a volunteer's scorecard with a recency-weighted
+// score: ratings decay exponentially with age, halving every halfLifeDays, and are
+// weighted up on completed projects and down on abandoned ones.
+func (s *VolunteerRatingService) GetWeightedScorecard(volunteerID uuid.UUID, halfLifeDays int) (*WeightedScorecard, error) {
+	if halfLifeDays < 1 {
+		halfLifeDays = 1
+	}
+
+	scorecard, err := s.GetVolunteerScorecard(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A project counts as abandoned if it was archived without ever being completed
+	query := `
+		SELECT vr.rating, EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - vr.created_at)) / 86400 as age_days,
+			CASE
+				WHEN p.id IS NULL THEN ''
+				WHEN p.project_status = 'completed' OR EXISTS (
+					SELECT 1 FROM project_status_history psh
+					WHERE psh.project_id = p.id AND psh.to_status = 'completed'
+				) THEN 'completed'
+				WHEN p.project_status = 'archived' THEN 'abandoned'
+				ELSE ''
+			END as project_outcome
+		FROM volunteer_ratings vr
+		LEFT JOIN projects p ON vr.project_id = p.id
+		WHERE vr.volunteer_id = $1 AND vr.hidden_at IS NULL`
+
+	rows, err := s.db.Query(query, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weightedSum, totalWeight float64
+	for rows.Next() {
+		var rating RatingType
+		var ageDays float64
+		var outcome string
+		if err := rows.Scan(&rating, &ageDays, &outcome); err != nil {
+			return nil, err
+		}
+
+		weight := math.Pow(0.5, math.Max(ageDays, 0)/float64(halfLifeDays))
+		switch outcome {
+		case "completed":
+			weight *= CompletedProjectRatingWeight
+		case "abandoned":
+			weight *= AbandonedProjectRatingWeight
+		}
+
+		switch rating {
+		case RatingUp:
+			weightedSum += weight
+		case RatingDown:
+			weightedSum -= weight
+		}
+		totalWeight += weight
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	weighted := &WeightedScorecard{
+		VolunteerScorecard: *scorecard,
+		Weighting:          "recency",
+		RawScore:           scorecard.OverallScore,
+		WeightedScore:      scorecard.OverallScore,
+		HalfLifeDays:       halfLifeDays,
+	}
+	// Very old ratings can underflow to zero weight; fall back to the raw score then
+	if totalWeight > 0 {
+		weighted.WeightedScore = weightedSum / totalWeight
+	}
+
+	return weighted, nil
+}
+
+// RecomputeScorecard recalculates a volunteer's scorecard from their ratings and caches it
+func (s *VolunteerRatingService) RecomputeScorecard(volunteerID uuid.UUID) (*VolunteerScorecard, error) {
+	scorecard, err := s.calculateScorecard(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	skillsJSON, err := json.Marshal(scorecard.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO volunteer_scorecards (volunteer_id, total_ratings, up_ratings, down_ratings, neutral_ratings, overall_score, skills, calculated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (volunteer_id) DO UPDATE SET
+			total_ratings = EXCLUDED.total_ratings,
+			up_ratings = EXCLUDED.up_ratings,
+			down_ratings = EXCLUDED.down_ratings,
+			neutral_ratings = EXCLUDED.neutral_ratings,
+			overall_score = EXCLUDED.overall_score,
+			skills = EXCLUDED.skills,
+			calculated_at = EXCLUDED.calculated_at
+		RETURNING calculated_at`
+
+	err = s.db.QueryRow(query, volunteerID, scorecard.TotalRatings, scorecard.UpRatings, scorecard.DownRatings,
+		scorecard.NeutralRatings, scorecard.OverallScore, string(skillsJSON)).Scan(&scorecard.CalculatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache scorecard: %w", err)
+	}
+
+	return scorecard, nil
+}
+
+// RecomputeAllScorecards recalculates and caches every volunteer's scorecard in batches.
+// It is safe to re-run; progress is reported after each batch if progress is non-nil.
+func (s *VolunteerRatingService) RecomputeAllScorecards(batchSize int, progress func(processed, total int)) (*ScorecardRecomputeResult, error) {
+	if batchSize < 1 {
+		batchSize = 100
+	}
+
+	result := &ScorecardRecomputeResult{Failed: []uuid.UUID{}}
+
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM volunteers`).Scan(&total); err != nil {
+		return result, err
+	}
+
+	lastID := uuid.Nil
+	for {
+		// Keyset pagination keeps batches stable even if volunteers are added mid-run
+		rows, err := s.db.Query(`SELECT id FROM volunteers WHERE id > $1 ORDER BY id LIMIT $2`, lastID, batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		var batch []uuid.UUID
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return result, err
+			}
+			batch = append(batch, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return result, err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, volunteerID := range batch {
+			if _, err := s.RecomputeScorecard(volunteerID); err != nil {
+				log.Printf("❌ RECOMPUTE_SCORECARDS: Failed for volunteer %s: %v", volunteerID, err)
+				result.Failed = append(result.Failed, volunteerID)
+				continue
+			}
+			result.Processed++
+		}
+
+		result.Batches++
+		lastID = batch[len(batch)-1]
+		if progress != nil {
+			progress(result.Processed+len(result.Failed), total)
+		}
+	}
+
+	return result, nil
+}
+
+// getCachedScorecard retrieves a cached scorecard, returning nil if none is cached
+func (s *VolunteerRatingService) getCachedScorecard(volunteerID uuid.UUID) (*VolunteerScorecard, error) {
+	query := `
+		SELECT volunteer_id, total_ratings, up_ratings, down_ratings, neutral_ratings, overall_score, skills, calculated_at
+		FROM volunteer_scorecards
+		WHERE volunteer_id = $1`
+
+	scorecard := &VolunteerScorecard{}
+	var skillsJSON []byte
+	err := s.db.QueryRow(query, volunteerID).Scan(&scorecard.VolunteerID, &scorecard.TotalRatings,
+		&scorecard.UpRatings, &scorecard.DownRatings, &scorecard.NeutralRatings, &scorecard.OverallScore,
+		&skillsJSON, &scorecard.CalculatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scorecard.Skills = []SkillRating{}
+	if err := json.Unmarshal(skillsJSON, &scorecard.Skills); err != nil {
+		return nil, err
+	}
+
+	return scorecard, nil
+}
+
+// invalidateScorecard drops a volunteer's cached scorecard so the next read recalculates it
+func (s *VolunteerRatingService) invalidateScorecard(volunteerID uuid.UUID) error {
+	_, err := s.db.Exec(`DELETE FROM volunteer_scorecards WHERE volunteer_id = $1`, volunteerID)
+	return err
+}
+
+// calculateScorecard aggregates a volunteer's ratings into a scorecard. Ratings
+// hidden by an upheld dispute are left out.
+func (s *VolunteerRatingService) calculateScorecard(volunteerID uuid.UUID) (*VolunteerScorecard, error) {
 	scorecard := &VolunteerScorecard{
 		VolunteerID: volunteerID,
 		Skills:      []SkillRating{},
@@ -165,7 +419,7 @@ func (s *VolunteerRatingService) GetVolunteerScorecard(volunteerID uuid.UUID) (*
 			COUNT(CASE WHEN rating = 'down' THEN 1 END) as down_ratings,
 			COUNT(CASE WHEN rating = 'neutral' THEN 1 END) as neutral_ratings
 		FROM volunteer_ratings 
-		WHERE volunteer_id = $1`
+		WHERE volunteer_id = $1 AND hidden_at IS NULL`
 
 	var totalRatings, upRatings, downRatings, neutralRatings int
 	err := s.db.QueryRow(query, volunteerID).Scan(&totalRatings, &upRatings, &downRatings, &neutralRatings)
@@ -193,7 +447,7 @@ func (s *VolunteerRatingService) GetVolunteerScorecard(volunteerID uuid.UUID) (*
 			COUNT(CASE WHEN vr.rating = 'down' THEN 1 END) as down_ratings,
 			COUNT(CASE WHEN vr.rating = 'neutral' THEN 1 END) as neutral_ratings
 		FROM skill_claims sc
-		LEFT JOIN volunteer_ratings vr ON sc.id = vr.skill_claim_id AND vr.volunteer_id = $1
+		LEFT JOIN volunteer_ratings vr ON sc.id = vr.skill_claim_id AND vr.volunteer_id = $1 AND vr.hidden_at IS NULL
 		WHERE sc.volunteer_id = $1 AND sc.is_active = true
 		GROUP BY sc.id, sc.claim_text
 		HAVING COUNT(vr.id) > 0
@@ -263,17 +517,35 @@ func (s *VolunteerRatingService) UpdateRating(rating *VolunteerRating) error {
 	query := `
 		UPDATE volunteer_ratings 
 		SET rating = $2, notes = $3
-		WHERE id = $1`
+		WHERE id = $1
+		RETURNING volunteer_id`
 
-	_, err := s.db.Exec(query, rating.ID, rating.Rating, rating.Notes)
-	return err
+	var volunteerID uuid.UUID
+	err := s.db.QueryRow(query, rating.ID, rating.Rating, rating.Notes).Scan(&volunteerID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.invalidateScorecard(volunteerID)
 }
 
 // DeleteRating deletes a volunteer rating
 func (s *VolunteerRatingService) DeleteRating(id uuid.UUID) error {
-	query := `DELETE FROM volunteer_ratings WHERE id = $1`
-	_, err := s.db.Exec(query, id)
-	return err
+	query := `DELETE FROM volunteer_ratings WHERE id = $1 RETURNING volunteer_id`
+
+	var volunteerID uuid.UUID
+	err := s.db.QueryRow(query, id).Scan(&volunteerID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.invalidateScorecard(volunteerID)
 }
 
 // HasRated checks if a user has already rated a volunteer for a specific skill/project
@@ -294,8 +566,9 @@ func (s *VolunteerRatingService) HasRated(raterID, volunteerID uuid.UUID, skillC
 	return count > 0, nil
 }
 
-// GetTopRatedVolunteers retrieves volunteers with highest overall scores
-func (s *VolunteerRatingService) GetTopRatedVolunteers(limit int) ([]VolunteerWithScore, error) {
+// GetTopRatedVolunteers retrieves volunteers with highest overall scores among
+// those with at least minRatings ratings
+func (s *VolunteerRatingService) GetTopRatedVolunteers(limit, minRatings int) ([]VolunteerWithScore, error) {
 	query := `
 		SELECT 
 			v.id,
@@ -319,14 +592,15 @@ func (s *VolunteerRatingService) GetTopRatedVolunteers(limit int) ([]VolunteerWi
 				CAST(COUNT(CASE WHEN rating = 'up' THEN 1 END) - COUNT(CASE WHEN rating = 'down' THEN 1 END) AS FLOAT) / NULLIF(COUNT(*), 0) as overall_score,
 				COUNT(*) as total_ratings
 			FROM volunteer_ratings
+			WHERE hidden_at IS NULL
 			GROUP BY volunteer_id
-			HAVING COUNT(*) >= 3  -- Only include volunteers with at least 3 ratings
+			HAVING COUNT(*) >= $2
 		) score_data ON v.id = score_data.volunteer_id
 		WHERE score_data.overall_score IS NOT NULL
 		ORDER BY score_data.overall_score DESC, score_data.total_ratings DESC
 		LIMIT $1`
 
-	rows, err := s.db.Query(query, limit)
+	rows, err := s.db.Query(query, limit, minRatings)
 	if err != nil {
 		return nil, err
 	}
@@ -354,3 +628,81 @@ type VolunteerWithScore struct {
 	OverallScore float64 `json:"overall_score"`
 	TotalRatings int     `json:"total_ratings"`
 }
+
+// SkillMatch describes how a volunteer holds the skill a leaderboard was filtered by
+type SkillMatch struct {
+	SkillID          int     `json:"skill_id"`
+	SkillName        string  `json:"skill_name"`
+	SkillWeight      float64 `json:"skill_weight"`
+	ProficiencyLevel *string `json:"proficiency_level,omitempty"`
+}
+
+// VolunteerWithSkillScore represents a volunteer with their rating score and matching skill
+type VolunteerWithSkillScore struct {
+	VolunteerWithScore
+	SkillMatch SkillMatch `json:"skill_match"`
+}
+
+// GetTopRatedBySkill retrieves the highest-scoring volunteers who hold a taxonomy
+// skill, among those with at least minRatings ratings
+func (s *VolunteerRatingService) GetTopRatedBySkill(skillID, limit, minRatings int) ([]VolunteerWithSkillScore, error) {
+	query := `
+		SELECT 
+			v.id,
+			v.user_id,
+			v.name,
+			v.phone,
+			v.location_lat,
+			v.location_lng,
+			v.location_address,
+			v.skills,
+			v.availability,
+			v.consent_given,
+			v.created_at,
+			v.updated_at,
+			score_data.overall_score,
+			score_data.total_ratings,
+			st.id,
+			st.skill_name,
+			vs.skill_weight,
+			vs.proficiency_level
+		FROM volunteer_skills vs
+		INNER JOIN skill_taxonomy st ON vs.skill_id = st.id
+		INNER JOIN volunteers v ON vs.volunteer_id = v.id
+		INNER JOIN (
+			SELECT 
+				volunteer_id,
+				CAST(COUNT(CASE WHEN rating = 'up' THEN 1 END) - COUNT(CASE WHEN rating = 'down' THEN 1 END) AS FLOAT) / NULLIF(COUNT(*), 0) as overall_score,
+				COUNT(*) as total_ratings
+			FROM volunteer_ratings
+			WHERE hidden_at IS NULL
+			GROUP BY volunteer_id
+			HAVING COUNT(*) >= $3
+		) score_data ON v.id = score_data.volunteer_id
+		WHERE vs.skill_id = $1 AND vs.visibility = $4
+		ORDER BY score_data.overall_score DESC, score_data.total_ratings DESC, vs.skill_weight DESC
+		LIMIT $2`
+
+	rows, err := s.db.Query(query, skillID, limit, minRatings, SkillVisibilityPublic)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volunteers := []VolunteerWithSkillScore{}
+	for rows.Next() {
+		var volunteer VolunteerWithSkillScore
+		err := rows.Scan(&volunteer.ID, &volunteer.UserID, &volunteer.Name, &volunteer.Phone,
+			&volunteer.LocationLat, &volunteer.LocationLng, &volunteer.LocationAddress,
+			&volunteer.Skills, &volunteer.Availability, &volunteer.ConsentGiven,
+			&volunteer.CreatedAt, &volunteer.UpdatedAt, &volunteer.OverallScore, &volunteer.TotalRatings,
+			&volunteer.SkillMatch.SkillID, &volunteer.SkillMatch.SkillName,
+			&volunteer.SkillMatch.SkillWeight, &volunteer.SkillMatch.ProficiencyLevel)
+		if err != nil {
+			return nil, err
+		}
+		volunteers = append(volunteers, volunteer)
+	}
+
+	return volunteers, rows.Err()
+}
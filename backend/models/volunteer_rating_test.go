@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+func TestRecomputeAllScorecardsReflectsAnUpheldDispute(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	volunteerID := uuid.New()
+	ratingID := uuid.New()
+	now := time.Now()
+	recorder.Rows("UPDATE rating_disputes", []string{
+		"id", "rating_id", "volunteer_id", "filed_by_user_id", "reason", "status", "resolution_note",
+		"resolved_by_user_id", "resolved_at", "created_at",
+	}, []driver.Value{
+		uuid.New().String(), ratingID.String(), volunteerID.String(), volunteerID.String(), "Not my shift", "upheld", nil,
+		nil, now, now,
+	})
+	recorder.Rows("SELECT COUNT(*) FROM volunteers", []string{"count"}, []driver.Value{int64(1)})
+	recorder.RowsOnce("SELECT id FROM volunteers WHERE id > $1", []string{"id"}, []driver.Value{volunteerID.String()})
+	// With the disputed down rating hidden, the volunteer's two remaining ratings are up
+	recorder.Rows("WHERE volunteer_id = $1 AND hidden_at IS NULL", []string{
+		"total_ratings", "up_ratings", "down_ratings", "neutral_ratings",
+	}, []driver.Value{int64(2), int64(2), int64(0), int64(0)})
+	recorder.Rows("INSERT INTO volunteer_scorecards", []string{"calculated_at"}, []driver.Value{now})
+	service := NewVolunteerRatingService(db)
+
+	if _, err := service.ResolveDispute(uuid.New(), DisputeStatusUpheld, uuid.New(), nil); err != nil {
+		t.Fatalf("ResolveDispute() error = %v", err)
+	}
+	if !recorder.Ran("UPDATE volunteer_ratings SET hidden_at") {
+		t.Error("upholding the dispute did not hide the rating")
+	}
+	if !recorder.Ran("DELETE FROM volunteer_scorecards") {
+		t.Error("upholding the dispute did not drop the cached scorecard")
+	}
+
+	result, err := service.RecomputeAllScorecards(10, nil)
+	if err != nil {
+		t.Fatalf("RecomputeAllScorecards() error = %v", err)
+	}
+	if result.Processed != 1 || result.Batches != 1 || len(result.Failed) != 0 {
+		t.Errorf("result = %+v, want one volunteer in one batch", *result)
+	}
+
+	var cached *fakesql.Statement
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "INSERT INTO volunteer_scorecards") {
+			statement := statement
+			cached = &statement
+		}
+	}
+	if cached == nil {
+		t.Fatal("the recomputed scorecard was not cached")
+	}
+	// total_ratings, up_ratings, down_ratings and overall_score leave out the hidden rating
+	if cached.Args[1] != int64(2) || cached.Args[2] != int64(2) || cached.Args[3] != int64(0) || cached.Args[5] != 1.0 {
+		t.Errorf("cached scorecard args = %v, want 2 up ratings and a score of 1", cached.Args)
+	}
+}
+
+func TestRecomputeAllScorecardsIsBatched(t *testing.T) {
+	db, recorder := fakesql.Open()
+	defer db.Close()
+	recorder.Rows("SELECT COUNT(*) FROM volunteers", []string{"count"}, []driver.Value{int64(3)})
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	recorder.RowsOnce("SELECT id FROM volunteers WHERE id > $1", []string{"id"},
+		[]driver.Value{first.String()}, []driver.Value{second.String()})
+	recorder.RowsOnce("SELECT id FROM volunteers WHERE id > $1", []string{"id"}, []driver.Value{third.String()})
+	recorder.Rows("FROM volunteer_ratings", []string{"total_ratings", "up_ratings", "down_ratings", "neutral_ratings"},
+		[]driver.Value{int64(0), int64(0), int64(0), int64(0)})
+	recorder.Rows("INSERT INTO volunteer_scorecards", []string{"calculated_at"}, []driver.Value{time.Now()})
+
+	var progress []int
+	result, err := NewVolunteerRatingService(db).RecomputeAllScorecards(2, func(processed, total int) {
+		progress = append(progress, processed)
+		if total != 3 {
+			t.Errorf("progress total = %d, want 3", total)
+		}
+	})
+	if err != nil {
+		t.Fatalf("RecomputeAllScorecards() error = %v", err)
+	}
+
+	if result.Processed != 3 || result.Batches != 2 {
+		t.Errorf("result = %+v, want 3 volunteers in 2 batches", *result)
+	}
+	if len(progress) != 2 || progress[0] != 2 || progress[1] != 3 {
+		t.Errorf("progress = %v, want [2 3]", progress)
+	}
+
+	// Each page starts after the last volunteer of the previous one
+	var pages [][]driver.Value
+	for _, statement := range recorder.Statements() {
+		if strings.Contains(statement.Query, "SELECT id FROM volunteers WHERE id > $1") {
+			pages = append(pages, statement.Args)
+		}
+	}
+	if len(pages) != 3 || pages[1][0] != second.String() || pages[2][0] != third.String() {
+		t.Errorf("page args = %v, want pages after %s and %s", pages, second, third)
+	}
+}
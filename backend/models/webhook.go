@@ -0,0 +1,283 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"civicweave/backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	WebhookEventProjectStatusChanged = "project.status_changed"
+	WebhookEventTaskCompleted        = "task.completed"
+	WebhookEventApplicationCreated   = "application.created"
+)
+
+// WebhookEventTypes lists the events a webhook can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventProjectStatusChanged,
+	WebhookEventTaskCompleted,
+	WebhookEventApplicationCreated,
+}
+
+// IsValidWebhookEventType reports whether eventType is one webhooks can subscribe to
+func IsValidWebhookEventType(eventType string) bool {
+	for _, valid := range WebhookEventTypes {
+		if eventType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is an admin-registered URL that receives signed payloads for the events it
+// subscribes to. The secret signs payloads and is only shown when created or rotated.
+type Webhook struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	URL         string     `json:"url" db:"url"`
+	Secret      string     `json:"-" db:"secret"`
+	EventTypes  []string   `json:"event_types" db:"event_types"`
+	Description string     `json:"description" db:"description"`
+	IsActive    bool       `json:"is_active" db:"is_active"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookDelivery is one event queued for, or sent to, one webhook
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id" db:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id" db:"event_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// DueWebhookDelivery is a claimed delivery along with where to send it
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// WebhookService handles webhook registrations and their delivery queue
+type WebhookService struct {
+	db *sql.DB
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(db *sql.DB) *WebhookService {
+	return &WebhookService{db: db}
+}
+
+// GenerateWebhookSecret creates a random secret for signing webhook payloads
+func GenerateWebhookSecret() string {
+	return "whsec_" + utils.GenerateRandomToken()
+}
+
+// Create registers a webhook, generating its secret if one isn't set
+func (s *WebhookService) Create(webhook *Webhook) error {
+	webhook.ID = uuid.New()
+	if webhook.Secret == "" {
+		webhook.Secret = GenerateWebhookSecret()
+	}
+
+	eventTypesJSON, err := ToJSONArray(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	return s.db.QueryRow(webhookCreateQuery, webhook.ID, webhook.URL, webhook.Secret, eventTypesJSON,
+		webhook.Description, webhook.IsActive, webhook.CreatedBy).Scan(&webhook.CreatedAt, &webhook.UpdatedAt)
+}
+
+// GetByID retrieves a webhook by ID
+func (s *WebhookService) GetByID(id uuid.UUID) (*Webhook, error) {
+	webhook, err := scanWebhook(s.db.QueryRow(webhookGetByIDQuery, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return webhook, err
+}
+
+// List retrieves every webhook, newest first
+func (s *WebhookService) List() ([]Webhook, error) {
+	rows, err := s.db.Query(webhookListQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Update saves a webhook's URL, subscriptions, description and active flag. It returns
+// sql.ErrNoRows if the webhook doesn't exist.
+func (s *WebhookService) Update(webhook *Webhook) error {
+	eventTypesJSON, err := ToJSONArray(webhook.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	return s.db.QueryRow(webhookUpdateQuery, webhook.ID, webhook.URL, eventTypesJSON,
+		webhook.Description, webhook.IsActive).Scan(&webhook.UpdatedAt)
+}
+
+// RotateSecret replaces a webhook's signing secret and returns the new one. It returns
+// sql.ErrNoRows if the webhook doesn't exist.
+func (s *WebhookService) RotateSecret(id uuid.UUID) (string, error) {
+	secret := GenerateWebhookSecret()
+	result, err := s.db.Exec(webhookRotateSecretQuery, id, secret)
+	if err != nil {
+		return "", err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if rowsAffected == 0 {
+		return "", sql.ErrNoRows
+	}
+	return secret, nil
+}
+
+// Delete removes a webhook and its delivery history. It returns sql.ErrNoRows if the
+// webhook doesn't exist.
+func (s *WebhookService) Delete(id uuid.UUID) error {
+	result, err := s.db.Exec(webhookDeleteQuery, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// EnqueueEvent queues payload for every active webhook subscribed to eventType and
+// returns how many deliveries were queued
+func (s *WebhookService) EnqueueEvent(eventID uuid.UUID, eventType string, payload []byte) (int64, error) {
+	result, err := s.db.Exec(webhookDeliveryEnqueueQuery, eventID, eventType, string(payload))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries whose next attempt is due.
+// Claimed deliveries are not handed out again for a few minutes unless a failure is
+// recorded with an earlier retry.
+func (s *WebhookService) ClaimDueDeliveries(limit int) ([]DueWebhookDelivery, error) {
+	rows, err := s.db.Query(webhookDeliveryClaimDueQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []DueWebhookDelivery{}
+	for rows.Next() {
+		var delivery DueWebhookDelivery
+		var payload []byte
+		err := rows.Scan(
+			&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload,
+			&delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &delivery.LastError,
+			&delivery.NextAttemptAt, &delivery.DeliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt,
+			&delivery.URL, &delivery.Secret,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Payload = json.RawMessage(payload)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// ListDeliveries retrieves a webhook's most recent deliveries
+func (s *WebhookService) ListDeliveries(webhookID uuid.UUID, limit int) ([]WebhookDelivery, error) {
+	rows, err := s.db.Query(webhookDeliveryListByWebhookQuery, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var payload []byte
+		err := rows.Scan(
+			&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &payload,
+			&delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &delivery.LastError,
+			&delivery.NextAttemptAt, &delivery.DeliveredAt, &delivery.CreatedAt, &delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Payload = json.RawMessage(payload)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// MarkDelivered records a successful delivery
+func (s *WebhookService) MarkDelivered(id uuid.UUID, responseStatus int) error {
+	_, err := s.db.Exec(webhookDeliveryMarkDeliveredQuery, id, responseStatus)
+	return err
+}
+
+// RecordFailure counts a failed attempt. The delivery is retried at retryAt, or marked
+// failed if retryAt is nil. responseStatus is nil when no response was received.
+func (s *WebhookService) RecordFailure(id uuid.UUID, responseStatus *int, cause error, retryAt *time.Time) error {
+	_, err := s.db.Exec(webhookDeliveryRecordFailureQuery, id, responseStatus, cause.Error(), retryAt)
+	return err
+}
+
+// scanWebhook scans a single webhook row
+func scanWebhook(row rowScanner) (*Webhook, error) {
+	webhook := &Webhook{}
+	var eventTypesJSON string
+	err := row.Scan(
+		&webhook.ID, &webhook.URL, &webhook.Secret, &eventTypesJSON, &webhook.Description,
+		&webhook.IsActive, &webhook.CreatedBy, &webhook.CreatedAt, &webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := ParseJSONArray(eventTypesJSON, &webhook.EventTypes); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
@@ -0,0 +1,78 @@
+package models
+
+const (
+	webhookCreateQuery = `
+		INSERT INTO webhooks (id, url, secret, event_types, description, is_active, created_by)
+		VALUES ($1, $2, $3, $4::jsonb, $5, $6, $7)
+		RETURNING created_at, updated_at`
+
+	webhookGetByIDQuery = `
+		SELECT id, url, secret, event_types, description, is_active, created_by, created_at, updated_at
+		FROM webhooks WHERE id = $1`
+
+	webhookListQuery = `
+		SELECT id, url, secret, event_types, description, is_active, created_by, created_at, updated_at
+		FROM webhooks
+		ORDER BY created_at DESC`
+
+	webhookUpdateQuery = `
+		UPDATE webhooks
+		SET url = $2, event_types = $3::jsonb, description = $4, is_active = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	webhookRotateSecretQuery = `
+		UPDATE webhooks SET secret = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	webhookDeleteQuery = `DELETE FROM webhooks WHERE id = $1`
+
+	// One delivery per active webhook subscribed to the event type
+	webhookDeliveryEnqueueQuery = `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3::jsonb
+		FROM webhooks
+		WHERE is_active = TRUE AND event_types ? $2`
+
+	// Push claimed deliveries' next attempt out so another server instance polling at the
+	// same time skips them while this one is sending
+	webhookDeliveryClaimDueQuery = `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = CURRENT_TIMESTAMP + INTERVAL '5 minutes'
+		FROM webhooks w
+		WHERE d.webhook_id = w.id
+		AND d.id IN (
+			SELECT pd.id
+			FROM webhook_deliveries pd
+			JOIN webhooks pw ON pd.webhook_id = pw.id
+			WHERE pd.status = 'pending' AND pd.next_attempt_at <= CURRENT_TIMESTAMP AND pw.is_active = TRUE
+			ORDER BY pd.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF pd SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+		          d.response_status, d.last_error, d.next_attempt_at, d.delivered_at, d.created_at,
+		          d.updated_at, w.url, w.secret`
+
+	webhookDeliveryListByWebhookQuery = `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts,
+		       response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	webhookDeliveryMarkDeliveredQuery = `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, response_status = $2, last_error = NULL,
+		    delivered_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	// A NULL retry time means the delivery has run out of attempts
+	webhookDeliveryRecordFailureQuery = `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, response_status = $2, last_error = $3,
+		    status = CASE WHEN $4::timestamp IS NULL THEN 'failed' ELSE 'pending' END,
+		    next_attempt_at = COALESCE($4::timestamp, next_attempt_at),
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+)
@@ -0,0 +1,229 @@
+// Package fakesql provides an in-memory database/sql driver for tests. It records every
+// statement and whether it ran in a transaction, and returns canned rows or errors for
+// statements matching a substring, so code built on *sql.DB can be tested without a
+// database. It does not interpret SQL.
+package fakesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Statement is a statement run against a fake database
+type Statement struct {
+	Query string
+	Args  []driver.Value
+	InTx  bool
+}
+
+// rule is a canned outcome for statements containing match
+type rule struct {
+	match   string
+	err     error
+	columns []string
+	rows    [][]driver.Value
+	// affected overrides the rows an Exec reports when set
+	affected *int64
+	// once drops the rule after the first statement it matches
+	once bool
+}
+
+// Recorder records what ran against a fake database and holds its canned outcomes
+type Recorder struct {
+	mu         sync.Mutex
+	rules      []rule
+	statements []Statement
+	commits    int
+	rollbacks  int
+}
+
+// Open returns a fake database and the recorder for it. Statements without a matching
+// rule succeed: Exec affects one row and Query returns no rows.
+func Open() (*sql.DB, *Recorder) {
+	recorder := &Recorder{}
+	return sql.OpenDB(connector{recorder}), recorder
+}
+
+// Fail makes statements containing match fail with err
+func (r *Recorder) Fail(match string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule{match: match, err: err})
+}
+
+// Rows makes queries containing match return rows with the given columns
+func (r *Recorder) Rows(match string, columns []string, rows ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule{match: match, columns: columns, rows: rows})
+}
+
+// RowsOnce is Rows for only the next matching query. Later ones fall through to the
+// remaining rules, so paginated reads can be given a page followed by an empty one.
+func (r *Recorder) RowsOnce(match string, columns []string, rows ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule{match: match, columns: columns, rows: rows, once: true})
+}
+
+// Affects makes Execs of statements containing match report n affected rows
+func (r *Recorder) Affects(match string, n int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule{match: match, affected: &n})
+}
+
+// Statements returns the statements run so far, in order
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Statement(nil), r.statements...)
+}
+
+// Ran reports whether any statement containing match has run
+func (r *Recorder) Ran(match string) bool {
+	for _, statement := range r.Statements() {
+		if strings.Contains(statement.Query, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// Commits returns how many transactions were committed
+func (r *Recorder) Commits() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.commits
+}
+
+// Rollbacks returns how many transactions were rolled back
+func (r *Recorder) Rollbacks() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rollbacks
+}
+
+// run records a statement and returns the rule for it, if any
+func (r *Recorder) run(query string, args []driver.NamedValue, inTx bool) *rule {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, Statement{Query: query, Args: values, InTx: inTx})
+	for i := range r.rules {
+		if strings.Contains(query, r.rules[i].match) {
+			matched := r.rules[i]
+			if matched.once {
+				r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			}
+			return &matched
+		}
+	}
+	return nil
+}
+
+type connector struct{ recorder *Recorder }
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{recorder: c.recorder}, nil
+}
+
+func (c connector) Driver() driver.Driver { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, fmt.Errorf("fakesql: use fakesql.Open")
+}
+
+type conn struct {
+	recorder *Recorder
+	inTx     bool
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("fakesql: prepared statements are not supported")
+}
+
+func (c *conn) Close() error { return nil }
+
+func (c *conn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return &tx{conn: c}, nil
+}
+
+// CheckNamedValue passes arguments through, converting them where database/sql would
+func (c *conn) CheckNamedValue(arg *driver.NamedValue) error {
+	if value, err := driver.DefaultParameterConverter.ConvertValue(arg.Value); err == nil {
+		arg.Value = value
+	}
+	return nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	rule := c.recorder.run(query, args, c.inTx)
+	if rule != nil && rule.err != nil {
+		return nil, rule.err
+	}
+	if rule != nil && rule.affected != nil {
+		return driver.RowsAffected(*rule.affected), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rule := c.recorder.run(query, args, c.inTx)
+	if rule == nil {
+		return &rows{}, nil
+	}
+	if rule.err != nil {
+		return nil, rule.err
+	}
+	return &rows{columns: rule.columns, values: rule.rows}, nil
+}
+
+type tx struct{ conn *conn }
+
+func (t *tx) Commit() error {
+	t.conn.inTx = false
+	t.conn.recorder.mu.Lock()
+	defer t.conn.recorder.mu.Unlock()
+	t.conn.recorder.commits++
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.conn.inTx = false
+	t.conn.recorder.mu.Lock()
+	defer t.conn.recorder.mu.Unlock()
+	t.conn.recorder.rollbacks++
+	return nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+
+func (r *rows) Close() error { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
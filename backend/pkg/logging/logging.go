@@ -0,0 +1,119 @@
+// Package logging configures structured logging with log/slog and carries request
+// correlation IDs through contexts so log lines from one request can be traced together.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Log output formats
+const (
+	// FormatText writes human-readable key=value lines, for local development
+	FormatText = "text"
+	// FormatJSON writes one JSON object per line, for log aggregation
+	FormatJSON = "json"
+)
+
+// RequestIDHeader is the HTTP header carrying a request's correlation ID
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key for a request's correlation ID
+type requestIDKey struct{}
+
+// Setup makes a slog logger in the given format and level the default. Output from
+// the standard log package goes through it too, so existing log.Printf calls come out
+// in the same format. Unknown formats fall back to text and unknown levels to info.
+func Setup(format, level string) {
+	options := &slog.HandlerOptions{Level: parseLevel(level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(format, FormatJSON) {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, options)
+	}
+
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+	// slog adds its own timestamp
+	log.SetFlags(0)
+}
+
+// parseLevel parses a level name such as "debug" or "warn"
+func parseLevel(level string) slog.Level {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return parsed
+}
+
+// NewRequestID generates a correlation ID for a request
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether id is acceptable as a correlation ID passed in by a
+// caller. IDs are UUIDs so they can be stored in UUID audit columns.
+func ValidRequestID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// WithRequestID returns a context carrying a request's correlation ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the correlation ID carried by ctx, or "" if there isn't one
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Printf logs an informational message for the request in ctx
+func Printf(ctx context.Context, format string, args ...interface{}) {
+	slog.InfoContext(ctx, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a warning for the request in ctx
+func Warnf(ctx context.Context, format string, args ...interface{}) {
+	slog.WarnContext(ctx, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs an error for the request in ctx
+func Errorf(ctx context.Context, format string, args ...interface{}) {
+	slog.ErrorContext(ctx, fmt.Sprintf(format, args...))
+}
+
+// contextHandler adds the request ID carried by a record's context to the record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the request_id attribute before passing the record on
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps request IDs being added to loggers derived with With
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps request IDs being added to loggers derived with WithGroup
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -126,7 +126,7 @@ func (p *Parser) parseMigrations() ([]*dbagent.Migration, error) {
 			Description:     fmt.Sprintf("Migration %s: %s", version, name),
 			UpSql:           upSQL,
 			DownSql:         downSQL,
-			Dependencies:    []string{},
+			Dependencies:    parseDependencies(string(content)),
 			Checksum:        checksum,
 			ExecutionTimeMs: 0,
 		}
@@ -0,0 +1,223 @@
+package manifest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validation checks reported in a ValidationIssue
+const (
+	CheckFilename             = "filename"
+	CheckDuplicateVersion     = "duplicate_version"
+	CheckMissingDown          = "missing_down"
+	CheckUnresolvedDependency = "unresolved_dependency"
+	CheckVersionGap           = "version_gap"
+	CheckManifest             = "manifest"
+)
+
+// migrationFilenameRegex matches migration files named V###__description.sql
+var migrationFilenameRegex = regexp.MustCompile(`^V(\d+)__(.+)\.sql$`)
+
+// dependsRegex matches a "-- DEPENDS: V001, V002" header in a migration file
+var dependsRegex = regexp.MustCompile(`(?mi)^--\s*DEPENDS:\s*(.+)$`)
+
+// ValidationIssue is one problem found in a manifest
+type ValidationIssue struct {
+	Check   string `json:"check"`
+	File    string `json:"file,omitempty"`
+	Version string `json:"version,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport lists every problem found in a manifest directory
+type ValidationReport struct {
+	Path       string            `json:"path"`
+	Valid      bool              `json:"valid"`
+	Migrations int               `json:"migrations"`
+	Issues     []ValidationIssue `json:"issues"`
+}
+
+// addIssue records a problem and marks the report invalid
+func (r *ValidationReport) addIssue(check, file, version, message string) {
+	r.Issues = append(r.Issues, ValidationIssue{Check: check, File: file, Version: version, Message: message})
+	r.Valid = false
+}
+
+// scannedMigration is a migration file as seen by the validator
+type scannedMigration struct {
+	file         string
+	number       int
+	dependencies []string
+}
+
+// Validate checks a manifest directory offline, without contacting an agent. Unlike
+// ParseManifest, which stops at the first problem, it reports every badly named
+// migration, duplicate version, missing DOWN section, unresolved dependency and gap
+// in the version sequence. The returned error is only for failures to read the
+// directory; validation problems are in the report.
+func (p *Parser) Validate() (*ValidationReport, error) {
+	report := &ValidationReport{Path: p.manifestPath, Valid: true, Issues: []ValidationIssue{}}
+
+	if _, err := os.Stat(p.manifestPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("manifest directory does not exist: %s", p.manifestPath)
+	}
+
+	migrations, err := p.scanMigrations(report)
+	if err != nil {
+		return nil, err
+	}
+	report.Migrations = len(migrations)
+
+	// Index migrations by version number, reporting duplicates such as V1 and V001
+	byNumber := make(map[int]scannedMigration)
+	for _, migration := range migrations {
+		if existing, ok := byNumber[migration.number]; ok {
+			report.addIssue(CheckDuplicateVersion, migration.file, formatVersion(migration.number),
+				fmt.Sprintf("version %d is also used by %s", migration.number, existing.file))
+			continue
+		}
+		byNumber[migration.number] = migration
+	}
+
+	// Dependencies must name an earlier migration in the manifest
+	for _, migration := range migrations {
+		for _, dependency := range migration.dependencies {
+			number, ok := parseVersionNumber(dependency)
+			if !ok {
+				report.addIssue(CheckUnresolvedDependency, migration.file, formatVersion(migration.number),
+					fmt.Sprintf("dependency %q is not a migration version", dependency))
+				continue
+			}
+			if _, exists := byNumber[number]; !exists {
+				report.addIssue(CheckUnresolvedDependency, migration.file, formatVersion(migration.number),
+					fmt.Sprintf("depends on %s, which is not in the manifest", dependency))
+			} else if number >= migration.number {
+				report.addIssue(CheckUnresolvedDependency, migration.file, formatVersion(migration.number),
+					fmt.Sprintf("depends on %s, which does not run before it", dependency))
+			}
+		}
+	}
+
+	// Versions must run 1, 2, 3... without gaps
+	numbers := make([]int, 0, len(byNumber))
+	for number := range byNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	expected := 1
+	for _, number := range numbers {
+		if number > expected {
+			report.addIssue(CheckVersionGap, "", formatVersion(number),
+				fmt.Sprintf("%s missing before %s", describeRange(expected, number-1), formatVersion(number)))
+		}
+		expected = number + 1
+	}
+
+	// Seed data and metadata are only checked once the migrations parse cleanly,
+	// since ParseManifest stops at the first bad migration
+	if report.Valid {
+		manifest, err := p.ParseManifest()
+		if err == nil {
+			err = p.ValidateManifest(manifest)
+		}
+		if err != nil {
+			report.addIssue(CheckManifest, "", "", err.Error())
+		}
+	}
+
+	return report, nil
+}
+
+// scanMigrations reads the migrations directory, reporting files that are badly named
+// or lack a DOWN section
+func (p *Parser) scanMigrations(report *ValidationReport) ([]scannedMigration, error) {
+	migrationsDir := filepath.Join(p.manifestPath, "migrations")
+	if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var migrations []scannedMigration
+	err := filepath.WalkDir(migrationsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".sql") {
+			return nil
+		}
+
+		matches := migrationFilenameRegex.FindStringSubmatch(d.Name())
+		if matches == nil {
+			report.addIssue(CheckFilename, d.Name(), "", "expected V###__description.sql")
+			return nil
+		}
+		number, err := strconv.Atoi(matches[1])
+		if err != nil {
+			report.addIssue(CheckFilename, d.Name(), "", fmt.Sprintf("version %s is not a number", matches[1]))
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", d.Name(), err)
+		}
+
+		_, downSQL, err := p.parseMigrationSections(string(content))
+		if err != nil || downSQL == "" {
+			report.addIssue(CheckMissingDown, d.Name(), formatVersion(number), "migration has no -- DOWN section to roll it back")
+		}
+
+		migrations = append(migrations, scannedMigration{
+			file:         d.Name(),
+			number:       number,
+			dependencies: parseDependencies(string(content)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].number < migrations[j].number
+	})
+	return migrations, nil
+}
+
+// parseDependencies reads the versions listed in a migration's "-- DEPENDS:" headers
+func parseDependencies(content string) []string {
+	dependencies := []string{}
+	for _, match := range dependsRegex.FindAllStringSubmatch(content, -1) {
+		for _, dependency := range strings.Split(match[1], ",") {
+			if dependency = strings.TrimSpace(dependency); dependency != "" {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	return dependencies
+}
+
+// parseVersionNumber parses a version such as "V003" or "3"
+func parseVersionNumber(version string) (int, bool) {
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(version), "V"))
+	return number, err == nil
+}
+
+// formatVersion formats a version number the way migration files are named
+func formatVersion(number int) string {
+	return fmt.Sprintf("V%03d", number)
+}
+
+// describeRange describes an inclusive range of versions as the subject of a sentence,
+// e.g. "versions V002-V004 are"
+func describeRange(from, to int) string {
+	if from == to {
+		return "version " + formatVersion(from) + " is"
+	}
+	return fmt.Sprintf("versions %s-%s are", formatVersion(from), formatVersion(to))
+}
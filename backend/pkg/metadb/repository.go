@@ -402,9 +402,15 @@ func (r *Repository) LogAuditEntry(entry *AuditLog) error {
 		deploymentID = entry.DeploymentID
 	}
 
+	// request_id is a UUID column; calls without a correlation ID store NULL
+	var requestID *string
+	if entry.RequestID != "" {
+		requestID = &entry.RequestID
+	}
+
 	_, err := r.db.Exec(query,
 		databaseID, deploymentID, entry.Action, entry.UserAgent, entry.ClientIP,
-		entry.RequestID, entry.StatusCode, entry.ErrorMessage, entry.ExecutionTimeMs,
+		requestID, entry.StatusCode, entry.ErrorMessage, entry.ExecutionTimeMs,
 		entry.RequestSizeBytes, entry.ResponseSizeBytes, entry.Metadata,
 	)
 	if err != nil {
@@ -0,0 +1,171 @@
+// Package metrics defines the Prometheus metrics exported by the API server and the
+// matching worker. Labels are kept to bounded sets: routes are recorded by their
+// template (/api/projects/:id), never the raw path.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "civicweave"
+
+// unmatchedRoute labels requests that didn't match a registered route, so probes of
+// arbitrary paths can't create new label values
+const unmatchedRoute = "unmatched"
+
+// Registry holds the application's metrics along with Go runtime and process metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	matchingRunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "matching_run_duration_seconds",
+		Help:      "Duration of full match calculation runs, by trigger and outcome.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200},
+	}, []string{"trigger", "outcome"})
+
+	emailsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Email send attempts, by kind (single or batch) and result.",
+	}, []string{"kind", "result"})
+
+	embeddingRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "embedding_requests_total",
+		Help:      "Embedding API requests after retries, by result (success, failure or circuit_open).",
+	}, []string{"result"})
+
+	pendingEmbeddingsQueuedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pending_embeddings_queued_total",
+		Help:      "Skill claims queued for later embedding because the embedding API was unavailable.",
+	})
+
+	geocodeCacheLookupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "geocode_cache_lookups_total",
+		Help:      "Geocoding cache lookups, by result (hit or miss).",
+	}, []string{"result"})
+
+	webhookDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts, by event type and result.",
+	}, []string{"event_type", "result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		matchingRunDuration,
+		emailsSentTotal,
+		embeddingRequestsTotal,
+		pendingEmbeddingsQueuedTotal,
+		geocodeCacheLookupsTotal,
+		webhookDeliveriesTotal,
+	)
+}
+
+// RegisterDBStats exports connection pool statistics for db, such as connections open
+// and in use against the configured maximum and time spent waiting for a connection
+func RegisterDBStats(db *sql.DB) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records the count and latency of each request by route template
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveMatchingRun records how long a full match calculation took. trigger is what
+// started it, such as "periodic" or "requested".
+func ObserveMatchingRun(trigger string, duration time.Duration, err error) {
+	matchingRunDuration.WithLabelValues(trigger, outcome(err)).Observe(duration.Seconds())
+}
+
+// ObserveEmailSend counts an email send attempt. kind is "single" or "batch".
+func ObserveEmailSend(kind string, err error) {
+	emailsSentTotal.WithLabelValues(kind, outcome(err)).Inc()
+}
+
+// Results of an embedding request
+const (
+	EmbeddingSucceeded   = "success"
+	EmbeddingFailed      = "failure"
+	EmbeddingCircuitOpen = "circuit_open"
+)
+
+// ObserveEmbeddingRequest counts an embedding request by its result, one of the
+// Embedding* constants
+func ObserveEmbeddingRequest(result string) {
+	embeddingRequestsTotal.WithLabelValues(result).Inc()
+}
+
+// ObservePendingEmbeddingQueued counts a skill claim queued for later embedding
+func ObservePendingEmbeddingQueued() {
+	pendingEmbeddingsQueuedTotal.Inc()
+}
+
+// ObserveGeocodeCacheLookup counts a geocoding cache lookup as a hit or a miss
+func ObserveGeocodeCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	geocodeCacheLookupsTotal.WithLabelValues(result).Inc()
+}
+
+// ObserveWebhookDelivery counts a webhook delivery attempt for eventType
+func ObserveWebhookDelivery(eventType string, err error) {
+	webhookDeliveriesTotal.WithLabelValues(eventType, outcome(err)).Inc()
+}
+
+// outcome labels the result of an operation
+func outcome(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
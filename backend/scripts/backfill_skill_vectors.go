@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -41,15 +43,19 @@ func main() {
 	log.Println("✅ Connected to database successfully")
 
 	// Initialize services
-	embeddingService := services.NewEmbeddingService(cfg.OpenAI.APIKey, cfg.OpenAI.EmbeddingModel)
+	embedder, err := services.NewEmbedder(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to configure embeddings: %v", err)
+	}
+	embeddingService := services.NewEmbeddingService(embedder)
 	skillClaimService := models.NewSkillClaimService(db)
 	vectorAggregationService := services.NewVectorAggregationService(db, skillClaimService)
 
 	log.Println("✅ Services initialized")
 
-	// Check if we have OpenAI API key
-	if cfg.OpenAI.APIKey == "" {
-		log.Fatalf("❌ OpenAI API key is required for backfill script")
+	// Check that an embedding provider is configured
+	if !embeddingService.IsConfigured() {
+		log.Fatalf("❌ An embedding provider must be configured for backfill script")
 	}
 
 	// Run the backfill process
@@ -152,7 +158,7 @@ func backfillSkillVectors(
 
 			// Create skill claim
 			claimText := strings.TrimSpace(skill)
-			_, err = skillClaimService.CreateSkillClaim(volunteerID, claimText, embedding)
+			_, err = skillClaimService.CreateSkillClaim(volunteerID, claimText, embedding, embeddingService.GetEmbeddingModel())
 			if err != nil {
 				log.Printf("❌ Failed to create skill claim for '%s' (volunteer %s): %v", skill, name, err)
 				errorCount++
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"civicweave/backend/models"
+)
+
+// availabilityFloor is the share of a match score kept when a volunteer's availability
+// doesn't overlap the project at all, so they're ranked lower rather than hidden
+const availabilityFloor = 0.5
+
+// AvailabilityFactor describes the availability factor of a match
+type AvailabilityFactor struct {
+	Applied bool    `json:"applied"`
+	Score   float64 `json:"score"` // 0-1 overlap with the project's dates; 1 when not applied
+	Note    string  `json:"note,omitempty"`
+}
+
+// CalculateAvailability scores how well a volunteer's availability overlaps a project's
+// start and end dates. Volunteers with no availability set, and projects without
+// dates, score 1 so matching behaves as it did before availability was recorded.
+//
+// A weekly slot counts as full overlap when its weekday falls within the project. Date
+// ranges score the share of the project's days they cover, or 1 if the project is
+// open-ended and any range reaches it.
+func CalculateAvailability(slots []models.VolunteerAvailability, startDate, endDate *time.Time) AvailabilityFactor {
+	if len(slots) == 0 {
+		return AvailabilityFactor{Score: 1, Note: "No availability set; treated as always available"}
+	}
+	if startDate == nil && endDate == nil {
+		return AvailabilityFactor{Score: 1, Note: "Project has no dates to compare availability against"}
+	}
+
+	from, to := startDate, endDate
+	if from == nil {
+		from = endDate
+	}
+	first := truncateToDay(*from)
+
+	// Weekly slots recur, so any weekday inside the project window is a full match
+	var weekdays [7]bool
+	for _, slot := range slots {
+		if slot.Kind == models.AvailabilityWeekly && slot.DayOfWeek != nil {
+			weekdays[*slot.DayOfWeek] = true
+		}
+	}
+	for day, i := first, 0; i < 7; day, i = day.AddDate(0, 0, 1), i+1 {
+		if to != nil && day.After(truncateToDay(*to)) {
+			break
+		}
+		if weekdays[day.Weekday()] {
+			return AvailabilityFactor{Applied: true, Score: 1, Note: "Weekly availability falls within the project dates"}
+		}
+	}
+
+	if to == nil {
+		for _, slot := range slots {
+			if slot.Kind == models.AvailabilityDateRange && !truncateToDay(*slot.EndDate).Before(first) {
+				return AvailabilityFactor{Applied: true, Score: 1, Note: "Available from the project's start"}
+			}
+		}
+		return AvailabilityFactor{Applied: true, Score: 0, Note: "Not available after the project starts"}
+	}
+
+	last := truncateToDay(*to)
+	if last.Before(first) {
+		last = first
+	}
+	score := dateRangeCoverage(slots, first, last)
+	factor := AvailabilityFactor{Applied: true, Score: score}
+	switch {
+	case score == 0:
+		factor.Note = "Not available during the project dates"
+	case score < 1:
+		factor.Note = "Available for part of the project dates"
+	default:
+		factor.Note = "Available for all of the project dates"
+	}
+	return factor
+}
+
+// applyAvailability folds an availability factor into a match result's ranking score
+func applyAvailability(result *SkillMatchResult, factor AvailabilityFactor) {
+	result.AvailabilityScore = factor.Score
+	result.MatchScore = baseMatchScore(*result) * (availabilityFloor + (1-availabilityFloor)*factor.Score)
+}
+
+// dateRangeCoverage returns the share of days from first to last, inclusive, covered by
+// the date range slots
+func dateRangeCoverage(slots []models.VolunteerAvailability, first, last time.Time) float64 {
+	type span struct{ start, end time.Time }
+	var spans []span
+	for _, slot := range slots {
+		if slot.Kind != models.AvailabilityDateRange || slot.StartDate == nil || slot.EndDate == nil {
+			continue
+		}
+		start, end := truncateToDay(*slot.StartDate), truncateToDay(*slot.EndDate)
+		if start.Before(first) {
+			start = first
+		}
+		if end.After(last) {
+			end = last
+		}
+		if !end.Before(start) {
+			spans = append(spans, span{start, end})
+		}
+	}
+	if len(spans) == 0 {
+		return 0
+	}
+
+	// Merge overlapping ranges so shared days aren't counted twice
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	covered := 0
+	current := spans[0]
+	for _, next := range spans[1:] {
+		if !next.start.After(current.end.AddDate(0, 0, 1)) {
+			if next.end.After(current.end) {
+				current.end = next.end
+			}
+			continue
+		}
+		covered += daysBetween(current.start, current.end) + 1
+		current = next
+	}
+	covered += daysBetween(current.start, current.end) + 1
+
+	return float64(covered) / float64(daysBetween(first, last)+1)
+}
+
+// truncateToDay returns midnight UTC on t's calendar day
+func truncateToDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns the whole days from a to b, both at midnight UTC
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
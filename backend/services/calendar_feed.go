@@ -0,0 +1,182 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCalendarFeedToken is returned for malformed or forged calendar feed tokens
+var ErrInvalidCalendarFeedToken = fmt.Errorf("invalid calendar feed token")
+
+// calendarLineLimit is the longest an iCalendar content line may be, in octets, before
+// it has to be folded (RFC 5545 section 3.1)
+const calendarLineLimit = 75
+
+// SignCalendarFeedToken returns the token calendar clients pass as ?token= to fetch a
+// user's project feeds, "<user id>.<version>.<hex HMAC-SHA256>". Calendar apps can't
+// send a JWT, so the token itself authenticates the user; bumping the user's feed
+// version revokes every token signed before.
+func SignCalendarFeedToken(secret string, userID uuid.UUID, version int) string {
+	payload := userID.String() + "." + strconv.Itoa(version)
+	return payload + "." + calendarFeedSignature(secret, payload)
+}
+
+// ParseCalendarFeedToken verifies a calendar feed token and returns the user and feed
+// version it was signed for. The caller still has to check the version is current.
+func ParseCalendarFeedToken(secret, token string) (uuid.UUID, int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, 0, ErrInvalidCalendarFeedToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(calendarFeedSignature(secret, payload))) {
+		return uuid.Nil, 0, ErrInvalidCalendarFeedToken
+	}
+
+	userID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, 0, ErrInvalidCalendarFeedToken
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return uuid.Nil, 0, ErrInvalidCalendarFeedToken
+	}
+	return userID, version, nil
+}
+
+// calendarFeedSignature signs payload with a key scoped to calendar feeds, so a feed
+// signature can never be replayed as any other HMAC made with the same secret
+func calendarFeedSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("calendar-feed:"))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BuildProjectCalendar renders a project's timeline as an iCalendar feed: the project's
+// start and end dates as one all-day event, and each task's due date as an event.
+// Project dates have no time of day, so they're always all-day. Task due dates at
+// midnight UTC are treated as date-only and rendered all-day too; any other due time
+// is rendered in UTC so calendar apps show it in the viewer's own timezone.
+func BuildProjectCalendar(project *models.Project, tasks []models.ProjectTask, now time.Time) []byte {
+	var b strings.Builder
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeCalendarLine(&b, "BEGIN:VCALENDAR")
+	writeCalendarLine(&b, "VERSION:2.0")
+	writeCalendarLine(&b, "PRODID:-//CivicWeave//Project Calendar//EN")
+	writeCalendarLine(&b, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&b, "METHOD:PUBLISH")
+	writeCalendarLine(&b, "X-WR-CALNAME:"+escapeCalendarText(project.Title))
+
+	if start, end := projectCalendarDates(project); start != nil {
+		writeCalendarLine(&b, "BEGIN:VEVENT")
+		writeCalendarLine(&b, "UID:project-"+project.ID.String()+"@civicweave")
+		writeCalendarLine(&b, "DTSTAMP:"+stamp)
+		writeCalendarLine(&b, "DTSTART;VALUE=DATE:"+start.Format("20060102"))
+		// DTEND is exclusive for all-day events, so the event covers the end date itself
+		writeCalendarLine(&b, "DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format("20060102"))
+		writeCalendarLine(&b, "SUMMARY:"+escapeCalendarText(project.Title))
+		if project.Description != "" {
+			writeCalendarLine(&b, "DESCRIPTION:"+escapeCalendarText(project.Description))
+		}
+		if project.LocationAddress != "" {
+			writeCalendarLine(&b, "LOCATION:"+escapeCalendarText(project.LocationAddress))
+		}
+		writeCalendarLine(&b, "LAST-MODIFIED:"+project.UpdatedAt.UTC().Format("20060102T150405Z"))
+		writeCalendarLine(&b, "END:VEVENT")
+	}
+
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		due := task.DueDate.UTC()
+
+		writeCalendarLine(&b, "BEGIN:VEVENT")
+		writeCalendarLine(&b, "UID:task-"+task.ID.String()+"@civicweave")
+		writeCalendarLine(&b, "DTSTAMP:"+stamp)
+		if isDateOnly(due) {
+			writeCalendarLine(&b, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+		} else {
+			writeCalendarLine(&b, "DTSTART:"+due.Format("20060102T150405Z"))
+		}
+		writeCalendarLine(&b, "SUMMARY:"+escapeCalendarText("Due: "+task.Title))
+
+		description := fmt.Sprintf("Status: %s\nPriority: %s", task.Status, task.Priority)
+		if task.Description != "" {
+			description = task.Description + "\n\n" + description
+		}
+		writeCalendarLine(&b, "DESCRIPTION:"+escapeCalendarText(description))
+		writeCalendarLine(&b, "LAST-MODIFIED:"+task.UpdatedAt.UTC().Format("20060102T150405Z"))
+		writeCalendarLine(&b, "END:VEVENT")
+	}
+
+	writeCalendarLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// projectCalendarDates returns the first and last day of a project, in UTC. A project
+// with only one of its dates set spans that single day; one with neither returns nil.
+func projectCalendarDates(project *models.Project) (*time.Time, *time.Time) {
+	start, end := project.StartDate, project.EndDate
+	if start == nil {
+		start = end
+	}
+	if end == nil || end.Before(*start) {
+		end = start
+	}
+	if start == nil {
+		return nil, nil
+	}
+	first, last := truncateToDay(start.UTC()), truncateToDay(end.UTC())
+	return &first, &last
+}
+
+// isDateOnly reports whether t has no time of day
+func isDateOnly(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// escapeCalendarText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeCalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// writeCalendarLine writes one content line terminated by CRLF, folding it onto
+// continuation lines so none exceeds calendarLineLimit octets. Multi-byte characters
+// are never split across lines.
+func writeCalendarLine(b *strings.Builder, line string) {
+	limit := calendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = calendarLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+// defaultCampaignBatchSize is used when no batch size is configured
+const defaultCampaignBatchSize = 500
+
+const (
+	// campaignSendingStaleAfter is how long a campaign can be sending without finishing
+	// a batch before it's assumed abandoned. Each batch is a single Mailgun request.
+	campaignSendingStaleAfter = 10 * time.Minute
+	// campaignSweepInterval is how often Run looks for abandoned sends
+	campaignSweepInterval = 5 * time.Minute
+)
+
+// CampaignSender delivers a campaign to its pending recipients through Mailgun,
+// recording the outcome for each recipient
+type CampaignSender struct {
+	campaignService *models.CampaignService
+	emailService    *EmailService
+	batchSize       int
+	trackingBaseURL string
+}
+
+// NewCampaignSender creates a new campaign sender. batchSize is how many
+// recipients share one Mailgun request; 0 or less uses the default.
+// trackingBaseURL is the public API address for open and click tracking.
+func NewCampaignSender(campaignService *models.CampaignService, emailService *EmailService, batchSize int, trackingBaseURL string) *CampaignSender {
+	if batchSize <= 0 {
+		batchSize = defaultCampaignBatchSize
+	}
+	return &CampaignSender{
+		campaignService: campaignService,
+		emailService:    emailService,
+		batchSize:       batchSize,
+		trackingBaseURL: trackingBaseURL,
+	}
+}
+
+// Send emails a campaign that is already in the sending state to each of its
+// pending recipients in batches, using the subject line of each recipient's
+// variant. A failed batch marks only its own recipients as failed. The campaign
+// ends up sent if anyone was reached, otherwise failed.
+func (s *CampaignSender) Send(campaign *models.Campaign) (sent, failed int, err error) {
+	deliveries, err := s.campaignService.GetPendingDeliveries(campaign.ID)
+	if err != nil {
+		s.finish(campaign.ID, models.CampaignStatusFailed)
+		return 0, 0, fmt.Errorf("failed to get campaign recipients: %w", err)
+	}
+
+	htmlContent, textContent := s.campaignService.TrackedEmailContent(campaign, s.trackingBaseURL)
+	unsubscribeLink := models.CampaignUnsubscribeLink(s.trackingBaseURL)
+	htmlBody, textBody := s.emailService.CampaignEmailContent(htmlContent, textContent, unsubscribeLink)
+	headers := campaignListUnsubscribeHeaders(unsubscribeLink)
+
+	// A batch shares one subject, so each variant is batched separately
+	byVariant := make(map[models.CampaignVariant][]models.CampaignDelivery)
+	for _, delivery := range deliveries {
+		byVariant[delivery.Variant] = append(byVariant[delivery.Variant], delivery)
+	}
+
+	for _, variant := range []models.CampaignVariant{models.CampaignVariantA, models.CampaignVariantB} {
+		variantDeliveries := byVariant[variant]
+		subject := campaign.SubjectFor(variant)
+
+		for start := 0; start < len(variantDeliveries); start += s.batchSize {
+			end := start + s.batchSize
+			if end > len(variantDeliveries) {
+				end = len(variantDeliveries)
+			}
+			batchSent := s.sendBatch(campaign.ID, variantDeliveries[start:end], subject, htmlBody, textBody, headers)
+			if batchSent {
+				sent += end - start
+			} else {
+				failed += end - start
+			}
+		}
+	}
+
+	status := models.CampaignStatusSent
+	if sent == 0 && failed > 0 {
+		status = models.CampaignStatusFailed
+	}
+	s.finish(campaign.ID, status)
+
+	log.Printf("📧 CAMPAIGN_SENDER: Campaign %s %s: %d sent, %d failed", campaign.ID, status, sent, failed)
+	return sent, failed, nil
+}
+
+// sendBatch sends one Mailgun request and records the outcome for each of its
+// recipients. It reports whether the batch was accepted.
+func (s *CampaignSender) sendBatch(campaignID uuid.UUID, batch []models.CampaignDelivery, subject, htmlBody, textBody string, headers map[string]string) bool {
+	recipientIDs := make([]uuid.UUID, len(batch))
+	recipientVariables := make(map[string]map[string]string, len(batch))
+	for i, delivery := range batch {
+		recipientIDs[i] = delivery.RecipientID
+		recipientVariables[delivery.Email] = map[string]string{
+			models.CampaignTrackingTokenVariable: delivery.TrackingToken,
+		}
+	}
+
+	if sendErr := s.emailService.SendBatchEmail(recipientVariables, subject, htmlBody, textBody, headers); sendErr != nil {
+		log.Printf("❌ CAMPAIGN_SENDER: Batch of %d for campaign %s failed: %v", len(batch), campaignID, sendErr)
+		if err := s.campaignService.MarkRecipientsFailed(recipientIDs, sendErr.Error()); err != nil {
+			log.Printf("❌ CAMPAIGN_SENDER: Failed to record failed recipients for campaign %s: %v", campaignID, err)
+		}
+		return false
+	}
+
+	if err := s.campaignService.MarkRecipientsSent(recipientIDs); err != nil {
+		log.Printf("❌ CAMPAIGN_SENDER: Failed to record sent recipients for campaign %s: %v", campaignID, err)
+	}
+	if err := s.campaignService.TouchSending(campaignID); err != nil {
+		log.Printf("❌ CAMPAIGN_SENDER: Failed to record progress for campaign %s: %v", campaignID, err)
+	}
+	return true
+}
+
+// Run resumes abandoned sends at startup and then periodically until ctx is cancelled
+func (s *CampaignSender) Run(ctx context.Context) {
+	s.resumeStale()
+
+	ticker := time.NewTicker(campaignSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.resumeStale()
+		}
+	}
+}
+
+// resumeStale picks up campaigns whose send was interrupted. Recipients already sent
+// keep their status, so only the pending ones are sent; a campaign interrupted before
+// its recipients were recorded is marked failed, as nobody was reached.
+func (s *CampaignSender) resumeStale() {
+	campaigns, err := s.campaignService.ClaimStaleSending(time.Now().Add(-campaignSendingStaleAfter))
+	if err != nil {
+		log.Printf("❌ CAMPAIGN_SENDER: Failed to find interrupted campaigns: %v", err)
+		return
+	}
+
+	for i := range campaigns {
+		campaign := &campaigns[i]
+		recipients, err := s.campaignService.CountRecipients(campaign.ID)
+		if err != nil {
+			log.Printf("❌ CAMPAIGN_SENDER: Failed to count recipients for campaign %s: %v", campaign.ID, err)
+			continue
+		}
+		if recipients == 0 {
+			log.Printf("⚠️  CAMPAIGN_SENDER: Campaign %s was interrupted before recording recipients, marking failed", campaign.ID)
+			s.finish(campaign.ID, models.CampaignStatusFailed)
+			continue
+		}
+
+		log.Printf("🔄 CAMPAIGN_SENDER: Resuming interrupted campaign %s", campaign.ID)
+		if _, _, err := s.Send(campaign); err != nil {
+			log.Printf("❌ CAMPAIGN_SENDER: Failed to resume campaign %s: %v", campaign.ID, err)
+		}
+	}
+}
+
+// campaignListUnsubscribeHeaders returns the headers that let mail clients offer their
+// own unsubscribe button. List-Unsubscribe-Post marks the link as one-click (RFC 8058):
+// clients POST to it, which unsubscribes without the confirmation page.
+func campaignListUnsubscribeHeaders(unsubscribeLink string) map[string]string {
+	return map[string]string{
+		"List-Unsubscribe":      "<" + unsubscribeLink + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+}
+
+// finish records the campaign's final status
+func (s *CampaignSender) finish(campaignID uuid.UUID, status models.CampaignStatus) {
+	if err := s.campaignService.FinishSending(campaignID, status); err != nil {
+		log.Printf("❌ CAMPAIGN_SENDER: Failed to mark campaign %s as %s: %v", campaignID, status, err)
+	}
+}
@@ -0,0 +1,15 @@
+package services
+
+import "testing"
+
+func TestCampaignListUnsubscribeHeaders(t *testing.T) {
+	link := "https://api.example.org/api/campaigns/unsubscribe/%recipient.tracking_token%"
+	headers := campaignListUnsubscribeHeaders(link)
+
+	if got, want := headers["List-Unsubscribe"], "<"+link+">"; got != want {
+		t.Errorf("List-Unsubscribe = %q, want %q", got, want)
+	}
+	if got, want := headers["List-Unsubscribe-Post"], "List-Unsubscribe=One-Click"; got != want {
+		t.Errorf("List-Unsubscribe-Post = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+
+	"civicweave/backend/models"
+)
+
+// loadSkillParents returns the taxonomy's category links when category credit is
+// enabled, and nil otherwise so matching stays exact-only without the extra query
+func (s *SkillMatchingService) loadSkillParents() (map[int]int, error) {
+	if s.categoryCredit <= 0 {
+		return nil, nil
+	}
+	parents, err := models.NewSkillTaxonomyService(s.db).GetSkillParents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill categories: %w", err)
+	}
+	return parents, nil
+}
+
+// applyCategoryCredit gives partial credit for required skills the volunteer lacks but
+// has a related skill for: one in the same category (a volunteer with "Vue" for a
+// project wanting "React" under "Frontend Frameworks"), the category itself, or, when
+// the required skill is a category, a skill nested under it. Each such skill counts
+// as the category credit times the related skill's weight in the coverage and
+// euclidean scores. A volunteer with no exact matches is ranked by the category score
+// instead of cosine. It must run before applyAvailability, and returns the credited
+// weight per skill.
+func (s *SkillMatchingService) applyCategoryCredit(result *SkillMatchResult, volunteerSkills []VolunteerSkill, parents map[int]int) map[int]float64 {
+	if s.categoryCredit <= 0 || len(parents) == 0 || len(result.MissingSkillIDs) == 0 {
+		return nil
+	}
+
+	// Best weight the volunteer has in each category, counting the category skill itself
+	categoryWeights := make(map[int]float64)
+	for _, vs := range volunteerSkills {
+		for _, categoryID := range []int{vs.SkillID, parents[vs.SkillID]} {
+			if categoryID != 0 && vs.Weight > categoryWeights[categoryID] {
+				categoryWeights[categoryID] = vs.Weight
+			}
+		}
+	}
+
+	credited := make(map[int]float64)
+	for _, skillID := range result.MissingSkillIDs {
+		weight := categoryWeights[skillID]
+		if categoryID, ok := parents[skillID]; ok && categoryWeights[categoryID] > weight {
+			weight = categoryWeights[categoryID]
+		}
+		if weight > 0 {
+			credited[skillID] = s.categoryCredit * weight
+			result.PartialSkillIDs = append(result.PartialSkillIDs, skillID)
+		}
+	}
+	if len(credited) == 0 {
+		return nil
+	}
+
+	// Rebuild the project-dimension weights with the partial credit filled in
+	vMap := make(map[int]float64, len(volunteerSkills))
+	for _, vs := range volunteerSkills {
+		vMap[vs.SkillID] = vs.Weight
+	}
+
+	var vWeights []float64
+	categorySum := 0.0
+	for _, id := range result.MatchedSkillIDs {
+		vWeights = append(vWeights, vMap[id])
+	}
+	for _, id := range result.MissingSkillIDs {
+		vWeights = append(vWeights, credited[id])
+		categorySum += credited[id]
+	}
+
+	normP := float64(result.TotalRequired)
+	result.EuclideanScore = s.calculateEuclideanSimilarity(vWeights, normP)
+	result.CoverageScore = s.calculateCoverageScore(vWeights, normP)
+	result.CategoryScore = categorySum / normP
+	result.MatchScore = baseMatchScore(*result)
+	return credited
+}
+
+// baseMatchScore is the skill score a match is ranked by before availability: cosine
+// over the exact matches, or the category score when there are none
+func baseMatchScore(result SkillMatchResult) float64 {
+	if result.MatchedSkillCount == 0 {
+		return result.CategoryScore
+	}
+	return result.CosineScore
+}
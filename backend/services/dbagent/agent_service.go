@@ -2,19 +2,30 @@ package dbagent
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"civicweave/backend/pkg/metadb"
 	"civicweave/backend/proto/dbagent"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Database creation errors
+var (
+	ErrDatabaseExists       = errors.New("database already exists")
+	ErrCreateDatabaseDenied = errors.New("insufficient privileges to create database")
+)
+
 // AgentService implements the gRPC DatabaseAgent service
 type AgentService struct {
 	metaRepo    *metadb.Repository
@@ -79,7 +90,7 @@ func (s *AgentService) CompareManifest(ctx context.Context, req *dbagent.Compare
 	}
 
 	// Compare with manifest
-	comparison, err := s.compareSchemaWithManifest(currentSchema, req.Manifest, req.IncludeDataDiff)
+	comparison, err := s.compareSchemaWithManifest(database.ConnectionStringEnc, currentSchema, req.Manifest, req.IncludeDataDiff)
 	if err != nil {
 		executionTime := int(time.Since(startTime).Milliseconds())
 		s.auditLogger.LogRequest(ctx, "compare", &database.ID, nil, 500, err.Error(), executionTime, 0, 0, nil)
@@ -160,7 +171,7 @@ func (s *AgentService) DeployManifest(ctx context.Context, req *dbagent.DeployMa
 		database.ID,
 		req.Manifest.Version,
 		req.Manifest.Version,
-		"system", // TODO: Get from auth context
+		clientIDFromContext(ctx),
 		s.calculateManifestChecksum(req.Manifest),
 		req.DryRun,
 	)
@@ -242,16 +253,24 @@ func (s *AgentService) GetDeploymentHistory(ctx context.Context, req *dbagent.De
 func (s *AgentService) Bootstrap(ctx context.Context, req *dbagent.BootstrapRequest) (*dbagent.BootstrapResponse, error) {
 	startTime := time.Now()
 
+	// A connection string without a database name refers to the one being bootstrapped
+	connectionString, err := targetConnectionString(req.ConnectionString, req.DatabaseName)
+	if err != nil {
+		executionTime := int(time.Since(startTime).Milliseconds())
+		s.auditLogger.LogRequest(ctx, "bootstrap", nil, nil, 400, err.Error(), executionTime, 0, 0, nil)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	// Register the database if it doesn't exist
 	database, err := s.metaRepo.GetDatabase(req.DatabaseName)
 	if err != nil {
 		// Database doesn't exist, register it
 		database, err = s.metaRepo.RegisterDatabase(
 			req.DatabaseName,
-			req.ConnectionString,
+			connectionString,
 			"Bootstrap database",
 			"production",
-			"system", // TODO: Get from auth context
+			clientIDFromContext(ctx),
 			[]string{"bootstrap"},
 		)
 		if err != nil {
@@ -261,18 +280,27 @@ func (s *AgentService) Bootstrap(ctx context.Context, req *dbagent.BootstrapRequ
 		}
 	}
 
-	// Create database if requested
+	// Create database if requested. Bootstrapping is for new databases, so an existing
+	// one is reported rather than deployed over; callers can retry without CreateDatabase.
 	if req.CreateDatabase {
-		err = s.createDatabase(req.ConnectionString, req.DatabaseName)
+		err = s.createDatabase(connectionString)
 		if err != nil {
 			executionTime := int(time.Since(startTime).Milliseconds())
+			switch {
+			case errors.Is(err, ErrDatabaseExists):
+				s.auditLogger.LogRequest(ctx, "bootstrap", &database.ID, nil, 409, err.Error(), executionTime, 0, 0, nil)
+				return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+			case errors.Is(err, ErrCreateDatabaseDenied):
+				s.auditLogger.LogRequest(ctx, "bootstrap", &database.ID, nil, 403, err.Error(), executionTime, 0, 0, nil)
+				return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+			}
 			s.auditLogger.LogRequest(ctx, "bootstrap", &database.ID, nil, 500, err.Error(), executionTime, 0, 0, nil)
 			return nil, status.Errorf(codes.Internal, "failed to create database: %v", err)
 		}
 	}
 
 	// Connect to the target database
-	targetDB, err := sql.Open("postgres", req.ConnectionString)
+	targetDB, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		executionTime := int(time.Since(startTime).Milliseconds())
 		s.auditLogger.LogRequest(ctx, "bootstrap", &database.ID, nil, 500, err.Error(), executionTime, 0, 0, nil)
@@ -305,62 +333,34 @@ func (s *AgentService) Bootstrap(ctx context.Context, req *dbagent.BootstrapRequ
 
 // Helper methods
 
-// getCurrentSchemaState extracts the current schema state from a database
-func (s *AgentService) getCurrentSchemaState(db *sql.DB) (map[string]interface{}, error) {
-	// This is a simplified implementation
-	// In a real implementation, you would query the database schema
-	// and return a comprehensive schema representation
-
-	schema := make(map[string]interface{})
+// getCurrentSchemaState extracts a normalized snapshot of the live schema
+func (s *AgentService) getCurrentSchemaState(db *sql.DB) (*schemaSnapshot, error) {
+	return introspectSchema(db, liveSchemaName)
+}
 
-	// Get tables
-	tables := make([]string, 0)
-	rows, err := db.Query(`
-		SELECT table_name 
-		FROM information_schema.tables 
-		WHERE table_schema = 'public' 
-		ORDER BY table_name
-	`)
+// compareSchemaWithManifest compares the live schema with the schema the manifest's
+// migrations produce in a scratch database. Data differences are not compared yet.
+func (s *AgentService) compareSchemaWithManifest(connectionString string, currentSchema *schemaSnapshot, manifest *dbagent.Manifest, includeDataDiff bool) (*dbagent.CompareManifestResponse, error) {
+	expectedSchema, err := expectedSchemaFromManifest(connectionString, manifest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tables: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
-			return nil, fmt.Errorf("failed to scan table name: %w", err)
-		}
-		tables = append(tables, tableName)
+		return nil, err
 	}
 
-	schema["tables"] = tables
-
-	return schema, nil
-}
-
-// compareSchemaWithManifest compares current schema with manifest
-func (s *AgentService) compareSchemaWithManifest(currentSchema map[string]interface{}, manifest *dbagent.Manifest, includeDataDiff bool) (*dbagent.CompareManifestResponse, error) {
-	// This is a simplified implementation
-	// In a real implementation, you would perform detailed schema comparison
+	diff := diffSchemas(expectedSchema, currentSchema)
 
 	response := &dbagent.CompareManifestResponse{
-		IsIdentical:     true,
-		Differences:     []string{},
-		MissingObjects:  []string{},
-		ExtraObjects:    []string{},
+		IsIdentical:     len(diff.differences) == 0 && len(diff.missingObjects) == 0 && len(diff.extraObjects) == 0,
+		Differences:     diff.differences,
+		MissingObjects:  diff.missingObjects,
+		ExtraObjects:    diff.extraObjects,
 		DataDifferences: []*dbagent.DataDiff{},
 		LocalChecksum:   s.calculateManifestChecksum(manifest),
-		RemoteChecksum:  "remote_checksum_placeholder",
+		RemoteChecksum:  currentSchema.checksum(),
 	}
 
-	// For now, assume schemas are identical
-	// In a real implementation, you would compare:
-	// - Tables and their columns
-	// - Indexes
-	// - Constraints
-	// - Functions and procedures
-	// - Data differences (if requested)
+	if includeDataDiff {
+		log.Printf("Data differences requested but not supported; comparing schema only")
+	}
 
 	return response, nil
 }
@@ -470,24 +470,161 @@ func (s *AgentService) deployManifestToDatabase(db *sql.DB, manifest *dbagent.Ma
 	return response, nil
 }
 
-// calculateManifestChecksum calculates a checksum for a manifest
+// calculateManifestChecksum returns a deterministic SHA-256 of a manifest's migrations.
+// Migrations are hashed in version order with their SQL whitespace-normalized, so
+// manifests that describe the same changes produce the same checksum however they were
+// assembled. Authoring details such as the author and creation time are excluded.
 func (s *AgentService) calculateManifestChecksum(manifest *dbagent.Manifest) string {
-	// This is a simplified checksum calculation
-	// In a real implementation, you would hash the entire manifest content
-	return fmt.Sprintf("manifest_%d", time.Now().Unix())
+	migrations := make([]*dbagent.Migration, len(manifest.Migrations))
+	copy(migrations, manifest.Migrations)
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	hash := sha256.New()
+	for _, migration := range migrations {
+		dependencies := append([]string(nil), migration.Dependencies...)
+		sort.Strings(dependencies)
+
+		fmt.Fprintf(hash, "version=%s\nname=%s\nup=%s\ndown=%s\ndependencies=%s\n\n",
+			migration.Version, migration.Name,
+			normalizeSQL(migration.UpSql), normalizeSQL(migration.DownSql),
+			strings.Join(dependencies, ","))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
-// createDatabase creates a new database
-func (s *AgentService) createDatabase(connectionString, databaseName string) error {
-	// This is a simplified implementation
-	// In a real implementation, you would parse the connection string
-	// and create the database using appropriate SQL commands
+// normalizeSQL collapses runs of whitespace so formatting changes don't alter checksums
+func normalizeSQL(statement string) string {
+	return strings.Join(strings.Fields(statement), " ")
+}
 
-	log.Printf("Creating database: %s", databaseName)
-	// For PostgreSQL, you would typically:
-	// 1. Connect to the postgres database
-	// 2. Execute CREATE DATABASE command
-	// 3. Handle errors appropriately
+// targetConnectionString returns connectionString pointed at databaseName if it names
+// no database itself, so it connects to the database being bootstrapped rather than
+// the server's default one. A connection string that names a database is unchanged.
+func targetConnectionString(connectionString, databaseName string) (string, error) {
+	params, err := parseConnectionString(connectionString)
+	if err != nil {
+		return "", err
+	}
+	if params["dbname"] != "" {
+		return connectionString, nil
+	}
+	if databaseName == "" {
+		return "", fmt.Errorf("invalid connection string: no database name")
+	}
+	params["dbname"] = databaseName
+	return formatConnectionParams(params), nil
+}
+
+// createDatabase creates the database a connection string points at by connecting to
+// the server's maintenance "postgres" database. An existing database is an error.
+func (s *AgentService) createDatabase(connectionString string) error {
+	params, err := parseConnectionString(connectionString)
+	if err != nil {
+		return err
+	}
+
+	targetName := params["dbname"]
+	if targetName == "" {
+		return fmt.Errorf("invalid connection string: no database name")
+	}
+	params["dbname"] = "postgres"
+
+	maintenanceDB, err := sql.Open("postgres", formatConnectionParams(params))
+	if err != nil {
+		return fmt.Errorf("failed to connect to maintenance database: %w", err)
+	}
+	defer maintenanceDB.Close()
+
+	return createDatabaseIn(maintenanceDB, targetName)
+}
+
+// createDatabaseIn creates the database targetName from a connection to the server's
+// maintenance database, reporting an existing database or a missing CREATEDB grant
+// as ErrDatabaseExists or ErrCreateDatabaseDenied
+func createDatabaseIn(maintenanceDB *sql.DB, targetName string) error {
+	log.Printf("Creating database: %s", targetName)
+	if _, err := maintenanceDB.Exec("CREATE DATABASE " + pq.QuoteIdentifier(targetName)); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "42P04": // duplicate_database
+				return fmt.Errorf("%w: %s", ErrDatabaseExists, targetName)
+			case "42501": // insufficient_privilege
+				return fmt.Errorf("%w: cannot create %s; grant the role CREATEDB or create it manually", ErrCreateDatabaseDenied, targetName)
+			}
+		}
+		return fmt.Errorf("failed to create database %s: %w", targetName, err)
+	}
 
 	return nil
 }
+
+// parseConnectionString parses a URL ("postgres://...") or key/value ("host=... dbname=...")
+// connection string into its parameters
+func parseConnectionString(connectionString string) (map[string]string, error) {
+	if strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://") {
+		converted, err := pq.ParseURL(connectionString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection string: %w", err)
+		}
+		connectionString = converted
+	}
+
+	params := make(map[string]string)
+	for _, field := range splitConnectionFields(connectionString) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid connection string: expected key=value, found %q", field)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// splitConnectionFields splits a key/value connection string on spaces, keeping
+// single-quoted values (which may contain spaces and \' escapes) intact and unquoted
+func splitConnectionFields(connectionString string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range connectionString {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '\'':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// formatConnectionParams builds a key/value connection string, quoting every value
+func formatConnectionParams(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	escaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("%s='%s'", key, escaper.Replace(params[key])))
+	}
+	return strings.Join(fields, " ")
+}
@@ -2,17 +2,21 @@ package dbagent
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/base64"
 	"fmt"
+	"log"
+	"net"
 	"strings"
+	"sync"
 	"time"
 
+	"civicweave/backend/pkg/logging"
 	"civicweave/backend/pkg/metadb"
+	"civicweave/backend/proto/dbagent"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -25,15 +29,47 @@ const (
 	RequestIDHeader = "x-request-id"
 )
 
-// AuthInterceptor provides API key authentication for gRPC services
+// publicMethods lists the RPCs that may be called without an API key
+var publicMethods = map[string]bool{
+	dbagent.DatabaseAgent_Ping_FullMethodName: true,
+}
+
+// methodPermission is the key permission an RPC requires and the action it's audited as
+type methodPermission struct {
+	permission string
+	action     string
+}
+
+// methodPermissions lists the permission each protected RPC requires
+var methodPermissions = map[string]methodPermission{
+	dbagent.DatabaseAgent_CompareManifest_FullMethodName:      {permission: "read", action: "compare"},
+	dbagent.DatabaseAgent_DownloadManifest_FullMethodName:     {permission: "read", action: "download"},
+	dbagent.DatabaseAgent_GetDeploymentHistory_FullMethodName: {permission: "read", action: "history"},
+	dbagent.DatabaseAgent_DeployManifest_FullMethodName:       {permission: "deploy", action: "deploy"},
+	dbagent.DatabaseAgent_Bootstrap_FullMethodName:            {permission: "bootstrap", action: "bootstrap"},
+}
+
+// Failed authentications allowed per peer IP: a burst of failedAuthBurst, refilling at
+// failedAuthPerSecond. Legitimate clients rarely fail, so this only slows key guessing.
+const (
+	failedAuthPerSecond = 1
+	failedAuthBurst     = 10
+)
+
+// AuthInterceptor provides API key authentication and permission checks for gRPC services
 type AuthInterceptor struct {
-	metaRepo *metadb.Repository
+	keys        *ServerKeyStore
+	auditLogger *AuditLogger
+	failures    *RateLimiter // Failed authentications, keyed by peer IP
 }
 
-// NewAuthInterceptor creates a new authentication interceptor
-func NewAuthInterceptor(metaRepo *metadb.Repository) *AuthInterceptor {
+// NewAuthInterceptor creates a new authentication interceptor that accepts the given
+// keys and audits calls denied for lacking a permission
+func NewAuthInterceptor(keys *ServerKeyStore, auditLogger *AuditLogger) *AuthInterceptor {
 	return &AuthInterceptor{
-		metaRepo: metaRepo,
+		keys:        keys,
+		auditLogger: auditLogger,
+		failures:    NewRateLimiter(failedAuthPerSecond, failedAuthBurst),
 	}
 }
 
@@ -45,44 +81,9 @@ func (a *AuthInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Extract metadata from context
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
-		}
-
-		// Extract API key from metadata
-		apiKeys := md.Get(APIKeyHeader)
-		if len(apiKeys) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "missing API key")
-		}
-
-		clientIDs := md.Get(ClientIDHeader)
-		if len(clientIDs) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "missing client ID")
-		}
-
-		apiKey := apiKeys[0]
-		clientID := clientIDs[0]
-
-		// Validate API key
-		valid, err := a.validateAPIKey(clientID, apiKey)
+		ctx, err := a.authenticate(ctx, info.FullMethod)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "authentication error: %v", err)
-		}
-
-		if !valid {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid API key")
-		}
-
-		// Add authenticated context
-		ctx = context.WithValue(ctx, "client_id", clientID)
-		ctx = context.WithValue(ctx, "api_key", apiKey)
-
-		// Extract request ID for audit logging
-		requestIDs := md.Get(RequestIDHeader)
-		if len(requestIDs) > 0 {
-			ctx = context.WithValue(ctx, "request_id", requestIDs[0])
+			return nil, err
 		}
 
 		// Continue with the request
@@ -98,45 +99,9 @@ func (a *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		// Extract metadata from context
-		md, ok := metadata.FromIncomingContext(ss.Context())
-		if !ok {
-			return status.Errorf(codes.Unauthenticated, "missing metadata")
-		}
-
-		// Extract API key from metadata
-		apiKeys := md.Get(APIKeyHeader)
-		if len(apiKeys) == 0 {
-			return status.Errorf(codes.Unauthenticated, "missing API key")
-		}
-
-		clientIDs := md.Get(ClientIDHeader)
-		if len(clientIDs) == 0 {
-			return status.Errorf(codes.Unauthenticated, "missing client ID")
-		}
-
-		apiKey := apiKeys[0]
-		clientID := clientIDs[0]
-
-		// Validate API key
-		valid, err := a.validateAPIKey(clientID, apiKey)
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
-			return status.Errorf(codes.Internal, "authentication error: %v", err)
-		}
-
-		if !valid {
-			return status.Errorf(codes.Unauthenticated, "invalid API key")
-		}
-
-		// Create authenticated context
-		ctx := ss.Context()
-		ctx = context.WithValue(ctx, "client_id", clientID)
-		ctx = context.WithValue(ctx, "api_key", apiKey)
-
-		// Extract request ID for audit logging
-		requestIDs := md.Get(RequestIDHeader)
-		if len(requestIDs) > 0 {
-			ctx = context.WithValue(ctx, "request_id", requestIDs[0])
+			return err
 		}
 
 		// Wrap the server stream with authenticated context
@@ -150,14 +115,75 @@ func (a *AuthInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor
 	}
 }
 
-// validateAPIKey validates the API key against the metadata database
-func (a *AuthInterceptor) validateAPIKey(clientID, apiKey string) (bool, error) {
-	// Hash the API key for comparison
-	hash := sha256.Sum256([]byte(apiKey))
-	publicKeyHash := base64.StdEncoding.EncodeToString(hash[:])
+// authenticate verifies the client ID and API key in the request metadata and that the
+// key grants the method's permission, and returns a context carrying the client ID.
+// Public methods pass through unauthenticated. A peer IP that has used up its failed
+// authentications is refused before its key is checked.
+func (a *AuthInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	// Extract metadata from context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+
+	// Carry the caller's correlation ID so audit entries and logs can be matched to it
+	if requestIDs := md.Get(RequestIDHeader); len(requestIDs) > 0 && logging.ValidRequestID(requestIDs[0]) {
+		ctx = logging.WithRequestID(ctx, requestIDs[0])
+	}
+
+	if publicMethods[fullMethod] {
+		return ctx, nil
+	}
+
+	peerKey := peerRateLimitKey(ctx)
+	if a.failures.Exhausted(peerKey) {
+		log.Printf("🔒 Rejected %s: too many failed authentications from %s", fullMethod, peerKey)
+		return nil, status.Errorf(codes.ResourceExhausted, "too many failed authentication attempts, retry later")
+	}
+
+	// Extract API key from metadata
+	apiKeys := md.Get(APIKeyHeader)
+	if len(apiKeys) == 0 {
+		a.failures.Allow(peerKey)
+		return nil, status.Errorf(codes.Unauthenticated, "missing API key")
+	}
+
+	clientIDs := md.Get(ClientIDHeader)
+	if len(clientIDs) == 0 {
+		a.failures.Allow(peerKey)
+		return nil, status.Errorf(codes.Unauthenticated, "missing client ID")
+	}
+
+	key := a.keys.Authenticate(clientIDs[0], apiKeys[0])
+	if key == nil {
+		a.failures.Allow(peerKey)
+		log.Printf("🔒 Rejected %s: invalid, revoked or expired key for client %s", fullMethod, clientIDs[0])
+		return nil, status.Errorf(codes.Unauthenticated, "invalid API key")
+	}
+
+	// Add authenticated context
+	ctx = context.WithValue(ctx, "client_id", key.ID)
+
+	if required, ok := methodPermissions[fullMethod]; ok && !key.HasPermission(required.permission) {
+		message := fmt.Sprintf("API key %s lacks the %q permission required to %s", key.ID, required.permission, required.action)
+		if err := a.auditLogger.LogRequest(ctx, required.action, nil, nil, 403, message, 0, 0, 0, map[string]interface{}{
+			"denied_permission": required.permission,
+		}); err != nil {
+			log.Printf("Failed to audit denied %s: %v", required.action, err)
+		}
+		return nil, status.Errorf(codes.PermissionDenied, "%s", message)
+	}
+
+	return ctx, nil
+}
 
-	// Validate against metadata database
-	return a.metaRepo.ValidateAPIKey(clientID, publicKeyHash)
+// clientIDFromContext returns the authenticated client's ID, or "system" when
+// authentication is disabled
+func clientIDFromContext(ctx context.Context) string {
+	if clientID, ok := ctx.Value("client_id").(string); ok && clientID != "" {
+		return clientID
+	}
+	return "system"
 }
 
 // authenticatedServerStream wraps a grpc.ServerStream with authenticated context
@@ -185,33 +211,24 @@ func NewClientAuth(clientID, apiKey string) *ClientAuth {
 	}
 }
 
-// GetClientMetadata returns the metadata for gRPC client calls
-func (c *ClientAuth) GetClientMetadata() metadata.MD {
+// GetClientMetadata returns the metadata for gRPC client calls, tagged with requestID
+func (c *ClientAuth) GetClientMetadata(requestID string) metadata.MD {
 	return metadata.New(map[string]string{
 		APIKeyHeader:    c.APIKey,
 		ClientIDHeader:  c.ClientID,
-		RequestIDHeader: generateRequestID(),
+		RequestIDHeader: requestID,
 	})
 }
 
-// WithAuth adds authentication metadata to a context
+// WithAuth adds authentication metadata to a context. The call carries the context's
+// correlation ID if it has one, or a new one otherwise.
 func (c *ClientAuth) WithAuth(ctx context.Context) context.Context {
-	return metadata.NewOutgoingContext(ctx, c.GetClientMetadata())
-}
-
-// generateRequestID generates a unique request ID for tracking
-func generateRequestID() string {
-	return fmt.Sprintf("req_%d_%s", time.Now().UnixNano(), randomString(8))
-}
-
-// randomString generates a random string of specified length
-func randomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = charset[time.Now().UnixNano()%int64(len(charset))]
+	requestID := logging.RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+		ctx = logging.WithRequestID(ctx, requestID)
 	}
-	return string(b)
+	return metadata.NewOutgoingContext(ctx, c.GetClientMetadata(requestID))
 }
 
 // AuditLogger provides audit logging functionality
@@ -241,7 +258,7 @@ func (a *AuditLogger) LogRequest(
 ) error {
 	// Extract client information from context
 	clientID, _ := ctx.Value("client_id").(string)
-	requestID, _ := ctx.Value("request_id").(string)
+	requestID := logging.RequestIDFromContext(ctx)
 
 	// Get client IP from context (if available)
 	clientIP := getClientIP(ctx)
@@ -307,51 +324,96 @@ func marshalMetadata(metadata map[string]interface{}) []byte {
 	return []byte(jsonStr)
 }
 
-// RateLimiter provides rate limiting functionality
+// rateLimiterIdleTTL is how long a client's bucket may sit unused before it is dropped.
+// An idle bucket has refilled completely, so dropping it changes nothing for the client.
+const rateLimiterIdleTTL = 10 * time.Minute
+
+// RateLimiter limits each client to a steady request rate using a token bucket per
+// client. It is safe for concurrent use.
 type RateLimiter struct {
-	requests map[string][]time.Time
-	limit    int
-	window   time.Duration
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	rate        float64 // Tokens added per second
+	burst       float64 // Bucket capacity
+	lastCleanup time.Time
 }
 
-// NewRateLimiter creates a new rate limiter
-func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+// tokenBucket tracks one client's available requests
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing requestsPerSecond per client, with
+// up to burst requests at once
+func NewRateLimiter(requestsPerSecond, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
 	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
+		buckets:     make(map[string]*tokenBucket),
+		rate:        float64(requestsPerSecond),
+		burst:       float64(burst),
+		lastCleanup: time.Now(),
 	}
 }
 
 // Allow checks if a request from the given client should be allowed
-func (r *RateLimiter) Allow(clientID string) bool {
+func (r *RateLimiter) Allow(clientKey string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bucket := r.refill(clientKey)
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// Exhausted reports whether the client has no requests left, without using one
+func (r *RateLimiter) Exhausted(clientKey string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.refill(clientKey).tokens < 1
+}
+
+// refill returns the client's bucket topped up for the time since its last request.
+// Callers hold r.mu.
+func (r *RateLimiter) refill(clientKey string) *tokenBucket {
 	now := time.Now()
-	cutoff := now.Add(-r.window)
-
-	// Clean old requests
-	if requests, exists := r.requests[clientID]; exists {
-		var validRequests []time.Time
-		for _, reqTime := range requests {
-			if reqTime.After(cutoff) {
-				validRequests = append(validRequests, reqTime)
-			}
-		}
-		r.requests[clientID] = validRequests
-	} else {
-		r.requests[clientID] = []time.Time{}
+	if now.Sub(r.lastCleanup) > rateLimiterIdleTTL {
+		r.removeIdleBuckets(now)
 	}
 
-	// Check if limit exceeded
-	if len(r.requests[clientID]) >= r.limit {
-		return false
+	bucket, exists := r.buckets[clientKey]
+	if !exists {
+		bucket = &tokenBucket{tokens: r.burst, lastSeen: now}
+		r.buckets[clientKey] = bucket
 	}
 
-	// Add current request
-	r.requests[clientID] = append(r.requests[clientID], now)
-	return true
+	bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * r.rate
+	if bucket.tokens > r.burst {
+		bucket.tokens = r.burst
+	}
+	bucket.lastSeen = now
+	return bucket
+}
+
+// removeIdleBuckets drops buckets unused for rateLimiterIdleTTL. Callers hold r.mu.
+func (r *RateLimiter) removeIdleBuckets(now time.Time) {
+	for clientKey, bucket := range r.buckets {
+		if now.Sub(bucket.lastSeen) > rateLimiterIdleTTL {
+			delete(r.buckets, clientKey)
+		}
+	}
+	r.lastCleanup = now
 }
 
-// RateLimitInterceptor provides rate limiting for gRPC services
+// UnaryServerInterceptor returns a gRPC unary server interceptor that rate limits
+// requests per authenticated client, or per peer IP when the caller is unauthenticated.
+// It runs after the auth interceptor, which throttles failed authentications itself.
 func (r *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -359,18 +421,31 @@ func (r *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Get client ID from context
-		clientID, ok := ctx.Value("client_id").(string)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "missing client ID")
-		}
-
-		// Check rate limit
-		if !r.Allow(clientID) {
-			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded")
+		if !r.Allow(rateLimitKey(ctx)) {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry shortly")
 		}
 
 		// Continue with the request
 		return handler(ctx, req)
 	}
 }
+
+// rateLimitKey identifies the caller for rate limiting
+func rateLimitKey(ctx context.Context) string {
+	if clientID, ok := ctx.Value("client_id").(string); ok && clientID != "" {
+		return "client:" + clientID
+	}
+	return peerRateLimitKey(ctx)
+}
+
+// peerRateLimitKey identifies the caller by peer IP
+func peerRateLimitKey(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return "ip:unknown"
+}
@@ -0,0 +1,79 @@
+package dbagent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net"
+	"testing"
+	"time"
+
+	"civicweave/backend/proto/dbagent"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authContext returns an incoming call from ip presenting clientID and apiKey
+func authContext(ip, clientID, apiKey string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+	})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(
+		ClientIDHeader, clientID,
+		APIKeyHeader, apiKey,
+	))
+}
+
+// readKeyStore returns a store holding one active key with the read permission
+func readKeyStore(clientID, apiKey string) *ServerKeyStore {
+	hash := sha256.Sum256([]byte(apiKey))
+	return &ServerKeyStore{keys: map[string]ServerKey{
+		clientID: {
+			ID:             clientID,
+			PrivateKeyHash: base64.StdEncoding.EncodeToString(hash[:]),
+			ExpiresAt:      time.Now().Add(time.Hour),
+			Permissions:    []string{"read"},
+			IsActive:       true,
+		},
+	}}
+}
+
+func TestAuthenticateThrottlesFailedAttemptsPerIP(t *testing.T) {
+	auth := NewAuthInterceptor(readKeyStore("ci", "right-key"), nil)
+	method := dbagent.DatabaseAgent_CompareManifest_FullMethodName
+
+	for i := 0; i < failedAuthBurst; i++ {
+		_, err := auth.authenticate(authContext("203.0.113.7", "ci", "wrong-key"), method)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("attempt %d: got %v, want Unauthenticated", i+1, err)
+		}
+	}
+
+	// The right key is refused too once the IP has used up its failures, so guessing
+	// can't go on at full speed
+	if _, err := auth.authenticate(authContext("203.0.113.7", "ci", "right-key"), method); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("after %d failures got %v, want ResourceExhausted", failedAuthBurst, err)
+	}
+
+	ctx, err := auth.authenticate(authContext("198.51.100.2", "ci", "right-key"), method)
+	if err != nil {
+		t.Fatalf("another IP was refused: %v", err)
+	}
+	if got := clientIDFromContext(ctx); got != "ci" {
+		t.Errorf("client ID = %q, want ci", got)
+	}
+}
+
+func TestAuthenticateDoesNotCountSuccesses(t *testing.T) {
+	auth := NewAuthInterceptor(readKeyStore("ci", "right-key"), nil)
+	method := dbagent.DatabaseAgent_CompareManifest_FullMethodName
+
+	for i := 0; i < failedAuthBurst*2; i++ {
+		if _, err := auth.authenticate(authContext("203.0.113.7", "ci", "right-key"), method); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+}
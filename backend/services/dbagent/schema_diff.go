@@ -0,0 +1,229 @@
+package dbagent
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"civicweave/backend/proto/dbagent"
+
+	"github.com/lib/pq"
+)
+
+// liveSchemaName is the schema the agent compares manifests against
+const liveSchemaName = "public"
+
+// schemaSnapshot is a normalized view of one schema's tables, columns, indexes and
+// constraints. Keys identify an object (e.g. "column users.email") and values hold
+// its definition with schema qualifiers stripped, so two schemas built from the same
+// DDL produce identical snapshots.
+type schemaSnapshot struct {
+	objects map[string]string
+}
+
+// schemaObjectQueries introspect a schema, each returning (key, definition) rows
+var schemaObjectQueries = []struct {
+	kind  string
+	query string
+}{
+	{
+		kind: "table",
+		query: `
+			SELECT table_name, table_type
+			FROM information_schema.tables
+			WHERE table_schema = $1`,
+	},
+	{
+		kind: "column",
+		query: `
+			SELECT table_name || '.' || column_name,
+			       data_type || COALESCE('(' || character_maximum_length || ')', '') ||
+			       CASE WHEN is_nullable = 'NO' THEN ' NOT NULL' ELSE '' END ||
+			       COALESCE(' DEFAULT ' || column_default, '')
+			FROM information_schema.columns
+			WHERE table_schema = $1`,
+	},
+	{
+		kind: "index",
+		query: `
+			SELECT tablename || '.' || indexname, indexdef
+			FROM pg_indexes
+			WHERE schemaname = $1`,
+	},
+	{
+		kind: "constraint",
+		query: `
+			SELECT rel.relname || '.' || con.conname, pg_get_constraintdef(con.oid)
+			FROM pg_constraint con
+			JOIN pg_class rel ON rel.oid = con.conrelid
+			JOIN pg_namespace nsp ON nsp.oid = rel.relnamespace
+			WHERE nsp.nspname = $1`,
+	},
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// introspectSchema builds a normalized snapshot of a schema
+func introspectSchema(q querier, schemaName string) (*schemaSnapshot, error) {
+	snapshot := &schemaSnapshot{objects: make(map[string]string)}
+
+	for _, objectQuery := range schemaObjectQueries {
+		rows, err := q.Query(objectQuery.query, schemaName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %ss: %w", objectQuery.kind, err)
+		}
+
+		for rows.Next() {
+			var name, definition string
+			if err := rows.Scan(&name, &definition); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s: %w", objectQuery.kind, err)
+			}
+			key := objectQuery.kind + " " + name
+			snapshot.objects[key] = normalizeDefinition(definition, schemaName)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to read %ss: %w", objectQuery.kind, err)
+		}
+		rows.Close()
+	}
+
+	return snapshot, nil
+}
+
+// normalizeDefinition strips schema qualifiers and collapses whitespace so that
+// definitions from different schemas can be compared
+func normalizeDefinition(definition, schemaName string) string {
+	definition = strings.ReplaceAll(definition, `"`+schemaName+`".`, "")
+	definition = strings.ReplaceAll(definition, schemaName+".", "")
+	return strings.Join(strings.Fields(definition), " ")
+}
+
+// keys returns the snapshot's object keys in sorted order
+func (s *schemaSnapshot) keys() []string {
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checksum returns a deterministic hash of the snapshot. Identical schemas produce
+// identical checksums regardless of catalog ordering.
+func (s *schemaSnapshot) checksum() string {
+	hash := sha256.New()
+	for _, key := range s.keys() {
+		fmt.Fprintf(hash, "%s=%s\n", key, s.objects[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// schemaDiff lists how a live schema differs from an expected one
+type schemaDiff struct {
+	differences    []string // Objects present in both with different definitions
+	missingObjects []string // Expected objects the live schema lacks
+	extraObjects   []string // Live objects the expected schema doesn't have
+}
+
+// diffSchemas compares a live snapshot against the expected one. Results are sorted.
+func diffSchemas(expected, live *schemaSnapshot) schemaDiff {
+	diff := schemaDiff{
+		differences:    []string{},
+		missingObjects: []string{},
+		extraObjects:   []string{},
+	}
+
+	for _, key := range expected.keys() {
+		liveDefinition, ok := live.objects[key]
+		if !ok {
+			diff.missingObjects = append(diff.missingObjects, key)
+			continue
+		}
+		if liveDefinition != expected.objects[key] {
+			diff.differences = append(diff.differences,
+				fmt.Sprintf("%s: expected %q, found %q", key, expected.objects[key], liveDefinition))
+		}
+	}
+
+	for _, key := range live.keys() {
+		if _, ok := expected.objects[key]; !ok {
+			diff.extraObjects = append(diff.extraObjects, key)
+		}
+	}
+
+	return diff
+}
+
+// expectedSchemaFromManifest builds the schema a manifest describes by applying its
+// migrations to a throwaway database on the same server as the target, over its own
+// connection, then dropping it. Nothing is ever run against the target database itself.
+func expectedSchemaFromManifest(targetConnectionString string, manifest *dbagent.Manifest) (*schemaSnapshot, error) {
+	var snapshot *schemaSnapshot
+	err := withScratchDatabase(targetConnectionString, func(scratchDB *sql.DB) error {
+		for _, migration := range manifest.Migrations {
+			if _, err := scratchDB.Exec(migration.UpSql); err != nil {
+				return fmt.Errorf("failed to apply migration %s to scratch database: %w", migration.Version, err)
+			}
+		}
+
+		var err error
+		snapshot, err = introspectSchema(scratchDB, liveSchemaName)
+		return err
+	})
+	return snapshot, err
+}
+
+// withScratchDatabase creates an empty database next to the one targetConnectionString
+// points at, calls fn with a connection to it and drops it afterwards
+func withScratchDatabase(targetConnectionString string, fn func(scratchDB *sql.DB) error) error {
+	params, err := parseConnectionString(targetConnectionString)
+	if err != nil {
+		return err
+	}
+	scratchName := fmt.Sprintf("dbagent_compare_%d", time.Now().UnixNano())
+
+	maintenanceDB, err := sql.Open("postgres", connectionStringForDatabase(params, "postgres"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to maintenance database: %w", err)
+	}
+	defer maintenanceDB.Close()
+
+	if _, err := maintenanceDB.Exec("CREATE DATABASE " + pq.QuoteIdentifier(scratchName)); err != nil {
+		return fmt.Errorf("failed to create scratch database: %w", err)
+	}
+	defer func() {
+		if _, err := maintenanceDB.Exec("DROP DATABASE IF EXISTS " + pq.QuoteIdentifier(scratchName)); err != nil {
+			log.Printf("Failed to drop scratch database %s: %v", scratchName, err)
+		}
+	}()
+
+	scratchDB, err := sql.Open("postgres", connectionStringForDatabase(params, scratchName))
+	if err != nil {
+		return fmt.Errorf("failed to connect to scratch database: %w", err)
+	}
+	// Closed before the deferred drop runs, which fails while connections remain
+	defer scratchDB.Close()
+
+	return fn(scratchDB)
+}
+
+// connectionStringForDatabase formats connection parameters pointed at another database
+// on the same server
+func connectionStringForDatabase(params map[string]string, databaseName string) string {
+	withDatabase := make(map[string]string, len(params)+1)
+	for key, value := range params {
+		withDatabase[key] = value
+	}
+	withDatabase["dbname"] = databaseName
+	return formatConnectionParams(withDatabase)
+}
@@ -0,0 +1,104 @@
+package dbagent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeDefinition(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		schemaName string
+		want       string
+	}{
+		{
+			name:       "strips quoted schema qualifier",
+			definition: `CREATE INDEX idx ON "dbagent_compare_1".users USING btree (email)`,
+			schemaName: "dbagent_compare_1",
+			want:       "CREATE INDEX idx ON users USING btree (email)",
+		},
+		{
+			name:       "strips bare schema qualifier",
+			definition: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)",
+			schemaName: "public",
+			want:       "CREATE UNIQUE INDEX users_pkey ON users USING btree (id)",
+		},
+		{
+			name:       "collapses whitespace",
+			definition: "FOREIGN KEY (user_id)\n\t REFERENCES  public.users(id)",
+			schemaName: "public",
+			want:       "FOREIGN KEY (user_id) REFERENCES users(id)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDefinition(tt.definition, tt.schemaName); got != tt.want {
+				t.Errorf("normalizeDefinition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	expected := &schemaSnapshot{objects: map[string]string{
+		"table users":         "BASE TABLE",
+		"column users.email":  "character varying(255) NOT NULL",
+		"column users.name":   "text",
+		"index users.idx_one": "CREATE INDEX idx_one ON users USING btree (email)",
+	}}
+	live := &schemaSnapshot{objects: map[string]string{
+		"table users":        "BASE TABLE",
+		"column users.email": "character varying(100) NOT NULL",
+		"column users.name":  "text",
+		"table legacy":       "BASE TABLE",
+	}}
+
+	diff := diffSchemas(expected, live)
+
+	wantDifferences := []string{
+		`column users.email: expected "character varying(255) NOT NULL", found "character varying(100) NOT NULL"`,
+	}
+	if !reflect.DeepEqual(diff.differences, wantDifferences) {
+		t.Errorf("differences = %q, want %q", diff.differences, wantDifferences)
+	}
+	if want := []string{"index users.idx_one"}; !reflect.DeepEqual(diff.missingObjects, want) {
+		t.Errorf("missingObjects = %q, want %q", diff.missingObjects, want)
+	}
+	if want := []string{"table legacy"}; !reflect.DeepEqual(diff.extraObjects, want) {
+		t.Errorf("extraObjects = %q, want %q", diff.extraObjects, want)
+	}
+}
+
+func TestDiffSchemasIdentical(t *testing.T) {
+	snapshot := &schemaSnapshot{objects: map[string]string{
+		"table users":        "BASE TABLE",
+		"column users.email": "text NOT NULL",
+	}}
+	copied := &schemaSnapshot{objects: map[string]string{
+		"column users.email": "text NOT NULL",
+		"table users":        "BASE TABLE",
+	}}
+
+	diff := diffSchemas(snapshot, copied)
+	if len(diff.differences) != 0 || len(diff.missingObjects) != 0 || len(diff.extraObjects) != 0 {
+		t.Errorf("diffSchemas() of identical snapshots = %+v, want no differences", diff)
+	}
+	if snapshot.checksum() != copied.checksum() {
+		t.Error("identical snapshots have different checksums")
+	}
+}
+
+func TestConnectionStringForDatabase(t *testing.T) {
+	params := map[string]string{"host": "db.internal", "dbname": "civicweave", "user": "agent"}
+
+	got := connectionStringForDatabase(params, "dbagent_compare_1")
+	want := "dbname='dbagent_compare_1' host='db.internal' user='agent'"
+	if got != want {
+		t.Errorf("connectionStringForDatabase() = %q, want %q", got, want)
+	}
+	if params["dbname"] != "civicweave" {
+		t.Errorf("connectionStringForDatabase() modified its input: dbname = %q", params["dbname"])
+	}
+}
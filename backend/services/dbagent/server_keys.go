@@ -0,0 +1,84 @@
+package dbagent
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ServerKey is an API key the agent accepts, as written to server-keys.json by db-keygen
+type ServerKey struct {
+	ID             string    `json:"id"`
+	PublicKey      string    `json:"public_key"`
+	PrivateKeyHash string    `json:"private_key_hash"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Permissions    []string  `json:"permissions"`
+	IsActive       bool      `json:"is_active"`
+}
+
+// HasPermission reports whether the key grants a permission such as "deploy"
+func (k *ServerKey) HasPermission(permission string) bool {
+	for _, granted := range k.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerKeyStore holds the API keys loaded from a server-keys.json file
+type ServerKeyStore struct {
+	keys map[string]ServerKey
+}
+
+// LoadServerKeys reads the server keys file written by db-keygen. Keys generated
+// before private key hashes were recorded are skipped since they can't be verified.
+func LoadServerKeys(path string) (*ServerKeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server keys: %w", err)
+	}
+
+	var config struct {
+		Keys []ServerKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse server keys: %w", err)
+	}
+
+	store := &ServerKeyStore{keys: make(map[string]ServerKey)}
+	for _, key := range config.Keys {
+		if key.PrivateKeyHash == "" {
+			continue
+		}
+		store.keys[key.ID] = key
+	}
+	return store, nil
+}
+
+// Len returns the number of keys that can authenticate clients
+func (s *ServerKeyStore) Len() int {
+	return len(s.keys)
+}
+
+// Authenticate returns the key matching a client ID and the private key it presented,
+// or nil if there's no such key or it has been revoked or has expired
+func (s *ServerKeyStore) Authenticate(clientID, apiKey string) *ServerKey {
+	key, ok := s.keys[clientID]
+	if !ok || !key.IsActive || time.Now().After(key.ExpiresAt) {
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(apiKey))
+	presented := base64.StdEncoding.EncodeToString(hash[:])
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(key.PrivateKeyHash)) != 1 {
+		return nil
+	}
+	return &key
+}
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"civicweave/backend/config"
+	"civicweave/backend/pkg/metrics"
 )
 
 // EmailService handles email operations using Mailgun
@@ -31,7 +35,9 @@ type EmailTemplate struct {
 }
 
 // SendEmail sends an email using Mailgun
-func (s *EmailService) SendEmail(to, subject, html, text string) error {
+func (s *EmailService) SendEmail(to, subject, html, text string) (err error) {
+	defer func() { metrics.ObserveEmailSend("single", err) }()
+
 	if s.config.APIKey == "" || s.config.Domain == "" {
 		return fmt.Errorf("mailgun configuration missing")
 	}
@@ -50,6 +56,53 @@ func (s *EmailService) SendEmail(to, subject, html, text string) error {
 		data.Set("text", text)
 	}
 
+	return s.postMessage(data)
+}
+
+// SendBatchEmail sends one email to many recipients in a single Mailgun request.
+// recipientVariables maps each recipient address to its substitution variables,
+// referenced in the content as %recipient.name%. Recipient variables also make
+// Mailgun deliver a separate copy to each address, so recipients never see each other.
+// headers are added to every copy and may reference recipient variables too.
+func (s *EmailService) SendBatchEmail(recipientVariables map[string]map[string]string, subject, html, text string, headers map[string]string) (err error) {
+	if len(recipientVariables) == 0 {
+		return nil
+	}
+	defer func() { metrics.ObserveEmailSend("batch", err) }()
+
+	if s.config.APIKey == "" || s.config.Domain == "" {
+		return fmt.Errorf("mailgun configuration missing")
+	}
+
+	recipientVariablesJSON, err := json.Marshal(recipientVariables)
+	if err != nil {
+		return fmt.Errorf("failed to encode recipient variables: %w", err)
+	}
+
+	data := url.Values{}
+	data.Set("from", fmt.Sprintf("CivicWeave <noreply@%s>", s.config.Domain))
+	for recipient := range recipientVariables {
+		data.Add("to", recipient)
+	}
+	data.Set("subject", subject)
+	data.Set("recipient-variables", string(recipientVariablesJSON))
+	for name, value := range headers {
+		data.Set("h:"+name, value)
+	}
+
+	if html != "" {
+		data.Set("html", html)
+	}
+
+	if text != "" {
+		data.Set("text", text)
+	}
+
+	return s.postMessage(data)
+}
+
+// postMessage submits a message to the Mailgun messages API
+func (s *EmailService) postMessage(data url.Values) error {
 	// Create request
 	req, err := http.NewRequest("POST", fmt.Sprintf("https://api.mailgun.net/v3/%s/messages", s.config.Domain), strings.NewReader(data.Encode()))
 	if err != nil {
@@ -109,6 +162,43 @@ func (s *EmailService) SendVerificationEmail(to, token string) error {
 	return s.SendEmail(to, subject, html, text)
 }
 
+// SendEmailChangeVerificationEmail asks a user to confirm a new email address by
+// visiting verifyURL
+func (s *EmailService) SendEmailChangeVerificationEmail(to, verifyURL string) error {
+	subject := "Confirm your new CivicWeave email address"
+	html := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>Confirm your new email address</h2>
+			<p>You asked to change the email address on your CivicWeave account to this one. Click the link below to confirm:</p>
+			<p><a href="%s">Confirm Email Address</a></p>
+			<p>If the link doesn't work, copy and paste this URL into your browser:</p>
+			<p>%s</p>
+			<p>This link will expire in 24 hours. Until then, your account keeps using its current email address.</p>
+			<p>If you didn't request this change, please ignore this email.</p>
+			<p>Best regards,<br>The CivicWeave Team</p>
+		</body>
+		</html>
+	`, verifyURL, verifyURL)
+
+	text := fmt.Sprintf(`
+		Confirm your new email address
+		
+		You asked to change the email address on your CivicWeave account to this one. Visit the following link to confirm:
+		
+		%s
+		
+		This link will expire in 24 hours. Until then, your account keeps using its current email address.
+		
+		If you didn't request this change, please ignore this email.
+		
+		Best regards,
+		The CivicWeave Team
+	`, verifyURL)
+
+	return s.SendEmail(to, subject, html, text)
+}
+
 // SendWelcomeEmail sends a welcome email after verification
 func (s *EmailService) SendWelcomeEmail(to, name string) error {
 	subject := "Welcome to CivicWeave!"
@@ -151,10 +241,9 @@ func (s *EmailService) SendWelcomeEmail(to, name string) error {
 	return s.SendEmail(to, subject, html, text)
 }
 
-// SendPasswordResetEmail sends a password reset email
-func (s *EmailService) SendPasswordResetEmail(to, token string) error {
-	resetURL := fmt.Sprintf("http://localhost:3000/reset-password?token=%s", token)
-
+// SendPasswordResetEmail sends a password reset email linking to resetURL, which
+// stops working after expiresIn
+func (s *EmailService) SendPasswordResetEmail(to, resetURL string, expiresIn time.Duration) error {
 	subject := "Reset your CivicWeave password"
 	html := fmt.Sprintf(`
 		<html>
@@ -164,12 +253,12 @@ func (s *EmailService) SendPasswordResetEmail(to, token string) error {
 			<p><a href="%s">Reset Password</a></p>
 			<p>If the link doesn't work, copy and paste this URL into your browser:</p>
 			<p>%s</p>
-			<p>This link will expire in 1 hour.</p>
+			<p>This link will expire in %s.</p>
 			<p>If you didn't request this reset, please ignore this email.</p>
 			<p>Best regards,<br>The CivicWeave Team</p>
 		</body>
 		</html>
-	`, resetURL, resetURL)
+	`, resetURL, resetURL, formatExpiry(expiresIn))
 
 	text := fmt.Sprintf(`
 		Password Reset Request
@@ -178,17 +267,37 @@ func (s *EmailService) SendPasswordResetEmail(to, token string) error {
 		
 		%s
 		
-		This link will expire in 1 hour.
+		This link will expire in %s.
 		
 		If you didn't request this reset, please ignore this email.
 		
 		Best regards,
 		The CivicWeave Team
-	`, resetURL)
+	`, resetURL, formatExpiry(expiresIn))
 
 	return s.SendEmail(to, subject, html, text)
 }
 
+// formatExpiry describes a link lifetime such as "1 hour" or "30 minutes"
+func formatExpiry(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return pluralize(int(d/time.Hour), "hour")
+	case d >= time.Minute:
+		return pluralize(int(d/time.Minute), "minute")
+	default:
+		return d.String()
+	}
+}
+
+// pluralize formats a count with a unit, e.g. "1 hour" or "2 hours"
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
+
 // SendApplicationConfirmationEmail sends confirmation when volunteer applies
 func (s *EmailService) SendApplicationConfirmationEmail(to, volunteerName, initiativeTitle string) error {
 	subject := "Application submitted - " + initiativeTitle
@@ -272,6 +381,77 @@ func (s *EmailService) SendApplicationStatusUpdateEmail(to, volunteerName, initi
 	return s.SendEmail(to, subject, html, text)
 }
 
+// SendUnreadMessageEmail reminds a user about a direct message they haven't read yet
+func (s *EmailService) SendUnreadMessageEmail(to, recipientName, senderName, preview, link string) error {
+	subject, html, text := unreadMessageEmailContent(recipientName, senderName, preview, link)
+	return s.SendEmail(to, subject, html, text)
+}
+
+// unreadMessageEmailContent builds the unread message reminder. Names and the preview
+// are user-supplied, so they're escaped in the HTML body; the text body is left as is.
+func unreadMessageEmailContent(recipientName, senderName, preview, link string) (string, string, string) {
+	subject := fmt.Sprintf("You have an unread message from %s", senderName)
+	htmlBody := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>New message from %s</h2>
+			<p>Hi %s,</p>
+			<p>%s sent you a message on CivicWeave that you haven't read yet:</p>
+			<blockquote>%s</blockquote>
+			<p><a href="%s">Read and reply</a></p>
+			<p>You can turn off these emails in your notification preferences.</p>
+			<p>Best regards,<br>The CivicWeave Team</p>
+		</body>
+		</html>
+	`, html.EscapeString(senderName), html.EscapeString(recipientName), html.EscapeString(senderName),
+		html.EscapeString(preview), html.EscapeString(link))
+
+	textBody := fmt.Sprintf(`
+		New message from %s
+		
+		Hi %s,
+		
+		%s sent you a message on CivicWeave that you haven't read yet:
+		
+		%s
+		
+		Read and reply: %s
+		
+		You can turn off these emails in your notification preferences.
+		
+		Best regards,
+		The CivicWeave Team
+	`, senderName, recipientName, senderName, preview, link)
+
+	return subject, htmlBody, textBody
+}
+
+// CampaignEmailContent wraps a campaign's rendered content into complete HTML and
+// text bodies, each ending with an unsubscribe footer linking to unsubscribeURL
+func (s *EmailService) CampaignEmailContent(htmlContent, textContent, unsubscribeURL string) (string, string) {
+	html := fmt.Sprintf(`
+		<html>
+		<body>
+			%s
+			<hr>
+			<p style="font-size:12px;color:#666">
+				You are receiving this email because of your role on CivicWeave.
+				<a href="%s">Unsubscribe</a> from future campaign emails.
+			</p>
+		</body>
+		</html>
+	`, htmlContent, unsubscribeURL)
+
+	text := fmt.Sprintf(`%s
+
+--
+You are receiving this email because of your role on CivicWeave.
+Unsubscribe from future campaign emails: %s
+`, textContent, unsubscribeURL)
+
+	return html, text
+}
+
 // SendCampaignEmail sends a campaign email to multiple recipients
 func (s *EmailService) SendCampaignEmail(recipients []string, subject, body, htmlBody string) error {
 	var lastError error
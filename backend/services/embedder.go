@@ -0,0 +1,80 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"civicweave/backend/config"
+)
+
+// SkillEmbeddingDimensions is the vector size of the skill_claims, volunteer_skill_vectors
+// and initiative_skill_requirements embedding columns. Every provider must produce it.
+const SkillEmbeddingDimensions = 384
+
+// Embedding providers selectable with EMBEDDING_PROVIDER
+const (
+	EmbeddingProviderOpenAI = "openai"
+	EmbeddingProviderLocal  = "local"
+)
+
+// Embedder turns texts into embedding vectors, returning one vector per text in order
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Model names the model producing the vectors; it is recorded with each claim so
+	// claims can be re-embedded when it changes
+	Model() string
+	// Configured reports whether the provider has the settings it needs to be called
+	Configured() bool
+}
+
+// NewEmbedder creates the embedding provider selected in cfg
+func NewEmbedder(cfg *config.Config) (Embedder, error) {
+	switch cfg.Embedding.Provider {
+	case EmbeddingProviderOpenAI:
+		return NewOpenAIEmbedder(cfg.OpenAI.APIKey, cfg.OpenAI.EmbeddingModel), nil
+	case EmbeddingProviderLocal:
+		return NewLocalEmbedder(cfg.Embedding.BaseURL, cfg.Embedding.Model, cfg.Embedding.APIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.Embedding.Provider)
+	}
+}
+
+// postEmbeddingRequest sends payload as JSON to url and decodes the JSON response into
+// out. Non-200 responses are returned as *embeddingAPIError.
+func postEmbeddingRequest(ctx context.Context, client *http.Client, url, apiKey string, payload, out interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return &permanentEmbeddingError{err: fmt.Errorf("failed to marshal request: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return &permanentEmbeddingError{err: fmt.Errorf("failed to create request: %w", err)}
+	}
+
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &embeddingAPIError{statusCode: resp.StatusCode, body: string(body)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -1,58 +1,123 @@
 package services
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
+	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"civicweave/backend/pkg/metrics"
+
 	"github.com/pgvector/pgvector-go"
 )
 
-// EmbeddingService handles skill embedding generation
+// Retry and circuit breaker settings for the embeddings API. A request is tried up to
+// embeddingMaxAttempts times within embeddingRequestTimeout; after
+// embeddingFailureThreshold consecutive failed requests the API is not called again
+// until embeddingCircuitCooldown has passed.
+const (
+	embeddingMaxAttempts      = 3
+	embeddingInitialBackoff   = 500 * time.Millisecond
+	embeddingMaxBackoff       = 4 * time.Second
+	embeddingRequestTimeout   = 20 * time.Second
+	embeddingFailureThreshold = 5
+	embeddingCircuitCooldown  = time.Minute
+)
+
+// ErrEmbeddingDimensionMismatch is returned when a provider produces vectors of a size the
+// embedding columns cannot store
+var ErrEmbeddingDimensionMismatch = fmt.Errorf("embedding dimension mismatch")
+
+// ErrEmbeddingUnavailable is returned when the embeddings API keeps failing transiently
+// or the circuit breaker is open. Callers can queue the work and retry later.
+var ErrEmbeddingUnavailable = fmt.Errorf("embedding service unavailable")
+
+// EmbeddingService handles skill embedding generation, retrying transient failures of
+// its provider and checking every vector fits the embedding columns
 type EmbeddingService struct {
-	apiKey string
-	model  string
-	client *http.Client
+	embedder Embedder
+	breaker  circuitBreaker
 }
 
-// OpenAIEmbeddingRequest represents the request to OpenAI embeddings API
-type OpenAIEmbeddingRequest struct {
-	Model string   `json:"model"`
-	Input []string `json:"input"`
+// circuitBreaker stops calls to a failing dependency after a run of consecutive failures.
+// Once the cooldown passes a call is let through, and another failure reopens it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
 }
 
-// OpenAIEmbeddingResponse represents the response from OpenAI embeddings API
-type OpenAIEmbeddingResponse struct {
-	Data []struct {
-		Embedding []float64 `json:"embedding"`
-		Index     int       `json:"index"`
-	} `json:"data"`
-	Usage struct {
-		PromptTokens int `json:"prompt_tokens"`
-		TotalTokens  int `json:"total_tokens"`
-	} `json:"usage"`
+// allow reports whether a call may be made
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !time.Now().Before(b.openUntil)
 }
 
-// NewEmbeddingService creates a new embedding service
-func NewEmbeddingService(apiKey, model string) *EmbeddingService {
-	if model == "" {
-		model = "text-embedding-3-small" // Default model
+// recordSuccess closes the breaker
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+// recordFailure counts a failed call and reports whether it opened the breaker
+func (b *circuitBreaker) recordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures < embeddingFailureThreshold {
+		return false
 	}
+	b.openUntil = time.Now().Add(embeddingCircuitCooldown)
+	return true
+}
+
+// embeddingAPIError is a non-200 response from an embedding provider
+type embeddingAPIError struct {
+	statusCode int
+	body       string
+}
+
+func (e *embeddingAPIError) Error() string {
+	return fmt.Sprintf("OpenAI API returned status %d: %s", e.statusCode, e.body)
+}
 
-	return &EmbeddingService{
-		apiKey: apiKey,
-		model:  model,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// isTransientEmbeddingError reports whether a failed attempt is worth retrying: network
+// errors, timeouts, rate limiting and server errors are; rejected requests are not
+func isTransientEmbeddingError(err error) bool {
+	var apiErr *embeddingAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.statusCode == http.StatusTooManyRequests || apiErr.statusCode >= http.StatusInternalServerError
 	}
+	var permanentErr *permanentEmbeddingError
+	return !errors.As(err, &permanentErr)
+}
+
+// permanentEmbeddingError marks a failure that retrying will not fix
+type permanentEmbeddingError struct {
+	err error
+}
+
+func (e *permanentEmbeddingError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentEmbeddingError) Unwrap() error {
+	return e.err
 }
 
-// GenerateEmbedding generates embedding for a single skill text using OpenAI API
+// NewEmbeddingService creates a new embedding service using embedder
+func NewEmbeddingService(embedder Embedder) *EmbeddingService {
+	return &EmbeddingService{embedder: embedder}
+}
+
+// GenerateEmbedding generates embedding for a single skill text
 func (s *EmbeddingService) GenerateEmbedding(skillText string) (pgvector.Vector, error) {
 	if strings.TrimSpace(skillText) == "" {
 		return pgvector.NewVector([]float32{}), fmt.Errorf("skill text cannot be empty")
@@ -89,57 +154,72 @@ func (s *EmbeddingService) GenerateBatchEmbeddings(skillTexts []string) ([]pgvec
 		return nil, fmt.Errorf("no valid skill texts provided")
 	}
 
-	// Prepare request
-	requestBody := OpenAIEmbeddingRequest{
-		Model: s.model,
-		Input: cleanTexts,
+	if !s.breaker.allow() {
+		metrics.ObserveEmbeddingRequest(metrics.EmbeddingCircuitOpen)
+		return nil, fmt.Errorf("%w: circuit breaker open after repeated failures", ErrEmbeddingUnavailable)
 	}
 
-	jsonData, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), embeddingRequestTimeout)
+	defer cancel()
 
-	// Make API request
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/embeddings", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	backoff := embeddingInitialBackoff
+	for attempt := 1; ; attempt++ {
+		embeddings, err := s.requestEmbeddings(ctx, cleanTexts)
+		if err == nil || errors.Is(err, ErrEmbeddingDimensionMismatch) {
+			// A mismatch means the provider works but is misconfigured; don't trip the breaker
+			s.breaker.recordSuccess()
+		}
+		if err == nil {
+			metrics.ObserveEmbeddingRequest(metrics.EmbeddingSucceeded)
+			return embeddings, nil
+		}
 
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+		if !isTransientEmbeddingError(err) {
+			metrics.ObserveEmbeddingRequest(metrics.EmbeddingFailed)
+			log.Printf("❌ EMBEDDING: Request rejected: %v", err)
+			return nil, err
+		}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make API request: %w", err)
-	}
-	defer resp.Body.Close()
+		if attempt >= embeddingMaxAttempts || ctx.Err() != nil {
+			if s.breaker.recordFailure() {
+				log.Printf("⚠️  EMBEDDING: Circuit breaker opened for %s after repeated failures", embeddingCircuitCooldown)
+			}
+			metrics.ObserveEmbeddingRequest(metrics.EmbeddingFailed)
+			log.Printf("❌ EMBEDDING: Giving up after %d attempts: %v", attempt, err)
+			return nil, fmt.Errorf("%w: %v", ErrEmbeddingUnavailable, err)
+		}
+
+		log.Printf("⚠️  EMBEDDING: Attempt %d/%d failed, retrying in %s: %v", attempt, embeddingMaxAttempts, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("OpenAI API returned status %d: %s", resp.StatusCode, string(body))
+		backoff *= 2
+		if backoff > embeddingMaxBackoff {
+			backoff = embeddingMaxBackoff
+		}
 	}
+}
 
-	// Parse response
-	var response OpenAIEmbeddingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// requestEmbeddings makes a single call to the provider and converts the vectors,
+// rejecting any that would not fit the embedding columns
+func (s *EmbeddingService) requestEmbeddings(ctx context.Context, texts []string) ([]pgvector.Vector, error) {
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("no embeddings returned from OpenAI API")
+	if len(vectors) != len(texts) {
+		return nil, &permanentEmbeddingError{err: fmt.Errorf("embedding provider returned %d vectors for %d texts", len(vectors), len(texts))}
 	}
 
-	// Convert to pgvector.Vector format
-	embeddings := make([]pgvector.Vector, len(response.Data))
-	for i, data := range response.Data {
-		// Convert []float64 to []float32
-		float32Embedding := make([]float32, len(data.Embedding))
-		for j, val := range data.Embedding {
-			float32Embedding[j] = float32(val)
+	embeddings := make([]pgvector.Vector, len(vectors))
+	for i, vector := range vectors {
+		embeddings[i] = pgvector.NewVector(vector)
+		if err := s.ValidateEmbeddingDimensions(embeddings[i], SkillEmbeddingDimensions); err != nil {
+			return nil, &permanentEmbeddingError{err: fmt.Errorf("%w from model %q", err, s.embedder.Model())}
 		}
-		embeddings[i] = pgvector.NewVector(float32Embedding)
 	}
 
 	return embeddings, nil
@@ -153,7 +233,7 @@ func (s *EmbeddingService) ValidateEmbeddingDimensions(embedding pgvector.Vector
 
 	actualDim := len(embedding.Slice())
 	if actualDim != expectedDim {
-		return fmt.Errorf("embedding dimension mismatch: expected %d, got %d", expectedDim, actualDim)
+		return fmt.Errorf("%w: expected %d, got %d", ErrEmbeddingDimensionMismatch, expectedDim, actualDim)
 	}
 
 	return nil
@@ -161,10 +241,10 @@ func (s *EmbeddingService) ValidateEmbeddingDimensions(embedding pgvector.Vector
 
 // GetEmbeddingModel returns the current embedding model being used
 func (s *EmbeddingService) GetEmbeddingModel() string {
-	return s.model
+	return s.embedder.Model()
 }
 
-// IsAPIKeyConfigured checks if the API key is properly configured
-func (s *EmbeddingService) IsAPIKeyConfigured() bool {
-	return s.apiKey != "" && len(s.apiKey) > 10
+// IsConfigured checks if the embedding provider has the settings it needs
+func (s *EmbeddingService) IsConfigured() bool {
+	return s.embedder.Configured()
 }
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeEmbedder returns errs in turn, one per call, then vectors of the right size
+type fakeEmbedder struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	f.calls++
+	if f.calls <= len(f.errs) && f.errs[f.calls-1] != nil {
+		return nil, f.errs[f.calls-1]
+	}
+	vectors := make([][]float32, len(texts))
+	for i := range vectors {
+		vectors[i] = make([]float32, SkillEmbeddingDimensions)
+	}
+	return vectors, nil
+}
+
+func (f *fakeEmbedder) Model() string    { return "fake-model" }
+func (f *fakeEmbedder) Configured() bool { return true }
+
+func TestGenerateBatchEmbeddingsRetriesTransientErrors(t *testing.T) {
+	embedder := &fakeEmbedder{errs: []error{&embeddingAPIError{statusCode: http.StatusServiceUnavailable}}}
+	service := NewEmbeddingService(embedder)
+
+	embeddings, err := service.GenerateBatchEmbeddings([]string{"first aid"})
+	if err != nil {
+		t.Fatalf("GenerateBatchEmbeddings() error = %v", err)
+	}
+	if len(embeddings) != 1 {
+		t.Errorf("got %d embeddings, want 1", len(embeddings))
+	}
+	if embedder.calls != 2 {
+		t.Errorf("embedder called %d times, want 2", embedder.calls)
+	}
+	if service.breaker.failures != 0 {
+		t.Errorf("breaker failures = %d, want 0 after a success", service.breaker.failures)
+	}
+}
+
+func TestGenerateBatchEmbeddingsGivesUpAfterMaxAttempts(t *testing.T) {
+	errs := make([]error, embeddingMaxAttempts)
+	for i := range errs {
+		errs[i] = &embeddingAPIError{statusCode: http.StatusTooManyRequests}
+	}
+	embedder := &fakeEmbedder{errs: errs}
+	service := NewEmbeddingService(embedder)
+
+	_, err := service.GenerateBatchEmbeddings([]string{"first aid"})
+	if !errors.Is(err, ErrEmbeddingUnavailable) {
+		t.Fatalf("error = %v, want ErrEmbeddingUnavailable", err)
+	}
+	if embedder.calls != embeddingMaxAttempts {
+		t.Errorf("embedder called %d times, want %d", embedder.calls, embeddingMaxAttempts)
+	}
+	if service.breaker.failures != 1 {
+		t.Errorf("breaker failures = %d, want 1", service.breaker.failures)
+	}
+}
+
+func TestGenerateBatchEmbeddingsDoesNotRetryRejectedRequests(t *testing.T) {
+	embedder := &fakeEmbedder{errs: []error{&embeddingAPIError{statusCode: http.StatusBadRequest}}}
+	service := NewEmbeddingService(embedder)
+
+	_, err := service.GenerateBatchEmbeddings([]string{"first aid"})
+	if err == nil || errors.Is(err, ErrEmbeddingUnavailable) {
+		t.Fatalf("error = %v, want a rejection that isn't ErrEmbeddingUnavailable", err)
+	}
+	if embedder.calls != 1 {
+		t.Errorf("embedder called %d times, want 1", embedder.calls)
+	}
+	if service.breaker.failures != 0 {
+		t.Errorf("breaker failures = %d, want 0", service.breaker.failures)
+	}
+}
+
+func TestGenerateBatchEmbeddingsSkipsCallsWhileCircuitOpen(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	service := NewEmbeddingService(embedder)
+	service.breaker.openUntil = time.Now().Add(time.Minute)
+
+	_, err := service.GenerateBatchEmbeddings([]string{"first aid"})
+	if !errors.Is(err, ErrEmbeddingUnavailable) {
+		t.Fatalf("error = %v, want ErrEmbeddingUnavailable", err)
+	}
+	if embedder.calls != 0 {
+		t.Errorf("embedder called %d times while the circuit was open", embedder.calls)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var breaker circuitBreaker
+
+	for i := 1; i < embeddingFailureThreshold; i++ {
+		if breaker.recordFailure() {
+			t.Fatalf("breaker opened after %d failures, want %d", i, embeddingFailureThreshold)
+		}
+		if !breaker.allow() {
+			t.Fatalf("breaker blocks calls after %d failures", i)
+		}
+	}
+
+	if !breaker.recordFailure() {
+		t.Fatalf("breaker did not open after %d failures", embeddingFailureThreshold)
+	}
+	if breaker.allow() {
+		t.Error("breaker allows calls while open")
+	}
+
+	// Once the cooldown passes a call is let through, and another failure reopens it
+	breaker.openUntil = time.Now().Add(-time.Second)
+	if !breaker.allow() {
+		t.Error("breaker blocks calls after the cooldown")
+	}
+	if !breaker.recordFailure() {
+		t.Error("a failure after the cooldown did not reopen the breaker")
+	}
+
+	breaker.recordSuccess()
+	if !breaker.allow() || breaker.failures != 0 {
+		t.Errorf("after a success allow = %v, failures = %d; want true, 0", breaker.allow(), breaker.failures)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidStorageKey is returned for keys that would escape the storage root
+var ErrInvalidStorageKey = fmt.Errorf("invalid storage key")
+
+// FileStorage stores uploaded documents under opaque keys such as "credentials/<id>.pdf"
+type FileStorage interface {
+	// Save writes content under key, replacing any existing file, and returns its size
+	Save(key string, content io.Reader) (int64, error)
+	// Open opens the file stored under key; callers must close it
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the file stored under key. Deleting a missing file is not an error.
+	Delete(key string) error
+}
+
+// LocalFileStorage stores files on the local disk beneath a root directory
+type LocalFileStorage struct {
+	root string
+}
+
+// NewLocalFileStorage creates a file storage rooted at dir
+func NewLocalFileStorage(dir string) *LocalFileStorage {
+	return &LocalFileStorage{root: dir}
+}
+
+// path resolves a key to a path inside the root, rejecting traversal
+func (s *LocalFileStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", ErrInvalidStorageKey
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
+
+// Save writes content under key
+func (s *LocalFileStorage) Save(key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	size, err := io.Copy(dst, content)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return size, nil
+}
+
+// Open opens the file stored under key
+func (s *LocalFileStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Delete removes the file stored under key
+func (s *LocalFileStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
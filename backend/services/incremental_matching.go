@@ -0,0 +1,196 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+// dirtyBatchSize bounds how many changed entities one incremental pass recalculates
+const dirtyBatchSize = 200
+
+// RecalculateForProject recomputes only the matches involving one project, e.g. after
+// its required skills change. Projects that aren't recruiting or active, or that have
+// been deleted, end up with no matches.
+func (s *SkillMatchingService) RecalculateForProject(projectID uuid.UUID) error {
+	recalculationService := models.NewMatchingRecalculationService(s.db)
+	dirty, err := recalculationService.GetDirty(models.MatchingEntityProject, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get dirty mark: %w", err)
+	}
+
+	if _, err := s.db.Exec("DELETE FROM volunteer_project_matches WHERE project_id = $1", projectID); err != nil {
+		return fmt.Errorf("failed to clear project matches: %w", err)
+	}
+
+	var status string
+	var startDate, endDate *time.Time
+	err = s.db.QueryRow("SELECT project_status, start_date, end_date FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).
+		Scan(&status, &startDate, &endDate)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get project status: %w", err)
+	}
+
+	var requiredSkillIDs []int
+	if status == string(models.ProjectStatusRecruiting) || status == string(models.ProjectStatusActive) {
+		projectSkills, err := s.getProjectSkills(projectID)
+		if err != nil {
+			return fmt.Errorf("failed to get project skills: %w", err)
+		}
+		for _, ps := range projectSkills {
+			requiredSkillIDs = append(requiredSkillIDs, ps.SkillID)
+		}
+	}
+
+	if len(requiredSkillIDs) > 0 {
+		volunteers, err := s.getAllVolunteersWithSkills()
+		if err != nil {
+			return fmt.Errorf("failed to get volunteers: %w", err)
+		}
+		availability, err := models.NewVolunteerAvailabilityService(s.db).ListAll()
+		if err != nil {
+			return fmt.Errorf("failed to get volunteer availability: %w", err)
+		}
+		parents, err := s.loadSkillParents()
+		if err != nil {
+			return err
+		}
+
+		for _, volunteer := range volunteers {
+			result := s.CalculateMatch(volunteer.Skills, requiredSkillIDs)
+			s.applyCategoryCredit(&result, volunteer.Skills, parents)
+			applyAvailability(&result, CalculateAvailability(availability[volunteer.ID], startDate, endDate))
+			if result.MatchedSkillCount > 0 || len(result.PartialSkillIDs) > 0 {
+				if err := s.storeProjectMatch(volunteer.ID, projectID, result); err != nil {
+					return fmt.Errorf("failed to store project match: %w", err)
+				}
+			}
+		}
+	}
+
+	if dirty != nil {
+		return recalculationService.ClearDirty(*dirty)
+	}
+	return nil
+}
+
+// RecalculateForVolunteer recomputes only the matches involving one volunteer, e.g.
+// after their skills or availability change
+func (s *SkillMatchingService) RecalculateForVolunteer(volunteerID uuid.UUID) error {
+	recalculationService := models.NewMatchingRecalculationService(s.db)
+	dirty, err := recalculationService.GetDirty(models.MatchingEntityVolunteer, volunteerID)
+	if err != nil {
+		return fmt.Errorf("failed to get dirty mark: %w", err)
+	}
+
+	if _, err := s.db.Exec("DELETE FROM volunteer_project_matches WHERE volunteer_id = $1", volunteerID); err != nil {
+		return fmt.Errorf("failed to clear volunteer matches: %w", err)
+	}
+
+	volunteerSkills, err := s.getVolunteerSkills(volunteerID)
+	if err != nil {
+		return fmt.Errorf("failed to get volunteer skills: %w", err)
+	}
+
+	if len(volunteerSkills) > 0 {
+		skills := make([]VolunteerSkill, 0, len(volunteerSkills))
+		for _, vs := range volunteerSkills {
+			skills = append(skills, VolunteerSkill{SkillID: vs.SkillID, Weight: vs.SkillWeight})
+		}
+
+		projects, err := s.getAllActiveProjectsWithSkills()
+		if err != nil {
+			return fmt.Errorf("failed to get projects: %w", err)
+		}
+		availability, err := models.NewVolunteerAvailabilityService(s.db).ListByVolunteer(volunteerID)
+		if err != nil {
+			return fmt.Errorf("failed to get volunteer availability: %w", err)
+		}
+		parents, err := s.loadSkillParents()
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projects {
+			result := s.CalculateMatch(skills, project.RequiredSkillIDs)
+			s.applyCategoryCredit(&result, skills, parents)
+			applyAvailability(&result, CalculateAvailability(availability, project.StartDate, project.EndDate))
+			if result.MatchedSkillCount > 0 || len(result.PartialSkillIDs) > 0 {
+				if err := s.storeProjectMatch(volunteerID, project.ID, result); err != nil {
+					return fmt.Errorf("failed to store project match: %w", err)
+				}
+			}
+		}
+	}
+
+	if dirty != nil {
+		return recalculationService.ClearDirty(*dirty)
+	}
+	return nil
+}
+
+// EnsureVolunteerMatches computes a volunteer's matches live when none are cached, e.g.
+// right after their skills changed and before the worker caught up. It reports whether
+// a live computation ran.
+func (s *SkillMatchingService) EnsureVolunteerMatches(volunteerID uuid.UUID) (bool, error) {
+	var cached bool
+	err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM volunteer_project_matches WHERE volunteer_id = $1)", volunteerID).Scan(&cached)
+	if err != nil {
+		return false, fmt.Errorf("failed to check cached matches: %w", err)
+	}
+	if cached {
+		return false, nil
+	}
+
+	if err := s.RecalculateForVolunteer(volunteerID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RecalculateDirty recomputes matches for volunteers and projects whose skills changed
+// since they were last recalculated. It returns how many entities were recalculated;
+// failures are logged and left marked so the next pass retries them.
+func (s *SkillMatchingService) RecalculateDirty() (int, error) {
+	recalculationService := models.NewMatchingRecalculationService(s.db)
+	entities, err := recalculationService.ListDirty(dirtyBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list changed entities: %w", err)
+	}
+
+	recalculated := 0
+	for _, entity := range entities {
+		var err error
+		switch entity.EntityType {
+		case models.MatchingEntityProject:
+			err = s.RecalculateForProject(entity.EntityID)
+		case models.MatchingEntityVolunteer:
+			err = s.RecalculateForVolunteer(entity.EntityID)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf("❌ Failed to recalculate matches for %s %s: %v", entity.EntityType, entity.EntityID, err)
+			continue
+		}
+		recalculated++
+	}
+
+	return recalculated, nil
+}
+
+// clearDirtySnapshot clears the dirty marks seen before a full recalculation, which covered them
+func (s *SkillMatchingService) clearDirtySnapshot(entities []models.DirtyMatchingEntity) error {
+	recalculationService := models.NewMatchingRecalculationService(s.db)
+	for _, entity := range entities {
+		if err := recalculationService.ClearDirty(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LocalEmbedder generates embeddings with a self-hosted server speaking the Hugging Face
+// text-embeddings-inference API, so claim text never leaves the organization. The served
+// model must produce SkillEmbeddingDimensions-sized vectors, as all-MiniLM-L6-v2 does.
+type LocalEmbedder struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+// localEmbedRequest represents the request to the /embed endpoint
+type localEmbedRequest struct {
+	Inputs    []string `json:"inputs"`
+	Normalize bool     `json:"normalize"`
+	Truncate  bool     `json:"truncate"`
+}
+
+// NewLocalEmbedder creates a provider for the server at baseURL. model only labels the
+// vectors, since the server decides which model it runs; apiKey is optional.
+func NewLocalEmbedder(baseURL, model, apiKey string) *LocalEmbedder {
+	if model == "" {
+		model = "sentence-transformers/all-MiniLM-L6-v2"
+	}
+
+	return &LocalEmbedder{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		model:   model,
+		apiKey:  apiKey,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Embed implements Embedder
+func (e *LocalEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	requestBody := localEmbedRequest{
+		Inputs:    texts,
+		Normalize: true,
+		Truncate:  true,
+	}
+
+	var embeddings [][]float32
+	if err := postEmbeddingRequest(ctx, e.client, e.baseURL+"/embed", e.apiKey, requestBody, &embeddings); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
+
+// Model implements Embedder
+func (e *LocalEmbedder) Model() string {
+	return e.model
+}
+
+// Configured implements Embedder
+func (e *LocalEmbedder) Configured() bool {
+	return e.baseURL != ""
+}
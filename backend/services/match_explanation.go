@@ -0,0 +1,196 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+// SkillContribution describes how one required skill feeds into a match score
+type SkillContribution struct {
+	SkillID         int     `json:"skill_id"`
+	SkillName       string  `json:"skill_name"`
+	Matched         bool    `json:"matched"`
+	VolunteerWeight float64 `json:"volunteer_weight"` // 0 when the volunteer lacks the skill
+	// CategoryCredit is the partial weight credited through a related skill in the same
+	// category when the volunteer lacks this one
+	CategoryCredit float64 `json:"category_credit,omitempty"`
+	// CosineContribution is this skill's share of the cosine score; the shares sum to it
+	CosineContribution float64 `json:"cosine_contribution"`
+	// CoverageContribution is this skill's share of the coverage score; the shares sum to it
+	CoverageContribution float64 `json:"coverage_contribution"`
+}
+
+// MatchScoreComponents are the scores CalculateMatch produces for a pair
+type MatchScoreComponents struct {
+	CosineScore       float64 `json:"cosine_score"`
+	EuclideanScore    float64 `json:"euclidean_score"`
+	CoverageScore     float64 `json:"coverage_score"`
+	CategoryScore     float64 `json:"category_score"`
+	JaccardIndex      float64 `json:"jaccard_index"`
+	AvailabilityScore float64 `json:"availability_score"`
+}
+
+// ProximityFactor describes the location factor of a match, when one is applied
+type ProximityFactor struct {
+	Applied bool    `json:"applied"`
+	Weight  float64 `json:"weight"`
+	Note    string  `json:"note,omitempty"`
+}
+
+// MatchExplanation breaks a volunteer-project match score down into its parts
+type MatchExplanation struct {
+	VolunteerID       uuid.UUID            `json:"volunteer_id"`
+	ProjectID         uuid.UUID            `json:"project_id"`
+	MatchScore        float64              `json:"match_score"` // The ranking score: the cosine (or, without exact matches, category) component, scaled by availability
+	MatchPercentage   int                  `json:"match_percentage"`
+	Components        MatchScoreComponents `json:"components"`
+	Skills            []SkillContribution  `json:"skills"`
+	MatchedSkills     []SkillContribution  `json:"matched_skills"`
+	MissingSkills     []SkillContribution  `json:"missing_skills"`
+	MatchedSkillCount int                  `json:"matched_skill_count"`
+	TotalRequired     int                  `json:"total_required"`
+	Proximity         ProximityFactor      `json:"proximity"`
+	Availability      AvailabilityFactor   `json:"availability"`
+	// StoredScore is the score from the last batch run, as shown by GetMyMatches.
+	// It is nil when the pair has no stored match.
+	StoredScore  *float64   `json:"stored_score"`
+	CalculatedAt *time.Time `json:"calculated_at"`
+	Stale        bool       `json:"stale"` // Skills or availability changed since the stored score was calculated
+}
+
+// staleScoreTolerance absorbs float rounding between stored and recomputed scores
+const staleScoreTolerance = 1e-9
+
+// ExplainProjectMatch explains how a volunteer's match against a project is scored.
+// It uses the same skill vectors and CalculateMatch as BatchCalculateProjectMatches,
+// so the score reconciles with the stored match unless either side's skills, the
+// volunteer's availability or the project's dates changed.
+func (s *SkillMatchingService) ExplainProjectMatch(volunteerID, projectID uuid.UUID) (*MatchExplanation, error) {
+	volunteerSkills, err := s.getVolunteerSkills(volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volunteer skills: %w", err)
+	}
+
+	projectSkills, err := s.getProjectSkills(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project skills: %w", err)
+	}
+
+	vSkills := make([]VolunteerSkill, 0, len(volunteerSkills))
+	weights := make(map[int]float64, len(volunteerSkills))
+	for _, vs := range volunteerSkills {
+		vSkills = append(vSkills, VolunteerSkill{SkillID: vs.SkillID, Weight: vs.SkillWeight})
+		weights[vs.SkillID] = vs.SkillWeight
+	}
+
+	pSkillIDs := make([]int, 0, len(projectSkills))
+	for _, ps := range projectSkills {
+		pSkillIDs = append(pSkillIDs, ps.SkillID)
+	}
+
+	var startDate, endDate *time.Time
+	err = s.db.QueryRow("SELECT start_date, end_date FROM projects WHERE id = $1", projectID).Scan(&startDate, &endDate)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get project dates: %w", err)
+	}
+
+	availability, err := models.NewVolunteerAvailabilityService(s.db).ListByVolunteer(volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volunteer availability: %w", err)
+	}
+
+	parents, err := s.loadSkillParents()
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.CalculateMatch(vSkills, pSkillIDs)
+	credited := s.applyCategoryCredit(&result, vSkills, parents)
+	availabilityFactor := CalculateAvailability(availability, startDate, endDate)
+	applyAvailability(&result, availabilityFactor)
+
+	explanation := &MatchExplanation{
+		VolunteerID:     volunteerID,
+		ProjectID:       projectID,
+		MatchScore:      result.MatchScore,
+		MatchPercentage: int(result.MatchScore * 100),
+		Components: MatchScoreComponents{
+			CosineScore:       result.CosineScore,
+			EuclideanScore:    result.EuclideanScore,
+			CoverageScore:     result.CoverageScore,
+			CategoryScore:     result.CategoryScore,
+			AvailabilityScore: result.AvailabilityScore,
+		},
+		Skills:            make([]SkillContribution, 0, len(projectSkills)),
+		MatchedSkills:     []SkillContribution{},
+		MissingSkills:     []SkillContribution{},
+		MatchedSkillCount: result.MatchedSkillCount,
+		TotalRequired:     result.TotalRequired,
+		Proximity: ProximityFactor{
+			Applied: false,
+			Note:    "Skill matching does not currently weight by location",
+		},
+		Availability: availabilityFactor,
+	}
+	if result.TotalRequired > 0 {
+		explanation.Components.JaccardIndex = float64(result.MatchedSkillCount) / float64(result.TotalRequired)
+	}
+
+	// Cosine is restricted to the matched skills: sum(w) / (|w| * sqrt(k)),
+	// so each matched skill contributes w / (|w| * sqrt(k))
+	normV := 0.0
+	for _, id := range result.MatchedSkillIDs {
+		normV += weights[id] * weights[id]
+	}
+	cosineDenominator := math.Sqrt(normV) * math.Sqrt(float64(result.MatchedSkillCount))
+
+	for _, ps := range projectSkills {
+		weight, matched := weights[ps.SkillID]
+		contribution := SkillContribution{
+			SkillID:         ps.SkillID,
+			SkillName:       ps.SkillName,
+			Matched:         matched,
+			VolunteerWeight: weight,
+		}
+		if matched && cosineDenominator > 0 {
+			contribution.CosineContribution = weight / cosineDenominator
+		}
+		if !matched {
+			contribution.CategoryCredit = credited[ps.SkillID]
+		}
+		if result.TotalRequired > 0 {
+			contribution.CoverageContribution = (weight + contribution.CategoryCredit) / float64(result.TotalRequired)
+		}
+
+		explanation.Skills = append(explanation.Skills, contribution)
+		if matched {
+			explanation.MatchedSkills = append(explanation.MatchedSkills, contribution)
+		} else {
+			explanation.MissingSkills = append(explanation.MissingSkills, contribution)
+		}
+	}
+
+	var storedScore float64
+	var calculatedAt time.Time
+	err = s.db.QueryRow(`
+		SELECT match_score, calculated_at
+		FROM volunteer_project_matches
+		WHERE volunteer_id = $1 AND project_id = $2
+	`, volunteerID, projectID).Scan(&storedScore, &calculatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get stored match: %w", err)
+	}
+	if err == nil {
+		explanation.StoredScore = &storedScore
+		explanation.CalculatedAt = &calculatedAt
+		explanation.Stale = math.Abs(storedScore-result.MatchScore) > staleScoreTolerance
+	}
+
+	return explanation, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// dmEmailFallbackLookback bounds how far back the fallback looks, so enabling it
+	// doesn't email users about a backlog of old messages
+	dmEmailFallbackLookback = 7 * 24 * time.Hour
+	// dmEmailFallbackBatchSize caps how many messages are emailed per run
+	dmEmailFallbackBatchSize = 100
+	// dmEmailFallbackMaxAttempts is how many times a message's email is tried before
+	// giving up on it
+	dmEmailFallbackMaxAttempts = 5
+	// dmEmailFallbackRetryAfter is the wait after a failed first attempt; it doubles
+	// with each attempt after that
+	dmEmailFallbackRetryAfter = 5 * time.Minute
+	// dmEmailPreviewLength caps how much of the message is quoted in the email
+	dmEmailPreviewLength = 280
+)
+
+// unreadMessageStore is the part of models.MessageService the email fallback uses
+type unreadMessageStore interface {
+	GetUnreadDirectMessagesForEmail(before, since time.Time, maxAttempts, limit int) ([]models.UnreadDirectMessage, error)
+	ClaimEmailFallback(messageID, userID uuid.UUID, maxAttempts int, retryAfter time.Duration) (bool, error)
+	MarkEmailFallbackDelivered(messageID, userID uuid.UUID) error
+}
+
+// unreadMessageMailer is the part of EmailService the email fallback uses
+type unreadMessageMailer interface {
+	SendUnreadMessageEmail(to, recipientName, senderName, preview, link string) error
+}
+
+// MessageEmailFallbackService emails recipients about direct messages they haven't read
+type MessageEmailFallbackService struct {
+	messageService unreadMessageStore
+	emailService   unreadMessageMailer
+	window         time.Duration
+	frontendURL    string
+}
+
+// NewMessageEmailFallbackService creates a new message email fallback service
+func NewMessageEmailFallbackService(messageService *models.MessageService, emailService *EmailService, window time.Duration, frontendURL string) *MessageEmailFallbackService {
+	return &MessageEmailFallbackService{
+		messageService: messageService,
+		emailService:   emailService,
+		window:         window,
+		frontendURL:    frontendURL,
+	}
+}
+
+// ProcessUnreadDirectMessages emails recipients of direct messages that have stayed unread
+// longer than the configured window. Each message is emailed at most once per recipient;
+// failed sends are retried with backoff up to dmEmailFallbackMaxAttempts times.
+func (s *MessageEmailFallbackService) ProcessUnreadDirectMessages() (int, error) {
+	now := time.Now()
+	messages, err := s.messageService.GetUnreadDirectMessagesForEmail(now.Add(-s.window), now.Add(-dmEmailFallbackLookback), dmEmailFallbackMaxAttempts, dmEmailFallbackBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unread direct messages: %w", err)
+	}
+
+	sent := 0
+	for _, msg := range messages {
+		claimed, err := s.messageService.ClaimEmailFallback(msg.MessageID, msg.RecipientID, dmEmailFallbackMaxAttempts, dmEmailFallbackRetryAfter)
+		if err != nil {
+			log.Printf("❌ DM_EMAIL_FALLBACK: Failed to claim message %s: %v", msg.MessageID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		link := fmt.Sprintf("%s/messages?message=%s", s.frontendURL, msg.MessageID)
+		if err := s.emailService.SendUnreadMessageEmail(msg.RecipientEmail, msg.RecipientName, msg.SenderName, messagePreview(msg), link); err != nil {
+			// The claim stays, so the message is retried once its backoff has passed
+			log.Printf("❌ DM_EMAIL_FALLBACK: Failed to email %s about message %s: %v", msg.RecipientEmail, msg.MessageID, err)
+			continue
+		}
+		if err := s.messageService.MarkEmailFallbackDelivered(msg.MessageID, msg.RecipientID); err != nil {
+			log.Printf("❌ DM_EMAIL_FALLBACK: Failed to record email about message %s: %v", msg.MessageID, err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// messagePreview builds a short plain-text summary of a message
+func messagePreview(msg models.UnreadDirectMessage) string {
+	preview := []rune(msg.MessageText)
+	text := string(preview)
+	if len(preview) > dmEmailPreviewLength {
+		text = string(preview[:dmEmailPreviewLength]) + "…"
+	}
+	if msg.Subject != nil && *msg.Subject != "" {
+		text = *msg.Subject + ": " + text
+	}
+	return text
+}
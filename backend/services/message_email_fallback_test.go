@@ -0,0 +1,259 @@
+package services
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/fakesql"
+
+	"github.com/google/uuid"
+)
+
+type fakeUnreadMessageStore struct {
+	messages    []models.UnreadDirectMessage
+	claimErr    map[uuid.UUID]error
+	unclaimed   map[uuid.UUID]bool
+	delivered   []uuid.UUID
+	before      time.Time
+	since       time.Time
+	batchLimit  int
+	maxAttempts int
+	retryAfter  time.Duration
+}
+
+func (f *fakeUnreadMessageStore) GetUnreadDirectMessagesForEmail(before, since time.Time, maxAttempts, limit int) ([]models.UnreadDirectMessage, error) {
+	f.before, f.since, f.maxAttempts, f.batchLimit = before, since, maxAttempts, limit
+	return f.messages, nil
+}
+
+func (f *fakeUnreadMessageStore) ClaimEmailFallback(messageID, userID uuid.UUID, maxAttempts int, retryAfter time.Duration) (bool, error) {
+	f.retryAfter = retryAfter
+	if err := f.claimErr[messageID]; err != nil {
+		return false, err
+	}
+	return !f.unclaimed[messageID], nil
+}
+
+func (f *fakeUnreadMessageStore) MarkEmailFallbackDelivered(messageID, userID uuid.UUID) error {
+	f.delivered = append(f.delivered, messageID)
+	return nil
+}
+
+type sentUnreadEmail struct {
+	to, recipientName, senderName, preview, link string
+}
+
+type fakeUnreadMessageMailer struct {
+	sent    []sentUnreadEmail
+	failFor map[string]bool
+}
+
+func (f *fakeUnreadMessageMailer) SendUnreadMessageEmail(to, recipientName, senderName, preview, link string) error {
+	if f.failFor[to] {
+		return errors.New("mailgun unavailable")
+	}
+	f.sent = append(f.sent, sentUnreadEmail{to, recipientName, senderName, preview, link})
+	return nil
+}
+
+func unreadMessage(recipientEmail, text string) models.UnreadDirectMessage {
+	return models.UnreadDirectMessage{
+		MessageID:      uuid.New(),
+		SenderName:     "Sam",
+		MessageText:    text,
+		RecipientID:    uuid.New(),
+		RecipientEmail: recipientEmail,
+		RecipientName:  "Alex",
+	}
+}
+
+func TestProcessUnreadDirectMessages(t *testing.T) {
+	delivered := unreadMessage("delivered@example.com", "See you at the cleanup")
+	alreadyClaimed := unreadMessage("claimed@example.com", "Already emailed by another worker")
+	claimFails := unreadMessage("claimfails@example.com", "Claim errors out")
+	sendFails := unreadMessage("sendfails@example.com", "Mail provider is down")
+
+	store := &fakeUnreadMessageStore{
+		messages:  []models.UnreadDirectMessage{delivered, alreadyClaimed, claimFails, sendFails},
+		claimErr:  map[uuid.UUID]error{claimFails.MessageID: errors.New("connection reset")},
+		unclaimed: map[uuid.UUID]bool{alreadyClaimed.MessageID: true},
+	}
+	mailer := &fakeUnreadMessageMailer{failFor: map[string]bool{"sendfails@example.com": true}}
+	service := &MessageEmailFallbackService{
+		messageService: store,
+		emailService:   mailer,
+		window:         time.Hour,
+		frontendURL:    "https://civicweave.example",
+	}
+
+	start := time.Now()
+	sent, err := service.ProcessUnreadDirectMessages()
+	if err != nil {
+		t.Fatalf("ProcessUnreadDirectMessages() error = %v", err)
+	}
+
+	if sent != 1 {
+		t.Errorf("sent = %d, want 1", sent)
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0].to != "delivered@example.com" {
+		t.Fatalf("emails sent = %+v, want one to delivered@example.com", mailer.sent)
+	}
+	wantLink := "https://civicweave.example/messages?message=" + delivered.MessageID.String()
+	if mailer.sent[0].link != wantLink {
+		t.Errorf("link = %q, want %q", mailer.sent[0].link, wantLink)
+	}
+
+	// A failed send keeps its claim undelivered, so it is retried after its backoff
+	if len(store.delivered) != 1 || store.delivered[0] != delivered.MessageID {
+		t.Errorf("delivered = %v, want only %s", store.delivered, delivered.MessageID)
+	}
+	if store.maxAttempts != dmEmailFallbackMaxAttempts || store.retryAfter != dmEmailFallbackRetryAfter {
+		t.Errorf("max attempts = %d, retry after = %v, want %d and %v",
+			store.maxAttempts, store.retryAfter, dmEmailFallbackMaxAttempts, dmEmailFallbackRetryAfter)
+	}
+
+	if got := start.Sub(store.before); got < time.Hour-time.Minute || got > time.Hour+time.Minute {
+		t.Errorf("before is %v ago, want about %v", got, time.Hour)
+	}
+	if got := start.Sub(store.since); got < dmEmailFallbackLookback-time.Minute || got > dmEmailFallbackLookback+time.Minute {
+		t.Errorf("since is %v ago, want about %v", got, dmEmailFallbackLookback)
+	}
+	if store.batchLimit != dmEmailFallbackBatchSize {
+		t.Errorf("batch limit = %d, want %d", store.batchLimit, dmEmailFallbackBatchSize)
+	}
+}
+
+// TestProcessUnreadDirectMessagesQueries runs the fallback against the real message
+// queries, checking what they ask the database rather than faking the store
+func TestProcessUnreadDirectMessagesQueries(t *testing.T) {
+	tests := []struct {
+		name string
+		// claimed is how many rows the claim affects: none once the recipient has read
+		// the message, or when it is delivered, out of attempts or backing off
+		claimed       int64
+		wantEmailed   bool
+		wantDelivered bool
+	}{
+		{name: "still unread", claimed: 1, wantEmailed: true, wantDelivered: true},
+		{name: "read before the claim", claimed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageID, senderID, recipientID := uuid.New(), uuid.New(), uuid.New()
+			db, recorder := fakesql.Open()
+			recorder.Rows("FROM project_messages pm",
+				[]string{"id", "sender_id", "subject", "message_text", "created_at", "sender_name", "id", "email", "recipient_name"},
+				[]driver.Value{messageID.String(), senderID.String(), nil, "Are you coming?", time.Now().Add(-2 * time.Hour), "Sam", recipientID.String(), "alex@example.com", "Alex"},
+			)
+			recorder.Affects("INSERT INTO message_email_fallbacks", tt.claimed)
+
+			mailer := &fakeUnreadMessageMailer{}
+			service := &MessageEmailFallbackService{
+				messageService: models.NewMessageService(db),
+				emailService:   mailer,
+				window:         time.Hour,
+				frontendURL:    "https://civicweave.example",
+			}
+
+			if _, err := service.ProcessUnreadDirectMessages(); err != nil {
+				t.Fatalf("ProcessUnreadDirectMessages() error = %v", err)
+			}
+
+			if emailed := len(mailer.sent) > 0; emailed != tt.wantEmailed {
+				t.Errorf("emailed = %v, want %v", emailed, tt.wantEmailed)
+			}
+			if delivered := recorder.Ran("SET delivered_at"); delivered != tt.wantDelivered {
+				t.Errorf("marked delivered = %v, want %v", delivered, tt.wantDelivered)
+			}
+
+			for _, statement := range recorder.Statements() {
+				switch {
+				case strings.Contains(statement.Query, "FROM project_messages pm"):
+					if !strings.Contains(statement.Query, "FROM message_reads mr") {
+						t.Error("unread query doesn't leave out read messages")
+					}
+					if len(statement.Args) != 4 || statement.Args[3] != int64(dmEmailFallbackMaxAttempts) {
+						t.Errorf("unread query args = %v, want max attempts %d last", statement.Args, dmEmailFallbackMaxAttempts)
+					}
+				case strings.Contains(statement.Query, "INSERT INTO message_email_fallbacks"):
+					// The claim checks the read again, so a message read since the
+					// unread query ran isn't emailed
+					if !strings.Contains(statement.Query, "FROM message_reads mr") {
+						t.Error("claim doesn't check whether the message has been read")
+					}
+					want := []driver.Value{messageID.String(), recipientID.String(), int64(dmEmailFallbackMaxAttempts), dmEmailFallbackRetryAfter.Seconds()}
+					if len(statement.Args) != len(want) {
+						t.Fatalf("claim args = %v, want %v", statement.Args, want)
+					}
+					for i := range want {
+						if statement.Args[i] != want[i] {
+							t.Errorf("claim arg %d = %v, want %v", i+1, statement.Args[i], want[i])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMessagePreview(t *testing.T) {
+	subject := "Saturday & Sunday"
+	long := strings.Repeat("a", dmEmailPreviewLength+10)
+
+	tests := []struct {
+		name string
+		msg  models.UnreadDirectMessage
+		want string
+	}{
+		{
+			name: "plain text is not escaped",
+			msg:  models.UnreadDirectMessage{MessageText: "Bring <gloves> & bags"},
+			want: "Bring <gloves> & bags",
+		},
+		{
+			name: "subject is prefixed",
+			msg:  models.UnreadDirectMessage{Subject: &subject, MessageText: "Which day works?"},
+			want: "Saturday & Sunday: Which day works?",
+		},
+		{
+			name: "long messages are truncated",
+			msg:  models.UnreadDirectMessage{MessageText: long},
+			want: long[:dmEmailPreviewLength] + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messagePreview(tt.msg); got != tt.want {
+				t.Errorf("messagePreview() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnreadMessageEmailContentEscapesHTMLOnly(t *testing.T) {
+	_, htmlBody, textBody := unreadMessageEmailContent("<b>Alex</b>", "Sam & Co", "Bring <gloves>", "https://civicweave.example/messages")
+
+	for _, want := range []string{"&lt;b&gt;Alex&lt;/b&gt;", "Sam &amp; Co", "Bring &lt;gloves&gt;"} {
+		if !strings.Contains(htmlBody, want) {
+			t.Errorf("HTML body is missing %q", want)
+		}
+	}
+	if strings.Contains(htmlBody, "<b>Alex</b>") || strings.Contains(htmlBody, "<gloves>") {
+		t.Error("HTML body contains unescaped user input")
+	}
+
+	for _, want := range []string{"Hi <b>Alex</b>,", "Sam & Co sent you", "Bring <gloves>"} {
+		if !strings.Contains(textBody, want) {
+			t.Errorf("text body is missing %q", want)
+		}
+	}
+	if strings.Contains(textBody, "&amp;") || strings.Contains(textBody, "&lt;") {
+		t.Error("text body contains HTML escapes")
+	}
+}
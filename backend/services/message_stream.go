@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// publishTimeout bounds how long sending a message waits on Redis
+const publishTimeout = 2 * time.Second
+
+const (
+	// TypingEventTTL is how long a typing indicator stays valid after it is sent
+	TypingEventTTL = 5 * time.Second
+	// PresenceHeartbeatInterval is how often a connected stream refreshes its presence
+	PresenceHeartbeatInterval = 15 * time.Second
+	// PresenceTTL is how long a user counts as online without a heartbeat
+	PresenceTTL = 45 * time.Second
+)
+
+// Activity event types published alongside project messages
+const (
+	ActivityEventTyping  = "typing"
+	ActivityEventOnline  = "online"
+	ActivityEventOffline = "offline"
+)
+
+// ProjectActivityEvent is a transient typing or presence notice. It is only
+// ever sent over Pub/Sub and is never stored.
+type ProjectActivityEvent struct {
+	Type      string    `json:"type"`
+	ProjectID uuid.UUID `json:"project_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	SentAt    time.Time `json:"sent_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// StreamEvent is a single event delivered to a stream subscriber. Name is the
+// server-sent event name ("message", "typing" or "presence") and Data is the
+// JSON payload.
+type StreamEvent struct {
+	Name string
+	Data string
+}
+
+// ErrStreamingUnavailable is returned when real-time delivery is disabled
+var ErrStreamingUnavailable = fmt.Errorf("real-time message streaming is unavailable")
+
+// MessageStreamService fans new project messages out to connected clients over
+// Redis Pub/Sub. With a nil Redis client it is a no-op and clients keep polling.
+type MessageStreamService struct {
+	redis *redis.Client
+}
+
+// NewMessageStreamService creates a new message stream service. Pass nil when
+// Redis is unavailable.
+func NewMessageStreamService(client *redis.Client) *MessageStreamService {
+	return &MessageStreamService{redis: client}
+}
+
+// Enabled reports whether messages are being published
+func (s *MessageStreamService) Enabled() bool {
+	return s != nil && s.redis != nil
+}
+
+// projectChannel returns the Pub/Sub channel for a project's messages
+func projectChannel(projectID uuid.UUID) string {
+	return "project_messages:" + projectID.String()
+}
+
+// projectActivityChannel returns the Pub/Sub channel for a project's typing
+// and presence events
+func projectActivityChannel(projectID uuid.UUID) string {
+	return "project_activity:" + projectID.String()
+}
+
+// projectPresenceKey returns the sorted set holding who is online in a project,
+// scored by when each user's presence expires
+func projectPresenceKey(projectID uuid.UUID) string {
+	return "project_presence:" + projectID.String()
+}
+
+// PublishProjectMessage publishes a newly created message to its project's
+// channel. Messages without a project are ignored. Failures are logged, not
+// returned, since the message has already been stored.
+func (s *MessageStreamService) PublishProjectMessage(message *models.ProjectMessage) {
+	if !s.Enabled() || message.ProjectID == nil {
+		return
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("❌ MESSAGE_STREAM: Failed to encode message %s: %v", message.ID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := s.redis.Publish(ctx, projectChannel(*message.ProjectID), payload).Err(); err != nil {
+		log.Printf("❌ MESSAGE_STREAM: Failed to publish message %s: %v", message.ID, err)
+	}
+}
+
+// PublishTyping tells the project's subscribers that a user is typing. The
+// event expires after TypingEventTTL.
+func (s *MessageStreamService) PublishTyping(projectID, userID uuid.UUID) error {
+	if !s.Enabled() {
+		return ErrStreamingUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	return s.publishActivity(ctx, projectID, userID, ActivityEventTyping, TypingEventTTL)
+}
+
+// MarkOnline records that a user has the project's stream open and announces
+// it to the other subscribers. Presence lapses after PresenceTTL unless it is
+// refreshed.
+func (s *MessageStreamService) MarkOnline(ctx context.Context, projectID, userID uuid.UUID) error {
+	if !s.Enabled() {
+		return ErrStreamingUnavailable
+	}
+
+	key := projectPresenceKey(projectID)
+	expiresAt := time.Now().Add(PresenceTTL)
+
+	pipe := s.redis.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(expiresAt.Unix()), Member: userID.String()})
+	pipe.Expire(ctx, key, PresenceTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return s.publishActivity(ctx, projectID, userID, ActivityEventOnline, PresenceTTL)
+}
+
+// MarkOffline removes a user's presence and announces that they left
+func (s *MessageStreamService) MarkOffline(ctx context.Context, projectID, userID uuid.UUID) error {
+	if !s.Enabled() {
+		return ErrStreamingUnavailable
+	}
+
+	if err := s.redis.ZRem(ctx, projectPresenceKey(projectID), userID.String()).Err(); err != nil {
+		return err
+	}
+
+	return s.publishActivity(ctx, projectID, userID, ActivityEventOffline, PresenceTTL)
+}
+
+// OnlineUsers returns the users whose presence in the project has not lapsed
+func (s *MessageStreamService) OnlineUsers(ctx context.Context, projectID uuid.UUID) ([]uuid.UUID, error) {
+	if !s.Enabled() {
+		return nil, ErrStreamingUnavailable
+	}
+
+	key := projectPresenceKey(projectID)
+	now := fmt.Sprintf("%d", time.Now().Unix())
+
+	if err := s.redis.ZRemRangeByScore(ctx, key, "-inf", now).Err(); err != nil {
+		return nil, err
+	}
+
+	members, err := s.redis.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// publishActivity publishes a typing or presence event valid for ttl
+func (s *MessageStreamService) publishActivity(ctx context.Context, projectID, userID uuid.UUID, eventType string, ttl time.Duration) error {
+	now := time.Now().UTC()
+	payload, err := json.Marshal(ProjectActivityEvent{
+		Type:      eventType,
+		ProjectID: projectID,
+		UserID:    userID,
+		SentAt:    now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.redis.Publish(ctx, projectActivityChannel(projectID), payload).Err()
+}
+
+// SubscribeProject subscribes to a project's messages and its typing and
+// presence events. Message events carry a JSON-encoded ProjectMessage and the
+// others a ProjectActivityEvent; activity that has already expired when it is
+// received is dropped. The subscription ends when ctx is cancelled; callers
+// must call the returned close function when done.
+func (s *MessageStreamService) SubscribeProject(ctx context.Context, projectID uuid.UUID) (<-chan StreamEvent, func() error, error) {
+	if !s.Enabled() {
+		return nil, nil, ErrStreamingUnavailable
+	}
+
+	messagesChannel := projectChannel(projectID)
+	pubsub := s.redis.Subscribe(ctx, messagesChannel, projectActivityChannel(projectID))
+
+	// Wait for the subscription to be confirmed so no messages are missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, err
+	}
+
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		for msg := range pubsub.Channel() {
+			event := StreamEvent{Name: "message", Data: msg.Payload}
+			if msg.Channel != messagesChannel {
+				var ok bool
+				if event, ok = activityStreamEvent(msg.Payload); !ok {
+					continue
+				}
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, pubsub.Close, nil
+}
+
+// activityStreamEvent converts a published activity payload into a stream
+// event, reporting false for malformed or expired events
+func activityStreamEvent(payload string) (StreamEvent, bool) {
+	var activity ProjectActivityEvent
+	if err := json.Unmarshal([]byte(payload), &activity); err != nil {
+		return StreamEvent{}, false
+	}
+	if time.Now().After(activity.ExpiresAt) {
+		return StreamEvent{}, false
+	}
+
+	name := "presence"
+	if activity.Type == ActivityEventTyping {
+		name = "typing"
+	}
+
+	return StreamEvent{Name: name, Data: payload}, true
+}
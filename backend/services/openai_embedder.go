@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// openAIEmbeddingsURL is the OpenAI embeddings endpoint
+const openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// OpenAIEmbedder generates embeddings with the OpenAI API. Vectors are requested at
+// SkillEmbeddingDimensions, which text-embedding-3 models support natively.
+type OpenAIEmbedder struct {
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+// OpenAIEmbeddingRequest represents the request to OpenAI embeddings API
+type OpenAIEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+// OpenAIEmbeddingResponse represents the response from OpenAI embeddings API
+type OpenAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// NewOpenAIEmbedder creates an OpenAI embedding provider
+func NewOpenAIEmbedder(apiKey, model string) *OpenAIEmbedder {
+	if model == "" {
+		model = "text-embedding-3-small" // Default model
+	}
+
+	return &OpenAIEmbedder{
+		apiKey: apiKey,
+		model:  model,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Embed implements Embedder
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	requestBody := OpenAIEmbeddingRequest{
+		Model:      e.model,
+		Input:      texts,
+		Dimensions: SkillEmbeddingDimensions,
+	}
+
+	var response OpenAIEmbeddingResponse
+	if err := postEmbeddingRequest(ctx, e.client, openAIEmbeddingsURL, e.apiKey, requestBody, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, &permanentEmbeddingError{err: fmt.Errorf("no embeddings returned from OpenAI API")}
+	}
+
+	// Results carry their input's index; return them in input order
+	sort.Slice(response.Data, func(i, j int) bool {
+		return response.Data[i].Index < response.Data[j].Index
+	})
+
+	embeddings := make([][]float32, len(response.Data))
+	for i, data := range response.Data {
+		embeddings[i] = data.Embedding
+	}
+	return embeddings, nil
+}
+
+// Model implements Embedder
+func (e *OpenAIEmbedder) Model() string {
+	return e.model
+}
+
+// Configured implements Embedder
+func (e *OpenAIEmbedder) Configured() bool {
+	return e.apiKey != "" && len(e.apiKey) > 10
+}
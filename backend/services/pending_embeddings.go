@@ -0,0 +1,189 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+	"github.com/pgvector/pgvector-go"
+)
+
+// Queued claims are retried after pendingEmbeddingBaseDelay, doubling with each failed
+// attempt up to pendingEmbeddingMaxDelay. After pendingEmbeddingMaxAttempts attempts a
+// claim is marked failed and no longer retried.
+const (
+	pendingEmbeddingBaseDelay   = time.Minute
+	pendingEmbeddingMaxDelay    = time.Hour
+	pendingEmbeddingMaxAttempts = 12
+)
+
+// pendingEmbeddingStore is the part of models.PendingEmbeddingService the processor uses
+type pendingEmbeddingStore interface {
+	ListDue(limit int) ([]models.PendingEmbedding, error)
+	Complete(pending *models.PendingEmbedding, embedding pgvector.Vector, model string) (*models.SkillClaim, error)
+	RecordFailure(id uuid.UUID, cause error, retryAt time.Time) error
+	MarkFailed(id uuid.UUID, cause error) error
+}
+
+// claimEmbedder is the part of EmbeddingService the processor uses
+type claimEmbedder interface {
+	GenerateEmbedding(skillText string) (pgvector.Vector, error)
+	GenerateBatchEmbeddings(skillTexts []string) ([]pgvector.Vector, error)
+	GetEmbeddingModel() string
+}
+
+// claimAggregator is the part of VectorAggregationService the processor uses
+type claimAggregator interface {
+	TriggerAggregationOnClaimChange(volunteerID uuid.UUID) error
+}
+
+// PendingEmbeddingProcessor turns queued skill claims into skill claims once their
+// embeddings can be generated
+type PendingEmbeddingProcessor struct {
+	pendingService     pendingEmbeddingStore
+	embeddingService   claimEmbedder
+	aggregationService claimAggregator
+}
+
+// NewPendingEmbeddingProcessor creates a new pending embedding processor
+func NewPendingEmbeddingProcessor(
+	pendingService *models.PendingEmbeddingService,
+	embeddingService *EmbeddingService,
+	aggregationService *VectorAggregationService,
+) *PendingEmbeddingProcessor {
+	return &PendingEmbeddingProcessor{
+		pendingService:     pendingService,
+		embeddingService:   embeddingService,
+		aggregationService: aggregationService,
+	}
+}
+
+// ProcessDue embeds up to limit queued claims whose next attempt is due, in one batch,
+// and returns how many became skill claims. While the API is unavailable every claim is
+// rescheduled with backoff. If the batch is rejected for any other reason, each claim is
+// embedded on its own so one bad claim can't hold back the rest; claims rejected on
+// their own are marked failed.
+func (p *PendingEmbeddingProcessor) ProcessDue(limit int) (int, error) {
+	due, err := p.pendingService.ListDue(limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending embeddings: %w", err)
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	texts := make([]string, len(due))
+	for i, pending := range due {
+		texts[i] = pending.ClaimText
+	}
+
+	embeddings, err := p.embeddingService.GenerateBatchEmbeddings(texts)
+	if err == nil && len(embeddings) != len(due) {
+		err = fmt.Errorf("embedding API returned %d vectors for %d claims", len(embeddings), len(due))
+	}
+	if err != nil {
+		if isRetryablePendingEmbeddingError(err) {
+			for i := range due {
+				p.recordFailure(&due[i], err)
+			}
+			return 0, err
+		}
+
+		log.Printf("⚠️  PENDING_EMBEDDINGS: Batch of %d claims rejected, embedding them one by one: %v", len(due), err)
+		return p.processIndividually(due), nil
+	}
+
+	model := p.embeddingService.GetEmbeddingModel()
+	embedded := 0
+	for i := range due {
+		if p.complete(&due[i], embeddings[i], model) {
+			embedded++
+		}
+	}
+
+	return embedded, nil
+}
+
+// processIndividually embeds each claim with its own request and returns how many
+// became skill claims
+func (p *PendingEmbeddingProcessor) processIndividually(due []models.PendingEmbedding) int {
+	model := p.embeddingService.GetEmbeddingModel()
+	embedded := 0
+	for i := range due {
+		pending := &due[i]
+		embedding, err := p.embeddingService.GenerateEmbedding(pending.ClaimText)
+		if err != nil {
+			if isRetryablePendingEmbeddingError(err) {
+				p.recordFailure(pending, err)
+			} else {
+				p.markFailed(pending, err)
+			}
+			continue
+		}
+		if p.complete(pending, embedding, model) {
+			embedded++
+		}
+	}
+	return embedded
+}
+
+// complete turns a queued claim into a skill claim and reports whether it did
+func (p *PendingEmbeddingProcessor) complete(pending *models.PendingEmbedding, embedding pgvector.Vector, model string) bool {
+	if _, err := p.pendingService.Complete(pending, embedding, model); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("❌ PENDING_EMBEDDINGS: Failed to create skill claim for %s: %v", pending.ID, err)
+		}
+		return false
+	}
+
+	if err := p.aggregationService.TriggerAggregationOnClaimChange(pending.VolunteerID); err != nil {
+		log.Printf("❌ PENDING_EMBEDDINGS: Failed to aggregate skills for volunteer %s: %v", pending.VolunteerID, err)
+	}
+	return true
+}
+
+// recordFailure reschedules a claim after a failed attempt, or marks it failed once it
+// has used all its attempts
+func (p *PendingEmbeddingProcessor) recordFailure(pending *models.PendingEmbedding, cause error) {
+	if pending.Attempts+1 >= pendingEmbeddingMaxAttempts {
+		p.markFailed(pending, cause)
+		return
+	}
+
+	retryAt := time.Now().Add(pendingEmbeddingRetryDelay(pending.Attempts))
+	if err := p.pendingService.RecordFailure(pending.ID, cause, retryAt); err != nil {
+		log.Printf("❌ PENDING_EMBEDDINGS: Failed to reschedule %s: %v", pending.ID, err)
+	}
+}
+
+// markFailed stops retrying a claim
+func (p *PendingEmbeddingProcessor) markFailed(pending *models.PendingEmbedding, cause error) {
+	log.Printf("⚠️  PENDING_EMBEDDINGS: Giving up on %s after %d attempts: %v", pending.ID, pending.Attempts+1, cause)
+	if err := p.pendingService.MarkFailed(pending.ID, cause); err != nil {
+		log.Printf("❌ PENDING_EMBEDDINGS: Failed to mark %s failed: %v", pending.ID, err)
+	}
+}
+
+// isRetryablePendingEmbeddingError reports whether a failed embedding is worth retrying
+// later: the API being unavailable is, and so is a dimension mismatch, which means the
+// provider is misconfigured rather than the claim being bad
+func isRetryablePendingEmbeddingError(err error) bool {
+	return errors.Is(err, ErrEmbeddingUnavailable) || errors.Is(err, ErrEmbeddingDimensionMismatch)
+}
+
+// pendingEmbeddingRetryDelay returns how long to wait after a claim's attempts-th failure
+func pendingEmbeddingRetryDelay(attempts int) time.Duration {
+	delay := pendingEmbeddingBaseDelay
+	for i := 1; i < attempts && delay < pendingEmbeddingMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > pendingEmbeddingMaxDelay {
+		delay = pendingEmbeddingMaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+	"github.com/pgvector/pgvector-go"
+)
+
+type fakePendingEmbeddingStore struct {
+	due         []models.PendingEmbedding
+	completed   []uuid.UUID
+	rescheduled map[uuid.UUID]time.Time
+	failed      []uuid.UUID
+}
+
+func (f *fakePendingEmbeddingStore) ListDue(limit int) ([]models.PendingEmbedding, error) {
+	return f.due, nil
+}
+
+func (f *fakePendingEmbeddingStore) Complete(pending *models.PendingEmbedding, embedding pgvector.Vector, model string) (*models.SkillClaim, error) {
+	f.completed = append(f.completed, pending.ID)
+	return &models.SkillClaim{ID: uuid.New(), VolunteerID: pending.VolunteerID}, nil
+}
+
+func (f *fakePendingEmbeddingStore) RecordFailure(id uuid.UUID, cause error, retryAt time.Time) error {
+	if f.rescheduled == nil {
+		f.rescheduled = map[uuid.UUID]time.Time{}
+	}
+	f.rescheduled[id] = retryAt
+	return nil
+}
+
+func (f *fakePendingEmbeddingStore) MarkFailed(id uuid.UUID, cause error) error {
+	f.failed = append(f.failed, id)
+	return nil
+}
+
+// fakeClaimEmbedder fails batches with batchErr and single claims whose text is in
+// claimErrs
+type fakeClaimEmbedder struct {
+	batchErr  error
+	claimErrs map[string]error
+}
+
+func (f *fakeClaimEmbedder) GenerateEmbedding(skillText string) (pgvector.Vector, error) {
+	if err := f.claimErrs[skillText]; err != nil {
+		return pgvector.Vector{}, err
+	}
+	return pgvector.NewVector(make([]float32, SkillEmbeddingDimensions)), nil
+}
+
+func (f *fakeClaimEmbedder) GenerateBatchEmbeddings(skillTexts []string) ([]pgvector.Vector, error) {
+	if f.batchErr != nil {
+		return nil, f.batchErr
+	}
+	embeddings := make([]pgvector.Vector, len(skillTexts))
+	for i := range embeddings {
+		embeddings[i] = pgvector.NewVector(make([]float32, SkillEmbeddingDimensions))
+	}
+	return embeddings, nil
+}
+
+func (f *fakeClaimEmbedder) GetEmbeddingModel() string { return "fake-model" }
+
+type fakeClaimAggregator struct {
+	volunteers []uuid.UUID
+}
+
+func (f *fakeClaimAggregator) TriggerAggregationOnClaimChange(volunteerID uuid.UUID) error {
+	f.volunteers = append(f.volunteers, volunteerID)
+	return nil
+}
+
+func pendingClaim(text string, attempts int) models.PendingEmbedding {
+	return models.PendingEmbedding{ID: uuid.New(), VolunteerID: uuid.New(), ClaimText: text, Attempts: attempts}
+}
+
+func TestProcessDueEmbedsBatch(t *testing.T) {
+	store := &fakePendingEmbeddingStore{due: []models.PendingEmbedding{pendingClaim("first aid", 1), pendingClaim("carpentry", 2)}}
+	aggregator := &fakeClaimAggregator{}
+	processor := &PendingEmbeddingProcessor{pendingService: store, embeddingService: &fakeClaimEmbedder{}, aggregationService: aggregator}
+
+	embedded, err := processor.ProcessDue(10)
+	if err != nil {
+		t.Fatalf("ProcessDue() error = %v", err)
+	}
+	if embedded != 2 || len(store.completed) != 2 {
+		t.Errorf("embedded = %d, completed = %d; want 2, 2", embedded, len(store.completed))
+	}
+	if len(aggregator.volunteers) != 2 {
+		t.Errorf("aggregated %d volunteers, want 2", len(aggregator.volunteers))
+	}
+}
+
+func TestProcessDueReschedulesWhileUnavailable(t *testing.T) {
+	early := pendingClaim("first aid", 1)
+	later := pendingClaim("carpentry", 4)
+	exhausted := pendingClaim("plumbing", pendingEmbeddingMaxAttempts-1)
+	store := &fakePendingEmbeddingStore{due: []models.PendingEmbedding{early, later, exhausted}}
+	embedder := &fakeClaimEmbedder{batchErr: fmt.Errorf("%w: circuit breaker open", ErrEmbeddingUnavailable)}
+	processor := &PendingEmbeddingProcessor{pendingService: store, embeddingService: embedder, aggregationService: &fakeClaimAggregator{}}
+
+	start := time.Now()
+	embedded, err := processor.ProcessDue(10)
+	if !errors.Is(err, ErrEmbeddingUnavailable) {
+		t.Fatalf("error = %v, want ErrEmbeddingUnavailable", err)
+	}
+	if embedded != 0 {
+		t.Errorf("embedded = %d, want 0", embedded)
+	}
+
+	// Each claim backs off by its own attempt count
+	if got := store.rescheduled[early.ID].Sub(start); got < pendingEmbeddingBaseDelay || got > pendingEmbeddingBaseDelay+time.Second {
+		t.Errorf("first attempt retried in %v, want %v", got, pendingEmbeddingBaseDelay)
+	}
+	if got := store.rescheduled[later.ID].Sub(start); got < 8*pendingEmbeddingBaseDelay || got > 8*pendingEmbeddingBaseDelay+time.Second {
+		t.Errorf("fourth attempt retried in %v, want %v", got, 8*pendingEmbeddingBaseDelay)
+	}
+
+	// A claim out of attempts is marked failed instead
+	if _, ok := store.rescheduled[exhausted.ID]; ok {
+		t.Error("claim out of attempts was rescheduled")
+	}
+	if len(store.failed) != 1 || store.failed[0] != exhausted.ID {
+		t.Errorf("failed = %v, want only %s", store.failed, exhausted.ID)
+	}
+}
+
+func TestProcessDueFallsBackToIndividualClaims(t *testing.T) {
+	good := pendingClaim("first aid", 1)
+	bad := pendingClaim("bad claim", 1)
+	flaky := pendingClaim("carpentry", 1)
+	store := &fakePendingEmbeddingStore{due: []models.PendingEmbedding{good, bad, flaky}}
+	embedder := &fakeClaimEmbedder{
+		batchErr: errors.New("OpenAI API returned status 400: invalid input"),
+		claimErrs: map[string]error{
+			"bad claim": errors.New("OpenAI API returned status 400: invalid input"),
+			"carpentry": fmt.Errorf("%w: timeout", ErrEmbeddingUnavailable),
+		},
+	}
+	processor := &PendingEmbeddingProcessor{pendingService: store, embeddingService: embedder, aggregationService: &fakeClaimAggregator{}}
+
+	embedded, err := processor.ProcessDue(10)
+	if err != nil {
+		t.Fatalf("ProcessDue() error = %v", err)
+	}
+	if embedded != 1 || len(store.completed) != 1 || store.completed[0] != good.ID {
+		t.Errorf("embedded = %d, completed = %v; want only %s", embedded, store.completed, good.ID)
+	}
+	if len(store.failed) != 1 || store.failed[0] != bad.ID {
+		t.Errorf("failed = %v, want only %s", store.failed, bad.ID)
+	}
+	if _, ok := store.rescheduled[flaky.ID]; !ok || len(store.rescheduled) != 1 {
+		t.Errorf("rescheduled = %v, want only %s", store.rescheduled, flaky.ID)
+	}
+}
+
+func TestPendingEmbeddingRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{6, 32 * time.Minute},
+		{7, time.Hour},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := pendingEmbeddingRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("pendingEmbeddingRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
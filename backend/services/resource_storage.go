@@ -0,0 +1,25 @@
+package services
+
+import (
+	"fmt"
+
+	"civicweave/backend/config"
+)
+
+// ResourceStorage stores resource library uploads under keys such as "resources/<id>.pdf".
+// LocalFileStorage keeps them on disk and S3FileStorage in an S3-compatible object store.
+type ResourceStorage interface {
+	FileStorage
+}
+
+// NewResourceStorage creates the storage backend selected by the resource config
+func NewResourceStorage(cfg config.ResourceConfig) (ResourceStorage, error) {
+	switch cfg.StorageBackend {
+	case "", "local":
+		return NewLocalFileStorage(cfg.UploadDir), nil
+	case "s3":
+		return NewS3FileStorage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown resource storage backend %q", cfg.StorageBackend)
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"civicweave/backend/config"
+)
+
+// S3FileStorage stores files in an S3 bucket, or any store speaking the S3 API, signing
+// requests with AWS Signature Version 4
+type S3FileStorage struct {
+	bucket          string
+	region          string
+	endpoint        *url.URL // Set for S3-compatible stores, addressed path-style
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+// NewS3FileStorage creates an S3 file storage from the S3 config
+func NewS3FileStorage(cfg config.S3Config) (*S3FileStorage, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is not configured")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 credentials are not configured")
+	}
+
+	storage := &S3FileStorage{
+		bucket:          cfg.Bucket,
+		region:          cfg.Region,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		client: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+	}
+	if cfg.Endpoint != "" {
+		endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+		if err != nil || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+		}
+		storage.endpoint = endpoint
+	}
+	return storage, nil
+}
+
+// Save uploads content under key. S3 needs the length up front, so content is spooled
+// to a temporary file first.
+func (s *S3FileStorage) Save(key string, content io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp("", "civicweave-upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, content)
+	if err != nil {
+		return 0, fmt.Errorf("failed to buffer upload: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind upload: %w", err)
+	}
+
+	req, err := s.newRequest(http.MethodPut, key, io.NopCloser(tmp))
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = size
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upload to S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, s3Error("upload", resp)
+	}
+	return size, nil
+}
+
+// Open downloads the object stored under key; callers must close it. A missing object
+// returns an error wrapping os.ErrNotExist, as LocalFileStorage does.
+func (s *S3FileStorage) Open(key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download from S3: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("S3 object %s: %w", key, os.ErrNotExist)
+	default:
+		defer resp.Body.Close()
+		return nil, s3Error("download", resp)
+	}
+}
+
+// Delete removes the object stored under key. S3 treats deleting a missing object as success.
+func (s *S3FileStorage) Delete(key string) error {
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete from S3: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error("delete", resp)
+	}
+	return nil
+}
+
+// newRequest builds a signed request for the object stored under key
+func (s *S3FileStorage) newRequest(method, key string, body io.ReadCloser) (*http.Request, error) {
+	if !validS3Key(key) {
+		return nil, ErrInvalidStorageKey
+	}
+
+	var objectURL url.URL
+	if s.endpoint != nil {
+		objectURL = *s.endpoint
+		objectURL.Path = objectURL.Path + "/" + s.bucket + "/" + key
+	} else {
+		objectURL = url.URL{Scheme: "https", Host: s.bucket + ".s3." + s.region + ".amazonaws.com", Path: "/" + key}
+	}
+	objectURL.RawPath = s3EscapePath(objectURL.Path)
+
+	req, err := http.NewRequest(method, objectURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build S3 request: %w", err)
+	}
+	if body != nil {
+		req.Body = body
+	}
+
+	s.sign(req, time.Now().UTC())
+	return req, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header. The payload is left
+// unsigned so uploads can stream without hashing them twice.
+func (s *S3FileStorage) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	const payloadHash = "UNSIGNED-PAYLOAD"
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 computes HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath percent-encodes every byte of path except unreserved characters and '/',
+// as Signature Version 4 requires
+func s3EscapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		b := path[i]
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+// validS3Key rejects keys that are empty, absolute, or contain relative segments
+func validS3Key(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// s3Error describes a failed S3 response, including the start of its error body
+func s3Error(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3 %s failed with status %d: %s", action, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"civicweave/backend/models"
+)
+
+const (
+	// scheduledMessagePollInterval is how often due scheduled messages are sent
+	scheduledMessagePollInterval = 30 * time.Second
+	// scheduledMessageBatchSize caps how many scheduled messages are sent per pass
+	scheduledMessageBatchSize = 100
+)
+
+// ScheduledMessageDispatcher periodically sends scheduled messages whose time has come
+type ScheduledMessageDispatcher struct {
+	messageService *models.MessageService
+	streamService  *MessageStreamService
+}
+
+// NewScheduledMessageDispatcher creates a new scheduled message dispatcher
+func NewScheduledMessageDispatcher(messageService *models.MessageService, streamService *MessageStreamService) *ScheduledMessageDispatcher {
+	return &ScheduledMessageDispatcher{
+		messageService: messageService,
+		streamService:  streamService,
+	}
+}
+
+// DispatchDue sends every scheduled message that is due, in batches, and returns
+// how many were sent. Sent messages are published like ones sent immediately.
+func (d *ScheduledMessageDispatcher) DispatchDue() (int, error) {
+	sent := 0
+	for {
+		messages, err := d.messageService.DeliverDueScheduledMessages(scheduledMessageBatchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for i := range messages {
+			d.streamService.PublishProjectMessage(&messages[i])
+		}
+		sent += len(messages)
+
+		if len(messages) < scheduledMessageBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// Run dispatches due messages until ctx is cancelled
+func (d *ScheduledMessageDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduledMessagePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := d.DispatchDue()
+			if err != nil {
+				log.Printf("❌ SCHEDULED_MESSAGES: Failed to send scheduled messages: %v", err)
+			}
+			if sent > 0 {
+				log.Printf("📨 SCHEDULED_MESSAGES: Sent %d scheduled messages", sent)
+			}
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"civicweave/backend/models"
+)
+
+const (
+	// MaxSkillExtractionTextLength caps how many characters of free text are analysed
+	MaxSkillExtractionTextLength = 10000
+	// maxSkillExtractionPhrases caps how many phrases of the text are embedded
+	maxSkillExtractionPhrases = 50
+	// skillEmbeddingBatchSize bounds how many taxonomy skills are embedded per request
+	skillEmbeddingBatchSize = 100
+	// semanticSuggestionThreshold is the lowest similarity suggested from embeddings
+	semanticSuggestionThreshold = 0.6
+	// aliasMatchConfidence is the confidence given to a skill found by one of its aliases
+	aliasMatchConfidence = 0.9
+)
+
+// Sources of a skill suggestion
+const (
+	SkillSuggestionSourceName     = "name"
+	SkillSuggestionSourceAlias    = "alias"
+	SkillSuggestionSourceSemantic = "semantic"
+)
+
+// ErrSkillTextTooLong is returned when the text to extract skills from exceeds MaxSkillExtractionTextLength
+var ErrSkillTextTooLong = fmt.Errorf("text exceeds %d characters", MaxSkillExtractionTextLength)
+
+// SkillSuggestion is a taxonomy skill suggested from free text, for the volunteer to confirm
+type SkillSuggestion struct {
+	SkillID      int     `json:"skill_id"`
+	SkillName    string  `json:"skill_name"`
+	Confidence   float64 `json:"confidence"` // 0 to 1
+	Source       string  `json:"source"`
+	MatchedText  string  `json:"matched_text"`
+	AlreadyAdded bool    `json:"already_added"`
+}
+
+// SkillExtractionResult lists the skills suggested from a piece of text
+type SkillExtractionResult struct {
+	Suggestions  []SkillSuggestion `json:"suggestions"`
+	SemanticUsed bool              `json:"semantic_used"` // False when only exact name and alias matches were used
+}
+
+// cachedSkillEmbedding is a taxonomy skill's embedding, keyed by the name it was made from
+type cachedSkillEmbedding struct {
+	name   string
+	vector []float32
+}
+
+// SkillExtractionService suggests taxonomy skills from free text such as a pasted
+// résumé. Skill names and aliases found in the text are suggested with high
+// confidence; when embeddings are available, phrases are also compared against
+// every taxonomy skill. Suggestions always refer to existing taxonomy skills.
+type SkillExtractionService struct {
+	taxonomyService  *models.SkillTaxonomyService
+	embeddingService *EmbeddingService
+
+	mu              sync.Mutex
+	skillEmbeddings map[int]cachedSkillEmbedding
+}
+
+// NewSkillExtractionService creates a new skill extraction service. embeddingService
+// may be nil, in which case only names and aliases are matched.
+func NewSkillExtractionService(taxonomyService *models.SkillTaxonomyService, embeddingService *EmbeddingService) *SkillExtractionService {
+	return &SkillExtractionService{
+		taxonomyService:  taxonomyService,
+		embeddingService: embeddingService,
+		skillEmbeddings:  make(map[int]cachedSkillEmbedding),
+	}
+}
+
+// Extract suggests up to limit taxonomy skills found in text, most confident first.
+// Skills in existing are still suggested but flagged as already added.
+func (s *SkillExtractionService) Extract(text string, existing map[int]bool, limit int) (*SkillExtractionResult, error) {
+	if utf8.RuneCountInString(text) > MaxSkillExtractionTextLength {
+		return nil, ErrSkillTextTooLong
+	}
+
+	skills, err := s.taxonomyService.GetAllSkills()
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := s.taxonomyService.ListAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(skills))
+	for _, skill := range skills {
+		names[skill.ID] = skill.SkillName
+	}
+
+	best := make(map[int]SkillSuggestion)
+	suggest := func(suggestion SkillSuggestion) {
+		if current, ok := best[suggestion.SkillID]; !ok || suggestion.Confidence > current.Confidence {
+			best[suggestion.SkillID] = suggestion
+		}
+	}
+
+	lowerText := strings.ToLower(text)
+	for _, skill := range skills {
+		if containsTerm(lowerText, strings.ToLower(skill.SkillName)) {
+			suggest(SkillSuggestion{SkillID: skill.ID, SkillName: skill.SkillName, Confidence: 1, Source: SkillSuggestionSourceName, MatchedText: skill.SkillName})
+		}
+	}
+	for _, alias := range aliases {
+		name, ok := names[alias.SkillID]
+		if ok && containsTerm(lowerText, alias.Alias) {
+			suggest(SkillSuggestion{SkillID: alias.SkillID, SkillName: name, Confidence: aliasMatchConfidence, Source: SkillSuggestionSourceAlias, MatchedText: alias.Alias})
+		}
+	}
+
+	result := &SkillExtractionResult{}
+	semantic, err := s.semanticSuggestions(text, skills)
+	switch {
+	case err == nil:
+		result.SemanticUsed = semantic != nil
+		for _, suggestion := range semantic {
+			suggest(suggestion)
+		}
+	case errors.Is(err, ErrEmbeddingUnavailable):
+		log.Printf("⚠️  SKILL_EXTRACTION: Embeddings unavailable, using name and alias matches only: %v", err)
+	default:
+		return nil, err
+	}
+
+	result.Suggestions = make([]SkillSuggestion, 0, len(best))
+	for _, suggestion := range best {
+		suggestion.AlreadyAdded = existing[suggestion.SkillID]
+		result.Suggestions = append(result.Suggestions, suggestion)
+	}
+	sort.Slice(result.Suggestions, func(i, j int) bool {
+		a, b := result.Suggestions[i], result.Suggestions[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.SkillName < b.SkillName
+	})
+	if limit > 0 && len(result.Suggestions) > limit {
+		result.Suggestions = result.Suggestions[:limit]
+	}
+
+	return result, nil
+}
+
+// semanticSuggestions compares the text's phrases with every taxonomy skill by
+// embedding similarity. It returns nil when embeddings aren't configured.
+func (s *SkillExtractionService) semanticSuggestions(text string, skills []models.SkillTaxonomy) ([]SkillSuggestion, error) {
+	if s.embeddingService == nil || !s.embeddingService.IsConfigured() || len(skills) == 0 {
+		return nil, nil
+	}
+
+	phrases := splitSkillPhrases(text)
+	if len(phrases) == 0 {
+		return []SkillSuggestion{}, nil
+	}
+
+	skillVectors, err := s.embedSkills(skills)
+	if err != nil {
+		return nil, err
+	}
+
+	phraseEmbeddings, err := s.embeddingService.GenerateBatchEmbeddings(phrases)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []SkillSuggestion{}
+	for _, skill := range skills {
+		skillVector, ok := skillVectors[skill.ID]
+		if !ok {
+			continue
+		}
+
+		bestScore, bestPhrase := 0.0, ""
+		for i, phraseEmbedding := range phraseEmbeddings {
+			if score := cosineSimilarity(skillVector, phraseEmbedding.Slice()); score > bestScore {
+				bestScore, bestPhrase = score, phrases[i]
+			}
+		}
+
+		if bestScore >= semanticSuggestionThreshold {
+			suggestions = append(suggestions, SkillSuggestion{
+				SkillID:     skill.ID,
+				SkillName:   skill.SkillName,
+				Confidence:  math.Round(bestScore*100) / 100,
+				Source:      SkillSuggestionSourceSemantic,
+				MatchedText: bestPhrase,
+			})
+		}
+	}
+
+	return suggestions, nil
+}
+
+// embedSkills returns an embedding for each taxonomy skill, embedding only skills
+// that are new or renamed since they were last cached
+func (s *SkillExtractionService) embedSkills(skills []models.SkillTaxonomy) (map[int][]float32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var missing []models.SkillTaxonomy
+	for _, skill := range skills {
+		if cached, ok := s.skillEmbeddings[skill.ID]; !ok || cached.name != skill.SkillName {
+			if strings.TrimSpace(skill.SkillName) != "" {
+				missing = append(missing, skill)
+			}
+		}
+	}
+
+	for start := 0; start < len(missing); start += skillEmbeddingBatchSize {
+		end := start + skillEmbeddingBatchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+		batch := missing[start:end]
+
+		texts := make([]string, len(batch))
+		for i, skill := range batch {
+			texts[i] = strings.TrimSpace(skill.SkillName)
+		}
+
+		embeddings, err := s.embeddingService.GenerateBatchEmbeddings(texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed taxonomy skills: %w", err)
+		}
+		for i, skill := range batch {
+			s.skillEmbeddings[skill.ID] = cachedSkillEmbedding{name: skill.SkillName, vector: embeddings[i].Slice()}
+		}
+	}
+
+	vectors := make(map[int][]float32, len(skills))
+	for _, skill := range skills {
+		if cached, ok := s.skillEmbeddings[skill.ID]; ok {
+			vectors[skill.ID] = cached.vector
+		}
+	}
+	return vectors, nil
+}
+
+// splitSkillPhrases breaks free text into distinct short phrases (lines, sentences
+// and list items) worth comparing against skills
+func splitSkillPhrases(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		switch r {
+		case '\n', '\r', '.', ';', ',', '•', '|', '\t':
+			return true
+		}
+		return false
+	})
+
+	seen := make(map[string]bool)
+	var phrases []string
+	for _, field := range fields {
+		phrase := strings.Trim(strings.TrimSpace(field), "-*·:()[]")
+		phrase = strings.TrimSpace(phrase)
+		length := utf8.RuneCountInString(phrase)
+		if length < 2 || length > 200 {
+			continue
+		}
+
+		key := strings.ToLower(phrase)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		phrases = append(phrases, phrase)
+		if len(phrases) == maxSkillExtractionPhrases {
+			break
+		}
+	}
+
+	return phrases
+}
+
+// containsTerm reports whether term occurs in text as a whole word or phrase, so
+// "go" doesn't match "google" but "c++" and "node.js" still match. Both must be
+// lower case.
+func containsTerm(text, term string) bool {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return false
+	}
+
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], term)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+
+	return false
+}
+
+// isWordRune reports whether r continues a word; '+' and '#' count so "c" doesn't
+// match "c++" or "c#". utf8.RuneError marks the start or end of the text.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#')
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 when their
+// lengths differ or either is zero
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+
+	"civicweave/backend/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// skillGapCandidatePool is how many ranked candidates are considered when suggesting
+	// volunteers for a project's missing skills
+	skillGapCandidatePool = 100
+	// skillGapCandidatesPerSkill caps the suggestions listed for each missing skill
+	skillGapCandidatesPerSkill = 5
+)
+
+// CoveredSkill is a required skill held by at least one active team member
+type CoveredSkill struct {
+	SkillID     int     `json:"skill_id"`
+	SkillName   string  `json:"skill_name"`
+	MemberCount int     `json:"member_count"`
+	MaxWeight   float64 `json:"max_weight"`
+}
+
+// SkillGap is a required skill no active team member holds, with candidate volunteers
+// outside the team who could fill it
+type SkillGap struct {
+	SkillID    int                  `json:"skill_id"`
+	SkillName  string               `json:"skill_name"`
+	Candidates []CandidateVolunteer `json:"candidates"`
+}
+
+// TeamSkillGapAnalysis compares a project's required skills with its active team's
+type TeamSkillGapAnalysis struct {
+	ProjectID       uuid.UUID      `json:"project_id"`
+	ActiveTeamSize  int            `json:"active_team_size"`
+	TotalRequired   int            `json:"total_required"`
+	CoveragePercent int            `json:"coverage_percent"`
+	CoveredSkills   []CoveredSkill `json:"covered_skills"`
+	MissingSkills   []SkillGap     `json:"missing_skills"`
+}
+
+// teamSkill is one skill aggregated across a project's active team members
+type teamSkill struct {
+	memberCount int
+	maxWeight   float64
+}
+
+// AnalyzeTeamSkillGap reports which of a project's required skills its active team
+// members cover and which they lack. Each missing skill lists the best-ranked candidate
+// volunteers who aren't on the team and whose match includes that skill.
+func (s *SkillMatchingService) AnalyzeTeamSkillGap(projectID uuid.UUID) (*TeamSkillGapAnalysis, error) {
+	requiredSkills, err := s.getProjectSkills(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project skills: %w", err)
+	}
+
+	teamMemberIDs, err := s.getActiveTeamMemberIDs(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	teamSkills, err := s.getActiveTeamSkills(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team skills: %w", err)
+	}
+
+	analysis := &TeamSkillGapAnalysis{
+		ProjectID:      projectID,
+		ActiveTeamSize: len(teamMemberIDs),
+		TotalRequired:  len(requiredSkills),
+		CoveredSkills:  []CoveredSkill{},
+		MissingSkills:  []SkillGap{},
+	}
+
+	for _, required := range requiredSkills {
+		if skill, covered := teamSkills[required.SkillID]; covered {
+			analysis.CoveredSkills = append(analysis.CoveredSkills, CoveredSkill{
+				SkillID:     required.SkillID,
+				SkillName:   required.SkillName,
+				MemberCount: skill.memberCount,
+				MaxWeight:   skill.maxWeight,
+			})
+		} else {
+			analysis.MissingSkills = append(analysis.MissingSkills, SkillGap{
+				SkillID:    required.SkillID,
+				SkillName:  required.SkillName,
+				Candidates: []CandidateVolunteer{},
+			})
+		}
+	}
+
+	if analysis.TotalRequired > 0 {
+		analysis.CoveragePercent = len(analysis.CoveredSkills) * 100 / analysis.TotalRequired
+	}
+	if len(analysis.MissingSkills) == 0 {
+		return analysis, nil
+	}
+
+	candidates, err := s.RankCandidateVolunteers(projectID, 0, skillGapCandidatePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank candidate volunteers: %w", err)
+	}
+
+	// Candidates are already best first, so each gap keeps the first few who hold the skill
+	gapIndex := make(map[int]int, len(analysis.MissingSkills))
+	for i, gap := range analysis.MissingSkills {
+		gapIndex[gap.SkillID] = i
+	}
+	for _, candidate := range candidates {
+		if teamMemberIDs[candidate.ID] {
+			continue
+		}
+		for _, skillID := range candidate.MatchedSkillIDs {
+			i, missing := gapIndex[skillID]
+			if missing && len(analysis.MissingSkills[i].Candidates) < skillGapCandidatesPerSkill {
+				analysis.MissingSkills[i].Candidates = append(analysis.MissingSkills[i].Candidates, candidate)
+			}
+		}
+	}
+
+	return analysis, nil
+}
+
+// getActiveTeamMemberIDs retrieves the volunteer IDs of a project's active team members
+func (s *SkillMatchingService) getActiveTeamMemberIDs(projectID uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `
+		SELECT volunteer_id
+		FROM project_team_members
+		WHERE project_id = $1 AND status = 'active'
+	`
+
+	rows, err := s.db.Query(query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberIDs := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var volunteerID uuid.UUID
+		if err := rows.Scan(&volunteerID); err != nil {
+			return nil, err
+		}
+		memberIDs[volunteerID] = true
+	}
+
+	return memberIDs, rows.Err()
+}
+
+// getActiveTeamSkills aggregates the skills of a project's active team members by skill
+// ID. Team-only skills count, since the analysis is for the members' own team; private
+// skills don't.
+func (s *SkillMatchingService) getActiveTeamSkills(projectID uuid.UUID) (map[int]teamSkill, error) {
+	query := `
+		SELECT vs.skill_id, COUNT(DISTINCT vs.volunteer_id), MAX(vs.skill_weight)
+		FROM project_team_members ptm
+		JOIN volunteer_skills vs ON ptm.volunteer_id = vs.volunteer_id
+		WHERE ptm.project_id = $1 AND ptm.status = 'active' AND vs.visibility <> $2
+		GROUP BY vs.skill_id
+	`
+
+	rows, err := s.db.Query(query, projectID, models.SkillVisibilityPrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make(map[int]teamSkill)
+	for rows.Next() {
+		var skillID int
+		var skill teamSkill
+		if err := rows.Scan(&skillID, &skill.memberCount, &skill.maxWeight); err != nil {
+			return nil, err
+		}
+		skills[skillID] = skill
+	}
+
+	return skills, rows.Err()
+}
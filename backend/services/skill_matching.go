@@ -2,8 +2,10 @@ package services
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math"
+	"time"
 
 	"civicweave/backend/models"
 
@@ -21,20 +23,48 @@ type SkillMatchResult struct {
 	CosineScore       float64 `json:"cosine_score"`
 	EuclideanScore    float64 `json:"euclidean_score"`
 	CoverageScore     float64 `json:"coverage_score"`
+	AvailabilityScore float64 `json:"availability_score"` // 1 unless availability was applied
+	CategoryScore     float64 `json:"category_score"`     // Partial credit from related skills in the same category
+	MatchScore        float64 `json:"match_score"`        // The ranking score: cosine (or the category score without exact matches), scaled by availability
 	MatchedSkillIDs   []int   `json:"matched_skill_ids"`
 	MissingSkillIDs   []int   `json:"missing_skill_ids"`
+	PartialSkillIDs   []int   `json:"partial_skill_ids,omitempty"` // Missing skills credited through their category
 	MatchedSkillCount int     `json:"matched_skill_count"`
 	TotalRequired     int     `json:"total_required"`
 }
 
+// CandidateVolunteer is a volunteer ranked by their pre-calculated match with a project
+type CandidateVolunteer struct {
+	ID                uuid.UUID `json:"id"`
+	Name              string    `json:"name"`
+	Phone             string    `json:"phone"`
+	LocationAddress   string    `json:"location_address"`
+	MatchScore        float64   `json:"match_score"`
+	JaccardIndex      float64   `json:"jaccard_index"`
+	MatchedSkillIDs   []int     `json:"matched_skill_ids"`
+	MatchedSkillCount int       `json:"matched_skill_count"`
+	CalculatedAt      time.Time `json:"calculated_at"`
+}
+
 // SkillMatchingService handles skill matching calculations
 type SkillMatchingService struct {
 	db *sql.DB
+	// categoryCredit is the share (0-1) of a related skill's weight credited toward a
+	// missing required skill in the same category; 0 disables category matching
+	categoryCredit float64
 }
 
-// NewSkillMatchingService creates a new skill matching service
-func NewSkillMatchingService(db *sql.DB) *SkillMatchingService {
-	return &SkillMatchingService{db: db}
+// NewSkillMatchingService creates a new skill matching service. categoryCreditPercent
+// is the percentage of credit given for related skills in the same category, from 0
+// (exact matches only) to 100.
+func NewSkillMatchingService(db *sql.DB, categoryCreditPercent int) *SkillMatchingService {
+	if categoryCreditPercent < 0 {
+		categoryCreditPercent = 0
+	}
+	if categoryCreditPercent > 100 {
+		categoryCreditPercent = 100
+	}
+	return &SkillMatchingService{db: db, categoryCredit: float64(categoryCreditPercent) / 100}
 }
 
 // CalculateMatch calculates match scores between volunteer skills and project requirements
@@ -75,6 +105,8 @@ func (s *SkillMatchingService) CalculateMatch(volunteerSkills []VolunteerSkill,
 		CosineScore:       cosineScore,
 		EuclideanScore:    euclideanScore,
 		CoverageScore:     coverageScore,
+		AvailabilityScore: 1,
+		MatchScore:        cosineScore,
 		MatchedSkillIDs:   matched,
 		MissingSkillIDs:   missing,
 		MatchedSkillCount: len(matched),
@@ -227,8 +259,15 @@ func (s *SkillMatchingService) BatchCalculateMatches() error {
 	return nil
 }
 
-// BatchCalculateProjectMatches calculates matches for multiple volunteer-project pairs
+// BatchCalculateProjectMatches calculates matches for multiple volunteer-project pairs.
+// It recomputes everything, reconciling any changes incremental recalculation missed.
 func (s *SkillMatchingService) BatchCalculateProjectMatches() error {
+	// Changes made before this point are covered by the full run
+	dirty, err := models.NewMatchingRecalculationService(s.db).ListDirty(0)
+	if err != nil {
+		return fmt.Errorf("failed to list changed entities: %w", err)
+	}
+
 	// Get all active/recruiting projects with their required skills
 	projects, err := s.getAllActiveProjectsWithSkills()
 	if err != nil {
@@ -241,6 +280,16 @@ func (s *SkillMatchingService) BatchCalculateProjectMatches() error {
 		return fmt.Errorf("failed to get volunteers: %w", err)
 	}
 
+	availability, err := models.NewVolunteerAvailabilityService(s.db).ListAll()
+	if err != nil {
+		return fmt.Errorf("failed to get volunteer availability: %w", err)
+	}
+
+	parents, err := s.loadSkillParents()
+	if err != nil {
+		return err
+	}
+
 	// Clear existing project matches
 	_, err = s.db.Exec("TRUNCATE volunteer_project_matches")
 	if err != nil {
@@ -251,9 +300,11 @@ func (s *SkillMatchingService) BatchCalculateProjectMatches() error {
 	for _, project := range projects {
 		for _, volunteer := range volunteers {
 			result := s.CalculateMatch(volunteer.Skills, project.RequiredSkillIDs)
+			s.applyCategoryCredit(&result, volunteer.Skills, parents)
+			applyAvailability(&result, CalculateAvailability(availability[volunteer.ID], project.StartDate, project.EndDate))
 
-			// Only store if at least 1 skill matches
-			if result.MatchedSkillCount > 0 {
+			// Only store if at least 1 skill matches, exactly or through its category
+			if result.MatchedSkillCount > 0 || len(result.PartialSkillIDs) > 0 {
 				err := s.storeProjectMatch(volunteer.ID, project.ID, result)
 				if err != nil {
 					return fmt.Errorf("failed to store project match: %w", err)
@@ -262,7 +313,53 @@ func (s *SkillMatchingService) BatchCalculateProjectMatches() error {
 		}
 	}
 
-	return nil
+	return s.clearDirtySnapshot(dirty)
+}
+
+// RankCandidateVolunteers returns up to limit volunteers with visible skills whose
+// pre-calculated match with a project scores at least minScore, best matches first
+func (s *SkillMatchingService) RankCandidateVolunteers(projectID uuid.UUID, minScore float64, limit int) ([]CandidateVolunteer, error) {
+	query := `
+		SELECT 
+			v.id, v.name, v.phone, v.location_address,
+			m.match_score, m.jaccard_index, 
+			COALESCE(array_to_json(m.matched_skill_ids), '[]')::text, m.matched_skill_count,
+			m.calculated_at
+		FROM volunteer_project_matches m
+		JOIN volunteers v ON m.volunteer_id = v.id
+		WHERE m.project_id = $1 
+			AND m.match_score >= $2
+			AND v.skills_visible = true
+		ORDER BY m.match_score DESC, m.matched_skill_count DESC
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(query, projectID, minScore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []CandidateVolunteer{}
+	for rows.Next() {
+		var candidate CandidateVolunteer
+		var matchedSkillIDsJSON string
+		err := rows.Scan(
+			&candidate.ID, &candidate.Name, &candidate.Phone, &candidate.LocationAddress,
+			&candidate.MatchScore, &candidate.JaccardIndex,
+			&matchedSkillIDsJSON, &candidate.MatchedSkillCount,
+			&candidate.CalculatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(matchedSkillIDsJSON), &candidate.MatchedSkillIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode matched skills: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
 }
 
 // storeMatch stores a calculated match in the database
@@ -288,24 +385,31 @@ func (s *SkillMatchingService) storeMatch(volunteerID, initiativeID uuid.UUID, r
 	return err
 }
 
-// storeProjectMatch stores a calculated project match in the database
+// storeProjectMatch stores a calculated project match in the database, along with
+// the full result as its explanation
 func (s *SkillMatchingService) storeProjectMatch(volunteerID, projectID uuid.UUID, result SkillMatchResult) error {
 	query := `
 		INSERT INTO volunteer_project_matches 
 		(volunteer_id, project_id, match_score, jaccard_index, 
-		 matched_skill_ids, matched_skill_count, calculated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		 matched_skill_ids, matched_skill_count, explanation, calculated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
 	`
 
 	jaccardIndex := float64(result.MatchedSkillCount) / float64(result.TotalRequired)
 
-	_, err := s.db.Exec(query,
+	explanationJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode match explanation: %w", err)
+	}
+
+	_, err = s.db.Exec(query,
 		volunteerID,
 		projectID,
-		result.CosineScore, // Use cosine as primary match score
+		result.MatchScore, // Cosine, scaled by availability
 		jaccardIndex,
 		result.MatchedSkillIDs,
 		result.MatchedSkillCount,
+		string(explanationJSON),
 	)
 
 	return err
@@ -314,10 +418,10 @@ func (s *SkillMatchingService) storeProjectMatch(volunteerID, projectID uuid.UUI
 // getAllActiveProjectsWithSkills retrieves all active/recruiting projects with their required skills
 func (s *SkillMatchingService) getAllActiveProjectsWithSkills() ([]ProjectWithSkills, error) {
 	query := `
-		SELECT p.id, COALESCE(ARRAY_AGG(prs.skill_id) FILTER (WHERE prs.skill_id IS NOT NULL), '{}') as skill_ids
+		SELECT p.id, p.start_date, p.end_date, COALESCE(ARRAY_AGG(prs.skill_id) FILTER (WHERE prs.skill_id IS NOT NULL), '{}') as skill_ids
 		FROM projects p
 		LEFT JOIN project_required_skills prs ON p.id = prs.project_id
-		WHERE p.project_status IN ('recruiting', 'active')
+		WHERE p.project_status IN ('recruiting', 'active') AND p.deleted_at IS NULL
 		GROUP BY p.id
 	`
 
@@ -331,7 +435,7 @@ func (s *SkillMatchingService) getAllActiveProjectsWithSkills() ([]ProjectWithSk
 	for rows.Next() {
 		var project ProjectWithSkills
 		var skillIDs []int
-		err := rows.Scan(&project.ID, &skillIDs)
+		err := rows.Scan(&project.ID, &project.StartDate, &project.EndDate, &skillIDs)
 		if err != nil {
 			return nil, err
 		}
@@ -349,8 +453,10 @@ type InitiativeWithSkills struct {
 }
 
 type ProjectWithSkills struct {
-	ID               uuid.UUID `json:"id"`
-	RequiredSkillIDs []int     `json:"required_skill_ids"`
+	ID               uuid.UUID  `json:"id"`
+	StartDate        *time.Time `json:"start_date"`
+	EndDate          *time.Time `json:"end_date"`
+	RequiredSkillIDs []int      `json:"required_skill_ids"`
 }
 
 type VolunteerWithSkills struct {
@@ -387,16 +493,17 @@ func (s *SkillMatchingService) getAllActiveInitiativesWithSkills() ([]Initiative
 	return initiatives, rows.Err()
 }
 
-// getAllVolunteersWithSkills retrieves all volunteers and their skills
+// getAllVolunteersWithSkills retrieves all volunteers and their matchable (public) skills
 func (s *SkillMatchingService) getAllVolunteersWithSkills() ([]VolunteerWithSkills, error) {
 	query := `
 		SELECT v.id, vs.skill_id, vs.skill_weight
 		FROM volunteers v
 		JOIN volunteer_skills vs ON v.id = vs.volunteer_id
+		WHERE vs.visibility = $1
 		ORDER BY v.id, vs.skill_id
 	`
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.Query(query, models.SkillVisibilityPublic)
 	if err != nil {
 		return nil, err
 	}
@@ -436,10 +543,22 @@ func (s *SkillMatchingService) getAllVolunteersWithSkills() ([]VolunteerWithSkil
 	return volunteers, rows.Err()
 }
 
-// getVolunteerSkills retrieves skills for a specific volunteer
+// getVolunteerSkills retrieves the skills of a specific volunteer that they made
+// matchable (public). Team-only and private skills never affect matches.
 func (s *SkillMatchingService) getVolunteerSkills(volunteerID uuid.UUID) ([]models.VolunteerSkill, error) {
 	taxonomyService := models.NewSkillTaxonomyService(s.db)
-	return taxonomyService.GetVolunteerSkills(volunteerID)
+	skills, err := taxonomyService.GetVolunteerSkills(volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	matchable := make([]models.VolunteerSkill, 0, len(skills))
+	for _, skill := range skills {
+		if skill.Visibility == models.SkillVisibilityPublic {
+			matchable = append(matchable, skill)
+		}
+	}
+	return matchable, nil
 }
 
 // getInitiativeSkills retrieves required skills for a specific initiative
@@ -447,3 +566,9 @@ func (s *SkillMatchingService) getInitiativeSkills(initiativeID uuid.UUID) ([]mo
 	taxonomyService := models.NewSkillTaxonomyService(s.db)
 	return taxonomyService.GetInitiativeSkills(initiativeID)
 }
+
+// getProjectSkills retrieves required skills for a specific project
+func (s *SkillMatchingService) getProjectSkills(projectID uuid.UUID) ([]models.ProjectRequiredSkill, error) {
+	taxonomyService := models.NewSkillTaxonomyService(s.db)
+	return taxonomyService.GetProjectSkills(projectID)
+}
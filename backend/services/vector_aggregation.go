@@ -104,9 +104,9 @@ func (s *VectorAggregationService) calculateWeightedAverage(claims []*models.Ski
 
 // createZeroVector creates a zero vector for volunteers with no skill claims
 func (s *VectorAggregationService) createZeroVector(volunteerID uuid.UUID) error {
-	// Create a zero vector with standard embedding dimension (384)
+	// Create a zero vector with the embedding columns' dimension
 	// Go initializes slices with zero values, so no need to explicitly set
-	zeroVector := make([]float32, 384) // text-embedding-3-small dimension
+	zeroVector := make([]float32, SkillEmbeddingDimensions)
 
 	vector := pgvector.NewVector(zeroVector)
 	locationPoint, err := s.getVolunteerLocationPoint(volunteerID)
@@ -169,24 +169,17 @@ func (s *VectorAggregationService) UpdateVectorOnTaskCompletion(volunteerID uuid
 		return fmt.Errorf("performance score must be between 1 and 5, got %d", performanceScore)
 	}
 
-	// Start transaction
-	tx, err := s.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
 	// Update weights for relevant skill claims
-	for _, claimID := range relevantClaimIDs {
-		err = s.updateSkillWeightForTask(tx, claimID, performanceScore, taskID)
-		if err != nil {
-			return fmt.Errorf("failed to update skill weight for claim %s: %w", claimID, err)
+	err := models.WithTransaction(s.db, func(tx *sql.Tx) error {
+		for _, claimID := range relevantClaimIDs {
+			if err := s.updateSkillWeightForTask(tx, claimID, performanceScore, taskID); err != nil {
+				return fmt.Errorf("failed to update skill weight for claim %s: %w", claimID, err)
+			}
 		}
-	}
-
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Re-aggregate the volunteer's vector
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"civicweave/backend/models"
+	"civicweave/backend/pkg/metrics"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// webhookPollInterval is how often due webhook deliveries are sent
+	webhookPollInterval = 15 * time.Second
+	// webhookBatchSize caps how many deliveries are claimed per pass
+	webhookBatchSize = 50
+	// webhookRequestTimeout bounds a single delivery attempt
+	webhookRequestTimeout = 10 * time.Second
+	// webhookMaxResponseBytes caps how much of a receiver's response is read
+	webhookMaxResponseBytes = 64 * 1024
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-CivicWeave-Event"
+	WebhookDeliveryHeader  = "X-CivicWeave-Delivery"
+	WebhookTimestampHeader = "X-CivicWeave-Timestamp"
+	WebhookSignatureHeader = "X-CivicWeave-Signature"
+)
+
+// webhookRetryDelays is how long to wait after each failed attempt before the next.
+// A delivery is marked failed once every delay has been used.
+var webhookRetryDelays = []time.Duration{
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	1 * time.Hour,
+	6 * time.Hour,
+}
+
+// WebhookEvent is the JSON body POSTed to webhooks
+type WebhookEvent struct {
+	ID         uuid.UUID   `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// WebhookDispatcher queues events for subscribed webhooks and delivers them in the
+// background, so a slow or failing receiver never holds up the request that raised
+// the event
+type WebhookDispatcher struct {
+	webhookService *models.WebhookService
+	httpClient     *http.Client
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(webhookService *models.WebhookService) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookService: webhookService,
+		httpClient:     &http.Client{Timeout: webhookRequestTimeout},
+	}
+}
+
+// Publish queues an event for every active webhook subscribed to eventType. It returns
+// immediately; failures to queue are logged rather than returned.
+func (d *WebhookDispatcher) Publish(eventType string, data interface{}) {
+	event := WebhookEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	go func() {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("❌ WEBHOOKS: Failed to encode %s event: %v", eventType, err)
+			return
+		}
+		if _, err := d.webhookService.EnqueueEvent(event.ID, eventType, payload); err != nil {
+			log.Printf("❌ WEBHOOKS: Failed to queue %s event %s: %v", eventType, event.ID, err)
+		}
+	}()
+}
+
+// DeliverDue sends every delivery that is due, in batches, and returns how many
+// succeeded and how many failed
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, int, error) {
+	delivered, failed := 0, 0
+	for {
+		deliveries, err := d.webhookService.ClaimDueDeliveries(webhookBatchSize)
+		if err != nil {
+			return delivered, failed, err
+		}
+
+		for i := range deliveries {
+			if d.deliver(ctx, &deliveries[i]) {
+				delivered++
+			} else {
+				failed++
+			}
+		}
+
+		if len(deliveries) < webhookBatchSize || ctx.Err() != nil {
+			return delivered, failed, nil
+		}
+	}
+}
+
+// Run delivers due webhooks until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, failed, err := d.DeliverDue(ctx)
+			if err != nil {
+				log.Printf("❌ WEBHOOKS: Failed to claim due deliveries: %v", err)
+			}
+			if delivered > 0 || failed > 0 {
+				log.Printf("📤 WEBHOOKS: Delivered %d webhooks, %d failed", delivered, failed)
+			}
+		}
+	}
+}
+
+// deliver makes one attempt at a delivery and records the outcome, scheduling a retry
+// on failure. It reports whether the receiver accepted the delivery.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *models.DueWebhookDelivery) bool {
+	responseStatus, err := d.send(ctx, delivery)
+	metrics.ObserveWebhookDelivery(delivery.EventType, err)
+
+	if err == nil {
+		if err := d.webhookService.MarkDelivered(delivery.ID, *responseStatus); err != nil {
+			log.Printf("❌ WEBHOOKS: Failed to record delivery %s: %v", delivery.ID, err)
+		}
+		return true
+	}
+
+	var retryAt *time.Time
+	if delivery.Attempts < len(webhookRetryDelays) {
+		next := time.Now().Add(webhookRetryDelays[delivery.Attempts])
+		retryAt = &next
+	} else {
+		log.Printf("⚠️ WEBHOOKS: Giving up on delivery %s to webhook %s after %d attempts: %v",
+			delivery.ID, delivery.WebhookID, delivery.Attempts+1, err)
+	}
+	if err := d.webhookService.RecordFailure(delivery.ID, responseStatus, err, retryAt); err != nil {
+		log.Printf("❌ WEBHOOKS: Failed to record failed delivery %s: %v", delivery.ID, err)
+	}
+	return false
+}
+
+// send POSTs a delivery's payload, signed with its webhook's secret. It returns the
+// response status, if any, and an error unless the receiver answered with a 2xx.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *models.DueWebhookDelivery) (*int, error) {
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CivicWeave-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhookPayload(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxResponseBytes))
+
+	status := resp.StatusCode
+	if status < 200 || status > 299 {
+		return &status, fmt.Errorf("webhook responded with status %d", status)
+	}
+	return &status, nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// webhook's secret. Receivers recompute it from the X-CivicWeave-Timestamp header and
+// the raw request body to verify a delivery came from us and wasn't replayed late.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -36,3 +36,9 @@ func HashPassword(password string) (string, error) {
 	hash := sha256.Sum256([]byte(password))
 	return fmt.Sprintf("%x", hash), nil
 }
+
+// HashToken returns the SHA-256 hex digest stored in place of a secret token
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -8,122 +8,232 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
+
+	"civicweave/backend/config"
+	"civicweave/backend/pkg/metrics"
+
+	"github.com/redis/go-redis/v9"
 )
 
-// GeocodingService handles geocoding operations
+// GeocodingService handles geocoding operations. Resolved addresses are cached, and
+// calls to Nominatim are spaced at least minRequestInterval apart.
 type GeocodingService struct {
 	NominatimBaseURL string
 	HTTPClient       *http.Client
+
+	cache              geocodeCache
+	cacheTTL           time.Duration
+	minRequestInterval time.Duration
+
+	throttleMu  sync.Mutex
+	lastRequest time.Time
 }
 
 // GeocodingResult represents a geocoding result from Nominatim
 type GeocodingResult struct {
-	Lat         string `json:"lat"`
-	Lon         string `json:"lon"`
-	DisplayName string `json:"display_name"`
-	PlaceID     int64  `json:"place_id"`
+	Lat         string  `json:"lat"`
+	Lon         string  `json:"lon"`
+	DisplayName string  `json:"display_name"`
+	PlaceID     int64   `json:"place_id"`
+	PlaceRank   int     `json:"place_rank"`
+	Importance  float64 `json:"importance"`
+	Error       string  `json:"error"`
 }
 
-// NewGeocodingService creates a new geocoding service
-func NewGeocodingService(baseURL string) *GeocodingService {
+// GeocodedLocation is a resolved address and its coordinates
+type GeocodedLocation struct {
+	FormattedAddress string  `json:"formatted_address"`
+	Lat              float64 `json:"lat"`
+	Lng              float64 `json:"lng"`
+	// Confidence ranges from 0 to 1. Forward lookups use Nominatim's importance score;
+	// reverse lookups use how specific the matched place is, 1 being a single building.
+	Confidence float64 `json:"confidence"`
+}
+
+// maxPlaceRank is the place_rank Nominatim gives to individual buildings
+const maxPlaceRank = 30
+
+var (
+	// ErrGeocodingUnavailable is returned when Nominatim times out, is unreachable, or is
+	// refusing requests
+	ErrGeocodingUnavailable = fmt.Errorf("geocoding service unavailable")
+	// ErrNoGeocodingResults is returned when Nominatim finds no match
+	ErrNoGeocodingResults = fmt.Errorf("no geocoding results found")
+)
+
+// NewGeocodingService creates a new geocoding service. Results are cached in Redis when
+// redisClient is non-nil, otherwise in memory.
+func NewGeocodingService(cfg config.GeocodingConfig, redisClient *redis.Client) *GeocodingService {
+	var cache geocodeCache
+	if redisClient != nil {
+		cache = &redisGeocodeCache{client: redisClient}
+	} else {
+		cache = newLRUGeocodeCache(cfg.CacheSize)
+	}
+
 	return &GeocodingService{
-		NominatimBaseURL: baseURL,
+		NominatimBaseURL: cfg.NominatimBaseURL,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		cache:              cache,
+		cacheTTL:           cfg.CacheTTL,
+		minRequestInterval: cfg.MinRequestInterval,
+	}
+}
+
+// waitForRequestSlot blocks until minRequestInterval has passed since the previous
+// call to Nominatim
+func (s *GeocodingService) waitForRequestSlot() {
+	s.throttleMu.Lock()
+	defer s.throttleMu.Unlock()
+
+	if wait := s.minRequestInterval - time.Since(s.lastRequest); wait > 0 {
+		time.Sleep(wait)
 	}
+	s.lastRequest = time.Now()
 }
 
 // GeocodeAddress converts an address to latitude and longitude coordinates
 func (s *GeocodingService) GeocodeAddress(address string) (lat, lng float64, displayName string, err error) {
+	location, err := s.Geocode(address)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return location.Lat, location.Lng, location.FormattedAddress, nil
+}
+
+// Geocode resolves an address to its best match
+func (s *GeocodingService) Geocode(address string) (*GeocodedLocation, error) {
 	if address == "" {
-		return 0, 0, "", fmt.Errorf("address cannot be empty")
+		return nil, fmt.Errorf("address cannot be empty")
+	}
+
+	cacheKey := "address:" + normalizeAddress(address)
+	if cached, ok := s.cachedLocation(cacheKey); ok {
+		return cached, nil
 	}
 
 	// Prepare the request URL
 	requestURL := fmt.Sprintf("%s/search?format=json&q=%s&limit=1&addressdetails=1",
 		s.NominatimBaseURL, url.QueryEscape(address))
 
-	// Make the request
-	resp, err := s.HTTPClient.Get(requestURL)
-	if err != nil {
-		return 0, 0, "", fmt.Errorf("failed to make geocoding request: %w", err)
+	var results []GeocodingResult
+	if err := s.fetch(requestURL, &results); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return 0, 0, "", fmt.Errorf("geocoding service returned status %d", resp.StatusCode)
+	// Check if we got any results
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%w for address: %s", ErrNoGeocodingResults, address)
 	}
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	result := results[0]
+	location, err := parseGeocodingResult(result)
 	if err != nil {
-		return 0, 0, "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	location.Confidence = math.Min(math.Max(result.Importance, 0), 1)
 
-	// Parse JSON response
-	var results []GeocodingResult
-	if err := json.Unmarshal(body, &results); err != nil {
-		return 0, 0, "", fmt.Errorf("failed to parse geocoding response: %w", err)
+	s.cache.set(cacheKey, *location, s.cacheTTL)
+
+	return location, nil
+}
+
+// ReverseGeocode converts latitude and longitude coordinates to the nearest address
+func (s *GeocodingService) ReverseGeocode(lat, lng float64) (*GeocodedLocation, error) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("coordinates out of range")
 	}
 
-	// Check if we got any results
-	if len(results) == 0 {
-		return 0, 0, "", fmt.Errorf("no geocoding results found for address: %s", address)
+	// Points within about a metre of each other share a cache entry
+	cacheKey := fmt.Sprintf("reverse:%.5f,%.5f", lat, lng)
+	if cached, ok := s.cachedLocation(cacheKey); ok {
+		return cached, nil
 	}
 
-	result := results[0]
+	// Prepare the request URL
+	requestURL := fmt.Sprintf("%s/reverse?format=json&lat=%f&lon=%f&addressdetails=1",
+		s.NominatimBaseURL, lat, lng)
 
-	// Parse latitude and longitude
-	lat, err = strconv.ParseFloat(result.Lat, 64)
-	if err != nil {
-		return 0, 0, "", fmt.Errorf("failed to parse latitude: %w", err)
+	var result GeocodingResult
+	if err := s.fetch(requestURL, &result); err != nil {
+		return nil, err
 	}
 
-	lng, err = strconv.ParseFloat(result.Lon, 64)
+	// Nominatim reports an unmatched point in the body rather than the status code
+	if result.Error != "" || result.DisplayName == "" {
+		return nil, fmt.Errorf("%w for coordinates: %f, %f", ErrNoGeocodingResults, lat, lng)
+	}
+
+	location, err := parseGeocodingResult(result)
 	if err != nil {
-		return 0, 0, "", fmt.Errorf("failed to parse longitude: %w", err)
+		return nil, err
 	}
+	location.Confidence = math.Min(float64(result.PlaceRank)/maxPlaceRank, 1)
 
-	return lat, lng, result.DisplayName, nil
+	s.cache.set(cacheKey, *location, s.cacheTTL)
+
+	return location, nil
 }
 
-// ReverseGeocode converts latitude and longitude coordinates to an address
-func (s *GeocodingService) ReverseGeocode(lat, lng float64) (address string, err error) {
-	// Prepare the request URL
-	requestURL := fmt.Sprintf("%s/reverse?format=json&lat=%f&lon=%f&addressdetails=1",
-		s.NominatimBaseURL, lat, lng)
+// cachedLocation looks up a previously resolved location, recording the hit or miss
+func (s *GeocodingService) cachedLocation(key string) (*GeocodedLocation, bool) {
+	cached, ok := s.cache.get(key)
+	metrics.ObserveGeocodeCacheLookup(ok)
+	if !ok {
+		return nil, false
+	}
+	return &cached, true
+}
 
+// fetch calls Nominatim and decodes its JSON response into out. Timeouts, connection
+// failures, rate limiting and server errors are reported as ErrGeocodingUnavailable.
+func (s *GeocodingService) fetch(requestURL string, out interface{}) error {
 	// Make the request
+	s.waitForRequestSlot()
 	resp, err := s.HTTPClient.Get(requestURL)
 	if err != nil {
-		return "", fmt.Errorf("failed to make reverse geocoding request: %w", err)
+		return fmt.Errorf("%w: %v", ErrGeocodingUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: geocoding service returned status %d", ErrGeocodingUnavailable, resp.StatusCode)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("reverse geocoding service returned status %d", resp.StatusCode)
+		return fmt.Errorf("geocoding service returned status %d", resp.StatusCode)
 	}
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return fmt.Errorf("%w: failed to read response body: %v", ErrGeocodingUnavailable, err)
 	}
 
 	// Parse JSON response
-	var result struct {
-		DisplayName string `json:"display_name"`
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse geocoding response: %w", err)
 	}
+	return nil
+}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to parse reverse geocoding response: %w", err)
+// parseGeocodingResult converts the coordinates Nominatim returns as strings
+func parseGeocodingResult(result GeocodingResult) (*GeocodedLocation, error) {
+	lat, err := strconv.ParseFloat(result.Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse latitude: %w", err)
+	}
+
+	lng, err := strconv.ParseFloat(result.Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse longitude: %w", err)
 	}
 
-	return result.DisplayName, nil
+	return &GeocodedLocation{FormattedAddress: result.DisplayName, Lat: lat, Lng: lng}, nil
 }
 
 // CalculateDistance calculates the distance between two points using the Haversine formula
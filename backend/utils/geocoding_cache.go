@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// geocodeCacheTimeout bounds how long a cache lookup or write waits on Redis
+const geocodeCacheTimeout = 500 * time.Millisecond
+
+// geocodeCacheKeyPrefix namespaces cached locations in Redis
+const geocodeCacheKeyPrefix = "geocode:"
+
+// geocodeCache stores resolved locations by lookup key: a normalized address for
+// forward lookups or rounded coordinates for reverse ones
+type geocodeCache interface {
+	get(key string) (GeocodedLocation, bool)
+	set(key string, entry GeocodedLocation, ttl time.Duration)
+}
+
+// normalizeAddress reduces an address to the form used as its cache key, so spacing
+// and letter case don't cause separate lookups
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
+
+// redisGeocodeCache shares resolved locations between server instances. Redis errors
+// are logged and treated as misses so geocoding still works when Redis is degraded.
+type redisGeocodeCache struct {
+	client *redis.Client
+}
+
+func (c *redisGeocodeCache) get(key string) (GeocodedLocation, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), geocodeCacheTimeout)
+	defer cancel()
+
+	var entry GeocodedLocation
+	payload, err := c.client.Get(ctx, geocodeCacheKeyPrefix+key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("⚠️  GEOCODING: Cache lookup failed: %v", err)
+		}
+		return entry, false
+	}
+	if err := json.Unmarshal(payload, &entry); err != nil {
+		log.Printf("⚠️  GEOCODING: Ignoring unreadable cache entry: %v", err)
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *redisGeocodeCache) set(key string, entry GeocodedLocation, ttl time.Duration) {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), geocodeCacheTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, geocodeCacheKeyPrefix+key, payload, ttl).Err(); err != nil {
+		log.Printf("⚠️  GEOCODING: Failed to cache result: %v", err)
+	}
+}
+
+// lruGeocodeCache keeps up to capacity resolved locations in memory, evicting the least
+// recently used once full
+type lruGeocodeCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// lruGeocodeItem is an element of lruGeocodeCache.order
+type lruGeocodeItem struct {
+	key       string
+	entry     GeocodedLocation
+	expiresAt time.Time
+}
+
+func newLRUGeocodeCache(capacity int) *lruGeocodeCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &lruGeocodeCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruGeocodeCache) get(key string) (GeocodedLocation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return GeocodedLocation{}, false
+	}
+	item := element.Value.(*lruGeocodeItem)
+	if time.Now().After(item.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+		return GeocodedLocation{}, false
+	}
+	c.order.MoveToFront(element)
+	return item.entry, true
+}
+
+func (c *lruGeocodeCache) set(key string, entry GeocodedLocation, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*lruGeocodeItem)
+		item.entry = entry
+		item.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruGeocodeItem{key: key, entry: entry, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruGeocodeItem).key)
+	}
+}
@@ -57,7 +57,7 @@ export default function SkillChipInput({
   const fetchAvailableSkills = async () => {
     try {
       setIsLoading(true)
-      const response = await api.get('/skills/taxonomy')
+      const response = await api.get('/skills/taxonomy?flat=true')
       setAvailableSkills(response.data.skills || [])
     } catch (error) {
       showToast('Failed to load skills', 'error')
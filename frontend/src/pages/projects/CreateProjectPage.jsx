@@ -48,7 +48,7 @@ export default function CreateProjectPage() {
 
   const fetchSkills = async () => {
     try {
-      const response = await api.get('/skills/taxonomy')
+      const response = await api.get('/skills/taxonomy?flat=true')
       console.log('Skills response:', response.data)
       console.log('First skill:', response.data.skills?.[0])
       setAvailableSkills(response.data.skills || [])
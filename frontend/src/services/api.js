@@ -193,6 +193,10 @@ export const markTaskDone = (taskId, completionNote) => {
   return api.post(`/tasks/${taskId}/mark-done`, { completion_note: completionNote })
 }
 
+export const reopenTask = (taskId) => {
+  return api.post(`/tasks/${taskId}/reopen`)
+}
+
 // Task Assignment API
 export const selfAssignTask = (taskId) => {
   return api.post(`/tasks/${taskId}/assign`)